@@ -19,6 +19,7 @@ import (
 
 	"github.com/google/kf/v2/pkg/reconciler/apiservercerts"
 	"github.com/google/kf/v2/pkg/reconciler/app"
+	"github.com/google/kf/v2/pkg/reconciler/appnetworkpolicy"
 	"github.com/google/kf/v2/pkg/reconciler/build"
 	"github.com/google/kf/v2/pkg/reconciler/clusterservicebroker"
 	"github.com/google/kf/v2/pkg/reconciler/featureflag"
@@ -46,6 +47,7 @@ func main() {
 		build.NewController,
 		route.NewController,
 		app.NewController,
+		appnetworkpolicy.NewController,
 		serviceinstance.NewController,
 		serviceinstancebinding.NewController,
 		servicebroker.NewController,
@@ -0,0 +1,64 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/google/kf/v2/pkg/servicesbroker"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func main() {
+	username := os.Getenv("BROKER_USERNAME")
+	password := os.Getenv("BROKER_PASSWORD")
+	if username == "" || password == "" {
+		log.Fatal("BROKER_USERNAME and BROKER_PASSWORD must be set")
+	}
+
+	namespace := os.Getenv("INSTANCE_NAMESPACE")
+	if namespace == "" {
+		log.Fatal("INSTANCE_NAMESPACE must be set")
+	}
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	executor := servicesbroker.NewExecutor(config, kubeClient)
+	broker := servicesbroker.NewBroker(kubeClient, executor, namespace, username, password, servicesbroker.DefaultCatalog())
+
+	log.Printf("provisioning instances in namespace %q", namespace)
+	log.Fatal(http.ListenAndServe(hostPort(), broker.Handler()))
+}
+
+func hostPort() string {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	return fmt.Sprintf(":%s", port)
+}
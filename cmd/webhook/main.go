@@ -21,9 +21,13 @@ import (
 	apiconfig "github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	appinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/app"
+	clusterservicebrokerinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/clusterservicebroker"
+	orginformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/org"
+	routeinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/route"
 	serviceinstanceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstance"
 	serviceinstancebindinginformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstancebinding"
 	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
+	spacequotainformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/spacequota"
 	buildconfig "github.com/google/kf/v2/pkg/reconciler/build/config"
 	v1 "k8s.io/api/admission/v1"
 	autoscaling "k8s.io/api/autoscaling/v1"
@@ -45,6 +49,7 @@ import (
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	v1alpha1.SchemeGroupVersion.WithKind("Space"):                  &v1alpha1.Space{},
 	v1alpha1.SchemeGroupVersion.WithKind("App"):                    &v1alpha1.App{},
+	v1alpha1.SchemeGroupVersion.WithKind("AppNetworkPolicy"):       &v1alpha1.AppNetworkPolicy{},
 	v1alpha1.SchemeGroupVersion.WithKind("Build"):                  &v1alpha1.Build{},
 	v1alpha1.SchemeGroupVersion.WithKind("Route"):                  &v1alpha1.Route{},
 	v1alpha1.SchemeGroupVersion.WithKind("ClusterServiceBroker"):   &v1alpha1.ClusterServiceBroker{},
@@ -55,12 +60,15 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	v1alpha1.SchemeGroupVersion.WithKind("Task"):                   &v1alpha1.Task{},
 	v1alpha1.SchemeGroupVersion.WithKind("TaskSchedule"):           &v1alpha1.TaskSchedule{},
 	v1alpha1.SchemeGroupVersion.WithKind("SourcePackage"):          &v1alpha1.SourcePackage{},
+	v1alpha1.SchemeGroupVersion.WithKind("SpaceQuota"):             &v1alpha1.SpaceQuota{},
+	v1alpha1.SchemeGroupVersion.WithKind("Org"):                    &v1alpha1.Org{},
+	v1alpha1.SchemeGroupVersion.WithKind("SecurityGroup"):          &v1alpha1.SecurityGroup{},
 }
 
 var callbacks = map[schema.GroupVersionKind]validation.Callback{
 	v1alpha1.SchemeGroupVersion.WithKind("ClusterServiceBroker"):   validation.NewCallback(kfvalidation.ClusterServiceBrokerValidationCallback, v1.Delete),
 	v1alpha1.SchemeGroupVersion.WithKind("ServiceBroker"):          validation.NewCallback(kfvalidation.ServiceBrokerValidationCallback, v1.Delete),
-	v1alpha1.SchemeGroupVersion.WithKind("ServiceInstance"):        validation.NewCallback(kfvalidation.ServiceInstanceValidationCallback, v1.Delete),
+	v1alpha1.SchemeGroupVersion.WithKind("ServiceInstance"):        validation.NewCallback(kfvalidation.ServiceInstanceValidationCallback, v1.Create, v1.Update, v1.Delete),
 	v1alpha1.SchemeGroupVersion.WithKind("ServiceInstanceBinding"): validation.NewCallback(kfvalidation.ServiceInstanceBindingValidationCallback, v1.Create, v1.Update),
 	v1alpha1.SchemeGroupVersion.WithKind("App"):                    validation.NewCallback(kfvalidation.AppValidationCallback, v1.Create, v1.Update),
 	v1alpha1.SchemeGroupVersion.WithKind("Route"):                  validation.NewCallback(kfvalidation.RouteValidationCallback, v1.Create),
@@ -104,6 +112,10 @@ func newValidationAdmissionController(controllerCtx context.Context, cmw configm
 	spaceInformer := spaceinformer.Get(controllerCtx)
 	appInformer := appinformer.Get(controllerCtx)
 	serviceInstanceInformer := serviceinstanceinformer.Get(controllerCtx)
+	routeInformer := routeinformer.Get(controllerCtx)
+	spaceQuotaInformer := spacequotainformer.Get(controllerCtx)
+	orgInformer := orginformer.Get(controllerCtx)
+	clusterServiceBrokerInformer := clusterservicebrokerinformer.Get(controllerCtx)
 	return validation.NewAdmissionController(controllerCtx,
 
 		// Name of the resource webhook.
@@ -123,6 +135,10 @@ func newValidationAdmissionController(controllerCtx context.Context, cmw configm
 			ctx = context.WithValue(ctx, kfvalidation.SpaceInformerKey{}, spaceInformer)
 			ctx = context.WithValue(ctx, kfvalidation.AppInformerKey{}, appInformer)
 			ctx = context.WithValue(ctx, kfvalidation.ServiceInstanceInformerKey{}, serviceInstanceInformer)
+			ctx = context.WithValue(ctx, kfvalidation.RouteInformerKey{}, routeInformer)
+			ctx = context.WithValue(ctx, kfvalidation.SpaceQuotaInformerKey{}, spaceQuotaInformer)
+			ctx = context.WithValue(ctx, kfvalidation.OrgInformerKey{}, orgInformer)
+			ctx = context.WithValue(ctx, kfvalidation.ClusterServiceBrokerInformerKey{}, clusterServiceBrokerInformer)
 			return store.ToContext(ctx)
 		},
 
@@ -0,0 +1,53 @@
+#!/usr/bin/env bash
+
+# Copyright 2022 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the License);
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     https://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an AS IS BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# This script installs the optional Kf services broker and registers it as a
+# ClusterServiceBroker. Here are the following inputs (all envs).
+# KO_DOCKER_REPO - required, use kind.local to load the image into kind.
+# BROKER_NAME - name of the broker in Kf, defaults to kf-services.
+
+set -eu
+
+cd "${0%/*}"/..
+
+: "${KO_DOCKER_REPO:?must be set, use kind.local for kind clusters}"
+export KO_DOCKER_REPO
+BROKER_NAME="${BROKER_NAME:-kf-services}"
+NAMESPACE=kf-services
+AUTH_SECRET=kf-services-broker-auth
+
+kubectl apply -f config/services-broker/100-namespace.yaml
+
+# Reuse existing credentials so re-running the script doesn't lock Kf out of
+# the broker.
+if ! kubectl get secret "${AUTH_SECRET}" -n "${NAMESPACE}" >/dev/null 2>&1; then
+  kubectl create secret generic "${AUTH_SECRET}" -n "${NAMESPACE}" \
+    --from-literal=username=kf \
+    --from-literal=password="$(openssl rand -hex 16)"
+fi
+
+ko apply --filename config/services-broker
+kubectl rollout status deployment/kf-services-broker -n "${NAMESPACE}"
+
+username="$(kubectl get secret "${AUTH_SECRET}" -n "${NAMESPACE}" -o jsonpath='{.data.username}' | base64 --decode)"
+password="$(kubectl get secret "${AUTH_SECRET}" -n "${NAMESPACE}" -o jsonpath='{.data.password}' | base64 --decode)"
+url="http://kf-services-broker.${NAMESPACE}.svc.cluster.local"
+
+if kubectl get clusterservicebrokers "${BROKER_NAME}" >/dev/null 2>&1; then
+  echo "Broker ${BROKER_NAME} is already registered"
+else
+  kf create-service-broker "${BROKER_NAME}" "${username}" "${password}" "${url}"
+fi
@@ -23,6 +23,7 @@ import (
 	kfinformer "github.com/google/kf/v2/pkg/client/kf/informers/externalversions/kf/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
@@ -62,6 +63,23 @@ func AppValidationCallback(ctx context.Context, unstructured *unstructured.Unstr
 		return err
 	}
 
+	quota, err := spaceQuota(ctx, space)
+	if err != nil {
+		return err
+	}
+
+	if !quota.IsEmpty() {
+		appInformer := ctx.Value(AppInformerKey{}).(kfinformer.AppInformer)
+		existingApps, err := appInformer.Lister().Apps(app.Namespace).List(labels.Everything())
+		if err != nil {
+			return err
+		}
+
+		if err := validateAppQuota(space, quota, app, existingApps); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,176 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfvalidation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfinformer "github.com/google/kf/v2/pkg/client/kf/informers/externalversions/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// quotaMessage holds the Cloud Foundry style message returned when a request
+// would exceed a Space's quota and the unit the limit is displayed in.
+type quotaMessage struct {
+	message string
+	unit    string
+}
+
+var quotaMessages = map[corev1.ResourceName]quotaMessage{
+	v1alpha1.QuotaResourceApps:             {"You have exceeded the total apps for your space's quota.", "Apps"},
+	v1alpha1.QuotaResourceInstances:        {"You have exceeded the instance limit for your space's quota.", "instances"},
+	v1alpha1.QuotaResourceMemory:           {"You have exceeded your space's memory limit.", "memory"},
+	v1alpha1.QuotaResourceCPU:              {"You have exceeded your space's CPU limit.", "CPU"},
+	v1alpha1.QuotaResourceRoutes:           {"You have exceeded the total routes for your space's quota.", "Routes"},
+	v1alpha1.QuotaResourceServiceInstances: {"You have exceeded your space's services limit.", "service instances"},
+}
+
+// appQuotaResources is the order App quota resources are checked in.
+var appQuotaResources = []corev1.ResourceName{
+	v1alpha1.QuotaResourceApps,
+	v1alpha1.QuotaResourceInstances,
+	v1alpha1.QuotaResourceMemory,
+	v1alpha1.QuotaResourceCPU,
+}
+
+// spaceQuota returns the limits that apply to the Space, combining its own
+// quota with the SpaceQuota it or its Org references. A missing Org or
+// SpaceQuota is ignored so deleting one doesn't block every change in the
+// Space.
+func spaceQuota(ctx context.Context, space *v1alpha1.Space) (v1alpha1.SpaceSpecQuota, error) {
+	var org *v1alpha1.Org
+	if space.Spec.OrgRef != nil {
+		orgInformer := ctx.Value(OrgInformerKey{}).(kfinformer.OrgInformer)
+		var err error
+		org, err = orgInformer.Lister().Get(space.Spec.OrgRef.Name)
+		switch {
+		case apierrs.IsNotFound(err):
+			org = nil
+		case err != nil:
+			return v1alpha1.SpaceSpecQuota{}, err
+		}
+	}
+
+	quotaRef := space.EffectiveQuotaRef(org)
+	if quotaRef == nil {
+		return space.EffectiveQuota(nil), nil
+	}
+
+	spaceQuotaInformer := ctx.Value(SpaceQuotaInformerKey{}).(kfinformer.SpaceQuotaInformer)
+	plan, err := spaceQuotaInformer.Lister().Get(quotaRef.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		return space.EffectiveQuota(nil), nil
+	case err != nil:
+		return v1alpha1.SpaceSpecQuota{}, err
+	default:
+		return space.EffectiveQuota(plan), nil
+	}
+}
+
+func quotaExceededError(space *v1alpha1.Space, name corev1.ResourceName, limit, requested resource.Quantity) error {
+	msg := quotaMessages[name]
+	return fmt.Errorf(
+		"%s Space %q allows %s %s, the request needs %s.",
+		msg.message,
+		space.Name,
+		limit.String(),
+		msg.unit,
+		requested.String(),
+	)
+}
+
+// validateAppQuota validates that the App fits in the quota given the other
+// Apps in the Space. Changes that don't increase the usage of a
+// resource are always allowed so Apps in a Space that's over its quota can
+// still be scaled down.
+func validateAppQuota(space *v1alpha1.Space, quota v1alpha1.SpaceSpecQuota, app *v1alpha1.App, existingApps []*v1alpha1.App) error {
+	if quota.InstanceMemory != nil {
+		memory := app.InstanceMemory()
+		if memory.Cmp(*quota.InstanceMemory) > 0 && !instanceMemoryAllowed(app.Name, memory, existingApps) {
+			return fmt.Errorf(
+				"You have exceeded the instance memory limit for your space's quota. Space %q allows %s per instance, the App requests %s.",
+				space.Name,
+				quota.InstanceMemory.String(),
+				memory.String(),
+			)
+		}
+	}
+
+	hard := quota.Hard()
+	if len(hard) == 0 {
+		return nil
+	}
+
+	before := corev1.ResourceList{}
+	after := corev1.ResourceList{}
+	for _, existing := range existingApps {
+		v1alpha1.AddQuotaUsage(before, v1alpha1.AppQuotaUsage(existing))
+		if existing.Name != app.Name {
+			v1alpha1.AddQuotaUsage(after, v1alpha1.AppQuotaUsage(existing))
+		}
+	}
+	v1alpha1.AddQuotaUsage(after, v1alpha1.AppQuotaUsage(app))
+
+	for _, name := range appQuotaResources {
+		limit, ok := hard[name]
+		if !ok {
+			continue
+		}
+
+		requested, used := after[name], before[name]
+		if requested.Cmp(limit) > 0 && requested.Cmp(used) > 0 {
+			return quotaExceededError(space, name, limit, requested)
+		}
+	}
+
+	return nil
+}
+
+// instanceMemoryAllowed returns true if the App already exists with at least
+// the given per-instance memory, so lowering the limit doesn't block changes
+// to Apps that were already above it.
+func instanceMemoryAllowed(name string, memory resource.Quantity, existingApps []*v1alpha1.App) bool {
+	for _, existing := range existingApps {
+		if existing.Name != name {
+			continue
+		}
+
+		used := existing.InstanceMemory()
+		return memory.Cmp(used) <= 0
+	}
+
+	return false
+}
+
+// validateCountQuota validates that creating one more of the resource fits
+// in the quota.
+func validateCountQuota(space *v1alpha1.Space, quota v1alpha1.SpaceSpecQuota, name corev1.ResourceName, existing int) error {
+	limit, ok := quota.Hard()[name]
+	if !ok {
+		return nil
+	}
+
+	requested := resource.NewQuantity(int64(existing)+1, resource.DecimalSI)
+	if requested.Cmp(limit) > 0 {
+		return quotaExceededError(space, name, limit, *requested)
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfvalidation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kffake "github.com/google/kf/v2/pkg/client/kf/clientset/versioned/fake"
+	"github.com/google/kf/v2/pkg/client/kf/informers/externalversions"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/ptr"
+)
+
+func quotaTestApp(name string, replicas int32, memory string) *v1alpha1.App {
+	app := &v1alpha1.App{}
+	app.Name = name
+	app.Spec.Instances.Replicas = ptr.Int32(replicas)
+	app.Spec.Template.Spec.Containers = []corev1.Container{{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		},
+	}}
+	return app
+}
+
+func quotaTestSpace(quota v1alpha1.SpaceSpecQuota) *v1alpha1.Space {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+	space.Spec.Quota = quota
+	return space
+}
+
+func quantityPtr(value string) *resource.Quantity {
+	out := resource.MustParse(value)
+	return &out
+}
+
+func TestValidateAppQuota(t *testing.T) {
+	existingApps := []*v1alpha1.App{
+		quotaTestApp("app-a", 2, "1Gi"),
+		quotaTestApp("app-b", 1, "1Gi"),
+	}
+
+	cases := map[string]struct {
+		quota    v1alpha1.SpaceSpecQuota
+		app      *v1alpha1.App
+		existing []*v1alpha1.App
+		want     error
+	}{
+		"no quota": {
+			app:      quotaTestApp("app-c", 100, "100Gi"),
+			existing: existingApps,
+		},
+		"fits": {
+			quota: v1alpha1.SpaceSpecQuota{
+				Memory:    quantityPtr("4Gi"),
+				Instances: ptr.Int64(4),
+				Apps:      ptr.Int64(3),
+			},
+			app:      quotaTestApp("app-c", 1, "1Gi"),
+			existing: existingApps,
+		},
+		"too many apps": {
+			quota:    v1alpha1.SpaceSpecQuota{Apps: ptr.Int64(2)},
+			app:      quotaTestApp("app-c", 1, "1Gi"),
+			existing: existingApps,
+			want:     errors.New(`You have exceeded the total apps for your space's quota. Space "my-space" allows 2 Apps, the request needs 3.`),
+		},
+		"updating an app doesn't count it twice": {
+			quota:    v1alpha1.SpaceSpecQuota{Apps: ptr.Int64(2)},
+			app:      quotaTestApp("app-b", 1, "1Gi"),
+			existing: existingApps,
+		},
+		"too many instances": {
+			quota:    v1alpha1.SpaceSpecQuota{Instances: ptr.Int64(4)},
+			app:      quotaTestApp("app-b", 3, "1Gi"),
+			existing: existingApps,
+			want:     errors.New(`You have exceeded the instance limit for your space's quota. Space "my-space" allows 4 instances, the request needs 5.`),
+		},
+		"too much memory": {
+			quota:    v1alpha1.SpaceSpecQuota{Memory: quantityPtr("4Gi")},
+			app:      quotaTestApp("app-c", 2, "1Gi"),
+			existing: existingApps,
+			want:     errors.New(`You have exceeded your space's memory limit. Space "my-space" allows 4Gi memory, the request needs 5Gi.`),
+		},
+		"scaling down while over quota": {
+			quota:    v1alpha1.SpaceSpecQuota{Memory: quantityPtr("1Gi")},
+			app:      quotaTestApp("app-a", 1, "1Gi"),
+			existing: existingApps,
+		},
+		"instance memory too large": {
+			quota:    v1alpha1.SpaceSpecQuota{InstanceMemory: quantityPtr("512Mi")},
+			app:      quotaTestApp("app-c", 1, "1Gi"),
+			existing: existingApps,
+			want:     errors.New(`You have exceeded the instance memory limit for your space's quota. Space "my-space" allows 512Mi per instance, the App requests 1Gi.`),
+		},
+		"instance memory increased above limit": {
+			quota:    v1alpha1.SpaceSpecQuota{InstanceMemory: quantityPtr("512Mi")},
+			app:      quotaTestApp("app-a", 2, "2Gi"),
+			existing: existingApps,
+			want:     errors.New(`You have exceeded the instance memory limit for your space's quota. Space "my-space" allows 512Mi per instance, the App requests 2Gi.`),
+		},
+		"existing app above instance memory limit": {
+			quota:    v1alpha1.SpaceSpecQuota{InstanceMemory: quantityPtr("512Mi")},
+			app:      quotaTestApp("app-a", 1, "1Gi"),
+			existing: existingApps,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := validateAppQuota(quotaTestSpace(tc.quota), tc.quota, tc.app, tc.existing)
+			testutil.AssertErrorsEqual(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateCountQuota(t *testing.T) {
+	cases := map[string]struct {
+		quota    v1alpha1.SpaceSpecQuota
+		resource corev1.ResourceName
+		existing int
+		want     error
+	}{
+		"no quota": {
+			resource: v1alpha1.QuotaResourceRoutes,
+			existing: 100,
+		},
+		"routes fit": {
+			quota:    v1alpha1.SpaceSpecQuota{Routes: ptr.Int64(10)},
+			resource: v1alpha1.QuotaResourceRoutes,
+			existing: 9,
+		},
+		"too many routes": {
+			quota:    v1alpha1.SpaceSpecQuota{Routes: ptr.Int64(10)},
+			resource: v1alpha1.QuotaResourceRoutes,
+			existing: 10,
+			want:     errors.New(`You have exceeded the total routes for your space's quota. Space "my-space" allows 10 Routes, the request needs 11.`),
+		},
+		"services disallowed": {
+			quota:    v1alpha1.SpaceSpecQuota{ServiceInstances: ptr.Int64(0)},
+			resource: v1alpha1.QuotaResourceServiceInstances,
+			existing: 0,
+			want:     errors.New(`You have exceeded your space's services limit. Space "my-space" allows 0 service instances, the request needs 1.`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := validateCountQuota(quotaTestSpace(tc.quota), tc.quota, tc.resource, tc.existing)
+			testutil.AssertErrorsEqual(t, tc.want, got)
+		})
+	}
+}
+
+func TestSpaceQuota(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	plan := &v1alpha1.SpaceQuota{}
+	plan.Name = "small"
+	plan.Spec.Memory = quantityPtr("2Gi")
+	plan.Spec.Apps = ptr.Int64(5)
+
+	org := &v1alpha1.Org{}
+	org.Name = "my-org"
+	org.Spec.QuotaRef = &corev1.LocalObjectReference{Name: "small"}
+
+	// Create fake SpaceQuota and Org informers.
+	kfClient := kffake.NewSimpleClientset(plan, org)
+	informers := externalversions.NewSharedInformerFactory(kfClient, 0)
+	spaceQuotaInformer := informers.Kf().V1alpha1().SpaceQuotas()
+	orgInformer := informers.Kf().V1alpha1().Orgs()
+	quotaSynced := spaceQuotaInformer.Informer().HasSynced
+	orgSynced := orgInformer.Informer().HasSynced
+
+	informers.Start(ctx.Done())
+	cache.WaitForCacheSync(ctx.Done(), quotaSynced, orgSynced)
+
+	ctx = context.WithValue(ctx, SpaceQuotaInformerKey{}, spaceQuotaInformer)
+	ctx = context.WithValue(ctx, OrgInformerKey{}, orgInformer)
+
+	cases := map[string]struct {
+		quota    v1alpha1.SpaceSpecQuota
+		quotaRef string
+		orgRef   string
+		want     v1alpha1.SpaceSpecQuota
+	}{
+		"no plan": {
+			quota: v1alpha1.SpaceSpecQuota{Apps: ptr.Int64(3)},
+			want:  v1alpha1.SpaceSpecQuota{Apps: ptr.Int64(3)},
+		},
+		"plan only": {
+			quotaRef: "small",
+			want: v1alpha1.SpaceSpecQuota{
+				Memory: quantityPtr("2Gi"),
+				Apps:   ptr.Int64(5),
+			},
+		},
+		"lower limit wins": {
+			quota:    v1alpha1.SpaceSpecQuota{Apps: ptr.Int64(3), Routes: ptr.Int64(1)},
+			quotaRef: "small",
+			want: v1alpha1.SpaceSpecQuota{
+				Memory: quantityPtr("2Gi"),
+				Apps:   ptr.Int64(3),
+				Routes: ptr.Int64(1),
+			},
+		},
+		"missing plan is ignored": {
+			quota:    v1alpha1.SpaceSpecQuota{Apps: ptr.Int64(3)},
+			quotaRef: "missing",
+			want:     v1alpha1.SpaceSpecQuota{Apps: ptr.Int64(3)},
+		},
+		"plan from Org": {
+			orgRef: "my-org",
+			want: v1alpha1.SpaceSpecQuota{
+				Memory: quantityPtr("2Gi"),
+				Apps:   ptr.Int64(5),
+			},
+		},
+		"missing Org is ignored": {
+			quota:  v1alpha1.SpaceSpecQuota{Apps: ptr.Int64(3)},
+			orgRef: "missing",
+			want:   v1alpha1.SpaceSpecQuota{Apps: ptr.Int64(3)},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			space := quotaTestSpace(tc.quota)
+			if tc.quotaRef != "" {
+				space.Spec.QuotaRef = &corev1.LocalObjectReference{Name: tc.quotaRef}
+			}
+			if tc.orgRef != "" {
+				space.Spec.OrgRef = &corev1.LocalObjectReference{Name: tc.orgRef}
+			}
+
+			got, err := spaceQuota(ctx, space)
+			testutil.AssertNil(t, "err", err)
+			testutil.AssertEqual(t, "hard", tc.want.Hard(), got.Hard())
+			testutil.AssertEqual(t, "instanceMemory", tc.want.InstanceMemory, got.InstanceMemory)
+		})
+	}
+}
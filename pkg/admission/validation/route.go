@@ -22,6 +22,7 @@ import (
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kfinformer "github.com/google/kf/v2/pkg/client/kf/informers/externalversions/kf/v1alpha1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
@@ -56,6 +57,40 @@ func RouteValidationCallback(ctx context.Context, unstructured *unstructured.Uns
 		return err
 	}
 
+	if route.Spec.IsTCP() {
+		routeInformer := ctx.Value(RouteInformerKey{}).(kfinformer.RouteInformer)
+		clusterRoutes, err := routeInformer.Lister().List(labels.Everything())
+		if err != nil {
+			return err
+		}
+
+		spaces, err := spaceLister.List(labels.Everything())
+		if err != nil {
+			return err
+		}
+
+		if err := validateRoutePortAvailable(route, clusterRoutes, spaces); err != nil {
+			return err
+		}
+	}
+
+	quota, err := spaceQuota(ctx, space)
+	if err != nil {
+		return err
+	}
+
+	if !quota.IsEmpty() {
+		routeInformer := ctx.Value(RouteInformerKey{}).(kfinformer.RouteInformer)
+		existingRoutes, err := routeInformer.Lister().Routes(route.Namespace).List(labels.Everything())
+		if err != nil {
+			return err
+		}
+
+		if err := validateCountQuota(space, quota, v1alpha1.QuotaResourceRoutes, len(existingRoutes)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -82,5 +117,82 @@ func validateRouteDomain(space *v1alpha1.Space, route *v1alpha1.Route) error {
 		)
 	}
 
+	for _, domain := range space.Status.NetworkConfig.Domains {
+		if domain.Domain == route.Spec.Domain {
+			return validateRouteProtocol(&domain, route)
+		}
+	}
+
+	return nil
+}
+
+// validateRouteProtocol validates that TCP Routes are only created on TCP
+// domains, within the domain's reservable ports, and that HTTP Routes aren't
+// created on TCP domains.
+func validateRouteProtocol(domain *v1alpha1.SpaceDomain, route *v1alpha1.Route) error {
+	if !domain.IsTCP() {
+		if route.Spec.IsTCP() {
+			return fmt.Errorf("Route has a port but domain %q doesn't use the tcp protocol", domain.Domain)
+		}
+
+		return nil
+	}
+
+	if !route.Spec.IsTCP() {
+		return fmt.Errorf("Route must have a port because domain %q uses the tcp protocol", domain.Domain)
+	}
+
+	portRanges, err := v1alpha1.ParsePortRanges(domain.ReservablePorts)
+	if err != nil {
+		return err
+	}
+
+	if !v1alpha1.PortRangesContain(portRanges, route.Spec.Port) {
+		return fmt.Errorf(
+			"Route port %d isn't reservable on domain %q, allowed ports are: %s",
+			route.Spec.Port,
+			domain.Domain,
+			domain.ReservablePorts,
+		)
+	}
+
+	return nil
+}
+
+// validateRoutePortAvailable validates that no other Route in the cluster has
+// reserved the same port on the same gateway. Ports are reserved cluster wide
+// because TCP traffic can only be told apart by port on a shared gateway.
+func validateRoutePortAvailable(route *v1alpha1.Route, clusterRoutes []*v1alpha1.Route, spaces []*v1alpha1.Space) error {
+	// Map Space and domain to the gateway the domain is served on.
+	gateways := make(map[string]string)
+	for _, space := range spaces {
+		for _, domain := range space.Status.NetworkConfig.Domains {
+			gateways[space.Name+"/"+domain.Domain] = domain.GatewayName
+		}
+	}
+
+	gateway := gateways[route.Namespace+"/"+route.Spec.Domain]
+
+	for _, existing := range clusterRoutes {
+		if existing.Namespace == route.Namespace && existing.Name == route.Name {
+			continue
+		}
+
+		if existing.Spec.Port != route.Spec.Port {
+			continue
+		}
+
+		existingGateway := gateways[existing.Namespace+"/"+existing.Spec.Domain]
+		if existing.Spec.Domain == route.Spec.Domain || (gateway != "" && gateway == existingGateway) {
+			return fmt.Errorf(
+				"Route port %d on domain %q is already reserved by Route %q in Space %q",
+				route.Spec.Port,
+				route.Spec.Domain,
+				existing.Name,
+				existing.Namespace,
+			)
+		}
+	}
+
 	return nil
 }
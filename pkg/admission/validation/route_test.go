@@ -31,6 +31,7 @@ func TestValidateRouteDomain(t *testing.T) {
 	exampleSpace.Status.NetworkConfig.Domains = []v1alpha1.SpaceDomain{
 		{Domain: "example.com"},
 		{Domain: "some-other-domain.com"},
+		{Domain: "tcp.example.com", Protocol: v1alpha1.SpaceDomainProtocolTCP, ReservablePorts: "1024-1033"},
 	}
 
 	exampleRoute := v1alpha1.Route{}
@@ -39,6 +40,21 @@ func TestValidateRouteDomain(t *testing.T) {
 	badDomainRoute := v1alpha1.Route{}
 	badDomainRoute.Spec.Domain = "bad.com"
 
+	tcpRoute := v1alpha1.Route{}
+	tcpRoute.Spec.Domain = "tcp.example.com"
+	tcpRoute.Spec.Port = 1024
+
+	tcpOutOfRangeRoute := v1alpha1.Route{}
+	tcpOutOfRangeRoute.Spec.Domain = "tcp.example.com"
+	tcpOutOfRangeRoute.Spec.Port = 5432
+
+	httpOnTCPRoute := v1alpha1.Route{}
+	httpOnTCPRoute.Spec.Domain = "tcp.example.com"
+
+	tcpOnHTTPRoute := v1alpha1.Route{}
+	tcpOnHTTPRoute.Spec.Domain = "example.com"
+	tcpOnHTTPRoute.Spec.Port = 1024
+
 	cases := map[string]struct {
 		space v1alpha1.Space
 		route v1alpha1.Route
@@ -56,7 +72,26 @@ func TestValidateRouteDomain(t *testing.T) {
 		"mismatch": {
 			space: exampleSpace,
 			route: badDomainRoute,
-			want:  errors.New(`Route has invalid domain: "bad.com", Space "example" only allows domain(s): [example.com, some-other-domain.com]`),
+			want:  errors.New(`Route has invalid domain: "bad.com", Space "example" only allows domain(s): [example.com, some-other-domain.com, tcp.example.com]`),
+		},
+		"tcp route in range": {
+			space: exampleSpace,
+			route: tcpRoute,
+		},
+		"tcp route out of range": {
+			space: exampleSpace,
+			route: tcpOutOfRangeRoute,
+			want:  errors.New(`Route port 5432 isn't reservable on domain "tcp.example.com", allowed ports are: 1024-1033`),
+		},
+		"http route on tcp domain": {
+			space: exampleSpace,
+			route: httpOnTCPRoute,
+			want:  errors.New(`Route must have a port because domain "tcp.example.com" uses the tcp protocol`),
+		},
+		"tcp route on http domain": {
+			space: exampleSpace,
+			route: tcpOnHTTPRoute,
+			want:  errors.New(`Route has a port but domain "example.com" doesn't use the tcp protocol`),
 		},
 	}
 
@@ -67,3 +102,81 @@ func TestValidateRouteDomain(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRoutePortAvailable(t *testing.T) {
+	makeSpace := func(name string, domains ...v1alpha1.SpaceDomain) *v1alpha1.Space {
+		space := &v1alpha1.Space{}
+		space.Name = name
+		space.Status.NetworkConfig.Domains = domains
+		return space
+	}
+
+	makeRoute := func(namespace, name, domain string, port int32) *v1alpha1.Route {
+		route := &v1alpha1.Route{}
+		route.Namespace = namespace
+		route.Name = name
+		route.Spec.Domain = domain
+		route.Spec.Port = port
+		return route
+	}
+
+	spaces := []*v1alpha1.Space{
+		makeSpace("space-a",
+			v1alpha1.SpaceDomain{Domain: "tcp.example.com", GatewayName: "kf/tcp-gateway"},
+			v1alpha1.SpaceDomain{Domain: "other-tcp.example.com", GatewayName: "kf/other-gateway"},
+		),
+		makeSpace("space-b",
+			v1alpha1.SpaceDomain{Domain: "tcp.example.com", GatewayName: "kf/tcp-gateway"},
+			v1alpha1.SpaceDomain{Domain: "b.tcp.example.com", GatewayName: "kf/tcp-gateway"},
+		),
+	}
+
+	cases := map[string]struct {
+		route         *v1alpha1.Route
+		clusterRoutes []*v1alpha1.Route
+		want          error
+	}{
+		"no routes": {
+			route: makeRoute("space-a", "new", "tcp.example.com", 1024),
+		},
+		"ignores self": {
+			route: makeRoute("space-a", "new", "tcp.example.com", 1024),
+			clusterRoutes: []*v1alpha1.Route{
+				makeRoute("space-a", "new", "tcp.example.com", 1024),
+			},
+		},
+		"different port": {
+			route: makeRoute("space-a", "new", "tcp.example.com", 1024),
+			clusterRoutes: []*v1alpha1.Route{
+				makeRoute("space-b", "existing", "tcp.example.com", 1025),
+			},
+		},
+		"same domain and port in another space": {
+			route: makeRoute("space-a", "new", "tcp.example.com", 1024),
+			clusterRoutes: []*v1alpha1.Route{
+				makeRoute("space-b", "existing", "tcp.example.com", 1024),
+			},
+			want: errors.New(`Route port 1024 on domain "tcp.example.com" is already reserved by Route "existing" in Space "space-b"`),
+		},
+		"same gateway and port": {
+			route: makeRoute("space-a", "new", "tcp.example.com", 1024),
+			clusterRoutes: []*v1alpha1.Route{
+				makeRoute("space-b", "existing", "b.tcp.example.com", 1024),
+			},
+			want: errors.New(`Route port 1024 on domain "tcp.example.com" is already reserved by Route "existing" in Space "space-b"`),
+		},
+		"different gateway and same port": {
+			route: makeRoute("space-a", "new", "other-tcp.example.com", 1024),
+			clusterRoutes: []*v1alpha1.Route{
+				makeRoute("space-b", "existing", "b.tcp.example.com", 1024),
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := validateRoutePortAvailable(tc.route, tc.clusterRoutes, spaces)
+			testutil.AssertErrorsEqual(t, tc.want, got)
+		})
+	}
+}
@@ -21,32 +21,258 @@ import (
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kfinformer "github.com/google/kf/v2/pkg/client/kf/informers/externalversions/kf/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 )
 
-// ServiceInstanceValidationCallback validates that an existing ServiceInstance is not part of a binding.
-// It is intended to be used as a callback on a delete request.
+// ServiceInstanceValidationCallback validates that a new ServiceInstance fits
+// in the Space's quota, that its plan is visible in the Space, that the user
+// sharing a ServiceInstance can bind to it in the Spaces it's shared with, and
+// that an existing ServiceInstance is not part of a binding when it's deleted
+// or unshared.
 func ServiceInstanceValidationCallback(ctx context.Context, unstructured *unstructured.Unstructured) error {
 	serviceinstance := &v1alpha1.ServiceInstance{}
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured.Object, serviceinstance); err != nil {
 		return err
 	}
 
+	switch {
+	case apis.IsInCreate(ctx):
+		if err := validateServiceInstanceCreate(ctx, serviceinstance); err != nil {
+			return err
+		}
+		if err := validateServiceInstancePlanVisible(ctx, serviceinstance); err != nil {
+			return err
+		}
+		return validateServiceInstanceSharing(ctx, serviceinstance, nil)
+	case apis.IsInUpdate(ctx):
+		original, ok := apis.GetBaseline(ctx).(*v1alpha1.ServiceInstance)
+		if !ok {
+			return nil
+		}
+		if serviceinstance.IsKfBrokered() && original.IsKfBrokered() &&
+			serviceinstance.Spec.OSB.PlanName != original.Spec.OSB.PlanName {
+			if err := validateServiceInstancePlanVisible(ctx, serviceinstance); err != nil {
+				return err
+			}
+		}
+		return validateServiceInstanceSharing(ctx, serviceinstance, original)
+	case apis.IsInDelete(ctx):
+		return validateServiceInstanceDelete(ctx, serviceinstance)
+	default:
+		return nil
+	}
+}
+
+// validateServiceInstanceCreate validates that a new ServiceInstance fits in
+// the Space's quota.
+func validateServiceInstanceCreate(ctx context.Context, serviceinstance *v1alpha1.ServiceInstance) error {
+	spaceInformer := ctx.Value(SpaceInformerKey{}).(kfinformer.SpaceInformer)
+	space, err := spaceInformer.Lister().Get(serviceinstance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	quota, err := spaceQuota(ctx, space)
+	if err != nil {
+		return err
+	}
+
+	if quota.IsEmpty() {
+		return nil
+	}
+
+	serviceInstanceInformer := ctx.Value(ServiceInstanceInformerKey{}).(kfinformer.ServiceInstanceInformer)
+	existing, err := serviceInstanceInformer.Lister().ServiceInstances(serviceinstance.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	return validateCountQuota(space, quota, v1alpha1.QuotaResourceServiceInstances, len(existing))
+}
+
+// validateServiceInstancePlanVisible validates that the plan of a brokered
+// ServiceInstance is visible in the ServiceInstance's Space.
+func validateServiceInstancePlanVisible(ctx context.Context, serviceinstance *v1alpha1.ServiceInstance) error {
+	// ServiceBrokers are only visible in their own Space so only
+	// ClusterServiceBrokers need to be checked.
+	if !serviceinstance.IsKfBrokered() || serviceinstance.Spec.OSB.Namespaced {
+		return nil
+	}
+
+	osb := serviceinstance.Spec.OSB
+	clusterServiceBrokerInformer := ctx.Value(ClusterServiceBrokerInformerKey{}).(kfinformer.ClusterServiceBrokerInformer)
+	broker, err := clusterServiceBrokerInformer.Lister().Get(osb.BrokerName)
+	switch {
+	case errors.IsNotFound(err):
+		// The reconciler reports missing brokers on the ServiceInstance.
+		return nil
+	case err != nil:
+		return err
+	}
+
+	if !broker.IsPlanVisible(osb.ClassName, osb.PlanName, serviceinstance.Namespace) {
+		return fmt.Errorf("plan %s of service %s from the service-broker %s is not available in Space %q",
+			osb.PlanName, osb.ClassName, osb.BrokerName, serviceinstance.Namespace)
+	}
+
+	return nil
+}
+
+// validateServiceInstanceSharing validates that Spaces newly added to a
+// ServiceInstance's shared Spaces exist, don't have a ServiceInstance with the
+// same name, and that the user can create bindings in them. It also validates
+// that Spaces removed from the shared Spaces don't have bindings to the
+// ServiceInstance.
+func validateServiceInstanceSharing(ctx context.Context, serviceinstance, original *v1alpha1.ServiceInstance) error {
+	previous := sets.NewString()
+	if original != nil {
+		previous.Insert(original.Spec.SharedSpaces...)
+	}
+	current := sets.NewString(serviceinstance.Spec.SharedSpaces...)
+
+	if unshared := previous.Difference(current); unshared.Len() > 0 {
+		if err := validateServiceInstanceUnshare(ctx, serviceinstance, unshared); err != nil {
+			return err
+		}
+	}
+
+	shared := current.Difference(previous)
+	if shared.Len() == 0 {
+		return nil
+	}
+
+	userInfo := apis.GetUserInfo(ctx)
+	if userInfo == nil {
+		return fmt.Errorf("ServiceInstance %q can't be shared, the requesting user is unknown", serviceinstance.Name)
+	}
+
+	spaceLister := ctx.Value(SpaceInformerKey{}).(kfinformer.SpaceInformer).Lister()
+	serviceInstanceLister := ctx.Value(ServiceInstanceInformerKey{}).(kfinformer.ServiceInstanceInformer).Lister()
+	for _, space := range shared.List() {
+		if _, err := spaceLister.Get(space); errors.IsNotFound(err) {
+			return fmt.Errorf("Space %q does not exist. The service cannot be shared with it", space)
+		} else if err != nil {
+			return err
+		}
+
+		if _, err := serviceInstanceLister.ServiceInstances(space).Get(serviceinstance.Name); err == nil {
+			return fmt.Errorf("Space %q already has a ServiceInstance named %q. The service cannot be shared with it", space, serviceinstance.Name)
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+
+		allowed, err := canCreateBindings(ctx, userInfo, space)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return fmt.Errorf("User %q cannot create bindings in Space %q. The service cannot be shared with it", userInfo.Username, space)
+		}
+	}
+
+	return nil
+}
+
+// canCreateBindings checks whether the user is allowed to create
+// ServiceInstanceBindings in the Space.
+func canCreateBindings(ctx context.Context, userInfo *authenticationv1.UserInfo, space string) (bool, error) {
+	extra := make(map[string]authorizationv1.ExtraValue)
+	for k, v := range userInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: space,
+				Verb:      "create",
+				Group:     v1alpha1.SchemeGroupVersion.Group,
+				Resource:  "serviceinstancebindings",
+			},
+			User:   userInfo.Username,
+			Groups: userInfo.Groups,
+			UID:    userInfo.UID,
+			Extra:  extra,
+		},
+	}
+
+	result, err := kubeclient.Get(ctx).
+		AuthorizationV1().
+		SubjectAccessReviews().
+		Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to check access to Space %q: %v", space, err)
+	}
+
+	return result.Status.Allowed, nil
+}
+
+// validateServiceInstanceUnshare validates that the Spaces a ServiceInstance is
+// no longer shared with don't have bindings to it.
+func validateServiceInstanceUnshare(ctx context.Context, serviceinstance *v1alpha1.ServiceInstance, unshared sets.String) error {
+	serviceBindingInformer := ctx.Value(ServiceInstanceBindingInformerKey{}).(kfinformer.ServiceInstanceBindingInformer)
+	serviceInstanceBindingLister := serviceBindingInformer.Lister()
+
+	for _, space := range unshared.List() {
+		bindings, err := serviceInstanceBindingLister.ServiceInstanceBindings(space).List(labels.Everything())
+		if err != nil {
+			return err
+		}
+
+		matchedBindings := sets.NewString()
+		for _, binding := range bindings {
+			if binding.IsBindingFor(serviceinstance) {
+				matchedBindings.Insert(binding.Name)
+			}
+		}
+
+		if matchedBindings.Len() > 0 {
+			return fmt.Errorf("ServiceInstance %q cannot be unshared from Space %q while it has bindings there: %s",
+				serviceinstance.Name, space, strings.Join(matchedBindings.List(), ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateServiceInstanceDelete validates that an existing ServiceInstance is
+// not part of a binding.
+func validateServiceInstanceDelete(ctx context.Context, serviceinstance *v1alpha1.ServiceInstance) error {
 	serviceBindingInformer := ctx.Value(ServiceInstanceBindingInformerKey{}).(kfinformer.ServiceInstanceBindingInformer)
 	serviceInstanceBindingLister := serviceBindingInformer.Lister()
-	bindings, err := serviceInstanceBindingLister.ServiceInstanceBindings(serviceinstance.Namespace).List(labels.Everything())
+
+	// Bindings may be in other Spaces if the instance is shared.
+	bindings, err := serviceInstanceBindingLister.List(labels.Everything())
 	if err != nil {
 		return err
 	}
 
-	// matchedBindings holds the names of the apps the service instance is bound to.
+	// matchedBindings holds the names of the apps the service instance is bound
+	// to, apps in other Spaces are prefixed with their Space.
 	matchedBindings := sets.NewString()
+	// matchedKeys holds the names of the service keys for the service instance.
+	matchedKeys := sets.NewString()
 	for _, binding := range bindings {
-		if binding.Spec.InstanceRef.Name == serviceinstance.Name {
+		if !binding.IsBindingFor(serviceinstance) {
+			continue
+		}
+
+		switch {
+		case binding.IsAppBinding() && binding.IsSharedInstanceBinding():
+			matchedBindings.Insert(binding.Namespace + "/" + binding.Spec.BindingType.App.Name)
+		case binding.IsAppBinding():
 			matchedBindings.Insert(binding.Spec.BindingType.App.Name)
+		case binding.IsServiceKeyBinding():
+			matchedKeys.Insert(binding.Spec.BindingType.ServiceKey.Name)
 		}
 	}
 
@@ -55,5 +281,10 @@ func ServiceInstanceValidationCallback(ctx context.Context, unstructured *unstru
 			serviceinstance.Name, strings.Join(matchedBindings.List(), ", "))
 	}
 
+	if len(matchedKeys) > 0 {
+		return fmt.Errorf("ServiceInstance %q cannot be deleted while it has service keys. Delete the service key(s) first: %s",
+			serviceinstance.Name, strings.Join(matchedKeys.List(), ", "))
+	}
+
 	return nil
 }
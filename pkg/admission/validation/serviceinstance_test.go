@@ -0,0 +1,212 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfvalidation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kffake "github.com/google/kf/v2/pkg/client/kf/clientset/versioned/fake"
+	"github.com/google/kf/v2/pkg/client/kf/informers/externalversions"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+)
+
+func TestValidateServiceInstanceSharing(t *testing.T) {
+	newSpace := func(name string) *v1alpha1.Space {
+		space := &v1alpha1.Space{}
+		space.Name = name
+		return space
+	}
+
+	newInstance := func(namespace string, sharedSpaces ...string) *v1alpha1.ServiceInstance {
+		instance := &v1alpha1.ServiceInstance{}
+		instance.Name = "my-db"
+		instance.Namespace = namespace
+		instance.Spec.UPS = &v1alpha1.UPSInstance{}
+		instance.Spec.SharedSpaces = sharedSpaces
+		return instance
+	}
+
+	binding := &v1alpha1.ServiceInstanceBinding{}
+	binding.Name = "binding-my-app-my-db"
+	binding.Namespace = "bound-space"
+	binding.Spec.App = &v1alpha1.AppRef{Name: "my-app"}
+	binding.Spec.InstanceRef.Name = "my-db"
+	binding.Spec.InstanceSpace = "source-space"
+
+	cases := map[string]struct {
+		instance *v1alpha1.ServiceInstance
+		original *v1alpha1.ServiceInstance
+		allowed  bool
+		want     error
+	}{
+		"not shared": {
+			instance: newInstance("source-space"),
+		},
+		"shared spaces unchanged": {
+			instance: newInstance("source-space", "target-space"),
+			original: newInstance("source-space", "target-space"),
+		},
+		"shared with allowed user": {
+			instance: newInstance("source-space", "target-space"),
+			original: newInstance("source-space"),
+			allowed:  true,
+		},
+		"shared with denied user": {
+			instance: newInstance("source-space", "target-space"),
+			want:     errors.New(`User "some-user" cannot create bindings in Space "target-space". The service cannot be shared with it`),
+		},
+		"space doesn't exist": {
+			instance: newInstance("source-space", "missing-space"),
+			allowed:  true,
+			want:     errors.New(`Space "missing-space" does not exist. The service cannot be shared with it`),
+		},
+		"space has instance with the same name": {
+			instance: newInstance("source-space", "conflict-space"),
+			allowed:  true,
+			want:     errors.New(`Space "conflict-space" already has a ServiceInstance named "my-db". The service cannot be shared with it`),
+		},
+		"unshared without bindings": {
+			instance: newInstance("source-space"),
+			original: newInstance("source-space", "target-space"),
+		},
+		"unshared with bindings": {
+			instance: newInstance("source-space"),
+			original: newInstance("source-space", "bound-space"),
+			want:     errors.New(`ServiceInstance "my-db" cannot be unshared from Space "bound-space" while it has bindings there: binding-my-app-my-db`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			kfClient := kffake.NewSimpleClientset(
+				newSpace("source-space"),
+				newSpace("target-space"),
+				newSpace("bound-space"),
+				newSpace("conflict-space"),
+				newInstance("conflict-space"),
+				binding,
+			)
+			informers := externalversions.NewSharedInformerFactory(kfClient, 0)
+			spaceInformer := informers.Kf().V1alpha1().Spaces()
+			serviceInstanceInformer := informers.Kf().V1alpha1().ServiceInstances()
+			serviceInstanceBindingInformer := informers.Kf().V1alpha1().ServiceInstanceBindings()
+			spaceInformer.Informer()
+			serviceInstanceInformer.Informer()
+			serviceInstanceBindingInformer.Informer()
+			informers.Start(ctx.Done())
+			informers.WaitForCacheSync(ctx.Done())
+
+			kubeClient := k8sfake.NewSimpleClientset()
+			kubeClient.PrependReactor("create", "subjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+				review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+				testutil.AssertEqual(t, "user", "some-user", review.Spec.User)
+				testutil.AssertEqual(t, "resource", "serviceinstancebindings", review.Spec.ResourceAttributes.Resource)
+				review.Status.Allowed = tc.allowed
+				return true, review, nil
+			})
+
+			ctx = context.WithValue(ctx, SpaceInformerKey{}, spaceInformer)
+			ctx = context.WithValue(ctx, ServiceInstanceInformerKey{}, serviceInstanceInformer)
+			ctx = context.WithValue(ctx, ServiceInstanceBindingInformerKey{}, serviceInstanceBindingInformer)
+			ctx = context.WithValue(ctx, kubeclient.Key{}, kubeClient)
+			ctx = apis.WithUserInfo(ctx, &authenticationv1.UserInfo{Username: "some-user"})
+
+			got := validateServiceInstanceSharing(ctx, tc.instance, tc.original)
+			testutil.AssertErrorsEqual(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateServiceInstancePlanVisible(t *testing.T) {
+	newInstance := func(brokerName, planName string, namespaced bool) *v1alpha1.ServiceInstance {
+		instance := &v1alpha1.ServiceInstance{}
+		instance.Name = "my-db"
+		instance.Namespace = "dev-space"
+		instance.Spec.OSB = &v1alpha1.OSBInstance{
+			BrokerName: brokerName,
+			Namespaced: namespaced,
+			ClassName:  "db",
+			PlanName:   planName,
+		}
+		return instance
+	}
+
+	broker := &v1alpha1.ClusterServiceBroker{}
+	broker.Name = "cluster-broker"
+	broker.Spec.PlanVisibilities = []v1alpha1.ServicePlanVisibility{
+		{ServiceName: "db", PlanName: "limited", Spaces: []string{"dev-space"}},
+		{ServiceName: "db", PlanName: "hidden", Spaces: []string{"prod-space"}},
+	}
+
+	upsInstance := &v1alpha1.ServiceInstance{}
+	upsInstance.Spec.UPS = &v1alpha1.UPSInstance{}
+
+	cases := map[string]struct {
+		instance *v1alpha1.ServiceInstance
+		want     error
+	}{
+		"user-provided service": {
+			instance: upsInstance,
+		},
+		"namespaced broker": {
+			instance: newInstance("space-broker", "hidden", true),
+		},
+		"missing broker": {
+			instance: newInstance("missing-broker", "hidden", false),
+		},
+		"public plan": {
+			instance: newInstance("cluster-broker", "public", false),
+		},
+		"plan visible in Space": {
+			instance: newInstance("cluster-broker", "limited", false),
+		},
+		"plan not visible in Space": {
+			instance: newInstance("cluster-broker", "hidden", false),
+			want:     errors.New(`plan hidden of service db from the service-broker cluster-broker is not available in Space "dev-space"`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			kfClient := kffake.NewSimpleClientset(broker)
+			informers := externalversions.NewSharedInformerFactory(kfClient, 0)
+			clusterServiceBrokerInformer := informers.Kf().V1alpha1().ClusterServiceBrokers()
+			clusterServiceBrokerInformer.Informer()
+			informers.Start(ctx.Done())
+			informers.WaitForCacheSync(ctx.Done())
+
+			ctx = context.WithValue(ctx, ClusterServiceBrokerInformerKey{}, clusterServiceBrokerInformer)
+
+			got := validateServiceInstancePlanVisible(ctx, tc.instance)
+			testutil.AssertErrorsEqual(t, tc.want, got)
+		})
+	}
+}
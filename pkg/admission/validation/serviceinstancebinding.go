@@ -64,9 +64,21 @@ func validateAppExists(appLister kflisters.AppLister, serviceinstancebinding *v1
 }
 
 func validateServiceInstanceExists(serviceInstanceLister kflisters.ServiceInstanceLister, serviceinstancebinding *v1alpha1.ServiceInstanceBinding) error {
-	_, err := serviceInstanceLister.ServiceInstances(serviceinstancebinding.Namespace).Get(serviceinstancebinding.Spec.InstanceRef.Name)
-	if errors.IsNotFound(err) {
-		return fmt.Errorf("ServiceInstance %q does not exist. The binding cannot be created", serviceinstancebinding.Spec.InstanceRef.Name)
+	instanceName := serviceinstancebinding.Spec.InstanceRef.Name
+	instance, err := serviceInstanceLister.ServiceInstances(serviceinstancebinding.InstanceNamespace()).Get(instanceName)
+	switch {
+	case errors.IsNotFound(err) && serviceinstancebinding.IsSharedInstanceBinding():
+		return fmt.Errorf("ServiceInstance %q does not exist in Space %q. The binding cannot be created", instanceName, serviceinstancebinding.InstanceNamespace())
+	case errors.IsNotFound(err):
+		return fmt.Errorf("ServiceInstance %q does not exist. The binding cannot be created", instanceName)
+	case err != nil:
+		return err
 	}
-	return err
+
+	if serviceinstancebinding.IsSharedInstanceBinding() && !instance.IsSharedWith(serviceinstancebinding.Namespace) {
+		return fmt.Errorf("ServiceInstance %q in Space %q isn't shared with Space %q. The binding cannot be created",
+			instanceName, serviceinstancebinding.InstanceNamespace(), serviceinstancebinding.Namespace)
+	}
+
+	return nil
 }
@@ -107,7 +107,14 @@ func TestValidateServiceInstanceExists(t *testing.T) {
 
 	// Create a fake ServiceInstance lister. By default, this returns a "not found" error if the
 	// requested ServiceInstance does not exist.
-	kfClient := kffake.NewSimpleClientset(si)
+	sharedSI := &v1alpha1.ServiceInstance{}
+	sharedSI.Name = "shared-service"
+	sharedSI.Namespace = "other-ns"
+	sharedSI.APIVersion = "kf.dev/v1alpha1"
+	sharedSI.Kind = "ServiceInstance"
+	sharedSI.Spec.SharedSpaces = []string{"test-ns"}
+
+	kfClient := kffake.NewSimpleClientset(si, sharedSI)
 	informers := externalversions.NewSharedInformerFactory(kfClient, 0)
 	serviceInstanceInformer := informers.Kf().V1alpha1().ServiceInstances().Informer()
 	serviceInstanceLister := informers.Kf().V1alpha1().ServiceInstances().Lister()
@@ -147,6 +154,14 @@ func TestValidateServiceInstanceExists(t *testing.T) {
 		},
 	}
 
+	sharedBinding := func(space, instanceName, instanceSpace string) *v1alpha1.ServiceInstanceBinding {
+		binding := validBinding.DeepCopy()
+		binding.Namespace = space
+		binding.Spec.InstanceRef.Name = instanceName
+		binding.Spec.InstanceSpace = instanceSpace
+		return binding
+	}
+
 	cases := map[string]struct {
 		serviceinstancebinding *v1alpha1.ServiceInstanceBinding
 		want                   error
@@ -158,6 +173,17 @@ func TestValidateServiceInstanceExists(t *testing.T) {
 			serviceinstancebinding: invalidBinding,
 			want:                   errors.New("ServiceInstance \"missing-service\" does not exist. The binding cannot be created"),
 		},
+		"shared service instance": {
+			serviceinstancebinding: sharedBinding("test-ns", "shared-service", "other-ns"),
+		},
+		"shared service instance doesn't exist": {
+			serviceinstancebinding: sharedBinding("test-ns", "missing-service", "other-ns"),
+			want:                   errors.New("ServiceInstance \"missing-service\" does not exist in Space \"other-ns\". The binding cannot be created"),
+		},
+		"service instance isn't shared": {
+			serviceinstancebinding: sharedBinding("another-ns", "valid-service", "test-ns"),
+			want:                   errors.New("ServiceInstance \"valid-service\" in Space \"test-ns\" isn't shared with Space \"another-ns\". The binding cannot be created"),
+		},
 	}
 
 	for tn, tc := range cases {
@@ -25,3 +25,15 @@ type AppInformerKey struct{}
 
 // ServiceInstanceInformerKey is used for associating the ServiceInstanceInformer inside the context.Context.
 type ServiceInstanceInformerKey struct{}
+
+// RouteInformerKey is used for associating the RouteInformer inside the context.Context.
+type RouteInformerKey struct{}
+
+// SpaceQuotaInformerKey is used for associating the SpaceQuotaInformer inside the context.Context.
+type SpaceQuotaInformerKey struct{}
+
+// OrgInformerKey is used for associating the OrgInformer inside the context.Context.
+type OrgInformerKey struct{}
+
+// ClusterServiceBrokerInformerKey is used for associating the ClusterServiceBrokerInformer inside the context.Context.
+type ClusterServiceBrokerInformerKey struct{}
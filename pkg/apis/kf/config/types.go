@@ -233,6 +233,15 @@ type DomainTemplate struct {
 	// Only the kf Namespace is allowed e.g. kf/some-gateway.
 	// See https://istio.io/docs/reference/config/networking/gateway/
 	GatewayName string `json:"gatewayName,omitempty"`
+	// Protocol is the type of traffic routed on the domain, either http or
+	// tcp. Defaults to http.
+	Protocol string `json:"protocol,omitempty"`
+	// ReservablePorts are the ports Routes on a tcp domain can use e.g.
+	// 1024-1033.
+	ReservablePorts string `json:"reservablePorts,omitempty"`
+	// TLSIssuerName is the cert-manager ClusterIssuer used to issue
+	// certificates for the domain in every Space, or kf-self-signed.
+	TLSIssuerName string `json:"tlsIssuerName,omitempty"`
 }
 
 // FeatureFlagToggles maps a feature name to a bool representing whether the feature is enabled.
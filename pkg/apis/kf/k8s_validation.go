@@ -33,14 +33,6 @@ var (
 	)
 )
 
-func errOnlyOneAllowed(fieldType string) *apis.FieldError {
-	return &apis.FieldError{
-		Message: fmt.Sprintf("More than one %s is set", fieldType),
-		Paths:   []string{apis.CurrentField},
-		Details: fmt.Sprintf("Only a single %s is allowed", fieldType),
-	}
-}
-
 // ErrDuplicateValue creates a FieldError that indicates a value must not be
 // duplicated.
 func ErrDuplicateValue(value interface{}, fieldPaths ...string) *apis.FieldError {
@@ -55,17 +47,36 @@ func ErrDuplicateValue(value interface{}, fieldPaths ...string) *apis.FieldError
 func ValidatePodSpec(podSpec corev1.PodSpec) *apis.FieldError {
 	errs := apis.CheckDisallowedFields(podSpec, podSpecMask(podSpec))
 
-	switch len(podSpec.Containers) {
-	case 0:
-		errs = errs.Also(apis.ErrMissingField("containers"))
-	case 1:
-		errs = errs.Also(ValidateContainer(podSpec.Containers[0]).ViaFieldIndex("containers", 0))
-	default:
-		errs = errs.Also(errOnlyOneAllowed("container").ViaField("containers"))
+	if len(podSpec.Containers) == 0 {
+		return errs.Also(apis.ErrMissingField("containers"))
 	}
+
+	errs = errs.Also(ValidateContainer(podSpec.Containers[0]).ViaFieldIndex("containers", 0))
+
+	// Any additional containers are sidecars.
+	names := sets.NewString()
+	for idx, sidecar := range podSpec.Containers[1:] {
+		errs = errs.Also(ValidateSidecarContainer(sidecar).ViaFieldIndex("containers", idx+1))
+
+		if names.Has(sidecar.Name) {
+			errs = errs.Also(ErrDuplicateValue(sidecar.Name, "name").ViaFieldIndex("containers", idx+1))
+		}
+		names.Insert(sidecar.Name)
+	}
+
 	return errs
 }
 
+// ValidateSidecarContainer performs a deep validation that a container that
+// runs next to the user container matches Kf's expectations.
+func ValidateSidecarContainer(container corev1.Container) (errs *apis.FieldError) {
+	if container.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+
+	return errs.Also(ValidateContainer(container))
+}
+
 // ValidateContainer performs a deep validation that the Container matches Kf's
 // expectations.
 func ValidateContainer(container corev1.Container) *apis.FieldError {
@@ -83,6 +94,7 @@ func ValidateContainer(container corev1.Container) *apis.FieldError {
 	errs = errs.Also(ValidateContainerResources(container.Resources).ViaField("resources"))
 	errs = errs.Also(ValidateContainerProbe(container.LivenessProbe).ViaField("livenessProbe"))
 	errs = errs.Also(ValidateContainerProbe(container.ReadinessProbe).ViaField("readinessProbe"))
+	errs = errs.Also(ValidateContainerProbe(container.StartupProbe).ViaField("startupProbe"))
 
 	return errs
 }
@@ -51,11 +51,34 @@ func TestValidatePodSpec(t *testing.T) {
 			},
 			want: apis.ErrDisallowedFields("containers[0].envFrom"),
 		},
-		"too many containers": {
+		"sidecar containers": {
+			field: corev1.PodSpec{
+				Containers: []corev1.Container{{}, {Name: "log-shipper"}, {Name: "metrics-agent"}},
+			},
+		},
+		"sidecar missing name": {
 			field: corev1.PodSpec{
 				Containers: []corev1.Container{{}, {}},
 			},
-			want: errOnlyOneAllowed("container").ViaField("containers"),
+			want: apis.ErrMissingField("containers[1].name"),
+		},
+		"duplicate sidecar names": {
+			field: corev1.PodSpec{
+				Containers: []corev1.Container{{}, {Name: "agent"}, {Name: "agent"}},
+			},
+			want: ErrDuplicateValue("agent", "containers[2].name"),
+		},
+		"recurses to sidecars": {
+			field: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{},
+					{
+						Name:    "agent",
+						EnvFrom: []corev1.EnvFromSource{{}},
+					},
+				},
+			},
+			want: apis.ErrDisallowedFields("containers[1].envFrom"),
 		},
 	}
 
@@ -83,6 +106,7 @@ func goodContainer() corev1.Container {
 		Resources:      goodResourceRequirements(),
 		LivenessProbe:  goodHTTPContainerProbe(),
 		ReadinessProbe: goodTCPContainerProbe(),
+		StartupProbe:   goodHTTPContainerProbe(),
 	}
 }
 
@@ -155,6 +179,15 @@ func TestValidateContainer(t *testing.T) {
 			}(),
 			want: apis.ErrDisallowedFields("readinessProbe.exec"),
 		},
+		"recurses to startup probe": {
+			field: func() (container corev1.Container) {
+				container = goodContainer()
+				container.StartupProbe = badContainerProbe()
+
+				return
+			}(),
+			want: apis.ErrDisallowedFields("startupProbe.exec"),
+		},
 	}
 
 	for tn, tc := range cases {
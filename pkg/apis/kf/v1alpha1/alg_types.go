@@ -97,7 +97,7 @@ func (d RouteSpecFieldsSlice) Len() int {
 // RouteSpecFields are sorted alphabetically by hostname and domain (though the domain should be the same for all RSFs being compared).
 // RSFs with the "*" host are listed last, since they are the most general.
 // Within RSFs with the same hostname + domain, the ones with longer paths come first, since they are more specific and
-// should be evaluated first in the VS.
+// should be evaluated first in the VS. RSFs with the same path that have match rules come before those without.
 func (d RouteSpecFieldsSlice) Less(i int, j int) bool {
 	// TODO(https://github.com/knative/pkg/issues/542):
 	// We can't garuntee that the path will have the '/' or not
@@ -121,7 +121,27 @@ func (d RouteSpecFieldsSlice) Less(i int, j int) bool {
 		return d[i].Domain < d[j].Domain
 	}
 
-	return len(d[i].Path) > len(d[j].Path)
+	// TCP Routes have no hostname or path so they're ordered by port.
+	if d[i].Port != d[j].Port {
+		return d[i].Port < d[j].Port
+	}
+
+	if len(d[i].Path) != len(d[j].Path) {
+		return len(d[i].Path) > len(d[j].Path)
+	}
+
+	// Routes with more match rules are more specific so they come before
+	// Routes with the same path and fewer rules.
+	iMatches, jMatches := d[i].matchCount(), d[j].matchCount()
+	if iMatches != jMatches {
+		return iMatches > jMatches
+	}
+
+	if iMatches > 0 {
+		return d[i].String() < d[j].String()
+	}
+
+	return false
 }
 
 // Swap implements Interface.
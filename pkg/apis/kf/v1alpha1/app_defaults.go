@@ -51,6 +51,10 @@ const (
 	DefaultHealthCheckFailureThreshold = 3
 )
 
+// DefaultCanarySteps contains the percentages of traffic sent to the new
+// revision of an App during a Canary rollout if none are specified.
+var DefaultCanarySteps = []int32{10, 50}
+
 // SetDefaults implements apis.Defaultable
 func (k *App) SetDefaults(ctx context.Context) {
 	k.Spec.SetDefaults(ctx)
@@ -62,6 +66,7 @@ func (k *AppSpec) SetDefaults(ctx context.Context) {
 	k.Template.SetDefaults(ctx, k)
 	k.Instances.SetDefaults(ctx)
 	k.SetRouteDefaults(ctx)
+	k.Rollout.SetDefaults(ctx)
 }
 
 // SetBuildDefaults implements apis.Defaultable for the embedded BuildSpec.
@@ -150,6 +155,21 @@ func (as *AppSpecAutoscaling) SetAutoscalingDefaults(_ context.Context) {
 	}
 }
 
+// SetDefaults implements apis.Defaultable.
+func (rollout *AppSpecRollout) SetDefaults(_ context.Context) {
+	if rollout == nil {
+		return
+	}
+
+	if rollout.Strategy == "" {
+		rollout.Strategy = RollingRolloutStrategy
+	}
+
+	if rollout.Strategy == CanaryRolloutStrategy && len(rollout.Steps) == 0 {
+		rollout.Steps = append([]int32{}, DefaultCanarySteps...)
+	}
+}
+
 // SetDefaults implements apis.Defaultable.
 func (s *Scale) SetDefaults(ctx context.Context) {
 	// No defaults
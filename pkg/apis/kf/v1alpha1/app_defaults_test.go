@@ -112,6 +112,30 @@ func TestAppSpec_SetDefaults_updateRequests(t *testing.T) {
 				},
 			},
 		},
+		"rollout change doesn't increment": {
+			old: &AppSpec{
+				Template: AppSpecTemplate{
+					UpdateRequests: 2,
+				},
+				Rollout: &AppSpecRollout{
+					Strategy: CanaryRolloutStrategy,
+				},
+			},
+			current: AppSpec{
+				Template: AppSpecTemplate{
+					UpdateRequests: 2,
+				},
+				Rollout: &AppSpecRollout{
+					Strategy:        CanaryRolloutStrategy,
+					PromoteRequests: 1,
+				},
+			},
+			want: AppSpec{
+				Template: AppSpecTemplate{
+					UpdateRequests: 2,
+				},
+			},
+		},
 		"update with no change": {
 			old: &AppSpec{
 				Template: AppSpecTemplate{},
@@ -621,3 +645,50 @@ func TestAppSpecInstances_SetDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestAppSpecRollout_SetDefaults(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		spec *AppSpecRollout
+		want *AppSpecRollout
+	}{
+		"nil": {
+			spec: nil,
+			want: nil,
+		},
+		"defaults strategy": {
+			spec: &AppSpecRollout{},
+			want: &AppSpecRollout{
+				Strategy: RollingRolloutStrategy,
+			},
+		},
+		"defaults canary steps": {
+			spec: &AppSpecRollout{
+				Strategy: CanaryRolloutStrategy,
+			},
+			want: &AppSpecRollout{
+				Strategy: CanaryRolloutStrategy,
+				Steps:    []int32{10, 50},
+			},
+		},
+		"keeps canary steps": {
+			spec: &AppSpecRollout{
+				Strategy: CanaryRolloutStrategy,
+				Steps:    []int32{25},
+			},
+			want: &AppSpecRollout{
+				Strategy: CanaryRolloutStrategy,
+				Steps:    []int32{25},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			tc.spec.SetDefaults(context.Background())
+
+			testutil.AssertEqual(t, "rollout", tc.want, tc.spec)
+		})
+	}
+}
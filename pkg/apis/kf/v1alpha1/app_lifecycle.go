@@ -17,12 +17,14 @@ package v1alpha1
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
-	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
@@ -67,33 +69,125 @@ func (status *AppStatus) PropagateInstanceStatus(is InstanceStatus) {
 }
 
 // PropagateAutoscalingStatus updates the effective instance status with autoscaling status.
-func (status *InstanceStatus) PropagateAutoscalingStatus(app *App, hpa *autoscalingv1.HorizontalPodAutoscaler) {
+func (status *InstanceStatus) PropagateAutoscalingStatus(app *App, hpa *autoscalingv2.HorizontalPodAutoscaler) {
 	// hpa is nil when autoscaling is disabled, or maxreplicas hasn't been set, or no rules specified by user.
 	if hpa == nil {
 		return
 	}
 
-	if hpa.Status.CurrentCPUUtilizationPercentage == nil {
+	var ruleStatuses []AutoscalingRuleStatus
+	for _, rule := range app.Spec.Instances.Autoscaling.Rules {
+		current := currentAutoscalingMetricValue(rule.RuleType, hpa.Status.CurrentMetrics)
+		if current == nil {
+			// hpa hasn't been able to read the metric yet
+			continue
+		}
+
+		ruleStatuses = append(ruleStatuses, AutoscalingRuleStatus{
+			AppAutoscalingRule: rule,
+			Current: AutoscalingRuleMetricValueStatus{
+				AverageValue: current,
+			},
+		})
+	}
+
+	if len(ruleStatuses) == 0 {
 		// hpa is not ready yet
 		return
 	}
 
-	// Set instance status for autoscaling rule
-	status.AutoscalingStatus = []AutoscalingRuleStatus{
-		{
-			// Rules is guaranteed to not be empty here because hpa is not nil.
-			AppAutoscalingRule: app.Spec.Instances.Autoscaling.Rules[0],
-			Current: AutoscalingRuleMetricValueStatus{
-				AverageValue: resource.NewQuantity(int64(*hpa.Status.CurrentCPUUtilizationPercentage), resource.DecimalSI),
-			},
-		},
+	// Set instance status for autoscaling rules
+	status.AutoscalingStatus = ruleStatuses
+}
+
+// currentAutoscalingMetricValue finds the current value of the metric backing
+// the rule type. Utilization metrics are reported as a percentage. Returns nil
+// if the metric isn't reported.
+func currentAutoscalingMetricValue(ruleType AutoscalingRuleType, metrics []autoscalingv2.MetricStatus) *resource.Quantity {
+	for _, metric := range metrics {
+		switch {
+		case metric.Type == autoscalingv2.ResourceMetricSourceType && metric.Resource != nil:
+			utilization := metric.Resource.Current.AverageUtilization
+			if utilization == nil {
+				continue
+			}
+
+			if (ruleType == CPURuleType && metric.Resource.Name == corev1.ResourceCPU) ||
+				(ruleType == MemoryRuleType && metric.Resource.Name == corev1.ResourceMemory) {
+				return resource.NewQuantity(int64(*utilization), resource.DecimalSI)
+			}
+
+		case metric.Type == autoscalingv2.PodsMetricSourceType && metric.Pods != nil:
+			value := metric.Pods.Current.AverageValue
+			if value == nil {
+				continue
+			}
+
+			if (ruleType == HTTPThroughputRuleType && metric.Pods.Metric.Name == HTTPThroughputMetricName) ||
+				(ruleType == HTTPLatencyRuleType && metric.Pods.Metric.Name == HTTPLatencyMetricName) {
+				current := value.DeepCopy()
+				return &current
+			}
+		}
+	}
+
+	return nil
+}
+
+// PropagateAutoscalingScheduleStatus updates the state of the App's
+// autoscaling schedules as of now.
+func (status *AppStatus) PropagateAutoscalingScheduleStatus(autoscaling *AppSpecAutoscaling, now time.Time) {
+	if len(autoscaling.Schedules) == 0 {
+		status.AutoscalingSchedules = nil
+		return
+	}
+
+	active := autoscaling.ActiveSchedule(now)
+
+	var scheduleStatuses []AutoscalingScheduleStatus
+	for i := range autoscaling.Schedules {
+		schedule := &autoscaling.Schedules[i]
+
+		scheduleStatus := AutoscalingScheduleStatus{
+			Name:   schedule.Name,
+			Active: schedule == active,
+		}
+
+		if start, end, ok := schedule.NextWindow(now); ok {
+			if now.Before(start) {
+				nextStart := metav1.NewTime(start.UTC())
+				scheduleStatus.NextStartTime = &nextStart
+			}
+
+			nextEnd := metav1.NewTime(end.UTC())
+			scheduleStatus.NextEndTime = &nextEnd
+		}
+
+		scheduleStatuses = append(scheduleStatuses, scheduleStatus)
+	}
+
+	status.AutoscalingSchedules = scheduleStatuses
+}
+
+// NextAutoscalingScheduleTransition returns the earliest time one of the
+// App's autoscaling schedules starts or ends, or nil if none will.
+func (status *AppStatus) NextAutoscalingScheduleTransition() *time.Time {
+	var next *time.Time
+	for _, scheduleStatus := range status.AutoscalingSchedules {
+		for _, t := range []*metav1.Time{scheduleStatus.NextStartTime, scheduleStatus.NextEndTime} {
+			if t != nil && (next == nil || t.Time.Before(*next)) {
+				transition := t.Time
+				next = &transition
+			}
+		}
 	}
+
+	return next
 }
 
-// PropagateAutoscalerV1Status updates the autoscaler status to reflect the
+// PropagateAutoscalerV2Status updates the autoscaler status to reflect the
 // underlying state of the autoscaler.
-// HorizontalPodAutoscalerCondition is not available for V1.
-func (status *AppStatus) PropagateAutoscalerV1Status(autoscaler *autoscalingv1.HorizontalPodAutoscaler) {
+func (status *AppStatus) PropagateAutoscalerV2Status(autoscaler *autoscalingv2.HorizontalPodAutoscaler) {
 
 	if autoscaler == nil {
 		status.HorizontalPodAutoscalerCondition().MarkSuccess()
@@ -154,6 +248,49 @@ func (status *AppStatus) PropagateDeploymentStatus(deployment *appsv1.Deployment
 	status.DeploymentCondition().MarkSuccess()
 }
 
+// PropagateRolloutStatus updates the status of the App's latest rollout.
+// Rollouts that are still moving traffic, or that were rolled back, are
+// reflected in the deployment condition so clients waiting on the App see
+// the result. Paused rollouts keep serving traffic so the condition stays
+// ready but reports that the rollout is waiting to be promoted.
+func (status *AppStatus) PropagateRolloutStatus(rollout *AppRolloutStatus) {
+	status.Rollout = rollout
+	if rollout == nil {
+		return
+	}
+
+	switch rollout.State {
+	case RolloutStateProgressing, RolloutStatePromoting:
+		status.DeploymentCondition().MarkUnknown("Rollout"+string(rollout.State), "%s", rollout.Message)
+	case RolloutStatePaused:
+		if cond := status.GetCondition(AppConditionDeploymentReady); cond.IsTrue() {
+			status.DeploymentCondition().MarkSuccessWithReason("RolloutPaused", "%s", rollout.Message)
+		}
+	case RolloutStateRolledBack:
+		status.DeploymentCondition().MarkFalse("RolloutFailed", "%s", rollout.Message)
+	}
+}
+
+// PropagateProcessStatus copies the scale of the Deployments backing the App's
+// additional processes into the status. Deployments must have the
+// ProcessTypeLabel set.
+func (status *AppStatus) PropagateProcessStatus(deployments []*appsv1.Deployment) {
+	status.Processes = nil
+
+	for _, deployment := range deployments {
+		var replicas int32
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+
+		status.Processes = append(status.Processes, AppProcessStatus{
+			Type:          deployment.Labels[ProcessTypeLabel],
+			Replicas:      replicas,
+			ReadyReplicas: deployment.Status.ReadyReplicas,
+		})
+	}
+}
+
 // PropagateEnvVarSecretStatus updates the env var secret readiness status.
 func (status *AppStatus) PropagateEnvVarSecretStatus(secret *v1.Secret) {
 	status.EnvVarSecretCondition().MarkSuccess()
@@ -187,9 +324,9 @@ func (status *AppStatus) PropagateRouteStatus(bindings []QualifiedRouteBinding,
 	})
 
 	// Create a mapping for lookup later
-	routeMapping := make(map[RouteSpecFields]Route)
+	routeMapping := make(map[string]Route)
 	for _, route := range routes {
-		routeMapping[route.Spec.RouteSpecFields] = route
+		routeMapping[route.Spec.RouteSpecFields.Key()] = route
 	}
 
 	for idx, binding := range bindings {
@@ -203,7 +340,7 @@ func (status *AppStatus) PropagateRouteStatus(bindings []QualifiedRouteBinding,
 			Status:                RouteBindingStatusUnknown,
 		}
 
-		route, routeFound := routeMapping[binding.Source]
+		route, routeFound := routeMapping[binding.Source.Key()]
 
 		// Reasons all start with Route so when they're propagated all the way
 		// up to the App's status it's easy to determine what went wrong.
@@ -17,12 +17,13 @@ package v1alpha1
 import (
 	"errors"
 	"testing"
+	"time"
 
 	networking "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
 	"github.com/google/kf/v2/pkg/kf/dynamicutils"
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	appsv1 "k8s.io/api/apps/v1"
-	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -243,24 +244,24 @@ func pendingDeployment() *appsv1.Deployment {
 	}
 }
 
-func happyHorizontalPodAutoscaler() *autoscalingv1.HorizontalPodAutoscaler {
-	return &autoscalingv1.HorizontalPodAutoscaler{
+func happyHorizontalPodAutoscaler() *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "some-hpa-name",
 		},
-		Status: autoscalingv1.HorizontalPodAutoscalerStatus{
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
 			CurrentReplicas: 1,
 			DesiredReplicas: 1,
 		},
 	}
 }
 
-func pendingHorizontalPodAutoscaler() *autoscalingv1.HorizontalPodAutoscaler {
-	return &autoscalingv1.HorizontalPodAutoscaler{
+func pendingHorizontalPodAutoscaler() *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "some-hpa-name",
 		},
-		Status: autoscalingv1.HorizontalPodAutoscalerStatus{
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{
 			CurrentReplicas: 1,
 			DesiredReplicas: 2,
 		},
@@ -326,7 +327,7 @@ func TestAppHappyPath(t *testing.T) {
 	apitesting.CheckConditionSucceeded(status.duck(), AppConditionServiceAccountReady, t)
 
 	// Hpa gets reconciled
-	status.PropagateAutoscalerV1Status(pendingHorizontalPodAutoscaler())
+	status.PropagateAutoscalerV2Status(pendingHorizontalPodAutoscaler())
 	apitesting.CheckConditionOngoing(status.duck(), AppConditionHorizontalPodAutoscalerReady, t)
 
 	// Deployment starts out pending
@@ -340,7 +341,7 @@ func TestAppHappyPath(t *testing.T) {
 	apitesting.CheckConditionSucceeded(status.duck(), AppConditionDeploymentReady, t)
 
 	// Autoscaler is ready
-	status.PropagateAutoscalerV1Status(happyHorizontalPodAutoscaler())
+	status.PropagateAutoscalerV2Status(happyHorizontalPodAutoscaler())
 	apitesting.CheckConditionSucceeded(status.duck(), AppConditionHorizontalPodAutoscalerReady, t)
 
 	// Routes and bindings are reeady
@@ -368,7 +369,7 @@ func TestAppStatus_lifecycle(t *testing.T) {
 				status.PropagateServiceInstanceBindingsStatus(nil)
 				status.PropagateServiceAccountStatus(serviceAccount())
 				status.PropagateDeploymentStatus(happyDeployment())
-				status.PropagateAutoscalerV1Status(happyHorizontalPodAutoscaler())
+				status.PropagateAutoscalerV2Status(happyHorizontalPodAutoscaler())
 			},
 			ExpectSucceeded: []apis.ConditionType{
 				AppConditionReady,
@@ -593,14 +594,92 @@ func TestAppStatus_PropagateDeploymentStatus(t *testing.T) {
 	}
 }
 
+func TestAppStatus_PropagateRolloutStatus(t *testing.T) {
+	cases := map[string]struct {
+		deployment    *appsv1.Deployment
+		rollout       *AppRolloutStatus
+		wantCondition apis.Condition
+	}{
+		"no rollout": {
+			deployment: happyDeployment(),
+			wantCondition: apis.Condition{
+				Status: corev1.ConditionTrue,
+			},
+		},
+		"promoting": {
+			deployment: happyDeployment(),
+			rollout: &AppRolloutStatus{
+				State:   RolloutStatePromoting,
+				Message: "Promoting the new revision",
+			},
+			wantCondition: apis.Condition{
+				Status:  corev1.ConditionUnknown,
+				Reason:  "RolloutPromoting",
+				Message: "Promoting the new revision",
+			},
+		},
+		"paused": {
+			deployment: happyDeployment(),
+			rollout: &AppRolloutStatus{
+				State:   RolloutStatePaused,
+				Message: "Sending 10% of traffic to the new revision, promote to continue",
+			},
+			wantCondition: apis.Condition{
+				Status:  corev1.ConditionTrue,
+				Reason:  "RolloutPaused",
+				Message: "Sending 10% of traffic to the new revision, promote to continue",
+			},
+		},
+		"paused with pending deployment": {
+			deployment: pendingDeployment(),
+			rollout: &AppRolloutStatus{
+				State:   RolloutStatePaused,
+				Message: "Sending 10% of traffic to the new revision, promote to continue",
+			},
+			wantCondition: apis.Condition{
+				Status:  corev1.ConditionUnknown,
+				Reason:  "InitializingPods",
+				Message: `waiting for deployment "some-service-name" rollout to finish: 1 of 3 updated replicas are available`,
+			},
+		},
+		"rolled back": {
+			deployment: happyDeployment(),
+			rollout: &AppRolloutStatus{
+				State:   RolloutStateRolledBack,
+				Message: "New revision abc failed to become ready and was rolled back",
+			},
+			wantCondition: apis.Condition{
+				Status:  corev1.ConditionFalse,
+				Reason:  "RolloutFailed",
+				Message: "New revision abc failed to become ready and was rolled back",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := AppStatus{}
+			status.PropagateDeploymentStatus(tc.deployment)
+			status.PropagateRolloutStatus(tc.rollout)
+
+			actualCond := status.GetCondition(AppConditionDeploymentReady)
+
+			testutil.AssertEqual(t, "rollout", tc.rollout, status.Rollout)
+			testutil.AssertEqual(t, "condition status", tc.wantCondition.Status, actualCond.Status)
+			testutil.AssertEqual(t, "condition reason", tc.wantCondition.Reason, actualCond.Reason)
+			testutil.AssertEqual(t, "condition message", tc.wantCondition.Message, actualCond.Message)
+		})
+	}
+}
+
 func TestAppStatus_PropagateAutoscalerStatus(t *testing.T) {
 	cases := map[string]struct {
-		autoscaler    *autoscalingv1.HorizontalPodAutoscaler
+		autoscaler    *autoscalingv2.HorizontalPodAutoscaler
 		wantCondition apis.Condition
 	}{
 		"scaling up": {
-			autoscaler: &autoscalingv1.HorizontalPodAutoscaler{
-				Status: autoscalingv1.HorizontalPodAutoscalerStatus{
+			autoscaler: &autoscalingv2.HorizontalPodAutoscaler{
+				Status: autoscalingv2.HorizontalPodAutoscalerStatus{
 					CurrentReplicas: 1,
 					DesiredReplicas: 2,
 				},
@@ -613,8 +692,8 @@ func TestAppStatus_PropagateAutoscalerStatus(t *testing.T) {
 			},
 		},
 		"scaling down": {
-			autoscaler: &autoscalingv1.HorizontalPodAutoscaler{
-				Status: autoscalingv1.HorizontalPodAutoscalerStatus{
+			autoscaler: &autoscalingv2.HorizontalPodAutoscaler{
+				Status: autoscalingv2.HorizontalPodAutoscalerStatus{
 					CurrentReplicas: 2,
 					DesiredReplicas: 1,
 				},
@@ -631,7 +710,7 @@ func TestAppStatus_PropagateAutoscalerStatus(t *testing.T) {
 	for tn, tc := range cases {
 		t.Run(tn, func(t *testing.T) {
 			status := AppStatus{}
-			status.PropagateAutoscalerV1Status(tc.autoscaler)
+			status.PropagateAutoscalerV2Status(tc.autoscaler)
 
 			actualCond := status.GetCondition(AppConditionHorizontalPodAutoscalerReady)
 
@@ -877,7 +956,7 @@ func TestAppInstancesStatus(t *testing.T) {
 
 	cases := map[string]struct {
 		app     *App
-		hpa     *autoscalingv1.HorizontalPodAutoscaler
+		hpa     *autoscalingv2.HorizontalPodAutoscaler
 		current InstanceStatus
 		want    InstanceStatus
 	}{
@@ -897,9 +976,19 @@ func TestAppInstancesStatus(t *testing.T) {
 					},
 				},
 			},
-			hpa: &autoscalingv1.HorizontalPodAutoscaler{
-				Status: autoscalingv1.HorizontalPodAutoscalerStatus{
-					CurrentCPUUtilizationPercentage: ptr.Int32(70),
+			hpa: &autoscalingv2.HorizontalPodAutoscaler{
+				Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+					CurrentMetrics: []autoscalingv2.MetricStatus{
+						{
+							Type: autoscalingv2.ResourceMetricSourceType,
+							Resource: &autoscalingv2.ResourceMetricStatus{
+								Name: corev1.ResourceCPU,
+								Current: autoscalingv2.MetricValueStatus{
+									AverageUtilization: ptr.Int32(70),
+								},
+							},
+						},
+					},
 				},
 			},
 			current: InstanceStatus{},
@@ -914,6 +1003,111 @@ func TestAppInstancesStatus(t *testing.T) {
 				},
 			},
 		},
+		"hpa has no metrics yet": {
+			app: &App{
+				Spec: AppSpec{
+					Instances: AppSpecInstances{
+						Autoscaling: AppSpecAutoscaling{
+							Rules: []AppAutoscalingRule{
+								autoscalingRule,
+							},
+						},
+					},
+				},
+			},
+			hpa:     &autoscalingv2.HorizontalPodAutoscaler{},
+			current: InstanceStatus{},
+			want:    InstanceStatus{},
+		},
+		"memory utilization": {
+			app: &App{
+				Spec: AppSpec{
+					Instances: AppSpecInstances{
+						Autoscaling: AppSpecAutoscaling{
+							Rules: []AppAutoscalingRule{
+								{RuleType: MemoryRuleType, Target: ptr.Int32(60)},
+							},
+						},
+					},
+				},
+			},
+			hpa: &autoscalingv2.HorizontalPodAutoscaler{
+				Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+					CurrentMetrics: []autoscalingv2.MetricStatus{
+						{
+							Type: autoscalingv2.ResourceMetricSourceType,
+							Resource: &autoscalingv2.ResourceMetricStatus{
+								Name: corev1.ResourceCPU,
+								Current: autoscalingv2.MetricValueStatus{
+									AverageUtilization: ptr.Int32(10),
+								},
+							},
+						},
+						{
+							Type: autoscalingv2.ResourceMetricSourceType,
+							Resource: &autoscalingv2.ResourceMetricStatus{
+								Name: corev1.ResourceMemory,
+								Current: autoscalingv2.MetricValueStatus{
+									AverageUtilization: ptr.Int32(45),
+								},
+							},
+						},
+					},
+				},
+			},
+			current: InstanceStatus{},
+			want: InstanceStatus{
+				AutoscalingStatus: []AutoscalingRuleStatus{
+					{
+						AppAutoscalingRule: AppAutoscalingRule{RuleType: MemoryRuleType, Target: ptr.Int32(60)},
+						Current: AutoscalingRuleMetricValueStatus{
+							AverageValue: resource.NewQuantity(45, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+		"http throughput": {
+			app: &App{
+				Spec: AppSpec{
+					Instances: AppSpecInstances{
+						Autoscaling: AppSpecAutoscaling{
+							Rules: []AppAutoscalingRule{
+								{RuleType: HTTPThroughputRuleType, Target: ptr.Int32(200)},
+							},
+						},
+					},
+				},
+			},
+			hpa: &autoscalingv2.HorizontalPodAutoscaler{
+				Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+					CurrentMetrics: []autoscalingv2.MetricStatus{
+						{
+							Type: autoscalingv2.PodsMetricSourceType,
+							Pods: &autoscalingv2.PodsMetricStatus{
+								Metric: autoscalingv2.MetricIdentifier{
+									Name: HTTPThroughputMetricName,
+								},
+								Current: autoscalingv2.MetricValueStatus{
+									AverageValue: resource.NewQuantity(150, resource.DecimalSI),
+								},
+							},
+						},
+					},
+				},
+			},
+			current: InstanceStatus{},
+			want: InstanceStatus{
+				AutoscalingStatus: []AutoscalingRuleStatus{
+					{
+						AppAutoscalingRule: AppAutoscalingRule{RuleType: HTTPThroughputRuleType, Target: ptr.Int32(200)},
+						Current: AutoscalingRuleMetricValueStatus{
+							AverageValue: resource.NewQuantity(150, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -947,3 +1141,58 @@ func TestPropagateADXBuildStatus(t *testing.T) {
 		testutil.AssertErrorsEqual(t, errors.New("failed to read image from status: .status.image accessor error: [wrong-type] is of the type []string, expected string"), err)
 	})
 }
+
+func TestAppStatus_PropagateAutoscalingScheduleStatus(t *testing.T) {
+	t.Parallel()
+
+	autoscaling := &AppSpecAutoscaling{
+		Schedules: []AppAutoscalingSchedule{
+			{Name: "weekdays", DaysOfWeek: []int32{1, 2, 3, 4, 5}, StartTime: "08:00", EndTime: "18:00"},
+			{Name: "mondays", DaysOfWeek: []int32{1}, StartTime: "12:00", EndTime: "14:00"},
+			{Name: "expired", StartDate: "2022-01-01", StartTime: "00:00", EndDate: "2022-01-02", EndTime: "00:00"},
+		},
+	}
+
+	// Monday, 7 March 2022
+	now := time.Date(2022, 3, 7, 13, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *metav1.Time {
+		t := metav1.NewTime(time.Date(2022, 3, day, hour, 0, 0, 0, time.UTC))
+		return &t
+	}
+
+	status := AppStatus{}
+	status.PropagateAutoscalingScheduleStatus(autoscaling, now)
+
+	testutil.AssertEqual(t, "schedules", []AutoscalingScheduleStatus{
+		{Name: "weekdays", Active: true, NextEndTime: at(7, 18)},
+		{Name: "mondays", NextEndTime: at(7, 14)},
+		{Name: "expired"},
+	}, status.AutoscalingSchedules)
+
+	next := status.NextAutoscalingScheduleTransition()
+	testutil.AssertNotNil(t, "next transition", next)
+	testutil.AssertTrue(t, "next transition", at(7, 14).Time.Equal(*next))
+
+	status.PropagateAutoscalingScheduleStatus(&AppSpecAutoscaling{}, now)
+	testutil.AssertEqual(t, "schedules", 0, len(status.AutoscalingSchedules))
+	testutil.AssertTrue(t, "no transition", status.NextAutoscalingScheduleTransition() == nil)
+}
+
+func TestAppStatus_PropagateProcessStatus(t *testing.T) {
+	status := &AppStatus{
+		Processes: []AppProcessStatus{{Type: "removed"}},
+	}
+
+	worker := &appsv1.Deployment{}
+	worker.Labels = map[string]string{ProcessTypeLabel: "worker"}
+	worker.Spec.Replicas = ptr.Int32(3)
+	worker.Status.ReadyReplicas = 2
+
+	status.PropagateProcessStatus([]*appsv1.Deployment{worker})
+	testutil.AssertEqual(t, "processes", []AppProcessStatus{
+		{Type: "worker", Replicas: 3, ReadyReplicas: 2},
+	}, status.Processes)
+
+	status.PropagateProcessStatus(nil)
+	testutil.AssertEqual(t, "processes", []AppProcessStatus(nil), status.Processes)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	autoscaling "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -50,6 +51,22 @@ const (
 	MaxTaskCount = 500
 	// AppServerComponent is the value used for the App component.
 	AppServerComponent = "app-server"
+	// AppProcessComponent is the value used for the component of an App's
+	// additional processes.
+	AppProcessComponent = "app-process"
+	// ProcessTypeLabel holds the type of process a Pod runs for an App.
+	ProcessTypeLabel = "kf.dev/process-type"
+	// WebProcessType is the type of the App's default process which receives
+	// traffic from Routes.
+	WebProcessType = "web"
+	// RolloutLabel holds whether a Pod runs the stable or candidate revision
+	// of an App.
+	RolloutLabel = "kf.dev/rollout"
+	// RolloutStable is the RolloutLabel value for Pods of the stable revision.
+	RolloutStable = "stable"
+	// RolloutCandidate is the RolloutLabel value for Pods of the revision
+	// being rolled out.
+	RolloutCandidate = "candidate"
 )
 
 // RouteBindingStatus represents the status of a RouteBinding.
@@ -134,6 +151,18 @@ type AppSpec struct {
 	// +optional
 	// +patchStrategy=merge
 	Routes []RouteWeightBinding `json:"routes,omitempty"`
+
+	// Rollout defines how new revisions of the App are released. If blank,
+	// instances are replaced with a rolling update.
+	// +optional
+	Rollout *AppSpecRollout `json:"rollout,omitempty"`
+
+	// Processes defines additional processes, such as workers, that run from
+	// the App's image alongside the web process. Each process runs in its
+	// own Deployment and shares the App's build, environment variables, and
+	// service bindings.
+	// +optional
+	Processes []AppSpecProcess `json:"processes,omitempty"`
 }
 
 // AppSpecBuild defines an app's build configuration.
@@ -190,6 +219,75 @@ type AppSpecInstances struct {
 	DeprecatedExactly *int32 `json:"exactly,omitempty"`
 }
 
+// AppSpecProcess defines an additional process of an App.
+type AppSpecProcess struct {
+	// Type is the name of the process, e.g. worker. It must be unique within
+	// the App and can't be web.
+	Type string `json:"type"`
+
+	// Command overrides the entrypoint of the App's container.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args overrides the arguments of the App's container.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Replicas defines the number of desired instances of the process.
+	// Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources overrides the resource requirements of the App's container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ReadinessProbe is the process's readiness check. Processes don't
+	// inherit the App's probes because they usually don't listen on a port.
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+
+	// LivenessProbe is the process's liveness check.
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+
+	// StartupProbe is the check that must pass before the process's liveness
+	// and readiness checks start.
+	// +optional
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+
+	// Sidecars are containers that run from the App's image next to the
+	// process. The web process's sidecars are the additional containers in
+	// the App's template.
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+}
+
+// DeploymentReplicas returns the value that the process's deployment replicas
+// should be set to given the App's instances.
+func (process *AppSpecProcess) DeploymentReplicas(instances AppSpecInstances) int32 {
+	switch {
+	case instances.Stopped:
+		return 0
+	case process.Replicas != nil:
+		return *process.Replicas
+	default:
+		return 1
+	}
+}
+
+// Process returns the additional process with the given type or nil if it
+// doesn't exist.
+func (spec *AppSpec) Process(processType string) *AppSpecProcess {
+	for i := range spec.Processes {
+		if spec.Processes[i].Type == processType {
+			return &spec.Processes[i]
+		}
+	}
+
+	return nil
+}
+
 // AppSpecAutoscaling defines the autoscaling specs for an App.
 type AppSpecAutoscaling struct {
 
@@ -204,6 +302,66 @@ type AppSpecAutoscaling struct {
 
 	// Rules defines the autoscaling rules for the App.
 	Rules []AppAutoscalingRule `json:"rules,omitempty"`
+
+	// Schedules override MinReplicas and MaxReplicas during specific time
+	// windows. If multiple schedules are active at the same time, the first
+	// one in the list is used.
+	// +optional
+	Schedules []AppAutoscalingSchedule `json:"schedules,omitempty"`
+}
+
+// Time formats used by AppAutoscalingSchedule.
+const (
+	// AutoscalingScheduleTimeFormat is the format of schedule start and end
+	// times.
+	AutoscalingScheduleTimeFormat = "15:04"
+	// AutoscalingScheduleDateFormat is the format of schedule start and end
+	// dates.
+	AutoscalingScheduleDateFormat = "2006-01-02"
+)
+
+// AppAutoscalingSchedule sets the autoscaling limits of an App during a time
+// window. The window is either recurring, if DaysOfWeek or DaysOfMonth are
+// set, or a single window on specific dates.
+type AppAutoscalingSchedule struct {
+	// Name uniquely identifies the schedule within the App.
+	Name string `json:"name"`
+
+	// TimeZone is the IANA time zone the schedule's times are in, e.g.
+	// America/New_York. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// DaysOfWeek the recurring window occurs on, from 1 (Monday) to 7
+	// (Sunday).
+	// +optional
+	DaysOfWeek []int32 `json:"daysOfWeek,omitempty"`
+
+	// DaysOfMonth the recurring window occurs on, from 1 to 31.
+	// +optional
+	DaysOfMonth []int32 `json:"daysOfMonth,omitempty"`
+
+	// StartTime is the time of day the window starts in HH:MM format.
+	StartTime string `json:"startTime"`
+
+	// EndTime is the time of day the window ends in HH:MM format.
+	EndTime string `json:"endTime"`
+
+	// StartDate is the date in YYYY-MM-DD format of a specific-date window's
+	// start, or the first date a recurring window may occur on.
+	// +optional
+	StartDate string `json:"startDate,omitempty"`
+
+	// EndDate is the date in YYYY-MM-DD format of a specific-date window's
+	// end, or the last date a recurring window may occur on.
+	// +optional
+	EndDate string `json:"endDate,omitempty"`
+
+	// MinReplicas is the minimum number of instances during the window.
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the maximum number of instances during the window.
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
 }
 
 // AutoscalingRuleType defines supported ruletypes for autoscaling.
@@ -211,9 +369,38 @@ type AutoscalingRuleType string
 
 // Allowed RuleTypes rule autoscaling.
 const (
+	// CPURuleType scales on the average CPU utilization of the App's
+	// instances.
 	CPURuleType AutoscalingRuleType = "CPU"
+	// MemoryRuleType scales on the average memory utilization of the App's
+	// instances.
+	MemoryRuleType AutoscalingRuleType = "Memory"
+	// HTTPThroughputRuleType scales on the average number of HTTP requests
+	// per second each instance receives, as reported by the Istio sidecar.
+	HTTPThroughputRuleType AutoscalingRuleType = "HTTPThroughput"
+	// HTTPLatencyRuleType scales on the average HTTP response latency of the
+	// App's instances in milliseconds, as reported by the Istio sidecar.
+	HTTPLatencyRuleType AutoscalingRuleType = "HTTPLatency"
+)
+
+// Names of the per-instance metrics the HTTP rule types scale on. They're
+// derived from the Istio sidecar's telemetry and must be served through the
+// custom metrics API by a metrics adapter installed on the cluster.
+const (
+	// HTTPThroughputMetricName is the metric used by HTTPThroughputRuleType.
+	HTTPThroughputMetricName = "istio_requests_per_second"
+	// HTTPLatencyMetricName is the metric used by HTTPLatencyRuleType.
+	HTTPLatencyMetricName = "istio_request_duration_milliseconds"
 )
 
+// AutoscalingRuleTypes contains all the supported autoscaling rule types.
+var AutoscalingRuleTypes = []AutoscalingRuleType{
+	CPURuleType,
+	MemoryRuleType,
+	HTTPThroughputRuleType,
+	HTTPLatencyRuleType,
+}
+
 // AppAutoscalingRule defines the autoscaling rules for an App.
 type AppAutoscalingRule struct {
 
@@ -222,7 +409,9 @@ type AppAutoscalingRule struct {
 
 	// Target value for the metric.
 	// Unit of target depends on the rule type.
-	// For CPU, it will be a percentage represented by number in range (0, 100].
+	// For CPU and Memory, it will be a percentage represented by number in range (0, 100].
+	// For HTTPThroughput, it will be the number of requests per second per instance.
+	// For HTTPLatency, it will be the response time in milliseconds.
 	Target *int32 `json:"target,omitempty"`
 }
 
@@ -251,13 +440,263 @@ func (autoscaling *AppSpecAutoscaling) RequiresHPA() bool {
 	return autoscaling.Enabled && autoscaling.MaxReplicas != nil && len(autoscaling.Rules) > 0
 }
 
+// IsRecurring returns true if the schedule repeats on days of the week or
+// month rather than occurring once on specific dates.
+func (schedule *AppAutoscalingSchedule) IsRecurring() bool {
+	return len(schedule.DaysOfWeek) > 0 || len(schedule.DaysOfMonth) > 0
+}
+
+// Location returns the time zone of the schedule.
+func (schedule *AppAutoscalingSchedule) Location() (*time.Location, error) {
+	if schedule.TimeZone == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(schedule.TimeZone)
+}
+
+// maxRecurringScheduleSearchDays bounds the search for the next occurrence of
+// a recurring schedule. Every day of the month occurs at least once within it.
+const maxRecurringScheduleSearchDays = 366
+
+// NextWindow returns the start and end of the schedule's window that is
+// either active at now, or the next one to start. ok is false if the schedule
+// is malformed or won't occur again.
+func (schedule *AppAutoscalingSchedule) NextWindow(now time.Time) (start, end time.Time, ok bool) {
+	loc, err := schedule.Location()
+	if err != nil {
+		return
+	}
+
+	startTime, err := time.Parse(AutoscalingScheduleTimeFormat, schedule.StartTime)
+	if err != nil {
+		return
+	}
+
+	endTime, err := time.Parse(AutoscalingScheduleTimeFormat, schedule.EndTime)
+	if err != nil {
+		return
+	}
+
+	atTime := func(date, clock time.Time) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+
+	parseDate := func(date string) (time.Time, bool) {
+		if date == "" {
+			return time.Time{}, false
+		}
+		t, err := time.ParseInLocation(AutoscalingScheduleDateFormat, date, loc)
+		return t, err == nil
+	}
+
+	startDate, hasStartDate := parseDate(schedule.StartDate)
+	endDate, hasEndDate := parseDate(schedule.EndDate)
+
+	if !schedule.IsRecurring() {
+		if !hasStartDate || !hasEndDate {
+			return
+		}
+
+		start, end = atTime(startDate, startTime), atTime(endDate, endTime)
+		return start, end, now.Before(end)
+	}
+
+	day := now.In(loc)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	if hasStartDate && day.Before(startDate) {
+		day = startDate
+	}
+
+	for i := 0; i < maxRecurringScheduleSearchDays; i++ {
+		date := day.AddDate(0, 0, i)
+		if hasEndDate && date.After(endDate) {
+			return
+		}
+
+		if !schedule.occursOn(date) {
+			continue
+		}
+
+		start, end = atTime(date, startTime), atTime(date, endTime)
+		if now.Before(end) {
+			return start, end, true
+		}
+	}
+
+	return
+}
+
+// occursOn returns true if a recurring schedule has a window on the date.
+func (schedule *AppAutoscalingSchedule) occursOn(date time.Time) bool {
+	// Convert Go's Sunday based weekdays to ISO 8601 where Sunday is 7.
+	weekday := int32(date.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+
+	for _, d := range schedule.DaysOfWeek {
+		if d == weekday {
+			return true
+		}
+	}
+
+	for _, d := range schedule.DaysOfMonth {
+		if d == int32(date.Day()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ActiveSchedule returns the first schedule with a window that includes now,
+// or nil if no schedules are active.
+func (autoscaling *AppSpecAutoscaling) ActiveSchedule(now time.Time) *AppAutoscalingSchedule {
+	for i := range autoscaling.Schedules {
+		schedule := &autoscaling.Schedules[i]
+		if start, _, ok := schedule.NextWindow(now); ok && !now.Before(start) {
+			return schedule
+		}
+	}
+
+	return nil
+}
+
 // GetAutoscalingRuleType converts a string to AutoscalingRuleType.
 // This is used by CLI to get rule type based on string.
-// No validation is needed.
+// Known rule types are matched case-insensitively, no other validation is
+// needed.
 func GetAutoscalingRuleType(s string) AutoscalingRuleType {
+	for _, ruleType := range AutoscalingRuleTypes {
+		if strings.EqualFold(s, string(ruleType)) {
+			return ruleType
+		}
+	}
+
 	return AutoscalingRuleType(strings.ToUpper(s))
 }
 
+// RolloutStrategy defines how a new revision of an App replaces the old one.
+type RolloutStrategy string
+
+// Allowed rollout strategies.
+const (
+	// RollingRolloutStrategy replaces instances of the App in place.
+	RollingRolloutStrategy RolloutStrategy = "Rolling"
+
+	// BlueGreenRolloutStrategy starts a full set of instances for the new
+	// revision and switches all traffic to them once they're ready.
+	BlueGreenRolloutStrategy RolloutStrategy = "BlueGreen"
+
+	// CanaryRolloutStrategy starts instances for the new revision and shifts
+	// traffic to them in steps, pausing for promotion after each one.
+	CanaryRolloutStrategy RolloutStrategy = "Canary"
+)
+
+// AppSpecRollout defines how new revisions of an App are released.
+type AppSpecRollout struct {
+
+	// Strategy is the rollout strategy to use, one of Rolling, BlueGreen or
+	// Canary.
+	// +optional
+	Strategy RolloutStrategy `json:"strategy,omitempty"`
+
+	// Steps contains the percentage of traffic sent to the new revision at each
+	// step of a Canary rollout.
+	// +optional
+	Steps []int32 `json:"steps,omitempty"`
+
+	// PromoteRequests is incremented to move a Canary rollout to its next step.
+	// +optional
+	PromoteRequests int `json:"promoteRequests,omitempty"`
+
+	// AbortRequests is incremented to abort the current rollout and send all
+	// traffic back to the previous revision.
+	// +optional
+	AbortRequests int `json:"abortRequests,omitempty"`
+}
+
+// GetStrategy returns the rollout strategy, defaulting to
+// RollingRolloutStrategy if the rollout is nil or the strategy is blank.
+func (rollout *AppSpecRollout) GetStrategy() RolloutStrategy {
+	if rollout == nil || rollout.Strategy == "" {
+		return RollingRolloutStrategy
+	}
+
+	return rollout.Strategy
+}
+
+// RolloutState is the state of an in-progress or finished rollout.
+type RolloutState string
+
+// Rollout states.
+const (
+	// RolloutStateProgressing indicates the new revision is starting.
+	RolloutStateProgressing RolloutState = "Progressing"
+
+	// RolloutStatePaused indicates a Canary rollout is waiting to be promoted.
+	RolloutStatePaused RolloutState = "Paused"
+
+	// RolloutStatePromoting indicates the new revision is replacing the old one.
+	RolloutStatePromoting RolloutState = "Promoting"
+
+	// RolloutStateComplete indicates the new revision has replaced the old one.
+	RolloutStateComplete RolloutState = "Complete"
+
+	// RolloutStateAborted indicates the rollout was aborted by the user.
+	RolloutStateAborted RolloutState = "Aborted"
+
+	// RolloutStateRolledBack indicates the new revision failed to become ready
+	// and traffic was sent back to the previous revision.
+	RolloutStateRolledBack RolloutState = "RolledBack"
+)
+
+// AppRolloutStatus contains the status of the App's latest rollout.
+type AppRolloutStatus struct {
+
+	// Strategy is the strategy used for the rollout.
+	Strategy RolloutStrategy `json:"strategy,omitempty"`
+
+	// State is the state of the rollout.
+	State RolloutState `json:"state,omitempty"`
+
+	// Revision is a hash of the Pod template being rolled out.
+	Revision string `json:"revision,omitempty"`
+
+	// Step is the current step of a Canary rollout.
+	Step int32 `json:"step,omitempty"`
+
+	// CandidatePercent is the percentage of traffic sent to the new revision.
+	CandidatePercent int32 `json:"candidatePercent,omitempty"`
+
+	// StartPromoteRequests holds the value of PromoteRequests when the rollout
+	// started.
+	StartPromoteRequests int `json:"startPromoteRequests,omitempty"`
+
+	// StartAbortRequests holds the value of AbortRequests when the rollout
+	// started.
+	StartAbortRequests int `json:"startAbortRequests,omitempty"`
+
+	// Message is a human-readable description of the rollout state.
+	Message string `json:"message,omitempty"`
+}
+
+// IsActive returns true if the rollout has a candidate revision that hasn't
+// been fully promoted or discarded.
+func (status *AppRolloutStatus) IsActive() bool {
+	if status == nil {
+		return false
+	}
+
+	switch status.State {
+	case RolloutStateProgressing, RolloutStatePaused, RolloutStatePromoting:
+		return true
+	default:
+		return false
+	}
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -308,11 +747,19 @@ func (spec *AppSpecBuild) NeedsUpdateRequestsIncrement(old AppSpecBuild) bool {
 //
 // This can happen if a field in the spec changes without also updating the
 // UpdateRequests.
+//
+// Changes to the Rollout don't require a redeploy because they only control
+// how the current revision is released.
 func (spec *AppSpec) NeedsUpdateRequestsIncrement(old AppSpec) bool {
 	updateRequestsChanged := old.Template.UpdateRequests != spec.Template.UpdateRequests
 
 	if !updateRequestsChanged {
-		specsChanged := !reflect.DeepEqual(&old, spec)
+		oldWithoutRollout := old.DeepCopy()
+		oldWithoutRollout.Rollout = nil
+		newWithoutRollout := spec.DeepCopy()
+		newWithoutRollout.Rollout = nil
+
+		specsChanged := !reflect.DeepEqual(oldWithoutRollout, newWithoutRollout)
 		return specsChanged
 	}
 
@@ -407,6 +854,53 @@ type AppStatus struct {
 
 	// Tasks are status of Tasks run on the App.
 	Tasks AppTaskStatus `json:"tasks,omitempty"`
+
+	// Rollout contains the status of the App's latest rollout, if the App
+	// uses a BlueGreen or Canary rollout strategy.
+	// +optional
+	Rollout *AppRolloutStatus `json:"rollout,omitempty"`
+
+	// AutoscalingSchedules contains the state of each of the App's
+	// autoscaling schedules.
+	// +optional
+	AutoscalingSchedules []AutoscalingScheduleStatus `json:"autoscalingSchedules,omitempty"`
+
+	// Processes contains the status of the App's additional processes.
+	// +optional
+	Processes []AppProcessStatus `json:"processes,omitempty"`
+}
+
+// AppProcessStatus contains the state of an additional process of an App.
+type AppProcessStatus struct {
+	// Type of the process.
+	Type string `json:"type"`
+
+	// Replicas is the desired number of instances of the process.
+	Replicas int32 `json:"replicas"`
+
+	// ReadyReplicas is the number of instances of the process that are
+	// ready.
+	ReadyReplicas int32 `json:"readyReplicas"`
+}
+
+// AutoscalingScheduleStatus contains the state of an autoscaling schedule.
+type AutoscalingScheduleStatus struct {
+	// Name of the schedule.
+	Name string `json:"name"`
+
+	// Active is true if the schedule's limits are currently applied to the
+	// App's autoscaler.
+	Active bool `json:"active,omitempty"`
+
+	// NextStartTime is when the schedule's next window starts. It's unset if
+	// the window is in progress or the schedule won't occur again.
+	// +optional
+	NextStartTime *metav1.Time `json:"nextStartTime,omitempty"`
+
+	// NextEndTime is when the schedule's current or next window ends. It's
+	// unset if the schedule won't occur again.
+	// +optional
+	NextEndTime *metav1.Time `json:"nextEndTime,omitempty"`
 }
 
 // AppVolumeStatus contains the status of mounted volume.
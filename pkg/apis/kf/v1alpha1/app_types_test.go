@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	"knative.dev/pkg/ptr"
@@ -54,6 +55,20 @@ func ExampleAppComponentLabels() {
 	// component: database
 }
 
+func ExampleGetAutoscalingRuleType() {
+	fmt.Println(GetAutoscalingRuleType("cpu"))
+	fmt.Println(GetAutoscalingRuleType("memory"))
+	fmt.Println(GetAutoscalingRuleType("httpthroughput"))
+	fmt.Println(GetAutoscalingRuleType("HTTPLATENCY"))
+	fmt.Println(GetAutoscalingRuleType("unknown"))
+
+	// Output: CPU
+	// Memory
+	// HTTPThroughput
+	// HTTPLatency
+	// UNKNOWN
+}
+
 func TestBuildSpec_NeedsUpdateRequestsIncrement(t *testing.T) {
 
 	buildpackBuildSpec := &BuildSpec{
@@ -221,3 +236,195 @@ func TestAppSpecInstances_DeploymentReplicas(t *testing.T) {
 		})
 	}
 }
+
+func TestAppSpecProcess_DeploymentReplicas(t *testing.T) {
+	tests := map[string]struct {
+		process   AppSpecProcess
+		instances AppSpecInstances
+		want      int32
+	}{
+		"default": {
+			process: AppSpecProcess{Type: "worker"},
+			want:    1,
+		},
+		"replicas": {
+			process: AppSpecProcess{Type: "worker", Replicas: ptr.Int32(3)},
+			want:    3,
+		},
+		"stopped App": {
+			process:   AppSpecProcess{Type: "worker", Replicas: ptr.Int32(3)},
+			instances: AppSpecInstances{Stopped: true},
+			want:      0,
+		},
+	}
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "replicas", tc.want, tc.process.DeploymentReplicas(tc.instances))
+		})
+	}
+}
+
+func ExampleAppSpec_Process() {
+	spec := AppSpec{
+		Processes: []AppSpecProcess{
+			{Type: "worker", Replicas: ptr.Int32(2)},
+		},
+	}
+
+	fmt.Println("worker replicas:", *spec.Process("worker").Replicas)
+	fmt.Println("clock exists:", spec.Process("clock") != nil)
+
+	// Output: worker replicas: 2
+	// clock exists: false
+}
+
+func TestAppAutoscalingSchedule_NextWindow(t *testing.T) {
+	t.Parallel()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	testutil.AssertNil(t, "LoadLocation error", err)
+
+	// Monday, 7 March 2022
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2022, 3, 7, hour, minute, 0, 0, newYork)
+	}
+
+	weekdays := AppAutoscalingSchedule{
+		Name:        "business-hours",
+		TimeZone:    "America/New_York",
+		DaysOfWeek:  []int32{1, 2, 3, 4, 5},
+		StartTime:   "08:00",
+		EndTime:     "18:00",
+		MinReplicas: ptr.Int32(5),
+		MaxReplicas: ptr.Int32(20),
+	}
+
+	withStartDate := weekdays
+	withStartDate.StartDate = "2022-03-09"
+
+	withEndDate := weekdays
+	withEndDate.EndDate = "2022-03-06"
+
+	cases := map[string]struct {
+		schedule  AppAutoscalingSchedule
+		now       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+		wantOK    bool
+	}{
+		"before window": {
+			schedule:  weekdays,
+			now:       monday(7, 0),
+			wantStart: monday(8, 0),
+			wantEnd:   monday(18, 0),
+			wantOK:    true,
+		},
+		"in window": {
+			schedule:  weekdays,
+			now:       monday(12, 0),
+			wantStart: monday(8, 0),
+			wantEnd:   monday(18, 0),
+			wantOK:    true,
+		},
+		"end is exclusive": {
+			schedule:  weekdays,
+			now:       monday(18, 0),
+			wantStart: monday(8, 0).AddDate(0, 0, 1),
+			wantEnd:   monday(18, 0).AddDate(0, 0, 1),
+			wantOK:    true,
+		},
+		"skips weekend": {
+			schedule:  weekdays,
+			now:       monday(12, 0).AddDate(0, 0, 5),
+			wantStart: monday(8, 0).AddDate(0, 0, 7),
+			wantEnd:   monday(18, 0).AddDate(0, 0, 7),
+			wantOK:    true,
+		},
+		"waits for start date": {
+			schedule:  withStartDate,
+			now:       monday(12, 0),
+			wantStart: monday(8, 0).AddDate(0, 0, 2),
+			wantEnd:   monday(18, 0).AddDate(0, 0, 2),
+			wantOK:    true,
+		},
+		"after end date": {
+			schedule: withEndDate,
+			now:      monday(12, 0),
+			wantOK:   false,
+		},
+		"days of month": {
+			schedule: AppAutoscalingSchedule{
+				DaysOfMonth: []int32{1},
+				StartTime:   "00:00",
+				EndTime:     "06:00",
+			},
+			now:       time.Date(2022, 3, 7, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2022, 4, 1, 6, 0, 0, 0, time.UTC),
+			wantOK:    true,
+		},
+		"specific date": {
+			schedule: AppAutoscalingSchedule{
+				StartDate: "2022-03-10",
+				StartTime: "20:00",
+				EndDate:   "2022-03-12",
+				EndTime:   "02:00",
+			},
+			now:       time.Date(2022, 3, 7, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2022, 3, 10, 20, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2022, 3, 12, 2, 0, 0, 0, time.UTC),
+			wantOK:    true,
+		},
+		"specific date passed": {
+			schedule: AppAutoscalingSchedule{
+				StartDate: "2022-03-01",
+				StartTime: "20:00",
+				EndDate:   "2022-03-02",
+				EndTime:   "02:00",
+			},
+			now:    time.Date(2022, 3, 7, 0, 0, 0, 0, time.UTC),
+			wantOK: false,
+		},
+		"invalid time zone": {
+			schedule: AppAutoscalingSchedule{
+				TimeZone:   "Mars/Olympus_Mons",
+				DaysOfWeek: []int32{1},
+				StartTime:  "08:00",
+				EndTime:    "18:00",
+			},
+			now:    monday(12, 0),
+			wantOK: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			start, end, ok := tc.schedule.NextWindow(tc.now)
+			testutil.AssertEqual(t, "ok", tc.wantOK, ok)
+			if !tc.wantOK {
+				return
+			}
+
+			testutil.AssertTrue(t, "start", tc.wantStart.Equal(start))
+			testutil.AssertTrue(t, "end", tc.wantEnd.Equal(end))
+		})
+	}
+}
+
+func TestAppSpecAutoscaling_ActiveSchedule(t *testing.T) {
+	t.Parallel()
+
+	autoscaling := AppSpecAutoscaling{
+		Schedules: []AppAutoscalingSchedule{
+			{Name: "nights", DaysOfWeek: []int32{1}, StartTime: "00:00", EndTime: "06:00"},
+			{Name: "mondays", DaysOfWeek: []int32{1}, StartTime: "00:00", EndTime: "23:59"},
+			{Name: "all-week", DaysOfWeek: []int32{1, 2, 3, 4, 5, 6, 7}, StartTime: "00:00", EndTime: "23:59"},
+		},
+	}
+
+	// Monday, 7 March 2022
+	testutil.AssertEqual(t, "first match wins", "nights", autoscaling.ActiveSchedule(time.Date(2022, 3, 7, 1, 0, 0, 0, time.UTC)).Name)
+	testutil.AssertEqual(t, "later match", "mondays", autoscaling.ActiveSchedule(time.Date(2022, 3, 7, 12, 0, 0, 0, time.UTC)).Name)
+	testutil.AssertEqual(t, "other day", "all-week", autoscaling.ActiveSchedule(time.Date(2022, 3, 8, 12, 0, 0, 0, time.UTC)).Name)
+	testutil.AssertTrue(t, "none active", autoscaling.ActiveSchedule(time.Date(2022, 3, 7, 23, 59, 30, 0, time.UTC)) == nil)
+}
@@ -18,8 +18,12 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/google/kf/v2/pkg/apis/kf"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -42,10 +46,70 @@ func (app *App) Validate(ctx context.Context) (errs *apis.FieldError) {
 func (spec *AppSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(kf.ValidatePodSpec(spec.Template.Spec).ViaField("template.spec"))
+	if len(spec.Template.Spec.Containers) > 1 {
+		errs = errs.Also(validateSidecarNames(spec.Template.Spec.Containers[1:], 1).ViaField("template.spec.containers"))
+	}
 	errs = errs.Also(spec.Instances.Validate(ctx).ViaField("instances"))
 	errs = errs.Also(spec.Build.Validate(ctx).ViaField("build"))
 	errs = errs.Also(spec.ValidateRoutes(ctx).ViaField("routes"))
 
+	if spec.Rollout != nil {
+		errs = errs.Also(spec.Rollout.Validate(ctx).ViaField("rollout"))
+	}
+
+	processTypes := sets.NewString()
+	for idx, process := range spec.Processes {
+		errs = errs.Also(process.Validate(ctx).ViaFieldIndex("processes", idx))
+
+		if processTypes.Has(process.Type) {
+			errs = errs.Also(apis.ErrGeneric("duplicate process type", "type").ViaFieldIndex("processes", idx))
+		}
+		processTypes.Insert(process.Type)
+	}
+
+	return errs
+}
+
+// validateSidecarNames checks that sidecars don't use the name reserved for the
+// App's container. Indexes in errors start at offset.
+func validateSidecarNames(sidecars []corev1.Container, offset int) (errs *apis.FieldError) {
+	for idx, sidecar := range sidecars {
+		if sidecar.Name == DefaultUserContainerName {
+			errs = errs.Also(apis.ErrInvalidValue(sidecar.Name, "name", "the name is reserved for the App's container").ViaIndex(idx + offset))
+		}
+	}
+
+	return errs
+}
+
+// Validate checks that the process has a usable type, scale, and sidecars.
+func (process *AppSpecProcess) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch {
+	case process.Type == "":
+		errs = errs.Also(apis.ErrMissingField("type"))
+	case process.Type == WebProcessType:
+		errs = errs.Also(apis.ErrInvalidValue(process.Type, "type", "the web process is configured by the App's template"))
+	default:
+		for _, msg := range validation.IsDNS1123Label(process.Type) {
+			errs = errs.Also(apis.ErrInvalidValue(process.Type, "type", msg))
+		}
+	}
+
+	if process.Replicas != nil && *process.Replicas < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*process.Replicas, "replicas"))
+	}
+
+	sidecarNames := sets.NewString()
+	for idx, sidecar := range process.Sidecars {
+		errs = errs.Also(kf.ValidateSidecarContainer(sidecar).ViaFieldIndex("sidecars", idx))
+
+		if sidecarNames.Has(sidecar.Name) {
+			errs = errs.Also(kf.ErrDuplicateValue(sidecar.Name, "name").ViaFieldIndex("sidecars", idx))
+		}
+		sidecarNames.Insert(sidecar.Name)
+	}
+	errs = errs.Also(validateSidecarNames(process.Sidecars, 0).ViaField("sidecars"))
+
 	return errs
 }
 
@@ -126,6 +190,16 @@ func (autoscaling *AppSpecAutoscaling) Validate(ctx context.Context) (errs *apis
 		errs = errs.Also(rule.Validate(ctx).ViaFieldIndex("rules", idx))
 	}
 
+	scheduleNames := sets.NewString()
+	for idx, schedule := range autoscaling.Schedules {
+		errs = errs.Also(schedule.Validate(ctx).ViaFieldIndex("schedules", idx))
+
+		if scheduleNames.Has(schedule.Name) {
+			errs = errs.Also(apis.ErrGeneric("duplicate schedule name", "name").ViaFieldIndex("schedules", idx))
+		}
+		scheduleNames.Insert(schedule.Name)
+	}
+
 	minReplicas, maxReplicas := autoscaling.MinReplicas, autoscaling.MaxReplicas
 
 	switch {
@@ -149,14 +223,166 @@ func (autoscaling *AppSpecAutoscaling) Validate(ctx context.Context) (errs *apis
 func (r *AppAutoscalingRule) Validate(ctx context.Context) (errs *apis.FieldError) {
 	target := r.Target
 
+	var maxTarget int32
+	switch r.RuleType {
+	case CPURuleType, MemoryRuleType:
+		// Utilization is a percentage of the requested resources.
+		maxTarget = 100
+	case HTTPThroughputRuleType, HTTPLatencyRuleType:
+		maxTarget = math.MaxInt32
+	default:
+		return errs.Also(apis.ErrInvalidValue(r.RuleType, "ruleType"))
+	}
+
 	switch {
-	case r.RuleType != CPURuleType:
-		errs = errs.Also(apis.ErrInvalidValue(r.RuleType, "ruleType"))
 	case target == nil:
 		errs = errs.Also(apis.ErrMissingField("target"))
-	case *target <= 0 || *target > 100:
-		// XXX: For different rule types, we may need impose different limits to Target.
-		errs = errs.Also(apis.ErrOutOfBoundsValue(*r.Target, 1, 100, "target"))
+	case *target <= 0 || *target > maxTarget:
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*r.Target, 1, maxTarget, "target"))
+	}
+
+	return errs
+}
+
+// Validate checks that the autoscaling schedule describes a window that can
+// occur and sets valid limits.
+func (schedule *AppAutoscalingSchedule) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if schedule.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+
+	loc, err := schedule.Location()
+	if err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(schedule.TimeZone, "timeZone", err.Error()))
+		loc = time.UTC
+	}
+
+	parseTime := func(value, field string) (t time.Time, ok bool) {
+		if value == "" {
+			errs = errs.Also(apis.ErrMissingField(field))
+			return t, false
+		}
+
+		t, err := time.Parse(AutoscalingScheduleTimeFormat, value)
+		if err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(value, field, "must be in HH:MM format"))
+			return t, false
+		}
+
+		return t, true
+	}
+
+	parseDate := func(value, field string) (t time.Time, ok bool) {
+		if value == "" {
+			return t, false
+		}
+
+		t, err := time.ParseInLocation(AutoscalingScheduleDateFormat, value, loc)
+		if err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(value, field, "must be in YYYY-MM-DD format"))
+			return t, false
+		}
+
+		return t, true
+	}
+
+	startTime, hasStartTime := parseTime(schedule.StartTime, "startTime")
+	endTime, hasEndTime := parseTime(schedule.EndTime, "endTime")
+	startDate, hasStartDate := parseDate(schedule.StartDate, "startDate")
+	endDate, hasEndDate := parseDate(schedule.EndDate, "endDate")
+
+	for idx, day := range schedule.DaysOfWeek {
+		if day < 1 || day > 7 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(day, 1, 7, apis.CurrentField).ViaFieldIndex("daysOfWeek", idx))
+		}
+	}
+
+	for idx, day := range schedule.DaysOfMonth {
+		if day < 1 || day > 31 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(day, 1, 31, apis.CurrentField).ViaFieldIndex("daysOfMonth", idx))
+		}
+	}
+
+	if schedule.IsRecurring() {
+		if len(schedule.DaysOfWeek) > 0 && len(schedule.DaysOfMonth) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("daysOfWeek", "daysOfMonth"))
+		}
+
+		if hasStartTime && hasEndTime && !endTime.After(startTime) {
+			errs = errs.Also(apis.ErrInvalidValue(schedule.EndTime, "endTime", "must be after startTime"))
+		}
+
+		if hasStartDate && hasEndDate && endDate.Before(startDate) {
+			errs = errs.Also(apis.ErrInvalidValue(schedule.EndDate, "endDate", "must not be before startDate"))
+		}
+	} else {
+		// Specific date schedules need a full start and end.
+		if schedule.StartDate == "" {
+			errs = errs.Also(apis.ErrMissingField("startDate"))
+		}
+
+		if schedule.EndDate == "" {
+			errs = errs.Also(apis.ErrMissingField("endDate"))
+		}
+
+		if hasStartTime && hasEndTime && hasStartDate && hasEndDate {
+			start := startDate.Add(time.Duration(startTime.Hour())*time.Hour + time.Duration(startTime.Minute())*time.Minute)
+			end := endDate.Add(time.Duration(endTime.Hour())*time.Hour + time.Duration(endTime.Minute())*time.Minute)
+			if !end.After(start) {
+				errs = errs.Also(apis.ErrInvalidValue(schedule.EndDate+" "+schedule.EndTime, "endDate", "must be after the start"))
+			}
+		}
+	}
+
+	minReplicas, maxReplicas := schedule.MinReplicas, schedule.MaxReplicas
+	switch {
+	case maxReplicas == nil:
+		errs = errs.Also(apis.ErrMissingField("maxReplicas"))
+	case *maxReplicas <= 0:
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*maxReplicas, 1, math.MaxInt32, "maxReplicas"))
+	case minReplicas == nil:
+		errs = errs.Also(apis.ErrMissingField("minReplicas"))
+	case *minReplicas <= 0 || *minReplicas > *maxReplicas:
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*minReplicas, 1, *maxReplicas, "minReplicas"))
+	}
+
+	return errs
+}
+
+// Validate checks that the rollout strategy is known and that Canary steps
+// send an increasing share of traffic to the new revision.
+func (rollout *AppSpecRollout) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch rollout.Strategy {
+	case RollingRolloutStrategy, BlueGreenRolloutStrategy, CanaryRolloutStrategy:
+		// Valid
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(rollout.Strategy, "strategy"))
+	}
+
+	if len(rollout.Steps) > 0 && rollout.Strategy != CanaryRolloutStrategy {
+		errs = errs.Also(&apis.FieldError{
+			Message: "steps can only be set for the Canary strategy",
+			Paths:   []string{"steps"},
+		})
+	}
+
+	var previous int32
+	for idx, step := range rollout.Steps {
+		switch {
+		case step <= 0 || step >= 100:
+			errs = errs.Also(apis.ErrOutOfBoundsValue(step, 1, 99, apis.CurrentField).ViaFieldIndex("steps", idx))
+		case step <= previous:
+			errs = errs.Also(apis.ErrInvalidValue(step, apis.CurrentField, "steps must be increasing").ViaFieldIndex("steps", idx))
+		}
+		previous = step
+	}
+
+	if rollout.PromoteRequests < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(rollout.PromoteRequests, "promoteRequests"))
+	}
+
+	if rollout.AbortRequests < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(rollout.AbortRequests, "abortRequests"))
 	}
 
 	return errs
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/kf/v2/pkg/apis/kf"
 	"github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
@@ -594,6 +595,44 @@ func TestAppAutoscalingRules_Validate(t *testing.T) {
 				Target:   ptr.Int32(80),
 			},
 		},
+		"memory target too large": {
+			spec: AppAutoscalingRule{
+				RuleType: MemoryRuleType,
+				Target:   ptr.Int32(101),
+			},
+			want: apis.ErrOutOfBoundsValue(101, 1, 100, "target"),
+		},
+		"valid memory": {
+			spec: AppAutoscalingRule{
+				RuleType: MemoryRuleType,
+				Target:   ptr.Int32(70),
+			},
+		},
+		"throughput target too small": {
+			spec: AppAutoscalingRule{
+				RuleType: HTTPThroughputRuleType,
+				Target:   ptr.Int32(0),
+			},
+			want: apis.ErrOutOfBoundsValue(0, 1, math.MaxInt32, "target"),
+		},
+		"valid throughput above 100": {
+			spec: AppAutoscalingRule{
+				RuleType: HTTPThroughputRuleType,
+				Target:   ptr.Int32(500),
+			},
+		},
+		"latency target is nil": {
+			spec: AppAutoscalingRule{
+				RuleType: HTTPLatencyRuleType,
+			},
+			want: apis.ErrMissingField("target"),
+		},
+		"valid latency": {
+			spec: AppAutoscalingRule{
+				RuleType: HTTPLatencyRuleType,
+				Target:   ptr.Int32(250),
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -604,6 +643,276 @@ func TestAppAutoscalingRules_Validate(t *testing.T) {
 	}
 }
 
+func TestAppAutoscalingSchedule_Validate(t *testing.T) {
+	recurring := func(mutate func(*AppAutoscalingSchedule)) AppAutoscalingSchedule {
+		schedule := AppAutoscalingSchedule{
+			Name:        "business-hours",
+			TimeZone:    "America/New_York",
+			DaysOfWeek:  []int32{1, 2, 3, 4, 5},
+			StartTime:   "08:00",
+			EndTime:     "18:00",
+			MinReplicas: ptr.Int32(5),
+			MaxReplicas: ptr.Int32(20),
+		}
+		mutate(&schedule)
+		return schedule
+	}
+
+	cases := map[string]struct {
+		spec AppAutoscalingSchedule
+		want *apis.FieldError
+	}{
+		"valid recurring": {
+			spec: recurring(func(*AppAutoscalingSchedule) {}),
+		},
+		"valid specific date": {
+			spec: AppAutoscalingSchedule{
+				Name:        "launch",
+				StartDate:   "2022-03-10",
+				StartTime:   "20:00",
+				EndDate:     "2022-03-11",
+				EndTime:     "02:00",
+				MinReplicas: ptr.Int32(10),
+				MaxReplicas: ptr.Int32(50),
+			},
+		},
+		"missing name": {
+			spec: recurring(func(s *AppAutoscalingSchedule) { s.Name = "" }),
+			want: apis.ErrMissingField("name"),
+		},
+		"invalid time zone": {
+			spec: recurring(func(s *AppAutoscalingSchedule) { s.TimeZone = "Mars/Olympus_Mons" }),
+			want: apis.ErrInvalidValue("Mars/Olympus_Mons", "timeZone", "unknown time zone Mars/Olympus_Mons"),
+		},
+		"invalid start time": {
+			spec: recurring(func(s *AppAutoscalingSchedule) { s.StartTime = "8am" }),
+			want: apis.ErrInvalidValue("8am", "startTime", "must be in HH:MM format"),
+		},
+		"end before start": {
+			spec: recurring(func(s *AppAutoscalingSchedule) { s.EndTime = "07:00" }),
+			want: apis.ErrInvalidValue("07:00", "endTime", "must be after startTime"),
+		},
+		"invalid day of week": {
+			spec: recurring(func(s *AppAutoscalingSchedule) { s.DaysOfWeek = []int32{0} }),
+			want: apis.ErrOutOfBoundsValue(0, 1, 7, apis.CurrentField).ViaFieldIndex("daysOfWeek", 0),
+		},
+		"days of week and month": {
+			spec: recurring(func(s *AppAutoscalingSchedule) { s.DaysOfMonth = []int32{1} }),
+			want: apis.ErrMultipleOneOf("daysOfWeek", "daysOfMonth"),
+		},
+		"end date before start date": {
+			spec: recurring(func(s *AppAutoscalingSchedule) {
+				s.StartDate = "2022-03-10"
+				s.EndDate = "2022-03-01"
+			}),
+			want: apis.ErrInvalidValue("2022-03-01", "endDate", "must not be before startDate"),
+		},
+		"specific date without dates": {
+			spec: recurring(func(s *AppAutoscalingSchedule) { s.DaysOfWeek = nil }),
+			want: apis.ErrMissingField("startDate", "endDate"),
+		},
+		"specific date ends before start": {
+			spec: recurring(func(s *AppAutoscalingSchedule) {
+				s.DaysOfWeek = nil
+				s.StartDate = "2022-03-10"
+				s.EndDate = "2022-03-10"
+				s.StartTime = "18:00"
+				s.EndTime = "08:00"
+			}),
+			want: apis.ErrInvalidValue("2022-03-10 08:00", "endDate", "must be after the start"),
+		},
+		"missing max": {
+			spec: recurring(func(s *AppAutoscalingSchedule) { s.MaxReplicas = nil }),
+			want: apis.ErrMissingField("maxReplicas"),
+		},
+		"min above max": {
+			spec: recurring(func(s *AppAutoscalingSchedule) { s.MinReplicas = ptr.Int32(30) }),
+			want: apis.ErrOutOfBoundsValue(30, 1, 20, "minReplicas"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.spec.Validate(context.Background())
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestAppSpecAutoscaling_Validate_duplicateSchedules(t *testing.T) {
+	schedule := AppAutoscalingSchedule{
+		Name:        "nights",
+		DaysOfWeek:  []int32{1},
+		StartTime:   "00:00",
+		EndTime:     "06:00",
+		MinReplicas: ptr.Int32(1),
+		MaxReplicas: ptr.Int32(2),
+	}
+
+	autoscaling := AppSpecAutoscaling{
+		Schedules: []AppAutoscalingSchedule{schedule, schedule},
+	}
+
+	want := apis.ErrGeneric("duplicate schedule name", "name").ViaFieldIndex("schedules", 1)
+	testutil.AssertEqual(t, "validation errors", want.Error(), autoscaling.Validate(context.Background()).Error())
+}
+
+func TestAppSpecRollout_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec AppSpecRollout
+		want *apis.FieldError
+	}{
+		"blank": {
+			spec: AppSpecRollout{},
+			want: apis.ErrInvalidValue("", "strategy"),
+		},
+		"unknown strategy": {
+			spec: AppSpecRollout{Strategy: "Recreate"},
+			want: apis.ErrInvalidValue("Recreate", "strategy"),
+		},
+		"valid blue-green": {
+			spec: AppSpecRollout{Strategy: BlueGreenRolloutStrategy},
+		},
+		"valid canary": {
+			spec: AppSpecRollout{
+				Strategy: CanaryRolloutStrategy,
+				Steps:    []int32{10, 50, 90},
+			},
+		},
+		"steps without canary": {
+			spec: AppSpecRollout{
+				Strategy: RollingRolloutStrategy,
+				Steps:    []int32{10},
+			},
+			want: &apis.FieldError{
+				Message: "steps can only be set for the Canary strategy",
+				Paths:   []string{"steps"},
+			},
+		},
+		"step out of bounds": {
+			spec: AppSpecRollout{
+				Strategy: CanaryRolloutStrategy,
+				Steps:    []int32{10, 100},
+			},
+			want: apis.ErrOutOfBoundsValue(100, 1, 99, "steps[1]"),
+		},
+		"steps not increasing": {
+			spec: AppSpecRollout{
+				Strategy: CanaryRolloutStrategy,
+				Steps:    []int32{50, 10},
+			},
+			want: apis.ErrInvalidValue(10, "steps[1]", "steps must be increasing"),
+		},
+		"negative requests": {
+			spec: AppSpecRollout{
+				Strategy:        BlueGreenRolloutStrategy,
+				PromoteRequests: -1,
+				AbortRequests:   -1,
+			},
+			want: apis.ErrInvalidValue(-1, "promoteRequests").Also(apis.ErrInvalidValue(-1, "abortRequests")),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.spec.Validate(context.Background())
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestAppSpecProcess_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec AppSpecProcess
+		want *apis.FieldError
+	}{
+		"valid": {
+			spec: AppSpecProcess{Type: "worker", Replicas: ptr.Int32(0)},
+		},
+		"missing type": {
+			spec: AppSpecProcess{},
+			want: apis.ErrMissingField("type"),
+		},
+		"web type": {
+			spec: AppSpecProcess{Type: WebProcessType},
+			want: apis.ErrInvalidValue("web", "type", "the web process is configured by the App's template"),
+		},
+		"invalid type": {
+			spec: AppSpecProcess{Type: "Worker"},
+			want: apis.ErrInvalidValue("Worker", "type", "a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+		},
+		"negative replicas": {
+			spec: AppSpecProcess{Type: "worker", Replicas: ptr.Int32(-1)},
+			want: apis.ErrInvalidValue(-1, "replicas"),
+		},
+		"sidecars": {
+			spec: AppSpecProcess{
+				Type:     "worker",
+				Sidecars: []corev1.Container{{Name: "metrics-agent"}, {Name: "log-shipper"}},
+			},
+		},
+		"sidecar missing name": {
+			spec: AppSpecProcess{
+				Type:     "worker",
+				Sidecars: []corev1.Container{{}},
+			},
+			want: apis.ErrMissingField("sidecars[0].name"),
+		},
+		"duplicate sidecar names": {
+			spec: AppSpecProcess{
+				Type:     "worker",
+				Sidecars: []corev1.Container{{Name: "metrics-agent"}, {Name: "metrics-agent"}},
+			},
+			want: kf.ErrDuplicateValue("metrics-agent", "sidecars[1].name"),
+		},
+		"sidecar with reserved name": {
+			spec: AppSpecProcess{
+				Type:     "worker",
+				Sidecars: []corev1.Container{{Name: DefaultUserContainerName}},
+			},
+			want: apis.ErrInvalidValue(DefaultUserContainerName, "sidecars[0].name", "the name is reserved for the App's container"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.spec.Validate(context.Background())
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestAppSpec_Validate_duplicateProcesses(t *testing.T) {
+	spec := AppSpec{
+		Build: AppSpecBuild{Image: ptr.String("gcr.io/my-app")},
+		Template: AppSpecTemplate{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{}}},
+		},
+		Processes: []AppSpecProcess{
+			{Type: "worker"},
+			{Type: "worker"},
+		},
+	}
+
+	want := apis.ErrGeneric("duplicate process type", "type").ViaFieldIndex("processes", 1)
+	testutil.AssertEqual(t, "validation errors", want.Error(), spec.Validate(context.Background()).Error())
+}
+
+func TestAppSpec_Validate_templateSidecars(t *testing.T) {
+	spec := AppSpec{
+		Build: AppSpecBuild{Image: ptr.String("gcr.io/my-app")},
+		Template: AppSpecTemplate{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{},
+				{Name: DefaultUserContainerName},
+			}},
+		},
+	}
+
+	want := apis.ErrInvalidValue(DefaultUserContainerName, "name", "the name is reserved for the App's container").ViaFieldIndex("template.spec.containers", 1)
+	testutil.AssertEqual(t, "validation errors", want.Error(), spec.Validate(context.Background()).Error())
+}
+
 func TestScale_Validate(t *testing.T) {
 	// These test cases are broken out separately because they're
 	// too extenstive to copy the whole service struct for.
@@ -0,0 +1,38 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+)
+
+// SetDefaults implements apis.Defaultable.
+func (p *AppNetworkPolicy) SetDefaults(ctx context.Context) {
+	p.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults implements apis.Defaultable.
+func (s *AppNetworkPolicySpec) SetDefaults(ctx context.Context) {
+	s.Protocol = strings.ToLower(strings.TrimSpace(s.Protocol))
+	if s.Protocol == "" {
+		s.Protocol = AppNetworkPolicyProtocolTCP
+	}
+
+	s.Ports = strings.ReplaceAll(s.Ports, " ", "")
+	if s.Ports == "" {
+		s.Ports = DefaultAppNetworkPolicyPorts
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/kmeta"
+)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (p *AppNetworkPolicy) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("AppNetworkPolicy")
+}
+
+var _ kmeta.OwnerRefable = (*AppNetworkPolicy)(nil)
+
+// SourceSpace returns the Space of the source App.
+func (p *AppNetworkPolicy) SourceSpace() string {
+	if p.Spec.Source.Space != "" {
+		return p.Spec.Source.Space
+	}
+
+	return p.Namespace
+}
+
+// PropagateNetworkPolicyStatus copies the name of the generated NetworkPolicy
+// into the status and marks it as ready.
+func (status *AppNetworkPolicyStatus) PropagateNetworkPolicyStatus(policy *networkingv1.NetworkPolicy) {
+	status.NetworkPolicyName = policy.Name
+
+	// NetworkPolicies don't have any status to propagate.
+	status.NetworkPolicyCondition().MarkSuccess()
+}
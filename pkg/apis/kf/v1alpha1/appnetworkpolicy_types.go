@@ -0,0 +1,105 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+const (
+	// AppNetworkPolicyProtocolTCP allows TCP traffic.
+	AppNetworkPolicyProtocolTCP = "tcp"
+	// AppNetworkPolicyProtocolUDP allows UDP traffic.
+	AppNetworkPolicyProtocolUDP = "udp"
+
+	// DefaultAppNetworkPolicyPorts is the port traffic is allowed on if none
+	// are specified, it matches the default port of Apps.
+	DefaultAppNetworkPolicyPorts = "8080"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AppNetworkPolicy allows an App to send traffic directly to another App over
+// the cluster network. It lives in the Space of the destination App. It's the
+// equivalent of a Cloud Foundry network policy.
+type AppNetworkPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec AppNetworkPolicySpec `json:"spec,omitempty"`
+
+	// +optional
+	Status AppNetworkPolicyStatus `json:"status,omitempty"`
+}
+
+var _ apis.Validatable = (*AppNetworkPolicy)(nil)
+var _ apis.Defaultable = (*AppNetworkPolicy)(nil)
+
+// AppNetworkPolicySpec contains the specification of an AppNetworkPolicy.
+type AppNetworkPolicySpec struct {
+	// Source is the App allowed to send traffic.
+	Source AppNetworkPolicySource `json:"source"`
+
+	// DestinationApp is the name of the App in the policy's Space that is
+	// allowed to receive traffic.
+	DestinationApp string `json:"destinationApp"`
+
+	// Protocol is the protocol traffic is allowed on, either tcp or udp.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// Ports is the port or range of ports traffic is allowed on e.g. 8080
+	// or 8000-9000.
+	// +optional
+	Ports string `json:"ports,omitempty"`
+}
+
+// AppNetworkPolicySource references the App allowed to send traffic.
+type AppNetworkPolicySource struct {
+	// Space is the Space of the source App. If blank, the policy's Space is
+	// used.
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// App is the name of the source App.
+	App string `json:"app"`
+}
+
+// AppNetworkPolicyStatus represents information about the status of an
+// AppNetworkPolicy.
+type AppNetworkPolicyStatus struct {
+	// Pull in the fields from Knative's duckv1beta1 status field.
+	duckv1beta1.Status `json:",inline"`
+
+	// NetworkPolicyName is the name of the Kubernetes NetworkPolicy
+	// generated for the policy.
+	// +optional
+	NetworkPolicyName string `json:"networkPolicyName,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AppNetworkPolicyList is a list of AppNetworkPolicy resources.
+type AppNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AppNetworkPolicy `json:"items"`
+}
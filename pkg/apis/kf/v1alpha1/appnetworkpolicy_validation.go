@@ -0,0 +1,64 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable.
+func (p *AppNetworkPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	// If we're specifically updating status, don't reject the change because
+	// of a spec issue.
+	if apis.IsInStatusUpdate(ctx) {
+		return
+	}
+
+	errs = errs.Also(apis.ValidateObjectMetadata(p.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(p.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (s *AppNetworkPolicySpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if s.Source.App == "" {
+		errs = errs.Also(apis.ErrMissingField("source.app"))
+	}
+
+	if s.DestinationApp == "" {
+		errs = errs.Also(apis.ErrMissingField("destinationApp"))
+	}
+
+	validProtocols := sets.NewString(
+		AppNetworkPolicyProtocolTCP,
+		AppNetworkPolicyProtocolUDP,
+	)
+
+	if !validProtocols.Has(s.Protocol) {
+		errs = errs.Also(ErrInvalidEnumValue(s.Protocol, "protocol", validProtocols.List()))
+	}
+
+	if s.Ports == "" {
+		errs = errs.Also(apis.ErrMissingField("ports"))
+	} else if _, err := ParsePortRanges(s.Ports); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(err.Error(), "ports"))
+	}
+
+	return errs
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestAppNetworkPolicy_Validate(t *testing.T) {
+	validSpec := func() AppNetworkPolicySpec {
+		return AppNetworkPolicySpec{
+			Source:         AppNetworkPolicySource{Space: "frontend", App: "web"},
+			DestinationApp: "api",
+			Protocol:       "tcp",
+			Ports:          "8080",
+		}
+	}
+
+	cases := map[string]struct {
+		mutate func(spec *AppNetworkPolicySpec)
+		want   *apis.FieldError
+	}{
+		"valid": {
+			mutate: func(spec *AppNetworkPolicySpec) {},
+		},
+		"port range": {
+			mutate: func(spec *AppNetworkPolicySpec) {
+				spec.Protocol = "udp"
+				spec.Ports = "8000-9000"
+			},
+		},
+		"missing apps": {
+			mutate: func(spec *AppNetworkPolicySpec) {
+				spec.Source.App = ""
+				spec.DestinationApp = ""
+			},
+			want: apis.ErrMissingField("spec.source.app", "spec.destinationApp"),
+		},
+		"invalid protocol": {
+			mutate: func(spec *AppNetworkPolicySpec) {
+				spec.Protocol = "icmp"
+			},
+			want: ErrInvalidEnumValue("icmp", "spec.protocol", []string{"tcp", "udp"}),
+		},
+		"invalid ports": {
+			mutate: func(spec *AppNetworkPolicySpec) {
+				spec.Ports = "9000-8000"
+			},
+			want: apis.ErrInvalidValue(`invalid port range "9000-8000": start is after end`, "spec.ports"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			policy := &AppNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-policy", Namespace: "backend"},
+				Spec:       validSpec(),
+			}
+			tc.mutate(&policy.Spec)
+
+			got := policy.Validate(context.Background())
+
+			testutil.AssertEqual(t, "errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestAppNetworkPolicy_SetDefaults(t *testing.T) {
+	policy := &AppNetworkPolicy{}
+	policy.Spec.Protocol = " UDP"
+	policy.SetDefaults(context.Background())
+
+	testutil.AssertEqual(t, "protocol", "udp", policy.Spec.Protocol)
+	testutil.AssertEqual(t, "ports", DefaultAppNetworkPolicyPorts, policy.Spec.Ports)
+}
+
+func TestAppNetworkPolicy_SourceSpace(t *testing.T) {
+	policy := &AppNetworkPolicy{}
+	policy.Namespace = "backend"
+	testutil.AssertEqual(t, "default", "backend", policy.SourceSpace())
+
+	policy.Spec.Source.Space = "frontend"
+	testutil.AssertEqual(t, "explicit", "frontend", policy.SourceSpace())
+}
@@ -17,7 +17,7 @@
 // +groupName=kf.dev
 
 //go:generate go run ../../../kf/internal/tools/conditiongen/generator.go --pkg v1alpha1 --status-type AppStatus --prefix App Build Service ServiceAccount Deployment Space Route EnvVarSecret ServiceInstanceBindings HorizontalPodAutoscaler
-//go:generate go run ../../../kf/internal/tools/conditiongen/generator.go --pkg v1alpha1 --status-type SpaceStatus --prefix Space Namespace BuildServiceAccount BuildSecret BuildRole BuildRoleBinding IngressGateway RuntimeConfig NetworkConfig BuildConfig BuildNetworkPolicy AppNetworkPolicy RoleBindings ClusterRole ClusterRoleBindings IAMPolicy
+//go:generate go run ../../../kf/internal/tools/conditiongen/generator.go --pkg v1alpha1 --status-type SpaceStatus --prefix Space Namespace BuildServiceAccount BuildSecret BuildRole BuildRoleBinding IngressGateway RuntimeConfig NetworkConfig BuildConfig BuildNetworkPolicy AppNetworkPolicy RoleBindings ClusterRole ClusterRoleBindings IAMPolicy Quota
 //go:generate go run ../../../kf/internal/tools/conditiongen/generator.go --pkg v1alpha1 --status-type BuildStatus --prefix Build --batch=true Space TaskRun SourcePackage
 //go:generate go run ../../../kf/internal/tools/conditiongen/generator.go --pkg v1alpha1 --status-type ServiceInstanceStatus --prefix ServiceInstance Space BackingResource ParamsSecret ParamsSecretPopulated
 //go:generate go run ../../../kf/internal/tools/conditiongen/generator.go --pkg v1alpha1 --status-type ServiceInstanceBindingStatus --prefix ServiceInstanceBinding ServiceInstance BackingResource ParamsSecret ParamsSecretPopulated CredentialsSecret VolumeParamsPopulated
@@ -30,5 +30,6 @@
 
 //go:generate go run ../../../kf/internal/tools/conditiongen/generator.go --pkg v1alpha1 --status-type SourcePackageStatus --prefix SourcePackage --batch=true Upload
 //go:generate go run ../../../kf/internal/tools/conditiongen/generator.go --pkg v1alpha1 --status-type TaskScheduleStatus --prefix TaskSchedule Space
+//go:generate go run ../../../kf/internal/tools/conditiongen/generator.go --pkg v1alpha1 --status-type AppNetworkPolicyStatus --prefix AppNetworkPolicy NetworkPolicy
 
 package v1alpha1
@@ -0,0 +1,32 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import "context"
+
+// SetDefaults implements apis.Defaultable.
+func (o *Org) SetDefaults(ctx context.Context) {
+	o.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults implements apis.Defaultable.
+func (o *OrgSpec) SetDefaults(ctx context.Context) {
+	o.Domains = StableDeduplicateSpaceDomainList(o.Domains)
+	for i := range o.Domains {
+		if o.Domains[i].GatewayName == "" {
+			o.Domains[i].GatewayName = KfExternalIngressGateway
+		}
+	}
+}
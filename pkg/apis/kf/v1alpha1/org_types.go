@@ -0,0 +1,72 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Org groups Spaces and holds settings shared by all of them. It's the
+// equivalent of a Cloud Foundry organization.
+type Org struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the settings applied to Spaces in the Org.
+	// +optional
+	Spec OrgSpec `json:"spec,omitempty"`
+}
+
+var _ apis.Validatable = (*Org)(nil)
+var _ apis.Defaultable = (*Org)(nil)
+
+// OrgSpec contains the settings applied to Spaces in the Org.
+type OrgSpec struct {
+	// Managers are granted the SpaceManager role in every Space in the Org.
+	// +optional
+	Managers []rbacv1.Subject `json:"managers,omitempty"`
+
+	// Auditors are granted the SpaceAuditor role in every Space in the Org.
+	// +optional
+	Auditors []rbacv1.Subject `json:"auditors,omitempty"`
+
+	// Domains are added to the domains of every Space in the Org after the
+	// Space's own domains.
+	// +optional
+	Domains []SpaceDomain `json:"domains,omitempty"`
+
+	// QuotaRef references the SpaceQuota applied to Spaces in the Org that
+	// don't reference one themselves.
+	// +optional
+	QuotaRef *corev1.LocalObjectReference `json:"quotaRef,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OrgList is a list of Org resources.
+type OrgList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Org `json:"items"`
+}
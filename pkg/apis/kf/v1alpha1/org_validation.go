@@ -0,0 +1,66 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable.
+func (o *Org) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(apis.ValidateObjectMetadata(o.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(o.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (o *OrgSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validateOrgSubjects(o.Managers).ViaField("managers"))
+	errs = errs.Also(validateOrgSubjects(o.Auditors).ViaField("auditors"))
+
+	// Org domains have the same requirements as Space domains.
+	networkConfig := SpaceSpecNetworkConfig{Domains: o.Domains}
+	errs = errs.Also(networkConfig.ValidateDomainGateways(ctx))
+
+	if o.QuotaRef != nil && o.QuotaRef.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("quotaRef.name"))
+	}
+
+	return errs
+}
+
+func validateOrgSubjects(subjects []rbacv1.Subject) (errs *apis.FieldError) {
+	for idx, subject := range subjects {
+		switch subject.Kind {
+		case rbacv1.UserKind, rbacv1.GroupKind, rbacv1.ServiceAccountKind:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(subject.Kind, "kind").ViaIndex(idx))
+		}
+
+		if subject.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(idx))
+		}
+
+		if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == "" {
+			errs = errs.Also(apis.ErrMissingField("namespace").ViaIndex(idx))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestOrg_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec OrgSpec
+		want *apis.FieldError
+	}{
+		"valid": {
+			spec: OrgSpec{
+				Managers: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice@example.com"}},
+				Auditors: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "auditors@example.com"}},
+				Domains:  []SpaceDomain{{Domain: "example.com", GatewayName: KfExternalIngressGateway}},
+			},
+		},
+		"invalid subject kind": {
+			spec: OrgSpec{
+				Managers: []rbacv1.Subject{{Kind: "Robot", Name: "r2d2"}},
+			},
+			want: apis.ErrInvalidValue("Robot", "spec.managers[0].kind"),
+		},
+		"service account without namespace": {
+			spec: OrgSpec{
+				Auditors: []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "ci"}},
+			},
+			want: apis.ErrMissingField("spec.auditors[0].namespace"),
+		},
+		"domain without gateway": {
+			spec: OrgSpec{
+				Domains: []SpaceDomain{{Domain: "example.com"}},
+			},
+			want: apis.ErrMissingField("spec.domains[0].gatewayName"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			org := &Org{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-org"},
+				Spec:       tc.spec,
+			}
+
+			got := org.Validate(context.Background())
+
+			testutil.AssertEqual(t, "errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestOrg_SetDefaults(t *testing.T) {
+	org := &Org{}
+	org.Spec.Domains = []SpaceDomain{
+		{Domain: "example.com"},
+		{Domain: "example.com"},
+		{Domain: "internal.example.com", GatewayName: "kf/internal-gateway"},
+	}
+
+	org.SetDefaults(context.Background())
+
+	testutil.AssertEqual(t, "domains", []SpaceDomain{
+		{Domain: "example.com", GatewayName: KfExternalIngressGateway},
+		{Domain: "internal.example.com", GatewayName: "kf/internal-gateway"},
+	}, org.Spec.Domains)
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PortRange is an inclusive range of ports.
+type PortRange struct {
+	Start int32
+	End   int32
+}
+
+// ParsePortRanges parses a comma separated list of ports and port ranges like
+// "80,443,8000-9000". A nil result means all ports are allowed.
+func ParsePortRanges(ports string) ([]PortRange, error) {
+	if ports == "" {
+		return nil, nil
+	}
+
+	var out []PortRange
+	for _, part := range strings.Split(ports, ",") {
+		startStr, endStr := part, part
+		if idx := strings.Index(part, "-"); idx >= 0 {
+			startStr, endStr = part[:idx], part[idx+1:]
+		}
+
+		start, err := parsePort(startStr)
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := parsePort(endStr)
+		if err != nil {
+			return nil, err
+		}
+
+		if start > end {
+			return nil, fmt.Errorf("invalid port range %q: start is after end", part)
+		}
+
+		out = append(out, PortRange{Start: start, End: end})
+	}
+
+	return out, nil
+}
+
+func parsePort(port string) (int32, error) {
+	val, err := strconv.ParseInt(port, 10, 32)
+	if err != nil || val < 1 || val > 65535 {
+		return 0, fmt.Errorf("invalid port %q: must be between 1 and 65535", port)
+	}
+
+	return int32(val), nil
+}
+
+// PortRangesContain returns true if the port falls within any of the ranges.
+func PortRangesContain(ranges []PortRange, port int32) bool {
+	for _, r := range ranges {
+		if port >= r.Start && port <= r.End {
+			return true
+		}
+	}
+
+	return false
+}
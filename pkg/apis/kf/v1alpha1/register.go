@@ -48,6 +48,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		SchemeGroupVersion,
 		&App{},
 		&AppList{},
+		&AppNetworkPolicy{},
+		&AppNetworkPolicyList{},
 		&Build{},
 		&BuildList{},
 		&ClusterServiceBroker{},
@@ -60,12 +62,18 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ServiceInstanceBindingList{},
 		&Space{},
 		&SpaceList{},
+		&SpaceQuota{},
+		&SpaceQuotaList{},
 		&Task{},
 		&TaskList{},
 		&TaskSchedule{},
 		&TaskScheduleList{},
+		&Org{},
+		&OrgList{},
 		&Route{},
 		&RouteList{},
+		&SecurityGroup{},
+		&SecurityGroupList{},
 		&SourcePackage{},
 		&SourcePackageList{},
 		&metav1.Status{},
@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	"context"
 	"path"
+	"strconv"
 )
 
 var defaultRouteWeight int32 = 1
@@ -74,6 +75,29 @@ func GenerateRouteName(hostname, domain, urlPath string) string {
 	return GenerateName(hostname, domain, path.Join("/", urlPath), "")
 }
 
+// GenerateRouteNameFromFields creates the deterministic name for a Route
+// from its RouteSpecFields. TCP routes are named after their domain and port
+// because they can't have a hostname or path.
+func GenerateRouteNameFromFields(fields RouteSpecFields) string {
+	if fields.IsTCP() {
+		return GenerateName(fields.Domain, strconv.Itoa(int(fields.Port)))
+	}
+
+	if fields.HasMatches() {
+		fields.normalizeMatches()
+		return GenerateName(
+			fields.Hostname,
+			fields.Domain,
+			path.Join("/", fields.Path),
+			fields.Method,
+			routeMatchesKey(fields.Headers),
+			routeMatchesKey(fields.QueryParams),
+		)
+	}
+
+	return GenerateRouteName(fields.Hostname, fields.Domain, fields.Path)
+}
+
 // SetDefaults implements apis.Defaultable
 func (k *RouteWeightBinding) SetDefaults(ctx context.Context) {
 	if k.Weight == nil {
@@ -90,6 +114,7 @@ func (k *RouteWeightBinding) SetDefaults(ctx context.Context) {
 // SetDefaults implements apis.Defaultable
 func (k *RouteSpecFields) SetDefaults(ctx context.Context) {
 	k.Path = path.Join("/", k.Path)
+	k.normalizeMatches()
 
 	if defaultDomain := getRouteDefaultDomain(ctx); k.Domain == "" && defaultDomain != nil {
 		k.Domain = *defaultDomain
@@ -116,3 +116,9 @@ func (status *RouteStatus) PropagateSpaceDomain(spaceDomain *SpaceDomain) {
 		status.SpaceDomainCondition().MarkSuccess()
 	}
 }
+
+// PropagateTLS sets the status of the certificate the Route is served with
+// over HTTPS. A nil certificate means the Route isn't served over HTTPS.
+func (status *RouteStatus) PropagateTLS(cert *CertificateStatus) {
+	status.TLS = cert
+}
@@ -0,0 +1,190 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/google/kf/v2/pkg/apis/kf"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// routeMatchMethods contains the HTTP methods a Route can match.
+var routeMatchMethods = []string{
+	http.MethodConnect,
+	http.MethodDelete,
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodPatch,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodTrace,
+}
+
+// RouteMatch is a condition a request must meet to match a Route.
+type RouteMatch struct {
+	// Name is the name of the header or query parameter.
+	Name string `json:"name"`
+
+	// Value is the exact value the header or query parameter must have.
+	Value string `json:"value"`
+}
+
+// String implements fmt.Stringer.
+func (m RouteMatch) String() string {
+	return m.Name + "=" + m.Value
+}
+
+// ParseRouteMatch parses a match in the form NAME=VALUE. Everything after
+// the first = is the value so it may contain = and commas. A match without a
+// = has an empty value, which validation rejects.
+func ParseRouteMatch(match string) RouteMatch {
+	parts := strings.SplitN(match, "=", 2)
+	out := RouteMatch{Name: parts[0]}
+	if len(parts) == 2 {
+		out.Value = parts[1]
+	}
+
+	return out
+}
+
+// normalizeRouteMatches returns a sorted copy of the matches with their
+// names converted by normalizeName so equivalent matches compare equal.
+func normalizeRouteMatches(matches []RouteMatch, normalizeName func(string) string) []RouteMatch {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	out := make([]RouteMatch, len(matches))
+	for i, m := range matches {
+		out[i] = RouteMatch{Name: normalizeName(m.Name), Value: m.Value}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Value < out[j].Value
+	})
+
+	return out
+}
+
+// headerName normalizes header names, which are case insensitive, to lower
+// case.
+func headerName(name string) string {
+	return strings.ToLower(name)
+}
+
+// queryParamName normalizes query parameter names, which are case
+// sensitive.
+func queryParamName(name string) string {
+	return name
+}
+
+// validateRouteMatches checks that each match has a valid name and a value
+// and that no name is matched twice.
+func validateRouteMatches(matches []RouteMatch, normalizeName func(string) string, validName func(string) bool) (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for i, m := range matches {
+		switch {
+		case m.Name == "":
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(i))
+		case !validName(m.Name):
+			errs = errs.Also(apis.ErrInvalidValue(m.Name, "name").ViaIndex(i))
+		case seen.Has(normalizeName(m.Name)):
+			errs = errs.Also(kf.ErrDuplicateValue(m.Name, "name").ViaIndex(i))
+		}
+		seen.Insert(normalizeName(m.Name))
+
+		if m.Value == "" {
+			errs = errs.Also(apis.ErrMissingField("value").ViaIndex(i))
+		}
+	}
+
+	return errs
+}
+
+// FormatRouteMatches converts matches into a human readable, comma separated
+// list.
+func FormatRouteMatches(matches []RouteMatch) string {
+	var parts []string
+	for _, m := range matches {
+		parts = append(parts, m.String())
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// routeMatchesKey converts matches into a canonical string that's used to
+// name Routes. Unlike FormatRouteMatches it escapes values so matches with
+// commas or = in them can't collide.
+func routeMatchesKey(matches []RouteMatch) string {
+	values := url.Values{}
+	for _, m := range matches {
+		values.Add(m.Name, m.Value)
+	}
+
+	return values.Encode()
+}
+
+// HasMatches returns true if the Route only matches requests with specific
+// headers, query parameters, or method.
+func (route RouteSpecFields) HasMatches() bool {
+	return len(route.Headers) > 0 || len(route.QueryParams) > 0 || route.Method != ""
+}
+
+// matchCount returns the number of conditions a request must meet to match
+// the Route beyond its host and path.
+func (route RouteSpecFields) matchCount() int {
+	count := len(route.Headers) + len(route.QueryParams)
+	if route.Method != "" {
+		count++
+	}
+
+	return count
+}
+
+// normalizeMatches converts the match fields into a canonical form so equal
+// Routes compare equal. The slices are replaced rather than modified so
+// copies of the RouteSpecFields aren't changed.
+func (route *RouteSpecFields) normalizeMatches() {
+	route.Headers = normalizeRouteMatches(route.Headers, headerName)
+	route.QueryParams = normalizeRouteMatches(route.QueryParams, queryParamName)
+	route.Method = strings.ToUpper(route.Method)
+}
+
+// matchString returns a human readable description of the match fields.
+func (route RouteSpecFields) matchString() string {
+	route.normalizeMatches()
+
+	var parts []string
+	if route.Method != "" {
+		parts = append(parts, "method: "+route.Method)
+	}
+	if len(route.Headers) > 0 {
+		parts = append(parts, "headers: "+FormatRouteMatches(route.Headers))
+	}
+	if len(route.QueryParams) > 0 {
+		parts = append(parts, "query: "+FormatRouteMatches(route.QueryParams))
+	}
+
+	return "(" + strings.Join(parts, "; ") + ")"
+}
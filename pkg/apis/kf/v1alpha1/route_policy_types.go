@@ -0,0 +1,144 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RateLimitUnitSecond limits the number of requests per second.
+	RateLimitUnitSecond = "second"
+
+	// RateLimitUnitMinute limits the number of requests per minute.
+	RateLimitUnitMinute = "minute"
+
+	// RateLimitUnitHour limits the number of requests per hour.
+	RateLimitUnitHour = "hour"
+)
+
+// RoutePolicy configures how the gateway handles requests for a Route.
+// Unset fields use the gateway's defaults.
+type RoutePolicy struct {
+	// Timeout is the maximum time a request can take, including retries.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retries configures how failed requests are retried.
+	// +optional
+	Retries *RouteRetryPolicy `json:"retries,omitempty"`
+
+	// CORS configures Cross-Origin Resource Sharing for the Route.
+	// +optional
+	CORS *RouteCORSPolicy `json:"cors,omitempty"`
+
+	// RateLimit limits the number of requests each gateway instance accepts
+	// for the Route.
+	// +optional
+	RateLimit *RouteRateLimit `json:"rateLimit,omitempty"`
+
+	// RequestHeaders modifies the headers of requests before they're sent to
+	// the App.
+	// +optional
+	RequestHeaders *RouteHeaderOperations `json:"requestHeaders,omitempty"`
+
+	// ResponseHeaders modifies the headers of responses before they're
+	// returned to the client.
+	// +optional
+	ResponseHeaders *RouteHeaderOperations `json:"responseHeaders,omitempty"`
+}
+
+// RouteRetryPolicy configures how failed requests are retried.
+type RouteRetryPolicy struct {
+	// Attempts is the number of times a request is retried.
+	Attempts int32 `json:"attempts"`
+
+	// PerTryTimeout is the maximum time each attempt can take.
+	// +optional
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+
+	// RetryOn is a comma separated list of conditions to retry on, using
+	// the values supported by Envoy's x-envoy-retry-on header e.g.
+	// "5xx,connect-failure".
+	// +optional
+	RetryOn string `json:"retryOn,omitempty"`
+}
+
+// RouteCORSPolicy configures Cross-Origin Resource Sharing.
+type RouteCORSPolicy struct {
+	// AllowOrigins is the list of origins allowed to make requests. An
+	// origin of "*" allows all origins.
+	AllowOrigins []string `json:"allowOrigins"`
+
+	// AllowMethods is the list of methods allowed for requests.
+	// +optional
+	AllowMethods []string `json:"allowMethods,omitempty"`
+
+	// AllowHeaders is the list of headers allowed in requests.
+	// +optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+
+	// ExposeHeaders is the list of response headers browsers can access.
+	// +optional
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+
+	// MaxAge is how long the results of a preflight request can be cached.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// AllowCredentials allows requests to include credentials.
+	// +optional
+	AllowCredentials bool `json:"allowCredentials,omitempty"`
+}
+
+// RouteRateLimit limits the rate of requests using a token bucket that's
+// refilled every Unit.
+type RouteRateLimit struct {
+	// Requests is the number of requests allowed every Unit.
+	Requests int32 `json:"requests"`
+
+	// Unit is the period requests are limited over, one of second, minute,
+	// or hour.
+	Unit string `json:"unit"`
+}
+
+// FillInterval returns the period the token bucket is refilled over.
+func (r *RouteRateLimit) FillInterval() time.Duration {
+	switch r.Unit {
+	case RateLimitUnitHour:
+		return time.Hour
+	case RateLimitUnitMinute:
+		return time.Minute
+	default:
+		return time.Second
+	}
+}
+
+// RouteHeaderOperations modifies the headers of a request or response.
+type RouteHeaderOperations struct {
+	// Set overwrites headers with the given values.
+	// +optional
+	Set map[string]string `json:"set,omitempty"`
+
+	// Add appends the given values to headers.
+	// +optional
+	Add map[string]string `json:"add,omitempty"`
+
+	// Remove deletes the given headers.
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/http/httpguts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable.
+func (p *RoutePolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validatePositiveDuration(p.Timeout, "timeout"))
+
+	if p.Retries != nil {
+		errs = errs.Also(p.Retries.Validate(ctx).ViaField("retries"))
+	}
+
+	if p.CORS != nil {
+		errs = errs.Also(p.CORS.Validate(ctx).ViaField("cors"))
+	}
+
+	if p.RateLimit != nil {
+		errs = errs.Also(p.RateLimit.Validate(ctx).ViaField("rateLimit"))
+	}
+
+	if p.RequestHeaders != nil {
+		errs = errs.Also(p.RequestHeaders.Validate(ctx).ViaField("requestHeaders"))
+	}
+
+	if p.ResponseHeaders != nil {
+		errs = errs.Also(p.ResponseHeaders.Validate(ctx).ViaField("responseHeaders"))
+	}
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (r *RouteRetryPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.Attempts < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.Attempts, "attempts", "must be non-negative"))
+	}
+
+	errs = errs.Also(validatePositiveDuration(r.PerTryTimeout, "perTryTimeout"))
+
+	if r.RetryOn != "" {
+		for _, condition := range strings.Split(r.RetryOn, ",") {
+			if strings.TrimSpace(condition) == "" {
+				errs = errs.Also(apis.ErrInvalidValue(r.RetryOn, "retryOn", "conditions must not be empty"))
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (c *RouteCORSPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(c.AllowOrigins) == 0 {
+		errs = errs.Also(apis.ErrMissingField("allowOrigins"))
+	}
+
+	for i, origin := range c.AllowOrigins {
+		if origin == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(origin, "allowOrigins", i))
+		}
+	}
+
+	for i, method := range c.AllowMethods {
+		if !sets.NewString(routeMatchMethods...).Has(strings.ToUpper(method)) {
+			errs = errs.Also(apis.ErrInvalidArrayValue(method, "allowMethods", i))
+		}
+	}
+
+	errs = errs.Also(validateHeaderNames(c.AllowHeaders, "allowHeaders"))
+	errs = errs.Also(validateHeaderNames(c.ExposeHeaders, "exposeHeaders"))
+	errs = errs.Also(validatePositiveDuration(c.MaxAge, "maxAge"))
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (r *RouteRateLimit) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.Requests <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.Requests, "requests", "must be positive"))
+	}
+
+	switch r.Unit {
+	case RateLimitUnitSecond, RateLimitUnitMinute, RateLimitUnitHour:
+		break
+	case "":
+		errs = errs.Also(apis.ErrMissingField("unit"))
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(r.Unit, "unit", fmt.Sprintf(
+			"must be one of %s, %s, or %s",
+			RateLimitUnitSecond,
+			RateLimitUnitMinute,
+			RateLimitUnitHour,
+		)))
+	}
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (h *RouteHeaderOperations) Validate(ctx context.Context) (errs *apis.FieldError) {
+	for name, value := range h.Set {
+		if !httpguts.ValidHeaderFieldName(name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, "set"))
+		} else if !httpguts.ValidHeaderFieldValue(value) {
+			errs = errs.Also(apis.ErrInvalidValue(value, fmt.Sprintf("set[%s]", name)))
+		}
+	}
+
+	for name, value := range h.Add {
+		if !httpguts.ValidHeaderFieldName(name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, "add"))
+		} else if !httpguts.ValidHeaderFieldValue(value) {
+			errs = errs.Also(apis.ErrInvalidValue(value, fmt.Sprintf("add[%s]", name)))
+		}
+	}
+
+	return errs.Also(validateHeaderNames(h.Remove, "remove"))
+}
+
+func validateHeaderNames(names []string, field string) (errs *apis.FieldError) {
+	for i, name := range names {
+		if !httpguts.ValidHeaderFieldName(name) {
+			errs = errs.Also(apis.ErrInvalidArrayValue(name, field, i))
+		}
+	}
+
+	return errs
+}
+
+func validatePositiveDuration(d *metav1.Duration, field string) *apis.FieldError {
+	if d != nil && d.Duration <= 0 {
+		return apis.ErrInvalidValue(d.Duration.String(), field, "must be positive")
+	}
+
+	return nil
+}
@@ -16,6 +16,8 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/url"
 	"path"
 	"reflect"
@@ -94,6 +96,26 @@ type RouteSpecFields struct {
 	// Path is the URL path of the route.
 	// +optional
 	Path string `json:"path,omitempty"`
+
+	// Port is the port the route listens on for TCP routes. It's only valid
+	// on domains with the tcp protocol, and Hostname and Path must be empty
+	// when it's set.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Headers restricts the route to requests with all of the given headers.
+	// Names are case insensitive and values must match exactly.
+	// +optional
+	Headers []RouteMatch `json:"headers,omitempty"`
+
+	// QueryParams restricts the route to requests with all of the given query
+	// parameters. Values must match exactly.
+	// +optional
+	QueryParams []RouteMatch `json:"queryParams,omitempty"`
+
+	// Method restricts the route to requests with the given HTTP method.
+	// +optional
+	Method string `json:"method,omitempty"`
 }
 
 // RouteWeightBinding contains the fields of a route.
@@ -227,10 +249,22 @@ func (rwb *RouteWeightBinding) Qualify(defaultDomain, serviceName string) (out Q
 
 // String returns a RouteSpecFields converted into an address.
 func (route RouteSpecFields) String() string {
-	if len(route.Path) == 0 || route.Path == "/" {
-		return route.Host()
+	if route.IsTCP() {
+		return fmt.Sprintf("%s:%d", route.Domain, route.Port)
 	}
-	return route.Host() + path.Join("/", route.Path)
+
+	address := route.Host()
+	if len(route.Path) != 0 && route.Path != "/" {
+		address += path.Join("/", route.Path)
+	}
+
+	// Routes with match rules share an address with the Route that handles
+	// the rest of the traffic.
+	if route.HasMatches() {
+		address += " " + route.matchString()
+	}
+
+	return address
 }
 
 // IsWildcard returns whether or not the route is a wildcard e.g. *.example.com.
@@ -238,6 +272,11 @@ func (route RouteSpecFields) IsWildcard() bool {
 	return route.Hostname == "*"
 }
 
+// IsTCP returns whether or not the route is a TCP route.
+func (route RouteSpecFields) IsTCP() bool {
+	return route.Port != 0
+}
+
 // Host returns the hostname concatenated with the domain.
 func (route RouteSpecFields) Host() string {
 	var hostnamePrefix string
@@ -247,6 +286,16 @@ func (route RouteSpecFields) Host() string {
 	return hostnamePrefix + route.Domain
 }
 
+// Key returns a string that uniquely identifies the route fields so they can
+// be used as a map key. RouteSpecFields can't be compared with == because
+// they hold lists of matches.
+func (route RouteSpecFields) Key() string {
+	// Marshaling can't fail because the fields are all strings, numbers, and
+	// lists of them.
+	out, _ := json.Marshal(route)
+	return string(out)
+}
+
 // Equals returns whether or not the route fields are equal to those of another route.
 func (route RouteSpecFields) Equals(cmpRoute RouteSpecFields) bool {
 	return route.String() == cmpRoute.String()
@@ -254,6 +303,12 @@ func (route RouteSpecFields) Equals(cmpRoute RouteSpecFields) bool {
 
 // ToURL creates a URL from the RouteSpecFields
 func (route RouteSpecFields) ToURL() url.URL {
+	if route.IsTCP() {
+		return url.URL{
+			Host: fmt.Sprintf("%s:%d", route.Host(), route.Port),
+		}
+	}
+
 	return url.URL{
 		Host: route.Host(),
 		Path: route.Path,
@@ -264,6 +319,16 @@ func (route RouteSpecFields) ToURL() url.URL {
 type RouteSpec struct {
 	// RouteSpecFields contains the fields of a route.
 	RouteSpecFields `json:",inline"`
+
+	// TLS configures a certificate for the Route's host that takes
+	// precedence over the domain's certificate.
+	// +optional
+	TLS *DomainTLS `json:"tls,omitempty"`
+
+	// Policy configures timeouts, retries, CORS, rate limits, and header
+	// rewrites for requests to the Route.
+	// +optional
+	Policy *RoutePolicy `json:"policy,omitempty"`
 }
 
 // RouteStatus is the current configuration for a Route.
@@ -285,6 +350,11 @@ type RouteStatus struct {
 
 	// RouteService is the Route Service instance bound to the route, if one exists.
 	RouteService corev1.LocalObjectReference `json:"routeService,omitempty"`
+
+	// TLS holds the state of the certificate the Route is served with, if
+	// the Route or its domain has TLS configured.
+	// +optional
+	TLS *CertificateStatus `json:"tls,omitempty"`
 }
 
 // RouteServiceBinding represents a binding between a route and a route service.
@@ -308,6 +378,16 @@ type RouteDestination struct {
 
 	// Weight is the proportion of traffic to send to this binding.
 	Weight int32 `json:"weight"` // always encode because zero is meaningful
+
+	// CandidateServiceName is the name of the service running a new revision
+	// of the destination during an App rollout.
+	// +optional
+	CandidateServiceName string `json:"candidateServiceName,omitempty"`
+
+	// CandidatePercent is the percentage of this destination's traffic sent to
+	// CandidateServiceName.
+	// +optional
+	CandidatePercent int32 `json:"candidatePercent,omitempty"`
 }
 
 // QualifiedRouteBinding contains a fully qualified route binding with
@@ -320,8 +400,12 @@ type QualifiedRouteBinding struct {
 	Destination RouteDestination `json:"destination"` // always encode because blank is meaningful
 }
 
-// Assert that QualifiedRouteBinding can be compared with ==
-var _ map[QualifiedRouteBinding]interface{}
+// Key returns a string that uniquely identifies the binding so it can be
+// used as a map key.
+func (qrb QualifiedRouteBinding) Key() string {
+	out, _ := json.Marshal(qrb)
+	return string(out)
+}
 
 // ToUnqualified converts the QualifiedRouteBinding back into an unqualified
 // one.
@@ -336,7 +420,7 @@ func (qrb *QualifiedRouteBinding) ToUnqualified() RouteWeightBinding {
 // MergableWith returns true if the binding has matching fields across the board
 // except weight.
 func (qrb *QualifiedRouteBinding) MergableWith(other QualifiedRouteBinding) bool {
-	return qrb.Source == other.Source &&
+	return qrb.Source.Key() == other.Source.Key() &&
 		qrb.Destination.Port == other.Destination.Port &&
 		qrb.Destination.ServiceName == other.Destination.ServiceName
 }
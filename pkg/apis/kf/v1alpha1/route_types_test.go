@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/google/kf/v2/pkg/kf/testutil"
@@ -57,6 +58,33 @@ func ExampleRouteSpecFields_String_without_path() {
 	// Output: foo.example.com
 }
 
+func ExampleRouteSpecFields_String_tcp() {
+	r := RouteSpecFields{
+		Domain: "tcp.example.com",
+		Path:   "/",
+		Port:   1024,
+	}
+
+	fmt.Println(r.String())
+
+	// Output: tcp.example.com:1024
+}
+
+func ExampleRouteSpecFields_String_matches() {
+	r := RouteSpecFields{
+		Hostname:    "foo",
+		Domain:      "example.com",
+		Path:        "/bar",
+		Headers:     []RouteMatch{{Name: "X-Env", Value: "beta"}, {Name: "X-Canary", Value: "true"}},
+		QueryParams: []RouteMatch{{Name: "version", Value: "2"}},
+		Method:      "get",
+	}
+
+	fmt.Println(r.String())
+
+	// Output: foo.example.com/bar (method: GET; headers: x-canary=true,x-env=beta; query: version=2)
+}
+
 func ExampleRouteSpecFields_IsWildcard() {
 	example := RouteSpecFields{Hostname: "example"}
 	fmt.Println("Example is wildcard:", example.IsWildcard())
@@ -101,6 +129,70 @@ func ExampleRouteSpecFields_ToURL() {
 	// Output: //foo.example.com/bar
 }
 
+func ExampleRouteSpecFields_ToURL_tcp() {
+	url := RouteSpecFields{
+		Domain: "tcp.example.com",
+		Port:   1024,
+	}.ToURL()
+
+	fmt.Println((&url).String())
+
+	// Output: //tcp.example.com:1024
+}
+
+func ExampleGenerateRouteNameFromFields() {
+	http := RouteSpecFields{Hostname: "foo", Domain: "example.com", Path: "/bar"}
+	fmt.Println("HTTP matches GenerateRouteName:", GenerateRouteNameFromFields(http) == GenerateRouteName("foo", "example.com", "/bar"))
+
+	tcp := RouteSpecFields{Domain: "tcp.example.com", Port: 1024}
+	fmt.Println("TCP:", GenerateRouteNameFromFields(tcp))
+
+	// Output: HTTP matches GenerateRouteName: true
+	// TCP: tcp-example-com-102449656522b08ad00970f92171e2732995
+}
+
+func ExampleGenerateRouteNameFromFields_matches() {
+	a := RouteSpecFields{Hostname: "foo", Domain: "example.com", Headers: []RouteMatch{{Name: "X-Canary", Value: "true"}, {Name: "X-Env", Value: "beta"}}}
+	b := RouteSpecFields{Hostname: "foo", Domain: "example.com", Path: "/", Headers: []RouteMatch{{Name: "x-env", Value: "beta"}, {Name: "x-canary", Value: "true"}}}
+	fmt.Println("Equivalent matches share a name:", GenerateRouteNameFromFields(a) == GenerateRouteNameFromFields(b))
+
+	plain := RouteSpecFields{Hostname: "foo", Domain: "example.com"}
+	fmt.Println("Matches change the name:", GenerateRouteNameFromFields(a) != GenerateRouteNameFromFields(plain))
+
+	comma := RouteSpecFields{Hostname: "foo", Domain: "example.com", Headers: []RouteMatch{{Name: "x-canary", Value: "true,x-env=beta"}}}
+	fmt.Println("Values with commas don't collide:", GenerateRouteNameFromFields(a) != GenerateRouteNameFromFields(comma))
+
+	// Output: Equivalent matches share a name: true
+	// Matches change the name: true
+	// Values with commas don't collide: true
+}
+
+func TestRouteSpecFieldsSlice_Less(t *testing.T) {
+	t.Parallel()
+
+	rsfs := RouteSpecFieldsSlice{
+		{Hostname: "foo", Domain: "example.com", Path: "/"},
+		{Hostname: "foo", Domain: "example.com", Path: "/", Headers: []RouteMatch{{Name: "x-canary", Value: "true"}}},
+		{Hostname: "foo", Domain: "example.com", Path: "/api"},
+		{Hostname: "foo", Domain: "example.com", Path: "/", Headers: []RouteMatch{{Name: "x-canary", Value: "true"}}, Method: "GET"},
+		{Hostname: "foo", Domain: "example.com", Path: "/", QueryParams: []RouteMatch{{Name: "beta", Value: "true"}}},
+	}
+	sort.Sort(rsfs)
+
+	var got []string
+	for _, rsf := range rsfs {
+		got = append(got, rsf.String())
+	}
+
+	testutil.AssertEqual(t, "order", []string{
+		"foo.example.com/api",
+		"foo.example.com (method: GET; headers: x-canary=true)",
+		"foo.example.com (headers: x-canary=true)",
+		"foo.example.com (query: beta=true)",
+		"foo.example.com",
+	}, got)
+}
+
 func TestRouteWeightBinding_Merge(t *testing.T) {
 	t.Parallel()
 
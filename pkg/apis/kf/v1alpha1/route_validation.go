@@ -16,9 +16,12 @@ package v1alpha1
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/kf/v2/pkg/apis/kf"
 	"github.com/gorilla/mux"
+	"golang.org/x/net/http/httpguts"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
@@ -99,6 +102,38 @@ func (r *RouteSpecFields) Validate(ctx context.Context) (errs *apis.FieldError)
 		errs = errs.Also(apis.ErrInvalidValue(r.Path, "path"))
 	}
 
+	if r.IsTCP() {
+		errs = errs.Also(kf.ValidatePortNumberBounds(r.Port, "port"))
+
+		if r.Hostname != "" {
+			errs = errs.Also(&apis.FieldError{
+				Message: "hostname can't be set on TCP routes",
+				Paths:   []string{"hostname"},
+			})
+		}
+
+		if r.Path != "" && r.Path != "/" {
+			errs = errs.Also(&apis.FieldError{
+				Message: "path can't be set on TCP routes",
+				Paths:   []string{"path"},
+			})
+		}
+
+		if r.HasMatches() {
+			errs = errs.Also(&apis.FieldError{
+				Message: "headers, queryParams, and method can't be set on TCP routes",
+				Paths:   []string{"headers", "queryParams", "method"},
+			})
+		}
+	}
+
+	errs = errs.Also(validateRouteMatches(r.Headers, headerName, httpguts.ValidHeaderFieldName).ViaField("headers"))
+	errs = errs.Also(validateRouteMatches(r.QueryParams, queryParamName, func(string) bool { return true }).ViaField("queryParams"))
+
+	if r.Method != "" && !sets.NewString(routeMatchMethods...).Has(strings.ToUpper(r.Method)) {
+		errs = errs.Also(apis.ErrInvalidValue(r.Method, "method"))
+	}
+
 	return errs
 }
 
@@ -119,7 +154,31 @@ func (r *Route) Validate(ctx context.Context) (errs *apis.FieldError) {
 // Validate validates a RouteSpec.
 func (r *RouteSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// don't include a ViaField because the field is embedded
-	return errs.Also(r.RouteSpecFields.Validate(ctx))
+	errs = errs.Also(r.RouteSpecFields.Validate(ctx))
+
+	if r.TLS != nil {
+		if r.IsTCP() {
+			errs = errs.Also(&apis.FieldError{
+				Message: "tls can't be set on TCP routes",
+				Paths:   []string{"tls"},
+			})
+		}
+
+		errs = errs.Also(r.TLS.Validate(ctx).ViaField("tls"))
+	}
+
+	if r.Policy != nil {
+		if r.IsTCP() {
+			errs = errs.Also(&apis.FieldError{
+				Message: "policy can't be set on TCP routes",
+				Paths:   []string{"policy"},
+			})
+		}
+
+		errs = errs.Also(r.Policy.Validate(ctx).ViaField("policy"))
+	}
+
+	return errs
 }
 
 // BuildPathRegexp uses gorilla/mux to convert a path into regular expression
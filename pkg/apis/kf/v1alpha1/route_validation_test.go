@@ -18,7 +18,9 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/kf/v2/pkg/apis/kf"
 	"github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
 	"github.com/google/kf/v2/pkg/client/networking/clientset/versioned/typed/networking/v1alpha3/fake"
 	"github.com/google/kf/v2/pkg/kf/testutil"
@@ -149,6 +151,217 @@ func TestRouteValidation(t *testing.T) {
 			},
 			want: nil,
 		},
+		"tcp route": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Path:   "/",
+						Port:   1024,
+					},
+				},
+			},
+			want: nil,
+		},
+		"tcp route with hostname and path": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "host",
+						Domain:   "tcp.example.com",
+						Path:     "/myapp",
+						Port:     1024,
+					},
+				},
+			},
+			want: (&apis.FieldError{
+				Message: "hostname can't be set on TCP routes",
+				Paths:   []string{"spec.hostname"},
+			}).Also(&apis.FieldError{
+				Message: "path can't be set on TCP routes",
+				Paths:   []string{"spec.path"},
+			}),
+		},
+		"tls route": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: goodRouteSpec.RouteSpecFields,
+					TLS:             &DomainTLS{IssuerName: "letsencrypt"},
+				},
+			},
+		},
+		"tls on tcp route": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+					TLS: &DomainTLS{SecretName: "some-cert"},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "tls can't be set on TCP routes",
+				Paths:   []string{"spec.tls"},
+			},
+		},
+		"bad tls": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: goodRouteSpec.RouteSpecFields,
+					TLS: &DomainTLS{
+						SecretName: "Not_Valid",
+						IssuerName: "letsencrypt",
+					},
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.tls.secretName", "spec.tls.issuerName").Also(&apis.FieldError{
+				Message: "Invalid secretName",
+				Details: "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+				Paths:   []string{"spec.tls.secretName"},
+			}),
+		},
+		"route with matches": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname:    "host",
+						Domain:      "example.com",
+						Headers:     []RouteMatch{{Name: "X-Canary", Value: "true"}, {Name: "Accept", Value: "text/html,application/json"}},
+						QueryParams: []RouteMatch{{Name: "version", Value: "2"}},
+						Method:      "post",
+					},
+				},
+			},
+			want: nil,
+		},
+		"route with bad matches": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname:    "host",
+						Domain:      "example.com",
+						Headers:     []RouteMatch{{Name: "X Canary", Value: "true"}, {Name: "X-Env"}},
+						QueryParams: []RouteMatch{{Name: "version", Value: "2"}, {Name: "version", Value: "3"}},
+						Method:      "FETCH",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("X Canary", "spec.headers[0].name").
+				Also(apis.ErrMissingField("spec.headers[1].value")).
+				Also(kf.ErrDuplicateValue("version", "spec.queryParams[1].name")).
+				Also(apis.ErrInvalidValue("FETCH", "spec.method")),
+		},
+		"tcp route with matches": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain:  "tcp.example.com",
+						Port:    1024,
+						Headers: []RouteMatch{{Name: "x-canary", Value: "true"}},
+					},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "headers, queryParams, and method can't be set on TCP routes",
+				Paths:   []string{"spec.headers", "spec.queryParams", "spec.method"},
+			},
+		},
+		"route with policy": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "host",
+						Domain:   "example.com",
+					},
+					Policy: &RoutePolicy{
+						Timeout: &metav1.Duration{Duration: 30 * time.Second},
+						Retries: &RouteRetryPolicy{
+							Attempts:      3,
+							PerTryTimeout: &metav1.Duration{Duration: 10 * time.Second},
+							RetryOn:       "5xx,connect-failure",
+						},
+						CORS: &RouteCORSPolicy{
+							AllowOrigins: []string{"https://example.com"},
+							AllowMethods: []string{"GET", "post"},
+							AllowHeaders: []string{"Authorization"},
+						},
+						RateLimit: &RouteRateLimit{Requests: 100, Unit: RateLimitUnitMinute},
+						RequestHeaders: &RouteHeaderOperations{
+							Set:    map[string]string{"X-Forwarded-Prefix": "/api"},
+							Remove: []string{"Cookie"},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		"route with bad policy": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "host",
+						Domain:   "example.com",
+					},
+					Policy: &RoutePolicy{
+						Timeout:   &metav1.Duration{Duration: -1 * time.Second},
+						Retries:   &RouteRetryPolicy{Attempts: -1},
+						CORS:      &RouteCORSPolicy{AllowMethods: []string{"FETCH"}},
+						RateLimit: &RouteRateLimit{Requests: 0, Unit: "day"},
+						ResponseHeaders: &RouteHeaderOperations{
+							Remove: []string{"Bad Header"},
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("-1s", "spec.policy.timeout", "must be positive").
+				Also(apis.ErrInvalidValue(-1, "spec.policy.retries.attempts", "must be non-negative")).
+				Also(apis.ErrMissingField("spec.policy.cors.allowOrigins")).
+				Also(apis.ErrInvalidArrayValue("FETCH", "spec.policy.cors.allowMethods", 0)).
+				Also(apis.ErrInvalidValue(0, "spec.policy.rateLimit.requests", "must be positive")).
+				Also(apis.ErrInvalidValue("day", "spec.policy.rateLimit.unit", "must be one of second, minute, or hour")).
+				Also(apis.ErrInvalidArrayValue("Bad Header", "spec.policy.responseHeaders.remove", 0)),
+		},
+		"tcp route with policy": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+					Policy: &RoutePolicy{
+						Timeout: &metav1.Duration{Duration: time.Second},
+					},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "policy can't be set on TCP routes",
+				Paths:   []string{"spec.policy"},
+			},
+		},
+		"tcp route port out of range": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   70000,
+					},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(70000, 1, 65535, "spec.port"),
+		},
 	}
 
 	for tn, tc := range cases {
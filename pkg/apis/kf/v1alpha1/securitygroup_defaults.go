@@ -0,0 +1,39 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+)
+
+// SetDefaults implements apis.Defaultable.
+func (s *SecurityGroup) SetDefaults(ctx context.Context) {
+	s.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults implements apis.Defaultable.
+func (s *SecurityGroupSpec) SetDefaults(ctx context.Context) {
+	for i := range s.Rules {
+		s.Rules[i].SetDefaults(ctx)
+	}
+}
+
+// SetDefaults implements apis.Defaultable.
+func (r *SecurityGroupRule) SetDefaults(ctx context.Context) {
+	r.Protocol = strings.ToLower(strings.TrimSpace(r.Protocol))
+	r.Destination = strings.TrimSpace(r.Destination)
+	r.Ports = strings.ReplaceAll(r.Ports, " ", "")
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net"
+	"strings"
+)
+
+// ParsePorts returns the port ranges the rule allows. A nil result means all
+// ports are allowed.
+func (r *SecurityGroupRule) ParsePorts() ([]PortRange, error) {
+	return ParsePortRanges(r.Ports)
+}
+
+// DestinationCIDRs returns the destination of the rule as a list of CIDR
+// blocks. IPv4 ranges are split into the smallest list of blocks that cover
+// them.
+func (r *SecurityGroupRule) DestinationCIDRs() ([]string, error) {
+	dest := r.Destination
+
+	if _, ipNet, err := net.ParseCIDR(dest); err == nil {
+		return []string{ipNet.String()}, nil
+	}
+
+	if ip := net.ParseIP(dest); ip != nil {
+		if ip.To4() != nil {
+			return []string{ip.String() + "/32"}, nil
+		}
+		return []string{ip.String() + "/128"}, nil
+	}
+
+	if idx := strings.Index(dest, "-"); idx >= 0 {
+		start := net.ParseIP(dest[:idx]).To4()
+		end := net.ParseIP(dest[idx+1:]).To4()
+		if start == nil || end == nil {
+			return nil, fmt.Errorf("invalid destination %q: ranges must be between two IPv4 addresses", dest)
+		}
+
+		cidrs, err := ipv4RangeToCIDRs(binary.BigEndian.Uint32(start), binary.BigEndian.Uint32(end))
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination %q: %v", dest, err)
+		}
+		return cidrs, nil
+	}
+
+	return nil, fmt.Errorf("invalid destination %q: must be an IP address, CIDR block, or IPv4 range", dest)
+}
+
+func ipv4RangeToCIDRs(start, end uint32) ([]string, error) {
+	if start > end {
+		return nil, fmt.Errorf("start is after end")
+	}
+
+	var cidrs []string
+	for current := uint64(start); current <= uint64(end); {
+		// Find the largest block aligned at current that doesn't pass end.
+		hostBits := bits.TrailingZeros32(uint32(current))
+		if current == 0 {
+			hostBits = 32
+		}
+		for current+(uint64(1)<<hostBits)-1 > uint64(end) {
+			hostBits--
+		}
+
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, uint32(current))
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", ip, 32-hostBits))
+
+		current += uint64(1) << hostBits
+	}
+
+	return cidrs, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// SecurityGroupProtocolTCP matches TCP traffic.
+	SecurityGroupProtocolTCP = "tcp"
+	// SecurityGroupProtocolUDP matches UDP traffic.
+	SecurityGroupProtocolUDP = "udp"
+	// SecurityGroupProtocolAll matches traffic of any protocol.
+	SecurityGroupProtocolAll = "all"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SecurityGroup is a named set of egress rules that can be bound to many
+// Spaces. It's the equivalent of a Cloud Foundry application security group.
+type SecurityGroup struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the rules of the SecurityGroup.
+	// +optional
+	Spec SecurityGroupSpec `json:"spec,omitempty"`
+}
+
+var _ apis.Validatable = (*SecurityGroup)(nil)
+var _ apis.Defaultable = (*SecurityGroup)(nil)
+
+// SecurityGroupSpec holds the egress rules of a SecurityGroup.
+type SecurityGroupSpec struct {
+	// Rules allow outbound traffic from workloads in Spaces the SecurityGroup
+	// is bound to.
+	// +optional
+	Rules []SecurityGroupRule `json:"rules,omitempty"`
+}
+
+// SecurityGroupRule allows outbound traffic to a set of destinations. The
+// fields match the rules of a Cloud Foundry application security group.
+type SecurityGroupRule struct {
+	// Protocol is the protocol the rule applies to, one of tcp, udp, or all.
+	Protocol string `json:"protocol"`
+
+	// Destination is a single IP address, a CIDR block, or a range of IPv4
+	// addresses separated by a dash e.g. 10.0.0.1-10.0.0.20.
+	Destination string `json:"destination"`
+
+	// Ports is a single port, a comma separated list of ports, or a range of
+	// ports separated by a dash e.g. 8000-9000. All ports are allowed if
+	// blank. Ports can't be set if the protocol is all.
+	// +optional
+	Ports string `json:"ports,omitempty"`
+
+	// Description describes the purpose of the rule.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SecurityGroupList is a list of SecurityGroup resources.
+type SecurityGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SecurityGroup `json:"items"`
+}
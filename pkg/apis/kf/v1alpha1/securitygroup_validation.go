@@ -0,0 +1,71 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable.
+func (s *SecurityGroup) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(apis.ValidateObjectMetadata(s.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(s.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (s *SecurityGroupSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	for idx := range s.Rules {
+		errs = errs.Also(s.Rules[idx].Validate(ctx).ViaFieldIndex("rules", idx))
+	}
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (r *SecurityGroupRule) Validate(ctx context.Context) (errs *apis.FieldError) {
+	validProtocols := sets.NewString(
+		SecurityGroupProtocolTCP,
+		SecurityGroupProtocolUDP,
+		SecurityGroupProtocolAll,
+	)
+
+	if !validProtocols.Has(r.Protocol) {
+		errs = errs.Also(ErrInvalidEnumValue(r.Protocol, "protocol", validProtocols.List()))
+	}
+
+	if r.Destination == "" {
+		errs = errs.Also(apis.ErrMissingField("destination"))
+	} else if _, err := r.DestinationCIDRs(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(err.Error(), "destination"))
+	}
+
+	if r.Ports != "" {
+		if r.Protocol == SecurityGroupProtocolAll {
+			errs = errs.Also(&apis.FieldError{
+				Message: "ports can't be set when protocol is all",
+				Paths:   []string{"ports"},
+			})
+		} else if _, err := r.ParsePorts(); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), "ports"))
+		}
+	}
+
+	return errs
+}
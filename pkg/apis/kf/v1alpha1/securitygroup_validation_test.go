@@ -0,0 +1,181 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestSecurityGroup_Validate(t *testing.T) {
+	cases := map[string]struct {
+		rule SecurityGroupRule
+		want *apis.FieldError
+	}{
+		"valid": {
+			rule: SecurityGroupRule{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "80,443"},
+		},
+		"all protocols": {
+			rule: SecurityGroupRule{Protocol: "all", Destination: "10.0.0.1-10.0.0.20"},
+		},
+		"invalid protocol": {
+			rule: SecurityGroupRule{Protocol: "icmp", Destination: "10.0.0.1"},
+			want: ErrInvalidEnumValue("icmp", "spec.rules[0].protocol", []string{"all", "tcp", "udp"}),
+		},
+		"missing destination": {
+			rule: SecurityGroupRule{Protocol: "tcp"},
+			want: apis.ErrMissingField("spec.rules[0].destination"),
+		},
+		"invalid destination": {
+			rule: SecurityGroupRule{Protocol: "tcp", Destination: "example.com"},
+			want: apis.ErrInvalidValue(`invalid destination "example.com": must be an IP address, CIDR block, or IPv4 range`, "spec.rules[0].destination"),
+		},
+		"ports with all protocols": {
+			rule: SecurityGroupRule{Protocol: "all", Destination: "10.0.0.1", Ports: "80"},
+			want: &apis.FieldError{
+				Message: "ports can't be set when protocol is all",
+				Paths:   []string{"spec.rules[0].ports"},
+			},
+		},
+		"invalid ports": {
+			rule: SecurityGroupRule{Protocol: "udp", Destination: "10.0.0.1", Ports: "0-53"},
+			want: apis.ErrInvalidValue(`invalid port "0": must be between 1 and 65535`, "spec.rules[0].ports"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			sg := &SecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-group"},
+				Spec:       SecurityGroupSpec{Rules: []SecurityGroupRule{tc.rule}},
+			}
+
+			got := sg.Validate(context.Background())
+
+			testutil.AssertEqual(t, "errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestSecurityGroupRule_SetDefaults(t *testing.T) {
+	rule := &SecurityGroupRule{Protocol: " TCP", Destination: "10.0.0.1 ", Ports: "80, 443"}
+	rule.SetDefaults(context.Background())
+
+	testutil.AssertEqual(t, "rule", &SecurityGroupRule{
+		Protocol:    "tcp",
+		Destination: "10.0.0.1",
+		Ports:       "80,443",
+	}, rule)
+}
+
+func TestSecurityGroupRule_ParsePorts(t *testing.T) {
+	cases := map[string]struct {
+		ports   string
+		want    []PortRange
+		wantErr error
+	}{
+		"blank": {
+			ports: "",
+			want:  nil,
+		},
+		"single": {
+			ports: "443",
+			want:  []PortRange{{Start: 443, End: 443}},
+		},
+		"list and range": {
+			ports: "80,8000-9000",
+			want: []PortRange{
+				{Start: 80, End: 80},
+				{Start: 8000, End: 9000},
+			},
+		},
+		"backwards range": {
+			ports:   "9000-8000",
+			wantErr: errors.New(`invalid port range "9000-8000": start is after end`),
+		},
+		"not a number": {
+			ports:   "http",
+			wantErr: errors.New(`invalid port "http": must be between 1 and 65535`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			rule := &SecurityGroupRule{Ports: tc.ports}
+
+			got, err := rule.ParsePorts()
+
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "ports", tc.want, got)
+		})
+	}
+}
+
+func TestSecurityGroupRule_DestinationCIDRs(t *testing.T) {
+	cases := map[string]struct {
+		destination string
+		want        []string
+		wantErr     error
+	}{
+		"ipv4": {
+			destination: "10.0.0.1",
+			want:        []string{"10.0.0.1/32"},
+		},
+		"ipv6": {
+			destination: "2001:db8::1",
+			want:        []string{"2001:db8::1/128"},
+		},
+		"cidr is normalized": {
+			destination: "10.1.2.3/8",
+			want:        []string{"10.0.0.0/8"},
+		},
+		"aligned range": {
+			destination: "10.0.0.0-10.0.0.255",
+			want:        []string{"10.0.0.0/24"},
+		},
+		"unaligned range": {
+			destination: "10.0.0.1-10.0.0.6",
+			want:        []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"},
+		},
+		"whole address space": {
+			destination: "0.0.0.0-255.255.255.255",
+			want:        []string{"0.0.0.0/0"},
+		},
+		"backwards range": {
+			destination: "10.0.0.6-10.0.0.1",
+			wantErr:     errors.New(`invalid destination "10.0.0.6-10.0.0.1": start is after end`),
+		},
+		"ipv6 range": {
+			destination: "2001:db8::1-2001:db8::2",
+			wantErr:     errors.New(`invalid destination "2001:db8::1-2001:db8::2": ranges must be between two IPv4 addresses`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			rule := &SecurityGroupRule{Destination: tc.destination}
+
+			got, err := rule.DestinationCIDRs()
+
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "cidrs", tc.want, got)
+		})
+	}
+}
@@ -15,6 +15,9 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -25,6 +28,10 @@ import (
 
 const (
 	VolumeBrokerKind = "VolumeBroker"
+
+	// DefaultCatalogRefreshInterval is the default interval the catalog of a
+	// broker is re-fetched at.
+	DefaultCatalogRefreshInterval = 24 * time.Hour
 )
 
 // CommonServiceBroker is an interface common to cluster and namespaced brokers.
@@ -40,6 +47,9 @@ type CommonServiceBroker interface {
 	// GetCredentialsSecretRef gets the Secret reference for the connection
 	// credentials.
 	GetCredentialsSecretRef() NamespacedObjectReference
+	// IsPlanVisible returns true if the plan of the service offering can be
+	// seen and provisioned in the Space.
+	IsPlanVisible(serviceName, planName, space string) bool
 }
 
 // +genclient
@@ -83,6 +93,12 @@ func (sb *ServiceBroker) GetCredentialsSecretRef() NamespacedObjectReference {
 	}
 }
 
+// IsPlanVisible implements CommonServiceBroker. Plans of a ServiceBroker are
+// visible in the Space the broker is in.
+func (sb *ServiceBroker) IsPlanVisible(serviceName, planName, space string) bool {
+	return sb.Namespace == space
+}
+
 // ServiceBrokerSpec contains the user supplied specification for the broker.
 type ServiceBrokerSpec struct {
 	CommonServiceBrokerSpec `json:",inline"`
@@ -144,6 +160,11 @@ func (sb *ClusterServiceBroker) GetCredentialsSecretRef() NamespacedObjectRefere
 	}
 }
 
+// IsPlanVisible implements CommonServiceBroker.
+func (sb *ClusterServiceBroker) IsPlanVisible(serviceName, planName, space string) bool {
+	return sb.Spec.IsPlanVisible(serviceName, planName, space)
+}
+
 // ClusterServiceBrokerSpec contains the user supplied specification for the broker.
 type ClusterServiceBrokerSpec struct {
 	CommonServiceBrokerSpec `json:",inline"`
@@ -152,6 +173,107 @@ type ClusterServiceBrokerSpec struct {
 	// for the service.
 	// +optional
 	Credentials NamespacedObjectReference `json:"credentials"`
+
+	// PlanVisibilities limits the Spaces that plans can be seen and
+	// provisioned in. Plans without an entry are visible in all Spaces.
+	// +optional
+	PlanVisibilities []ServicePlanVisibility `json:"planVisibilities,omitempty"`
+}
+
+// ServicePlanVisibility limits the Spaces a plan is visible in.
+type ServicePlanVisibility struct {
+	// ServiceName is the DisplayName of the ServiceOffering the plan belongs
+	// to.
+	ServiceName string `json:"serviceName"`
+
+	// PlanName is the DisplayName of the ServicePlan.
+	PlanName string `json:"planName"`
+
+	// Spaces contains the names of the Spaces the plan is visible in. If empty,
+	// the plan isn't visible in any Space.
+	// +optional
+	Spaces []string `json:"spaces,omitempty"`
+}
+
+// GetPlanVisibility returns the visibility for the plan or nil if the plan is
+// visible in all Spaces.
+func (spec *ClusterServiceBrokerSpec) GetPlanVisibility(serviceName, planName string) *ServicePlanVisibility {
+	for i, visibility := range spec.PlanVisibilities {
+		if visibility.ServiceName == serviceName && visibility.PlanName == planName {
+			return &spec.PlanVisibilities[i]
+		}
+	}
+
+	return nil
+}
+
+// IsPlanVisible returns true if the plan can be seen and provisioned in the
+// Space.
+func (spec *ClusterServiceBrokerSpec) IsPlanVisible(serviceName, planName, space string) bool {
+	visibility := spec.GetPlanVisibility(serviceName, planName)
+	if visibility == nil {
+		return true
+	}
+
+	for _, visibleSpace := range visibility.Spaces {
+		if visibleSpace == space {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EnablePlanAccess makes the plan visible in the Space. If space is blank, the
+// plan is made visible in all Spaces.
+func (spec *ClusterServiceBrokerSpec) EnablePlanAccess(serviceName, planName, space string) {
+	if space == "" {
+		var visibilities []ServicePlanVisibility
+		for _, visibility := range spec.PlanVisibilities {
+			if visibility.ServiceName == serviceName && visibility.PlanName == planName {
+				continue
+			}
+			visibilities = append(visibilities, visibility)
+		}
+		spec.PlanVisibilities = visibilities
+		return
+	}
+
+	if spec.IsPlanVisible(serviceName, planName, space) {
+		return
+	}
+
+	visibility := spec.GetPlanVisibility(serviceName, planName)
+	visibility.Spaces = append(visibility.Spaces, space)
+}
+
+// DisablePlanAccess hides the plan from the Space. If space is blank, the plan
+// is hidden from all Spaces. Access can't be removed for a single Space if the
+// plan is visible in all Spaces.
+func (spec *ClusterServiceBrokerSpec) DisablePlanAccess(serviceName, planName, space string) error {
+	visibility := spec.GetPlanVisibility(serviceName, planName)
+
+	switch {
+	case space == "" && visibility == nil:
+		spec.PlanVisibilities = append(spec.PlanVisibilities, ServicePlanVisibility{
+			ServiceName: serviceName,
+			PlanName:    planName,
+		})
+	case space == "":
+		visibility.Spaces = nil
+	case visibility == nil:
+		return fmt.Errorf("plan %s of service %s is visible in all Spaces, disable access for all Spaces before enabling it for specific ones", planName, serviceName)
+	default:
+		var spaces []string
+		for _, visibleSpace := range visibility.Spaces {
+			if visibleSpace != space {
+				spaces = append(spaces, visibleSpace)
+			}
+		}
+		visibility.Spaces = spaces
+	}
+
+	return nil
 }
 
 // NamespacedObjectReference is like corev1.LocalObjectReference but includes
@@ -173,9 +295,11 @@ type CommonServiceBrokerSpec struct {
 	// +optional
 	UpdateRequests int `json:"updateRequests"`
 
-	// Future additions may need to be made to enable operators to enable/disable
-	// services in particular namespaces; these fields should be inlined here
-	// a shared structure between ServiceBroker and ClusterServiceBroker.
+	// CatalogRefreshInterval is how often the catalog is fetched from the
+	// broker, a value of zero disables periodic refreshes. Defaults to
+	// DefaultCatalogRefreshInterval.
+	// +optional
+	CatalogRefreshInterval *metav1.Duration `json:"catalogRefreshInterval,omitempty"`
 
 	// VolumeBrokerSpec indicates this service broker is a VolumeBroker.
 	VolumeBrokerSpec *VolumeBrokerSpec `json:"volume,omitempty"`
@@ -189,10 +313,48 @@ type CommonServiceBrokerStatus struct {
 	// UpdateRequests is the last processed UpdateRequests value.
 	UpdateRequests int `json:"updateRequests"`
 
+	// LastCatalogRefreshTime is the last time the catalog was fetched from the
+	// broker.
+	// +optional
+	LastCatalogRefreshTime *metav1.Time `json:"lastCatalogRefreshTime,omitempty"`
+
 	// Services contains the list of services offered by the broker.
 	Services []ServiceOffering `json:"services,omitempty"`
 }
 
+// GetCatalogRefreshInterval returns how often the catalog is fetched from the
+// broker, zero if periodic refreshes are disabled.
+func (spec *CommonServiceBrokerSpec) GetCatalogRefreshInterval() time.Duration {
+	if spec.CatalogRefreshInterval == nil {
+		return DefaultCatalogRefreshInterval
+	}
+
+	return spec.CatalogRefreshInterval.Duration
+}
+
+// NeedsCatalogRefresh returns true if the catalog cached in the status should
+// be fetched from the broker.
+func (status *CommonServiceBrokerStatus) NeedsCatalogRefresh(spec *CommonServiceBrokerSpec, now time.Time) bool {
+	if spec.UpdateRequests != status.UpdateRequests || status.LastCatalogRefreshTime == nil {
+		return true
+	}
+
+	delay, ok := status.CatalogRefreshDelay(spec, now)
+	return ok && delay <= 0
+}
+
+// CatalogRefreshDelay returns how long until the next periodic refresh of the
+// catalog. It returns false if the catalog has never been fetched or periodic
+// refreshes are disabled.
+func (status *CommonServiceBrokerStatus) CatalogRefreshDelay(spec *CommonServiceBrokerSpec, now time.Time) (time.Duration, bool) {
+	interval := spec.GetCatalogRefreshInterval()
+	if interval <= 0 || status.LastCatalogRefreshTime == nil {
+		return 0, false
+	}
+
+	return status.LastCatalogRefreshTime.Add(interval).Sub(now), true
+}
+
 // ServiceOffering has just enough info to display the offering in
 // the marketplace command and provision it.
 type ServiceOffering struct {
@@ -209,6 +371,9 @@ type ServiceOffering struct {
 	// gcp, sql, myssql.
 	// +nullable
 	Tags []string `json:"tags,omitempty"`
+	// Shareable indicates that instances of the offering may be shared with
+	// other Spaces. The value comes from the offering's "shareable" metadata.
+	Shareable bool `json:"shareable,omitempty"`
 	// Plans contains a list of tiers that can be provisioned. For example,
 	// databases might come in different sizes.
 	Plans []ServicePlan `json:"plans,omitempty"`
@@ -229,6 +394,10 @@ type ServicePlan struct {
 	UID string `json:"uid"`
 	// Description is a human readable description of the plan.
 	Description string `json:"description"`
+	// PlanUpdatable indicates that instances of this plan may be updated to
+	// a different plan. The value is resolved from the plan, falling back to
+	// the service offering.
+	PlanUpdatable bool `json:"planUpdatable,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
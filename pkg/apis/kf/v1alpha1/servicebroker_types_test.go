@@ -0,0 +1,236 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterServiceBrokerSpec_IsPlanVisible(t *testing.T) {
+	t.Parallel()
+
+	spec := ClusterServiceBrokerSpec{
+		PlanVisibilities: []ServicePlanVisibility{
+			{ServiceName: "db", PlanName: "small", Spaces: []string{"dev", "test"}},
+			{ServiceName: "db", PlanName: "large"},
+		},
+	}
+
+	cases := map[string]struct {
+		serviceName string
+		planName    string
+		space       string
+		wantVisible bool
+	}{
+		"no visibility entry": {
+			serviceName: "db",
+			planName:    "medium",
+			space:       "prod",
+			wantVisible: true,
+		},
+		"space listed": {
+			serviceName: "db",
+			planName:    "small",
+			space:       "test",
+			wantVisible: true,
+		},
+		"space not listed": {
+			serviceName: "db",
+			planName:    "small",
+			space:       "prod",
+			wantVisible: false,
+		},
+		"disabled everywhere": {
+			serviceName: "db",
+			planName:    "large",
+			space:       "dev",
+			wantVisible: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gotVisible := spec.IsPlanVisible(tc.serviceName, tc.planName, tc.space)
+			testutil.AssertEqual(t, "visible", tc.wantVisible, gotVisible)
+		})
+	}
+}
+
+func TestClusterServiceBrokerSpec_EnablePlanAccess(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		visibilities []ServicePlanVisibility
+		space        string
+		want         []ServicePlanVisibility
+	}{
+		"enable everywhere removes entry": {
+			visibilities: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "small", Spaces: []string{"dev"}},
+				{ServiceName: "db", PlanName: "large"},
+			},
+			want: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "large"},
+			},
+		},
+		"enable for Space appends": {
+			visibilities: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "small", Spaces: []string{"dev"}},
+			},
+			space: "test",
+			want: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "small", Spaces: []string{"dev", "test"}},
+			},
+		},
+		"enable for Space already listed": {
+			visibilities: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "small", Spaces: []string{"dev"}},
+			},
+			space: "dev",
+			want: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "small", Spaces: []string{"dev"}},
+			},
+		},
+		"enable for Space on public plan": {
+			space: "dev",
+			want:  nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			spec := ClusterServiceBrokerSpec{PlanVisibilities: tc.visibilities}
+			spec.EnablePlanAccess("db", "small", tc.space)
+			testutil.AssertEqual(t, "visibilities", tc.want, spec.PlanVisibilities)
+		})
+	}
+}
+
+func TestClusterServiceBrokerSpec_DisablePlanAccess(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		visibilities []ServicePlanVisibility
+		space        string
+		want         []ServicePlanVisibility
+		wantErr      error
+	}{
+		"disable everywhere on public plan": {
+			want: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "small"},
+			},
+		},
+		"disable everywhere on limited plan": {
+			visibilities: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "small", Spaces: []string{"dev"}},
+			},
+			want: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "small"},
+			},
+		},
+		"disable for Space": {
+			visibilities: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "small", Spaces: []string{"dev", "test"}},
+			},
+			space: "dev",
+			want: []ServicePlanVisibility{
+				{ServiceName: "db", PlanName: "small", Spaces: []string{"test"}},
+			},
+		},
+		"disable for Space on public plan": {
+			space:   "dev",
+			wantErr: errors.New("plan small of service db is visible in all Spaces, disable access for all Spaces before enabling it for specific ones"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			spec := ClusterServiceBrokerSpec{PlanVisibilities: tc.visibilities}
+			err := spec.DisablePlanAccess("db", "small", tc.space)
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+			testutil.AssertEqual(t, "visibilities", tc.want, spec.PlanVisibilities)
+		})
+	}
+}
+
+func TestCommonServiceBrokerStatus_NeedsCatalogRefresh(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	refreshedAt := func(ago time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(-ago)}
+	}
+
+	cases := map[string]struct {
+		spec        CommonServiceBrokerSpec
+		status      CommonServiceBrokerStatus
+		wantRefresh bool
+		wantDelay   time.Duration
+		wantOk      bool
+	}{
+		"update requested": {
+			spec:        CommonServiceBrokerSpec{UpdateRequests: 2},
+			status:      CommonServiceBrokerStatus{UpdateRequests: 1, LastCatalogRefreshTime: refreshedAt(time.Minute)},
+			wantRefresh: true,
+			wantDelay:   DefaultCatalogRefreshInterval - time.Minute,
+			wantOk:      true,
+		},
+		"never refreshed": {
+			spec:        CommonServiceBrokerSpec{UpdateRequests: 1},
+			status:      CommonServiceBrokerStatus{UpdateRequests: 1},
+			wantRefresh: true,
+		},
+		"recently refreshed": {
+			spec:        CommonServiceBrokerSpec{UpdateRequests: 1},
+			status:      CommonServiceBrokerStatus{UpdateRequests: 1, LastCatalogRefreshTime: refreshedAt(time.Hour)},
+			wantRefresh: false,
+			wantDelay:   DefaultCatalogRefreshInterval - time.Hour,
+			wantOk:      true,
+		},
+		"refresh interval elapsed": {
+			spec: CommonServiceBrokerSpec{
+				UpdateRequests:         1,
+				CatalogRefreshInterval: &metav1.Duration{Duration: time.Hour},
+			},
+			status:      CommonServiceBrokerStatus{UpdateRequests: 1, LastCatalogRefreshTime: refreshedAt(2 * time.Hour)},
+			wantRefresh: true,
+			wantDelay:   -time.Hour,
+			wantOk:      true,
+		},
+		"periodic refresh disabled": {
+			spec: CommonServiceBrokerSpec{
+				UpdateRequests:         1,
+				CatalogRefreshInterval: &metav1.Duration{},
+			},
+			status:      CommonServiceBrokerStatus{UpdateRequests: 1, LastCatalogRefreshTime: refreshedAt(1000 * time.Hour)},
+			wantRefresh: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "refresh", tc.wantRefresh, tc.status.NeedsCatalogRefresh(&tc.spec, now))
+
+			gotDelay, gotOk := tc.status.CatalogRefreshDelay(&tc.spec, now)
+			testutil.AssertEqual(t, "delay", tc.wantDelay, gotDelay)
+			testutil.AssertEqual(t, "ok", tc.wantOk, gotOk)
+		})
+	}
+}
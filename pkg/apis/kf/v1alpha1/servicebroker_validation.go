@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
 
@@ -62,6 +63,7 @@ func (sb *ClusterServiceBroker) Validate(ctx context.Context) (errs *apis.FieldE
 // Validate implements apis.Validatable.
 func (spec *ClusterServiceBrokerSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(spec.CommonServiceBrokerSpec.Validate(ctx)) // no ViaField for embedded type
+	errs = errs.Also(spec.validatePlanVisibilities())
 
 	if spec.VolumeBrokerSpec != nil {
 		errs = errs.Also(spec.VolumeBrokerSpec.Validate(ctx))
@@ -81,9 +83,35 @@ func (spec *ClusterServiceBrokerSpec) Validate(ctx context.Context) (errs *apis.
 	return
 }
 
+// validatePlanVisibilities validates that each plan has at most one visibility
+// entry.
+func (spec *ClusterServiceBrokerSpec) validatePlanVisibilities() (errs *apis.FieldError) {
+	seen := sets.NewString()
+	for i, visibility := range spec.PlanVisibilities {
+		if visibility.ServiceName == "" {
+			errs = errs.Also(apis.ErrMissingField("serviceName").ViaFieldIndex("planVisibilities", i))
+		}
+
+		if visibility.PlanName == "" {
+			errs = errs.Also(apis.ErrMissingField("planName").ViaFieldIndex("planVisibilities", i))
+		}
+
+		key := visibility.ServiceName + "/" + visibility.PlanName
+		if seen.Has(key) {
+			errs = errs.Also(errDuplicateValue(key, "planVisibilities"))
+		}
+		seen.Insert(key)
+	}
+
+	return
+}
+
 // Validate implements apis.Validatable.
 func (sc *CommonServiceBrokerSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
-	// nothing to validate
+	if sc.CatalogRefreshInterval != nil && sc.CatalogRefreshInterval.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(sc.CatalogRefreshInterval.Duration, "catalogRefreshInterval"))
+	}
+
 	return
 }
 
@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
@@ -122,6 +123,51 @@ func TestClusterServiceBroker_Validate(t *testing.T) {
 			}()),
 			Want: apis.ErrMissingField("spec.credentials.namespace"),
 		},
+		"negative catalog refresh interval": {
+			Context: defaultContext(),
+			Input: (func() *ClusterServiceBroker {
+				tmp := validClusterBrokerInstance()
+				tmp.Spec.CatalogRefreshInterval = &metav1.Duration{Duration: -time.Hour}
+				return tmp
+			}()),
+			Want: apis.ErrInvalidValue(-time.Hour, "spec.catalogRefreshInterval"),
+		},
+		"valid plan visibilities": {
+			Context: defaultContext(),
+			Input: (func() *ClusterServiceBroker {
+				tmp := validClusterBrokerInstance()
+				tmp.Spec.PlanVisibilities = []ServicePlanVisibility{
+					{ServiceName: "db", PlanName: "small", Spaces: []string{"dev"}},
+					{ServiceName: "db", PlanName: "large"},
+				}
+				return tmp
+			}()),
+			Want: nil,
+		},
+		"plan visibility missing names": {
+			Context: defaultContext(),
+			Input: (func() *ClusterServiceBroker {
+				tmp := validClusterBrokerInstance()
+				tmp.Spec.PlanVisibilities = []ServicePlanVisibility{{}}
+				return tmp
+			}()),
+			Want: apis.ErrMissingField(
+				"spec.planVisibilities[0].planName",
+				"spec.planVisibilities[0].serviceName",
+			),
+		},
+		"duplicate plan visibilities": {
+			Context: defaultContext(),
+			Input: (func() *ClusterServiceBroker {
+				tmp := validClusterBrokerInstance()
+				tmp.Spec.PlanVisibilities = []ServicePlanVisibility{
+					{ServiceName: "db", PlanName: "small", Spaces: []string{"dev"}},
+					{ServiceName: "db", PlanName: "small"},
+				}
+				return tmp
+			}()),
+			Want: errDuplicateValue("db/small", "spec.planVisibilities"),
+		},
 	}
 
 	cases.Run(t)
@@ -22,6 +22,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
 	osbclient "sigs.k8s.io/go-open-service-broker-client/v2"
 )
 
@@ -373,6 +374,113 @@ func (status *ServiceInstanceStatus) PropagateProvisionAsyncStatus(
 	}
 }
 
+// ServiceInstanceConditionInstanceUpdated reports whether the service broker
+// applied the latest update to the instance. It isn't part of the Ready
+// condition because a rejected update leaves the instance working on its
+// previous plan and parameters.
+const ServiceInstanceConditionInstanceUpdated apis.ConditionType = "InstanceUpdated"
+
+// MarkUpdatePending records that an update was requested but can't be sent to
+// the service broker until the instance's current operation completes.
+func (status *ServiceInstanceStatus) MarkUpdatePending() {
+	status.manage().MarkUnknown(
+		ServiceInstanceConditionInstanceUpdated,
+		"UpdatePending",
+		"waiting for the instance's current operation to complete",
+	)
+}
+
+// PropagateUpdateStatus propagates the result of a synchronous
+// OSB update request.
+//
+// At the end of this call, the backing resource and instance updated
+// conditions and the OSBStatus field will be updated. A failed update doesn't
+// fail the backing resource.
+func (status *ServiceInstanceStatus) PropagateUpdateStatus(
+	response *osbclient.UpdateInstanceResponse,
+	err error,
+) {
+	condition := status.BackingResourceCondition()
+
+	switch {
+	case err != nil:
+		status.markUpdateFailed("UpdatingInstance", fmt.Sprintf("couldn't update: %v", err))
+
+	case response.Async:
+		status.OSBStatus = OSBStatus{
+			Updating: &OSBState{
+				OperationKey: (*string)(response.OperationKey),
+			},
+		}
+		condition.MarkUnknown("UpdatingInstance", "operation is pending")
+		status.manage().MarkUnknown(ServiceInstanceConditionInstanceUpdated, "UpdatingInstance", "operation is pending")
+
+	default:
+		status.OSBStatus = OSBStatus{
+			Provisioned: &OSBState{},
+		}
+		condition.MarkSuccess()
+		status.manage().MarkTrue(ServiceInstanceConditionInstanceUpdated)
+	}
+}
+
+// PropagateUpdateAsyncStatus propagates the result of an asynchronous
+// OSB update request.
+//
+// At the end of this call, the backing resource and instance updated
+// conditions and the OSBStatus field will be updated. A failed update doesn't
+// fail the backing resource.
+func (status *ServiceInstanceStatus) PropagateUpdateAsyncStatus(
+	response *osbclient.LastOperationResponse,
+	err error,
+) {
+	condition := status.BackingResourceCondition()
+	switch {
+	case isRetryableOSBError(err):
+		condition.MarkUnknown(
+			"UpdatingInstance",
+			"temporary error while polling: %v",
+			err,
+		)
+		// No update is necessary to OSBStatus, it should already
+		// contain the Updating status for the state to get here.
+
+	case err != nil:
+		status.markUpdateFailed("PollingOperation", err.Error())
+
+	case osbclient.StateInProgress == response.State:
+		condition.MarkUnknown(
+			"UpdatingInstance",
+			formatOperationMessage(response),
+		)
+		// No update is necessary to OSBStatus, it should already
+		// contain the Updating status for the state to get here.
+
+	case osbclient.StateSucceeded == response.State:
+		condition.MarkSuccess()
+		status.manage().MarkTrue(ServiceInstanceConditionInstanceUpdated)
+		status.OSBStatus = OSBStatus{
+			Provisioned: &OSBState{},
+		}
+
+	case osbclient.StateFailed == response.State:
+		status.markUpdateFailed("UpdateFailed", "update failed: "+formatOperationMessage(response))
+
+	default:
+		status.markUpdateFailed("UnknownState", "unknown state: "+formatOperationMessage(response))
+	}
+}
+
+// markUpdateFailed records a failed update. The instance is still usable with
+// the plan and parameters the service broker last accepted.
+func (status *ServiceInstanceStatus) markUpdateFailed(reason, message string) {
+	status.BackingResourceCondition().MarkSuccess()
+	status.manage().MarkFalse(ServiceInstanceConditionInstanceUpdated, reason, "%s", message)
+	status.OSBStatus = OSBStatus{
+		UpdateFailed: &OSBState{},
+	}
+}
+
 func isRetryableOSBError(err error) bool {
 	if err == nil {
 		return false
@@ -376,6 +376,135 @@ func assertCreationInvariant(t *testing.T, original, updated *ServiceInstanceSta
 	}
 }
 
+func TestServiceInstanceStatus_PropagateUpdateStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		response      *osbclient.UpdateInstanceResponse
+		err           error
+		wantCondition corev1.ConditionStatus
+	}{
+		"500 error fails": {
+			err:           &osbclient.HTTPStatusCodeError{StatusCode: 500},
+			wantCondition: corev1.ConditionFalse,
+		},
+		"other error fails": {
+			err:           errors.New("other"),
+			wantCondition: corev1.ConditionFalse,
+		},
+		"async operation continues": {
+			response:      &osbclient.UpdateInstanceResponse{Async: true},
+			wantCondition: corev1.ConditionUnknown,
+		},
+		"successful operation completes": {
+			response:      &osbclient.UpdateInstanceResponse{},
+			wantCondition: corev1.ConditionTrue,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &ServiceInstanceStatus{}
+			status.InitializeConditions()
+			status.OSBStatus = OSBStatus{
+				Provisioned: &OSBState{},
+			}
+			original := status.DeepCopy()
+
+			status.PropagateUpdateStatus(tc.response, tc.err)
+
+			actualCondition := status.manage().GetCondition(ServiceInstanceConditionInstanceUpdated)
+			testutil.AssertEqual(t, "condition", tc.wantCondition, actualCondition.Status)
+
+			assertUpdateInvariant(t, original, status)
+		})
+	}
+}
+
+func TestServiceInstanceStatus_PropagateUpdateAsyncStatus(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		response      *osbclient.LastOperationResponse
+		err           error
+		wantCondition corev1.ConditionStatus
+	}{
+		"500 error retries": {
+			err:           &osbclient.HTTPStatusCodeError{StatusCode: 500},
+			wantCondition: corev1.ConditionUnknown,
+		},
+		"409 error retries": {
+			err:           &osbclient.HTTPStatusCodeError{StatusCode: 409},
+			wantCondition: corev1.ConditionUnknown,
+		},
+		"other error fails": {
+			err:           errors.New("other"),
+			wantCondition: corev1.ConditionFalse,
+		},
+		"in-progress operation continues": {
+			response:      &osbclient.LastOperationResponse{State: osbclient.StateInProgress},
+			wantCondition: corev1.ConditionUnknown,
+		},
+		"successful operation completes": {
+			response:      &osbclient.LastOperationResponse{State: osbclient.StateSucceeded},
+			wantCondition: corev1.ConditionTrue,
+		},
+		"failed operation completes": {
+			response:      &osbclient.LastOperationResponse{State: osbclient.StateFailed},
+			wantCondition: corev1.ConditionFalse,
+		},
+		"unknown operation fails": {
+			response:      &osbclient.LastOperationResponse{State: "badstate"},
+			wantCondition: corev1.ConditionFalse,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &ServiceInstanceStatus{}
+			status.InitializeConditions()
+			status.OSBStatus = OSBStatus{
+				Updating: &OSBState{},
+			}
+			status.manage().MarkUnknown(ServiceInstanceConditionInstanceUpdated, "UpdatingInstance", "operation is pending")
+			original := status.DeepCopy()
+
+			status.PropagateUpdateAsyncStatus(tc.response, tc.err)
+
+			actualCondition := status.manage().GetCondition(ServiceInstanceConditionInstanceUpdated)
+			testutil.AssertEqual(t, "condition", tc.wantCondition, actualCondition.Status)
+
+			assertUpdateInvariant(t, original, status)
+		})
+	}
+}
+
+// assertUpdateInvariant checks the invariant that condition and status are
+// set and that they're in a valid state after an update. Failed updates must
+// not fail the backing resource because the instance still exists.
+func assertUpdateInvariant(t *testing.T, original, updated *ServiceInstanceStatus) {
+	actualCondition := updated.manage().GetCondition(ServiceInstanceConditionInstanceUpdated)
+	backingResource := updated.manage().GetCondition(ServiceInstanceConditionBackingResourceReady)
+
+	actualOSB := updated.OSBStatus
+
+	switch actualCondition.Status {
+	case corev1.ConditionTrue:
+		testutil.AssertTrue(t, "OSBStatus provisioned", actualOSB.Provisioned != nil)
+		testutil.AssertTrue(t, "backing resource ready", backingResource.IsTrue())
+	case corev1.ConditionFalse:
+		testutil.AssertTrue(t, "OSBStatus updateFailed", actualOSB.UpdateFailed != nil)
+		testutil.AssertTrue(t, "backing resource ready", backingResource.IsTrue())
+	case corev1.ConditionUnknown:
+		if actualOSB.Updating == nil && !reflect.DeepEqual(actualOSB, original.OSBStatus) {
+			t.Errorf("expected updating or no change got: %#v", actualOSB)
+		}
+		testutil.AssertTrue(t, "backing resource pending", backingResource.IsUnknown())
+	default:
+		t.Fatal("expected condition to be set")
+	}
+}
+
 func TestFormatOperationMessage(t *testing.T) {
 
 	cases := map[string]struct {
@@ -28,6 +28,17 @@ const (
 	// ServiceInstanceParamsSecretKey contains the secret key that holds parameters.
 	ServiceInstanceParamsSecretKey = "params"
 
+	// ServiceInstanceUpdateParamsSecretKey contains the secret key that holds
+	// the parameters of the latest update to a brokered instance. The
+	// ServiceInstanceParamsSecretKey key holds the instance's parameters with
+	// the update applied.
+	ServiceInstanceUpdateParamsSecretKey = "updateParams"
+
+	// ServiceInstanceUpdateRequestsAnnotation is set on the parameters secret
+	// to the UpdateRequests value that ServiceInstanceUpdateParamsSecretKey
+	// belongs to.
+	ServiceInstanceUpdateRequestsAnnotation = "kf.dev/update-requests"
+
 	// UserProvidedServiceClassName is the class name for user-provided service
 	// instances, unless overridden by a mock name.
 	// The class name is also used as the label for the service in VCAP_SERVICES.
@@ -93,9 +104,19 @@ type ServiceInstanceSpec struct {
 	// for the service.
 	ParametersFrom corev1.LocalObjectReference `json:"parametersFrom,omitempty"`
 
+	// SharedSpaces contains the names of other Spaces the instance is shared
+	// with. Apps in those Spaces can bind to the instance.
+	// +optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
+
 	// DeleteRequests is a unique identifier for an ServiceInstanceSpec.
 	// Updating sub-values will trigger an additional delete retry.
 	DeleteRequests int `json:"deleteRequests,omitempty"`
+
+	// UpdateRequests is a unique identifier for an ServiceInstanceSpec.
+	// Updating sub-values will trigger the current plan and parameters to be
+	// sent to the service broker.
+	UpdateRequests int `json:"updateRequests,omitempty"`
 }
 
 // ServiceType is the type of the service instance.
@@ -217,6 +238,14 @@ type ServiceInstanceStatus struct {
 
 	// DeleteRequests is the last processed DeleteRequests value
 	DeleteRequests int `json:"deleteRequests,omitempty"`
+
+	// UpdateRequests is the last processed UpdateRequests value
+	UpdateRequests int `json:"updateRequests,omitempty"`
+
+	// PlanUID is the UID of the plan the service broker last successfully
+	// provisioned or updated the instance with. It's only set for OSB backed
+	// services.
+	PlanUID string `json:"planUID,omitempty"`
 }
 
 // VolumeStatus is a union of status information for an volume instance.
@@ -239,6 +268,8 @@ type OSBStatus struct {
 	Deprovisioning    *OSBState `json:"deprovisioning,omitempty"`
 	Deprovisioned     *OSBState `json:"deprovisioned,omitempty"`
 	DeprovisionFailed *OSBState `json:"deprovisionFailed,omitempty"`
+	Updating          *OSBState `json:"updating,omitempty"`
+	UpdateFailed      *OSBState `json:"updateFailed,omitempty"`
 }
 
 // IsBlank returns true if the status is unset.
@@ -246,6 +277,12 @@ func (o *OSBStatus) IsBlank() bool {
 	return *o == OSBStatus{}
 }
 
+// IsUpdatable returns true if the instance exists in the broker and has no
+// operations in progress so it may be updated.
+func (o *OSBStatus) IsUpdatable() bool {
+	return o.Provisioned != nil || o.UpdateFailed != nil
+}
+
 // OSBState contains information about a specific state.
 type OSBState struct {
 	// OperationKey, if specified, holds the long running operation key for a given
@@ -309,6 +346,16 @@ func (service *ServiceInstance) IsKfBrokered() bool {
 	return service.Spec.OSB != nil
 }
 
+// AcceptedPlanUID returns the UID of the plan the service broker last accepted
+// for the instance. Until the instance is provisioned it's the requested plan.
+// Callers must check IsKfBrokered first.
+func (service *ServiceInstance) AcceptedPlanUID() string {
+	if service.Status.PlanUID != "" {
+		return service.Status.PlanUID
+	}
+	return service.Spec.OSB.PlanUID
+}
+
 // IsUserProvided returns whether the service instance is a user-provided service.
 func (service *ServiceInstance) IsUserProvided() bool {
 	return service.Spec.UPS != nil
@@ -329,3 +376,20 @@ func (service *ServiceInstance) IsVolume() bool {
 func (service *ServiceInstance) HasNoBackingResources() bool {
 	return service.IsUserProvided() || service.IsVolume()
 }
+
+// IsShareable returns whether the ServiceType supports sharing the instance
+// with other Spaces. Brokered instances must also be marked shareable in the
+// broker's catalog.
+func (service *ServiceInstance) IsShareable() bool {
+	return service.IsUserProvided() || service.IsKfBrokered()
+}
+
+// IsSharedWith returns whether the instance is shared with the given Space.
+func (service *ServiceInstance) IsSharedWith(space string) bool {
+	for _, shared := range service.Spec.SharedSpaces {
+		if shared == space {
+			return true
+		}
+	}
+	return false
+}
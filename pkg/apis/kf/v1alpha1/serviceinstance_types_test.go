@@ -58,6 +58,14 @@ func TestOSBStatus_IsBlank(t *testing.T) {
 			status:    OSBStatus{DeprovisionFailed: statePtr},
 			wantBlank: false,
 		},
+		"Updating": {
+			status:    OSBStatus{Updating: statePtr},
+			wantBlank: false,
+		},
+		"UpdateFailed": {
+			status:    OSBStatus{UpdateFailed: statePtr},
+			wantBlank: false,
+		},
 	}
 
 	for tn, tc := range cases {
@@ -68,6 +76,59 @@ func TestOSBStatus_IsBlank(t *testing.T) {
 	}
 }
 
+func TestOSBStatus_IsUpdatable(t *testing.T) {
+	t.Parallel()
+
+	statePtr := &OSBState{}
+
+	cases := map[string]struct {
+		status        OSBStatus
+		wantUpdatable bool
+	}{
+		"blank": {
+			wantUpdatable: false,
+		},
+		"Provisioning": {
+			status:        OSBStatus{Provisioning: statePtr},
+			wantUpdatable: false,
+		},
+		"Provisioned": {
+			status:        OSBStatus{Provisioned: statePtr},
+			wantUpdatable: true,
+		},
+		"Deprovisioning": {
+			status:        OSBStatus{Deprovisioning: statePtr},
+			wantUpdatable: false,
+		},
+		"Updating": {
+			status:        OSBStatus{Updating: statePtr},
+			wantUpdatable: false,
+		},
+		"UpdateFailed": {
+			status:        OSBStatus{UpdateFailed: statePtr},
+			wantUpdatable: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gotUpdatable := tc.status.IsUpdatable()
+			testutil.AssertEqual(t, "updatable", tc.wantUpdatable, gotUpdatable)
+		})
+	}
+}
+
+func TestServiceInstance_IsSharedWith(t *testing.T) {
+	t.Parallel()
+
+	instance := &ServiceInstance{}
+	instance.Spec.SharedSpaces = []string{"space-a", "space-b"}
+
+	testutil.AssertTrue(t, "shared with space-a", instance.IsSharedWith("space-a"))
+	testutil.AssertTrue(t, "shared with space-b", instance.IsSharedWith("space-b"))
+	testutil.AssertFalse(t, "shared with space-c", instance.IsSharedWith("space-c"))
+}
+
 func TestParseVolumeInstanceParams(t *testing.T) {
 	share, capacity := "192.168.0.1/test", "1Gi"
 	goodSecret := &corev1.Secret{}
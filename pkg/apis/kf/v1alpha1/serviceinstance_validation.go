@@ -32,12 +32,24 @@ func (instance *ServiceInstance) Validate(ctx context.Context) (errs *apis.Field
 	}
 
 	errs = errs.Also(apis.ValidateObjectMetadata(instance.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(instance.validateSharedSpaces().ViaField("spec"))
 
 	// Deny changes to spec if the instance is a brokered service instance.
 	if apis.IsInUpdate(ctx) && (instance.IsLegacyBrokered() || instance.IsKfBrokered()) {
 		original := apis.GetBaseline(ctx).(*ServiceInstance)
-		instance.Spec.DeleteRequests = original.Spec.DeleteRequests
-		if diff, err := kmp.ShortDiff(original.Spec, instance.Spec); err != nil {
+		updated := instance.Spec.DeepCopy()
+		updated.DeleteRequests = original.Spec.DeleteRequests
+		updated.SharedSpaces = original.Spec.SharedSpaces
+
+		// The plan of a Kf brokered instance can be changed and sent to the
+		// broker with an update request.
+		if instance.IsKfBrokered() && original.IsKfBrokered() {
+			updated.UpdateRequests = original.Spec.UpdateRequests
+			updated.OSB.PlanUID = original.Spec.OSB.PlanUID
+			updated.OSB.PlanName = original.Spec.OSB.PlanName
+		}
+
+		if diff, err := kmp.ShortDiff(original.Spec, *updated); err != nil {
 			return errs.Also(&apis.FieldError{
 				Message: "Failed to diff",
 				Paths:   []string{"spec"},
@@ -57,6 +69,33 @@ func (instance *ServiceInstance) Validate(ctx context.Context) (errs *apis.Field
 	return errs
 }
 
+// validateSharedSpaces checks that the instance can be shared and that the
+// Spaces it's shared with are unique and not its own Space.
+func (instance *ServiceInstance) validateSharedSpaces() (errs *apis.FieldError) {
+	if len(instance.Spec.SharedSpaces) == 0 {
+		return nil
+	}
+
+	if !instance.IsShareable() {
+		return apis.ErrGeneric("only user-provided and brokered services can be shared", "sharedSpaces")
+	}
+
+	seen := sets.NewString()
+	for idx, space := range instance.Spec.SharedSpaces {
+		switch {
+		case space == "":
+			errs = errs.Also(apis.ErrMissingField(apis.CurrentField).ViaFieldIndex("sharedSpaces", idx))
+		case space == instance.Namespace:
+			errs = errs.Also(apis.ErrGeneric("a service can't be shared with its own Space", apis.CurrentField).ViaFieldIndex("sharedSpaces", idx))
+		case seen.Has(space):
+			errs = errs.Also(errDuplicateValue(space, apis.CurrentField).ViaFieldIndex("sharedSpaces", idx))
+		}
+		seen.Insert(space)
+	}
+
+	return errs
+}
+
 // Validate implements apis.Validatable.
 func (spec *ServiceInstanceSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(spec.ServiceType.Validate(ctx))
@@ -51,6 +51,22 @@ func validUserProvidedInstance() *ServiceInstance {
 	}
 }
 
+func validOSBServiceInstance() *ServiceInstance {
+	return &ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: ServiceInstanceSpec{
+			ServiceType: ServiceType{
+				OSB: validOSBInstance(),
+			},
+			ParametersFrom: corev1.LocalObjectReference{
+				Name: "my-params-secret",
+			},
+		},
+	}
+}
+
 func TestServiceInstance_Validate(t *testing.T) {
 	cases := testutil.ApisValidatableTestSuite{
 		"ignores status updates": {
@@ -127,6 +143,76 @@ func TestServiceInstance_Validate(t *testing.T) {
 			},
 			Want: nil,
 		},
+		"update ok if OSB plan and UpdateRequests change": {
+			Context: apis.WithinUpdate(context.Background(), validOSBServiceInstance()),
+			Input: (func() *ServiceInstance {
+				inst := validOSBServiceInstance()
+				inst.Spec.OSB.PlanName = "new-plan"
+				inst.Spec.OSB.PlanUID = "new-plan-uid"
+				inst.Spec.UpdateRequests = 1
+				return inst
+			}()),
+			Want: nil,
+		},
+		"OSB service update rejected if class changes": {
+			Context: apis.WithinUpdate(context.Background(), validOSBServiceInstance()),
+			Input: (func() *ServiceInstance {
+				inst := validOSBServiceInstance()
+				inst.Spec.OSB.ClassUID = "new-class-uid"
+				return inst
+			}()),
+			Want: &apis.FieldError{
+				Message: "Immutable fields changed (-old +new)",
+				Paths:   []string{"spec"},
+				Details: `{v1alpha1.ServiceInstanceSpec}.ServiceType.OSB.ClassUID:
+	-: "abc-def"
+	+: "new-class-uid"
+`,
+			},
+		},
+		"update ok if OSB shared spaces change": {
+			Context: apis.WithinUpdate(context.Background(), validOSBServiceInstance()),
+			Input: (func() *ServiceInstance {
+				inst := validOSBServiceInstance()
+				inst.Spec.SharedSpaces = []string{"other-space"}
+				return inst
+			}()),
+			Want: nil,
+		},
+		"user-provided service shared": {
+			Context: context.Background(),
+			Input: (func() *ServiceInstance {
+				inst := validUserProvidedInstance()
+				inst.Namespace = "my-space"
+				inst.Spec.SharedSpaces = []string{"other-space", "another-space"}
+				return inst
+			}()),
+			Want: nil,
+		},
+		"volume service can't be shared": {
+			Context: context.Background(),
+			Input: (func() *ServiceInstance {
+				inst := validUserProvidedInstance()
+				inst.Spec.UPS = nil
+				inst.Spec.Volume = validOSBInstance()
+				inst.Spec.SharedSpaces = []string{"other-space"}
+				return inst
+			}()),
+			Want: apis.ErrGeneric("only user-provided and brokered services can be shared", "spec.sharedSpaces"),
+		},
+		"invalid shared spaces": {
+			Context: context.Background(),
+			Input: (func() *ServiceInstance {
+				inst := validUserProvidedInstance()
+				inst.Namespace = "my-space"
+				inst.Spec.SharedSpaces = []string{"", "my-space", "other-space", "other-space"}
+				return inst
+			}()),
+			Want: apis.ErrMissingField("spec.sharedSpaces[0]").Also(
+				apis.ErrGeneric("a service can't be shared with its own Space", "spec.sharedSpaces[1]"),
+				errDuplicateValue("other-space", "spec.sharedSpaces[3]"),
+			),
+		},
 	}
 
 	cases.Run(t)
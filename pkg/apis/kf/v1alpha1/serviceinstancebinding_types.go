@@ -64,6 +64,18 @@ func MakeRouteServiceBindingParamsSecretName(hostname, domain, path, instanceNam
 	return GenerateName("binding", rsf.String(), instanceName, "params")
 }
 
+// MakeServiceKeyBindingName returns a deterministic name for a service key
+// binding.
+func MakeServiceKeyBindingName(instanceName, keyName string) string {
+	return GenerateName("key", instanceName, keyName)
+}
+
+// MakeServiceKeyParamsSecretName returns a deterministic name for a service key
+// parameters secret.
+func MakeServiceKeyParamsSecretName(instanceName, keyName string) string {
+	return GenerateName("key", instanceName, keyName, "params")
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -101,6 +113,12 @@ type ServiceInstanceBindingSpec struct {
 	// InstanceRef is the service instance that is bound to the App or Route.
 	InstanceRef core.LocalObjectReference `json:"instanceRef"`
 
+	// InstanceSpace is the Space the service instance is in. If blank, the
+	// instance is in the same Space as the binding. Instances in other Spaces
+	// must be shared with the binding's Space.
+	// +optional
+	InstanceSpace string `json:"instanceSpace,omitempty"`
+
 	// ParametersFrom contains a reference to a secret containing parameters for
 	// the service instance binding.
 	ParametersFrom core.LocalObjectReference `json:"parametersFrom,omitempty"`
@@ -130,12 +148,23 @@ type BindingType struct {
 	// Route is the Route that the service instance is bound to.
 	// +optional
 	Route *RouteRef `json:"route,omitempty"`
+
+	// ServiceKey is a named set of credentials for the service instance that
+	// isn't bound to an App or Route.
+	// +optional
+	ServiceKey *ServiceKeyRef `json:"serviceKey,omitempty"`
 }
 
 type AppRef core.LocalObjectReference
 
 type RouteRef RouteSpecFields
 
+// ServiceKeyRef identifies a service key.
+type ServiceKeyRef struct {
+	// Name is the name of the service key, unique for each service instance.
+	Name string `json:"name"`
+}
+
 // ServiceInstanceBindingStatus represents information about the status of a Binding.
 type ServiceInstanceBindingStatus struct {
 	// Pull in fields from Knative's duckv1beta1 status field.
@@ -284,3 +313,29 @@ func (binding *ServiceInstanceBinding) IsAppBinding() bool {
 func (binding *ServiceInstanceBinding) IsRouteBinding() bool {
 	return binding.Spec.BindingType.Route != nil
 }
+
+// IsServiceKeyBinding returns true if the service instance binding is a service key.
+func (binding *ServiceInstanceBinding) IsServiceKeyBinding() bool {
+	return binding.Spec.BindingType.ServiceKey != nil
+}
+
+// InstanceNamespace returns the namespace of the service instance the binding
+// refers to.
+func (binding *ServiceInstanceBinding) InstanceNamespace() string {
+	if binding.Spec.InstanceSpace != "" {
+		return binding.Spec.InstanceSpace
+	}
+	return binding.Namespace
+}
+
+// IsSharedInstanceBinding returns whether the binding refers to a service
+// instance in another Space.
+func (binding *ServiceInstanceBinding) IsSharedInstanceBinding() bool {
+	return binding.InstanceNamespace() != binding.Namespace
+}
+
+// IsBindingFor returns whether the binding refers to the service instance.
+func (binding *ServiceInstanceBinding) IsBindingFor(instance *ServiceInstance) bool {
+	return binding.Spec.InstanceRef.Name == instance.Name &&
+		binding.InstanceNamespace() == instance.Namespace
+}
@@ -65,3 +65,59 @@ func TestBindingOSBStatus_IsBlank(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceInstanceBinding_IsBindingFor(t *testing.T) {
+	t.Parallel()
+
+	instance := &ServiceInstance{}
+	instance.Name = "my-db"
+	instance.Namespace = "source-space"
+
+	newBinding := func(namespace, instanceName, instanceSpace string) *ServiceInstanceBinding {
+		binding := &ServiceInstanceBinding{}
+		binding.Namespace = namespace
+		binding.Spec.InstanceRef.Name = instanceName
+		binding.Spec.InstanceSpace = instanceSpace
+		return binding
+	}
+
+	cases := map[string]struct {
+		binding          *ServiceInstanceBinding
+		wantNamespace    string
+		wantShared       bool
+		wantBindingForDB bool
+	}{
+		"same Space": {
+			binding:          newBinding("source-space", "my-db", ""),
+			wantNamespace:    "source-space",
+			wantBindingForDB: true,
+		},
+		"same Space explicit": {
+			binding:          newBinding("source-space", "my-db", "source-space"),
+			wantNamespace:    "source-space",
+			wantBindingForDB: true,
+		},
+		"shared instance": {
+			binding:          newBinding("target-space", "my-db", "source-space"),
+			wantNamespace:    "source-space",
+			wantShared:       true,
+			wantBindingForDB: true,
+		},
+		"same name in another Space": {
+			binding:       newBinding("target-space", "my-db", ""),
+			wantNamespace: "target-space",
+		},
+		"different instance": {
+			binding:       newBinding("source-space", "other-db", ""),
+			wantNamespace: "source-space",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "InstanceNamespace", tc.wantNamespace, tc.binding.InstanceNamespace())
+			testutil.AssertEqual(t, "IsSharedInstanceBinding", tc.wantShared, tc.binding.IsSharedInstanceBinding())
+			testutil.AssertEqual(t, "IsBindingFor", tc.wantBindingForDB, tc.binding.IsBindingFor(instance))
+		})
+	}
+}
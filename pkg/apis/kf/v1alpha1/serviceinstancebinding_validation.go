@@ -99,6 +99,11 @@ func (bindingType *BindingType) Validate(ctx context.Context) (errs *apis.FieldE
 			isNil:     bindingType.Route == nil,
 			validator: bindingType.Route,
 		},
+		{
+			fieldName: "serviceKey",
+			isNil:     bindingType.ServiceKey == nil,
+			validator: bindingType.ServiceKey,
+		},
 	}
 
 	for _, field := range fields {
@@ -137,3 +142,12 @@ func (route *RouteRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	return
 }
+
+// Validate implements apis.Validatable.
+func (key *ServiceKeyRef) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if key.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+
+	return
+}
@@ -154,7 +154,22 @@ func TestBindingType_Validate(t *testing.T) {
 		"error no bindingtype": {
 			Context: context.Background(),
 			Input:   &BindingType{},
-			Want:    apis.ErrMissingOneOf("app", "route"),
+			Want:    apis.ErrMissingOneOf("app", "route", "serviceKey"),
+		},
+		"service key": {
+			Context: context.Background(),
+			Input: &BindingType{
+				ServiceKey: &ServiceKeyRef{Name: "my-key"},
+			},
+			Want: nil,
+		},
+		"error multiple bindingtypes": {
+			Context: context.Background(),
+			Input: &BindingType{
+				App:        validAppBindingType(),
+				ServiceKey: &ServiceKeyRef{Name: "my-key"},
+			},
+			Want: apis.ErrMultipleOneOf("app", "serviceKey"),
 		},
 	}
 
@@ -209,3 +224,20 @@ func TestRouteRef_Validate(t *testing.T) {
 
 	cases.Run(t)
 }
+
+func TestServiceKeyRef_Validate(t *testing.T) {
+	cases := testutil.ApisValidatableTestSuite{
+		"missing fields": {
+			Context: context.Background(),
+			Input:   &ServiceKeyRef{},
+			Want:    apis.ErrMissingField("name"),
+		},
+		"populated": {
+			Context: context.Background(),
+			Input:   &ServiceKeyRef{Name: "my-key"},
+			Want:    nil,
+		},
+	}
+
+	cases.Run(t)
+}
@@ -76,6 +76,18 @@ func (status *SpaceStatus) PropagateIngressGatewayStatus(ingresses []corev1.Load
 	}
 }
 
+// PropagateQuotaStatus copies the quota limits and the amount of each
+// resource used in the space to the status.
+func (status *SpaceStatus) PropagateQuotaStatus(quota SpaceSpecQuota, used corev1.ResourceList) {
+	status.Quota.Hard = nil
+	if hard := quota.Hard(); len(hard) > 0 {
+		status.Quota.Hard = hard
+	}
+	status.Quota.Used = used
+
+	status.QuotaCondition().MarkSuccess()
+}
+
 // PropagateRuntimeConfigStatus copies the application runtime settings to the
 // space status.
 func (status *SpaceStatus) PropagateRuntimeConfigStatus(runtimeConfig SpaceSpecRuntimeConfig, cfg *config.Config) {
@@ -123,9 +135,17 @@ func (status *SpaceStatus) PropagateNetworkConfigStatus(networkConfig SpaceSpecN
 		}
 
 		for _, defaultDomain := range defaultsConfig.SpaceClusterDomains {
+			var tls *DomainTLS
+			if defaultDomain.TLSIssuerName != "" {
+				tls = &DomainTLS{IssuerName: defaultDomain.TLSIssuerName}
+			}
+
 			domains = append(domains, SpaceDomain{
-				Domain:      defaultDomain.Domain,
-				GatewayName: defaultDomain.GatewayName,
+				Domain:          defaultDomain.Domain,
+				GatewayName:     defaultDomain.GatewayName,
+				Protocol:        defaultDomain.Protocol,
+				ReservablePorts: defaultDomain.ReservablePorts,
+				TLS:             tls,
 			})
 		}
 
@@ -150,8 +170,11 @@ func (status *SpaceStatus) PropagateNetworkConfigStatus(networkConfig SpaceSpecN
 		for _, d := range status.NetworkConfig.Domains {
 			if d.GatewayName == "" {
 				domains = append(domains, SpaceDomain{
-					Domain:      d.Domain,
-					GatewayName: KfExternalIngressGateway,
+					Domain:          d.Domain,
+					GatewayName:     KfExternalIngressGateway,
+					Protocol:        d.Protocol,
+					ReservablePorts: d.ReservablePorts,
+					TLS:             d.TLS,
 				})
 			} else {
 				domains = append(domains, d)
@@ -163,6 +186,12 @@ func (status *SpaceStatus) PropagateNetworkConfigStatus(networkConfig SpaceSpecN
 	status.NetworkConfigCondition().MarkSuccess()
 }
 
+// PropagateDomainCertificates sets the status of the certificates the
+// Space's domains are served with over HTTPS.
+func (status *SpaceStatus) PropagateDomainCertificates(certs []CertificateStatus) {
+	status.NetworkConfig.DomainCertificates = certs
+}
+
 func applyDomainReplacements(input string, replacements map[string]string) string {
 	var oldnew []string
 	for k, v := range replacements {
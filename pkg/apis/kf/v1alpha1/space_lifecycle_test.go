@@ -21,6 +21,7 @@ import (
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -213,6 +214,7 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				status.ClusterRoleCondition().MarkSuccess()
 				status.ClusterRoleBindingsCondition().MarkSuccess()
 				status.IAMPolicyCondition().MarkSuccess()
+				status.QuotaCondition().MarkSuccess()
 			},
 			ExpectSucceeded: []apis.ConditionType{
 				SpaceConditionReady,
@@ -229,6 +231,7 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				SpaceConditionClusterRoleReady,
 				SpaceConditionClusterRoleBindingsReady,
 				SpaceConditionIAMPolicyReady,
+				SpaceConditionQuotaReady,
 			},
 		},
 		"terminating namespace": {
@@ -316,6 +319,48 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 	}
 }
 
+func TestSpaceStatus_PropagateQuotaStatus(t *testing.T) {
+	t.Parallel()
+
+	memory := resource.MustParse("1Gi")
+	used := corev1.ResourceList{
+		QuotaResourceApps:   resource.MustParse("2"),
+		QuotaResourceMemory: resource.MustParse("512Mi"),
+	}
+
+	cases := map[string]struct {
+		quota    SpaceSpecQuota
+		wantHard corev1.ResourceList
+	}{
+		"no limits": {
+			quota:    SpaceSpecQuota{},
+			wantHard: nil,
+		},
+		"limits": {
+			quota: SpaceSpecQuota{
+				Memory: &memory,
+				Apps:   ptr.Int64(10),
+			},
+			wantHard: corev1.ResourceList{
+				QuotaResourceMemory: resource.MustParse("1Gi"),
+				QuotaResourceApps:   resource.MustParse("10"),
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := initTestStatus(t)
+
+			status.PropagateQuotaStatus(tc.quota, used)
+
+			assertResourceListEqual(t, tc.wantHard, status.Quota.Hard)
+			testutil.AssertEqual(t, "used", used, status.Quota.Used)
+			apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionQuotaReady, t)
+		})
+	}
+}
+
 func TestSpaceStatus_PropagateBuildConfigStatus(t *testing.T) {
 	t.Parallel()
 	cases := map[string]struct {
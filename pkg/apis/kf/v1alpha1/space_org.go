@@ -0,0 +1,49 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EffectiveQuotaRef returns the SpaceQuota that applies to the Space given the
+// Org it belongs to, which may be nil. The Space's own QuotaRef takes
+// precedence over the Org's default.
+func (s *Space) EffectiveQuotaRef(org *Org) *corev1.LocalObjectReference {
+	if s.Spec.QuotaRef != nil {
+		return s.Spec.QuotaRef
+	}
+
+	if org != nil {
+		return org.Spec.QuotaRef
+	}
+
+	return nil
+}
+
+// EffectiveNetworkConfig returns the Space's network config with the domains
+// of the Org it belongs to, which may be nil, added after the Space's own.
+func (s *Space) EffectiveNetworkConfig(org *Org) SpaceSpecNetworkConfig {
+	out := *s.Spec.NetworkConfig.DeepCopy()
+	if org == nil {
+		return out
+	}
+
+	for _, domain := range org.Spec.Domains {
+		out.Domains = append(out.Domains, *domain.DeepCopy())
+	}
+
+	return out
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSpace_EffectiveQuotaRef(t *testing.T) {
+	t.Parallel()
+
+	org := &Org{}
+	org.Spec.QuotaRef = &corev1.LocalObjectReference{Name: "org-default"}
+
+	cases := map[string]struct {
+		quotaRef *corev1.LocalObjectReference
+		org      *Org
+		want     *corev1.LocalObjectReference
+	}{
+		"no Org": {
+			want: nil,
+		},
+		"Org default": {
+			org:  org,
+			want: &corev1.LocalObjectReference{Name: "org-default"},
+		},
+		"Space takes precedence": {
+			quotaRef: &corev1.LocalObjectReference{Name: "space"},
+			org:      org,
+			want:     &corev1.LocalObjectReference{Name: "space"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			space := &Space{}
+			space.Spec.QuotaRef = tc.quotaRef
+
+			testutil.AssertEqual(t, "quotaRef", tc.want, space.EffectiveQuotaRef(tc.org))
+		})
+	}
+}
+
+func TestSpace_EffectiveNetworkConfig(t *testing.T) {
+	t.Parallel()
+
+	space := &Space{}
+	space.Spec.NetworkConfig.Domains = []SpaceDomain{{Domain: "space.example.com"}}
+
+	org := &Org{}
+	org.Spec.Domains = []SpaceDomain{{Domain: "org.example.com"}}
+
+	testutil.AssertEqual(
+		t,
+		"no Org",
+		[]SpaceDomain{{Domain: "space.example.com"}},
+		space.EffectiveNetworkConfig(nil).Domains,
+	)
+
+	testutil.AssertEqual(
+		t,
+		"with Org",
+		[]SpaceDomain{{Domain: "space.example.com"}, {Domain: "org.example.com"}},
+		space.EffectiveNetworkConfig(org).Domains,
+	)
+
+	testutil.AssertEqual(
+		t,
+		"Space unmodified",
+		[]SpaceDomain{{Domain: "space.example.com"}},
+		space.Spec.NetworkConfig.Domains,
+	)
+}
@@ -0,0 +1,233 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// QuotaResourceMemory is the name of the total memory quota resource.
+	QuotaResourceMemory corev1.ResourceName = "memory"
+	// QuotaResourceCPU is the name of the total CPU quota resource.
+	QuotaResourceCPU corev1.ResourceName = "cpu"
+	// QuotaResourceInstances is the name of the App instance quota resource.
+	QuotaResourceInstances corev1.ResourceName = "instances"
+	// QuotaResourceApps is the name of the App count quota resource.
+	QuotaResourceApps corev1.ResourceName = "apps"
+	// QuotaResourceRoutes is the name of the Route count quota resource.
+	QuotaResourceRoutes corev1.ResourceName = "routes"
+	// QuotaResourceServiceInstances is the name of the service instance count
+	// quota resource.
+	QuotaResourceServiceInstances corev1.ResourceName = "serviceInstances"
+)
+
+// IsEmpty returns true if the quota doesn't limit any resources.
+func (q *SpaceSpecQuota) IsEmpty() bool {
+	return q.InstanceMemory == nil && len(q.Hard()) == 0
+}
+
+// Hard returns the limits of the quota keyed by their resource name. Resources
+// that aren't limited are left out. InstanceMemory is a per-instance limit so
+// it isn't included.
+func (q *SpaceSpecQuota) Hard() corev1.ResourceList {
+	out := corev1.ResourceList{}
+
+	if q.Memory != nil {
+		out[QuotaResourceMemory] = q.Memory.DeepCopy()
+	}
+
+	if q.CPU != nil {
+		out[QuotaResourceCPU] = q.CPU.DeepCopy()
+	}
+
+	counts := map[corev1.ResourceName]*int64{
+		QuotaResourceInstances:        q.Instances,
+		QuotaResourceApps:             q.Apps,
+		QuotaResourceRoutes:           q.Routes,
+		QuotaResourceServiceInstances: q.ServiceInstances,
+	}
+	for name, count := range counts {
+		if count != nil {
+			out[name] = *resource.NewQuantity(*count, resource.DecimalSI)
+		}
+	}
+
+	return out
+}
+
+// EffectiveQuota returns the limits that apply to the Space given the
+// SpaceQuota it references, which may be nil. If both limit a resource, the
+// lower limit is used.
+func (s *Space) EffectiveQuota(plan *SpaceQuota) SpaceSpecQuota {
+	out := *s.Spec.Quota.DeepCopy()
+	if plan == nil {
+		return out
+	}
+
+	minQuantity := func(a, b *resource.Quantity) *resource.Quantity {
+		if b == nil || (a != nil && a.Cmp(*b) <= 0) {
+			return a
+		}
+		out := b.DeepCopy()
+		return &out
+	}
+
+	minCount := func(a, b *int64) *int64 {
+		if b == nil || (a != nil && *a <= *b) {
+			return a
+		}
+		out := *b
+		return &out
+	}
+
+	out.Memory = minQuantity(out.Memory, plan.Spec.Memory)
+	out.CPU = minQuantity(out.CPU, plan.Spec.CPU)
+	out.InstanceMemory = minQuantity(out.InstanceMemory, plan.Spec.InstanceMemory)
+	out.Instances = minCount(out.Instances, plan.Spec.Instances)
+	out.Apps = minCount(out.Apps, plan.Spec.Apps)
+	out.Routes = minCount(out.Routes, plan.Spec.Routes)
+	out.ServiceInstances = minCount(out.ServiceInstances, plan.Spec.ServiceInstances)
+
+	return out
+}
+
+// Validate implements apis.Validatable.
+func (q *SpaceSpecQuota) Validate(ctx context.Context) (errs *apis.FieldError) {
+	quantities := map[string]*resource.Quantity{
+		"memory":         q.Memory,
+		"cpu":            q.CPU,
+		"instanceMemory": q.InstanceMemory,
+	}
+	for field, quantity := range quantities {
+		if quantity != nil && quantity.Sign() < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(quantity.String(), field))
+		}
+	}
+
+	counts := map[string]*int64{
+		"instances":        q.Instances,
+		"apps":             q.Apps,
+		"routes":           q.Routes,
+		"serviceInstances": q.ServiceInstances,
+	}
+	for field, count := range counts {
+		if count != nil && *count < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(*count, field))
+		}
+	}
+
+	return errs
+}
+
+// AppQuotaUsage returns how much of each quota resource the App uses. Apps
+// that autoscale are counted at their maximum number of instances.
+func AppQuotaUsage(app *App) corev1.ResourceList {
+	instances := app.Spec.Instances
+	webReplicas := int64(1)
+	switch {
+	case instances.Stopped:
+		webReplicas = 0
+	case instances.Autoscaling.Enabled && instances.Autoscaling.MaxReplicas != nil:
+		webReplicas = int64(*instances.Autoscaling.MaxReplicas)
+	case instances.Replicas != nil:
+		webReplicas = int64(*instances.Replicas)
+	}
+
+	memory := resource.NewQuantity(0, resource.BinarySI)
+	cpu := resource.NewMilliQuantity(0, resource.DecimalSI)
+	totalInstances := webReplicas
+
+	addRequests := func(replicas int64, containers ...corev1.Container) {
+		for _, container := range containers {
+			addScaled(memory, container.Resources.Requests.Memory(), replicas)
+			addScaled(cpu, container.Resources.Requests.Cpu(), replicas)
+		}
+	}
+
+	containers := app.Spec.Template.Spec.Containers
+	addRequests(webReplicas, containers...)
+
+	for i := range app.Spec.Processes {
+		process := &app.Spec.Processes[i]
+		replicas := int64(process.DeploymentReplicas(instances))
+		totalInstances += replicas
+
+		addRequests(replicas, processContainers(containers, process)...)
+	}
+
+	return corev1.ResourceList{
+		QuotaResourceApps:      *resource.NewQuantity(1, resource.DecimalSI),
+		QuotaResourceInstances: *resource.NewQuantity(totalInstances, resource.DecimalSI),
+		QuotaResourceMemory:    *memory,
+		QuotaResourceCPU:       *cpu,
+	}
+}
+
+// InstanceMemory returns the most memory requested by a single instance of
+// any of the App's processes.
+func (app *App) InstanceMemory() resource.Quantity {
+	sumRequests := func(containers ...corev1.Container) resource.Quantity {
+		out := resource.NewQuantity(0, resource.BinarySI)
+		for _, container := range containers {
+			out.Add(*container.Resources.Requests.Memory())
+		}
+		return *out
+	}
+
+	containers := app.Spec.Template.Spec.Containers
+	max := sumRequests(containers...)
+
+	for i := range app.Spec.Processes {
+		memory := sumRequests(processContainers(containers, &app.Spec.Processes[i])...)
+		if memory.Cmp(max) > 0 {
+			max = memory
+		}
+	}
+
+	return max
+}
+
+// AddQuotaUsage adds the values in delta to total.
+func AddQuotaUsage(total, delta corev1.ResourceList) {
+	for name, quantity := range delta {
+		current := total[name]
+		current.Add(quantity)
+		total[name] = current
+	}
+}
+
+// processContainers returns the containers that run in each instance of an
+// additional process. The process runs the App's first container with its
+// own resources alongside its sidecars.
+func processContainers(appContainers []corev1.Container, process *AppSpecProcess) []corev1.Container {
+	container := corev1.Container{}
+	if len(appContainers) > 0 {
+		container = appContainers[0]
+	}
+	if process.Resources != nil {
+		container.Resources = *process.Resources
+	}
+
+	return append([]corev1.Container{container}, process.Sidecars...)
+}
+
+func addScaled(total, quantity *resource.Quantity, replicas int64) {
+	total.Add(*resource.NewMilliQuantity(quantity.MilliValue()*replicas, quantity.Format))
+}
@@ -0,0 +1,262 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+)
+
+func quantityPtr(value string) *resource.Quantity {
+	out := resource.MustParse(value)
+	return &out
+}
+
+func assertResourceListEqual(t *testing.T, expected, actual corev1.ResourceList) {
+	t.Helper()
+
+	testutil.AssertEqual(t, "resource count", len(expected), len(actual))
+	for name, want := range expected {
+		got, ok := actual[name]
+		if !ok || want.Cmp(got) != 0 {
+			t.Errorf("expected %s to be %s got %s", name, want.String(), got.String())
+		}
+	}
+}
+
+func TestSpaceSpecQuota_Hard(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		quota SpaceSpecQuota
+		want  corev1.ResourceList
+	}{
+		"empty": {
+			quota: SpaceSpecQuota{},
+			want:  corev1.ResourceList{},
+		},
+		"instance memory is excluded": {
+			quota: SpaceSpecQuota{InstanceMemory: quantityPtr("1Gi")},
+			want:  corev1.ResourceList{},
+		},
+		"all limits": {
+			quota: SpaceSpecQuota{
+				Memory:           quantityPtr("10Gi"),
+				CPU:              quantityPtr("4"),
+				InstanceMemory:   quantityPtr("1Gi"),
+				Instances:        ptr.Int64(20),
+				Apps:             ptr.Int64(5),
+				Routes:           ptr.Int64(10),
+				ServiceInstances: ptr.Int64(0),
+			},
+			want: corev1.ResourceList{
+				QuotaResourceMemory:           resource.MustParse("10Gi"),
+				QuotaResourceCPU:              resource.MustParse("4"),
+				QuotaResourceInstances:        resource.MustParse("20"),
+				QuotaResourceApps:             resource.MustParse("5"),
+				QuotaResourceRoutes:           resource.MustParse("10"),
+				QuotaResourceServiceInstances: resource.MustParse("0"),
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			assertResourceListEqual(t, tc.want, tc.quota.Hard())
+		})
+	}
+}
+
+func TestSpaceSpecQuota_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		quota SpaceSpecQuota
+		want  *apis.FieldError
+	}{
+		"empty": {
+			quota: SpaceSpecQuota{},
+		},
+		"zero values": {
+			quota: SpaceSpecQuota{
+				Memory:    quantityPtr("0"),
+				Instances: ptr.Int64(0),
+			},
+		},
+		"negative memory": {
+			quota: SpaceSpecQuota{Memory: quantityPtr("-1Gi")},
+			want:  apis.ErrInvalidValue("-1Gi", "memory"),
+		},
+		"negative routes": {
+			quota: SpaceSpecQuota{Routes: ptr.Int64(-1)},
+			want:  apis.ErrInvalidValue(-1, "routes"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.quota.Validate(context.Background())
+
+			testutil.AssertEqual(t, "errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestAppQuotaUsage(t *testing.T) {
+	t.Parallel()
+
+	requests := func(memory, cpu string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse(memory),
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+			},
+		}
+	}
+
+	appWith := func(instances AppSpecInstances, processes ...AppSpecProcess) *App {
+		app := &App{}
+		app.Spec.Instances = instances
+		app.Spec.Template.Spec.Containers = []corev1.Container{{
+			Resources: requests("512Mi", "250m"),
+		}}
+		app.Spec.Processes = processes
+		return app
+	}
+
+	usage := func(instances, memory, cpu string) corev1.ResourceList {
+		return corev1.ResourceList{
+			QuotaResourceApps:      resource.MustParse("1"),
+			QuotaResourceInstances: resource.MustParse(instances),
+			QuotaResourceMemory:    resource.MustParse(memory),
+			QuotaResourceCPU:       resource.MustParse(cpu),
+		}
+	}
+
+	cases := map[string]struct {
+		app  *App
+		want corev1.ResourceList
+	}{
+		"default replicas": {
+			app:  appWith(AppSpecInstances{}),
+			want: usage("1", "512Mi", "250m"),
+		},
+		"replicas": {
+			app:  appWith(AppSpecInstances{Replicas: ptr.Int32(3)}),
+			want: usage("3", "1536Mi", "750m"),
+		},
+		"stopped": {
+			app:  appWith(AppSpecInstances{Stopped: true, Replicas: ptr.Int32(3)}),
+			want: usage("0", "0", "0"),
+		},
+		"autoscaling counts max replicas": {
+			app: appWith(AppSpecInstances{
+				Replicas: ptr.Int32(1),
+				Autoscaling: AppSpecAutoscaling{
+					Enabled:     true,
+					MaxReplicas: ptr.Int32(4),
+				},
+			}),
+			want: usage("4", "2Gi", "1"),
+		},
+		"processes": {
+			app: appWith(AppSpecInstances{}, AppSpecProcess{
+				Type:     "worker",
+				Replicas: ptr.Int32(2),
+			}, AppSpecProcess{
+				Type:      "clock",
+				Resources: &corev1.ResourceRequirements{Requests: requests("1Gi", "1").Requests},
+				Sidecars:  []corev1.Container{{Resources: requests("64Mi", "50m")}},
+			}),
+			want: usage("4", "2624Mi", "1800m"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			assertResourceListEqual(t, tc.want, AppQuotaUsage(tc.app))
+		})
+	}
+}
+
+func TestApp_InstanceMemory(t *testing.T) {
+	t.Parallel()
+
+	app := &App{}
+	app.Spec.Template.Spec.Containers = []corev1.Container{{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+	}}
+	app.Spec.Processes = []AppSpecProcess{{
+		Type: "worker",
+		Resources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}}
+
+	got := app.InstanceMemory()
+	testutil.AssertEqual(t, "instance memory", "1Gi", got.String())
+}
+
+func TestSpace_EffectiveQuota(t *testing.T) {
+	t.Parallel()
+
+	space := &Space{}
+	space.Spec.Quota = SpaceSpecQuota{
+		Memory: quantityPtr("10Gi"),
+		Apps:   ptr.Int64(5),
+		Routes: ptr.Int64(20),
+	}
+
+	cases := map[string]struct {
+		plan *SpaceQuota
+		want SpaceSpecQuota
+	}{
+		"no plan": {
+			plan: nil,
+			want: space.Spec.Quota,
+		},
+		"lower limits win": {
+			plan: &SpaceQuota{
+				Spec: SpaceSpecQuota{
+					Memory:    quantityPtr("20Gi"),
+					Apps:      ptr.Int64(2),
+					Instances: ptr.Int64(10),
+				},
+			},
+			want: SpaceSpecQuota{
+				Memory:    quantityPtr("10Gi"),
+				Apps:      ptr.Int64(2),
+				Routes:    ptr.Int64(20),
+				Instances: ptr.Int64(10),
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := space.EffectiveQuota(tc.plan)
+
+			testutil.AssertEqual(t, "quota", tc.want, got)
+		})
+	}
+}
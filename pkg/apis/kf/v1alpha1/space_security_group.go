@@ -0,0 +1,36 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// BindsSecurityGroup returns true if the SecurityGroup with the given name is
+// bound to the apps or builds of the Space.
+func (s *SpaceSpecNetworkConfig) BindsSecurityGroup(name string) bool {
+	return hasLocalObjectReference(s.AppSecurityGroups, name) ||
+		hasLocalObjectReference(s.BuildSecurityGroups, name)
+}
+
+func hasLocalObjectReference(refs []corev1.LocalObjectReference, name string) bool {
+	for _, ref := range refs {
+		if ref.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
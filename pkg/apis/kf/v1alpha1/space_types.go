@@ -40,6 +40,13 @@ const (
 	PermitAllNetworkPolicy = "PermitAll"
 	// DenyAllNetworkPolicy is the key used to indcate all traffic is denied.
 	DenyAllNetworkPolicy = "DenyAll"
+
+	// SpaceDomainProtocolHTTP is the protocol of domains that route HTTP
+	// traffic by hostname and path.
+	SpaceDomainProtocolHTTP = "http"
+	// SpaceDomainProtocolTCP is the protocol of domains that route TCP
+	// traffic by port.
+	SpaceDomainProtocolTCP = "tcp"
 )
 
 // +genclient
@@ -86,6 +93,20 @@ type SpaceSpec struct {
 	// NetworkConfig contains settings for the space's networking environment.
 	// +optional
 	NetworkConfig SpaceSpecNetworkConfig `json:"networkConfig,omitempty"`
+
+	// Quota limits the resources that can be used in the Space.
+	// +optional
+	Quota SpaceSpecQuota `json:"quota,omitempty"`
+
+	// QuotaRef references a SpaceQuota whose limits apply to the Space. If
+	// the SpaceQuota and Quota both limit a resource, the lower limit is used.
+	// +optional
+	QuotaRef *corev1.LocalObjectReference `json:"quotaRef,omitempty"`
+
+	// OrgRef references the Org the Space belongs to. The Org's roles,
+	// domains, and default SpaceQuota apply to the Space.
+	// +optional
+	OrgRef *corev1.LocalObjectReference `json:"orgRef,omitempty"`
 }
 
 // SpaceSpecBuildConfig holds fields for managing building.
@@ -144,6 +165,22 @@ type SpaceSpecNetworkConfig struct {
 
 	// BuildNetworkPolicy holds the default network policy for builds.
 	BuildNetworkPolicy SpaceSpecNetworkConfigPolicy `json:"buildNetworkPolicy,omitempty"`
+
+	// AppSecurityGroups references SecurityGroups whose rules allow outbound
+	// traffic from apps when AppNetworkPolicy denies egress.
+	// +optional
+	AppSecurityGroups []corev1.LocalObjectReference `json:"appSecurityGroups,omitempty"`
+
+	// BuildSecurityGroups references SecurityGroups whose rules allow outbound
+	// traffic from builds when BuildNetworkPolicy denies egress.
+	// +optional
+	BuildSecurityGroups []corev1.LocalObjectReference `json:"buildSecurityGroups,omitempty"`
+
+	// InternalDomain is the domain Apps in the Space can be discovered at
+	// directly by other Apps e.g. APP.apps.internal, without going through
+	// an ingress gateway. Discovery is disabled if blank.
+	// +optional
+	InternalDomain string `json:"internalDomain,omitempty"`
 }
 
 // SpaceSpecNetworkConfigPolicy holds the policy for a particular type.
@@ -154,6 +191,42 @@ type SpaceSpecNetworkConfigPolicy struct {
 	Egress string `json:"egress,omitempty"`
 }
 
+// SpaceSpecQuota holds the limits on the resources that can be used in a
+// Space. Resources without a limit can be used freely.
+type SpaceSpecQuota struct {
+	// Memory is the total amount of memory that can be requested by Apps,
+	// Builds, and Tasks in the Space.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+
+	// CPU is the total amount of CPU that can be requested by Apps, Builds,
+	// and Tasks in the Space.
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+
+	// InstanceMemory is the most memory a single App instance can request.
+	// +optional
+	InstanceMemory *resource.Quantity `json:"instanceMemory,omitempty"`
+
+	// Instances is the total number of App instances that can run in the
+	// Space.
+	// +optional
+	Instances *int64 `json:"instances,omitempty"`
+
+	// Apps is the number of Apps that can be created in the Space.
+	// +optional
+	Apps *int64 `json:"apps,omitempty"`
+
+	// Routes is the number of Routes that can be created in the Space.
+	// +optional
+	Routes *int64 `json:"routes,omitempty"`
+
+	// ServiceInstances is the number of service instances that can be created
+	// in the Space.
+	// +optional
+	ServiceInstances *int64 `json:"serviceInstances,omitempty"`
+}
+
 // SpaceDomain stores information about a domain available in a space.
 type SpaceDomain struct {
 	// Domain is the valid domain that can be used in conjunction with a
@@ -165,6 +238,28 @@ type SpaceDomain struct {
 	// Only the kf Namespace is allowed e.g. kf/some-gateway.
 	// See https://istio.io/docs/reference/config/networking/gateway/
 	GatewayName string `json:"gatewayName,omitempty"`
+
+	// Protocol is the type of traffic routed on the domain, either http or
+	// tcp. Defaults to http.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// ReservablePorts are the ports Routes on a tcp domain can use as a
+	// comma separated list of ports or ranges e.g. 1024-1033. The gateway
+	// must listen on the ports.
+	// +optional
+	ReservablePorts string `json:"reservablePorts,omitempty"`
+
+	// TLS configures the certificate used to serve the domain and its
+	// subdomains over HTTPS. TLS can't be set on tcp domains. If Spaces share
+	// the domain, only the oldest Space with TLS configured serves it.
+	// +optional
+	TLS *DomainTLS `json:"tls,omitempty"`
+}
+
+// IsTCP returns true if the domain routes TCP traffic.
+func (sd *SpaceDomain) IsTCP() bool {
+	return sd.Protocol == SpaceDomainProtocolTCP
 }
 
 // StableDeduplicateSpaceDomainList removes SpaceDomain with duplicate domain fields
@@ -202,6 +297,10 @@ type SpaceStatus struct {
 	// IngressGateways contains the list of ingress gateways that could
 	// direct traffic into this Kf space.
 	IngressGateways []corev1.LoadBalancerIngress `json:"ingressGateways"`
+
+	// Quota contains the limits of the Space's quota and how much of each is
+	// used.
+	Quota SpaceStatusQuota `json:"quota,omitempty"`
 }
 
 // FindIngressIP gets the lexicographicaly first IP address from a the
@@ -246,6 +345,22 @@ type SpaceStatusNetworkConfig struct {
 	// Domains sets valid domains that can be used for routes in the space.
 	// +optional
 	Domains []SpaceDomain `json:"domains,omitempty"`
+
+	// DomainCertificates holds the state of certificates for Domains that
+	// have TLS configured.
+	// +optional
+	DomainCertificates []CertificateStatus `json:"domainCertificates,omitempty"`
+}
+
+// SpaceStatusQuota reflects the usage of a Space's quota.
+type SpaceStatusQuota struct {
+	// Hard holds the limits of the quota.
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+
+	// Used holds the amount of each quota resource in use.
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty"`
 }
 
 // SpaceStatusBuildConfig reflects the actual build configuration for the
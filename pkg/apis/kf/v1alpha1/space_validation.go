@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
@@ -48,6 +49,7 @@ func (s *SpaceSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(s.BuildConfig.Validate(ctx).ViaField("buildConfig"))
 	errs = errs.Also(s.NetworkConfig.Validate(ctx).ViaField("networkConfig"))
 	errs = errs.Also(s.RuntimeConfig.Validate(ctx).ViaField("runtimeConfig"))
+	errs = errs.Also(s.Quota.Validate(ctx).ViaField("quota"))
 
 	return errs
 }
@@ -82,11 +84,73 @@ func (s *SpaceSpecNetworkConfig) Validate(ctx context.Context) (errs *apis.Field
 		}
 
 		foundDomains.Insert(domain.Domain)
+		errs = errs.Also(domain.Validate(ctx).ViaFieldIndex("domains", idx))
 	}
 
 	errs = errs.Also(s.ValidateDomainGateways(ctx))
 	errs = errs.Also(s.AppNetworkPolicy.Validate(ctx).ViaField("appNetworkPolicy"))
 	errs = errs.Also(s.BuildNetworkPolicy.Validate(ctx).ViaField("buildNetworkPolicy"))
+	errs = errs.Also(validateSecurityGroupRefs(s.AppSecurityGroups).ViaField("appSecurityGroups"))
+	errs = errs.Also(validateSecurityGroupRefs(s.BuildSecurityGroups).ViaField("buildSecurityGroups"))
+
+	if s.InternalDomain != "" {
+		for _, errMsg := range validation.IsDNS1123Subdomain(s.InternalDomain) {
+			errs = errs.Also(&apis.FieldError{
+				Message: "Invalid internalDomain",
+				Details: errMsg,
+				Paths:   []string{"internalDomain"},
+			})
+		}
+	}
+
+	return errs
+}
+
+func validateSecurityGroupRefs(refs []corev1.LocalObjectReference) (errs *apis.FieldError) {
+	names := sets.NewString()
+	for idx, ref := range refs {
+		switch {
+		case ref.Name == "":
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(idx))
+		case names.Has(ref.Name):
+			errs = errs.Also(errDuplicateValue(ref.Name, "name").ViaIndex(idx))
+		}
+		names.Insert(ref.Name)
+	}
+
+	return errs
+}
+
+// Validate implements apis.Validatable.
+func (sd *SpaceDomain) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch sd.Protocol {
+	case "", SpaceDomainProtocolHTTP:
+		if sd.ReservablePorts != "" {
+			errs = errs.Also(&apis.FieldError{
+				Message: "reservablePorts can only be set on tcp domains",
+				Paths:   []string{"reservablePorts"},
+			})
+		}
+	case SpaceDomainProtocolTCP:
+		if sd.ReservablePorts == "" {
+			errs = errs.Also(apis.ErrMissingField("reservablePorts"))
+		} else if _, err := ParsePortRanges(sd.ReservablePorts); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), "reservablePorts"))
+		}
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(sd.Protocol, "protocol"))
+	}
+
+	if sd.TLS != nil {
+		if sd.IsTCP() {
+			errs = errs.Also(&apis.FieldError{
+				Message: "tls can't be set on tcp domains",
+				Paths:   []string{"tls"},
+			})
+		}
+
+		errs = errs.Also(sd.TLS.Validate(ctx).ViaField("tls"))
+	}
 
 	return errs
 }
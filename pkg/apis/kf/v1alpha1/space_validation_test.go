@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -191,6 +192,47 @@ func TestSpaceValidation(t *testing.T) {
 				ErrInvalidEnumValue("badbldingress", "spec.networkConfig.buildNetworkPolicy.ingress", []string{DenyAllNetworkPolicy, PermitAllNetworkPolicy}),
 			),
 		},
+		"bad security group references": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					BuildConfig: goodBuildConfig,
+					NetworkConfig: SpaceSpecNetworkConfig{
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+						AppSecurityGroups: []corev1.LocalObjectReference{
+							{Name: "dns"},
+							{Name: "dns"},
+						},
+						BuildSecurityGroups: []corev1.LocalObjectReference{
+							{Name: ""},
+						},
+					},
+				},
+			},
+			want: (*apis.FieldError)(nil).Also(
+				errDuplicateValue("dns", "spec.networkConfig.appSecurityGroups[1].name"),
+				apis.ErrMissingField("spec.networkConfig.buildSecurityGroups[0].name"),
+			),
+		},
+		"bad internal domain": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					BuildConfig: goodBuildConfig,
+					NetworkConfig: SpaceSpecNetworkConfig{
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+						InternalDomain:     "Apps_Internal",
+					},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "Invalid internalDomain",
+				Details: "a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')",
+				Paths:   []string{"spec.networkConfig.internalDomain"},
+			},
+		},
 		"custom gateways": {
 			space: &Space{
 				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
@@ -209,6 +251,106 @@ func TestSpaceValidation(t *testing.T) {
 				},
 			},
 		},
+		"tcp domain": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					NetworkConfig: SpaceSpecNetworkConfig{
+						Domains: []SpaceDomain{
+							{
+								Domain:          "tcp.example.com",
+								GatewayName:     "kf/some-gateway",
+								Protocol:        SpaceDomainProtocolTCP,
+								ReservablePorts: "1024-1033,5432",
+							},
+						},
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+					},
+					BuildConfig: goodBuildConfig,
+				},
+			},
+		},
+		"bad domain protocols": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					NetworkConfig: SpaceSpecNetworkConfig{
+						Domains: []SpaceDomain{
+							{
+								Domain:      "tcp.example.com",
+								GatewayName: "kf/some-gateway",
+								Protocol:    SpaceDomainProtocolTCP,
+							},
+							{
+								Domain:          "http.example.com",
+								GatewayName:     "kf/some-gateway",
+								ReservablePorts: "1024",
+							},
+							{
+								Domain:      "udp.example.com",
+								GatewayName: "kf/some-gateway",
+								Protocol:    "udp",
+							},
+							{
+								Domain:          "bad-ports.example.com",
+								GatewayName:     "kf/some-gateway",
+								Protocol:        SpaceDomainProtocolTCP,
+								ReservablePorts: "2000-1000",
+							},
+						},
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+					},
+					BuildConfig: goodBuildConfig,
+				},
+			},
+			want: apis.ErrMissingField("spec.networkConfig.domains[0].reservablePorts").Also(
+				&apis.FieldError{
+					Message: "reservablePorts can only be set on tcp domains",
+					Paths:   []string{"spec.networkConfig.domains[1].reservablePorts"},
+				},
+				apis.ErrInvalidValue("udp", "spec.networkConfig.domains[2].protocol"),
+				apis.ErrInvalidValue(`invalid port range "2000-1000": start is after end`, "spec.networkConfig.domains[3].reservablePorts"),
+			),
+		},
+		"bad domain tls": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					NetworkConfig: SpaceSpecNetworkConfig{
+						Domains: []SpaceDomain{
+							{
+								Domain:      "example.com",
+								GatewayName: "kf/some-gateway",
+								TLS:         &DomainTLS{SecretName: "example-cert"},
+							},
+							{
+								Domain:          "tcp.example.com",
+								GatewayName:     "kf/some-gateway",
+								Protocol:        SpaceDomainProtocolTCP,
+								ReservablePorts: "1024",
+								TLS:             &DomainTLS{IssuerName: SelfSignedIssuerName},
+							},
+							{
+								Domain:      "empty.example.com",
+								GatewayName: "kf/some-gateway",
+								TLS:         &DomainTLS{},
+							},
+						},
+						AppNetworkPolicy:   goodNetworkPolicy,
+						BuildNetworkPolicy: goodNetworkPolicy,
+					},
+					BuildConfig: goodBuildConfig,
+				},
+			},
+			want: (&apis.FieldError{
+				Message: "tls can't be set on tcp domains",
+				Paths:   []string{"spec.networkConfig.domains[1].tls"},
+			}).Also(
+				apis.ErrMissingOneOf("secretName", "issuerName").ViaField("tls").ViaFieldIndex("spec.networkConfig.domains", 2),
+			),
+		},
 		"custom gateways missing gatewayName": {
 			space: &Space{
 				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
@@ -0,0 +1,29 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable.
+func (q *SpaceQuota) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(apis.ValidateObjectMetadata(q.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(q.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+	return errs
+}
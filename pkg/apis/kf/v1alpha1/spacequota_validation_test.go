@@ -0,0 +1,59 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+)
+
+func TestSpaceQuota_Validate(t *testing.T) {
+	cases := map[string]struct {
+		quota SpaceQuota
+		want  *apis.FieldError
+	}{
+		"valid": {
+			quota: SpaceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "small"},
+				Spec: SpaceSpecQuota{
+					Memory: quantityPtr("10Gi"),
+					Apps:   ptr.Int64(5),
+				},
+			},
+		},
+		"negative limit": {
+			quota: SpaceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "small"},
+				Spec: SpaceSpecQuota{
+					Apps: ptr.Int64(-1),
+				},
+			},
+			want: apis.ErrInvalidValue(-1, "spec.apps"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.quota.Validate(context.Background())
+
+			testutil.AssertEqual(t, "errors", tc.want.Error(), got.Error())
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SelfSignedIssuerName is a special issuer that makes Kf issue a
+	// self-signed certificate itself. It stands in for an ACME issuer on
+	// clusters without cert-manager e.g. for local development.
+	SelfSignedIssuerName = "kf-self-signed"
+
+	// TLSSecretHostsAnnotation holds the hosts a Kf issued certificate was
+	// issued for.
+	TLSSecretHostsAnnotation = "kf.dev/tls-hosts"
+)
+
+// DomainTLS configures the certificate used to serve a domain or Route over
+// HTTPS. Exactly one of SecretName or IssuerName must be set.
+type DomainTLS struct {
+	// SecretName is the name of a kubernetes.io/tls Secret in the Space that
+	// holds the certificate.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// IssuerName is the name of a cert-manager ClusterIssuer e.g. an ACME
+	// issuer used to issue the certificate. If set to kf-self-signed, Kf
+	// issues a self-signed certificate instead.
+	// +optional
+	IssuerName string `json:"issuerName,omitempty"`
+}
+
+// CertificateSecretName returns the name of the Secret in the Space holding
+// the certificate for the given host.
+func (tls *DomainTLS) CertificateSecretName(host string) string {
+	if tls.SecretName != "" {
+		return tls.SecretName
+	}
+
+	return GenerateName(host, "tls")
+}
+
+// IsSelfSigned returns true if Kf issues the certificate itself.
+func (tls *DomainTLS) IsSelfSigned() bool {
+	return tls.SecretName == "" && tls.IssuerName == SelfSignedIssuerName
+}
+
+// CertificateStatus holds the state of a certificate used to serve HTTPS.
+type CertificateStatus struct {
+	// Host is the host the certificate is served for.
+	Host string `json:"host"`
+
+	// SecretName is the name of the Secret in the Space holding the
+	// certificate.
+	SecretName string `json:"secretName,omitempty"`
+
+	// NotAfter is the time the certificate expires. It's unset until the
+	// certificate has been issued.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable.
+func (tls *DomainTLS) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch {
+	case tls.SecretName == "" && tls.IssuerName == "":
+		errs = errs.Also(apis.ErrMissingOneOf("secretName", "issuerName"))
+	case tls.SecretName != "" && tls.IssuerName != "":
+		errs = errs.Also(apis.ErrMultipleOneOf("secretName", "issuerName"))
+	}
+
+	if tls.SecretName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(tls.SecretName) {
+			errs = errs.Also(&apis.FieldError{
+				Message: "Invalid secretName",
+				Details: msg,
+				Paths:   []string{"secretName"},
+			})
+		}
+	}
+
+	return errs
+}
@@ -97,6 +97,10 @@ type SingleConditionManager interface {
 	// MarkSuccess marks the condition as being successfully reconciled.
 	MarkSuccess()
 
+	// MarkSuccessWithReason marks the condition as being successfully
+	// reconciled with the given reason and message format.
+	MarkSuccessWithReason(reason, messageFormat string, messageA ...interface{})
+
 	// TimeSinceTransition returns the time since last transition for the resource.
 	// If the resource hasn't transitioned, the time is zero.
 	TimeSinceTransition() time.Duration
@@ -191,6 +195,12 @@ func (ci *conditionImpl) MarkSuccess() {
 	ci.manager.MarkTrue(ci.destination)
 }
 
+// MarkSuccessWithReason marks the source as being successfully reconciled
+// with the given reason and message format.
+func (ci *conditionImpl) MarkSuccessWithReason(reason, messageFormat string, messageA ...interface{}) {
+	ci.manager.MarkTrueWithReason(ci.destination, reason, messageFormat, messageA...)
+}
+
 // IsPending returns whether the condition's state is final or in progress.
 func (ci *conditionImpl) IsPending() bool {
 	cond := ci.manager.GetCondition(ci.destination)
@@ -86,6 +86,10 @@ const (
 	// SpaceConditionIAMPolicyReady is set when the child
 	// resource(s) IAMPolicy is/are ready.
 	SpaceConditionIAMPolicyReady apis.ConditionType = "IAMPolicyReady"
+
+	// SpaceConditionQuotaReady is set when the child
+	// resource(s) Quota is/are ready.
+	SpaceConditionQuotaReady apis.ConditionType = "QuotaReady"
 )
 
 func (status *SpaceStatus) manage() apis.ConditionManager {
@@ -105,6 +109,7 @@ func (status *SpaceStatus) manage() apis.ConditionManager {
 		SpaceConditionClusterRoleReady,
 		SpaceConditionClusterRoleBindingsReady,
 		SpaceConditionIAMPolicyReady,
+		SpaceConditionQuotaReady,
 	).Manage(status)
 }
 
@@ -204,6 +209,11 @@ func (status *SpaceStatus) IAMPolicyCondition() SingleConditionManager {
 	return NewSingleConditionManager(status.manage(), SpaceConditionIAMPolicyReady, "IAMPolicy")
 }
 
+// QuotaCondition gets a manager for the state of the child resource.
+func (status *SpaceStatus) QuotaCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), SpaceConditionQuotaReady, "Quota")
+}
+
 func (status *SpaceStatus) duck() *duckv1beta1.Status {
 	return &status.Status
 }
@@ -22,6 +22,7 @@ package v1alpha1
 import (
 	config "github.com/google/kf/v2/pkg/apis/kf/config"
 	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -76,6 +77,42 @@ func (in *AppAutoscalingRule) DeepCopy() *AppAutoscalingRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppAutoscalingSchedule) DeepCopyInto(out *AppAutoscalingSchedule) {
+	*out = *in
+	if in.DaysOfWeek != nil {
+		in, out := &in.DaysOfWeek, &out.DaysOfWeek
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.DaysOfMonth != nil {
+		in, out := &in.DaysOfMonth, &out.DaysOfMonth
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppAutoscalingSchedule.
+func (in *AppAutoscalingSchedule) DeepCopy() *AppAutoscalingSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AppAutoscalingSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppList) DeepCopyInto(out *AppList) {
 	*out = *in
@@ -109,6 +146,133 @@ func (in *AppList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppNetworkPolicy) DeepCopyInto(out *AppNetworkPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppNetworkPolicy.
+func (in *AppNetworkPolicy) DeepCopy() *AppNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AppNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AppNetworkPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppNetworkPolicyList) DeepCopyInto(out *AppNetworkPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AppNetworkPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppNetworkPolicyList.
+func (in *AppNetworkPolicyList) DeepCopy() *AppNetworkPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AppNetworkPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AppNetworkPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppNetworkPolicySource) DeepCopyInto(out *AppNetworkPolicySource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppNetworkPolicySource.
+func (in *AppNetworkPolicySource) DeepCopy() *AppNetworkPolicySource {
+	if in == nil {
+		return nil
+	}
+	out := new(AppNetworkPolicySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppNetworkPolicySpec) DeepCopyInto(out *AppNetworkPolicySpec) {
+	*out = *in
+	out.Source = in.Source
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppNetworkPolicySpec.
+func (in *AppNetworkPolicySpec) DeepCopy() *AppNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AppNetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppNetworkPolicyStatus) DeepCopyInto(out *AppNetworkPolicyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppNetworkPolicyStatus.
+func (in *AppNetworkPolicyStatus) DeepCopy() *AppNetworkPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AppNetworkPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppProcessStatus) DeepCopyInto(out *AppProcessStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppProcessStatus.
+func (in *AppProcessStatus) DeepCopy() *AppProcessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AppProcessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppRef) DeepCopyInto(out *AppRef) {
 	*out = *in
@@ -125,10 +289,26 @@ func (in *AppRef) DeepCopy() *AppRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppRolloutStatus) DeepCopyInto(out *AppRolloutStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppRolloutStatus.
+func (in *AppRolloutStatus) DeepCopy() *AppRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AppRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppRouteStatus) DeepCopyInto(out *AppRouteStatus) {
 	*out = *in
-	out.QualifiedRouteBinding = in.QualifiedRouteBinding
+	in.QualifiedRouteBinding.DeepCopyInto(&out.QualifiedRouteBinding)
 	out.VirtualService = in.VirtualService
 	return
 }
@@ -156,6 +336,18 @@ func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(AppSpecRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Processes != nil {
+		in, out := &in.Processes, &out.Processes
+		*out = make([]AppSpecProcess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -189,6 +381,13 @@ func (in *AppSpecAutoscaling) DeepCopyInto(out *AppSpecAutoscaling) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]AppAutoscalingSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -260,6 +459,85 @@ func (in *AppSpecInstances) DeepCopy() *AppSpecInstances {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppSpecProcess) DeepCopyInto(out *AppSpecProcess) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSpecProcess.
+func (in *AppSpecProcess) DeepCopy() *AppSpecProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(AppSpecProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppSpecRollout) DeepCopyInto(out *AppSpecRollout) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSpecRollout.
+func (in *AppSpecRollout) DeepCopy() *AppSpecRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(AppSpecRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppSpecTemplate) DeepCopyInto(out *AppSpecTemplate) {
 	*out = *in
@@ -297,7 +575,9 @@ func (in *AppStatus) DeepCopyInto(out *AppStatus) {
 	if in.Routes != nil {
 		in, out := &in.Routes, &out.Routes
 		*out = make([]AppRouteStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.URLs != nil {
 		in, out := &in.URLs, &out.URLs
@@ -318,6 +598,23 @@ func (in *AppStatus) DeepCopyInto(out *AppStatus) {
 	}
 	in.Instances.DeepCopyInto(&out.Instances)
 	out.Tasks = in.Tasks
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(AppRolloutStatus)
+		**out = **in
+	}
+	if in.AutoscalingSchedules != nil {
+		in, out := &in.AutoscalingSchedules, &out.AutoscalingSchedules
+		*out = make([]AutoscalingScheduleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Processes != nil {
+		in, out := &in.Processes, &out.Processes
+		*out = make([]AppProcessStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -403,6 +700,30 @@ func (in *AutoscalingRuleStatus) DeepCopy() *AutoscalingRuleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingScheduleStatus) DeepCopyInto(out *AutoscalingScheduleStatus) {
+	*out = *in
+	if in.NextStartTime != nil {
+		in, out := &in.NextStartTime, &out.NextStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextEndTime != nil {
+		in, out := &in.NextEndTime, &out.NextEndTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingScheduleStatus.
+func (in *AutoscalingScheduleStatus) DeepCopy() *AutoscalingScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BindingOSBStatus) DeepCopyInto(out *BindingOSBStatus) {
 	*out = *in
@@ -460,6 +781,11 @@ func (in *BindingType) DeepCopyInto(out *BindingType) {
 	if in.Route != nil {
 		in, out := &in.Route, &out.Route
 		*out = new(RouteRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceKey != nil {
+		in, out := &in.ServiceKey, &out.ServiceKey
+		*out = new(ServiceKeyRef)
 		**out = **in
 	}
 	return
@@ -702,6 +1028,26 @@ func (in *BuildTaskRef) DeepCopy() *BuildTaskRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterServiceBroker) DeepCopyInto(out *ClusterServiceBroker) {
 	*out = *in
@@ -768,6 +1114,13 @@ func (in *ClusterServiceBrokerSpec) DeepCopyInto(out *ClusterServiceBrokerSpec)
 	*out = *in
 	in.CommonServiceBrokerSpec.DeepCopyInto(&out.CommonServiceBrokerSpec)
 	out.Credentials = in.Credentials
+	if in.PlanVisibilities != nil {
+		in, out := &in.PlanVisibilities, &out.PlanVisibilities
+		*out = make([]ServicePlanVisibility, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -784,6 +1137,11 @@ func (in *ClusterServiceBrokerSpec) DeepCopy() *ClusterServiceBrokerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonServiceBrokerSpec) DeepCopyInto(out *CommonServiceBrokerSpec) {
 	*out = *in
+	if in.CatalogRefreshInterval != nil {
+		in, out := &in.CatalogRefreshInterval, &out.CatalogRefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.VolumeBrokerSpec != nil {
 		in, out := &in.VolumeBrokerSpec, &out.VolumeBrokerSpec
 		*out = new(VolumeBrokerSpec)
@@ -806,6 +1164,10 @@ func (in *CommonServiceBrokerSpec) DeepCopy() *CommonServiceBrokerSpec {
 func (in *CommonServiceBrokerStatus) DeepCopyInto(out *CommonServiceBrokerStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.LastCatalogRefreshTime != nil {
+		in, out := &in.LastCatalogRefreshTime, &out.LastCatalogRefreshTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Services != nil {
 		in, out := &in.Services, &out.Services
 		*out = make([]ServiceOffering, len(*in))
@@ -826,6 +1188,22 @@ func (in *CommonServiceBrokerStatus) DeepCopy() *CommonServiceBrokerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainTLS) DeepCopyInto(out *DomainTLS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainTLS.
+func (in *DomainTLS) DeepCopy() *DomainTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceStatus) DeepCopyInto(out *InstanceStatus) {
 	*out = *in
@@ -955,6 +1333,16 @@ func (in *OSBStatus) DeepCopyInto(out *OSBStatus) {
 		*out = new(OSBState)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Updating != nil {
+		in, out := &in.Updating, &out.Updating
+		*out = new(OSBState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateFailed != nil {
+		in, out := &in.UpdateFailed, &out.UpdateFailed
+		*out = new(OSBState)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -988,10 +1376,124 @@ func (in ObjectReferences) DeepCopy() ObjectReferences {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Org) DeepCopyInto(out *Org) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Org.
+func (in *Org) DeepCopy() *Org {
+	if in == nil {
+		return nil
+	}
+	out := new(Org)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Org) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgList) DeepCopyInto(out *OrgList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Org, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgList.
+func (in *OrgList) DeepCopy() *OrgList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrgList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgSpec) DeepCopyInto(out *OrgSpec) {
+	*out = *in
+	if in.Managers != nil {
+		in, out := &in.Managers, &out.Managers
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Auditors != nil {
+		in, out := &in.Auditors, &out.Auditors
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]SpaceDomain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QuotaRef != nil {
+		in, out := &in.QuotaRef, &out.QuotaRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgSpec.
+func (in *OrgSpec) DeepCopy() *OrgSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortRange) DeepCopyInto(out *PortRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortRange.
+func (in *PortRange) DeepCopy() *PortRange {
+	if in == nil {
+		return nil
+	}
+	out := new(PortRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QualifiedRouteBinding) DeepCopyInto(out *QualifiedRouteBinding) {
 	*out = *in
-	out.Source = in.Source
+	in.Source.DeepCopyInto(&out.Source)
 	out.Destination = in.Destination
 	return
 }
@@ -1011,7 +1513,7 @@ func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -1034,6 +1536,47 @@ func (in *Route) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteCORSPolicy) DeepCopyInto(out *RouteCORSPolicy) {
+	*out = *in
+	if in.AllowOrigins != nil {
+		in, out := &in.AllowOrigins, &out.AllowOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteCORSPolicy.
+func (in *RouteCORSPolicy) DeepCopy() *RouteCORSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteCORSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteDestination) DeepCopyInto(out *RouteDestination) {
 	*out = *in
@@ -1050,6 +1593,41 @@ func (in *RouteDestination) DeepCopy() *RouteDestination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteHeaderOperations) DeepCopyInto(out *RouteHeaderOperations) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteHeaderOperations.
+func (in *RouteHeaderOperations) DeepCopy() *RouteHeaderOperations {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteHeaderOperations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteList) DeepCopyInto(out *RouteList) {
 	*out = *in
@@ -1083,18 +1661,127 @@ func (in *RouteList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMatch) DeepCopyInto(out *RouteMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMatch.
+func (in *RouteMatch) DeepCopy() *RouteMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutePolicy) DeepCopyInto(out *RoutePolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(RouteRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(RouteCORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RouteRateLimit)
+		**out = **in
+	}
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = new(RouteHeaderOperations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = new(RouteHeaderOperations)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutePolicy.
+func (in *RoutePolicy) DeepCopy() *RoutePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRateLimit) DeepCopyInto(out *RouteRateLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRateLimit.
+func (in *RouteRateLimit) DeepCopy() *RouteRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteRef) DeepCopyInto(out *RouteRef) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]RouteMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryParams != nil {
+		in, out := &in.QueryParams, &out.QueryParams
+		*out = make([]RouteMatch, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRef.
+func (in *RouteRef) DeepCopy() *RouteRef {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteRetryPolicy) DeepCopyInto(out *RouteRetryPolicy) {
+	*out = *in
+	if in.PerTryTimeout != nil {
+		in, out := &in.PerTryTimeout, &out.PerTryTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRef.
-func (in *RouteRef) DeepCopy() *RouteRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteRetryPolicy.
+func (in *RouteRetryPolicy) DeepCopy() *RouteRetryPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(RouteRef)
+	out := new(RouteRetryPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1102,7 +1789,7 @@ func (in *RouteRef) DeepCopy() *RouteRef {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteServiceBinding) DeepCopyInto(out *RouteServiceBinding) {
 	*out = *in
-	out.Source = in.Source
+	in.Source.DeepCopyInto(&out.Source)
 	if in.Destination != nil {
 		in, out := &in.Destination, &out.Destination
 		*out = new(RouteServiceURL)
@@ -1161,7 +1848,17 @@ func (in *RouteServiceURL) DeepCopy() *RouteServiceURL {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 	*out = *in
-	out.RouteSpecFields = in.RouteSpecFields
+	in.RouteSpecFields.DeepCopyInto(&out.RouteSpecFields)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DomainTLS)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(RoutePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1178,6 +1875,16 @@ func (in *RouteSpec) DeepCopy() *RouteSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteSpecFields) DeepCopyInto(out *RouteSpecFields) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]RouteMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryParams != nil {
+		in, out := &in.QueryParams, &out.QueryParams
+		*out = make([]RouteMatch, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1196,7 +1903,9 @@ func (in RouteSpecFieldsSlice) DeepCopyInto(out *RouteSpecFieldsSlice) {
 	{
 		in := &in
 		*out = make(RouteSpecFieldsSlice, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 		return
 	}
 }
@@ -1215,7 +1924,7 @@ func (in RouteSpecFieldsSlice) DeepCopy() RouteSpecFieldsSlice {
 func (in *RouteStatus) DeepCopyInto(out *RouteStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
-	out.RouteSpecFields = in.RouteSpecFields
+	in.RouteSpecFields.DeepCopyInto(&out.RouteSpecFields)
 	out.VirtualService = in.VirtualService
 	if in.Bindings != nil {
 		in, out := &in.Bindings, &out.Bindings
@@ -1228,6 +1937,11 @@ func (in *RouteStatus) DeepCopyInto(out *RouteStatus) {
 		copy(*out, *in)
 	}
 	out.RouteService = in.RouteService
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(CertificateStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1244,7 +1958,7 @@ func (in *RouteStatus) DeepCopy() *RouteStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteWeightBinding) DeepCopyInto(out *RouteWeightBinding) {
 	*out = *in
-	out.RouteSpecFields = in.RouteSpecFields
+	in.RouteSpecFields.DeepCopyInto(&out.RouteSpecFields)
 	if in.Weight != nil {
 		in, out := &in.Weight, &out.Weight
 		*out = new(int32)
@@ -1326,6 +2040,103 @@ func (in *ScaleList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroup.
+func (in *SecurityGroup) DeepCopy() *SecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupList) DeepCopyInto(out *SecurityGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecurityGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupList.
+func (in *SecurityGroupList) DeepCopy() *SecurityGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRule.
+func (in *SecurityGroupRule) DeepCopy() *SecurityGroupRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupSpec) DeepCopyInto(out *SecurityGroupSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]SecurityGroupRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupSpec.
+func (in *SecurityGroupSpec) DeepCopy() *SecurityGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBroker) DeepCopyInto(out *ServiceBroker) {
 	*out = *in
@@ -1607,6 +2418,11 @@ func (in *ServiceInstanceSpec) DeepCopyInto(out *ServiceInstanceSpec) {
 		copy(*out, *in)
 	}
 	out.ParametersFrom = in.ParametersFrom
+	if in.SharedSpaces != nil {
+		in, out := &in.SharedSpaces, &out.SharedSpaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1649,6 +2465,22 @@ func (in *ServiceInstanceStatus) DeepCopy() *ServiceInstanceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceKeyRef) DeepCopyInto(out *ServiceKeyRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceKeyRef.
+func (in *ServiceKeyRef) DeepCopy() *ServiceKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceOffering) DeepCopyInto(out *ServiceOffering) {
 	*out = *in
@@ -1691,6 +2523,27 @@ func (in *ServicePlan) DeepCopy() *ServicePlan {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePlanVisibility) DeepCopyInto(out *ServicePlanVisibility) {
+	*out = *in
+	if in.Spaces != nil {
+		in, out := &in.Spaces, &out.Spaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePlanVisibility.
+func (in *ServicePlanVisibility) DeepCopy() *ServicePlanVisibility {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePlanVisibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceType) DeepCopyInto(out *ServiceType) {
 	*out = *in
@@ -1870,6 +2723,11 @@ func (in *Space) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceDomain) DeepCopyInto(out *SpaceDomain) {
 	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DomainTLS)
+		**out = **in
+	}
 	return
 }
 
@@ -1888,7 +2746,9 @@ func (in SpaceDomains) DeepCopyInto(out *SpaceDomains) {
 	{
 		in := &in
 		*out = make(SpaceDomains, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 		return
 	}
 }
@@ -1936,12 +2796,83 @@ func (in *SpaceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceQuota) DeepCopyInto(out *SpaceQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceQuota.
+func (in *SpaceQuota) DeepCopy() *SpaceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpaceQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceQuotaList) DeepCopyInto(out *SpaceQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpaceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceQuotaList.
+func (in *SpaceQuotaList) DeepCopy() *SpaceQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpaceQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceSpec) DeepCopyInto(out *SpaceSpec) {
 	*out = *in
 	in.BuildConfig.DeepCopyInto(&out.BuildConfig)
 	in.RuntimeConfig.DeepCopyInto(&out.RuntimeConfig)
 	in.NetworkConfig.DeepCopyInto(&out.NetworkConfig)
+	in.Quota.DeepCopyInto(&out.Quota)
+	if in.QuotaRef != nil {
+		in, out := &in.QuotaRef, &out.QuotaRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.OrgRef != nil {
+		in, out := &in.OrgRef, &out.OrgRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
@@ -1989,10 +2920,22 @@ func (in *SpaceSpecNetworkConfig) DeepCopyInto(out *SpaceSpecNetworkConfig) {
 	if in.Domains != nil {
 		in, out := &in.Domains, &out.Domains
 		*out = make([]SpaceDomain, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	out.AppNetworkPolicy = in.AppNetworkPolicy
 	out.BuildNetworkPolicy = in.BuildNetworkPolicy
+	if in.AppSecurityGroups != nil {
+		in, out := &in.AppSecurityGroups, &out.AppSecurityGroups
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.BuildSecurityGroups != nil {
+		in, out := &in.BuildSecurityGroups, &out.BuildSecurityGroups
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2022,6 +2965,57 @@ func (in *SpaceSpecNetworkConfigPolicy) DeepCopy() *SpaceSpecNetworkConfigPolicy
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceSpecQuota) DeepCopyInto(out *SpaceSpecQuota) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.InstanceMemory != nil {
+		in, out := &in.InstanceMemory, &out.InstanceMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Apps != nil {
+		in, out := &in.Apps, &out.Apps
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ServiceInstances != nil {
+		in, out := &in.ServiceInstances, &out.ServiceInstances
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceSpecQuota.
+func (in *SpaceSpecQuota) DeepCopy() *SpaceSpecQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceSpecQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceSpecRuntimeConfig) DeepCopyInto(out *SpaceSpecRuntimeConfig) {
 	*out = *in
@@ -2066,6 +3060,7 @@ func (in *SpaceStatus) DeepCopyInto(out *SpaceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.Quota.DeepCopyInto(&out.Quota)
 	return
 }
 
@@ -2127,7 +3122,16 @@ func (in *SpaceStatusNetworkConfig) DeepCopyInto(out *SpaceStatusNetworkConfig)
 	if in.Domains != nil {
 		in, out := &in.Domains, &out.Domains
 		*out = make([]SpaceDomain, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DomainCertificates != nil {
+		in, out := &in.DomainCertificates, &out.DomainCertificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -2142,6 +3146,36 @@ func (in *SpaceStatusNetworkConfig) DeepCopy() *SpaceStatusNetworkConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceStatusQuota) DeepCopyInto(out *SpaceStatusQuota) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceStatusQuota.
+func (in *SpaceStatusQuota) DeepCopy() *SpaceStatusQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceStatusQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceStatusRuntimeConfig) DeepCopyInto(out *SpaceStatusRuntimeConfig) {
 	*out = *in
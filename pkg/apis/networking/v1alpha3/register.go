@@ -47,6 +47,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(
 		SchemeGroupVersion,
 		&VirtualService{},
+		&EnvoyFilter{},
+		&EnvoyFilterList{},
+		&Gateway{},
+		&GatewayList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -30,6 +30,10 @@ func (c *FakeKfV1alpha1) Apps(namespace string) v1alpha1.AppInterface {
 	return &FakeApps{c, namespace}
 }
 
+func (c *FakeKfV1alpha1) AppNetworkPolicies(namespace string) v1alpha1.AppNetworkPolicyInterface {
+	return &FakeAppNetworkPolicies{c, namespace}
+}
+
 func (c *FakeKfV1alpha1) Builds(namespace string) v1alpha1.BuildInterface {
 	return &FakeBuilds{c, namespace}
 }
@@ -38,6 +42,10 @@ func (c *FakeKfV1alpha1) ClusterServiceBrokers() v1alpha1.ClusterServiceBrokerIn
 	return &FakeClusterServiceBrokers{c}
 }
 
+func (c *FakeKfV1alpha1) Orgs() v1alpha1.OrgInterface {
+	return &FakeOrgs{c}
+}
+
 func (c *FakeKfV1alpha1) Routes(namespace string) v1alpha1.RouteInterface {
 	return &FakeRoutes{c, namespace}
 }
@@ -46,6 +54,10 @@ func (c *FakeKfV1alpha1) Scales(namespace string) v1alpha1.ScaleInterface {
 	return &FakeScales{c, namespace}
 }
 
+func (c *FakeKfV1alpha1) SecurityGroups() v1alpha1.SecurityGroupInterface {
+	return &FakeSecurityGroups{c}
+}
+
 func (c *FakeKfV1alpha1) ServiceBrokers(namespace string) v1alpha1.ServiceBrokerInterface {
 	return &FakeServiceBrokers{c, namespace}
 }
@@ -66,6 +78,10 @@ func (c *FakeKfV1alpha1) Spaces() v1alpha1.SpaceInterface {
 	return &FakeSpaces{c}
 }
 
+func (c *FakeKfV1alpha1) SpaceQuotas() v1alpha1.SpaceQuotaInterface {
+	return &FakeSpaceQuotas{c}
+}
+
 func (c *FakeKfV1alpha1) Tasks(namespace string) v1alpha1.TaskInterface {
 	return &FakeTasks{c, namespace}
 }
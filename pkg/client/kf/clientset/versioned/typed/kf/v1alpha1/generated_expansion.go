@@ -18,14 +18,20 @@ package v1alpha1
 
 type AppExpansion interface{}
 
+type AppNetworkPolicyExpansion interface{}
+
 type BuildExpansion interface{}
 
 type ClusterServiceBrokerExpansion interface{}
 
+type OrgExpansion interface{}
+
 type RouteExpansion interface{}
 
 type ScaleExpansion interface{}
 
+type SecurityGroupExpansion interface{}
+
 type ServiceBrokerExpansion interface{}
 
 type ServiceInstanceExpansion interface{}
@@ -36,6 +42,8 @@ type SourcePackageExpansion interface{}
 
 type SpaceExpansion interface{}
 
+type SpaceQuotaExpansion interface{}
+
 type TaskExpansion interface{}
 
 type TaskScheduleExpansion interface{}
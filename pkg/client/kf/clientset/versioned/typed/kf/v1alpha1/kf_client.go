@@ -27,15 +27,19 @@ import (
 type KfV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	AppsGetter
+	AppNetworkPoliciesGetter
 	BuildsGetter
 	ClusterServiceBrokersGetter
+	OrgsGetter
 	RoutesGetter
 	ScalesGetter
+	SecurityGroupsGetter
 	ServiceBrokersGetter
 	ServiceInstancesGetter
 	ServiceInstanceBindingsGetter
 	SourcePackagesGetter
 	SpacesGetter
+	SpaceQuotasGetter
 	TasksGetter
 	TaskSchedulesGetter
 }
@@ -49,6 +53,10 @@ func (c *KfV1alpha1Client) Apps(namespace string) AppInterface {
 	return newApps(c, namespace)
 }
 
+func (c *KfV1alpha1Client) AppNetworkPolicies(namespace string) AppNetworkPolicyInterface {
+	return newAppNetworkPolicies(c, namespace)
+}
+
 func (c *KfV1alpha1Client) Builds(namespace string) BuildInterface {
 	return newBuilds(c, namespace)
 }
@@ -57,6 +65,10 @@ func (c *KfV1alpha1Client) ClusterServiceBrokers() ClusterServiceBrokerInterface
 	return newClusterServiceBrokers(c)
 }
 
+func (c *KfV1alpha1Client) Orgs() OrgInterface {
+	return newOrgs(c)
+}
+
 func (c *KfV1alpha1Client) Routes(namespace string) RouteInterface {
 	return newRoutes(c, namespace)
 }
@@ -65,6 +77,10 @@ func (c *KfV1alpha1Client) Scales(namespace string) ScaleInterface {
 	return newScales(c, namespace)
 }
 
+func (c *KfV1alpha1Client) SecurityGroups() SecurityGroupInterface {
+	return newSecurityGroups(c)
+}
+
 func (c *KfV1alpha1Client) ServiceBrokers(namespace string) ServiceBrokerInterface {
 	return newServiceBrokers(c, namespace)
 }
@@ -85,6 +101,10 @@ func (c *KfV1alpha1Client) Spaces() SpaceInterface {
 	return newSpaces(c)
 }
 
+func (c *KfV1alpha1Client) SpaceQuotas() SpaceQuotaInterface {
+	return newSpaceQuotas(c)
+}
+
 func (c *KfV1alpha1Client) Tasks(namespace string) TaskInterface {
 	return newTasks(c, namespace)
 }
@@ -53,14 +53,20 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=kf.dev, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("apps"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Apps().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("appnetworkpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().AppNetworkPolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("builds"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Builds().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clusterservicebrokers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().ClusterServiceBrokers().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("orgs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Orgs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("routes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Routes().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("scales"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Scales().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("securitygroups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().SecurityGroups().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("servicebrokers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().ServiceBrokers().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("serviceinstances"):
@@ -71,6 +77,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().SourcePackages().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("spaces"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Spaces().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("spacequotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().SpaceQuotas().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("tasks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Tasks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("taskschedules"):
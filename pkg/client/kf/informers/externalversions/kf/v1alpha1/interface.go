@@ -24,14 +24,20 @@ import (
 type Interface interface {
 	// Apps returns a AppInformer.
 	Apps() AppInformer
+	// AppNetworkPolicies returns a AppNetworkPolicyInformer.
+	AppNetworkPolicies() AppNetworkPolicyInformer
 	// Builds returns a BuildInformer.
 	Builds() BuildInformer
 	// ClusterServiceBrokers returns a ClusterServiceBrokerInformer.
 	ClusterServiceBrokers() ClusterServiceBrokerInformer
+	// Orgs returns an OrgInformer.
+	Orgs() OrgInformer
 	// Routes returns a RouteInformer.
 	Routes() RouteInformer
 	// Scales returns a ScaleInformer.
 	Scales() ScaleInformer
+	// SecurityGroups returns a SecurityGroupInformer.
+	SecurityGroups() SecurityGroupInformer
 	// ServiceBrokers returns a ServiceBrokerInformer.
 	ServiceBrokers() ServiceBrokerInformer
 	// ServiceInstances returns a ServiceInstanceInformer.
@@ -42,6 +48,8 @@ type Interface interface {
 	SourcePackages() SourcePackageInformer
 	// Spaces returns a SpaceInformer.
 	Spaces() SpaceInformer
+	// SpaceQuotas returns a SpaceQuotaInformer.
+	SpaceQuotas() SpaceQuotaInformer
 	// Tasks returns a TaskInformer.
 	Tasks() TaskInformer
 	// TaskSchedules returns a TaskScheduleInformer.
@@ -64,6 +72,11 @@ func (v *version) Apps() AppInformer {
 	return &appInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// AppNetworkPolicies returns a AppNetworkPolicyInformer.
+func (v *version) AppNetworkPolicies() AppNetworkPolicyInformer {
+	return &appNetworkPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Builds returns a BuildInformer.
 func (v *version) Builds() BuildInformer {
 	return &buildInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -74,6 +87,11 @@ func (v *version) ClusterServiceBrokers() ClusterServiceBrokerInformer {
 	return &clusterServiceBrokerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// Orgs returns an OrgInformer.
+func (v *version) Orgs() OrgInformer {
+	return &orgInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Routes returns a RouteInformer.
 func (v *version) Routes() RouteInformer {
 	return &routeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -84,6 +102,11 @@ func (v *version) Scales() ScaleInformer {
 	return &scaleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// SecurityGroups returns a SecurityGroupInformer.
+func (v *version) SecurityGroups() SecurityGroupInformer {
+	return &securityGroupInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ServiceBrokers returns a ServiceBrokerInformer.
 func (v *version) ServiceBrokers() ServiceBrokerInformer {
 	return &serviceBrokerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -109,6 +132,11 @@ func (v *version) Spaces() SpaceInformer {
 	return &spaceInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// SpaceQuotas returns a SpaceQuotaInformer.
+func (v *version) SpaceQuotas() SpaceQuotaInformer {
+	return &spaceQuotaInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Tasks returns a TaskInformer.
 func (v *version) Tasks() TaskInformer {
 	return &taskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -240,6 +240,137 @@ func (w *wrapKfV1alpha1AppImpl) Watch(ctx context.Context, opts v1.ListOptions)
 	return nil, errors.New("NYI: Watch")
 }
 
+func (w *wrapKfV1alpha1) AppNetworkPolicies(namespace string) typedkfv1alpha1.AppNetworkPolicyInterface {
+	return &wrapKfV1alpha1AppNetworkPolicyImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kf.dev",
+			Version:  "v1alpha1",
+			Resource: "appnetworkpolicies",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapKfV1alpha1AppNetworkPolicyImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedkfv1alpha1.AppNetworkPolicyInterface = (*wrapKfV1alpha1AppNetworkPolicyImpl)(nil)
+
+func (w *wrapKfV1alpha1AppNetworkPolicyImpl) Create(ctx context.Context, in *v1alpha1.AppNetworkPolicy, opts v1.CreateOptions) (*v1alpha1.AppNetworkPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "AppNetworkPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.AppNetworkPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1AppNetworkPolicyImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapKfV1alpha1AppNetworkPolicyImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKfV1alpha1AppNetworkPolicyImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.AppNetworkPolicy, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.AppNetworkPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1AppNetworkPolicyImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.AppNetworkPolicyList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.AppNetworkPolicyList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1AppNetworkPolicyImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.AppNetworkPolicy, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.AppNetworkPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1AppNetworkPolicyImpl) Update(ctx context.Context, in *v1alpha1.AppNetworkPolicy, opts v1.UpdateOptions) (*v1alpha1.AppNetworkPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "AppNetworkPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.AppNetworkPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1AppNetworkPolicyImpl) UpdateStatus(ctx context.Context, in *v1alpha1.AppNetworkPolicy, opts v1.UpdateOptions) (*v1alpha1.AppNetworkPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "AppNetworkPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.AppNetworkPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1AppNetworkPolicyImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapKfV1alpha1) Builds(namespace string) typedkfv1alpha1.BuildInterface {
 	return &wrapKfV1alpha1BuildImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
@@ -498,6 +629,133 @@ func (w *wrapKfV1alpha1ClusterServiceBrokerImpl) Watch(ctx context.Context, opts
 	return nil, errors.New("NYI: Watch")
 }
 
+func (w *wrapKfV1alpha1) Orgs() typedkfv1alpha1.OrgInterface {
+	return &wrapKfV1alpha1OrgImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kf.dev",
+			Version:  "v1alpha1",
+			Resource: "orgs",
+		}),
+	}
+}
+
+type wrapKfV1alpha1OrgImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+}
+
+var _ typedkfv1alpha1.OrgInterface = (*wrapKfV1alpha1OrgImpl)(nil)
+
+func (w *wrapKfV1alpha1OrgImpl) Create(ctx context.Context, in *v1alpha1.Org, opts v1.CreateOptions) (*v1alpha1.Org, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "Org",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Org{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1OrgImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Delete(ctx, name, opts)
+}
+
+func (w *wrapKfV1alpha1OrgImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKfV1alpha1OrgImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Org, error) {
+	uo, err := w.dyn.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Org{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1OrgImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.OrgList, error) {
+	uo, err := w.dyn.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.OrgList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1OrgImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Org, err error) {
+	uo, err := w.dyn.Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Org{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1OrgImpl) Update(ctx context.Context, in *v1alpha1.Org, opts v1.UpdateOptions) (*v1alpha1.Org, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "Org",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Org{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1OrgImpl) UpdateStatus(ctx context.Context, in *v1alpha1.Org, opts v1.UpdateOptions) (*v1alpha1.Org, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "Org",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Org{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1OrgImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapKfV1alpha1) Routes(namespace string) typedkfv1alpha1.RouteInterface {
 	return &wrapKfV1alpha1RouteImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
@@ -760,6 +1018,133 @@ func (w *wrapKfV1alpha1ScaleImpl) Watch(ctx context.Context, opts v1.ListOptions
 	return nil, errors.New("NYI: Watch")
 }
 
+func (w *wrapKfV1alpha1) SecurityGroups() typedkfv1alpha1.SecurityGroupInterface {
+	return &wrapKfV1alpha1SecurityGroupImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kf.dev",
+			Version:  "v1alpha1",
+			Resource: "securitygroups",
+		}),
+	}
+}
+
+type wrapKfV1alpha1SecurityGroupImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+}
+
+var _ typedkfv1alpha1.SecurityGroupInterface = (*wrapKfV1alpha1SecurityGroupImpl)(nil)
+
+func (w *wrapKfV1alpha1SecurityGroupImpl) Create(ctx context.Context, in *v1alpha1.SecurityGroup, opts v1.CreateOptions) (*v1alpha1.SecurityGroup, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "SecurityGroup",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SecurityGroup{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SecurityGroupImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Delete(ctx, name, opts)
+}
+
+func (w *wrapKfV1alpha1SecurityGroupImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKfV1alpha1SecurityGroupImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.SecurityGroup, error) {
+	uo, err := w.dyn.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SecurityGroup{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SecurityGroupImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.SecurityGroupList, error) {
+	uo, err := w.dyn.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SecurityGroupList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SecurityGroupImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.SecurityGroup, err error) {
+	uo, err := w.dyn.Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SecurityGroup{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SecurityGroupImpl) Update(ctx context.Context, in *v1alpha1.SecurityGroup, opts v1.UpdateOptions) (*v1alpha1.SecurityGroup, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "SecurityGroup",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SecurityGroup{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SecurityGroupImpl) UpdateStatus(ctx context.Context, in *v1alpha1.SecurityGroup, opts v1.UpdateOptions) (*v1alpha1.SecurityGroup, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "SecurityGroup",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SecurityGroup{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SecurityGroupImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapKfV1alpha1) ServiceBrokers(namespace string) typedkfv1alpha1.ServiceBrokerInterface {
 	return &wrapKfV1alpha1ServiceBrokerImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
@@ -1411,6 +1796,133 @@ func (w *wrapKfV1alpha1SpaceImpl) Watch(ctx context.Context, opts v1.ListOptions
 	return nil, errors.New("NYI: Watch")
 }
 
+func (w *wrapKfV1alpha1) SpaceQuotas() typedkfv1alpha1.SpaceQuotaInterface {
+	return &wrapKfV1alpha1SpaceQuotaImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "kf.dev",
+			Version:  "v1alpha1",
+			Resource: "spacequotas",
+		}),
+	}
+}
+
+type wrapKfV1alpha1SpaceQuotaImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+}
+
+var _ typedkfv1alpha1.SpaceQuotaInterface = (*wrapKfV1alpha1SpaceQuotaImpl)(nil)
+
+func (w *wrapKfV1alpha1SpaceQuotaImpl) Create(ctx context.Context, in *v1alpha1.SpaceQuota, opts v1.CreateOptions) (*v1alpha1.SpaceQuota, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "SpaceQuota",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SpaceQuota{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SpaceQuotaImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Delete(ctx, name, opts)
+}
+
+func (w *wrapKfV1alpha1SpaceQuotaImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapKfV1alpha1SpaceQuotaImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.SpaceQuota, error) {
+	uo, err := w.dyn.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SpaceQuota{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SpaceQuotaImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.SpaceQuotaList, error) {
+	uo, err := w.dyn.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SpaceQuotaList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SpaceQuotaImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.SpaceQuota, err error) {
+	uo, err := w.dyn.Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SpaceQuota{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SpaceQuotaImpl) Update(ctx context.Context, in *v1alpha1.SpaceQuota, opts v1.UpdateOptions) (*v1alpha1.SpaceQuota, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "SpaceQuota",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SpaceQuota{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SpaceQuotaImpl) UpdateStatus(ctx context.Context, in *v1alpha1.SpaceQuota, opts v1.UpdateOptions) (*v1alpha1.SpaceQuota, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "kf.dev",
+		Version: "v1alpha1",
+		Kind:    "SpaceQuota",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.SpaceQuota{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapKfV1alpha1SpaceQuotaImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapKfV1alpha1) Tasks(namespace string) typedkfv1alpha1.TaskInterface {
 	return &wrapKfV1alpha1TaskImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
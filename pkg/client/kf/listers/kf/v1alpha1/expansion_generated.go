@@ -24,6 +24,14 @@ type AppListerExpansion interface{}
 // AppNamespaceLister.
 type AppNamespaceListerExpansion interface{}
 
+// AppNetworkPolicyListerExpansion allows custom methods to be added to
+// AppNetworkPolicyLister.
+type AppNetworkPolicyListerExpansion interface{}
+
+// AppNetworkPolicyNamespaceListerExpansion allows custom methods to be added to
+// AppNetworkPolicyNamespaceLister.
+type AppNetworkPolicyNamespaceListerExpansion interface{}
+
 // BuildListerExpansion allows custom methods to be added to
 // BuildLister.
 type BuildListerExpansion interface{}
@@ -36,6 +44,10 @@ type BuildNamespaceListerExpansion interface{}
 // ClusterServiceBrokerLister.
 type ClusterServiceBrokerListerExpansion interface{}
 
+// OrgListerExpansion allows custom methods to be added to
+// OrgLister.
+type OrgListerExpansion interface{}
+
 // RouteListerExpansion allows custom methods to be added to
 // RouteLister.
 type RouteListerExpansion interface{}
@@ -52,6 +64,10 @@ type ScaleListerExpansion interface{}
 // ScaleNamespaceLister.
 type ScaleNamespaceListerExpansion interface{}
 
+// SecurityGroupListerExpansion allows custom methods to be added to
+// SecurityGroupLister.
+type SecurityGroupListerExpansion interface{}
+
 // ServiceBrokerListerExpansion allows custom methods to be added to
 // ServiceBrokerLister.
 type ServiceBrokerListerExpansion interface{}
@@ -88,6 +104,10 @@ type SourcePackageNamespaceListerExpansion interface{}
 // SpaceLister.
 type SpaceListerExpansion interface{}
 
+// SpaceQuotaListerExpansion allows custom methods to be added to
+// SpaceQuotaLister.
+type SpaceQuotaListerExpansion interface{}
+
 // TaskListerExpansion allows custom methods to be added to
 // TaskLister.
 type TaskListerExpansion interface{}
@@ -16,6 +16,10 @@
 
 package v1alpha3
 
+type EnvoyFilterExpansion interface{}
+
+type GatewayExpansion interface{}
+
 type ServiceEntryExpansion interface{}
 
 type VirtualServiceExpansion interface{}
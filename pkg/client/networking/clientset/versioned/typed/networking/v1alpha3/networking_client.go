@@ -26,6 +26,8 @@ import (
 
 type NetworkingV1alpha3Interface interface {
 	RESTClient() rest.Interface
+	EnvoyFiltersGetter
+	GatewaysGetter
 	ServiceEntriesGetter
 	VirtualServicesGetter
 }
@@ -35,6 +37,14 @@ type NetworkingV1alpha3Client struct {
 	restClient rest.Interface
 }
 
+func (c *NetworkingV1alpha3Client) EnvoyFilters(namespace string) EnvoyFilterInterface {
+	return newEnvoyFilters(c, namespace)
+}
+
+func (c *NetworkingV1alpha3Client) Gateways(namespace string) GatewayInterface {
+	return newGateways(c, namespace)
+}
+
 func (c *NetworkingV1alpha3Client) ServiceEntries(namespace string) ServiceEntryInterface {
 	return newServiceEntries(c, namespace)
 }
@@ -16,6 +16,22 @@
 
 package v1alpha3
 
+// EnvoyFilterListerExpansion allows custom methods to be added to
+// EnvoyFilterLister.
+type EnvoyFilterListerExpansion interface{}
+
+// EnvoyFilterNamespaceListerExpansion allows custom methods to be added to
+// EnvoyFilterNamespaceLister.
+type EnvoyFilterNamespaceListerExpansion interface{}
+
+// GatewayListerExpansion allows custom methods to be added to
+// GatewayLister.
+type GatewayListerExpansion interface{}
+
+// GatewayNamespaceListerExpansion allows custom methods to be added to
+// GatewayNamespaceLister.
+type GatewayNamespaceListerExpansion interface{}
+
 // ServiceEntryListerExpansion allows custom methods to be added to
 // ServiceEntryLister.
 type ServiceEntryListerExpansion interface{}
@@ -0,0 +1,68 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certutil
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSelfSigned(t *testing.T) {
+	t.Parallel()
+
+	notBefore := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	certPEM, keyPEM, err := SelfSigned([]string{"*.example.com", "example.com"}, notBefore, 24*time.Hour)
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertTrue(t, "has key", len(keyPEM) > 0)
+
+	cert, err := ParseCertificate(certPEM)
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "DNSNames", []string{"*.example.com", "example.com"}, cert.DNSNames)
+	testutil.AssertEqual(t, "NotAfter", notBefore.Add(24*time.Hour), cert.NotAfter.UTC())
+
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+	notAfter, err := NotAfter(secret)
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "NotAfter", notBefore.Add(24*time.Hour), notAfter.UTC())
+}
+
+func TestSelfSigned_noHosts(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := SelfSigned(nil, time.Now(), time.Hour)
+	testutil.AssertErrorsEqual(t, errors.New("at least one host is required"), err)
+}
+
+func TestNotAfter_invalid(t *testing.T) {
+	t.Parallel()
+
+	secret := &corev1.Secret{}
+	secret.Name = "some-secret"
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey: []byte("not a cert"),
+	}
+
+	_, err := NotAfter(secret)
+	testutil.AssertErrorsEqual(t, errors.New(`Secret "some-secret": no PEM encoded certificate found`), err)
+}
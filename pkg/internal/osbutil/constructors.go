@@ -135,11 +135,22 @@ func MapOSBToKfCatalog(catalog *osbclient.CatalogResponse) (out []v1alpha1.Servi
 				isFree = *osbPlan.Free
 			}
 
+			// plan_updateable on the plan takes precedence over the service's
+			// value, which defaults to false in OSB
+			isUpdatable := false
+			if osbService.PlanUpdatable != nil {
+				isUpdatable = *osbService.PlanUpdatable
+			}
+			if osbPlan.PlanUpdateable != nil {
+				isUpdatable = *osbPlan.PlanUpdateable
+			}
+
 			plans = append(plans, v1alpha1.ServicePlan{
-				DisplayName: osbPlan.Name,
-				UID:         osbPlan.ID,
-				Free:        isFree,
-				Description: osbPlan.Description,
+				DisplayName:   osbPlan.Name,
+				UID:           osbPlan.ID,
+				Free:          isFree,
+				Description:   osbPlan.Description,
+				PlanUpdatable: isUpdatable,
 			})
 		}
 
@@ -147,11 +158,16 @@ func MapOSBToKfCatalog(catalog *osbclient.CatalogResponse) (out []v1alpha1.Servi
 			return plans[i].DisplayName < plans[j].DisplayName
 		})
 
+		// shareable is a CF extension to the service's metadata that defaults
+		// to false
+		isShareable, _ := osbService.Metadata["shareable"].(bool)
+
 		out = append(out, v1alpha1.ServiceOffering{
 			DisplayName: osbService.Name,
 			UID:         osbService.ID,
 			Description: osbService.Description,
 			Tags:        osbService.Tags,
+			Shareable:   isShareable,
 			Plans:       plans,
 		})
 	}
@@ -26,6 +26,7 @@ import (
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
 	osbclient "sigs.k8s.io/go-open-service-broker-client/v2"
 )
 
@@ -181,6 +182,37 @@ func TestMapOSBToKfCatalog(t *testing.T) {
 		"minibroker": {
 			response: &minibrokerCatalog,
 		},
+		"plan updatable": {
+			response: &osbclient.CatalogResponse{
+				Services: []osbclient.Service{
+					{
+						ID:            "service-uid",
+						Name:          "service",
+						PlanUpdatable: ptr.Bool(true),
+						Plans: []osbclient.Plan{
+							{ID: "default-uid", Name: "default"},
+							{ID: "fixed-uid", Name: "fixed", PlanUpdateable: ptr.Bool(false)},
+						},
+					},
+				},
+			},
+		},
+		"shareable": {
+			response: &osbclient.CatalogResponse{
+				Services: []osbclient.Service{
+					{
+						ID:       "shared-uid",
+						Name:     "shared",
+						Metadata: map[string]interface{}{"shareable": true},
+					},
+					{
+						ID:       "unshared-uid",
+						Name:     "unshared",
+						Metadata: map[string]interface{}{"shareable": "true"},
+					},
+				},
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -22,6 +22,8 @@ import (
 	cv1alpha1 "github.com/google/kf/v2/pkg/client/kf/clientset/versioned/typed/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/builds"
 	"github.com/google/kf/v2/pkg/kf/logs"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ClientExtension holds additional functions that should be exposed by client.
@@ -30,6 +32,7 @@ type ClientExtension interface {
 	DeployLogs(ctx context.Context, out io.Writer, appName, resourceVersion, namespace string, noStart bool) error
 	Restart(ctx context.Context, namespace, name string) error
 	Restage(ctx context.Context, namespace, name string) (*v1alpha1.App, error)
+	DryRunUpsert(ctx context.Context, namespace string, newObj *v1alpha1.App, merge Merger) (*v1alpha1.App, error)
 }
 
 type appsClient struct {
@@ -71,3 +74,20 @@ func (ac *appsClient) Restage(ctx context.Context, namespace, name string) (app
 		return nil
 	})
 }
+
+// DryRunUpsert works like Upsert, but the API server doesn't persist the
+// result. It returns the App as it would be stored after defaulting and
+// validation.
+func (ac *appsClient) DryRunUpsert(ctx context.Context, namespace string, newObj *v1alpha1.App, merge Merger) (*v1alpha1.App, error) {
+	dryRun := []string{metav1.DryRunAll}
+
+	oldObj, err := ac.kclient.Apps(namespace).Get(ctx, newObj.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return ac.kclient.Apps(namespace).Create(ctx, newObj, metav1.CreateOptions{DryRun: dryRun})
+	case err != nil:
+		return nil, err
+	}
+
+	return ac.kclient.Apps(namespace).Update(ctx, merge(newObj, oldObj), metav1.UpdateOptions{DryRun: dryRun})
+}
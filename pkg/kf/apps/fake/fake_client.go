@@ -110,6 +110,21 @@ func (mr *FakeClientMockRecorder) DeployLogsForApp(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployLogsForApp", reflect.TypeOf((*FakeClient)(nil).DeployLogsForApp), arg0, arg1, arg2)
 }
 
+// DryRunUpsert mocks base method.
+func (m *FakeClient) DryRunUpsert(arg0 context.Context, arg1 string, arg2 *v1alpha1.App, arg3 apps.Merger) (*v1alpha1.App, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunUpsert", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1alpha1.App)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DryRunUpsert indicates an expected call of DryRunUpsert.
+func (mr *FakeClientMockRecorder) DryRunUpsert(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunUpsert", reflect.TypeOf((*FakeClient)(nil).DryRunUpsert), arg0, arg1, arg2, arg3)
+}
+
 // Get mocks base method.
 func (m *FakeClient) Get(arg0 context.Context, arg1, arg2 string) (*v1alpha1.App, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +71,26 @@ func (mr *FakePusherMockRecorder) CreatePlaceholderApp(arg0, arg1 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaceholderApp", reflect.TypeOf((*FakePusher)(nil).CreatePlaceholderApp), varargs...)
 }
 
+// PlanPush mocks base method.
+func (m *FakePusher) PlanPush(arg0 context.Context, arg1 string, arg2 ...apps.PushOption) (*apps.PushPlan, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PlanPush", varargs...)
+	ret0, _ := ret[0].(*apps.PushPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanPush indicates an expected call of PlanPush.
+func (mr *FakePusherMockRecorder) PlanPush(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanPush", reflect.TypeOf((*FakePusher)(nil).PlanPush), varargs...)
+}
+
 // Push mocks base method.
 func (m *FakePusher) Push(arg0 context.Context, arg1 string, arg2 ...apps.PushOption) error {
 	m.ctrl.T.Helper()
@@ -24,6 +24,8 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/internal/envutil"
@@ -40,6 +42,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/kmp"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/ptr"
 )
@@ -64,6 +67,50 @@ type Pusher interface {
 	// CreatePlaceholderApp creates a valid stopped application with the given name
 	// if the App doesn't exist yet.
 	CreatePlaceholderApp(ctx context.Context, appName string, opts ...PushOption) (*v1alpha1.App, error)
+
+	// PlanPush returns the changes Push would make to an application without
+	// changing anything.
+	PlanPush(ctx context.Context, appName string, opts ...PushOption) (*PushPlan, error)
+}
+
+// PushPlan describes the changes a push would make to an App.
+type PushPlan struct {
+	// App is the name of the App.
+	App string `json:"app"`
+
+	// Space is the Space the App is in.
+	Space string `json:"space"`
+
+	// Create is true if the App doesn't exist yet.
+	Create bool `json:"create"`
+
+	// Changed is true if the push would change the App or its bindings.
+	Changed bool `json:"changed"`
+
+	// ChangedFields holds the names of the top level fields in the App's spec
+	// that would change.
+	ChangedFields []string `json:"changedFields,omitempty"`
+
+	// Diff is a human readable diff of the App's spec.
+	Diff string `json:"diff,omitempty"`
+
+	// ServiceBindings holds the names of the services that would be bound to
+	// the App.
+	ServiceBindings []string `json:"serviceBindings,omitempty"`
+
+	// SourcePath is the directory that would be uploaded and built, if any.
+	SourcePath string `json:"sourcePath,omitempty"`
+}
+
+// dryRunBuildName stands in for the AppDevExperience Build a push would create.
+const dryRunBuildName = "<new Build>"
+
+// planDiffOptions leaves the counters that every push increments to force a
+// new rollout out of PushPlans.
+var planDiffOptions = cmp.Options{
+	cmpopts.IgnoreFields(v1alpha1.AppSpecTemplate{}, "UpdateRequests"),
+	cmpopts.IgnoreFields(v1alpha1.AppSpecBuild{}, "UpdateRequests"),
+	cmpopts.IgnoreFields(v1alpha1.BuildSpec{}, "SourcePackage"),
 }
 
 // NewPusher creates a new Pusher.
@@ -111,11 +158,13 @@ func newApp(appName, adxBuild string, opts ...PushOption) *v1alpha1.App {
 			},
 			Template: v1alpha1.AppSpecTemplate{
 				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{cfg.Container},
+					Containers: append([]corev1.Container{cfg.Container}, cfg.Sidecars...),
 				},
 			},
 			Instances: cfg.AppSpecInstances,
 			Routes:    cfg.Routes,
+			Rollout:   cfg.Rollout,
+			Processes: cfg.Processes,
 		},
 	}
 }
@@ -162,26 +211,7 @@ func (p *pusher) Push(ctx context.Context, appName string, opts ...PushOption) e
 		adxBuild = buildName
 	}
 
-	app = newApp(appName, adxBuild, opts...)
-
-	var hasDefaultRoutes bool
-	app.Spec.Routes, hasDefaultRoutes = setupRoutes(cfg, app.Name, app.Spec.Routes)
-
-	// Scaling
-	if noScaling(app.Spec.Instances) {
-		// Default to 1
-		app.Spec.Instances.Replicas = ptr.Int32(1)
-	}
-
-	// If there is a source path set, then we need to setup the App's Build to
-	// look for a SourcePackage.
-	if cfg.SourcePath != "" && !cfg.ADXBuild {
-		if app.Spec.Build.Spec == nil {
-			app.Spec.Build.Spec = &v1alpha1.BuildSpec{}
-		}
-
-		app.Spec.Build.Spec.SourcePackage.Name = sourcePackageName(app)
-	}
+	app, hasDefaultRoutes := desiredApp(cfg, appName, adxBuild, opts...)
 
 	resultingApp, err := p.appsClient.Upsert(
 		ctx,
@@ -246,6 +276,97 @@ func (p *pusher) Push(ctx context.Context, appName string, opts ...PushOption) e
 	return nil
 }
 
+// desiredApp builds the App a push creates, before it's merged with an
+// existing App.
+func desiredApp(cfg pushConfig, appName, adxBuild string, opts ...PushOption) (app *v1alpha1.App, hasDefaultRoutes bool) {
+	app = newApp(appName, adxBuild, opts...)
+	app.Spec.Routes, hasDefaultRoutes = setupRoutes(cfg, app.Name, app.Spec.Routes)
+
+	// Scaling
+	if noScaling(app.Spec.Instances) {
+		// Default to 1
+		app.Spec.Instances.Replicas = ptr.Int32(1)
+	}
+
+	// If there is a source path set, then we need to setup the App's Build to
+	// look for a SourcePackage.
+	if cfg.SourcePath != "" && !cfg.ADXBuild {
+		if app.Spec.Build.Spec == nil {
+			app.Spec.Build.Spec = &v1alpha1.BuildSpec{}
+		}
+
+		app.Spec.Build.Spec.SourcePackage.Name = sourcePackageName(app)
+	}
+
+	return app, hasDefaultRoutes
+}
+
+// PlanPush returns the changes Push would make to an App. The API server
+// defaults and validates the resulting App in dry-run mode so the plan
+// matches what Push would store, but nothing is changed and no source is
+// uploaded.
+func (p *pusher) PlanPush(ctx context.Context, appName string, opts ...PushOption) (*PushPlan, error) {
+	cfg := PushOptionDefaults().Extend(opts).toConfig()
+
+	plan := &PushPlan{
+		App:        appName,
+		Space:      cfg.Space,
+		SourcePath: cfg.SourcePath,
+	}
+
+	current, err := p.appsClient.Get(ctx, cfg.Space, appName)
+	switch {
+	case apierrs.IsNotFound(err):
+		plan.Create = true
+		current = &v1alpha1.App{}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get App %q: %v", appName, err)
+	}
+
+	for _, desiredBinding := range cfg.ServiceBindings {
+		_, err := p.bindingsClient.Get(ctx, desiredBinding.GetNamespace(), desiredBinding.Name)
+		switch {
+		case apierrs.IsNotFound(err):
+			plan.ServiceBindings = append(plan.ServiceBindings, desiredBinding.Spec.InstanceRef.Name)
+		case err != nil:
+			return nil, fmt.Errorf("failed to get ServiceInstanceBinding %q: %s", desiredBinding.Name, err)
+		}
+	}
+
+	var adxBuild string
+	if cfg.ADXBuild {
+		adxBuild = dryRunBuildName
+	}
+
+	app, hasDefaultRoutes := desiredApp(cfg, appName, adxBuild, opts...)
+
+	desired, err := p.appsClient.DryRunUpsert(
+		ctx,
+		app.Namespace,
+		app,
+		mergeApps(cfg, hasDefaultRoutes),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dry-run App: %s", err)
+	}
+
+	plan.ChangedFields, err = kmp.CompareSetFields(current.Spec, desired.Spec, planDiffOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare Apps: %s", err)
+	}
+
+	if len(plan.ChangedFields) > 0 {
+		plan.Diff, err = kmp.SafeDiff(current.Spec, desired.Spec, planDiffOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare Apps: %s", err)
+		}
+	}
+
+	plan.Changed = plan.Create || len(plan.ChangedFields) > 0 || len(plan.ServiceBindings) > 0
+
+	return plan, nil
+}
+
 // CreatePlaceholderApp creates a valid stopped application with the given name
 // if the App doesn't exist yet.
 func (p *pusher) CreatePlaceholderApp(ctx context.Context, appName string, opts ...PushOption) (*v1alpha1.App, error) {
@@ -412,6 +533,23 @@ func mergeApps(cfg pushConfig, hasDefaultRoutes bool) func(newapp, oldapp *v1alp
 			newapp.Spec.Instances.Replicas = ptr.Int32(1)
 		}
 
+		// Rollout
+		if newapp.Spec.Rollout == nil {
+			newapp.Spec.Rollout = oldapp.Spec.Rollout
+		} else if oldapp.Spec.Rollout != nil {
+			// Keep the request counters so they remain nondecreasing.
+			newapp.Spec.Rollout.PromoteRequests = oldapp.Spec.Rollout.PromoteRequests
+			newapp.Spec.Rollout.AbortRequests = oldapp.Spec.Rollout.AbortRequests
+		}
+
+		// Process scaling overrides
+		for i := range newapp.Spec.Processes {
+			process := &newapp.Spec.Processes[i]
+			if oldProcess := oldapp.Spec.Process(process.Type); oldProcess != nil && process.Replicas == nil {
+				process.Replicas = oldProcess.Replicas
+			}
+		}
+
 		newapp.ResourceVersion = oldapp.ResourceVersion
 
 		// Envs
@@ -47,10 +47,16 @@ type pushConfig struct {
 	GenerateRandomRoute bool
 	// Output is the io.Writer to write output such as build logs
 	Output io.Writer
+	// Processes is additional processes that run alongside the web process
+	Processes []v1alpha1.AppSpecProcess
+	// Rollout is the strategy used to release the new revision of the app
+	Rollout *v1alpha1.AppSpecRollout
 	// Routes is routes for the app
 	Routes []v1alpha1.RouteWeightBinding
 	// ServiceBindings is a list of Services to bind to the app
 	ServiceBindings []v1alpha1.ServiceInstanceBinding
+	// Sidecars is additional containers that run alongside the app's container
+	Sidecars []corev1.Container
 	// SourcePath is the path to the source code directory
 	SourcePath string
 	// Space is the Space to use
@@ -149,6 +155,18 @@ func (opts PushOptions) Output() io.Writer {
 	return opts.toConfig().Output
 }
 
+// Processes returns the last set value for Processes or the empty value
+// if not set.
+func (opts PushOptions) Processes() []v1alpha1.AppSpecProcess {
+	return opts.toConfig().Processes
+}
+
+// Rollout returns the last set value for Rollout or the empty value
+// if not set.
+func (opts PushOptions) Rollout() *v1alpha1.AppSpecRollout {
+	return opts.toConfig().Rollout
+}
+
 // Routes returns the last set value for Routes or the empty value
 // if not set.
 func (opts PushOptions) Routes() []v1alpha1.RouteWeightBinding {
@@ -161,6 +179,12 @@ func (opts PushOptions) ServiceBindings() []v1alpha1.ServiceInstanceBinding {
 	return opts.toConfig().ServiceBindings
 }
 
+// Sidecars returns the last set value for Sidecars or the empty value
+// if not set.
+func (opts PushOptions) Sidecars() []corev1.Container {
+	return opts.toConfig().Sidecars
+}
+
 // SourcePath returns the last set value for SourcePath or the empty value
 // if not set.
 func (opts PushOptions) SourcePath() string {
@@ -250,6 +274,20 @@ func WithPushOutput(val io.Writer) PushOption {
 	}
 }
 
+// WithPushProcesses creates an Option that sets additional processes that run alongside the web process
+func WithPushProcesses(val []v1alpha1.AppSpecProcess) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Processes = val
+	}
+}
+
+// WithPushRollout creates an Option that sets the strategy used to release the new revision of the app
+func WithPushRollout(val *v1alpha1.AppSpecRollout) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Rollout = val
+	}
+}
+
 // WithPushRoutes creates an Option that sets routes for the app
 func WithPushRoutes(val []v1alpha1.RouteWeightBinding) PushOption {
 	return func(cfg *pushConfig) {
@@ -264,6 +302,13 @@ func WithPushServiceBindings(val []v1alpha1.ServiceInstanceBinding) PushOption {
 	}
 }
 
+// WithPushSidecars creates an Option that sets additional containers that run alongside the app's container
+func WithPushSidecars(val []corev1.Container) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Sidecars = val
+	}
+}
+
 // WithPushSourcePath creates an Option that sets the path to the source code directory
 func WithPushSourcePath(val string) PushOption {
 	return func(cfg *pushConfig) {
@@ -260,6 +260,56 @@ func TestPush(t *testing.T) {
 					Return(&v1alpha1.App{}, nil)
 			},
 		},
+		"pushes app with processes but leaves process instances": {
+			appName:   "some-app",
+			buildpack: "some-buildpack",
+			opts: apps.PushOptions{
+				apps.WithPushBuild(&fakeBuild),
+				apps.WithPushProcesses([]v1alpha1.AppSpecProcess{
+					{Type: "worker", Args: []string{"bundle exec sidekiq"}},
+					{Type: "clock", Replicas: ptr.Int32(2)},
+				}),
+			},
+			setup: func(t *testing.T, f *fakes) {
+				f.appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, space string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := &v1alpha1.App{}
+						oldApp.Spec.Processes = []v1alpha1.AppSpecProcess{
+							{Type: "worker", Replicas: ptr.Int32(4)},
+							{Type: "clock", Replicas: ptr.Int32(1)},
+						}
+						newApp = merge(newApp, oldApp)
+						testutil.AssertEqual(t, "processes", []v1alpha1.AppSpecProcess{
+							{Type: "worker", Args: []string{"bundle exec sidekiq"}, Replicas: ptr.Int32(4)},
+							{Type: "clock", Replicas: ptr.Int32(2)},
+						}, newApp.Spec.Processes)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app with sidecars": {
+			appName:   "some-app",
+			buildpack: "some-buildpack",
+			opts: apps.PushOptions{
+				apps.WithPushBuild(&fakeBuild),
+				apps.WithPushContainer(corev1.Container{Args: []string{"rails server"}}),
+				apps.WithPushSidecars([]corev1.Container{
+					{Name: "metrics-agent", Args: []string{"./metrics-agent"}},
+				}),
+			},
+			setup: func(t *testing.T, f *fakes) {
+				f.appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, space string, newApp *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "containers", []corev1.Container{
+							{Args: []string{"rails server"}},
+							{Name: "metrics-agent", Args: []string{"./metrics-agent"}},
+						}, newApp.Spec.Template.Spec.Containers)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
 		"pushes app with buildpack": {
 			appName:   "some-app",
 			buildpack: "some-buildpack",
@@ -1148,3 +1198,220 @@ func Test_pusher_CreatePlaceholderApp(t *testing.T) {
 		})
 	}
 }
+
+func Test_pusher_PlanPush(t *testing.T) {
+	t.Parallel()
+
+	type mocks struct {
+		appsClient     *appsfake.FakeClient
+		bindingsClient *bindingsfake.FakeClient
+	}
+
+	var (
+		mockAppName      = "testapp"
+		mockAppNamespace = "default"
+		notFoundError    = apierrs.NewNotFound(schema.GroupResource{}, "")
+		mockApp          = &v1alpha1.App{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      mockAppName,
+				Namespace: mockAppNamespace,
+			},
+			Spec: v1alpha1.AppSpec{
+				Build: v1alpha1.AppSpecBuild{
+					Image:          ptr.String("some-image"),
+					UpdateRequests: 3,
+				},
+				Template: v1alpha1.AppSpecTemplate{
+					UpdateRequests: 5,
+				},
+				Instances: v1alpha1.AppSpecInstances{
+					Replicas: ptr.Int32(1),
+				},
+			},
+		}
+		mockBinding = v1alpha1.ServiceInstanceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-binding",
+				Namespace: mockAppNamespace,
+			},
+			Spec: v1alpha1.ServiceInstanceBindingSpec{
+				InstanceRef: corev1.LocalObjectReference{
+					Name: "some-service",
+				},
+			},
+		}
+	)
+
+	cases := map[string]struct {
+		opts []apps.PushOption
+
+		// Environment mocks and assertions
+		setup    func(t *testing.T, mocks *mocks)
+		wantPlan *apps.PushPlan
+		wantDiff bool
+		wantErr  error
+	}{
+		"new App is created": {
+			setup: func(t *testing.T, mocks *mocks) {
+				mocks.appsClient.EXPECT().
+					Get(gomock.Any(), mockAppNamespace, mockAppName).
+					Return(nil, notFoundError)
+
+				mocks.appsClient.EXPECT().
+					DryRunUpsert(gomock.Any(), mockAppNamespace, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_, _ interface{}, app *v1alpha1.App, _ apps.Merger) (*v1alpha1.App, error) {
+						return app, nil
+					})
+			},
+			wantPlan: &apps.PushPlan{
+				App:           mockAppName,
+				Space:         mockAppNamespace,
+				Create:        true,
+				Changed:       true,
+				ChangedFields: []string{"instances", "template"},
+			},
+			wantDiff: true,
+		},
+		"counters are left out": {
+			setup: func(t *testing.T, mocks *mocks) {
+				mocks.appsClient.EXPECT().
+					Get(gomock.Any(), mockAppNamespace, mockAppName).
+					Return(mockApp.DeepCopy(), nil)
+
+				mocks.appsClient.EXPECT().
+					DryRunUpsert(gomock.Any(), mockAppNamespace, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_, _ interface{}, _ *v1alpha1.App, _ apps.Merger) (*v1alpha1.App, error) {
+						app := mockApp.DeepCopy()
+						app.Spec.Build.UpdateRequests++
+						app.Spec.Template.UpdateRequests++
+						return app, nil
+					})
+			},
+			wantPlan: &apps.PushPlan{
+				App:   mockAppName,
+				Space: mockAppNamespace,
+			},
+		},
+		"changed fields are listed": {
+			setup: func(t *testing.T, mocks *mocks) {
+				mocks.appsClient.EXPECT().
+					Get(gomock.Any(), mockAppNamespace, mockAppName).
+					Return(mockApp.DeepCopy(), nil)
+
+				mocks.appsClient.EXPECT().
+					DryRunUpsert(gomock.Any(), mockAppNamespace, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_, _ interface{}, _ *v1alpha1.App, _ apps.Merger) (*v1alpha1.App, error) {
+						app := mockApp.DeepCopy()
+						app.Spec.Instances.Replicas = ptr.Int32(3)
+						return app, nil
+					})
+			},
+			wantPlan: &apps.PushPlan{
+				App:           mockAppName,
+				Space:         mockAppNamespace,
+				Changed:       true,
+				ChangedFields: []string{"instances"},
+			},
+			wantDiff: true,
+		},
+		"new service bindings are listed": {
+			opts: []apps.PushOption{
+				apps.WithPushServiceBindings([]v1alpha1.ServiceInstanceBinding{mockBinding}),
+			},
+			setup: func(t *testing.T, mocks *mocks) {
+				mocks.appsClient.EXPECT().
+					Get(gomock.Any(), mockAppNamespace, mockAppName).
+					Return(mockApp.DeepCopy(), nil)
+
+				mocks.bindingsClient.EXPECT().
+					Get(gomock.Any(), mockAppNamespace, "some-binding").
+					Return(nil, notFoundError)
+
+				mocks.appsClient.EXPECT().
+					DryRunUpsert(gomock.Any(), mockAppNamespace, gomock.Any(), gomock.Any()).
+					Return(mockApp.DeepCopy(), nil)
+			},
+			wantPlan: &apps.PushPlan{
+				App:             mockAppName,
+				Space:           mockAppNamespace,
+				Changed:         true,
+				ServiceBindings: []string{"some-service"},
+			},
+		},
+		"AppDevExperience Builds reference a placeholder": {
+			opts: []apps.PushOption{
+				apps.WithPushADXBuild(true),
+				apps.WithPushSourcePath("some-path"),
+			},
+			setup: func(t *testing.T, mocks *mocks) {
+				mocks.appsClient.EXPECT().
+					Get(gomock.Any(), mockAppNamespace, mockAppName).
+					Return(mockApp.DeepCopy(), nil)
+
+				mocks.appsClient.EXPECT().
+					DryRunUpsert(gomock.Any(), mockAppNamespace, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_, _ interface{}, app *v1alpha1.App, _ apps.Merger) (*v1alpha1.App, error) {
+						testutil.AssertEqual(t, "BuildRef", &corev1.LocalObjectReference{Name: "<new Build>"}, app.Spec.Build.BuildRef)
+						return mockApp.DeepCopy(), nil
+					})
+			},
+			wantPlan: &apps.PushPlan{
+				App:        mockAppName,
+				Space:      mockAppNamespace,
+				SourcePath: "some-path",
+			},
+		},
+		"fails if App can't be fetched": {
+			setup: func(t *testing.T, mocks *mocks) {
+				mocks.appsClient.EXPECT().
+					Get(gomock.Any(), mockAppNamespace, mockAppName).
+					Return(nil, errors.New("test failure"))
+			},
+			wantErr: errors.New(`failed to get App "testapp": test failure`),
+		},
+		"fails if dry-run fails": {
+			setup: func(t *testing.T, mocks *mocks) {
+				mocks.appsClient.EXPECT().
+					Get(gomock.Any(), mockAppNamespace, mockAppName).
+					Return(mockApp.DeepCopy(), nil)
+
+				mocks.appsClient.EXPECT().
+					DryRunUpsert(gomock.Any(), mockAppNamespace, gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("test failure"))
+			},
+			wantErr: errors.New("failed to dry-run App: test failure"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			m := &mocks{
+				appsClient:     appsfake.NewFakeClient(ctrl),
+				bindingsClient: bindingsfake.NewFakeClient(ctrl),
+			}
+
+			p := apps.NewPusher(
+				m.appsClient,
+				m.bindingsClient,
+				nil,
+				nil,
+				nil,
+			)
+
+			tc.setup(t, m)
+
+			opts := append([]apps.PushOption{apps.WithPushSpace(mockAppNamespace)}, tc.opts...)
+			plan, gotErr := p.PlanPush(context.TODO(), mockAppName, opts...)
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			if gotErr != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "has diff", tc.wantDiff, plan.Diff != "")
+			plan.Diff = ""
+			testutil.AssertEqual(t, "plan", tc.wantPlan, plan)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/manifest"
+	"github.com/google/kf/v2/pkg/kf/serviceinstancebindings"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// NewCreateAppManifestCommand creates a command that writes the manifest of a
+// running App.
+func NewCreateAppManifestCommand(
+	p *config.KfParams,
+	appsClient apps.Client,
+	bindingsClient serviceinstancebindings.Client,
+) *cobra.Command {
+	var path string
+
+	cmd := &cobra.Command{
+		Use:   "create-app-manifest APP_NAME",
+		Short: "Create a manifest for a running App.",
+		Long: `
+		Creates a manifest that pushes an App equivalent to the running App,
+		including its routes, service bindings, environment variables, resource
+		requests, health checks, and build.
+
+		Environment variables that reference Secrets or ConfigMaps and
+		custom service binding names can't be written to manifests and are
+		left out.
+		`,
+		Example: `
+		# Write the manifest to ./myapp_manifest.yml
+		kf create-app-manifest myapp
+
+		# Write the manifest to a specific file
+		kf create-app-manifest myapp -p manifest.yml
+		`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			app, err := appsClient.Get(cmd.Context(), p.Space, appName)
+			if err != nil {
+				return fmt.Errorf("failed to get App: %s", err)
+			}
+
+			bindings, err := bindingsClient.List(cmd.Context(), p.Space)
+			if err != nil {
+				return fmt.Errorf("failed to list service bindings: %s", err)
+			}
+
+			contents, err := yaml.Marshal(manifest.NewFromApp(app, bindings))
+			if err != nil {
+				return fmt.Errorf("failed to create manifest: %s", err)
+			}
+
+			if path == "" {
+				path = fmt.Sprintf("./%s_manifest.yml", appName)
+			}
+
+			if err := ioutil.WriteFile(path, contents, 0644); err != nil {
+				return fmt.Errorf("failed to write manifest: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Manifest file created successfully at %s\n", path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&path,
+		"path",
+		"p",
+		"",
+		"Path to write the manifest to. Defaults to ./APP_NAME_manifest.yml.",
+	)
+
+	return cmd
+}
@@ -0,0 +1,156 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/manifest"
+	serviceinstancebindingsfake "github.com/google/kf/v2/pkg/kf/serviceinstancebindings/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/ptr"
+)
+
+func TestCreateAppManifest(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-app"},
+		Spec: v1alpha1.AppSpec{
+			Build: v1alpha1.AppSpecBuild{
+				Image: ptr.String("gcr.io/my-app"),
+			},
+			Instances: v1alpha1.AppSpecInstances{
+				Replicas: ptr.Int32(2),
+			},
+			Routes: []v1alpha1.RouteWeightBinding{{
+				RouteSpecFields: v1alpha1.RouteSpecFields{
+					Hostname: "my-app",
+					Domain:   "example.com",
+				},
+			}},
+		},
+	}
+
+	binding := v1alpha1.ServiceInstanceBinding{
+		Spec: v1alpha1.ServiceInstanceBindingSpec{
+			BindingType: v1alpha1.BindingType{App: &v1alpha1.AppRef{Name: "my-app"}},
+			InstanceRef: corev1.LocalObjectReference{Name: "my-db"},
+		},
+	}
+
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "manifest.yml")
+
+	cases := map[string]struct {
+		Args             []string
+		ExpectedStrings  []string
+		ExpectedErr      error
+		ExpectedManifest *manifest.Application
+		Setup            func(t *testing.T, apps *fake.FakeClient, bindings *serviceinstancebindingsfake.FakeClient)
+	}{
+		"writes manifest": {
+			Args:            []string{"my-app", "--path", manifestPath},
+			ExpectedStrings: []string{"Manifest file created successfully at " + manifestPath},
+			ExpectedManifest: &manifest.Application{
+				Name:            "my-app",
+				Docker:          manifest.AppDockerImage{Image: "gcr.io/my-app"},
+				Instances:       ptr.Int32(2),
+				Services:        []string{"my-db"},
+				Routes:          []manifest.Route{{Route: "my-app.example.com"}},
+				HealthCheckType: "process",
+			},
+			Setup: func(t *testing.T, apps *fake.FakeClient, bindings *serviceinstancebindingsfake.FakeClient) {
+				apps.EXPECT().Get(gomock.Any(), "default", "my-app").Return(app, nil)
+				bindings.EXPECT().List(gomock.Any(), "default").Return([]v1alpha1.ServiceInstanceBinding{binding}, nil)
+			},
+		},
+		"default path": {
+			Args:            []string{"my-app"},
+			ExpectedStrings: []string{"Manifest file created successfully at ./my-app_manifest.yml"},
+			Setup: func(t *testing.T, apps *fake.FakeClient, bindings *serviceinstancebindingsfake.FakeClient) {
+				apps.EXPECT().Get(gomock.Any(), "default", "my-app").Return(app, nil)
+				bindings.EXPECT().List(gomock.Any(), "default")
+
+				t.Cleanup(func() { os.Remove("my-app_manifest.yml") })
+			},
+		},
+		"no app name": {
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"get app fails": {
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to get App: some-error"),
+			Setup: func(t *testing.T, apps *fake.FakeClient, bindings *serviceinstancebindingsfake.FakeClient) {
+				apps.EXPECT().Get(gomock.Any(), "default", "my-app").Return(nil, errors.New("some-error"))
+			},
+		},
+		"list bindings fails": {
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to list service bindings: some-error"),
+			Setup: func(t *testing.T, apps *fake.FakeClient, bindings *serviceinstancebindingsfake.FakeClient) {
+				apps.EXPECT().Get(gomock.Any(), "default", "my-app").Return(app, nil)
+				bindings.EXPECT().List(gomock.Any(), "default").Return(nil, errors.New("some-error"))
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeApps := fake.NewFakeClient(ctrl)
+			fakeBindings := serviceinstancebindingsfake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fakeApps, fakeBindings)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Space: "default",
+			}
+
+			cmd := NewCreateAppManifestCommand(p, fakeApps, fakeBindings)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+			testutil.AssertEqual(t, "SilenceUsage", true, cmd.SilenceUsage)
+
+			if tc.ExpectedManifest != nil {
+				m, err := manifest.NewFromFile(context.Background(), manifestPath, nil)
+				testutil.AssertNil(t, "NewFromFile err", err)
+				testutil.AssertEqual(t, "Applications", []manifest.Application{*tc.ExpectedManifest}, m.Applications)
+			}
+		})
+	}
+}
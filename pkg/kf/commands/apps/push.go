@@ -16,12 +16,14 @@ package apps
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,7 +40,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/ptr"
-	"sigs.k8s.io/yaml"
 )
 
 // SrcImageBuilder creates and uploads a container image that contains the
@@ -79,10 +80,18 @@ type pushParams struct {
 	task bool
 
 	// Variable subtitution
-	vars      map[string]string
+	vars      []string
 	varsFiles []string
 
 	appSuffix string
+
+	// Rollout Flags
+	strategy    string
+	canarySteps []int32
+
+	// Dry run Flags
+	dryRun bool
+	output string
 }
 
 // DefaultSrcImageBuilder is the default image builder that implements
@@ -126,6 +135,8 @@ func NewPushCommand(
   kf push myapp --env FOO=bar --env BAZ=foo
   kf push myapp --stack cloudfoundry/cflinuxfs3 # Use a cflinuxfs3 runtime
   kf push myapp --health-check-http-endpoint /myhealthcheck # Specify a healthCheck for the app
+  kf push myapp --dry-run # Show the changes without applying them
+  kf push myapp --dry-run --output json # Show the changes as JSON
   `,
 		Args:         cobra.MaximumNArgs(1),
 		SilenceUsage: true,
@@ -170,6 +181,12 @@ func NewPushCommand(
 				return err
 			}
 
+			if params.output != "" && !params.dryRun {
+				return errors.New("--output can only be used with --dry-run")
+			}
+
+			var plans []*apps.PushPlan
+
 			overrides, err := createAppOverrides(cmd, &params)
 			if err != nil {
 				return err
@@ -242,16 +259,38 @@ func NewPushCommand(
 					return err
 				}
 
+				processes, err := app.ToAppSpecProcesses(&space.Status.RuntimeConfig)
+				if err != nil {
+					return err
+				}
+
+				sidecars, err := app.ToSidecarContainers(v1alpha1.WebProcessType)
+				if err != nil {
+					return err
+				}
+
 				pushOpts := []apps.PushOption{
 					apps.WithPushSpace(p.Space),
 					apps.WithPushRoutes(routes),
 					apps.WithPushGenerateRandomRoute(generateRandomRoute),
 					apps.WithPushGenerateDefaultRoute(generateDefaultRoute),
 					apps.WithPushAppSpecInstances(app.ToAppSpecInstances()),
+					apps.WithPushProcesses(processes),
+					apps.WithPushSidecars(sidecars),
 					apps.WithPushContainer(container),
 					apps.WithPushContainerImage(image),
 				}
 
+				if params.strategy != "" {
+					rollout, err := parseRolloutStrategy(params.strategy, params.canarySteps)
+					if err != nil {
+						return err
+					}
+					pushOpts = append(pushOpts, apps.WithPushRollout(rollout))
+				} else if len(params.canarySteps) > 0 {
+					return errors.New("--canary-steps can only be used with --strategy canary")
+				}
+
 				var srcPath string
 				if params.path != "" {
 					srcPath = params.path
@@ -280,7 +319,7 @@ func NewPushCommand(
 
 					legacyPush := params.containerRegistry != "" || params.sourceImage != ""
 
-					if shouldPushSource && legacyPush {
+					if shouldPushSource && legacyPush && !params.dryRun {
 						// Legacy source upload path.
 						// TODO: This is still here to ensure we haven't
 						// broken the existing CLI UX. When we move to v3.x.x
@@ -352,6 +391,16 @@ func NewPushCommand(
 				}
 				pushOpts = append(pushOpts, apps.WithPushServiceBindings(bindings))
 
+				if params.dryRun {
+					plan, err := pusher.PlanPush(ctx, app.Name+params.appSuffix, pushOpts...)
+					if err != nil {
+						return err
+					}
+
+					plans = append(plans, plan)
+					continue
+				}
+
 				err = pusher.Push(ctx, app.Name+params.appSuffix, pushOpts...)
 
 				if err != nil {
@@ -359,6 +408,10 @@ func NewPushCommand(
 				}
 			}
 
+			if params.dryRun {
+				return writePushPlans(cmd.OutOrStdout(), params.output, plans)
+			}
+
 			return nil
 		},
 	}
@@ -545,17 +598,17 @@ func NewPushCommand(
 		"Number of dedicated CPU cores to give each App instance (for example 100m, 0.5, 1, 2). For more information see https://kubernetes.io/docs/tasks/configure-pod-container/assign-cpu-resource/.",
 	)
 
-	pushCmd.Flags().StringToStringVar(
+	pushCmd.Flags().StringArrayVar(
 		&params.vars,
 		"var",
 		nil,
-		"Manifest variable substitution. Multiple can be set by using the flag multiple times (for example NAME=VALUE).",
+		"Manifest variable substitution. Multiple can be set by using the flag multiple times or separating them with commas (for example NAME=VALUE). Escape commas in values as \\,. Takes precedence over --vars-file.",
 	)
 	pushCmd.Flags().StringArrayVar(
 		&params.varsFiles,
 		"vars-file",
 		nil,
-		"JSON or YAML file to read variable substitutions from. Can be supplied multiple times.",
+		"JSON or YAML file to read variable substitutions from. Can be supplied multiple times, later files take precedence.",
 	)
 
 	pushCmd.Flags().StringVar(
@@ -572,9 +625,75 @@ func NewPushCommand(
 		"Push an App to execute Tasks only. The App will be built, but not run. It will not have a route assigned.",
 	)
 
+	pushCmd.Flags().StringVar(
+		&params.strategy,
+		"strategy",
+		"",
+		"Strategy used to release the new revision of the App, one of rolling, blue-green, or canary.",
+	)
+
+	pushCmd.Flags().Int32SliceVar(
+		&params.canarySteps,
+		"canary-steps",
+		nil,
+		"Percentages of traffic sent to the new revision at each step of a canary rollout (for example 10,50).",
+	)
+
+	pushCmd.Flags().BoolVar(
+		&params.dryRun,
+		"dry-run",
+		false,
+		"Show the changes the push would make to each App without uploading source or changing the cluster.",
+	)
+
+	pushCmd.Flags().StringVarP(
+		&params.output,
+		"output",
+		"o",
+		"",
+		"Output format of --dry-run, one of: text or json.",
+	)
+
 	return pushCmd
 }
 
+// writePushPlans writes the changes of a dry-run push in the given format.
+func writePushPlans(w io.Writer, format string, plans []*apps.PushPlan) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plans)
+	case "", "text":
+		for _, plan := range plans {
+			switch {
+			case plan.Create:
+				fmt.Fprintf(w, "App %q in Space %q would be created.\n", plan.App, plan.Space)
+			case plan.Changed:
+				fmt.Fprintf(w, "App %q in Space %q would be updated.\n", plan.App, plan.Space)
+			default:
+				fmt.Fprintf(w, "App %q in Space %q has no changes.\n", plan.App, plan.Space)
+			}
+
+			if len(plan.ServiceBindings) > 0 {
+				fmt.Fprintf(w, "Services to bind: %s\n", strings.Join(plan.ServiceBindings, ", "))
+			}
+
+			if plan.SourcePath != "" {
+				fmt.Fprintf(w, "Source to upload: %s\n", plan.SourcePath)
+			}
+
+			if len(plan.ChangedFields) > 0 {
+				fmt.Fprintf(w, "Changed fields: %s\n", strings.Join(plan.ChangedFields, ", "))
+				fmt.Fprintln(w, plan.Diff)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q, must be one of text or json", format)
+	}
+}
+
 func pushTimeout(lookupEnv func(string) (string, bool)) (time.Duration, error) {
 	for _, env := range []string{"CF_STARTUP_TIMEOUT", "KF_STARTUP_TIMEOUT"} {
 		value, ok := lookupEnv(env)
@@ -611,7 +730,7 @@ func createServiceInstanceBinding(appName, serviceInstance, namespace string) v1
 }
 
 func createRoute(route manifest.Route, namespace string) (v1alpha1.RouteWeightBinding, error) {
-	hostname, domain, path, err := parseRouteStr(route.Route)
+	hostname, domain, path, port, err := parseRouteStr(route.Route)
 	if err != nil {
 		return v1alpha1.RouteWeightBinding{}, err
 	}
@@ -621,6 +740,7 @@ func createRoute(route manifest.Route, namespace string) (v1alpha1.RouteWeightBi
 			Hostname: hostname,
 			Domain:   domain,
 			Path:     path,
+			Port:     port,
 		},
 	}
 
@@ -631,19 +751,28 @@ func createRoute(route manifest.Route, namespace string) (v1alpha1.RouteWeightBi
 	return rwb, nil
 }
 
-// parseRouteStr parses a route URL into a hostname, domain, and path
-func parseRouteStr(routeStr string) (string, string, string, error) {
+// parseRouteStr parses a route URL into a hostname, domain, path, and port.
+// Routes with a port are TCP routes, so the whole host is used as the domain.
+func parseRouteStr(routeStr string) (string, string, string, int32, error) {
 	u, err := url.Parse(routeStr)
-	if err != nil {
-		return "", "", "", fmt.Errorf("failed to parse route: %s", err)
-	}
-	if u.Scheme == "" {
+	if err != nil || u.Host == "" {
 		// Parsing URLs without schemes causes the hostname and domain to incorrectly be empty.
 		// We handle this by assuming the route has a HTTP scheme if scheme is not provided.
+		// Hosts with ports like example.com:1024 parse as a scheme so they're
+		// handled here too.
 		u, err = url.Parse("http://" + routeStr)
 		if err != nil {
-			return "", "", "", fmt.Errorf("failed to parse route: %s", err)
+			return "", "", "", 0, fmt.Errorf("failed to parse route: %s", err)
+		}
+	}
+
+	if portStr := u.Port(); portStr != "" {
+		port, err := strconv.ParseInt(portStr, 10, 32)
+		if err != nil {
+			return "", "", "", 0, fmt.Errorf("failed to parse route port: %s", err)
 		}
+
+		return "", u.Hostname(), u.EscapedPath(), int32(port), nil
 	}
 
 	parts := strings.SplitN(u.Hostname(), ".", 3)
@@ -672,7 +801,7 @@ func parseRouteStr(routeStr string) (string, string, string, error) {
 
 	path = u.EscapedPath()
 
-	return hostname, domain, path, nil
+	return hostname, domain, path, 0, nil
 }
 
 func setupRoutes(space *v1alpha1.Space, app manifest.Application) (routes []v1alpha1.RouteWeightBinding, err error) {
@@ -784,24 +913,12 @@ func createManifest(
 	params *pushParams,
 	appName string,
 ) (*manifest.Manifest, error) {
-	variables := make(map[string]interface{})
-
-	for _, path := range params.varsFiles {
-		bytes, err := ioutil.ReadFile(path)
-		if err != nil {
-			return nil, fmt.Errorf("couldn't read var-file %q: %v", path, err)
-		}
-		if err = yaml.Unmarshal(bytes, &variables); err != nil {
-			return nil, fmt.Errorf("invalid var-file %q: %v", path, err)
-		}
-	}
-
-	for k, v := range params.vars {
-		variables[k] = v
+	variables, err := manifest.LoadVariables(params.varsFiles, params.vars)
+	if err != nil {
+		return nil, err
 	}
 
 	var pushManifest *manifest.Manifest
-	var err error
 	switch {
 	case params.noManifest:
 		if pushManifest, err = manifest.New(appName); err != nil {
@@ -904,3 +1021,29 @@ func checkStackExists(
 	}
 	return kfconfig.StackV3Definition{}, fmt.Errorf("no matching stack %q found in space %q", app.Stack, space.Name)
 }
+
+// parseRolloutStrategy converts the strategy and steps given on the command
+// line into an AppSpecRollout.
+func parseRolloutStrategy(strategy string, steps []int32) (*v1alpha1.AppSpecRollout, error) {
+	rollout := &v1alpha1.AppSpecRollout{}
+
+	switch strings.ToLower(strategy) {
+	case "rolling":
+		rollout.Strategy = v1alpha1.RollingRolloutStrategy
+	case "blue-green", "bluegreen":
+		rollout.Strategy = v1alpha1.BlueGreenRolloutStrategy
+	case "canary":
+		rollout.Strategy = v1alpha1.CanaryRolloutStrategy
+	default:
+		return nil, fmt.Errorf("unknown strategy %q, must be one of rolling, blue-green, or canary", strategy)
+	}
+
+	if len(steps) > 0 {
+		if rollout.Strategy != v1alpha1.CanaryRolloutStrategy {
+			return nil, errors.New("--canary-steps can only be used with --strategy canary")
+		}
+		rollout.Steps = steps
+	}
+
+	return rollout, nil
+}
@@ -337,6 +337,32 @@ func TestPushCommand(t *testing.T) {
 				apps.WithPushContainerImage(&someImage),
 			),
 		},
+		"canary rollout strategy": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--docker-image", "some-image",
+				"--strategy", "canary",
+				"--canary-steps", "20,40",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushSpace("some-namespace"),
+				apps.WithPushContainerImage(&someImage),
+				apps.WithPushRollout(&v1alpha1.AppSpecRollout{
+					Strategy: v1alpha1.CanaryRolloutStrategy,
+					Steps:    []int32{20, 40},
+				}),
+			),
+		},
+		"canary steps without strategy": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-name",
+				"--docker-image", "some-image",
+				"--canary-steps", "20,40",
+			},
+			wantErr: errors.New("--canary-steps can only be used with --strategy canary"),
+		},
 		"container image with AppDevExperience builds": {
 			namespace:      "some-namespace",
 			enableAppDevEx: true,
@@ -591,6 +617,27 @@ func TestPushCommand(t *testing.T) {
 				cwdSourcePathOption,
 			),
 		},
+		"create and map tcp routes from flags": {
+			namespace: "some-namespace",
+			args: []string{
+				"routes-app",
+				"--route=tcp.example.com:1024",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushSpace("some-namespace"),
+				buildpackWithoutSourceOption,
+				apps.WithPushRoutes([]v1alpha1.RouteWeightBinding{
+					{
+						RouteSpecFields: v1alpha1.RouteSpecFields{
+							Domain: "tcp.example.com",
+							Port:   1024,
+						},
+					},
+				}),
+				apps.WithPushGenerateDefaultRoute(false),
+				cwdSourcePathOption,
+			),
+		},
 		"http-health-check from manifest": {
 			namespace: "some-namespace",
 			args: []string{
@@ -789,7 +836,24 @@ func TestPushCommand(t *testing.T) {
 				"--vars-file", "testdata/replacement/prod.yaml",
 				"--vars-file", "testdata/replacement/ops-vars.json",
 			},
-			wantErr: errors.New("supplied manifest file testdata/replacement/manifest.yaml resulted in error: no variable found for key: ((db_url))"),
+			wantErr: errors.New("supplied manifest file testdata/replacement/manifest.yaml resulted in error: no variables found for keys: ((db_url))"),
+		},
+		"variable replacement lists all missing vars": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-accounting-prod",
+				"--manifest", "testdata/replacement/manifest.yaml",
+			},
+			wantErr: errors.New("supplied manifest file testdata/replacement/manifest.yaml resulted in error: no variables found for keys: ((db_url)), ((instances)), ((name)), ((registry)), ((version))"),
+		},
+		"invalid var": {
+			namespace: "some-namespace",
+			args: []string{
+				"app-accounting-prod",
+				"--manifest", "testdata/replacement/manifest.yaml",
+				"--var", "db_url",
+			},
+			wantErr: errors.New(`invalid var "db_url", expected NAME=VALUE`),
 		},
 		"variable replacement": {
 			namespace: "some-namespace",
@@ -1068,3 +1132,67 @@ func buildpackWithoutSource(b v1alpha1.BuildSpec) v1alpha1.BuildSpec {
 
 	return b
 }
+
+func TestWritePushPlans(t *testing.T) {
+	t.Parallel()
+
+	newApp := &apps.PushPlan{
+		App:             "new-app",
+		Space:           "some-space",
+		Create:          true,
+		Changed:         true,
+		ChangedFields:   []string{"instances"},
+		Diff:            "some-diff",
+		ServiceBindings: []string{"some-service"},
+		SourcePath:      "some-path",
+	}
+
+	unchangedApp := &apps.PushPlan{
+		App:   "unchanged-app",
+		Space: "some-space",
+	}
+
+	cases := map[string]struct {
+		format     string
+		plans      []*apps.PushPlan
+		wantOutput string
+		wantErr    error
+	}{
+		"text": {
+			plans: []*apps.PushPlan{newApp, unchangedApp},
+			wantOutput: `App "new-app" in Space "some-space" would be created.
+Services to bind: some-service
+Source to upload: some-path
+Changed fields: instances
+some-diff
+App "unchanged-app" in Space "some-space" has no changes.
+`,
+		},
+		"json": {
+			format: "json",
+			plans:  []*apps.PushPlan{unchangedApp},
+			wantOutput: `[
+  {
+    "app": "unchanged-app",
+    "space": "some-space",
+    "create": false,
+    "changed": false
+  }
+]
+`,
+		},
+		"unknown format": {
+			format:  "yaml",
+			wantErr: errors.New(`unknown output format "yaml", must be one of text or json`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			gotErr := writePushPlans(buffer, tc.format, tc.plans)
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertEqual(t, "output", tc.wantOutput, buffer.String())
+		})
+	}
+}
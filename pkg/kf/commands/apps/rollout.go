@@ -0,0 +1,211 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/describe"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/spf13/cobra"
+)
+
+// NewRolloutCommand creates a command to inspect and control App rollouts.
+func NewRolloutCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollout [subcommand]",
+		Short: "Inspect and control the rollout of a new App revision.",
+		Long: `
+		Apps pushed with the blue-green or canary strategy run the new revision
+		alongside the previous one and shift traffic to it gradually.
+
+		The rollout sub-commands show the progress of a rollout, promote a canary
+		to its next step, or abort the rollout and send all traffic back to the
+		previous revision.
+		`,
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newRolloutStatusCommand(p, client))
+	cmd.AddCommand(newRolloutPromoteCommand(p, client))
+	cmd.AddCommand(newRolloutAbortCommand(p, client))
+
+	return cmd
+}
+
+func newRolloutStatusCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:               "status APP_NAME",
+		Short:             "Show the status of the App's latest rollout.",
+		Example:           `kf rollout status myapp`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			app, err := client.Get(cmd.Context(), p.Space, appName)
+			if err != nil {
+				return fmt.Errorf("failed to get App: %s", err)
+			}
+
+			if app.Status.Rollout == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "App %q has no rollouts, it's updated using the %s strategy\n", appName, app.Spec.Rollout.GetStrategy())
+				return nil
+			}
+
+			describe.AppRolloutStatus(cmd.OutOrStdout(), app.Status.Rollout)
+			return nil
+		},
+	}
+}
+
+func newRolloutPromoteCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	var async utils.AsyncFlags
+
+	cmd := &cobra.Command{
+		Use:   "promote APP_NAME",
+		Short: "Move the App's canary rollout to its next step.",
+		Long: `
+		Promoting a canary rollout sends the next configured percentage of
+		traffic to the new revision. Promoting the last step replaces the
+		previous revision entirely.
+		`,
+		Example:           `kf rollout promote myapp`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			mutator := func(app *v1alpha1.App) error {
+				if err := checkRolloutActive(app); err != nil {
+					return err
+				}
+
+				if app.Status.Rollout.State != v1alpha1.RolloutStatePaused {
+					return fmt.Errorf("rollout is %s, only paused rollouts can be promoted", app.Status.Rollout.State)
+				}
+
+				app.Spec.Rollout.PromoteRequests++
+				return nil
+			}
+
+			if _, err := client.Transform(cmd.Context(), p.Space, appName, mutator); err != nil {
+				return fmt.Errorf("failed to promote App: %s", err)
+			}
+
+			action := fmt.Sprintf("Promoting App %q in Space %q", appName, p.Space)
+			return async.AwaitAndLog(cmd.OutOrStdout(), action, func() error {
+				_, err := client.WaitForConditionKnativeServiceReadyTrue(context.Background(), p.Space, appName, 1*time.Second)
+				return err
+			})
+		},
+	}
+
+	async.Add(cmd)
+
+	return cmd
+}
+
+func newRolloutAbortCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	var async utils.AsyncFlags
+
+	cmd := &cobra.Command{
+		Use:   "abort APP_NAME",
+		Short: "Abort the App's rollout and return traffic to the previous revision.",
+		Long: `
+		Aborting a rollout sends all traffic back to the previous revision and
+		removes the instances running the new revision. The aborted revision
+		won't be rolled out again until the App is pushed or changed.
+		`,
+		Example:           `kf rollout abort myapp`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			mutator := func(app *v1alpha1.App) error {
+				if err := checkRolloutActive(app); err != nil {
+					return err
+				}
+
+				if app.Status.Rollout.State == v1alpha1.RolloutStatePromoting {
+					return errors.New("rollout is already being promoted and can't be aborted")
+				}
+
+				app.Spec.Rollout.AbortRequests++
+				return nil
+			}
+
+			if _, err := client.Transform(cmd.Context(), p.Space, appName, mutator); err != nil {
+				return fmt.Errorf("failed to abort rollout: %s", err)
+			}
+
+			action := fmt.Sprintf("Aborting rollout of App %q in Space %q", appName, p.Space)
+			return async.AwaitAndLog(cmd.OutOrStdout(), action, func() error {
+				_, err := client.WaitForConditionKnativeServiceReadyTrue(context.Background(), p.Space, appName, 1*time.Second)
+				return err
+			})
+		},
+	}
+
+	async.Add(cmd)
+
+	return cmd
+}
+
+func checkRolloutActive(app *v1alpha1.App) error {
+	if app.Spec.Rollout == nil || !app.Status.Rollout.IsActive() {
+		return fmt.Errorf("App %q has no rollout in progress", app.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/apps/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func rolloutApp(state v1alpha1.RolloutState) v1alpha1.App {
+	app := v1alpha1.App{}
+	app.Name = "my-app"
+	app.Spec.Rollout = &v1alpha1.AppSpecRollout{
+		Strategy:        v1alpha1.CanaryRolloutStrategy,
+		Steps:           []int32{10, 50},
+		PromoteRequests: 3,
+		AbortRequests:   1,
+	}
+	app.Status.Rollout = &v1alpha1.AppRolloutStatus{
+		Strategy:         v1alpha1.CanaryRolloutStrategy,
+		State:            state,
+		Revision:         "1a2b3c4d",
+		CandidatePercent: 10,
+	}
+	return app
+}
+
+func TestRollout(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Space           string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fake *fake.FakeClient)
+	}{
+		"status shows rollout": {
+			Space: "default",
+			Args:  []string{"status", "my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				app := rolloutApp(v1alpha1.RolloutStatePaused)
+				fake.EXPECT().Get(gomock.Any(), "default", "my-app").Return(&app, nil)
+			},
+			ExpectedStrings: []string{"Canary", "Paused", "1a2b3c4d", "10%"},
+		},
+		"status without rollout": {
+			Space: "default",
+			Args:  []string{"status", "my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Get(gomock.Any(), "default", "my-app").Return(&v1alpha1.App{}, nil)
+			},
+			ExpectedStrings: []string{"has no rollouts", "Rolling"},
+		},
+		"status get fails": {
+			Space:       "default",
+			Args:        []string{"status", "my-app"},
+			ExpectedErr: errors.New("failed to get App: some-error"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Get(gomock.Any(), "default", "my-app").Return(nil, errors.New("some-error"))
+			},
+		},
+		"promote increments requests": {
+			Space: "default",
+			Args:  []string{"promote", "my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator apps.Mutator) (*v1alpha1.App, error) {
+						app := rolloutApp(v1alpha1.RolloutStatePaused)
+						testutil.AssertNil(t, "mutator err", mutator(&app))
+						testutil.AssertEqual(t, "promoteRequests", 4, app.Spec.Rollout.PromoteRequests)
+						testutil.AssertEqual(t, "abortRequests", 1, app.Spec.Rollout.AbortRequests)
+						return &app, nil
+					})
+				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
+			},
+		},
+		"promote requires paused rollout": {
+			Space:       "default",
+			Args:        []string{"promote", "my-app"},
+			ExpectedErr: errors.New("failed to promote App: rollout is Progressing, only paused rollouts can be promoted"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator apps.Mutator) (*v1alpha1.App, error) {
+						app := rolloutApp(v1alpha1.RolloutStateProgressing)
+						return nil, mutator(&app)
+					})
+			},
+		},
+		"abort increments requests": {
+			Space: "default",
+			Args:  []string{"abort", "my-app", "--async"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator apps.Mutator) (*v1alpha1.App, error) {
+						app := rolloutApp(v1alpha1.RolloutStateProgressing)
+						testutil.AssertNil(t, "mutator err", mutator(&app))
+						testutil.AssertEqual(t, "promoteRequests", 3, app.Spec.Rollout.PromoteRequests)
+						testutil.AssertEqual(t, "abortRequests", 2, app.Spec.Rollout.AbortRequests)
+						return &app, nil
+					})
+			},
+		},
+		"abort while promoting": {
+			Space:       "default",
+			Args:        []string{"abort", "my-app"},
+			ExpectedErr: errors.New("failed to abort rollout: rollout is already being promoted and can't be aborted"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator apps.Mutator) (*v1alpha1.App, error) {
+						app := rolloutApp(v1alpha1.RolloutStatePromoting)
+						return nil, mutator(&app)
+					})
+			},
+		},
+		"abort finished rollout": {
+			Space:       "default",
+			Args:        []string{"abort", "my-app"},
+			ExpectedErr: errors.New(`failed to abort rollout: App "my-app" has no rollout in progress`),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator apps.Mutator) (*v1alpha1.App, error) {
+						app := rolloutApp(v1alpha1.RolloutStateComplete)
+						return nil, mutator(&app)
+					})
+			},
+		},
+		"no app name": {
+			Space:       "default",
+			Args:        []string{"promote"},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Space: tc.Space,
+			}
+
+			cmd := NewRolloutCommand(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+			testutil.AssertEqual(t, "SilenceUsage", true, cmd.SilenceUsage)
+		})
+	}
+}
+
+func Test_parseRolloutStrategy(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		strategy    string
+		steps       []int32
+		expected    *v1alpha1.AppSpecRollout
+		expectedErr error
+	}{
+		"rolling": {
+			strategy: "rolling",
+			expected: &v1alpha1.AppSpecRollout{Strategy: v1alpha1.RollingRolloutStrategy},
+		},
+		"blue-green": {
+			strategy: "blue-green",
+			expected: &v1alpha1.AppSpecRollout{Strategy: v1alpha1.BlueGreenRolloutStrategy},
+		},
+		"canary with steps": {
+			strategy: "Canary",
+			steps:    []int32{20, 40},
+			expected: &v1alpha1.AppSpecRollout{
+				Strategy: v1alpha1.CanaryRolloutStrategy,
+				Steps:    []int32{20, 40},
+			},
+		},
+		"steps without canary": {
+			strategy:    "blue-green",
+			steps:       []int32{20, 40},
+			expectedErr: errors.New("--canary-steps can only be used with --strategy canary"),
+		},
+		"unknown strategy": {
+			strategy:    "recreate",
+			expectedErr: errors.New(`unknown strategy "recreate", must be one of rolling, blue-green, or canary`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual, err := parseRolloutStrategy(tc.strategy, tc.steps)
+			testutil.AssertErrorsEqual(t, tc.expectedErr, err)
+			testutil.AssertEqual(t, "rollout", tc.expected, actual)
+		})
+	}
+}
@@ -36,7 +36,8 @@ func NewScaleCommand(
 	var (
 		async utils.AsyncFlags
 
-		instances int32
+		instances   int32
+		processType string
 	)
 
 	cmd := &cobra.Command{
@@ -51,12 +52,17 @@ func NewScaleCommand(
 		additional instance of the App and swapping it out for an old instance.
 
 		The operation completes once all instances have been replaced.
+
+		Apps with additional processes, such as workers, can scale each
+		process independently using the --process flag.
 		`,
 		Example: `
 		# Display current scale settings
 		kf scale myapp
 		# Scale to exactly 3 instances
 		kf scale myapp --instances 3
+		# Scale the worker process to exactly 2 instances
+		kf scale myapp --process worker --instances 2
 		`,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: completion.AppCompletionFn(p),
@@ -75,11 +81,29 @@ func NewScaleCommand(
 					return fmt.Errorf("failed to get App: %s", err)
 				}
 				describe.AppSpecInstances(cmd.OutOrStderr(), app.Spec.Instances)
+				describe.AppSpecProcesses(cmd.OutOrStderr(), app.Spec.Processes)
 				return nil
 			}
 
 			// Manipulate the scaling
 			mutator := func(app *v1alpha1.App) error {
+				if processType != v1alpha1.WebProcessType {
+					process := app.Spec.Process(processType)
+					if process == nil {
+						return fmt.Errorf("App %q has no process %q", appName, processType)
+					}
+
+					process.Replicas = &instances
+
+					if err := process.Validate(context.Background()); err != nil {
+						return err
+					}
+
+					describe.AppSpecProcesses(cmd.OutOrStderr(), app.Spec.Processes)
+
+					return nil
+				}
+
 				if app.Spec.Instances.Autoscaling.RequiresHPA() {
 					utils.SuggestNextAction(utils.NextAction{
 						Description: "Disable autoscaling",
@@ -128,5 +152,12 @@ func NewScaleCommand(
 		"Number of instances, must be >= 1.",
 	)
 
+	cmd.Flags().StringVar(
+		&processType,
+		"process",
+		v1alpha1.WebProcessType,
+		"Type of the App's process to scale.",
+	)
+
 	return cmd
 }
@@ -58,6 +58,40 @@ func TestNewScaleCommand(t *testing.T) {
 				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
 			},
 		},
+		"updates process instances": {
+			Space:           "default",
+			Args:            []string{"my-app", "--process=worker", "-i=2"},
+			ExpectedStrings: []string{"Processes:", "worker", "2"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					Do(func(_ context.Context, _, _ string, m apps.Mutator) {
+						app := v1alpha1.App{}
+						app.Spec.Instances.Replicas = ptr.Int32(5)
+						app.Spec.Processes = []v1alpha1.AppSpecProcess{{Type: "worker"}}
+						testutil.AssertNil(t, "mutator error", m(&app))
+						testutil.AssertEqual(t, "app.spec.processes[0].replicas", ptr.Int32(2), app.Spec.Processes[0].Replicas)
+
+						// Assert the web process wasn't altered
+						testutil.AssertEqual(t, "app.spec.instances.replicas", ptr.Int32(5), app.Spec.Instances.Replicas)
+					})
+				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
+			},
+		},
+		"missing process returns error": {
+			Space:       "default",
+			Args:        []string{"my-app", "--process=clock", "-i=2"},
+			ExpectedErr: errors.New(`failed to scale App: App "my-app" has no process "clock"`),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, m apps.Mutator) (*v1alpha1.App, error) {
+						app := v1alpha1.App{}
+						app.Spec.Processes = []v1alpha1.AppSpecProcess{{Type: "worker"}}
+						return nil, m(&app)
+					})
+			},
+		},
 		"async does not wait": {
 			Space: "default",
 			Args:  []string{"my-app", "--instances=3", "--async"},
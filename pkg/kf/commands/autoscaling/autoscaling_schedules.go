@@ -0,0 +1,135 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/describe"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewAutoscalingSchedules command lists the scheduled autoscaling windows of
+// an App.
+func NewAutoscalingSchedules(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "autoscaling-schedules APP_NAME",
+		Short:             "List scheduled autoscaling windows for App.",
+		Example:           `kf autoscaling-schedules myapp`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			app, err := client.Get(cmd.Context(), p.Space, appName)
+			if err != nil {
+				return fmt.Errorf("failed to get App: %s", err)
+			}
+
+			statuses := make(map[string]v1alpha1.AutoscalingScheduleStatus)
+			for _, status := range app.Status.AutoscalingSchedules {
+				statuses[status.Name] = status
+			}
+
+			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
+				fmt.Fprintln(w, "Name\tTime Zone\tDays\tStart\tEnd\tMin\tMax\tActive\tNext Start\tNext End")
+
+				for _, schedule := range app.Spec.Instances.Autoscaling.Schedules {
+					status := statuses[schedule.Name]
+
+					fmt.Fprintf(
+						w,
+						"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\t%s\n",
+						schedule.Name,
+						scheduleTimeZone(schedule),
+						scheduleDays(schedule),
+						strings.TrimSpace(schedule.StartDate+" "+schedule.StartTime),
+						strings.TrimSpace(schedule.EndDate+" "+schedule.EndTime),
+						formatReplicas(schedule.MinReplicas),
+						formatReplicas(schedule.MaxReplicas),
+						status.Active,
+						formatScheduleTime(status.NextStartTime),
+						formatScheduleTime(status.NextEndTime),
+					)
+				}
+			})
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func scheduleTimeZone(schedule v1alpha1.AppAutoscalingSchedule) string {
+	if schedule.TimeZone == "" {
+		return time.UTC.String()
+	}
+
+	return schedule.TimeZone
+}
+
+func scheduleDays(schedule v1alpha1.AppAutoscalingSchedule) string {
+	var days []string
+	for _, day := range schedule.DaysOfWeek {
+		days = append(days, weekdayName(day))
+	}
+	for _, day := range schedule.DaysOfMonth {
+		days = append(days, fmt.Sprint(day))
+	}
+
+	return strings.Join(days, ",")
+}
+
+func weekdayName(day int32) string {
+	if day < 1 || day > 7 {
+		return fmt.Sprint(day)
+	}
+
+	// ISO days start on Monday, time.Weekday starts on Sunday.
+	return time.Weekday(day % 7).String()[:3]
+}
+
+func formatReplicas(replicas *int32) string {
+	if replicas == nil {
+		return ""
+	}
+
+	return fmt.Sprint(*replicas)
+}
+
+func formatScheduleTime(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
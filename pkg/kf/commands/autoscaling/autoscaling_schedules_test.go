@@ -0,0 +1,122 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/ptr"
+)
+
+func TestAutoscalingSchedulesCommand(t *testing.T) {
+	t.Parallel()
+
+	nextEnd := metav1.NewTime(time.Date(2022, 6, 6, 22, 0, 0, 0, time.UTC))
+
+	cases := map[string]struct {
+		Space           string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fake *fake.FakeClient)
+	}{
+		"lists schedules": {
+			Space: "default",
+			Args:  []string{"my-app"},
+			ExpectedStrings: []string{
+				"Name", "Time Zone", "Next Start",
+				"business-hours", "America/New_York", "Mon,Fri,Sun", "08:00", "18:00", "5", "20", "true", "2022-06-06T22:00:00Z",
+				"sale", "UTC", "2022-11-25 00:00", "2022-11-28 23:59", "false",
+			},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				app := &v1alpha1.App{}
+				app.Spec.Instances.Autoscaling.Schedules = []v1alpha1.AppAutoscalingSchedule{
+					{
+						Name:        "business-hours",
+						TimeZone:    "America/New_York",
+						DaysOfWeek:  []int32{1, 5, 7},
+						StartTime:   "08:00",
+						EndTime:     "18:00",
+						MinReplicas: ptr.Int32(5),
+						MaxReplicas: ptr.Int32(20),
+					},
+					{
+						Name:        "sale",
+						StartDate:   "2022-11-25",
+						StartTime:   "00:00",
+						EndDate:     "2022-11-28",
+						EndTime:     "23:59",
+						MinReplicas: ptr.Int32(10),
+					},
+				}
+				app.Status.AutoscalingSchedules = []v1alpha1.AutoscalingScheduleStatus{
+					{Name: "business-hours", Active: true, NextEndTime: &nextEnd},
+				}
+
+				fake.EXPECT().Get(gomock.Any(), "default", "my-app").Return(app, nil)
+			},
+		},
+		"wrong number of args": {
+			Space:       "default",
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"getting app failed": {
+			Space:       "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to get App: some-error"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Get(gomock.Any(), "default", "my-app").Return(nil, errors.New("some-error"))
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Space: tc.Space,
+			}
+
+			cmd := NewAutoscalingSchedules(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+		})
+	}
+}
@@ -42,15 +42,36 @@ func NewCreateAutoscalingRule(
 		Long: `
 		Create an autoscaling rule for App.
 
-		The only supported rule type is CPU. It is the target
-		percentage. It is calculated by taking the average of MIN_THRESHOLD
-		and MAX_THRESHOLD.
+		The target is calculated by taking the average of MIN_THRESHOLD and
+		MAX_THRESHOLD. The supported rule types are:
 
-		The range of MIN_THRESHOLD and MAX_THRESHOLD is 1 to 100 (percent).
+		* CPU: The target average CPU utilization of the App's instances.
+		  The range of MIN_THRESHOLD and MAX_THRESHOLD is 1 to 100 (percent).
+		* Memory: The target average memory utilization of the App's
+		  instances. The range of MIN_THRESHOLD and MAX_THRESHOLD is 1 to 100
+		  (percent).
+		* HTTPThroughput: The target number of HTTP requests per second each
+		  instance receives.
+		* HTTPLatency: The target average HTTP response time of the App's
+		  instances in milliseconds.
+
+		The HTTPThroughput and HTTPLatency rule types use metrics reported by
+		the Istio sidecar. They require a metrics adapter that serves the
+		istio_requests_per_second and istio_request_duration_milliseconds
+		metrics through the Kubernetes custom metrics API.
 		`,
 		Example: `
 		# Scale myapp based on CPU load targeting 50% utilization (halfway between 20 and 80)
 		kf create-autoscaling-rule myapp CPU 20 80
+
+		# Scale myapp based on memory usage targeting 60% utilization
+		kf create-autoscaling-rule myapp Memory 50 70
+
+		# Scale myapp to handle 100 requests per second per instance
+		kf create-autoscaling-rule myapp HTTPThroughput 100 100
+
+		# Scale myapp to keep the average response time around 200ms
+		kf create-autoscaling-rule myapp HTTPLatency 150 250
 		`,
 		Args:              cobra.ExactArgs(4),
 		ValidArgsFunction: completion.AppCompletionFn(p),
@@ -72,7 +93,7 @@ func NewCreateAutoscalingRule(
 			}
 
 			// Validation on rules are done on the server side.
-			// For v1, there can be only one rule and rule type has to be CPU.
+			// There can be only one rule and rule type has to be supported.
 			mutator := func(app *v1alpha1.App) error {
 				app.Spec.Instances.Autoscaling.Rules =
 					append(app.Spec.Instances.Autoscaling.Rules,
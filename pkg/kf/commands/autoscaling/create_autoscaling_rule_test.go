@@ -68,6 +68,23 @@ func TestCreateAutoscalingRuleCommand(t *testing.T) {
 				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
 			},
 		},
+		"rule type is case insensitive": {
+			Space:           "default",
+			Args:            []string{"my-app", "httpthroughput", "100", "300"},
+			ExpectedStrings: []string{"Creating"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				app := &v1alpha1.App{}
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					Do(func(_ context.Context, _, _ string, m apps.Mutator) {
+						testutil.AssertNil(t, "mutator error", m(app))
+						testutil.AssertEqual(t, "app.spec.instances.autoscalingspec.rules", 1, len(app.Spec.Instances.Autoscaling.Rules))
+						testutil.AssertEqual(t, "app.spec.instances.autoscalingspec.rules[0].ruletype", v1alpha1.HTTPThroughputRuleType, app.Spec.Instances.Autoscaling.Rules[0].RuleType)
+						testutil.AssertEqual(t, "app.spec.instances.autoscalingspec.rules[0].target", int32(200), *app.Spec.Instances.Autoscaling.Rules[0].Target)
+					})
+				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
+			},
+		},
 		"wrong number of args": {
 			Space:       "default",
 			Args:        []string{"CPU", "20", "80"},
@@ -0,0 +1,229 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/spf13/cobra"
+	"knative.dev/pkg/ptr"
+)
+
+// NewCreateAutoscalingSchedule command adds a scheduled autoscaling window to
+// an App.
+func NewCreateAutoscalingSchedule(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	var (
+		async        utils.AsyncFlags
+		schedule     v1alpha1.AppAutoscalingSchedule
+		daysOfWeek   []string
+		daysOfMonth  []int32
+		minInstances int32
+		maxInstances int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-autoscaling-schedule APP_NAME SCHEDULE_NAME",
+		Short: "Create a scheduled autoscaling window for App.",
+		Long: `
+		Create a window of time during which the App's autoscaling limits are
+		replaced by the schedule's minimum and maximum number of instances.
+
+		Recurring schedules occur between --start-time and --end-time on the
+		days given by --days-of-week or --days-of-month, optionally limited to
+		the dates between --start-date and --end-date. Schedules without days
+		are specific-date schedules that start at --start-date --start-time and
+		end at --end-date --end-time.
+
+		If a schedule with the same name exists it's replaced. If multiple
+		schedules are active at the same time the first one is applied.
+		`,
+		Example: `
+		# Keep between 5 and 20 instances of myapp during business hours
+		kf create-autoscaling-schedule myapp business-hours \
+		  --days-of-week Mon,Tue,Wed,Thu,Fri \
+		  --start-time 08:00 --end-time 18:00 \
+		  --time-zone America/New_York \
+		  --min-instances 5 --max-instances 20
+
+		# Keep at least 10 instances of myapp for a sale
+		kf create-autoscaling-schedule myapp sale \
+		  --start-date 2022-11-25 --start-time 00:00 \
+		  --end-date 2022-11-28 --end-time 23:59 \
+		  --min-instances 10 --max-instances 50
+		`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			appName := args[0]
+			schedule.Name = args[1]
+
+			days, err := parseDaysOfWeek(daysOfWeek)
+			if err != nil {
+				return err
+			}
+			schedule.DaysOfWeek = days
+			schedule.DaysOfMonth = daysOfMonth
+
+			if cmd.Flags().Changed("min-instances") {
+				schedule.MinReplicas = ptr.Int32(minInstances)
+			}
+			if cmd.Flags().Changed("max-instances") {
+				schedule.MaxReplicas = ptr.Int32(maxInstances)
+			}
+
+			// Validation on schedules is done on the server side.
+			mutator := func(app *v1alpha1.App) error {
+				autoscaling := &app.Spec.Instances.Autoscaling
+				for i := range autoscaling.Schedules {
+					if autoscaling.Schedules[i].Name == schedule.Name {
+						autoscaling.Schedules[i] = schedule
+						return nil
+					}
+				}
+
+				autoscaling.Schedules = append(autoscaling.Schedules, schedule)
+				return nil
+			}
+
+			if _, err := client.Transform(cmd.Context(), p.Space, appName, mutator); err != nil {
+				return fmt.Errorf("failed to add autoscaling schedule for App: %s", err)
+			}
+
+			action := fmt.Sprintf("Creating autoscaling schedule %q for App %q in Space %q", schedule.Name, appName, p.Space)
+			return async.AwaitAndLog(cmd.OutOrStdout(), action, func() error {
+				_, err := client.WaitForConditionKnativeServiceReadyTrue(context.Background(), p.Space, appName, 1*time.Second)
+				return err
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	async.Add(cmd)
+
+	cmd.Flags().StringVar(
+		&schedule.StartTime,
+		"start-time",
+		"",
+		"Time of day the window starts in HH:MM format.",
+	)
+
+	cmd.Flags().StringVar(
+		&schedule.EndTime,
+		"end-time",
+		"",
+		"Time of day the window ends in HH:MM format.",
+	)
+
+	cmd.Flags().StringVar(
+		&schedule.StartDate,
+		"start-date",
+		"",
+		"Date in YYYY-MM-DD format the window starts, or the first date a recurring window occurs.",
+	)
+
+	cmd.Flags().StringVar(
+		&schedule.EndDate,
+		"end-date",
+		"",
+		"Date in YYYY-MM-DD format the window ends, or the last date a recurring window occurs.",
+	)
+
+	cmd.Flags().StringVar(
+		&schedule.TimeZone,
+		"time-zone",
+		"",
+		"IANA time zone of the times and dates, e.g. America/New_York. Defaults to UTC.",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&daysOfWeek,
+		"days-of-week",
+		nil,
+		"Days of the week a recurring window occurs on, either 1 (Monday) to 7 (Sunday) or day names.",
+	)
+
+	cmd.Flags().Int32SliceVar(
+		&daysOfMonth,
+		"days-of-month",
+		nil,
+		"Days of the month a recurring window occurs on, from 1 to 31.",
+	)
+
+	cmd.Flags().Int32Var(
+		&minInstances,
+		"min-instances",
+		0,
+		"Minimum number of instances during the window.",
+	)
+
+	cmd.Flags().Int32Var(
+		&maxInstances,
+		"max-instances",
+		0,
+		"Maximum number of instances during the window.",
+	)
+
+	return cmd
+}
+
+// parseDaysOfWeek converts days given as ISO day numbers or English day names
+// into ISO day numbers.
+func parseDaysOfWeek(days []string) ([]int32, error) {
+	var out []int32
+	for _, day := range days {
+		if n, err := strconv.ParseInt(day, 10, 32); err == nil {
+			out = append(out, int32(n))
+			continue
+		}
+
+		n, ok := dayOfWeekNumber(day)
+		if !ok {
+			return nil, fmt.Errorf("invalid day of week %q", day)
+		}
+		out = append(out, n)
+	}
+
+	return out, nil
+}
+
+func dayOfWeekNumber(name string) (int32, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := d.String()
+		if strings.EqualFold(name, full) || strings.EqualFold(name, full[:3]) {
+			if d == time.Sunday {
+				return 7, true
+			}
+			return int32(d), true
+		}
+	}
+
+	return 0, false
+}
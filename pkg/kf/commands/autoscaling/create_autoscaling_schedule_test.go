@@ -0,0 +1,160 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaling
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/apps"
+	"github.com/google/kf/v2/pkg/kf/apps/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"knative.dev/pkg/ptr"
+)
+
+func TestCreateAutoscalingScheduleCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		Space           string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fake *fake.FakeClient)
+	}{
+		"creates recurring schedule": {
+			Space: "default",
+			Args: []string{
+				"my-app", "business-hours",
+				"--days-of-week", "Mon,tuesday,3",
+				"--start-time", "08:00",
+				"--end-time", "18:00",
+				"--time-zone", "America/New_York",
+				"--min-instances", "5",
+				"--max-instances", "20",
+			},
+			ExpectedStrings: []string{"Creating", "business-hours"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				app := &v1alpha1.App{}
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					Do(func(_ context.Context, _, _ string, m apps.Mutator) {
+						testutil.AssertNil(t, "mutator error", m(app))
+						testutil.AssertEqual(t, "schedules", []v1alpha1.AppAutoscalingSchedule{
+							{
+								Name:        "business-hours",
+								TimeZone:    "America/New_York",
+								DaysOfWeek:  []int32{1, 2, 3},
+								StartTime:   "08:00",
+								EndTime:     "18:00",
+								MinReplicas: ptr.Int32(5),
+								MaxReplicas: ptr.Int32(20),
+							},
+						}, app.Spec.Instances.Autoscaling.Schedules)
+					})
+				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
+			},
+		},
+		"replaces schedule with the same name": {
+			Space: "default",
+			Args: []string{
+				"my-app", "sale",
+				"--start-date", "2022-11-25",
+				"--start-time", "00:00",
+				"--end-date", "2022-11-28",
+				"--end-time", "23:59",
+				"--min-instances", "10",
+			},
+			ExpectedStrings: []string{"Creating"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				app := &v1alpha1.App{}
+				app.Spec.Instances.Autoscaling.Schedules = []v1alpha1.AppAutoscalingSchedule{
+					{Name: "nights", DaysOfMonth: []int32{1}, StartTime: "00:00", EndTime: "06:00"},
+					{Name: "sale", StartDate: "2022-11-01", StartTime: "00:00", EndDate: "2022-11-02", EndTime: "00:00"},
+				}
+				fake.EXPECT().
+					Transform(gomock.Any(), "default", "my-app", gomock.Any()).
+					Do(func(_ context.Context, _, _ string, m apps.Mutator) {
+						testutil.AssertNil(t, "mutator error", m(app))
+						schedules := app.Spec.Instances.Autoscaling.Schedules
+						testutil.AssertEqual(t, "schedules count", 2, len(schedules))
+						testutil.AssertEqual(t, "schedules[0].name", "nights", schedules[0].Name)
+						testutil.AssertEqual(t, "schedules[1]", v1alpha1.AppAutoscalingSchedule{
+							Name:        "sale",
+							StartDate:   "2022-11-25",
+							StartTime:   "00:00",
+							EndDate:     "2022-11-28",
+							EndTime:     "23:59",
+							MinReplicas: ptr.Int32(10),
+						}, schedules[1])
+					})
+				fake.EXPECT().WaitForConditionKnativeServiceReadyTrue(gomock.Any(), "default", "my-app", gomock.Any())
+			},
+		},
+		"wrong number of args": {
+			Space:       "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("accepts 2 arg(s), received 1"),
+		},
+		"invalid day of week": {
+			Space:       "default",
+			Args:        []string{"my-app", "weekends", "--days-of-week", "Caturday"},
+			ExpectedErr: errors.New(`invalid day of week "Caturday"`),
+		},
+		"updating app failed": {
+			Space:       "default",
+			Args:        []string{"my-app", "nights", "--days-of-month", "1"},
+			ExpectedErr: errors.New("failed to add autoscaling schedule for App: some-error"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Space: tc.Space,
+			}
+
+			cmd := NewCreateAutoscalingSchedule(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+		})
+	}
+}
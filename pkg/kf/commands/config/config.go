@@ -62,6 +62,9 @@ type KfParams struct {
 	// Space holds the namespace kf should connect to by default.
 	Space string `json:"space"`
 
+	// Org holds the Org the targeted Space belongs to, if any.
+	Org string `json:"org,omitempty"`
+
 	// KubeCfgFile holds the path to the kubeconfig.
 	KubeCfgFile string `json:"-"`
 
@@ -251,6 +251,18 @@ func TestGetContainer(t *testing.T) {
 					SuccessThreshold:    1,
 					FailureThreshold:    0,
 				},
+				StartupProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Port: intstr.FromInt(int(resources.DefaultUserPort)),
+						},
+					},
+					InitialDelaySeconds: 0,
+					TimeoutSeconds:      60,
+					PeriodSeconds:       2,
+					SuccessThreshold:    1,
+					FailureThreshold:    30,
+				},
 			},
 		},
 		"have manifest but no health check": {
@@ -353,6 +365,18 @@ func TestGetContainer(t *testing.T) {
 					SuccessThreshold:    1,
 					FailureThreshold:    0,
 				},
+				StartupProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Port: intstr.FromInt(int(resources.DefaultUserPort)),
+						},
+					},
+					InitialDelaySeconds: 0,
+					TimeoutSeconds:      60,
+					PeriodSeconds:       2,
+					SuccessThreshold:    1,
+					FailureThreshold:    30,
+				},
 			},
 		},
 	}
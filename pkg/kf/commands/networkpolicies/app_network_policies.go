@@ -0,0 +1,302 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/client/kf/injection/client"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/internal/genericcli"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+var appNetworkPolicyResourceInfo = &genericcli.KubernetesType{
+	Group:    "kf.dev",
+	Version:  "v1alpha1",
+	Kind:     "AppNetworkPolicy",
+	Resource: "appnetworkpolicies",
+	NsScoped: true,
+	KfName:   "AppNetworkPolicy",
+}
+
+// appNetworkPolicyFlags holds the flags shared by commands that identify an
+// AppNetworkPolicy.
+type appNetworkPolicyFlags struct {
+	destinationApp   string
+	destinationSpace string
+	protocol         string
+	ports            string
+}
+
+func (f *appNetworkPolicyFlags) Add(cmd *cobra.Command, p *config.KfParams) {
+	cmd.Flags().StringVar(
+		&f.destinationApp,
+		"destination-app",
+		"",
+		"App that receives traffic.",
+	)
+	cmd.MarkFlagRequired("destination-app")
+	cmd.RegisterFlagCompletionFunc("destination-app", completion.AppCompletionFn(p))
+
+	cmd.Flags().StringVar(
+		&f.destinationSpace,
+		"destination-space",
+		"",
+		"Space of the destination App, defaults to the targeted Space.",
+	)
+	cmd.RegisterFlagCompletionFunc("destination-space", completion.SpaceCompletionFn(p))
+
+	cmd.Flags().StringVar(
+		&f.protocol,
+		"protocol",
+		v1alpha1.AppNetworkPolicyProtocolTCP,
+		"Protocol traffic is allowed on, tcp or udp.",
+	)
+
+	cmd.Flags().StringVar(
+		&f.ports,
+		"port",
+		v1alpha1.DefaultAppNetworkPolicyPorts,
+		"Port or range of ports traffic is allowed on e.g. 8080 or 8000-9000.",
+	)
+}
+
+// policy creates the AppNetworkPolicy described by the flags, it lives in the
+// destination App's Space.
+func (f *appNetworkPolicyFlags) policy(sourceSpace, sourceApp string) *v1alpha1.AppNetworkPolicy {
+	destinationSpace := f.destinationSpace
+	if destinationSpace == "" {
+		destinationSpace = sourceSpace
+	}
+
+	policy := &v1alpha1.AppNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: destinationSpace,
+		},
+		Spec: v1alpha1.AppNetworkPolicySpec{
+			Source: v1alpha1.AppNetworkPolicySource{
+				Space: sourceSpace,
+				App:   sourceApp,
+			},
+			DestinationApp: f.destinationApp,
+			Protocol:       f.protocol,
+			Ports:          f.ports,
+		},
+	}
+
+	policy.SetDefaults(context.Background())
+	policy.Name = v1alpha1.GenerateName(
+		sourceSpace,
+		sourceApp,
+		"to",
+		policy.Spec.DestinationApp,
+		policy.Spec.Protocol,
+		policy.Spec.Ports,
+	)
+
+	return policy
+}
+
+// NewAddCommand allows users to add AppNetworkPolicies.
+func NewAddCommand(p *config.KfParams) *cobra.Command {
+	var (
+		flags appNetworkPolicyFlags
+		async utils.AsyncFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add-network-policy SOURCE_APP --destination-app DESTINATION_APP",
+		Short: "Allow an App to send traffic directly to another App.",
+		Long: `
+		Creates an AppNetworkPolicy that allows the source App in the targeted
+		Space to send traffic to the destination App over the cluster network.
+
+		The AppNetworkPolicy is created in the destination App's Space and
+		generates a Kubernetes NetworkPolicy that allows ingress to the
+		destination App's Pods from the source App's Pods.
+		`,
+		Example: `
+		# Allow frontend to call backend on port 8080 using TCP.
+		kf add-network-policy frontend --destination-app backend
+
+		# Allow frontend to call backend in the api Space on ports 9000 to 9100.
+		kf add-network-policy frontend --destination-app backend --destination-space api --port 9000-9100
+		`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			policy := flags.policy(p.Space, args[0])
+			if err := policy.Validate(ctx); err != nil {
+				return err
+			}
+
+			client := client.Get(ctx)
+
+			if _, err := client.KfV1alpha1().
+				Apps(policy.SourceSpace()).
+				Get(ctx, policy.Spec.Source.App, metav1.GetOptions{}); err != nil {
+				return fmt.Errorf("failed to get source App: %s", err)
+			}
+
+			if _, err := client.KfV1alpha1().
+				Apps(policy.Namespace).
+				Get(ctx, policy.Spec.DestinationApp, metav1.GetOptions{}); err != nil {
+				return fmt.Errorf("failed to get destination App: %s", err)
+			}
+
+			_, err := client.KfV1alpha1().
+				AppNetworkPolicies(policy.Namespace).
+				Create(ctx, policy, metav1.CreateOptions{})
+			switch {
+			case apierrs.IsAlreadyExists(err):
+				logging.FromContext(ctx).Infof("Network policy %s already exists.", policy.Name)
+				return nil
+			case err != nil:
+				return fmt.Errorf("failed to create AppNetworkPolicy: %s", err)
+			}
+
+			logging.FromContext(ctx).Infof("Network policy %s created.", policy.Name)
+
+			return async.WaitFor(
+				ctx,
+				cmd.OutOrStderr(),
+				"Waiting for network policy to become ready",
+				time.Second,
+				func() (bool, error) {
+					actual, err := client.KfV1alpha1().
+						AppNetworkPolicies(policy.Namespace).
+						Get(ctx, policy.Name, metav1.GetOptions{})
+					if err != nil {
+						return false, err
+					}
+					return actual.Status.IsReady(), nil
+				},
+			)
+		},
+	}
+
+	flags.Add(cmd, p)
+	async.Add(cmd)
+
+	return cmd
+}
+
+// NewRemoveCommand allows users to remove AppNetworkPolicies.
+func NewRemoveCommand(p *config.KfParams) *cobra.Command {
+	var flags appNetworkPolicyFlags
+
+	cmd := &cobra.Command{
+		Use:   "remove-network-policy SOURCE_APP --destination-app DESTINATION_APP",
+		Short: "Stop allowing an App to send traffic directly to another App.",
+		Long: `
+		Deletes the AppNetworkPolicies in the destination App's Space that
+		allow the source App in the targeted Space to send traffic to the
+		destination App with the given protocol and ports.
+		`,
+		Example: `
+		kf remove-network-policy frontend --destination-app backend
+		`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AppCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			want := flags.policy(p.Space, args[0])
+			client := client.Get(ctx)
+
+			policies, err := client.KfV1alpha1().
+				AppNetworkPolicies(want.Namespace).
+				List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to list AppNetworkPolicies: %s", err)
+			}
+
+			removed := 0
+			for _, policy := range policies.Items {
+				if !sameAppNetworkPolicy(want, &policy) {
+					continue
+				}
+
+				if err := client.KfV1alpha1().
+					AppNetworkPolicies(policy.Namespace).
+					Delete(ctx, policy.Name, metav1.DeleteOptions{}); err != nil {
+					return fmt.Errorf("failed to delete AppNetworkPolicy: %s", err)
+				}
+
+				logging.FromContext(ctx).Infof("Network policy %s removed.", policy.Name)
+				removed++
+			}
+
+			if removed == 0 {
+				return fmt.Errorf(
+					"no network policy allows %s/%s to reach %s/%s on %s port %s",
+					want.SourceSpace(),
+					want.Spec.Source.App,
+					want.Namespace,
+					want.Spec.DestinationApp,
+					want.Spec.Protocol,
+					want.Spec.Ports,
+				)
+			}
+
+			return nil
+		},
+	}
+
+	flags.Add(cmd, p)
+
+	return cmd
+}
+
+// sameAppNetworkPolicy returns true if the policies allow the same traffic.
+func sameAppNetworkPolicy(want, actual *v1alpha1.AppNetworkPolicy) bool {
+	actual = actual.DeepCopy()
+	actual.SetDefaults(context.Background())
+
+	return want.SourceSpace() == actual.SourceSpace() &&
+		want.Spec.Source.App == actual.Spec.Source.App &&
+		want.Spec.DestinationApp == actual.Spec.DestinationApp &&
+		want.Spec.Protocol == actual.Spec.Protocol &&
+		want.Spec.Ports == actual.Spec.Ports
+}
+
+// NewListAppNetworkPoliciesCommand allows users to list AppNetworkPolicies.
+func NewListAppNetworkPoliciesCommand(p *config.KfParams) *cobra.Command {
+	return genericcli.NewListCommand(
+		appNetworkPolicyResourceInfo,
+		p,
+		genericcli.WithListPluralFriendlyName("app network policies"),
+		genericcli.WithListCommandName("app-network-policies"),
+	)
+}
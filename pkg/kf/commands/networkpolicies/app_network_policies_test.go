@@ -0,0 +1,225 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicies_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	fakeclient "github.com/google/kf/v2/pkg/client/kf/injection/client/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	configlogging "github.com/google/kf/v2/pkg/kf/commands/config/logging"
+	"github.com/google/kf/v2/pkg/kf/commands/networkpolicies"
+	fakeinjection "github.com/google/kf/v2/pkg/kf/injection/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createApps(ctx context.Context, space string, names ...string) {
+	for _, name := range names {
+		app := &v1alpha1.App{}
+		app.Name = name
+		app.Namespace = space
+		fakeclient.Get(ctx).KfV1alpha1().Apps(space).Create(ctx, app, metav1.CreateOptions{})
+	}
+}
+
+func listAppNetworkPolicies(ctx context.Context, t *testing.T, space string) []v1alpha1.AppNetworkPolicy {
+	list, err := fakeclient.Get(ctx).KfV1alpha1().AppNetworkPolicies(space).List(ctx, metav1.ListOptions{})
+	testutil.AssertNil(t, "list err", err)
+	return list.Items
+}
+
+func TestNewAddCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		space     string
+		args      []string
+		setup     func(ctx context.Context)
+		expectErr error
+		assert    func(ctx context.Context, t *testing.T)
+	}{
+		"no target space": {
+			args:      []string{"frontend", "--destination-app", "backend"},
+			expectErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		"missing destination": {
+			space:     "web",
+			args:      []string{"frontend"},
+			expectErr: errors.New(`required flag(s) "destination-app" not set`),
+		},
+		"invalid protocol": {
+			space:     "web",
+			args:      []string{"frontend", "--destination-app", "backend", "--protocol", "icmp"},
+			expectErr: errors.New("invalid value: icmp, should be one of: tcp, udp: spec.protocol"),
+		},
+		"missing source App": {
+			space:     "web",
+			args:      []string{"frontend", "--destination-app", "backend"},
+			expectErr: errors.New(`failed to get source App: apps.kf.dev "frontend" not found`),
+		},
+		"missing destination App": {
+			space: "web",
+			args:  []string{"frontend", "--destination-app", "backend", "--destination-space", "api"},
+			setup: func(ctx context.Context) {
+				createApps(ctx, "web", "frontend")
+				createApps(ctx, "api", "frontend")
+			},
+			expectErr: errors.New(`failed to get destination App: apps.kf.dev "backend" not found`),
+		},
+		"same space": {
+			space: "web",
+			args:  []string{"frontend", "--destination-app", "backend", "--async"},
+			setup: func(ctx context.Context) {
+				createApps(ctx, "web", "frontend", "backend")
+			},
+			assert: func(ctx context.Context, t *testing.T) {
+				policies := listAppNetworkPolicies(ctx, t, "web")
+				testutil.AssertEqual(t, "count", 1, len(policies))
+				testutil.AssertEqual(t, "name", "web-frontend-to-backend-tcp-8080", policies[0].Name)
+				testutil.AssertEqual(t, "spec", v1alpha1.AppNetworkPolicySpec{
+					Source:         v1alpha1.AppNetworkPolicySource{Space: "web", App: "frontend"},
+					DestinationApp: "backend",
+					Protocol:       "tcp",
+					Ports:          "8080",
+				}, policies[0].Spec)
+			},
+		},
+		"other space": {
+			space: "web",
+			args: []string{
+				"frontend",
+				"--destination-app", "backend",
+				"--destination-space", "api",
+				"--protocol", "UDP",
+				"--port", "9000-9100",
+				"--async",
+			},
+			setup: func(ctx context.Context) {
+				createApps(ctx, "web", "frontend")
+				createApps(ctx, "api", "backend")
+			},
+			assert: func(ctx context.Context, t *testing.T) {
+				testutil.AssertEqual(t, "source space policies", 0, len(listAppNetworkPolicies(ctx, t, "web")))
+
+				policies := listAppNetworkPolicies(ctx, t, "api")
+				testutil.AssertEqual(t, "count", 1, len(policies))
+				testutil.AssertEqual(t, "spec", v1alpha1.AppNetworkPolicySpec{
+					Source:         v1alpha1.AppNetworkPolicySource{Space: "web", App: "frontend"},
+					DestinationApp: "backend",
+					Protocol:       "udp",
+					Ports:          "9000-9100",
+				}, policies[0].Spec)
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var buffer bytes.Buffer
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+			ctx = configlogging.SetupLogger(ctx, &buffer)
+
+			if tc.setup != nil {
+				tc.setup(ctx)
+			}
+
+			cmd := networkpolicies.NewAddCommand(&config.KfParams{Space: tc.space})
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tc.args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+			if tc.expectErr != nil {
+				testutil.AssertErrorsEqual(t, tc.expectErr, gotErr)
+				return
+			}
+			testutil.AssertNil(t, "err", gotErr)
+
+			if tc.assert != nil {
+				tc.assert(ctx, t)
+			}
+		})
+	}
+}
+
+func TestNewRemoveCommand(t *testing.T) {
+	t.Parallel()
+
+	existing := func(name, sourceSpace, ports string) *v1alpha1.AppNetworkPolicy {
+		policy := &v1alpha1.AppNetworkPolicy{}
+		policy.Name = name
+		policy.Namespace = "web"
+		policy.Spec = v1alpha1.AppNetworkPolicySpec{
+			Source:         v1alpha1.AppNetworkPolicySource{Space: sourceSpace, App: "frontend"},
+			DestinationApp: "backend",
+			Ports:          ports,
+		}
+		return policy
+	}
+
+	cases := map[string]struct {
+		args      []string
+		expectErr error
+		wantLeft  []string
+	}{
+		"removes matching policies": {
+			args:     []string{"frontend", "--destination-app", "backend"},
+			wantLeft: []string{"other-port"},
+		},
+		"no matching policy": {
+			args:      []string{"frontend", "--destination-app", "backend", "--port", "9000"},
+			expectErr: errors.New("no network policy allows web/frontend to reach web/backend on tcp port 9000"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var buffer bytes.Buffer
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+			ctx = configlogging.SetupLogger(ctx, &buffer)
+
+			for _, policy := range []*v1alpha1.AppNetworkPolicy{
+				existing("explicit-space", "web", "8080"),
+				existing("implicit-space", "", ""),
+				existing("other-port", "web", "8081"),
+			} {
+				fakeclient.Get(ctx).KfV1alpha1().AppNetworkPolicies("web").Create(ctx, policy, metav1.CreateOptions{})
+			}
+
+			cmd := networkpolicies.NewRemoveCommand(&config.KfParams{Space: "web"})
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tc.args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+			if tc.expectErr != nil {
+				testutil.AssertErrorsEqual(t, tc.expectErr, gotErr)
+				return
+			}
+			testutil.AssertNil(t, "err", gotErr)
+
+			var left []string
+			for _, policy := range listAppNetworkPolicies(ctx, t, "web") {
+				left = append(left, policy.Name)
+			}
+			testutil.AssertEqual(t, "remaining", tc.wantLeft, left)
+		})
+	}
+}
@@ -137,7 +137,9 @@ func NewRawKfCommand() *cobra.Command {
 				InjectStop(p),
 				InjectRestart(p),
 				InjectRestage(p),
+				InjectRollout(p),
 				InjectScale(p),
+				InjectCreateAppManifest(p),
 				InjectLogs(p),
 				InjectProxy(p),
 			},
@@ -150,6 +152,8 @@ func NewRawKfCommand() *cobra.Command {
 				InjectCreateAutoscalingRule(p),
 				InjectDeleteAutoscalingRules(p),
 				InjectUpdateAutoscalingLimits(p),
+				InjectCreateAutoscalingSchedule(p),
+				InjectAutoscalingSchedules(p),
 			},
 		},
 		{
@@ -178,6 +182,7 @@ func NewRawKfCommand() *cobra.Command {
 			Commands: []*cobra.Command{
 				InjectRoutes(p),
 				InjectCreateRoute(p),
+				InjectUpdateRoute(p),
 				InjectDeleteRoute(p),
 				InjectDeleteOrphanedRoutes(p),
 				InjectMapRoute(p),
@@ -190,6 +195,9 @@ func NewRawKfCommand() *cobra.Command {
 			Name: "Services",
 			Commands: []*cobra.Command{
 				InjectCreateService(p),
+				InjectUpdateService(p),
+				InjectShareService(p),
+				InjectUnshareService(p),
 				InjectCreateUserProvidedService(p),
 				InjectUpdateUserProvidedService(p),
 				InjectDeleteService(p),
@@ -207,11 +215,24 @@ func NewRawKfCommand() *cobra.Command {
 				InjectVcapServices(p),
 			},
 		},
+		{
+			Name: "Service Keys",
+			Commands: []*cobra.Command{
+				InjectCreateServiceKey(p),
+				InjectServiceKeys(p),
+				InjectServiceKey(p),
+				InjectDeleteServiceKey(p),
+			},
+		},
 		{
 			Name: "Service Brokers",
 			Commands: []*cobra.Command{
 				InjectCreateServiceBroker(p),
 				InjectDeleteServiceBroker(p),
+				InjectUpdateServiceBroker(p),
+				InjectServiceAccess(p),
+				InjectEnableServiceAccess(p),
+				InjectDisableServiceAccess(p),
 			},
 		},
 		{
@@ -226,6 +247,19 @@ func NewRawKfCommand() *cobra.Command {
 				InjectSpaceUsers(p),
 				InjectSetSpaceRole(p),
 				InjectUnsetSpaceRole(p),
+				InjectExportSpace(p),
+				InjectImportSpace(p),
+				InjectCreateSpaceQuota(p),
+				InjectSpaceQuotas(p),
+				InjectSetSpaceQuota(p),
+				InjectUnsetSpaceQuota(p),
+			},
+		},
+		{
+			Name: "Orgs",
+			Commands: []*cobra.Command{
+				InjectOrgs(p),
+				InjectCreateOrg(p),
 			},
 		},
 		{
@@ -248,6 +282,18 @@ func NewRawKfCommand() *cobra.Command {
 				InjectNetworkPolicies(p),
 				InjectDeleteNetworkPolicies(p),
 				InjectDescribeNetworkPolicy(p),
+				InjectAddNetworkPolicy(p),
+				InjectRemoveNetworkPolicy(p),
+				InjectAppNetworkPolicies(p),
+			},
+		},
+		{
+			Name: "Security Groups",
+			Commands: []*cobra.Command{
+				InjectSecurityGroups(p),
+				InjectCreateSecurityGroup(p),
+				InjectBindSecurityGroup(p),
+				InjectUnbindSecurityGroup(p),
 			},
 		},
 		{
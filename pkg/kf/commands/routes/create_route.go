@@ -16,6 +16,7 @@ package routes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -34,12 +35,15 @@ func NewCreateRouteCommand(
 	c routes.Client,
 ) *cobra.Command {
 	var (
-		routeFlags RouteFlags
-		async      utils.AsyncFlags
+		routeFlags  RouteFlags
+		policyFlags RoutePolicyFlags
+		tlsSecret   string
+		tlsIssuer   string
+		async       utils.AsyncFlags
 	)
 
 	cmd := &cobra.Command{
-		Use:   "create-route DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "create-route DOMAIN [--hostname HOSTNAME] [--path PATH] [--port PORT]",
 		Short: "Create a traffic routing rule for a host+path pair.",
 		Long: `
 		Creating a Route allows Apps to declare they want to receive traffic on
@@ -48,6 +52,23 @@ func NewCreateRouteCommand(
 		Routes without any bound Apps (or with only stopped Apps) will return a 404
 		HTTP status code.
 
+		TCP Routes are created by passing a port on a domain configured with the
+		tcp protocol. TCP Routes can't have a hostname or path, and each port on
+		a domain can only be reserved by a single Route in the cluster.
+
+		Routes can be restricted to requests with specific headers, query
+		parameters, or an HTTP method. They take precedence over the Route with
+		the same host and path that isn't restricted.
+
+		HTTPS for the Route's host can be served using a certificate from a
+		Secret in the Space with --tls-secret, or one issued automatically
+		with --tls-issuer. Routes without their own certificate use the
+		domain's certificate if it has one.
+
+		HTTP Routes can have a policy that sets request timeouts, retries,
+		CORS, a rate limit, and header rewrites. Policies can be changed
+		later with update-route.
+
 		Kf doesn't enforce Route uniqueness between Spaces. It's recommended
 		to provide each Space with its own subdomain instead.
 		`,
@@ -56,6 +77,10 @@ func NewCreateRouteCommand(
 		kf create-route --space myspace example.com --hostname myapp # myapp.example.com
 		kf create-route example.com --hostname myapp --path /mypath # myapp.example.com/mypath
 		kf create-route --space myspace myapp.example.com # myapp.example.com
+		kf create-route tcp.example.com --port 5432 # tcp.example.com:5432
+		kf create-route example.com --hostname myapp --tls-secret myapp-tls # https://myapp.example.com
+		kf create-route example.com --hostname myapp --header X-Canary=true # myapp.example.com with the header X-Canary: true
+		kf create-route example.com --hostname myapp --timeout 60s --retries 3 --rate-limit 100/minute
 
 		# Using SPACE to match 'cf'
 		kf create-route myspace example.com --hostname myapp # myapp.example.com
@@ -80,13 +105,13 @@ func NewCreateRouteCommand(
 				return fmt.Errorf("SPACE (argument=%q) and space (flag=%q) (if provided) must match", space, p.Space)
 			}
 
+			if tlsSecret != "" && tlsIssuer != "" {
+				return errors.New("--tls-secret and --tls-issuer can't both be set")
+			}
+
 			fields := routeFlags.RouteSpecFields(domain)
 
-			instanceName := v1alpha1.GenerateRouteName(
-				fields.Hostname,
-				fields.Domain,
-				fields.Path,
-			)
+			instanceName := v1alpha1.GenerateRouteNameFromFields(fields)
 
 			r := &v1alpha1.Route{
 				TypeMeta: metav1.TypeMeta{
@@ -101,6 +126,21 @@ func NewCreateRouteCommand(
 				},
 			}
 
+			if tlsSecret != "" || tlsIssuer != "" {
+				r.Spec.TLS = &v1alpha1.DomainTLS{
+					SecretName: tlsSecret,
+					IssuerName: tlsIssuer,
+				}
+			}
+
+			if policyFlags.Changed(cmd) {
+				policy, err := policyFlags.Apply(cmd, nil)
+				if err != nil {
+					return err
+				}
+				r.Spec.Policy = policy
+			}
+
 			if _, err := c.Create(ctx, space, r); err != nil {
 				return fmt.Errorf("failed to create Route: %s", err)
 			}
@@ -114,6 +154,21 @@ func NewCreateRouteCommand(
 	}
 	async.Add(cmd)
 	routeFlags.Add(cmd)
+	policyFlags.Add(cmd)
+
+	cmd.Flags().StringVar(
+		&tlsSecret,
+		"tls-secret",
+		"",
+		"Name of a kubernetes.io/tls Secret in the Space to serve HTTPS with.",
+	)
+
+	cmd.Flags().StringVar(
+		&tlsIssuer,
+		"tls-issuer",
+		"",
+		fmt.Sprintf("Name of a cert-manager ClusterIssuer to issue the HTTPS certificate, or %q.", v1alpha1.SelfSignedIssuerName),
+	)
 
 	return cmd
 }
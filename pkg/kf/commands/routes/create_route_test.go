@@ -16,8 +16,10 @@ package routes_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
@@ -145,6 +147,54 @@ func TestCreateRoute(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"creates route with tls secret": {
+			Args:  []string{"example.com", "--hostname=some-hostname", "--tls-secret=some-secret"},
+			Space: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().
+					Create(gomock.Any(), "some-space", gomock.Any()).
+					Do(func(_ context.Context, _ string, r *v1alpha1.Route) {
+						testutil.AssertEqual(t, "tls", &v1alpha1.DomainTLS{SecretName: "some-secret"}, r.Spec.TLS)
+					})
+				routesfake.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "some-space", gomock.Any(), gomock.Any())
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"creates route with policy": {
+			Args:  []string{"example.com", "--hostname=some-hostname", "--timeout=30s", "--retries=3", "--rate-limit=100/minute"},
+			Space: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().
+					Create(gomock.Any(), "some-space", gomock.Any()).
+					Do(func(_ context.Context, _ string, r *v1alpha1.Route) {
+						testutil.AssertEqual(t, "policy", &v1alpha1.RoutePolicy{
+							Timeout:   &metav1.Duration{Duration: 30 * time.Second},
+							Retries:   &v1alpha1.RouteRetryPolicy{Attempts: 3},
+							RateLimit: &v1alpha1.RouteRateLimit{Requests: 100, Unit: v1alpha1.RateLimitUnitMinute},
+						}, r.Spec.Policy)
+					})
+				routesfake.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "some-space", gomock.Any(), gomock.Any())
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"invalid rate limit": {
+			Args:  []string{"example.com", "--rate-limit=lots"},
+			Space: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New(`invalid rate limit "lots": must be in the form REQUESTS/UNIT`), err)
+			},
+		},
+		"tls secret and issuer both set": {
+			Args:  []string{"example.com", "--tls-secret=some-secret", "--tls-issuer=some-issuer"},
+			Space: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("--tls-secret and --tls-issuer can't both be set"), err)
+			},
+		},
 		"creates route path but with missing hostname": {
 			Args:  []string{"some-space", "example.com", "--path=somepath"},
 			Space: "some-space",
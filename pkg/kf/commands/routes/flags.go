@@ -22,10 +22,14 @@ import (
 	"knative.dev/pkg/ptr"
 )
 
-// RouteFlags includes commonly passed in flags to define a HTTP route.
+// RouteFlags includes commonly passed in flags to define a HTTP or TCP route.
 type RouteFlags struct {
-	Hostname string
-	Path     string
+	Hostname    string
+	Path        string
+	Port        int32
+	Headers     []string
+	QueryParams []string
+	Method      string
 }
 
 // Add appends the flags to the given command
@@ -43,17 +47,65 @@ func (flags *RouteFlags) Add(cmd *cobra.Command) {
 		"",
 		"URL path for the Route.",
 	)
+
+	cmd.Flags().Int32Var(
+		&flags.Port,
+		"port",
+		0,
+		"Port for a TCP Route, the domain must use the tcp protocol.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.Headers,
+		"header",
+		nil,
+		"Header in the form NAME=VALUE requests must have to match the Route. Can be repeated.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.QueryParams,
+		"query-param",
+		nil,
+		"Query parameter in the form NAME=VALUE requests must have to match the Route. Can be repeated.",
+	)
+
+	cmd.Flags().StringVar(
+		&flags.Method,
+		"method",
+		"",
+		"HTTP method requests must use to match the Route.",
+	)
+}
+
+// HasMatches returns true if the flags restrict the Route to requests with
+// specific headers, query parameters, or method.
+func (flags *RouteFlags) HasMatches() bool {
+	return len(flags.Headers) > 0 || len(flags.QueryParams) > 0 || flags.Method != ""
 }
 
 // RouteSpecFields converts the flags to a RouteSpecFields instance
 func (flags *RouteFlags) RouteSpecFields(domain string) v1alpha1.RouteSpecFields {
 	return v1alpha1.RouteSpecFields{
-		Hostname: flags.Hostname,
-		Domain:   domain,
-		Path:     path.Join("/", flags.Path),
+		Hostname:    flags.Hostname,
+		Domain:      domain,
+		Path:        path.Join("/", flags.Path),
+		Port:        flags.Port,
+		Headers:     parseRouteMatches(flags.Headers),
+		QueryParams: parseRouteMatches(flags.QueryParams),
+		Method:      flags.Method,
 	}
 }
 
+// parseRouteMatches parses NAME=VALUE flag values into RouteMatches.
+func parseRouteMatches(values []string) []v1alpha1.RouteMatch {
+	var out []v1alpha1.RouteMatch
+	for _, value := range values {
+		out = append(out, v1alpha1.ParseRouteMatch(value))
+	}
+
+	return out
+}
+
 // routeBindingFlags represents the keys for a route binding
 type routeBindingFlags struct {
 	RouteFlags
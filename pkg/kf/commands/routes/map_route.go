@@ -39,7 +39,7 @@ func NewMapRouteCommand(
 	)
 
 	cmd := &cobra.Command{
-		Use:   "map-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH] [--weight WEIGHT]",
+		Use:   "map-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH] [--port PORT] [--weight WEIGHT]",
 		Short: "Grant an App access to receive traffic from the Route.",
 		Long: `
 		Mapping an App to a Route will cause traffic to be forwarded to the App if
@@ -50,6 +50,12 @@ func NewMapRouteCommand(
 		gateways which update their routing tables with slight delays and route
 		independently. Because of this, traffic routing may not appear even but it
 		will converge over time.
+
+		Passing --header, --query-param, or --method maps the App to a Route that
+		only matches requests with those attributes. These Routes take
+		precedence over the Route with the same host and path without them,
+		which makes it possible to send a subset of traffic to a different App
+		for A/B testing.
 		`,
 		Example: `
 		kf map-route myapp example.com --hostname myapp # myapp.example.com
@@ -57,6 +63,8 @@ func NewMapRouteCommand(
 		kf map-route myapp example.com --hostname myapp --weight 2 # myapp.example.com, myapp receives 2x traffic
 		kf map-route --space myspace myapp example.com --hostname myapp # myapp.example.com
 		kf map-route myapp example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+		kf map-route myapp tcp.example.com --port 5432 # tcp.example.com:5432
+		kf map-route myapp-v2 example.com --hostname myapp --header X-Canary=true # myapp.example.com with the header X-Canary: true
 		`,
 		Args:              cobra.ExactArgs(2),
 		ValidArgsFunction: completion.AppCompletionFn(p),
@@ -92,6 +92,26 @@ func TestMapRoute(t *testing.T) {
 				appsfake.EXPECT().WaitForConditionRoutesReadyTrue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
 			},
 		},
+		"transform App by adding route with matches": {
+			Args:  []string{"some-app", "example.com", "--hostname=some-host", "--header=X-Canary=true", "--header=Accept=text/html,application/json", "--method=GET"},
+			Space: "some-space",
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, _, _ string, m apps.Mutator) {
+						oldApp := v1alpha1.App{}
+						testutil.AssertNil(t, "err", m(&oldApp))
+
+						testutil.AssertEqual(t, "Headers", []v1alpha1.RouteMatch{
+							{Name: "X-Canary", Value: "true"},
+							{Name: "Accept", Value: "text/html,application/json"},
+						}, oldApp.Spec.Routes[0].Headers)
+						testutil.AssertEqual(t, "QueryParams", 0, len(oldApp.Spec.Routes[0].QueryParams))
+						testutil.AssertEqual(t, "Method", "GET", oldApp.Spec.Routes[0].Method)
+					})
+				appsfake.EXPECT().WaitForConditionRoutesReadyTrue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
 		"transform App and keep old routes": {
 			Args:  []string{"some-app", "example.com", "--hostname=some-host", "--path=some-path"},
 			Space: "some-space",
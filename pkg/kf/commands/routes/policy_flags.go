@@ -0,0 +1,402 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RoutePolicyFlags includes flags to configure the policy of a HTTP route.
+// Only flags that are explicitly set modify the policy so they can be used to
+// update existing Routes.
+type RoutePolicyFlags struct {
+	timeout               time.Duration
+	retries               int32
+	retryPerTryTimeout    time.Duration
+	retryOn               string
+	corsAllowOrigins      []string
+	corsAllowMethods      []string
+	corsAllowHeaders      []string
+	corsExposeHeaders     []string
+	corsMaxAge            time.Duration
+	corsAllowCredentials  bool
+	rateLimit             string
+	setRequestHeaders     []string
+	removeRequestHeaders  []string
+	setResponseHeaders    []string
+	removeResponseHeaders []string
+}
+
+// Add appends the flags to the given command
+func (flags *RoutePolicyFlags) Add(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(
+		&flags.timeout,
+		"timeout",
+		0,
+		"Maximum time a request can take including retries e.g. 30s. Set to 0 to use the gateway's default.",
+	)
+
+	cmd.Flags().Int32Var(
+		&flags.retries,
+		"retries",
+		0,
+		"Number of times failed requests are retried. Set to 0 to use the gateway's default.",
+	)
+
+	cmd.Flags().DurationVar(
+		&flags.retryPerTryTimeout,
+		"retry-per-try-timeout",
+		0,
+		"Maximum time each attempt can take when retries are enabled.",
+	)
+
+	cmd.Flags().StringVar(
+		&flags.retryOn,
+		"retry-on",
+		"",
+		"Comma separated list of conditions to retry on e.g. 5xx,connect-failure.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.corsAllowOrigins,
+		"cors-allow-origin",
+		nil,
+		`Origin allowed to make cross-origin requests, or "*" for all origins. Can be repeated. Set to "" to disable CORS.`,
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.corsAllowMethods,
+		"cors-allow-method",
+		nil,
+		"Method allowed in cross-origin requests. Can be repeated.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.corsAllowHeaders,
+		"cors-allow-header",
+		nil,
+		"Header allowed in cross-origin requests. Can be repeated.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.corsExposeHeaders,
+		"cors-expose-header",
+		nil,
+		"Response header browsers can access in cross-origin requests. Can be repeated.",
+	)
+
+	cmd.Flags().DurationVar(
+		&flags.corsMaxAge,
+		"cors-max-age",
+		0,
+		"How long the results of a preflight request can be cached.",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.corsAllowCredentials,
+		"cors-allow-credentials",
+		false,
+		"Allow cross-origin requests to include credentials.",
+	)
+
+	cmd.Flags().StringVar(
+		&flags.rateLimit,
+		"rate-limit",
+		"",
+		`Requests each gateway instance accepts for the Route in the form REQUESTS/UNIT e.g. 100/minute, where UNIT is second, minute, or hour. Set to "" to remove the limit.`,
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.setRequestHeaders,
+		"set-request-header",
+		nil,
+		"Header in the form NAME=VALUE to set on requests before they're sent to the App. Can be repeated.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.removeRequestHeaders,
+		"remove-request-header",
+		nil,
+		"Header to remove from requests before they're sent to the App. Can be repeated.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.setResponseHeaders,
+		"set-response-header",
+		nil,
+		"Header in the form NAME=VALUE to set on responses before they're returned to the client. Can be repeated.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&flags.removeResponseHeaders,
+		"remove-response-header",
+		nil,
+		"Header to remove from responses before they're returned to the client. Can be repeated.",
+	)
+}
+
+// Changed returns true if any of the policy flags were set.
+func (flags *RoutePolicyFlags) Changed(cmd *cobra.Command) bool {
+	for _, name := range []string{
+		"timeout",
+		"retries",
+		"retry-per-try-timeout",
+		"retry-on",
+		"cors-allow-origin",
+		"cors-allow-method",
+		"cors-allow-header",
+		"cors-expose-header",
+		"cors-max-age",
+		"cors-allow-credentials",
+		"rate-limit",
+		"set-request-header",
+		"remove-request-header",
+		"set-response-header",
+		"remove-response-header",
+	} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply modifies the policy with the flags that were set and returns it. A
+// nil policy is returned if nothing is configured.
+func (flags *RoutePolicyFlags) Apply(cmd *cobra.Command, policy *v1alpha1.RoutePolicy) (*v1alpha1.RoutePolicy, error) {
+	changed := cmd.Flags().Changed
+
+	if policy == nil {
+		policy = &v1alpha1.RoutePolicy{}
+	}
+
+	if changed("timeout") {
+		policy.Timeout = optionalDuration(flags.timeout)
+	}
+
+	if changed("retries") {
+		if flags.retries == 0 {
+			policy.Retries = nil
+		} else {
+			if policy.Retries == nil {
+				policy.Retries = &v1alpha1.RouteRetryPolicy{}
+			}
+			policy.Retries.Attempts = flags.retries
+		}
+	}
+
+	if changed("retry-per-try-timeout") || changed("retry-on") {
+		if policy.Retries == nil {
+			return nil, errors.New("--retry-per-try-timeout and --retry-on require --retries")
+		}
+
+		if changed("retry-per-try-timeout") {
+			policy.Retries.PerTryTimeout = optionalDuration(flags.retryPerTryTimeout)
+		}
+
+		if changed("retry-on") {
+			policy.Retries.RetryOn = flags.retryOn
+		}
+	}
+
+	if err := flags.applyCORS(cmd, policy); err != nil {
+		return nil, err
+	}
+
+	if changed("rate-limit") {
+		rateLimit, err := parseRateLimit(flags.rateLimit)
+		if err != nil {
+			return nil, err
+		}
+		policy.RateLimit = rateLimit
+	}
+
+	var err error
+	if policy.RequestHeaders, err = applyHeaderOperations(
+		policy.RequestHeaders,
+		flags.setRequestHeaders,
+		flags.removeRequestHeaders,
+	); err != nil {
+		return nil, err
+	}
+
+	if policy.ResponseHeaders, err = applyHeaderOperations(
+		policy.ResponseHeaders,
+		flags.setResponseHeaders,
+		flags.removeResponseHeaders,
+	); err != nil {
+		return nil, err
+	}
+
+	if *policy == (v1alpha1.RoutePolicy{}) {
+		return nil, nil
+	}
+
+	return policy, nil
+}
+
+func (flags *RoutePolicyFlags) applyCORS(cmd *cobra.Command, policy *v1alpha1.RoutePolicy) error {
+	changed := cmd.Flags().Changed
+
+	if changed("cors-allow-origin") {
+		origins := removeEmpty(flags.corsAllowOrigins)
+		if len(origins) == 0 {
+			policy.CORS = nil
+		} else {
+			if policy.CORS == nil {
+				policy.CORS = &v1alpha1.RouteCORSPolicy{}
+			}
+			policy.CORS.AllowOrigins = origins
+		}
+	}
+
+	cors := policy.CORS
+	if cors == nil {
+		for _, name := range []string{
+			"cors-allow-method",
+			"cors-allow-header",
+			"cors-expose-header",
+			"cors-max-age",
+			"cors-allow-credentials",
+		} {
+			if changed(name) {
+				return fmt.Errorf("--%s requires --cors-allow-origin", name)
+			}
+		}
+
+		return nil
+	}
+
+	if changed("cors-allow-method") {
+		cors.AllowMethods = removeEmpty(flags.corsAllowMethods)
+	}
+
+	if changed("cors-allow-header") {
+		cors.AllowHeaders = removeEmpty(flags.corsAllowHeaders)
+	}
+
+	if changed("cors-expose-header") {
+		cors.ExposeHeaders = removeEmpty(flags.corsExposeHeaders)
+	}
+
+	if changed("cors-max-age") {
+		cors.MaxAge = optionalDuration(flags.corsMaxAge)
+	}
+
+	if changed("cors-allow-credentials") {
+		cors.AllowCredentials = flags.corsAllowCredentials
+	}
+
+	return nil
+}
+
+// applyHeaderOperations adds the headers to set and remove to the
+// operations. Setting a header stops it from being removed and vice versa.
+func applyHeaderOperations(ops *v1alpha1.RouteHeaderOperations, set, remove []string) (*v1alpha1.RouteHeaderOperations, error) {
+	if len(set) == 0 && len(remove) == 0 {
+		return ops, nil
+	}
+
+	if ops == nil {
+		ops = &v1alpha1.RouteHeaderOperations{}
+	}
+
+	for _, header := range set {
+		parts := strings.SplitN(header, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid header %q: must be in the form NAME=VALUE", header)
+		}
+
+		if ops.Set == nil {
+			ops.Set = make(map[string]string)
+		}
+		ops.Set[parts[0]] = parts[1]
+		ops.Remove = removeString(ops.Remove, parts[0])
+	}
+
+	for _, name := range remove {
+		delete(ops.Set, name)
+		delete(ops.Add, name)
+		ops.Remove = append(removeString(ops.Remove, name), name)
+	}
+
+	if len(ops.Set) == 0 {
+		ops.Set = nil
+	}
+
+	return ops, nil
+}
+
+// parseRateLimit parses a rate limit in the form REQUESTS/UNIT. An empty
+// string removes the rate limit.
+func parseRateLimit(rateLimit string) (*v1alpha1.RouteRateLimit, error) {
+	if rateLimit == "" {
+		return nil, nil
+	}
+
+	parts := strings.SplitN(rateLimit, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid rate limit %q: must be in the form REQUESTS/UNIT", rateLimit)
+	}
+
+	requests, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit %q: requests must be an integer", rateLimit)
+	}
+
+	return &v1alpha1.RouteRateLimit{
+		Requests: int32(requests),
+		Unit:     strings.TrimSuffix(strings.ToLower(parts[1]), "s"),
+	}, nil
+}
+
+func optionalDuration(d time.Duration) *metav1.Duration {
+	if d == 0 {
+		return nil
+	}
+
+	return &metav1.Duration{Duration: d}
+}
+
+func removeEmpty(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+func removeString(values []string, value string) []string {
+	var out []string
+	for _, v := range values {
+		if v != value {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
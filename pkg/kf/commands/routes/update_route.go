@@ -0,0 +1,125 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/routes"
+	"github.com/spf13/cobra"
+	"knative.dev/pkg/logging"
+)
+
+// NewUpdateRouteCommand creates an UpdateRoute command.
+func NewUpdateRouteCommand(
+	p *config.KfParams,
+	c routes.Client,
+) *cobra.Command {
+	var (
+		routeFlags  RouteFlags
+		policyFlags RoutePolicyFlags
+		clearPolicy bool
+		async       utils.AsyncFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update-route DOMAIN [--hostname HOSTNAME] [--path PATH] [POLICY FLAGS]",
+		Short: "Update the policy of a Route in the targeted Space.",
+		Long: `
+		Updates the policy the gateway uses to handle requests for a Route.
+		Only the settings passed as flags are changed, the rest of the policy
+		is kept.
+
+		Timeouts, retries, and CORS default to the gateway's behavior when
+		unset. Setting --timeout or --retries to 0 or --rate-limit or
+		--cors-allow-origin to an empty string removes them from the policy.
+		Use --clear-policy to remove the whole policy.
+
+		Rate limits are enforced by each gateway instance separately.
+		`,
+		Example: `
+		# Give slow requests to myapp.example.com a minute to complete
+		kf update-route example.com --hostname myapp --timeout 60s
+
+		# Retry failed requests up to 3 times
+		kf update-route example.com --hostname myapp --retries 3 --retry-on 5xx,connect-failure
+
+		# Allow requests from another origin
+		kf update-route example.com --hostname myapp --cors-allow-origin https://www.example.com
+
+		# Limit requests to 100 per minute
+		kf update-route example.com --hostname myapp --rate-limit 100/minute
+
+		# Remove the policy
+		kf update-route example.com --hostname myapp --clear-policy
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			if !clearPolicy && !policyFlags.Changed(cmd) {
+				return errors.New("at least one policy flag or --clear-policy must be set")
+			}
+
+			fields := routeFlags.RouteSpecFields(args[0])
+			instanceName := v1alpha1.GenerateRouteNameFromFields(fields)
+
+			if _, err := c.Transform(ctx, p.Space, instanceName, func(r *v1alpha1.Route) error {
+				if clearPolicy {
+					r.Spec.Policy = nil
+				}
+
+				policy, err := policyFlags.Apply(cmd, r.Spec.Policy)
+				if err != nil {
+					return err
+				}
+
+				r.Spec.Policy = policy
+				return nil
+			}); err != nil {
+				return fmt.Errorf("failed to update Route: %s", err)
+			}
+
+			logging.FromContext(ctx).Infof("Updating Route %q in space %q", instanceName, p.Space)
+			return async.AwaitAndLog(cmd.ErrOrStderr(), "Waiting for Route to become ready", func() (err error) {
+				_, err = c.WaitForConditionReadyTrue(context.Background(), p.Space, instanceName, 1*time.Second)
+				return
+			})
+		},
+	}
+	async.Add(cmd)
+	routeFlags.Add(cmd)
+	policyFlags.Add(cmd)
+
+	cmd.Flags().BoolVar(
+		&clearPolicy,
+		"clear-policy",
+		false,
+		"Remove the existing policy before applying the other flags.",
+	)
+
+	return cmd
+}
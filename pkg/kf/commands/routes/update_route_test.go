@@ -0,0 +1,168 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/commands/routes"
+	kfroutes "github.com/google/kf/v2/pkg/kf/routes"
+	routesfake "github.com/google/kf/v2/pkg/kf/routes/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdateRoute(t *testing.T) {
+	t.Parallel()
+
+	routeName := v1alpha1.GenerateRouteName("some-hostname", "example.com", "/")
+
+	existingPolicy := func() *v1alpha1.RoutePolicy {
+		return &v1alpha1.RoutePolicy{
+			Timeout: &metav1.Duration{Duration: 30 * time.Second},
+			Retries: &v1alpha1.RouteRetryPolicy{Attempts: 3, RetryOn: "5xx"},
+			RequestHeaders: &v1alpha1.RouteHeaderOperations{
+				Set: map[string]string{"x-team": "payments"},
+			},
+		}
+	}
+
+	for tn, tc := range map[string]struct {
+		Space          string
+		Args           []string
+		ExistingPolicy *v1alpha1.RoutePolicy
+		TransformErr   error
+		WantPolicy     *v1alpha1.RoutePolicy
+		WantErr        error
+	}{
+		"without space": {
+			Args:    []string{"example.com", "--timeout=1m"},
+			WantErr: errors.New(config.EmptySpaceError),
+		},
+		"no policy flags": {
+			Space:   "some-space",
+			Args:    []string{"example.com", "--hostname=some-hostname"},
+			WantErr: errors.New("at least one policy flag or --clear-policy must be set"),
+		},
+		"only changes set flags": {
+			Space:          "some-space",
+			Args:           []string{"example.com", "--hostname=some-hostname", "--timeout=1m", "--remove-request-header=x-team"},
+			ExistingPolicy: existingPolicy(),
+			WantPolicy: &v1alpha1.RoutePolicy{
+				Timeout: &metav1.Duration{Duration: time.Minute},
+				Retries: &v1alpha1.RouteRetryPolicy{Attempts: 3, RetryOn: "5xx"},
+				RequestHeaders: &v1alpha1.RouteHeaderOperations{
+					Remove: []string{"x-team"},
+				},
+			},
+		},
+		"zero values remove settings": {
+			Space:          "some-space",
+			Args:           []string{"example.com", "--hostname=some-hostname", "--timeout=0", "--retries=0"},
+			ExistingPolicy: existingPolicy(),
+			WantPolicy: &v1alpha1.RoutePolicy{
+				RequestHeaders: &v1alpha1.RouteHeaderOperations{
+					Set: map[string]string{"x-team": "payments"},
+				},
+			},
+		},
+		"clear policy": {
+			Space:          "some-space",
+			Args:           []string{"example.com", "--hostname=some-hostname", "--clear-policy"},
+			ExistingPolicy: existingPolicy(),
+		},
+		"cors": {
+			Space: "some-space",
+			Args: []string{
+				"example.com",
+				"--hostname=some-hostname",
+				"--cors-allow-origin=https://example.com",
+				"--cors-allow-method=GET",
+				"--cors-max-age=1h",
+				"--cors-allow-credentials",
+			},
+			WantPolicy: &v1alpha1.RoutePolicy{
+				CORS: &v1alpha1.RouteCORSPolicy{
+					AllowOrigins:     []string{"https://example.com"},
+					AllowMethods:     []string{"GET"},
+					MaxAge:           &metav1.Duration{Duration: time.Hour},
+					AllowCredentials: true,
+				},
+			},
+		},
+		"cors without origin": {
+			Space:   "some-space",
+			Args:    []string{"example.com", "--hostname=some-hostname", "--cors-allow-method=GET"},
+			WantErr: errors.New("failed to update Route: --cors-allow-method requires --cors-allow-origin"),
+		},
+		"retry settings without retries": {
+			Space:   "some-space",
+			Args:    []string{"example.com", "--hostname=some-hostname", "--retry-on=5xx"},
+			WantErr: errors.New("failed to update Route: --retry-per-try-timeout and --retry-on require --retries"),
+		},
+		"transform fails": {
+			Space:        "some-space",
+			Args:         []string{"example.com", "--hostname=some-hostname", "--timeout=1m"},
+			TransformErr: errors.New("some-error"),
+			WantErr:      errors.New("failed to update Route: some-error"),
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeRoutes := routesfake.NewFakeClient(ctrl)
+
+			fakeRoutes.EXPECT().
+				Transform(gomock.Any(), "some-space", routeName, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ string, m kfroutes.Mutator) (*v1alpha1.Route, error) {
+					if tc.TransformErr != nil {
+						return nil, tc.TransformErr
+					}
+
+					route := &v1alpha1.Route{}
+					route.Spec.Policy = tc.ExistingPolicy
+					if err := m(route); err != nil {
+						return nil, err
+					}
+
+					testutil.AssertEqual(t, "policy", tc.WantPolicy, route.Spec.Policy)
+					return route, nil
+				}).
+				AnyTimes()
+
+			fakeRoutes.EXPECT().
+				WaitForConditionReadyTrue(gomock.Any(), "some-space", routeName, gomock.Any()).
+				AnyTimes()
+
+			var buffer bytes.Buffer
+			cmd := routes.NewUpdateRouteCommand(
+				&config.KfParams{
+					Space: tc.Space,
+				},
+				fakeRoutes,
+			)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			testutil.AssertErrorsEqual(t, tc.WantErr, cmd.Execute())
+		})
+	}
+}
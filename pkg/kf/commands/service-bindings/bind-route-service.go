@@ -56,6 +56,14 @@ func NewBindRouteServiceCommand(p *config.KfParams, client serviceinstancebindin
 				return errors.New(`Route services feature is toggled off. Set "enable_route_services" to true in "config-defaults" to enable route services`)
 			}
 
+			if routeFlags.Port != 0 {
+				return errors.New("Route services can't be bound to TCP Routes")
+			}
+
+			if routeFlags.HasMatches() {
+				return errors.New("Route services can't be bound to Routes with headers, query parameters, or a method")
+			}
+
 			domain := args[0]
 			instanceName := args[1]
 			bindingName := v1alpha1.MakeRouteServiceBindingName(routeFlags.Hostname, domain, routeFlags.Path, instanceName)
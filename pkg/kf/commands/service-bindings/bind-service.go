@@ -39,17 +39,21 @@ func NewBindServiceCommand(p *config.KfParams, client serviceinstancebindings.Cl
 	var (
 		bindingOverride string
 		configAsJSON    string
+		instanceSpace   string
 		async           utils.AsyncFlags
 		timeout         time.Duration
 	)
 
 	createCmd := &cobra.Command{
-		Use:     "bind-service APP_NAME SERVICE_INSTANCE [-c PARAMETERS_AS_JSON] [--binding-name BINDING_NAME]",
+		Use:     "bind-service APP_NAME SERVICE_INSTANCE [-c PARAMETERS_AS_JSON] [--binding-name BINDING_NAME] [--instance-space SPACE]",
 		Aliases: []string{"bs"},
 		Short:   "Grant an App access to a service instance.",
 		Long: `
 		Binding a service injects information about the service into the App via the
 		VCAP_SERVICES environment variable.
+
+		Service instances shared from another Space can be bound by setting
+		--instance-space to the Space the instance is in.
 		`,
 		Example:           `  kf bind-service myapp mydb -c '{"permissions":"read-only"}'`,
 		Args:              cobra.ExactArgs(2),
@@ -77,6 +81,12 @@ func NewBindServiceCommand(p *config.KfParams, client serviceinstancebindings.Cl
 
 			paramsSecretName := v1alpha1.MakeServiceBindingParamsSecretName(appName, instanceName)
 
+			// Only record the instance's Space if it's different from the
+			// App's so bindings in the same Space don't change.
+			if instanceSpace == p.Space {
+				instanceSpace = ""
+			}
+
 			desiredBinding := &v1alpha1.ServiceInstanceBinding{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      bindingName,
@@ -94,6 +104,7 @@ func NewBindServiceCommand(p *config.KfParams, client serviceinstancebindings.Cl
 					InstanceRef: v1.LocalObjectReference{
 						Name: instanceName,
 					},
+					InstanceSpace: instanceSpace,
 					ParametersFrom: v1.LocalObjectReference{
 						Name: paramsSecretName,
 					},
@@ -151,6 +162,12 @@ func NewBindServiceCommand(p *config.KfParams, client serviceinstancebindings.Cl
 		"",
 		"Name of the binding injected into the app, defaults to the service instance name.")
 
+	createCmd.Flags().StringVar(
+		&instanceSpace,
+		"instance-space",
+		"",
+		"Space the service instance is in if it's shared from another Space.")
+
 	createCmd.Flags().DurationVar(
 		&timeout,
 		"timeout",
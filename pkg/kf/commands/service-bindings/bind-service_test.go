@@ -143,6 +143,39 @@ func TestNewBindServiceCommand(t *testing.T) {
 			},
 			ExpectedStrings: []string{"Success", "kf restart"},
 		},
+		"shared service instance": {
+			Args:  []string{"APP_NAME", "SERVICE_INSTANCE", "--instance-space=other-ns"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				bindingName := v1alpha1.MakeServiceBindingName("APP_NAME", "SERVICE_INSTANCE")
+				secretName := v1alpha1.MakeServiceBindingParamsSecretName("APP_NAME", "SERVICE_INSTANCE")
+				fakes.servicebindings.EXPECT().Create(gomock.Any(), "custom-ns", &v1alpha1.ServiceInstanceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            bindingName,
+						Namespace:       "custom-ns",
+						OwnerReferences: ownerRefs,
+					},
+					Spec: v1alpha1.ServiceInstanceBindingSpec{
+						BindingType: v1alpha1.BindingType{
+							App: &v1alpha1.AppRef{
+								Name: "APP_NAME",
+							},
+						},
+						InstanceRef: v1.LocalObjectReference{
+							Name: "SERVICE_INSTANCE",
+						},
+						InstanceSpace: "other-ns",
+						ParametersFrom: v1.LocalObjectReference{
+							Name: secretName,
+						},
+						ProgressDeadlineSeconds: v1alpha1.DefaultServiceInstanceBindingProgressDeadlineSeconds,
+					},
+				})
+				fakes.secrets.EXPECT().CreateParamsSecret(gomock.Any(), gomock.Any(), secretName, json.RawMessage("{}"))
+				fakes.servicebindings.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "custom-ns", bindingName, gomock.Any())
+				fakes.apps.EXPECT().Get(gomock.Any(), "custom-ns", "APP_NAME").Return(sampleApp, nil)
+			},
+		},
 		"empty namespace": {
 			Args:        []string{"APP_NAME", "SERVICE_INSTANCE", `-c={"ram_gb":4}`, "--binding-name=BINDING_NAME"},
 			ExpectedErr: errors.New(config.EmptySpaceError),
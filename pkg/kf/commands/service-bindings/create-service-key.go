@@ -0,0 +1,144 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebindings
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/describe"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/secrets"
+	"github.com/google/kf/v2/pkg/kf/serviceinstancebindings"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// NewCreateServiceKeyCommand allows users to create credentials for a service
+// instance without binding it to an App.
+func NewCreateServiceKeyCommand(p *config.KfParams, client serviceinstancebindings.Client, secretsClient secrets.Client) *cobra.Command {
+	var (
+		configAsJSON string
+		async        utils.AsyncFlags
+		timeout      time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:     "create-service-key SERVICE_INSTANCE SERVICE_KEY [-c PARAMETERS_AS_JSON]",
+		Aliases: []string{"csk"},
+		Short:   "Create a credential for a service instance that isn't bound to an App.",
+		Long: `
+		Service keys bind a service instance without an App, the credentials the
+		broker returns are stored in a Secret in the Space. Use service keys to
+		give CI jobs or operators access to a service.
+		`,
+		Example:      `  kf create-service-key mydb ci-key -c '{"permissions":"read-only"}'`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			instanceName := args[0]
+			keyName := args[1]
+			bindingName := v1alpha1.MakeServiceKeyBindingName(instanceName, keyName)
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			paramBytes, err := utils.ParseJSONOrFile(configAsJSON)
+			if err != nil {
+				return err
+			}
+
+			paramsSecretName := v1alpha1.MakeServiceKeyParamsSecretName(instanceName, keyName)
+
+			desiredBinding := &v1alpha1.ServiceInstanceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      bindingName,
+					Namespace: p.Space,
+				},
+				Spec: v1alpha1.ServiceInstanceBindingSpec{
+					BindingType: v1alpha1.BindingType{
+						ServiceKey: &v1alpha1.ServiceKeyRef{
+							Name: keyName,
+						},
+					},
+					InstanceRef: v1.LocalObjectReference{
+						Name: instanceName,
+					},
+					ParametersFrom: v1.LocalObjectReference{
+						Name: paramsSecretName,
+					},
+					ProgressDeadlineSeconds: int64(timeout / time.Second),
+				},
+			}
+
+			logger := logging.FromContext(ctx)
+			logger.Infof("Creating service key %q for service instance %q in Space %q", keyName, instanceName, p.Space)
+			describe.SectionWriter(cmd.ErrOrStderr(), "ServiceInstanceBinding Parameters", func(w io.Writer) {
+				if err := describe.UnstructuredStruct(w, desiredBinding.Spec); err != nil {
+					fmt.Fprintln(w, err.Error())
+				}
+			})
+
+			actualBinding, err := client.Create(ctx, p.Space, desiredBinding)
+			if err != nil {
+				return err
+			}
+
+			logger.Infof("Creating parameters Secret %q in Space %q", paramsSecretName, p.Space)
+			if _, err := secretsClient.CreateParamsSecret(ctx, actualBinding, paramsSecretName, paramBytes); err != nil {
+				return err
+			}
+
+			return async.AwaitAndLog(cmd.ErrOrStderr(), "Waiting for service key to become ready", func() (err error) {
+				_, err = client.WaitForConditionReadyTrue(ctx, p.Space, bindingName, 1*time.Second)
+				if err != nil {
+					return fmt.Errorf("create service key failed: %s", err)
+				}
+				utils.SuggestNextAction(utils.NextAction{
+					Description: "Show the service key credentials",
+					Commands: []string{
+						fmt.Sprintf("kf service-key %s %s", instanceName, keyName),
+					},
+				})
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&configAsJSON,
+		"parameters",
+		"c",
+		"{}",
+		"JSON object or path to a JSON file containing configuration parameters.")
+
+	cmd.Flags().DurationVar(
+		&timeout,
+		"timeout",
+		time.Duration(v1alpha1.DefaultServiceInstanceBindingProgressDeadlineSeconds)*time.Second,
+		`Amount of time to wait for the operation to complete. Valid units are "s", "m", "h".`,
+	)
+
+	async.Add(cmd)
+
+	return cmd
+}
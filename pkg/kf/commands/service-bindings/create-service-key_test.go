@@ -0,0 +1,148 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebindings_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	configlogging "github.com/google/kf/v2/pkg/kf/commands/config/logging"
+	servicebindingscmd "github.com/google/kf/v2/pkg/kf/commands/service-bindings"
+	secretsfake "github.com/google/kf/v2/pkg/kf/secrets/fake"
+	serviceinstancebindingsfake "github.com/google/kf/v2/pkg/kf/serviceinstancebindings/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func runCreateServiceKeyTest(t *testing.T, tc bindingTest) {
+	ctrl := gomock.NewController(t)
+
+	sbClient := serviceinstancebindingsfake.NewFakeClient(ctrl)
+	secretClient := secretsfake.NewFakeClient(ctrl)
+
+	if tc.Setup != nil {
+		tc.Setup(t, fakes{
+			servicebindings: sbClient,
+			secrets:         secretClient,
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	p := &config.KfParams{
+		Space: tc.Space,
+	}
+	ctx := configlogging.SetupLogger(context.Background(), buf)
+
+	cmd := servicebindingscmd.NewCreateServiceKeyCommand(p, sbClient, secretClient)
+	cmd.SetOutput(buf)
+	cmd.SetArgs(tc.Args)
+	cmd.SetContext(ctx)
+	_, actualErr := cmd.ExecuteC()
+	if tc.ExpectedErr != nil || actualErr != nil {
+		testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+		return
+	}
+
+	testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+}
+
+func TestNewCreateServiceKeyCommand(t *testing.T) {
+	cases := map[string]bindingTest{
+		"wrong number of args": {
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 2 arg(s), received 0"),
+		},
+		"command params get passed correctly": {
+			Args:  []string{"SERVICE_INSTANCE", "KEY_NAME", `-c={"role":"admin"}`, "--timeout=30s"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				bindingName := v1alpha1.MakeServiceKeyBindingName("SERVICE_INSTANCE", "KEY_NAME")
+				secretName := v1alpha1.MakeServiceKeyParamsSecretName("SERVICE_INSTANCE", "KEY_NAME")
+				fakes.servicebindings.EXPECT().Create(gomock.Any(), "custom-ns", &v1alpha1.ServiceInstanceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      bindingName,
+						Namespace: "custom-ns",
+					},
+					Spec: v1alpha1.ServiceInstanceBindingSpec{
+						BindingType: v1alpha1.BindingType{
+							ServiceKey: &v1alpha1.ServiceKeyRef{
+								Name: "KEY_NAME",
+							},
+						},
+						InstanceRef: v1.LocalObjectReference{
+							Name: "SERVICE_INSTANCE",
+						},
+						ParametersFrom: v1.LocalObjectReference{
+							Name: secretName,
+						},
+						ProgressDeadlineSeconds: 30,
+					},
+				})
+
+				fakes.secrets.EXPECT().CreateParamsSecret(gomock.Any(), gomock.Any(), secretName, json.RawMessage(`{"role":"admin"}`))
+				fakes.servicebindings.EXPECT().WaitForConditionReadyTrue(gomock.Any(), "custom-ns", bindingName, gomock.Any())
+			},
+			ExpectedStrings: []string{"Success"},
+		},
+		"empty namespace": {
+			Args:        []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			ExpectedErr: errors.New(config.EmptySpaceError),
+		},
+		"bad config path": {
+			Args:        []string{"SERVICE_INSTANCE", "KEY_NAME", `-c=/some/bad/path`},
+			Space:       "custom-ns",
+			ExpectedErr: errors.New("couldn't read file: open /some/bad/path: no such file or directory"),
+		},
+		"bad server call": {
+			Args:  []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.servicebindings.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("api-error"))
+			},
+			ExpectedErr: errors.New("api-error"),
+		},
+		"async": {
+			Args:  []string{"--async", "SERVICE_INSTANCE", "KEY_NAME"},
+			Space: "default",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.secrets.EXPECT().CreateParamsSecret(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				fakes.servicebindings.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		"failed binding": {
+			Args:  []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.secrets.EXPECT().CreateParamsSecret(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				fakes.servicebindings.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any())
+				fakes.servicebindings.EXPECT().WaitForConditionReadyTrue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("broker error"))
+			},
+			ExpectedErr: errors.New("create service key failed: broker error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runCreateServiceKeyTest(t, tc)
+		})
+	}
+}
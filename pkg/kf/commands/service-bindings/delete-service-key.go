@@ -0,0 +1,83 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebindings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/serviceinstancebindings"
+	"github.com/spf13/cobra"
+)
+
+// NewDeleteServiceKeyCommand allows users to delete service keys.
+func NewDeleteServiceKeyCommand(p *config.KfParams, client serviceinstancebindings.Client) *cobra.Command {
+	var async utils.AsyncFlags
+
+	cmd := &cobra.Command{
+		Use:     "delete-service-key SERVICE_INSTANCE SERVICE_KEY",
+		Aliases: []string{"dsk"},
+		Short:   "Delete a service key and revoke its credentials.",
+		Long: `Delete a service key.
+
+		This will delete the credential from the service broker that created the
+		instance and remove the Secret holding the credentials.
+		`,
+		Example:      `kf delete-service-key mydb ci-key`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			instanceName := args[0]
+			keyName := args[1]
+			bindingName := v1alpha1.MakeServiceKeyBindingName(instanceName, keyName)
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			mutator := func(b *v1alpha1.ServiceInstanceBinding) error {
+				b.Spec.UnbindRequests++
+
+				return nil
+			}
+
+			if _, err := client.Transform(ctx, p.Space, bindingName, mutator); err != nil {
+				return fmt.Errorf("Failed to update unbinding requests: %s", err)
+			}
+
+			if err := client.Delete(ctx, p.Space, bindingName); err != nil {
+				return err
+			}
+
+			action := fmt.Sprintf("Deleting service key %q in Space %q", keyName, p.Space)
+			return async.AwaitAndLog(cmd.OutOrStdout(), action, func() error {
+				_, err := client.WaitForDeletion(context.Background(), p.Space, bindingName, 1*time.Second)
+				if err != nil {
+					return fmt.Errorf("delete service key failed: %s", err)
+				}
+				return nil
+			})
+		},
+	}
+
+	async.Add(cmd)
+
+	return cmd
+}
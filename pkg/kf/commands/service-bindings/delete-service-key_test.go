@@ -0,0 +1,112 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebindings_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	servicebindingscmd "github.com/google/kf/v2/pkg/kf/commands/service-bindings"
+	"github.com/google/kf/v2/pkg/kf/serviceinstancebindings"
+	serviceinstancebindingsfake "github.com/google/kf/v2/pkg/kf/serviceinstancebindings/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func runDeleteServiceKeyTest(t *testing.T, tc bindingTest) {
+	ctrl := gomock.NewController(t)
+
+	sbClient := serviceinstancebindingsfake.NewFakeClient(ctrl)
+
+	if tc.Setup != nil {
+		tc.Setup(t, fakes{
+			servicebindings: sbClient,
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	p := &config.KfParams{
+		Space: tc.Space,
+	}
+
+	cmd := servicebindingscmd.NewDeleteServiceKeyCommand(p, sbClient)
+	cmd.SetOutput(buf)
+	cmd.SetArgs(tc.Args)
+	_, actualErr := cmd.ExecuteC()
+	if tc.ExpectedErr != nil || actualErr != nil {
+		testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+		return
+	}
+
+	testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+}
+
+func TestNewDeleteServiceKeyCommand(t *testing.T) {
+	bindingName := v1alpha1.MakeServiceKeyBindingName("SERVICE_INSTANCE", "KEY_NAME")
+
+	cases := map[string]bindingTest{
+		"wrong number of args": {
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 2 arg(s), received 0"),
+		},
+		"command params get passed correctly": {
+			Args:  []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				binding := &v1alpha1.ServiceInstanceBinding{}
+				fakes.servicebindings.EXPECT().
+					Transform(gomock.Any(), "custom-ns", bindingName, gomock.Any()).
+					Do(func(_ context.Context, _, _ string, m serviceinstancebindings.Mutator) {
+						testutil.AssertNil(t, "mutator error", m(binding))
+						testutil.AssertEqual(t, "binding.spec.UnbindRequests", 1, binding.Spec.UnbindRequests)
+					})
+				fakes.servicebindings.EXPECT().Delete(gomock.Any(), "custom-ns", bindingName)
+				fakes.servicebindings.EXPECT().WaitForDeletion(gomock.Any(), "custom-ns", bindingName, gomock.Any())
+			},
+			ExpectedStrings: []string{"Success"},
+		},
+		"empty namespace": {
+			Args:        []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			ExpectedErr: errors.New(config.EmptySpaceError),
+		},
+		"bad server call": {
+			Args:  []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.servicebindings.EXPECT().Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				fakes.servicebindings.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("api-error"))
+			},
+			ExpectedErr: errors.New("api-error"),
+		},
+		"async": {
+			Args:  []string{"--async", "SERVICE_INSTANCE", "KEY_NAME"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.servicebindings.EXPECT().Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				fakes.servicebindings.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runDeleteServiceKeyTest(t, tc)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebindings
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/cfutil"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/secrets"
+	"github.com/google/kf/v2/pkg/kf/serviceinstancebindings"
+	"github.com/spf13/cobra"
+)
+
+// NewServiceKeyCommand allows users to show the credentials in a service key.
+func NewServiceKeyCommand(p *config.KfParams, client serviceinstancebindings.Client, secretsClient secrets.Client) *cobra.Command {
+	var guid bool
+
+	cmd := &cobra.Command{
+		Use:   "service-key SERVICE_INSTANCE SERVICE_KEY [--guid]",
+		Short: "Show the credentials in a service key.",
+		Example: `
+		# Show the credentials in the key
+		kf service-key mydb ci-key
+
+		# Show the unique ID of the key
+		kf service-key mydb ci-key --guid
+		`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			instanceName := args[0]
+			keyName := args[1]
+			bindingName := v1alpha1.MakeServiceKeyBindingName(instanceName, keyName)
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			binding, err := client.Get(ctx, p.Space, bindingName)
+			if err != nil {
+				return err
+			}
+
+			if guid {
+				fmt.Fprintln(cmd.OutOrStdout(), binding.UID)
+				return nil
+			}
+
+			secretName := binding.Status.CredentialsSecretRef.Name
+			if secretName == "" {
+				return fmt.Errorf("service key %q doesn't have credentials yet, check its status with 'kf service-keys %s'", keyName, instanceName)
+			}
+
+			secret, err := secretsClient.Get(ctx, p.Space, secretName)
+			if err != nil {
+				return fmt.Errorf("failed to get credentials for service key %q: %s", keyName, err)
+			}
+
+			credentials, err := json.MarshalIndent(cfutil.NewVcapService(*binding, *secret).Credentials, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), string(credentials))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&guid,
+		"guid",
+		false,
+		"Print the unique ID of the service key rather than its credentials.")
+
+	return cmd
+}
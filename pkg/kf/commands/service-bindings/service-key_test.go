@@ -0,0 +1,138 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebindings_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	servicebindingscmd "github.com/google/kf/v2/pkg/kf/commands/service-bindings"
+	secretsfake "github.com/google/kf/v2/pkg/kf/secrets/fake"
+	serviceinstancebindingsfake "github.com/google/kf/v2/pkg/kf/serviceinstancebindings/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func runServiceKeyTest(t *testing.T, tc bindingTest) {
+	ctrl := gomock.NewController(t)
+
+	sbClient := serviceinstancebindingsfake.NewFakeClient(ctrl)
+	secretClient := secretsfake.NewFakeClient(ctrl)
+
+	if tc.Setup != nil {
+		tc.Setup(t, fakes{
+			servicebindings: sbClient,
+			secrets:         secretClient,
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	p := &config.KfParams{
+		Space: tc.Space,
+	}
+
+	cmd := servicebindingscmd.NewServiceKeyCommand(p, sbClient, secretClient)
+	cmd.SetOutput(buf)
+	cmd.SetArgs(tc.Args)
+	_, actualErr := cmd.ExecuteC()
+	if tc.ExpectedErr != nil || actualErr != nil {
+		testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+		return
+	}
+
+	testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+}
+
+func TestNewServiceKeyCommand(t *testing.T) {
+	bindingName := v1alpha1.MakeServiceKeyBindingName("SERVICE_INSTANCE", "KEY_NAME")
+
+	readyKey := &v1alpha1.ServiceInstanceBinding{}
+	readyKey.Name = bindingName
+	readyKey.UID = "some-uid"
+	readyKey.Spec.ServiceKey = &v1alpha1.ServiceKeyRef{Name: "KEY_NAME"}
+	readyKey.Spec.InstanceRef.Name = "SERVICE_INSTANCE"
+	readyKey.Status.CredentialsSecretRef.Name = "credentials-secret"
+
+	pendingKey := readyKey.DeepCopy()
+	pendingKey.Status.CredentialsSecretRef.Name = ""
+
+	cases := map[string]bindingTest{
+		"wrong number of args": {
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 2 arg(s), received 0"),
+		},
+		"empty namespace": {
+			Args:        []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			ExpectedErr: errors.New(config.EmptySpaceError),
+		},
+		"key doesn't exist": {
+			Args:  []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.servicebindings.EXPECT().Get(gomock.Any(), "custom-ns", bindingName).Return(nil, errors.New("not found"))
+			},
+			ExpectedErr: errors.New("not found"),
+		},
+		"guid": {
+			Args:  []string{"SERVICE_INSTANCE", "KEY_NAME", "--guid"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.servicebindings.EXPECT().Get(gomock.Any(), "custom-ns", bindingName).Return(readyKey, nil)
+			},
+			ExpectedStrings: []string{"some-uid"},
+		},
+		"credentials not ready": {
+			Args:  []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.servicebindings.EXPECT().Get(gomock.Any(), "custom-ns", bindingName).Return(pendingKey, nil)
+			},
+			ExpectedErr: errors.New(`service key "KEY_NAME" doesn't have credentials yet, check its status with 'kf service-keys SERVICE_INSTANCE'`),
+		},
+		"credentials secret missing": {
+			Args:  []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.servicebindings.EXPECT().Get(gomock.Any(), "custom-ns", bindingName).Return(readyKey, nil)
+				fakes.secrets.EXPECT().Get(gomock.Any(), "custom-ns", "credentials-secret").Return(nil, errors.New("not found"))
+			},
+			ExpectedErr: errors.New(`failed to get credentials for service key "KEY_NAME": not found`),
+		},
+		"prints credentials": {
+			Args:  []string{"SERVICE_INSTANCE", "KEY_NAME"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.servicebindings.EXPECT().Get(gomock.Any(), "custom-ns", bindingName).Return(readyKey, nil)
+				fakes.secrets.EXPECT().Get(gomock.Any(), "custom-ns", "credentials-secret").Return(&corev1.Secret{
+					Data: map[string][]byte{
+						"username": []byte(`"admin"`),
+						"port":     []byte(`5432`),
+					},
+				}, nil)
+			},
+			ExpectedStrings: []string{`"username": "admin"`, `"port": 5432`},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runServiceKeyTest(t, tc)
+		})
+	}
+}
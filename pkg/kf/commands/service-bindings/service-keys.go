@@ -0,0 +1,74 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebindings
+
+import (
+	"fmt"
+	"io"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/describe"
+	"github.com/google/kf/v2/pkg/kf/serviceinstancebindings"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NewServiceKeysCommand allows users to list the service keys for a service
+// instance.
+func NewServiceKeysCommand(p *config.KfParams, client serviceinstancebindings.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "service-keys SERVICE_INSTANCE",
+		Aliases:      []string{"sk"},
+		Short:        "List the service keys for a service instance.",
+		Example:      `kf service-keys mydb`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			bindings, err := client.List(cmd.Context(), p.Space)
+			if err != nil {
+				return err
+			}
+
+			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
+				fmt.Fprintln(w, "Name\tReady\tReason")
+				for _, binding := range bindings {
+					if !binding.IsServiceKeyBinding() || binding.Spec.InstanceRef.Name != instanceName {
+						continue
+					}
+
+					ready := string(corev1.ConditionUnknown)
+					reason := ""
+					if cond := binding.Status.GetCondition(v1alpha1.ServiceInstanceBindingConditionReady); cond != nil {
+						ready = string(cond.Status)
+						reason = cond.Reason
+					}
+
+					fmt.Fprintf(w, "%s\t%s\t%s\n", binding.Spec.ServiceKey.Name, ready, reason)
+				}
+			})
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebindings_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	servicebindingscmd "github.com/google/kf/v2/pkg/kf/commands/service-bindings"
+	serviceinstancebindingsfake "github.com/google/kf/v2/pkg/kf/serviceinstancebindings/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+func runServiceKeysTest(t *testing.T, tc bindingTest) {
+	ctrl := gomock.NewController(t)
+
+	sbClient := serviceinstancebindingsfake.NewFakeClient(ctrl)
+
+	if tc.Setup != nil {
+		tc.Setup(t, fakes{
+			servicebindings: sbClient,
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	p := &config.KfParams{
+		Space: tc.Space,
+	}
+
+	cmd := servicebindingscmd.NewServiceKeysCommand(p, sbClient)
+	cmd.SetOutput(buf)
+	cmd.SetArgs(tc.Args)
+	_, actualErr := cmd.ExecuteC()
+	if tc.ExpectedErr != nil || actualErr != nil {
+		testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+		return
+	}
+
+	testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+}
+
+func TestNewServiceKeysCommand(t *testing.T) {
+	serviceKey := func(instanceName, keyName string) v1alpha1.ServiceInstanceBinding {
+		binding := v1alpha1.ServiceInstanceBinding{}
+		binding.Name = v1alpha1.MakeServiceKeyBindingName(instanceName, keyName)
+		binding.Spec.ServiceKey = &v1alpha1.ServiceKeyRef{Name: keyName}
+		binding.Spec.InstanceRef.Name = instanceName
+		return binding
+	}
+
+	readyKey := serviceKey("SERVICE_INSTANCE", "ready-key")
+	readyKey.Status.Conditions = duckv1beta1.Conditions{
+		{
+			Type:   apis.ConditionReady,
+			Status: corev1.ConditionTrue,
+		},
+	}
+
+	appBinding := v1alpha1.ServiceInstanceBinding{}
+	appBinding.Name = "app-binding"
+	appBinding.Spec.App = &v1alpha1.AppRef{Name: "my-app"}
+	appBinding.Spec.InstanceRef.Name = "SERVICE_INSTANCE"
+
+	cases := map[string]bindingTest{
+		"wrong number of args": {
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"empty namespace": {
+			Args:        []string{"SERVICE_INSTANCE"},
+			ExpectedErr: errors.New(config.EmptySpaceError),
+		},
+		"bad server call": {
+			Args:  []string{"SERVICE_INSTANCE"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.servicebindings.EXPECT().List(gomock.Any(), "custom-ns").Return(nil, errors.New("api-error"))
+			},
+			ExpectedErr: errors.New("api-error"),
+		},
+		"lists keys for the instance": {
+			Args:  []string{"SERVICE_INSTANCE"},
+			Space: "custom-ns",
+			Setup: func(t *testing.T, fakes fakes) {
+				fakes.servicebindings.EXPECT().List(gomock.Any(), "custom-ns").Return([]v1alpha1.ServiceInstanceBinding{
+					readyKey,
+					serviceKey("SERVICE_INSTANCE", "pending-key"),
+					serviceKey("OTHER_INSTANCE", "other-key"),
+					appBinding,
+				}, nil)
+			},
+			ExpectedStrings: []string{"ready-key", "True", "pending-key", "Unknown"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runServiceKeysTest(t, tc)
+		})
+	}
+}
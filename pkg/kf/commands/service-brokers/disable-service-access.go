@@ -0,0 +1,93 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"fmt"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	cluster "github.com/google/kf/v2/pkg/kf/service-brokers/cluster"
+	"github.com/spf13/cobra"
+)
+
+// NewDisableServiceAccessCommand hides the plans of a service from a cluster
+// service broker from Spaces.
+func NewDisableServiceAccessCommand(
+	p *config.KfParams,
+	clusterClient cluster.Client,
+) *cobra.Command {
+	var (
+		brokerName string
+		planName   string
+		spaceName  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "disable-service-access SERVICE [-b BROKER] [-p PLAN] [-s SPACE]",
+		Short: "Prevent Spaces from using the plans of a service.",
+		Long: `
+		Hides the plans of a service from a cluster service broker from the
+		marketplace of a Space and prevents new instances of them from being
+		created there. If no Space is specified, the plans are disabled in all
+		Spaces.
+
+		Existing instances of the plans aren't affected.
+		`,
+		Example: `
+		# Disable all plans of a service in all Spaces
+		kf disable-service-access mysql
+
+		# Disable a single plan in a single Space
+		kf disable-service-access mysql -p large -s dev-space
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceName := args[0]
+
+			broker, err := findClusterBrokerForService(cmd.Context(), clusterClient, brokerName, serviceName)
+			if err != nil {
+				return err
+			}
+
+			planNames, err := selectPlanNames(broker, serviceName, planName)
+			if err != nil {
+				return err
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "Disabling access to %s\n", describeAccessChange(broker.Name, serviceName, planName, spaceName))
+
+			if _, err := clusterClient.Transform(cmd.Context(), broker.Name, func(b *v1alpha1.ClusterServiceBroker) error {
+				for _, plan := range planNames {
+					if err := b.Spec.DisablePlanAccess(serviceName, plan, spaceName); err != nil {
+						return err
+					}
+				}
+				return nil
+			}); err != nil {
+				return fmt.Errorf("failed to disable service access: %s", err)
+			}
+
+			fmt.Fprintln(w, "Success")
+			return nil
+		},
+	}
+
+	addServiceAccessFlags(cmd, &brokerName, &planName, &spaceName)
+
+	return cmd
+}
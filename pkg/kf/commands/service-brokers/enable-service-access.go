@@ -0,0 +1,129 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"fmt"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	cluster "github.com/google/kf/v2/pkg/kf/service-brokers/cluster"
+	"github.com/spf13/cobra"
+)
+
+// NewEnableServiceAccessCommand makes the plans of a service from a cluster
+// service broker available in Spaces.
+func NewEnableServiceAccessCommand(
+	p *config.KfParams,
+	clusterClient cluster.Client,
+) *cobra.Command {
+	var (
+		brokerName string
+		planName   string
+		spaceName  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "enable-service-access SERVICE [-b BROKER] [-p PLAN] [-s SPACE]",
+		Short: "Allow Spaces to use the plans of a service.",
+		Long: `
+		Makes the plans of a service from a cluster service broker visible in
+		the marketplace of a Space and allows instances of them to be created
+		there. If no Space is specified, the plans are made available in all
+		Spaces.
+
+		A plan available in all Spaces can be limited to specific Spaces by
+		first running disable-service-access for all Spaces, then
+		enable-service-access for each Space.
+		`,
+		Example: `
+		# Make all plans of a service available in all Spaces
+		kf enable-service-access mysql
+
+		# Make a single plan available in a single Space
+		kf enable-service-access mysql -p small -s dev-space
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceName := args[0]
+
+			broker, err := findClusterBrokerForService(cmd.Context(), clusterClient, brokerName, serviceName)
+			if err != nil {
+				return err
+			}
+
+			planNames, err := selectPlanNames(broker, serviceName, planName)
+			if err != nil {
+				return err
+			}
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "Enabling access to %s\n", describeAccessChange(broker.Name, serviceName, planName, spaceName))
+
+			if spaceName != "" {
+				for _, plan := range planNames {
+					if broker.Spec.GetPlanVisibility(serviceName, plan) == nil {
+						fmt.Fprintf(w, "Plan %s is already available in all Spaces\n", plan)
+					}
+				}
+			}
+
+			if _, err := clusterClient.Transform(cmd.Context(), broker.Name, func(b *v1alpha1.ClusterServiceBroker) error {
+				for _, plan := range planNames {
+					b.Spec.EnablePlanAccess(serviceName, plan, spaceName)
+				}
+				return nil
+			}); err != nil {
+				return fmt.Errorf("failed to enable service access: %s", err)
+			}
+
+			fmt.Fprintln(w, "Success")
+			return nil
+		},
+	}
+
+	addServiceAccessFlags(cmd, &brokerName, &planName, &spaceName)
+
+	return cmd
+}
+
+// addServiceAccessFlags adds the flags shared by the commands that change
+// service access.
+func addServiceAccessFlags(cmd *cobra.Command, brokerName, planName, spaceName *string) {
+	cmd.Flags().StringVarP(
+		brokerName,
+		"broker",
+		"b",
+		"",
+		"Name of the service broker offering the service, required if multiple brokers offer a service with the same name.",
+	)
+
+	cmd.Flags().StringVarP(
+		planName,
+		"plan",
+		"p",
+		"",
+		"Only change access to the plan.",
+	)
+
+	cmd.Flags().StringVarP(
+		spaceName,
+		"space-name",
+		"s",
+		"",
+		"Only change access in the Space.",
+	)
+}
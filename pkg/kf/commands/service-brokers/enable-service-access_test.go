@@ -0,0 +1,115 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	clusterclient "github.com/google/kf/v2/pkg/kf/service-brokers/cluster"
+	cluster "github.com/google/kf/v2/pkg/kf/service-brokers/cluster/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestNewEnableServiceAccessCommand(t *testing.T) {
+	cases := map[string]struct {
+		args             []string
+		wantTransform    bool
+		wantVisibilities []v1alpha1.ServicePlanVisibility
+		wantErr          error
+		wantOut          string
+	}{
+		"bad args": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"unknown service": {
+			args:    []string{"postgres"},
+			wantErr: errors.New("service postgres not found in any service-broker"),
+		},
+		"unknown service in broker": {
+			args:    []string{"mysql", "-b", "cache-broker"},
+			wantErr: errors.New("service mysql not found in the service-broker cache-broker"),
+		},
+		"service in multiple brokers": {
+			args:    []string{"cache"},
+			wantErr: errors.New("service cache is provided by multiple service-brokers: sql-broker, cache-broker, specify one with --broker"),
+		},
+		"unknown plan": {
+			args:    []string{"mysql", "-p", "huge"},
+			wantErr: errors.New("no plan huge found for service mysql in the service-broker sql-broker"),
+		},
+		"all plans in all Spaces": {
+			args:          []string{"mysql"},
+			wantTransform: true,
+			wantOut:       "Enabling access to all plans of service mysql from the service-broker sql-broker for all Spaces\nSuccess\n",
+		},
+		"plan in Space": {
+			args:          []string{"mysql", "-p", "medium", "-s", "prod"},
+			wantTransform: true,
+			wantVisibilities: []v1alpha1.ServicePlanVisibility{
+				{ServiceName: "mysql", PlanName: "medium", Spaces: []string{"dev", "test", "prod"}},
+				{ServiceName: "mysql", PlanName: "large"},
+			},
+			wantOut: "Enabling access to plan medium of service mysql from the service-broker sql-broker for Space \"prod\"\nSuccess\n",
+		},
+		"public plan in Space": {
+			args:          []string{"cache", "-b", "sql-broker", "--space-name", "prod"},
+			wantTransform: true,
+			wantVisibilities: []v1alpha1.ServicePlanVisibility{
+				{ServiceName: "mysql", PlanName: "medium", Spaces: []string{"dev", "test"}},
+				{ServiceName: "mysql", PlanName: "large"},
+			},
+			wantOut: "Enabling access to all plans of service cache from the service-broker sql-broker for Space \"prod\"\n" +
+				"Plan basic is already available in all Spaces\n" +
+				"Success\n",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			clusterClient := cluster.NewFakeClient(ctrl)
+			clusterClient.EXPECT().List(gomock.Any()).Return(newAccessTestBrokers(), nil).AnyTimes()
+			if tc.wantTransform {
+				clusterClient.EXPECT().
+					Transform(gomock.Any(), "sql-broker", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, mutator clusterclient.Mutator) (*v1alpha1.ClusterServiceBroker, error) {
+						broker := &newAccessTestBrokers()[0]
+						testutil.AssertNil(t, "mutator error", mutator(broker))
+						testutil.AssertEqual(t, "visibilities", tc.wantVisibilities, broker.Spec.PlanVisibilities)
+						return broker, nil
+					})
+			}
+
+			buf := new(bytes.Buffer)
+			cmd := NewEnableServiceAccessCommand(&config.KfParams{}, clusterClient)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.wantErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.wantErr, actualErr)
+				return
+			}
+
+			testutil.AssertEqual(t, "output", tc.wantOut, buf.String())
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/describe"
+	cluster "github.com/google/kf/v2/pkg/kf/service-brokers/cluster"
+	"github.com/spf13/cobra"
+)
+
+// NewServiceAccessCommand lists the Spaces the plans of cluster service
+// brokers are visible in.
+func NewServiceAccessCommand(
+	p *config.KfParams,
+	clusterClient cluster.Client,
+) *cobra.Command {
+	var (
+		brokerName  string
+		serviceName string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "service-access [-b BROKER] [-e SERVICE]",
+		Short: "List the Spaces the plans of cluster service brokers are available in.",
+		Long: `
+		Lists the plans of each cluster service broker and whether they're
+		available in all Spaces, a limited set of Spaces, or none.
+
+		Use enable-service-access and disable-service-access to change where
+		plans are available.
+		`,
+		Example: `
+		# List access for all plans
+		kf service-access
+
+		# List access for the plans of a service
+		kf service-access -e mysql
+		`,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			brokers, err := clusterClient.List(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
+				fmt.Fprintln(w, "Broker\tService\tPlan\tAccess\tSpaces")
+				for _, broker := range brokers {
+					if brokerName != "" && broker.Name != brokerName {
+						continue
+					}
+
+					for _, offering := range broker.Status.Services {
+						if serviceName != "" && offering.DisplayName != serviceName {
+							continue
+						}
+
+						for _, plan := range offering.Plans {
+							access, spaces := planAccess(&broker.Spec, offering.DisplayName, plan.DisplayName)
+							fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+								broker.Name,
+								offering.DisplayName,
+								plan.DisplayName,
+								access,
+								spaces,
+							)
+						}
+					}
+				}
+			})
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&brokerName,
+		"broker",
+		"b",
+		"",
+		"Only list plans from the service broker.",
+	)
+
+	cmd.Flags().StringVarP(
+		&serviceName,
+		"service",
+		"e",
+		"",
+		"Only list plans of the service.",
+	)
+
+	return cmd
+}
+
+// planAccess returns a human readable description of the Spaces a plan is
+// visible in.
+func planAccess(spec *v1alpha1.ClusterServiceBrokerSpec, serviceName, planName string) (access, spaces string) {
+	visibility := spec.GetPlanVisibility(serviceName, planName)
+	switch {
+	case visibility == nil:
+		return "all", ""
+	case len(visibility.Spaces) == 0:
+		return "none", ""
+	default:
+		return "limited", strings.Join(visibility.Spaces, ", ")
+	}
+}
+
+// findClusterBrokerForService finds the cluster service broker offering the
+// service. If brokerName is set, only that broker is checked.
+func findClusterBrokerForService(
+	ctx context.Context,
+	clusterClient cluster.Client,
+	brokerName string,
+	serviceName string,
+) (*v1alpha1.ClusterServiceBroker, error) {
+	brokers, err := clusterClient.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []v1alpha1.ClusterServiceBroker
+	var matchNames []string
+	for _, broker := range brokers {
+		if brokerName != "" && broker.Name != brokerName {
+			continue
+		}
+
+		if findServiceOffering(&broker, serviceName) != nil {
+			matches = append(matches, broker)
+			matchNames = append(matchNames, broker.Name)
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return &matches[0], nil
+	case len(matches) > 1:
+		return nil, fmt.Errorf("service %s is provided by multiple service-brokers: %s, specify one with --broker", serviceName, strings.Join(matchNames, ", "))
+	case brokerName != "":
+		return nil, fmt.Errorf("service %s not found in the service-broker %s", serviceName, brokerName)
+	default:
+		return nil, fmt.Errorf("service %s not found in any service-broker", serviceName)
+	}
+}
+
+// findServiceOffering returns the service offering with the given name or
+// nil if it doesn't exist.
+func findServiceOffering(broker v1alpha1.CommonServiceBroker, serviceName string) *v1alpha1.ServiceOffering {
+	for _, offering := range broker.GetServiceOfferings() {
+		if offering.DisplayName == serviceName {
+			return &offering
+		}
+	}
+
+	return nil
+}
+
+// selectPlanNames returns the names of the plans of the service that access
+// is changed for. If planName is set, it's the only plan selected.
+func selectPlanNames(broker v1alpha1.CommonServiceBroker, serviceName, planName string) ([]string, error) {
+	offering := findServiceOffering(broker, serviceName)
+	if offering == nil {
+		return nil, fmt.Errorf("service %s not found in the service-broker %s", serviceName, broker.GetName())
+	}
+
+	var out []string
+	for _, plan := range offering.Plans {
+		if planName == "" || plan.DisplayName == planName {
+			out = append(out, plan.DisplayName)
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no plan %s found for service %s in the service-broker %s", planName, serviceName, broker.GetName())
+	}
+
+	return out, nil
+}
+
+// describeAccessChange returns a human readable description of which plans and
+// Spaces an access change applies to.
+func describeAccessChange(brokerName, serviceName, planName, spaceName string) string {
+	plans := "all plans"
+	if planName != "" {
+		plans = fmt.Sprintf("plan %s", planName)
+	}
+
+	spaces := "all Spaces"
+	if spaceName != "" {
+		spaces = fmt.Sprintf("Space %q", spaceName)
+	}
+
+	return fmt.Sprintf("%s of service %s from the service-broker %s for %s", plans, serviceName, brokerName, spaces)
+}
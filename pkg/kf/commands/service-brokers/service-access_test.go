@@ -0,0 +1,124 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	cluster "github.com/google/kf/v2/pkg/kf/service-brokers/cluster/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+// newAccessTestBrokers creates cluster service brokers for testing service
+// access commands.
+func newAccessTestBrokers() []v1alpha1.ClusterServiceBroker {
+	sqlBroker := v1alpha1.ClusterServiceBroker{}
+	sqlBroker.Name = "sql-broker"
+	sqlBroker.Status.Services = []v1alpha1.ServiceOffering{
+		{
+			DisplayName: "mysql",
+			Plans: []v1alpha1.ServicePlan{
+				{DisplayName: "small"},
+				{DisplayName: "medium"},
+				{DisplayName: "large"},
+			},
+		},
+		{
+			DisplayName: "cache",
+			Plans: []v1alpha1.ServicePlan{
+				{DisplayName: "basic"},
+			},
+		},
+	}
+	sqlBroker.Spec.PlanVisibilities = []v1alpha1.ServicePlanVisibility{
+		{ServiceName: "mysql", PlanName: "medium", Spaces: []string{"dev", "test"}},
+		{ServiceName: "mysql", PlanName: "large"},
+	}
+
+	cacheBroker := v1alpha1.ClusterServiceBroker{}
+	cacheBroker.Name = "cache-broker"
+	cacheBroker.Status.Services = []v1alpha1.ServiceOffering{
+		{
+			DisplayName: "cache",
+			Plans: []v1alpha1.ServicePlan{
+				{DisplayName: "premium"},
+			},
+		},
+	}
+
+	return []v1alpha1.ClusterServiceBroker{sqlBroker, cacheBroker}
+}
+
+func TestNewServiceAccessCommand(t *testing.T) {
+	cases := map[string]struct {
+		args    []string
+		listErr error
+		wantErr error
+		wantOut []string
+	}{
+		"bad args": {
+			args:    []string{"extra"},
+			wantErr: errors.New("accepts 0 arg(s), received 1"),
+		},
+		"list error": {
+			listErr: errors.New("api-error"),
+			wantErr: errors.New("api-error"),
+		},
+		"all plans": {
+			wantOut: []string{
+				"Broker        Service  Plan     Access   Spaces",
+				"sql-broker    mysql    small    all",
+				"sql-broker    mysql    medium   limited  dev, test",
+				"sql-broker    mysql    large    none",
+				"sql-broker    cache    basic    all",
+				"cache-broker  cache    premium  all",
+			},
+		},
+		"filtered by broker and service": {
+			args: []string{"-b", "sql-broker", "-e", "cache"},
+			wantOut: []string{
+				"Broker      Service  Plan   Access  Spaces",
+				"sql-broker  cache    basic  all",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			clusterClient := cluster.NewFakeClient(ctrl)
+			if tc.wantOut != nil || tc.listErr != nil {
+				clusterClient.EXPECT().List(gomock.Any()).Return(newAccessTestBrokers(), tc.listErr)
+			}
+
+			buf := new(bytes.Buffer)
+			cmd := NewServiceAccessCommand(&config.KfParams{}, clusterClient)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.wantErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.wantErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.wantOut)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"fmt"
+	"time"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	cluster "github.com/google/kf/v2/pkg/kf/service-brokers/cluster"
+	namespaced "github.com/google/kf/v2/pkg/kf/service-brokers/namespaced"
+	"github.com/spf13/cobra"
+)
+
+// NewUpdateServiceBrokerCommand refreshes the catalog of a service broker
+// (either cluster or namespaced).
+func NewUpdateServiceBrokerCommand(
+	p *config.KfParams,
+	clusterClient cluster.Client,
+	namespacedClient namespaced.Client,
+) *cobra.Command {
+	var (
+		spaceScoped bool
+		async       utils.AsyncFlags
+	)
+
+	updateCmd := &cobra.Command{
+		Use:   "update-service-broker NAME",
+		Short: "Refresh the catalog of a service broker.",
+		Long: `
+		Fetches the catalog of services and plans from a service broker and
+		updates the marketplace.
+
+		Catalogs are also refreshed periodically based on the broker's
+		spec.catalogRefreshInterval, which defaults to 24 hours.
+		`,
+		Example:      `  kf update-service-broker mybroker`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceBrokerName := args[0]
+
+			if spaceScoped {
+				if err := p.ValidateSpaceTargeted(); err != nil {
+					return err
+				}
+			}
+
+			var callback func() error
+			var action string
+			switch {
+			case spaceScoped:
+				updated, err := namespacedClient.Transform(cmd.Context(), p.Space, serviceBrokerName, func(b *v1alpha1.ServiceBroker) error {
+					b.Spec.UpdateRequests++
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+
+				action = fmt.Sprintf("Updating service broker %q in Space %q", serviceBrokerName, p.Space)
+				callback = func() error {
+					broker, err := namespacedClient.WaitFor(cmd.Context(), p.Space, serviceBrokerName, 1*time.Second, func(b *v1alpha1.ServiceBroker) bool {
+						return b.Status.UpdateRequests >= updated.Spec.UpdateRequests
+					})
+					if err != nil {
+						return err
+					}
+					return catalogError(&broker.Status)
+				}
+
+			default:
+				updated, err := clusterClient.Transform(cmd.Context(), serviceBrokerName, func(b *v1alpha1.ClusterServiceBroker) error {
+					b.Spec.UpdateRequests++
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+
+				action = fmt.Sprintf("Updating cluster service broker %q", serviceBrokerName)
+				callback = func() error {
+					broker, err := clusterClient.WaitFor(cmd.Context(), serviceBrokerName, 1*time.Second, func(b *v1alpha1.ClusterServiceBroker) bool {
+						return b.Status.UpdateRequests >= updated.Spec.UpdateRequests
+					})
+					if err != nil {
+						return err
+					}
+					return catalogError(&broker.Status)
+				}
+			}
+
+			return async.AwaitAndLog(cmd.OutOrStdout(), action, callback)
+		},
+	}
+
+	async.Add(updateCmd)
+
+	updateCmd.Flags().BoolVar(
+		&spaceScoped,
+		"space-scoped",
+		false,
+		"Set to update a space scoped service broker.")
+
+	return updateCmd
+}
+
+// catalogError returns an error if the broker's catalog couldn't be fetched.
+func catalogError(status *v1alpha1.CommonServiceBrokerStatus) error {
+	if cond := status.GetCondition(v1alpha1.CommonServiceBrokerConditionCatalogReady); cond != nil && cond.IsFalse() {
+		return fmt.Errorf("failed to refresh the catalog: %s", cond.Message)
+	}
+
+	return nil
+}
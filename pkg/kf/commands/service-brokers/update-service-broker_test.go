@@ -0,0 +1,176 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebrokers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	clusterclient "github.com/google/kf/v2/pkg/kf/service-brokers/cluster"
+	cluster "github.com/google/kf/v2/pkg/kf/service-brokers/cluster/fake"
+	namespacedclient "github.com/google/kf/v2/pkg/kf/service-brokers/namespaced"
+	namespaced "github.com/google/kf/v2/pkg/kf/service-brokers/namespaced/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestNewUpdateServiceBrokerCommand(t *testing.T) {
+	type mocks struct {
+		p                *config.KfParams
+		clusterClient    *cluster.FakeClient
+		namespacedClient *namespaced.FakeClient
+	}
+
+	newClusterBroker := func(updateRequests int) *v1alpha1.ClusterServiceBroker {
+		broker := &v1alpha1.ClusterServiceBroker{}
+		broker.Name = "cluster-broker"
+		broker.Spec.UpdateRequests = updateRequests
+		broker.Status.UpdateRequests = updateRequests
+		return broker
+	}
+
+	expectClusterTransform := func(t *testing.T, mocks mocks) {
+		mocks.clusterClient.EXPECT().
+			Transform(gomock.Any(), "cluster-broker", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, mutator clusterclient.Mutator) (*v1alpha1.ClusterServiceBroker, error) {
+				broker := newClusterBroker(1)
+				testutil.AssertNil(t, "mutator error", mutator(broker))
+				testutil.AssertEqual(t, "updateRequests", 2, broker.Spec.UpdateRequests)
+				return broker, nil
+			})
+	}
+
+	expectClusterWait := func(t *testing.T, mocks mocks, result *v1alpha1.ClusterServiceBroker) {
+		mocks.clusterClient.EXPECT().
+			WaitFor(gomock.Any(), "cluster-broker", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ time.Duration, predicate clusterclient.Predicate) (*v1alpha1.ClusterServiceBroker, error) {
+				testutil.AssertEqual(t, "stale broker done", false, predicate(newClusterBroker(1)))
+				testutil.AssertEqual(t, "updated broker done", true, predicate(newClusterBroker(2)))
+				return result, nil
+			})
+	}
+
+	cases := map[string]struct {
+		args    []string
+		setup   func(t *testing.T, mocks mocks)
+		wantErr error
+		wantOut string
+	}{
+		"no params": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"no namespace space scoped": {
+			args: []string{"some-broker", "--space-scoped"},
+			setup: func(t *testing.T, mocks mocks) {
+				mocks.p.Space = ""
+			},
+			wantErr: errors.New(config.EmptySpaceError),
+		},
+		"cluster broker refreshed": {
+			args: []string{"cluster-broker"},
+			setup: func(t *testing.T, mocks mocks) {
+				expectClusterTransform(t, mocks)
+				expectClusterWait(t, mocks, newClusterBroker(2))
+			},
+			wantOut: "Updating cluster service broker \"cluster-broker\"...\nSuccess\n",
+		},
+		"cluster broker catalog fails": {
+			args: []string{"cluster-broker"},
+			setup: func(t *testing.T, mocks mocks) {
+				expectClusterTransform(t, mocks)
+
+				failed := newClusterBroker(2)
+				failed.Status.CatalogCondition().MarkFalse("GettingCatalog", "connection refused")
+				expectClusterWait(t, mocks, failed)
+			},
+			wantErr: errors.New("failed to refresh the catalog: connection refused"),
+		},
+		"cluster broker async": {
+			args: []string{"cluster-broker", "--async"},
+			setup: func(t *testing.T, mocks mocks) {
+				expectClusterTransform(t, mocks)
+			},
+			wantOut: "Updating cluster service broker \"cluster-broker\" asynchronously\n",
+		},
+		"cluster broker missing": {
+			args: []string{"cluster-broker"},
+			setup: func(t *testing.T, mocks mocks) {
+				mocks.clusterClient.EXPECT().
+					Transform(gomock.Any(), "cluster-broker", gomock.Any()).
+					Return(nil, errors.New("not found"))
+			},
+			wantErr: errors.New("not found"),
+		},
+		"namespaced broker refreshed": {
+			args: []string{"ns-broker", "--space-scoped"},
+			setup: func(t *testing.T, mocks mocks) {
+				mocks.p.Space = "custom-ns"
+				mocks.namespacedClient.EXPECT().
+					Transform(gomock.Any(), "custom-ns", "ns-broker", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator namespacedclient.Mutator) (*v1alpha1.ServiceBroker, error) {
+						broker := &v1alpha1.ServiceBroker{}
+						broker.Spec.UpdateRequests = 4
+						testutil.AssertNil(t, "mutator error", mutator(broker))
+						testutil.AssertEqual(t, "updateRequests", 5, broker.Spec.UpdateRequests)
+						return broker, nil
+					})
+				mocks.namespacedClient.EXPECT().
+					WaitFor(gomock.Any(), "custom-ns", "ns-broker", gomock.Any(), gomock.Any()).
+					Return(&v1alpha1.ServiceBroker{}, nil)
+			},
+			wantOut: "Updating service broker \"ns-broker\" in Space \"custom-ns\"...\nSuccess\n",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			args := mocks{
+				p: &config.KfParams{
+					Space: "default",
+				},
+				clusterClient:    cluster.NewFakeClient(ctrl),
+				namespacedClient: namespaced.NewFakeClient(ctrl),
+			}
+
+			if tc.setup != nil {
+				tc.setup(t, args)
+			}
+
+			buf := new(bytes.Buffer)
+			cmd := NewUpdateServiceBrokerCommand(
+				args.p,
+				args.clusterClient,
+				args.namespacedClient,
+			)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.wantErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.wantErr, actualErr)
+				return
+			}
+
+			testutil.AssertEqual(t, "output", tc.wantOut, buf.String())
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/marketplace"
+	"github.com/google/kf/v2/pkg/kf/serviceinstances"
+	"github.com/spf13/cobra"
+	"knative.dev/pkg/logging"
+)
+
+// NewShareServiceCommand allows users to share service instances with other
+// Spaces.
+func NewShareServiceCommand(p *config.KfParams, client serviceinstances.Client, marketplaceClient marketplace.ClientInterface) *cobra.Command {
+	var otherSpace string
+
+	cmd := &cobra.Command{
+		Use:   "share-service SERVICE_INSTANCE -s OTHER_SPACE",
+		Short: "Share a service instance with another Space.",
+		Long: `
+		Sharing a service instance lets Apps in another Space bind to it. The
+		instance stays in its original Space and can only be updated or deleted
+		from there.
+
+		You must be allowed to create bindings in the other Space. Services
+		from a service broker can only be shared if the broker marks them as
+		shareable in its catalog.
+		`,
+		Example:      `kf share-service mydb -s other-space`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			instanceName := args[0]
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			if otherSpace == "" {
+				return errors.New("the Space to share with is required, specify it with --other-space")
+			}
+
+			instance, err := client.Get(ctx, p.Space, instanceName)
+			if err != nil {
+				return err
+			}
+
+			if !instance.IsShareable() {
+				return errors.New("only user-provided and brokered services can be shared")
+			}
+
+			if instance.IsKfBrokered() {
+				osb := instance.Spec.OSB
+
+				catalog, err := marketplaceClient.Marketplace(ctx, p.Space)
+				if err != nil {
+					return err
+				}
+
+				planFilters := marketplace.ListPlanOptions{
+					PlanName:    osb.PlanName,
+					ServiceName: osb.ClassName,
+					BrokerName:  osb.BrokerName,
+				}
+
+				var matchingPlans []marketplace.PlanLineage
+				if osb.Namespaced {
+					matchingPlans = catalog.ListNamespacedPlans(p.Space, planFilters)
+				} else {
+					matchingPlans = catalog.ListClusterPlans(planFilters)
+				}
+
+				if len(matchingPlans) != 1 {
+					return fmt.Errorf("no plan %s found for class %s for the service-broker %s", osb.PlanName, osb.ClassName, osb.BrokerName)
+				}
+
+				if !matchingPlans[0].ServiceOffering.Shareable {
+					return fmt.Errorf("the service-broker %s doesn't allow instances of class %s to be shared", osb.BrokerName, osb.ClassName)
+				}
+			}
+
+			logger := logging.FromContext(ctx)
+			if instance.IsSharedWith(otherSpace) {
+				logger.Infof("Service instance %q is already shared with Space %q", instanceName, otherSpace)
+				return nil
+			}
+
+			logger.Infof("Sharing service instance %q in Space %q with Space %q", instanceName, p.Space, otherSpace)
+			if _, err := client.Transform(ctx, p.Space, instanceName, func(instance *v1alpha1.ServiceInstance) error {
+				if !instance.IsSharedWith(otherSpace) {
+					instance.Spec.SharedSpaces = append(instance.Spec.SharedSpaces, otherSpace)
+				}
+				return nil
+			}); err != nil {
+				return fmt.Errorf("Failed to share service instance: %s", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "Success")
+			utils.SuggestNextAction(utils.NextAction{
+				Description: "Bind the service to an App in the other Space",
+				Commands: []string{
+					fmt.Sprintf("kf bind-service APP_NAME %s --instance-space %s --space %s", instanceName, p.Space, otherSpace),
+				},
+			})
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&otherSpace,
+		"other-space",
+		"s",
+		"",
+		"Space to share the service instance with.")
+
+	return cmd
+}
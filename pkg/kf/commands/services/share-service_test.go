@@ -0,0 +1,201 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	servicescmd "github.com/google/kf/v2/pkg/kf/commands/services"
+	"github.com/google/kf/v2/pkg/kf/marketplace"
+	marketplacefake "github.com/google/kf/v2/pkg/kf/marketplace/fake"
+	"github.com/google/kf/v2/pkg/kf/serviceinstances"
+	serviceinstancesfake "github.com/google/kf/v2/pkg/kf/serviceinstances/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestNewShareServiceCommand(t *testing.T) {
+	type fakes struct {
+		services    *serviceinstancesfake.FakeClient
+		marketplace *marketplacefake.FakeClientInterface
+	}
+
+	const mockNs = "test-ns"
+
+	mockClusterBroker := &v1alpha1.ClusterServiceBroker{}
+	mockClusterBroker.Name = "cluster-broker"
+	mockClusterBroker.Status.Services = []v1alpha1.ServiceOffering{
+		{
+			DisplayName: "shared-service",
+			UID:         "shared-uid",
+			Shareable:   true,
+			Plans: []v1alpha1.ServicePlan{
+				{DisplayName: "free", UID: "free-uid"},
+			},
+		},
+		{
+			DisplayName: "private-service",
+			UID:         "private-uid",
+			Plans: []v1alpha1.ServicePlan{
+				{DisplayName: "free", UID: "free-uid"},
+			},
+		},
+	}
+
+	mockMarketplace := &marketplace.KfMarketplace{
+		Brokers: []v1alpha1.CommonServiceBroker{
+			mockClusterBroker,
+		},
+	}
+
+	brokeredInstance := func(className string) *v1alpha1.ServiceInstance {
+		instance := &v1alpha1.ServiceInstance{}
+		instance.Name = "mydb"
+		instance.Namespace = mockNs
+		instance.Spec.OSB = &v1alpha1.OSBInstance{
+			BrokerName: mockClusterBroker.Name,
+			ClassName:  className,
+			PlanName:   "free",
+		}
+		return instance
+	}
+
+	userProvidedInstance := func(sharedSpaces ...string) *v1alpha1.ServiceInstance {
+		instance := &v1alpha1.ServiceInstance{}
+		instance.Name = "mydb"
+		instance.Namespace = mockNs
+		instance.Spec.UPS = &v1alpha1.UPSInstance{}
+		instance.Spec.SharedSpaces = sharedSpaces
+		return instance
+	}
+
+	volumeInstance := &v1alpha1.ServiceInstance{}
+	volumeInstance.Spec.Volume = &v1alpha1.OSBInstance{}
+
+	// expectTransform checks the result of the mutator on an instance.
+	expectTransform := func(t *testing.T, fakes fakes, instance *v1alpha1.ServiceInstance, wantSharedSpaces []string) {
+		fakes.services.EXPECT().
+			Transform(gomock.Any(), mockNs, "mydb", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, mutator serviceinstances.Mutator) (*v1alpha1.ServiceInstance, error) {
+				testutil.AssertNil(t, "mutator error", mutator(instance))
+				testutil.AssertEqual(t, "sharedSpaces", wantSharedSpaces, instance.Spec.SharedSpaces)
+				return instance, nil
+			})
+	}
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(*testing.T, fakes)
+		expectErr error
+	}{
+		"bad number of args": {
+			expectErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"bad namespace": {
+			args:      []string{"mydb", "-s", "other-ns"},
+			expectErr: errors.New(config.EmptySpaceError),
+		},
+		"missing other space": {
+			namespace: mockNs,
+			args:      []string{"mydb"},
+			expectErr: errors.New("the Space to share with is required, specify it with --other-space"),
+		},
+		"volume services can't be shared": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-s", "other-ns"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(volumeInstance, nil)
+			},
+			expectErr: errors.New("only user-provided and brokered services can be shared"),
+		},
+		"broker doesn't allow sharing": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-s", "other-ns"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(brokeredInstance("private-service"), nil)
+				fakes.marketplace.EXPECT().Marketplace(gomock.Any(), mockNs).Return(mockMarketplace, nil)
+			},
+			expectErr: errors.New("the service-broker cluster-broker doesn't allow instances of class private-service to be shared"),
+		},
+		"brokered service shared": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-s", "other-ns"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(brokeredInstance("shared-service"), nil)
+				fakes.marketplace.EXPECT().Marketplace(gomock.Any(), mockNs).Return(mockMarketplace, nil)
+				expectTransform(t, fakes, brokeredInstance("shared-service"), []string{"other-ns"})
+			},
+		},
+		"user-provided service shared": {
+			namespace: mockNs,
+			args:      []string{"mydb", "--other-space", "other-ns"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(userProvidedInstance("first-ns"), nil)
+				expectTransform(t, fakes, userProvidedInstance("first-ns"), []string{"first-ns", "other-ns"})
+			},
+		},
+		"already shared": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-s", "other-ns"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(userProvidedInstance("other-ns"), nil)
+			},
+		},
+		"transform fails": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-s", "other-ns"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(userProvidedInstance(), nil)
+				fakes.services.EXPECT().Transform(gomock.Any(), mockNs, "mydb", gomock.Any()).Return(nil, errors.New("api-error"))
+			},
+			expectErr: errors.New("Failed to share service instance: api-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sClient := serviceinstancesfake.NewFakeClient(ctrl)
+			mClient := marketplacefake.NewFakeClientInterface(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakes{
+					services:    sClient,
+					marketplace: mClient,
+				})
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Space: tc.namespace,
+			}
+
+			cmd := servicescmd.NewShareServiceCommand(p, sClient, mClient)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.expectErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.expectErr, actualErr)
+			}
+		})
+	}
+}
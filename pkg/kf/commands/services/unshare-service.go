@@ -0,0 +1,84 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/serviceinstances"
+	"github.com/spf13/cobra"
+	"knative.dev/pkg/logging"
+)
+
+// NewUnshareServiceCommand allows users to stop sharing service instances
+// with other Spaces.
+func NewUnshareServiceCommand(p *config.KfParams, client serviceinstances.Client) *cobra.Command {
+	var otherSpace string
+
+	cmd := &cobra.Command{
+		Use:   "unshare-service SERVICE_INSTANCE -s OTHER_SPACE",
+		Short: "Stop sharing a service instance with another Space.",
+		Long: `
+		Unsharing a service instance prevents Apps in the other Space from
+		binding to it. Existing bindings in the other Space must be deleted
+		first.
+		`,
+		Example:      `kf unshare-service mydb -s other-space`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			instanceName := args[0]
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			if otherSpace == "" {
+				return errors.New("the Space to unshare from is required, specify it with --other-space")
+			}
+
+			logger := logging.FromContext(ctx)
+			logger.Infof("Unsharing service instance %q in Space %q from Space %q", instanceName, p.Space, otherSpace)
+			if _, err := client.Transform(ctx, p.Space, instanceName, func(instance *v1alpha1.ServiceInstance) error {
+				var sharedSpaces []string
+				for _, space := range instance.Spec.SharedSpaces {
+					if space != otherSpace {
+						sharedSpaces = append(sharedSpaces, space)
+					}
+				}
+				instance.Spec.SharedSpaces = sharedSpaces
+				return nil
+			}); err != nil {
+				return fmt.Errorf("Failed to unshare service instance: %s", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "Success")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&otherSpace,
+		"other-space",
+		"s",
+		"",
+		"Space to stop sharing the service instance with.")
+
+	return cmd
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	servicescmd "github.com/google/kf/v2/pkg/kf/commands/services"
+	"github.com/google/kf/v2/pkg/kf/serviceinstances"
+	"github.com/google/kf/v2/pkg/kf/serviceinstances/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestNewUnshareServiceCommand(t *testing.T) {
+	cases := map[string]serviceTest{
+		"bad number of args": {
+			ExpectedErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"bad namespace": {
+			Args:        []string{"mydb", "-s", "other-ns"},
+			ExpectedErr: errors.New(config.EmptySpaceError),
+		},
+		"missing other space": {
+			Args:        []string{"mydb"},
+			Space:       "test-ns",
+			ExpectedErr: errors.New("the Space to unshare from is required, specify it with --other-space"),
+		},
+		"unshares": {
+			Args:  []string{"mydb", "-s", "other-ns"},
+			Space: "test-ns",
+			Setup: func(t *testing.T, f *fake.FakeClient) {
+				f.EXPECT().
+					Transform(gomock.Any(), "test-ns", "mydb", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator serviceinstances.Mutator) (*v1alpha1.ServiceInstance, error) {
+						instance := &v1alpha1.ServiceInstance{}
+						instance.Spec.SharedSpaces = []string{"first-ns", "other-ns", "last-ns"}
+						testutil.AssertNil(t, "mutator error", mutator(instance))
+						testutil.AssertEqual(t, "sharedSpaces", []string{"first-ns", "last-ns"}, instance.Spec.SharedSpaces)
+						return instance, nil
+					})
+			},
+			ExpectedStrings: []string{"Success"},
+		},
+		"transform fails": {
+			Args:  []string{"mydb", "-s", "other-ns"},
+			Space: "test-ns",
+			Setup: func(t *testing.T, f *fake.FakeClient) {
+				f.EXPECT().Transform(gomock.Any(), "test-ns", "mydb", gomock.Any()).Return(nil, errors.New("api-error"))
+			},
+			ExpectedErr: errors.New("Failed to unshare service instance: api-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			runTest(t, tc, servicescmd.NewUnshareServiceCommand)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/marketplace"
+	"github.com/google/kf/v2/pkg/kf/secrets"
+	"github.com/google/kf/v2/pkg/kf/serviceinstances"
+	"github.com/spf13/cobra"
+)
+
+// NewUpdateServiceCommand allows users to update the plan and parameters of
+// brokered service instances.
+func NewUpdateServiceCommand(p *config.KfParams, client serviceinstances.Client, secretsClient secrets.Client, marketplaceClient marketplace.ClientInterface) *cobra.Command {
+	var (
+		configAsJSON string
+		planName     string
+		async        utils.AsyncFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update-service SERVICE_INSTANCE [-p NEW_PLAN] [-c PARAMETERS_AS_JSON]",
+		Short: "Update the plan or parameters of a brokered service instance.",
+		Long: `
+		Updates a ServiceInstance created from the marketplace. The new plan and
+		parameters are sent to the service broker in an update request.
+
+		Changing plans is only possible if the service broker marks the
+		instance's current plan as updatable in its catalog.
+
+		Parameters are merged into the ones used to create or last update the
+		instance, keys that aren't set keep their current values.
+		`,
+		Example: `
+		# Change the plan of the instance mydb to gold
+		kf update-service mydb -p gold
+
+		# Update the configuration of the instance mydb
+		kf update-service mydb -c '{"ram_gb":8}'`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			instanceName := args[0]
+
+			if err := p.ValidateSpaceTargeted(); err != nil {
+				return err
+			}
+
+			paramBytes, err := utils.ParseJSONOrFile(configAsJSON)
+			if err != nil {
+				return err
+			}
+
+			paramsChanged := !reflect.DeepEqual(paramBytes, json.RawMessage("{}"))
+			if planName == "" && !paramsChanged {
+				return errors.New("nothing to update, specify a new plan with --plan or parameters with -c")
+			}
+
+			existingInstance, err := client.Get(ctx, p.Space, instanceName)
+			if err != nil {
+				return err
+			}
+			if !existingInstance.IsKfBrokered() {
+				return errors.New("Service instance is not backed by a service broker")
+			}
+
+			var newPlan *v1alpha1.ServicePlan
+			if planName != "" {
+				osb := existingInstance.Spec.OSB
+
+				catalog, err := marketplaceClient.Marketplace(ctx, p.Space)
+				if err != nil {
+					return err
+				}
+
+				planFilters := marketplace.ListPlanOptions{
+					PlanName:    planName,
+					ServiceName: osb.ClassName,
+					BrokerName:  osb.BrokerName,
+				}
+
+				var matchingPlans []marketplace.PlanLineage
+				if osb.Namespaced {
+					matchingPlans = catalog.ListNamespacedPlans(p.Space, planFilters)
+				} else {
+					matchingPlans = catalog.ListClusterPlans(planFilters)
+				}
+
+				if len(matchingPlans) != 1 {
+					return fmt.Errorf("no plan %s found for class %s for the service-broker %s", planName, osb.ClassName, osb.BrokerName)
+				}
+
+				newPlan = &matchingPlans[0].ServicePlan
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Updating service instance %q in space %q\n", instanceName, p.Space)
+
+			// Record the parameters before requesting the update so the
+			// reconciler sends them to the broker with this update.
+			updateRequests := existingInstance.Spec.UpdateRequests + 1
+			if paramsChanged {
+				if _, err := secretsClient.UpdateServiceInstanceParams(ctx, existingInstance.Namespace, existingInstance.Spec.ParametersFrom.Name, updateRequests, paramBytes); err != nil {
+					return err
+				}
+			}
+
+			if _, err := client.Transform(ctx, p.Space, instanceName, func(instance *v1alpha1.ServiceInstance) error {
+				if instance.Spec.UpdateRequests >= updateRequests {
+					return errors.New("the service instance was updated concurrently, try again")
+				}
+				if newPlan != nil {
+					instance.Spec.OSB.PlanName = newPlan.DisplayName
+					instance.Spec.OSB.PlanUID = newPlan.UID
+				}
+				instance.Spec.UpdateRequests = updateRequests
+				return nil
+			}); err != nil {
+				return fmt.Errorf("Failed to update service instance: %s", err)
+			}
+
+			return async.AwaitAndLog(cmd.ErrOrStderr(), "Waiting for the service broker to update the instance", func() (err error) {
+				_, err = client.WaitForConditionInstanceUpdatedTrue(context.Background(), p.Space, instanceName, 1*time.Second)
+				return
+			})
+		},
+	}
+
+	async.Add(cmd)
+
+	cmd.Flags().StringVarP(
+		&planName,
+		"plan",
+		"p",
+		"",
+		"Name of the plan to change the instance to.")
+
+	cmd.Flags().StringVarP(
+		&configAsJSON,
+		"parameters",
+		"c",
+		"{}",
+		"JSON object or path to a JSON file containing configuration parameters.")
+
+	return cmd
+}
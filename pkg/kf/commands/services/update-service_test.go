@@ -0,0 +1,230 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	servicescmd "github.com/google/kf/v2/pkg/kf/commands/services"
+	"github.com/google/kf/v2/pkg/kf/marketplace"
+	marketplacefake "github.com/google/kf/v2/pkg/kf/marketplace/fake"
+	secretsfake "github.com/google/kf/v2/pkg/kf/secrets/fake"
+	"github.com/google/kf/v2/pkg/kf/serviceinstances"
+	serviceinstancesfake "github.com/google/kf/v2/pkg/kf/serviceinstances/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewUpdateServiceCommand(t *testing.T) {
+	type fakes struct {
+		services    *serviceinstancesfake.FakeClient
+		secrets     *secretsfake.FakeClient
+		marketplace *marketplacefake.FakeClientInterface
+	}
+
+	const mockNs = "test-ns"
+
+	mockClusterBroker := &v1alpha1.ClusterServiceBroker{}
+	mockClusterBroker.Name = "cluster-broker"
+	mockClusterBroker.Status.Services = []v1alpha1.ServiceOffering{
+		{
+			DisplayName: "db-service",
+			UID:         "service-uid",
+			Plans: []v1alpha1.ServicePlan{
+				{DisplayName: "free", UID: "free-uid", PlanUpdatable: true},
+				{DisplayName: "gold", UID: "gold-uid", PlanUpdatable: true},
+			},
+		},
+	}
+
+	mockMarketplace := &marketplace.KfMarketplace{
+		Brokers: []v1alpha1.CommonServiceBroker{
+			mockClusterBroker,
+		},
+	}
+
+	brokeredInstance := func() *v1alpha1.ServiceInstance {
+		instance := &v1alpha1.ServiceInstance{}
+		instance.Name = "mydb"
+		instance.Namespace = mockNs
+		instance.Spec.OSB = &v1alpha1.OSBInstance{
+			BrokerName: mockClusterBroker.Name,
+			ClassName:  "db-service",
+			ClassUID:   "service-uid",
+			PlanName:   "free",
+			PlanUID:    "free-uid",
+		}
+		instance.Spec.ParametersFrom = corev1.LocalObjectReference{Name: "mydb-params"}
+		return instance
+	}
+
+	userProvidedInstance := &v1alpha1.ServiceInstance{}
+	userProvidedInstance.Spec.UPS = &v1alpha1.UPSInstance{}
+
+	// expectTransform checks the result of the mutator on a brokered instance.
+	expectTransform := func(t *testing.T, fakes fakes, wantPlanName, wantPlanUID string) {
+		fakes.services.EXPECT().
+			Transform(gomock.Any(), mockNs, "mydb", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, mutator serviceinstances.Mutator) (*v1alpha1.ServiceInstance, error) {
+				instance := brokeredInstance()
+				testutil.AssertNil(t, "mutator error", mutator(instance))
+				testutil.AssertEqual(t, "planName", wantPlanName, instance.Spec.OSB.PlanName)
+				testutil.AssertEqual(t, "planUID", wantPlanUID, instance.Spec.OSB.PlanUID)
+				testutil.AssertEqual(t, "updateRequests", 1, instance.Spec.UpdateRequests)
+				return instance, nil
+			})
+	}
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(*testing.T, fakes)
+		expectErr error
+	}{
+		// user errors
+		"bad number of args": {
+			expectErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"bad namespace": {
+			args:      []string{"mydb", "-p", "gold"},
+			expectErr: errors.New(config.EmptySpaceError),
+		},
+		"bad path": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-c=/some/bad/path"},
+			expectErr: errors.New("couldn't read file: open /some/bad/path: no such file or directory"),
+		},
+		"no changes": {
+			namespace: mockNs,
+			args:      []string{"mydb"},
+			expectErr: errors.New("nothing to update, specify a new plan with --plan or parameters with -c"),
+		},
+		"not brokered": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-p", "gold"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(userProvidedInstance, nil)
+			},
+			expectErr: errors.New("Service instance is not backed by a service broker"),
+		},
+		"missing plan": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-p", "platinum"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(brokeredInstance(), nil)
+				fakes.marketplace.EXPECT().Marketplace(gomock.Any(), mockNs).Return(mockMarketplace, nil)
+			},
+			expectErr: errors.New("no plan platinum found for class db-service for the service-broker cluster-broker"),
+		},
+
+		// server errors
+		"marketplace failure": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-p", "gold"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(brokeredInstance(), nil)
+				fakes.marketplace.EXPECT().Marketplace(gomock.Any(), mockNs).Return(nil, errors.New("marketplace-failure"))
+			},
+			expectErr: errors.New("marketplace-failure"),
+		},
+
+		"concurrent update": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-p", "gold"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(brokeredInstance(), nil)
+				fakes.marketplace.EXPECT().Marketplace(gomock.Any(), mockNs).Return(mockMarketplace, nil)
+				fakes.services.EXPECT().
+					Transform(gomock.Any(), mockNs, "mydb", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, mutator serviceinstances.Mutator) (*v1alpha1.ServiceInstance, error) {
+						instance := brokeredInstance()
+						instance.Spec.UpdateRequests = 1
+						return nil, mutator(instance)
+					})
+			},
+			expectErr: errors.New("Failed to update service instance: the service instance was updated concurrently, try again"),
+		},
+
+		// good results
+		"plan": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-p", "gold"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(brokeredInstance(), nil)
+				fakes.marketplace.EXPECT().Marketplace(gomock.Any(), mockNs).Return(mockMarketplace, nil)
+				expectTransform(t, fakes, "gold", "gold-uid")
+				fakes.services.EXPECT().WaitForConditionInstanceUpdatedTrue(gomock.Any(), mockNs, "mydb", gomock.Any())
+			},
+		},
+		"parameters": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-c", `{"ram_gb":8}`},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(brokeredInstance(), nil)
+				fakes.secrets.EXPECT().UpdateServiceInstanceParams(gomock.Any(), mockNs, "mydb-params", 1, json.RawMessage(`{"ram_gb":8}`))
+				expectTransform(t, fakes, "free", "free-uid")
+				fakes.services.EXPECT().WaitForConditionInstanceUpdatedTrue(gomock.Any(), mockNs, "mydb", gomock.Any())
+			},
+		},
+		"async": {
+			namespace: mockNs,
+			args:      []string{"mydb", "-p", "gold", "--async"},
+			setup: func(t *testing.T, fakes fakes) {
+				fakes.services.EXPECT().Get(gomock.Any(), mockNs, "mydb").Return(brokeredInstance(), nil)
+				fakes.marketplace.EXPECT().Marketplace(gomock.Any(), mockNs).Return(mockMarketplace, nil)
+				expectTransform(t, fakes, "gold", "gold-uid")
+				// expect WaitForConditionInstanceUpdatedTrue not to be called
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			sClient := serviceinstancesfake.NewFakeClient(ctrl)
+			mClient := marketplacefake.NewFakeClientInterface(ctrl)
+			secretClient := secretsfake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakes{
+					services:    sClient,
+					marketplace: mClient,
+					secrets:     secretClient,
+				})
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Space: tc.namespace,
+			}
+
+			cmd := servicescmd.NewUpdateServiceCommand(p, sClient, secretClient, mClient)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.expectErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.expectErr, actualErr)
+			}
+		})
+	}
+}
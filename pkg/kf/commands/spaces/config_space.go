@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/google/kf/v2/pkg/kf/spaces"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"knative.dev/pkg/kmp"
 	k8syaml "sigs.k8s.io/yaml"
 )
@@ -62,15 +64,23 @@ func NewConfigSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 		newUnsetBuildpackEnvMutator(),
 		newSetContainerRegistryMutator(),
 		newAppendDomainMutator(),
+		newAppendTCPDomainMutator(),
 		newSetDefaultDomainMutator(),
 		newRemoveDomainMutator(),
+		newSetDomainTLSSecretMutator(),
+		newSetDomainTLSIssuerMutator(),
+		newRemoveDomainTLSMutator(),
 		newBuildServiceAccountMutator(),
 		newSetAppIngressPolicyMutator(),
 		newSetAppEgressPolicyMutator(),
 		newSetBuildIngressPolicyMutator(),
 		newSetBuildEgressPolicyMutator(),
+		newSetInternalDomainMutator(),
+		newUnsetInternalDomainMutator(),
 		newSetNodeSelectorMutator(),
 		newUnsetNodeSelectorMutator(),
+		newSetQuotaMutator(),
+		newUnsetQuotaMutator(),
 	}
 
 	for _, sm := range subcommands {
@@ -84,6 +94,7 @@ func NewConfigSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 		newGetDomainsAccessor(),
 		newGetBuildServiceAccountAccessor(),
 		newGetNodeSelectorAccessor(),
+		newGetQuotaAccessor(),
 	}
 
 	for _, sa := range accessors {
@@ -96,6 +107,7 @@ func NewConfigSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 type spaceMutator struct {
 	Name        string
 	Short       string
+	Long        string
 	Args        []string
 	ExampleArgs []string
 	Init        func(args []string) (spaces.Mutator, error)
@@ -114,10 +126,15 @@ func (sm spaceMutator) exampleCommands() string {
 func (sm spaceMutator) toCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
 	var async utils.AsyncFlags
 
+	long := sm.Long
+	if long == "" {
+		long = sm.Short
+	}
+
 	cmd := &cobra.Command{
 		Use:               fmt.Sprintf("%s [SPACE_NAME] %s", sm.Name, strings.Join(sm.Args, " ")),
 		Short:             sm.Short,
-		Long:              sm.Short,
+		Long:              long,
 		Args:              cobra.RangeArgs(len(sm.Args), 1+len(sm.Args)),
 		Example:           sm.exampleCommands(),
 		ValidArgsFunction: completion.SpaceCompletionFn(p),
@@ -309,6 +326,41 @@ func newAppendDomainMutator() spaceMutator {
 	}
 }
 
+func newAppendTCPDomainMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "append-tcp-domain",
+		Short: "Append a domain for TCP Routes to a Space.",
+		Long: `Append a domain that routes TCP traffic by port.
+
+		RESERVABLE_PORTS is a comma separated list of ports and port ranges
+		that Routes on the domain can reserve. The domain's gateway must
+		listen on the ports.
+		`,
+		Args:        []string{"DOMAIN", "RESERVABLE_PORTS"},
+		ExampleArgs: []string{"tcp.mycompany.com", "1024-1033"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain, ports := args[0], args[1]
+
+			if _, err := v1alpha1.ParsePortRanges(ports); err != nil {
+				return nil, err
+			}
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.NetworkConfig.Domains = append(
+					space.Spec.NetworkConfig.Domains,
+					v1alpha1.SpaceDomain{
+						Domain:          domain,
+						Protocol:        v1alpha1.SpaceDomainProtocolTCP,
+						ReservablePorts: ports,
+					},
+				)
+
+				return nil
+			}, nil
+		},
+	}
+}
+
 func newSetAppIngressPolicyMutator() spaceMutator {
 	return spaceMutator{
 		Name:        "set-app-ingress-policy",
@@ -377,6 +429,46 @@ func newSetBuildEgressPolicyMutator() spaceMutator {
 	}
 }
 
+func newSetInternalDomainMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-internal-domain",
+		Short: "Set the domain Apps in the Space can be discovered at directly by other Apps.",
+		Long: `Set the domain Apps in the Space can be discovered at directly by other Apps.
+
+		Apps in the Space will be reachable at APP.DOMAIN from other Apps in
+		the Space without going through the ingress gateway. Each App instance
+		also gets a DNS name under the App's headless Service so instances of
+		clustered Apps can find their peers.
+
+		The mesh must have Istio DNS proxying turned on for sidecars to
+		resolve APP.DOMAIN.
+		`,
+		Args:        []string{"DOMAIN"},
+		ExampleArgs: []string{"apps.internal"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain := args[0]
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.NetworkConfig.InternalDomain = domain
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newUnsetInternalDomainMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "unset-internal-domain",
+		Short: "Stop Apps in the Space from being discoverable on an internal domain.",
+		Init: func(args []string) (spaces.Mutator, error) {
+			return func(space *v1alpha1.Space) error {
+				space.Spec.NetworkConfig.InternalDomain = ""
+				return nil
+			}, nil
+		},
+	}
+}
+
 func newSetDefaultDomainMutator() spaceMutator {
 	return spaceMutator{
 		Name:        "set-default-domain",
@@ -422,6 +514,78 @@ func newRemoveDomainMutator() spaceMutator {
 	}
 }
 
+func newSetDomainTLSSecretMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-domain-tls-secret",
+		Short: "Serve HTTPS for a domain using a certificate from a Secret.",
+		Long: `Serve HTTPS for a domain using a certificate from a Secret.
+
+		SECRET_NAME is a Secret of type kubernetes.io/tls in the Space.
+		The certificate should cover the domain and its wildcard.
+		`,
+		Args:        []string{"DOMAIN", "SECRET_NAME"},
+		ExampleArgs: []string{"myspace.mycompany.com", "myspace-tls"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain, secretName := args[0], args[1]
+
+			return setDomainTLS(domain, &v1alpha1.DomainTLS{SecretName: secretName}), nil
+		},
+	}
+}
+
+func newSetDomainTLSIssuerMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-domain-tls-issuer",
+		Short: "Serve HTTPS for a domain using certificates issued automatically.",
+		Long: fmt.Sprintf(`Serve HTTPS for a domain using certificates issued automatically.
+
+		ISSUER_NAME is the name of a cert-manager ClusterIssuer, or %q
+		to have Kf issue self-signed certificates itself.
+		`, v1alpha1.SelfSignedIssuerName),
+		Args:        []string{"DOMAIN", "ISSUER_NAME"},
+		ExampleArgs: []string{"myspace.mycompany.com", "letsencrypt"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain, issuerName := args[0], args[1]
+
+			return setDomainTLS(domain, &v1alpha1.DomainTLS{IssuerName: issuerName}), nil
+		},
+	}
+}
+
+func newRemoveDomainTLSMutator() spaceMutator {
+	return spaceMutator{
+		Name:        "remove-domain-tls",
+		Short:       "Stop serving HTTPS for a domain.",
+		Args:        []string{"DOMAIN"},
+		ExampleArgs: []string{"myspace.mycompany.com"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			return setDomainTLS(args[0], nil), nil
+		},
+	}
+}
+
+// setDomainTLS returns a mutator that sets the TLS configuration on every
+// entry for the domain in the Space.
+func setDomainTLS(domain string, tls *v1alpha1.DomainTLS) spaces.Mutator {
+	return func(space *v1alpha1.Space) error {
+		found := false
+		for i := range space.Spec.NetworkConfig.Domains {
+			if space.Spec.NetworkConfig.Domains[i].Domain != domain {
+				continue
+			}
+
+			found = true
+			space.Spec.NetworkConfig.Domains[i].TLS = tls.DeepCopy()
+		}
+
+		if !found {
+			return fmt.Errorf("domain %q is not configured on the Space", domain)
+		}
+
+		return nil
+	}
+}
+
 func newBuildServiceAccountMutator() spaceMutator {
 	return spaceMutator{
 		Name:        "set-build-service-account",
@@ -439,6 +603,121 @@ func newBuildServiceAccountMutator() spaceMutator {
 	}
 }
 
+func newSetQuotaMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-quota",
+		Short: "Limit the amount of a resource the Space can use.",
+		Long: `Limit the amount of a resource the Space can use.
+
+		RESOURCE is one of: memory, cpu, instance-memory, instances, apps,
+		routes, or service-instances. Memory and CPU limits are quantities
+		like 10Gi or 500m, the rest are whole numbers.
+
+		Requests that would go over a limit are rejected.
+		`,
+		Args:        []string{"RESOURCE", "LIMIT"},
+		ExampleArgs: []string{"memory", "10Gi"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			resourceName := args[0]
+			limit := args[1]
+
+			// Validate the arguments before modifying the Space.
+			if err := setSpaceQuota(&v1alpha1.SpaceSpecQuota{}, resourceName, &limit); err != nil {
+				return nil, err
+			}
+
+			return func(space *v1alpha1.Space) error {
+				return setSpaceQuota(&space.Spec.Quota, resourceName, &limit)
+			}, nil
+		},
+	}
+}
+
+func newUnsetQuotaMutator() spaceMutator {
+	return spaceMutator{
+		Name:        "unset-quota",
+		Short:       "Remove the limit on a resource in the Space.",
+		Args:        []string{"RESOURCE"},
+		ExampleArgs: []string{"memory"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			resourceName := args[0]
+
+			if err := setSpaceQuota(&v1alpha1.SpaceSpecQuota{}, resourceName, nil); err != nil {
+				return nil, err
+			}
+
+			return func(space *v1alpha1.Space) error {
+				return setSpaceQuota(&space.Spec.Quota, resourceName, nil)
+			}, nil
+		},
+	}
+}
+
+// quotaResourceNames holds the names of the resources the quota commands
+// accept.
+var quotaResourceNames = []string{
+	"memory",
+	"cpu",
+	"instance-memory",
+	"instances",
+	"apps",
+	"routes",
+	"service-instances",
+}
+
+// setSpaceQuota sets the limit of the named resource in the quota. A nil limit
+// removes it.
+func setSpaceQuota(quota *v1alpha1.SpaceSpecQuota, resourceName string, limit *string) error {
+	switch resourceName {
+	case "memory":
+		return setQuotaQuantity(&quota.Memory, limit)
+	case "cpu":
+		return setQuotaQuantity(&quota.CPU, limit)
+	case "instance-memory":
+		return setQuotaQuantity(&quota.InstanceMemory, limit)
+	case "instances":
+		return setQuotaCount(&quota.Instances, limit)
+	case "apps":
+		return setQuotaCount(&quota.Apps, limit)
+	case "routes":
+		return setQuotaCount(&quota.Routes, limit)
+	case "service-instances":
+		return setQuotaCount(&quota.ServiceInstances, limit)
+	default:
+		return fmt.Errorf("unknown quota resource %q, must be one of: %s", resourceName, strings.Join(quotaResourceNames, ", "))
+	}
+}
+
+func setQuotaQuantity(field **resource.Quantity, limit *string) error {
+	if limit == nil {
+		*field = nil
+		return nil
+	}
+
+	quantity, err := resource.ParseQuantity(*limit)
+	if err != nil {
+		return fmt.Errorf("invalid limit %q: %s", *limit, err)
+	}
+
+	*field = &quantity
+	return nil
+}
+
+func setQuotaCount(field **int64, limit *string) error {
+	if limit == nil {
+		*field = nil
+		return nil
+	}
+
+	count, err := strconv.ParseInt(*limit, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid limit %q: must be a whole number", *limit)
+	}
+
+	*field = &count
+	return nil
+}
+
 type spaceAccessor struct {
 	Name     string
 	Short    string
@@ -558,6 +837,54 @@ func newGetNodeSelectorAccessor() spaceAccessor {
 	}
 }
 
+// quotaUsage is the limit and usage of a single quota resource.
+type quotaUsage struct {
+	Limit string `json:"limit"`
+	Used  string `json:"used,omitempty"`
+}
+
+func newGetQuotaAccessor() spaceAccessor {
+	return spaceAccessor{
+		Name:  "get-quota",
+		Short: "Get the limits of the Space's quota and how much of each resource is used.",
+		Accessor: func(space *v1alpha1.Space) interface{} {
+			quota := space.Spec.Quota
+			used := space.Status.Quota.Used
+
+			formatQuantity := func(q *resource.Quantity) string {
+				if q == nil {
+					return "unlimited"
+				}
+				return q.String()
+			}
+
+			formatCount := func(c *int64) string {
+				if c == nil {
+					return "unlimited"
+				}
+				return strconv.FormatInt(*c, 10)
+			}
+
+			formatUsed := func(name corev1.ResourceName) string {
+				if q, ok := used[name]; ok {
+					return q.String()
+				}
+				return ""
+			}
+
+			return map[string]quotaUsage{
+				"memory":            {Limit: formatQuantity(quota.Memory), Used: formatUsed(v1alpha1.QuotaResourceMemory)},
+				"cpu":               {Limit: formatQuantity(quota.CPU), Used: formatUsed(v1alpha1.QuotaResourceCPU)},
+				"instance-memory":   {Limit: formatQuantity(quota.InstanceMemory)},
+				"instances":         {Limit: formatCount(quota.Instances), Used: formatUsed(v1alpha1.QuotaResourceInstances)},
+				"apps":              {Limit: formatCount(quota.Apps), Used: formatUsed(v1alpha1.QuotaResourceApps)},
+				"routes":            {Limit: formatCount(quota.Routes), Used: formatUsed(v1alpha1.QuotaResourceRoutes)},
+				"service-instances": {Limit: formatCount(quota.ServiceInstances), Used: formatUsed(v1alpha1.QuotaResourceServiceInstances)},
+			}
+		},
+	}
+}
+
 // DiffWrapper wraps a mutator and prints out the diff between the original object
 // and the one it returns if there's no error.
 func DiffWrapper(w io.Writer, mutator spaces.Mutator) spaces.Mutator {
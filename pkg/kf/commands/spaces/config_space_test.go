@@ -30,7 +30,10 @@ import (
 	"github.com/google/kf/v2/pkg/kf/spaces"
 	"github.com/google/kf/v2/pkg/kf/spaces/fake"
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/ptr"
 )
 
 func TestNewConfigSpaceCommand(t *testing.T) {
@@ -154,6 +157,72 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 			},
 		},
 
+		"append-tcp-domain valid": {
+			args: []string{"append-tcp-domain", space, "tcp.example.com", "1024-1033"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "len(domains)", 1, len(space.Spec.NetworkConfig.Domains))
+				testutil.AssertEqual(t, "domain", "tcp.example.com", space.Spec.NetworkConfig.Domains[0].Domain)
+				testutil.AssertEqual(t, "protocol", v1alpha1.SpaceDomainProtocolTCP, space.Spec.NetworkConfig.Domains[0].Protocol)
+				testutil.AssertEqual(t, "reservablePorts", "1024-1033", space.Spec.NetworkConfig.Domains[0].ReservablePorts)
+			},
+		},
+		"append-tcp-domain invalid ports": {
+			args:    []string{"append-tcp-domain", space, "tcp.example.com", "1033-1024"},
+			wantErr: errors.New(`invalid port range "1033-1024": start is after end`),
+		},
+
+		"set-domain-tls-secret valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					NetworkConfig: v1alpha1.SpaceSpecNetworkConfig{
+						Domains: []v1alpha1.SpaceDomain{
+							{Domain: "example.com"},
+							{Domain: "other-example.com"},
+						},
+					},
+				},
+			},
+			args: []string{"set-domain-tls-secret", space, "other-example.com", "my-cert"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "domains[0].tls", (*v1alpha1.DomainTLS)(nil), space.Spec.NetworkConfig.Domains[0].TLS)
+				testutil.AssertEqual(t, "domains[1].tls", &v1alpha1.DomainTLS{SecretName: "my-cert"}, space.Spec.NetworkConfig.Domains[1].TLS)
+			},
+		},
+		"set-domain-tls-secret missing domain": {
+			args:    []string{"set-domain-tls-secret", space, "example.com", "my-cert"},
+			wantErr: errors.New(`domain "example.com" is not configured on the Space`),
+		},
+		"set-domain-tls-issuer valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					NetworkConfig: v1alpha1.SpaceSpecNetworkConfig{
+						Domains: []v1alpha1.SpaceDomain{
+							{Domain: "example.com", TLS: &v1alpha1.DomainTLS{SecretName: "my-cert"}},
+						},
+					},
+				},
+			},
+			args: []string{"set-domain-tls-issuer", space, "example.com", "letsencrypt"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "domains[0].tls", &v1alpha1.DomainTLS{IssuerName: "letsencrypt"}, space.Spec.NetworkConfig.Domains[0].TLS)
+			},
+		},
+		"remove-domain-tls valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					NetworkConfig: v1alpha1.SpaceSpecNetworkConfig{
+						Domains: []v1alpha1.SpaceDomain{
+							{Domain: "example.com", TLS: &v1alpha1.DomainTLS{SecretName: "my-cert"}},
+						},
+					},
+				},
+			},
+			args: []string{"remove-domain-tls", space, "example.com"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "domains[0].tls", (*v1alpha1.DomainTLS)(nil), space.Spec.NetworkConfig.Domains[0].TLS)
+			},
+		},
+
 		"set-default-domain valid": {
 			space: v1alpha1.Space{
 				Spec: v1alpha1.SpaceSpec{
@@ -251,6 +320,26 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 				testutil.AssertEqual(t, "policy", "DenyAll", space.Spec.NetworkConfig.BuildNetworkPolicy.Egress)
 			},
 		},
+		"set-internal-domain valid": {
+			space: v1alpha1.Space{},
+			args:  []string{"set-internal-domain", space, "apps.internal"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "internalDomain", "apps.internal", space.Spec.NetworkConfig.InternalDomain)
+			},
+		},
+		"unset-internal-domain valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					NetworkConfig: v1alpha1.SpaceSpecNetworkConfig{
+						InternalDomain: "apps.internal",
+					},
+				},
+			},
+			args: []string{"unset-internal-domain", space},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "internalDomain", "", space.Spec.NetworkConfig.InternalDomain)
+			},
+		},
 		"set-nodeselector valid": {
 			space: v1alpha1.Space{
 				Spec: v1alpha1.SpaceSpec{
@@ -299,6 +388,42 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 				}, space.Spec.RuntimeConfig.NodeSelector)
 			},
 		},
+		"set-quota valid": {
+			args: []string{"set-quota", space, "memory", "10Gi"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "memory", "10Gi", space.Spec.Quota.Memory.String())
+			},
+		},
+		"set-quota count": {
+			args: []string{"set-quota", space, "routes", "5"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "routes", ptr.Int64(5), space.Spec.Quota.Routes)
+			},
+		},
+		"set-quota unknown resource": {
+			args:    []string{"set-quota", space, "disk", "5"},
+			wantErr: errors.New(`unknown quota resource "disk", must be one of: memory, cpu, instance-memory, instances, apps, routes, service-instances`),
+		},
+		"set-quota invalid count": {
+			args:    []string{"set-quota", space, "apps", "5Gi"},
+			wantErr: errors.New(`invalid limit "5Gi": must be a whole number`),
+		},
+		"unset-quota valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					Quota: v1alpha1.SpaceSpecQuota{
+						Apps:   ptr.Int64(5),
+						Routes: ptr.Int64(10),
+					},
+				},
+			},
+			args: []string{"unset-quota", space, "apps"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "quota", v1alpha1.SpaceSpecQuota{
+					Routes: ptr.Int64(10),
+				}, space.Spec.Quota)
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -338,6 +463,7 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 }
 
 func TestNewConfigSpaceCommand_accessors(t *testing.T) {
+	memoryQuota := resource.MustParse("10Gi")
 	space := v1alpha1.Space{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "space-name",
@@ -367,6 +493,22 @@ func TestNewConfigSpaceCommand_accessors(t *testing.T) {
 					{Domain: "other-example.com"},
 				},
 			},
+			Quota: v1alpha1.SpaceSpecQuota{
+				Memory: &memoryQuota,
+				Apps:   ptr.Int64(10),
+			},
+		},
+		Status: v1alpha1.SpaceStatus{
+			Quota: v1alpha1.SpaceStatusQuota{
+				Used: corev1.ResourceList{
+					v1alpha1.QuotaResourceMemory:           resource.MustParse("2Gi"),
+					v1alpha1.QuotaResourceCPU:              resource.MustParse("500m"),
+					v1alpha1.QuotaResourceInstances:        resource.MustParse("4"),
+					v1alpha1.QuotaResourceApps:             resource.MustParse("2"),
+					v1alpha1.QuotaResourceRoutes:           resource.MustParse("3"),
+					v1alpha1.QuotaResourceServiceInstances: resource.MustParse("0"),
+				},
+			},
 		},
 	}
 
@@ -425,6 +567,31 @@ func TestNewConfigSpaceCommand_accessors(t *testing.T) {
 			space: space,
 			wantOutput: `CPU: X86
 DISKTYPE: SSD
+`,
+		},
+		"get-quota valid": {
+			args:  []string{"get-quota", "space-name"},
+			space: space,
+			wantOutput: `apps:
+  limit: "10"
+  used: "2"
+cpu:
+  limit: unlimited
+  used: 500m
+instance-memory:
+  limit: unlimited
+instances:
+  limit: unlimited
+  used: "4"
+memory:
+  limit: 10Gi
+  used: 2Gi
+routes:
+  limit: unlimited
+  used: "3"
+service-instances:
+  limit: unlimited
+  used: "0"
 `,
 		},
 	}
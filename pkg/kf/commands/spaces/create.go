@@ -28,6 +28,7 @@ import (
 	"github.com/google/kf/v2/pkg/kf/spaces"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // NewCreateSpaceCommand allows users to create spaces.
@@ -36,6 +37,7 @@ func NewCreateSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 		containerRegistry   string
 		buildServiceAccount string
 		domains             []string
+		org                 string
 		runningEnvVars      map[string]string
 		stagingEnvVars      map[string]string
 	)
@@ -52,6 +54,9 @@ func NewCreateSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 
 		# Set running and staging environment variables for Apps and Builds.
 		kf create-space my-space --run-env=ENVIRONMENT=nonprod --stage-env=ENVIRONMENT=nonprod,JDK_VERSION=8
+
+		# Create a Space in an Org.
+		kf create-space my-space --org my-org
 		`,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
@@ -71,6 +76,14 @@ func NewCreateSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 
 			toCreate.Spec.RuntimeConfig.Env = envutil.MapToEnvVars(runningEnvVars)
 
+			// Default to the targeted Org like the Cloud Foundry CLI.
+			if org == "" {
+				org = p.Org
+			}
+			if org != "" {
+				toCreate.Spec.OrgRef = &corev1.LocalObjectReference{Name: org}
+			}
+
 			if _, err := client.Create(cmd.Context(), toCreate); err != nil {
 				return err
 			}
@@ -112,6 +125,13 @@ func NewCreateSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 		"Sets the valid domains for the Space. The first provided domain is the default.",
 	)
 
+	cmd.Flags().StringVar(
+		&org,
+		"org",
+		"",
+		"Org the Space belongs to. Defaults to the targeted Org.",
+	)
+
 	cmd.Flags().StringToStringVar(
 		&runningEnvVars,
 		"run-env",
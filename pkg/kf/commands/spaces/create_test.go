@@ -51,6 +51,7 @@ func TestNewCreateSpaceCommand(t *testing.T) {
 				"--run-env=A=B,C=D",
 				"--stage-env=E=F",
 				"--stage-env=G=H",
+				"--org=my-org",
 			},
 			setup: func(t *testing.T, fakeSpaces *fake.FakeClient) {
 				fakeSpaces.
@@ -65,6 +66,7 @@ func TestNewCreateSpaceCommand(t *testing.T) {
 						testutil.AssertEqual(t, "sets build environment variables", wantBuildEnv, space.Spec.BuildConfig.Env)
 						wantRunEnv := envutil.MapToEnvVars(map[string]string{"A": "B", "C": "D"})
 						testutil.AssertEqual(t, "sets runtime environment variables", wantRunEnv, space.Spec.RuntimeConfig.Env)
+						testutil.AssertEqual(t, "sets org", "my-org", space.Spec.OrgRef.Name)
 					})
 
 				fakeSpaces.EXPECT().WaitFor(gomock.Any(), "my-ns", 1*time.Second, gomock.Any()).Return(&v1alpha1.Space{}, nil)
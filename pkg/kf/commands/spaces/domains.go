@@ -17,7 +17,9 @@ package spaces
 import (
 	"fmt"
 	"io"
+	"time"
 
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
 	"github.com/google/kf/v2/pkg/kf/describe"
 	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
@@ -45,11 +47,24 @@ func NewDomainsCommand(p *config.KfParams, client spaces.Client) *cobra.Command
 			logging.FromContext(ctx).Infof("Listing domains in Space: %s", p.Space)
 
 			describe.TabbedWriter(cmd.OutOrStdout(), func(w io.Writer) {
-				fmt.Fprintln(w, "Domain\tGateway")
+				fmt.Fprintln(w, "Domain\tGateway\tProtocol\tReservable Ports\tCertificate Expiry")
 
 				// Space status has domains in a deterministic order.
 				for _, domain := range space.Status.NetworkConfig.Domains {
-					fmt.Fprintf(w, "%s\t%s\n", domain.Domain, domain.GatewayName)
+					protocol := domain.Protocol
+					if protocol == "" {
+						protocol = v1alpha1.SpaceDomainProtocolHTTP
+					}
+
+					fmt.Fprintf(
+						w,
+						"%s\t%s\t%s\t%s\t%s\n",
+						domain.Domain,
+						domain.GatewayName,
+						protocol,
+						domain.ReservablePorts,
+						certificateExpiry(domain, space.Status.NetworkConfig.DomainCertificates),
+					)
 				}
 			})
 
@@ -66,3 +81,19 @@ func NewDomainsCommand(p *config.KfParams, client spaces.Client) *cobra.Command
 
 	return cmd
 }
+
+// certificateExpiry returns a human readable expiry of the domain's
+// certificate.
+func certificateExpiry(domain v1alpha1.SpaceDomain, certs []v1alpha1.CertificateStatus) string {
+	if domain.TLS == nil {
+		return ""
+	}
+
+	for _, cert := range certs {
+		if cert.Host == domain.Domain && cert.NotAfter != nil {
+			return cert.NotAfter.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return "<pending>"
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
@@ -26,6 +27,7 @@ import (
 	configlogging "github.com/google/kf/v2/pkg/kf/commands/config/logging"
 	"github.com/google/kf/v2/pkg/kf/spaces/fake"
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestNewDomainsCommand(t *testing.T) {
@@ -48,6 +50,17 @@ func TestNewDomainsCommand(t *testing.T) {
 				space.Status.NetworkConfig.Domains = []v1alpha1.SpaceDomain{
 					{Domain: "test.example.com", GatewayName: "kf/external-gateway"},
 					{Domain: "kf.internal", GatewayName: "kf/internal-gateway"},
+					{Domain: "tcp.example.com", GatewayName: "kf/tcp-gateway", Protocol: v1alpha1.SpaceDomainProtocolTCP, ReservablePorts: "1024-1033"},
+					{Domain: "tls.example.com", GatewayName: "kf/external-gateway", TLS: &v1alpha1.DomainTLS{SecretName: "tls-cert"}},
+					{Domain: "pending.example.com", GatewayName: "kf/external-gateway", TLS: &v1alpha1.DomainTLS{IssuerName: "letsencrypt"}},
+				}
+				space.Status.NetworkConfig.DomainCertificates = []v1alpha1.CertificateStatus{
+					{
+						Host:       "tls.example.com",
+						SecretName: "tls-cert",
+						NotAfter:   &metav1.Time{Time: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)},
+					},
+					{Host: "pending.example.com", SecretName: "pending-example-com-tls"},
 				}
 
 				fakeSpaces.EXPECT().Get(gomock.Any(), "default").Return(space, nil)
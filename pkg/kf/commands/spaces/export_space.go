@@ -0,0 +1,104 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/google/kf/v2/pkg/client/kf/injection/client"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/spf13/cobra"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"sigs.k8s.io/yaml"
+)
+
+// NewExportSpaceCommand allows users to export a Space and its contents to an
+// archive.
+func NewExportSpaceCommand(p *config.KfParams) *cobra.Command {
+	var (
+		outputFile string
+		redact     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export-space SPACE",
+		Short: "Export a Space and its contents to an archive.",
+		Long: `
+		Exports a Space's configuration and the Apps, Routes, service
+		instances, service bindings, Jobs, AppNetworkPolicies, and
+		NetworkPolicies in it to an archive that can be imported with
+		import-space.
+
+		Apps built from uploaded source are exported with the container image
+		of their latest Build. Build history and logs aren't exported.
+
+		The archive contains the credentials of user-provided services unless
+		--redact-credentials is set.
+		`,
+		Example: `
+		# Write the archive to stdout
+		kf export-space my-space
+
+		# Write the archive to a file without credentials
+		kf export-space my-space -o my-space.yaml --redact-credentials
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			spaceName := args[0]
+
+			archive, err := newSpaceArchive(ctx, client.Get(ctx), kubeclient.Get(ctx), spaceName, redact)
+			if err != nil {
+				return err
+			}
+
+			contents, err := yaml.Marshal(archive)
+			if err != nil {
+				return fmt.Errorf("failed to create archive: %s", err)
+			}
+
+			if outputFile == "" {
+				_, err := cmd.OutOrStdout().Write(contents)
+				return err
+			}
+
+			if err := ioutil.WriteFile(outputFile, contents, 0600); err != nil {
+				return fmt.Errorf("failed to write archive: %s", err)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Space %q exported to %s\n", spaceName, outputFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&outputFile,
+		"output",
+		"o",
+		"",
+		"File to write the archive to. Defaults to stdout.",
+	)
+
+	cmd.Flags().BoolVar(
+		&redact,
+		"redact-credentials",
+		false,
+		"Leave the credentials of user-provided services out of the archive.",
+	)
+
+	return cmd
+}
@@ -0,0 +1,331 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfclientset "github.com/google/kf/v2/pkg/client/kf/clientset/versioned"
+	"github.com/google/kf/v2/pkg/client/kf/injection/client"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/spaces"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/kmeta"
+	"sigs.k8s.io/yaml"
+)
+
+// NewImportSpaceCommand allows users to recreate a Space from an archive.
+func NewImportSpaceCommand(p *config.KfParams) *cobra.Command {
+	var (
+		domainMappings []string
+		timeout        time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import-space ARCHIVE [SPACE]",
+		Short: "Recreate a Space and its contents from an archive.",
+		Long: `
+		Recreates the Space, Apps, Routes, service instances, service bindings,
+		Jobs, AppNetworkPolicies, and NetworkPolicies in an archive created
+		by export-space.
+
+		The Space is created with the name in the archive unless SPACE is
+		given. If the Space already exists its configuration is left as-is and
+		the archive's contents are added to it. Objects that already exist in
+		the Space are skipped, so an import that failed part way through can be
+		run again.
+
+		Domains can be replaced with --domain-mapping to move Apps to a
+		cluster with different domains. Brokered service instances are
+		provisioned again by their broker.
+		`,
+		Example: `
+		# Restore a deleted Space
+		kf import-space my-space.yaml
+
+		# Copy a Space to a cluster with a different domain
+		kf import-space my-space.yaml my-new-space --domain-mapping example.com=new.example.com
+		`,
+		Args:         cobra.RangeArgs(1, 2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			contents, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read archive: %s", err)
+			}
+
+			archive := &spaceArchive{}
+			if err := yaml.Unmarshal(contents, archive); err != nil {
+				return fmt.Errorf("failed to read archive: %s", err)
+			}
+
+			if archive.Version != spaceArchiveVersion {
+				return fmt.Errorf("unsupported archive version %q, expected %q", archive.Version, spaceArchiveVersion)
+			}
+
+			domains, err := parseDomainMappings(domainMappings)
+			if err != nil {
+				return err
+			}
+
+			var spaceName string
+			if len(args) > 1 {
+				spaceName = args[1]
+			}
+			archive.remap(spaceName, domains)
+
+			return importSpaceArchive(ctx, cmd.OutOrStdout(), client.Get(ctx), kubeclient.Get(ctx), archive, timeout)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(
+		&domainMappings,
+		"domain-mapping",
+		nil,
+		"Replace a domain in the archive, in the form OLD=NEW. Can be repeated.",
+	)
+
+	cmd.Flags().DurationVar(
+		&timeout,
+		"timeout",
+		5*time.Minute,
+		"How long to wait for the Space to become ready.",
+	)
+
+	return cmd
+}
+
+// importSpaceArchive creates the objects in the archive. Objects are created
+// after the objects they reference and are owned by the same objects as when
+// they were exported. Objects that already exist are skipped.
+func importSpaceArchive(
+	ctx context.Context,
+	w io.Writer,
+	kfClient kfclientset.Interface,
+	k8sClient kubernetes.Interface,
+	archive *spaceArchive,
+	timeout time.Duration,
+) error {
+	kf := kfClient.KfV1alpha1()
+	spaceName := archive.Space.Name
+
+	if _, err := kf.Spaces().Create(ctx, &archive.Space, metav1.CreateOptions{}); err != nil {
+		if !apierrs.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create Space: %s", err)
+		}
+		fmt.Fprintf(w, "Space %q already exists, importing into it\n", spaceName)
+	} else {
+		fmt.Fprintf(w, "Space %q created\n", spaceName)
+	}
+
+	if err := waitForSpaceReady(ctx, kfClient, spaceName, timeout); err != nil {
+		return err
+	}
+
+	secrets := make(map[string]corev1.Secret)
+	for _, secret := range archive.Secrets {
+		secrets[secret.Name] = secret
+	}
+
+	createParamsSecret := func(owner kmeta.OwnerRefable, name string) error {
+		secret, ok := secrets[name]
+		if !ok {
+			return nil
+		}
+
+		secret.Namespace = spaceName
+		secret.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(owner)}
+		_, err := k8sClient.CoreV1().Secrets(spaceName).Create(ctx, &secret, metav1.CreateOptions{})
+		if _, err := skipExisting(w, "Secret", name, err); err != nil {
+			return fmt.Errorf("failed to create Secret %q: %s", name, err)
+		}
+
+		return nil
+	}
+
+	imported := 0
+	for _, route := range archive.Routes {
+		route.Namespace = spaceName
+		_, err := kf.Routes(spaceName).Create(ctx, &route, metav1.CreateOptions{})
+		created, err := skipExisting(w, "Route", route.Name, err)
+		if err != nil {
+			return fmt.Errorf("failed to create Route %q: %s", route.Name, err)
+		}
+		if created {
+			imported++
+		}
+	}
+	fmt.Fprintf(w, "Imported %d Route(s)\n", imported)
+
+	imported = 0
+	apps := make(map[string]*v1alpha1.App)
+	for _, app := range archive.Apps {
+		app.Namespace = spaceName
+		actual, err := kf.Apps(spaceName).Create(ctx, &app, metav1.CreateOptions{})
+		created, err := skipExisting(w, "App", app.Name, err)
+		if err != nil {
+			return fmt.Errorf("failed to create App %q: %s", app.Name, err)
+		}
+		if created {
+			imported++
+		} else if actual, err = kf.Apps(spaceName).Get(ctx, app.Name, metav1.GetOptions{}); err != nil {
+			return fmt.Errorf("failed to get App %q: %s", app.Name, err)
+		}
+		apps[actual.Name] = actual
+	}
+	fmt.Fprintf(w, "Imported %d App(s)\n", imported)
+
+	imported = 0
+	for _, instance := range archive.ServiceInstances {
+		instance.Namespace = spaceName
+		actual, err := kf.ServiceInstances(spaceName).Create(ctx, &instance, metav1.CreateOptions{})
+		created, err := skipExisting(w, "Service instance", instance.Name, err)
+		if err != nil {
+			return fmt.Errorf("failed to create service instance %q: %s", instance.Name, err)
+		}
+		if created {
+			imported++
+		} else if actual, err = kf.ServiceInstances(spaceName).Get(ctx, instance.Name, metav1.GetOptions{}); err != nil {
+			return fmt.Errorf("failed to get service instance %q: %s", instance.Name, err)
+		}
+
+		// The Secret is still created for existing instances in case a
+		// previous import failed before creating it.
+		if err := createParamsSecret(actual, instance.Spec.ParametersFrom.Name); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(w, "Imported %d service instance(s)\n", imported)
+
+	imported = 0
+	for _, binding := range archive.ServiceInstanceBindings {
+		binding.Namespace = spaceName
+		actual, err := kf.ServiceInstanceBindings(spaceName).Create(ctx, &binding, metav1.CreateOptions{})
+		created, err := skipExisting(w, "Service binding", binding.Name, err)
+		if err != nil {
+			return fmt.Errorf("failed to create service binding %q: %s", binding.Name, err)
+		}
+		if created {
+			imported++
+		} else if actual, err = kf.ServiceInstanceBindings(spaceName).Get(ctx, binding.Name, metav1.GetOptions{}); err != nil {
+			return fmt.Errorf("failed to get service binding %q: %s", binding.Name, err)
+		}
+
+		if err := createParamsSecret(actual, binding.Spec.ParametersFrom.Name); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(w, "Imported %d service binding(s)\n", imported)
+
+	imported = 0
+	for _, taskSchedule := range archive.TaskSchedules {
+		taskSchedule.Namespace = spaceName
+		if app, ok := apps[taskSchedule.Spec.TaskTemplate.AppRef.Name]; ok {
+			taskSchedule.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(app)}
+		}
+
+		_, err := kf.TaskSchedules(spaceName).Create(ctx, &taskSchedule, metav1.CreateOptions{})
+		created, err := skipExisting(w, "Job", taskSchedule.Name, err)
+		if err != nil {
+			return fmt.Errorf("failed to create Job %q: %s", taskSchedule.Name, err)
+		}
+		if created {
+			imported++
+		}
+	}
+	fmt.Fprintf(w, "Imported %d Job(s)\n", imported)
+
+	imported = 0
+	for _, policy := range archive.AppNetworkPolicies {
+		policy.Namespace = spaceName
+		_, err := kf.AppNetworkPolicies(spaceName).Create(ctx, &policy, metav1.CreateOptions{})
+		created, err := skipExisting(w, "AppNetworkPolicy", policy.Name, err)
+		if err != nil {
+			return fmt.Errorf("failed to create AppNetworkPolicy %q: %s", policy.Name, err)
+		}
+		if created {
+			imported++
+		}
+	}
+	fmt.Fprintf(w, "Imported %d AppNetworkPolicy(s)\n", imported)
+
+	imported = 0
+	for _, policy := range archive.NetworkPolicies {
+		policy.Namespace = spaceName
+		_, err := k8sClient.NetworkingV1().NetworkPolicies(spaceName).Create(ctx, &policy, metav1.CreateOptions{})
+		created, err := skipExisting(w, "NetworkPolicy", policy.Name, err)
+		if err != nil {
+			return fmt.Errorf("failed to create NetworkPolicy %q: %s", policy.Name, err)
+		}
+		if created {
+			imported++
+		}
+	}
+	fmt.Fprintf(w, "Imported %d NetworkPolicy(s)\n", imported)
+
+	return nil
+}
+
+// skipExisting checks the error from creating an object. Objects that already
+// exist are left as they are so an import can be re-run after it fails part
+// way through. It returns true if the object was created.
+func skipExisting(w io.Writer, kind, name string, err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case apierrs.IsAlreadyExists(err):
+		fmt.Fprintf(w, "%s %q already exists, skipping\n", kind, name)
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// waitForSpaceReady waits until the Space's Namespace and the other objects
+// its contents depend on exist.
+func waitForSpaceReady(ctx context.Context, kfClient kfclientset.Interface, spaceName string, timeout time.Duration) error {
+	var space *v1alpha1.Space
+	err := wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		var err error
+		space, err = kfClient.KfV1alpha1().Spaces().Get(ctx, spaceName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return spaces.IsStatusFinal(space), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for Space to become ready: %s", err)
+	}
+
+	if !space.Status.IsReady() {
+		return fmt.Errorf("Space %q isn't ready: %s", spaceName, space.Status.GetCondition(v1alpha1.SpaceConditionReady).Message)
+	}
+
+	return nil
+}
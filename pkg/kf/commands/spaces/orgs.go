@@ -0,0 +1,158 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/client/kf/injection/client"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/internal/genericcli"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var orgResourceInfo = &genericcli.KubernetesType{
+	Group:    "kf.dev",
+	Version:  "v1alpha1",
+	Kind:     "Org",
+	Resource: "orgs",
+	NsScoped: false,
+	KfName:   "Org",
+}
+
+// NewCreateOrgCommand allows operators to create Orgs.
+func NewCreateOrgCommand(p *config.KfParams) *cobra.Command {
+	var (
+		domains    []string
+		managers   []string
+		auditors   []string
+		spaceQuota string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-org NAME",
+		Short: "Create an Org to group Spaces.",
+		Long: `
+		Creates an Org, a group of Spaces that share roles, domains, and a
+		default SpaceQuota.
+
+		Org managers and auditors are granted the SpaceManager and
+		SpaceAuditor roles in every Space in the Org. Org domains are
+		available in every Space in the Org after the Space's own domains.
+		The Org's SpaceQuota applies to Spaces that don't have their own.
+		`,
+		Example: `
+		# Create an Org with a shared domain.
+		kf create-org my-org --domain my-org.my-company.com
+
+		# Create an Org with a manager and a default SpaceQuota.
+		kf create-org my-org --manager alice@my-company.com --space-quota small
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			toCreate := &v1alpha1.Org{}
+			toCreate.Name = args[0]
+
+			for _, domain := range domains {
+				toCreate.Spec.Domains = append(toCreate.Spec.Domains, v1alpha1.SpaceDomain{Domain: domain})
+			}
+
+			toCreate.Spec.Managers = userSubjects(managers)
+			toCreate.Spec.Auditors = userSubjects(auditors)
+
+			if spaceQuota != "" {
+				toCreate.Spec.QuotaRef = &corev1.LocalObjectReference{Name: spaceQuota}
+			}
+
+			if _, err := client.Get(ctx).
+				KfV1alpha1().
+				Orgs().
+				Create(ctx, toCreate, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("failed to create Org: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Org %s created\n", toCreate.Name)
+
+			utils.SuggestNextAction(utils.NextAction{
+				Description: "Create a Space in the Org",
+				Commands: []string{
+					fmt.Sprintf("kf create-space SPACE --org %s", toCreate.Name),
+				},
+			})
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(
+		&domains,
+		"domain",
+		nil,
+		"Domain available to every Space in the Org.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&managers,
+		"manager",
+		nil,
+		"User granted the SpaceManager role in every Space in the Org.",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&auditors,
+		"auditor",
+		nil,
+		"User granted the SpaceAuditor role in every Space in the Org.",
+	)
+
+	cmd.Flags().StringVar(
+		&spaceQuota,
+		"space-quota",
+		"",
+		"SpaceQuota applied to Spaces in the Org that don't reference one.",
+	)
+
+	return cmd
+}
+
+// NewListOrgsCommand allows users to list Orgs.
+func NewListOrgsCommand(p *config.KfParams) *cobra.Command {
+	return genericcli.NewListCommand(
+		orgResourceInfo,
+		p,
+		genericcli.WithListCommandName("orgs"),
+		genericcli.WithListPluralFriendlyName("Orgs"),
+	)
+}
+
+func userSubjects(names []string) []rbacv1.Subject {
+	var subjects []rbacv1.Subject
+	for _, name := range names {
+		subjects = append(subjects, rbacv1.Subject{
+			Kind:     rbacv1.UserKind,
+			APIGroup: rbacv1.GroupName,
+			Name:     name,
+		})
+	}
+	return subjects
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	fakeclient "github.com/google/kf/v2/pkg/client/kf/injection/client/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	fakeinjection "github.com/google/kf/v2/pkg/kf/injection/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewCreateOrgCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args    []string
+		wantErr error
+		assert  func(t *testing.T, org *v1alpha1.Org)
+	}{
+		"missing name": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"defaults": {
+			args: []string{"my-org"},
+			assert: func(t *testing.T, org *v1alpha1.Org) {
+				testutil.AssertEqual(t, "spec", v1alpha1.OrgSpec{}, org.Spec)
+			},
+		},
+		"all fields": {
+			args: []string{
+				"my-org",
+				"--domain=example.com",
+				"--manager=alice@example.com",
+				"--auditor=bob@example.com",
+				"--space-quota=small",
+			},
+			assert: func(t *testing.T, org *v1alpha1.Org) {
+				testutil.AssertEqual(t, "domains", []v1alpha1.SpaceDomain{{Domain: "example.com"}}, org.Spec.Domains)
+				testutil.AssertEqual(t, "managers", []rbacv1.Subject{
+					{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "alice@example.com"},
+				}, org.Spec.Managers)
+				testutil.AssertEqual(t, "auditors", []rbacv1.Subject{
+					{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "bob@example.com"},
+				}, org.Spec.Auditors)
+				testutil.AssertEqual(t, "quotaRef", "small", org.Spec.QuotaRef.Name)
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+
+			var buffer bytes.Buffer
+			cmd := NewCreateOrgCommand(&config.KfParams{})
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tc.args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+			if tc.wantErr != nil {
+				testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+				return
+			}
+			testutil.AssertNil(t, "err", gotErr)
+
+			org, err := fakeclient.Get(ctx).
+				KfV1alpha1().
+				Orgs().
+				Get(ctx, "my-org", metav1.GetOptions{})
+			testutil.AssertNil(t, "get err", err)
+			tc.assert(t, org)
+		})
+	}
+}
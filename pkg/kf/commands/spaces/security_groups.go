@@ -0,0 +1,240 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/client/kf/injection/client"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/internal/genericcli"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/spaces"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// lifecycleRunning binds a SecurityGroup to the Apps in a Space.
+	lifecycleRunning = "running"
+	// lifecycleStaging binds a SecurityGroup to the Builds in a Space.
+	lifecycleStaging = "staging"
+)
+
+var securityGroupResourceInfo = &genericcli.KubernetesType{
+	Group:    "kf.dev",
+	Version:  "v1alpha1",
+	Kind:     "SecurityGroup",
+	Resource: "securitygroups",
+	NsScoped: false,
+	KfName:   "SecurityGroup",
+}
+
+// NewCreateSecurityGroupCommand allows operators to create SecurityGroups.
+func NewCreateSecurityGroupCommand(p *config.KfParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-security-group NAME PATH_TO_RULES_FILE",
+		Short: "Create a SecurityGroup from a file of egress rules.",
+		Long: `
+		Creates a SecurityGroup, a named set of egress rules that can be bound
+		to many Spaces with bind-security-group.
+
+		The rules file is a JSON or YAML list of rules in the same format as
+		a Cloud Foundry application security group. Each rule has a protocol
+		(tcp, udp, or all), a destination (an IP address, CIDR block, or IPv4
+		range), and optionally ports and a description:
+
+		  [
+		    {
+		      "protocol": "tcp",
+		      "destination": "10.0.11.0/24",
+		      "ports": "80,443",
+		      "description": "Allow HTTP and HTTPS to internal services."
+		    }
+		  ]
+		`,
+		Example:      `kf create-security-group internal-services rules.json`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			contents, err := ioutil.ReadFile(args[1])
+			if err != nil {
+				return fmt.Errorf("failed to read rules: %s", err)
+			}
+
+			toCreate := &v1alpha1.SecurityGroup{}
+			toCreate.Name = args[0]
+			if err := yaml.UnmarshalStrict(contents, &toCreate.Spec.Rules); err != nil {
+				return fmt.Errorf("failed to parse rules: %s", err)
+			}
+
+			toCreate.SetDefaults(ctx)
+			if err := toCreate.Validate(ctx); err != nil {
+				return err
+			}
+
+			if _, err := client.Get(ctx).
+				KfV1alpha1().
+				SecurityGroups().
+				Create(ctx, toCreate, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("failed to create SecurityGroup: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "SecurityGroup %s created\n", toCreate.Name)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// NewListSecurityGroupsCommand allows users to list SecurityGroups.
+func NewListSecurityGroupsCommand(p *config.KfParams) *cobra.Command {
+	return genericcli.NewListCommand(
+		securityGroupResourceInfo,
+		p,
+		genericcli.WithListCommandName("security-groups"),
+		genericcli.WithListPluralFriendlyName("SecurityGroups"),
+	)
+}
+
+// NewBindSecurityGroupCommand allows operators to bind a SecurityGroup to a
+// Space.
+func NewBindSecurityGroupCommand(p *config.KfParams, spacesClient spaces.Client) *cobra.Command {
+	return newSecurityGroupBindingCommand(p, spacesClient, true)
+}
+
+// NewUnbindSecurityGroupCommand allows operators to unbind a SecurityGroup
+// from a Space.
+func NewUnbindSecurityGroupCommand(p *config.KfParams, spacesClient spaces.Client) *cobra.Command {
+	return newSecurityGroupBindingCommand(p, spacesClient, false)
+}
+
+func newSecurityGroupBindingCommand(p *config.KfParams, spacesClient spaces.Client, bind bool) *cobra.Command {
+	var (
+		async     utils.AsyncFlags
+		lifecycle string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bind-security-group SECURITY_GROUP SPACE",
+		Short: "Allow Apps or Builds in a Space to send traffic matching a SecurityGroup.",
+		Long: `
+		Binds a SecurityGroup to the Apps (running) or Builds (staging) in a
+		Space. The SecurityGroup's rules are added to the Space's
+		NetworkPolicy as egress rules.
+
+		SecurityGroups only take effect if the Space denies egress by
+		default, for example after running:
+
+		  kf configure-space set-app-egress-policy SPACE DenyAll
+		`,
+		Example: `
+		# Allow Apps in my-space to reach internal services.
+		kf bind-security-group internal-services my-space
+
+		# Allow Builds in my-space to reach an internal package mirror.
+		kf bind-security-group package-mirror my-space --lifecycle staging
+		`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			groupName := args[0]
+			spaceName := args[1]
+
+			if bind {
+				if _, err := client.Get(ctx).
+					KfV1alpha1().
+					SecurityGroups().
+					Get(ctx, groupName, metav1.GetOptions{}); err != nil {
+					return fmt.Errorf("failed to get SecurityGroup: %s", err)
+				}
+			}
+
+			mutator := DiffWrapper(cmd.OutOrStdout(), func(space *v1alpha1.Space) error {
+				var refs *[]corev1.LocalObjectReference
+				switch lifecycle {
+				case lifecycleRunning:
+					refs = &space.Spec.NetworkConfig.AppSecurityGroups
+				case lifecycleStaging:
+					refs = &space.Spec.NetworkConfig.BuildSecurityGroups
+				default:
+					return fmt.Errorf("invalid lifecycle %q, must be %s or %s", lifecycle, lifecycleRunning, lifecycleStaging)
+				}
+
+				*refs = removeSecurityGroupRef(*refs, groupName)
+				if bind {
+					*refs = append(*refs, corev1.LocalObjectReference{Name: groupName})
+				}
+
+				return nil
+			})
+
+			if _, err := spacesClient.Transform(ctx, spaceName, mutator); err != nil {
+				return err
+			}
+
+			return async.AwaitAndLog(cmd.OutOrStdout(), "configuring Space", func() error {
+				_, err := spacesClient.WaitForConditionReadyTrue(context.Background(), spaceName, 1*time.Second)
+				return err
+			})
+		},
+	}
+
+	if !bind {
+		cmd.Use = "unbind-security-group SECURITY_GROUP SPACE"
+		cmd.Short = "Remove a SecurityGroup from the Apps or Builds in a Space."
+		cmd.Long = "Unbinds a SecurityGroup from the Apps (running) or Builds (staging) in a Space."
+		cmd.Example = `kf unbind-security-group internal-services my-space`
+	}
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 1 {
+			return completion.SpaceCompletionFn(p)(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cmd.Flags().StringVar(
+		&lifecycle,
+		"lifecycle",
+		lifecycleRunning,
+		"Workloads the SecurityGroup applies to, running for Apps or staging for Builds.",
+	)
+
+	async.Add(cmd)
+
+	return cmd
+}
+
+func removeSecurityGroupRef(refs []corev1.LocalObjectReference, name string) []corev1.LocalObjectReference {
+	var out []corev1.LocalObjectReference
+	for _, ref := range refs {
+		if ref.Name != name {
+			out = append(out, ref)
+		}
+	}
+	return out
+}
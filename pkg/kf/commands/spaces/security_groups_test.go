@@ -0,0 +1,188 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	fakeclient "github.com/google/kf/v2/pkg/client/kf/injection/client/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	fakeinjection "github.com/google/kf/v2/pkg/kf/injection/fake"
+	"github.com/google/kf/v2/pkg/kf/spaces"
+	"github.com/google/kf/v2/pkg/kf/spaces/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewCreateSecurityGroupCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		rules   string
+		wantErr error
+		want    []v1alpha1.SecurityGroupRule
+	}{
+		"json rules": {
+			rules: `[{"protocol": "TCP", "destination": "10.0.0.0/8", "ports": "80, 443", "description": "web"}]`,
+			want: []v1alpha1.SecurityGroupRule{
+				{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "80,443", Description: "web"},
+			},
+		},
+		"yaml rules": {
+			rules: "- protocol: all\n  destination: 10.0.0.1-10.0.0.9\n",
+			want: []v1alpha1.SecurityGroupRule{
+				{Protocol: "all", Destination: "10.0.0.1-10.0.0.9"},
+			},
+		},
+		"unknown field": {
+			rules:   `[{"protocol": "tcp", "destination": "10.0.0.1", "port": "80"}]`,
+			wantErr: errors.New(`failed to parse rules: error unmarshaling JSON: while decoding JSON: json: unknown field "port"`),
+		},
+		"invalid rule": {
+			rules:   `[{"protocol": "icmp", "destination": "10.0.0.1"}]`,
+			wantErr: errors.New(`invalid value: icmp, should be one of: all, tcp, udp: spec.rules[0].protocol`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+
+			rulesPath := filepath.Join(t.TempDir(), "rules.json")
+			testutil.AssertNil(t, "write err", ioutil.WriteFile(rulesPath, []byte(tc.rules), 0600))
+
+			var buffer bytes.Buffer
+			cmd := NewCreateSecurityGroupCommand(&config.KfParams{})
+			cmd.SetContext(ctx)
+			cmd.SetArgs([]string{"my-group", rulesPath})
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+			if tc.wantErr != nil {
+				testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+				return
+			}
+			testutil.AssertNil(t, "err", gotErr)
+
+			group, err := fakeclient.Get(ctx).
+				KfV1alpha1().
+				SecurityGroups().
+				Get(ctx, "my-group", metav1.GetOptions{})
+			testutil.AssertNil(t, "get err", err)
+			testutil.AssertEqual(t, "rules", tc.want, group.Spec.Rules)
+		})
+	}
+}
+
+func TestSecurityGroupBindingCommands(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		bind          bool
+		args          []string
+		groups        []string
+		initial       v1alpha1.SpaceSpecNetworkConfig
+		wantErr       error
+		wantApps      []corev1.LocalObjectReference
+		wantBuilds    []corev1.LocalObjectReference
+		skipTransform bool
+	}{
+		"bind missing group": {
+			bind:          true,
+			args:          []string{"dns", "my-space"},
+			wantErr:       errors.New(`failed to get SecurityGroup: securitygroups.kf.dev "dns" not found`),
+			skipTransform: true,
+		},
+		"bind running": {
+			bind:     true,
+			args:     []string{"dns", "my-space"},
+			groups:   []string{"dns"},
+			wantApps: []corev1.LocalObjectReference{{Name: "dns"}},
+		},
+		"bind staging is idempotent": {
+			bind:   true,
+			args:   []string{"dns", "my-space", "--lifecycle", "staging"},
+			groups: []string{"dns"},
+			initial: v1alpha1.SpaceSpecNetworkConfig{
+				BuildSecurityGroups: []corev1.LocalObjectReference{{Name: "dns"}},
+			},
+			wantBuilds: []corev1.LocalObjectReference{{Name: "dns"}},
+		},
+		"bind invalid lifecycle": {
+			bind:    true,
+			args:    []string{"dns", "my-space", "--lifecycle", "launching"},
+			groups:  []string{"dns"},
+			wantErr: errors.New(`invalid lifecycle "launching", must be running or staging`),
+		},
+		"unbind running": {
+			args: []string{"dns", "my-space"},
+			initial: v1alpha1.SpaceSpecNetworkConfig{
+				AppSecurityGroups: []corev1.LocalObjectReference{{Name: "dns"}, {Name: "web"}},
+			},
+			wantApps: []corev1.LocalObjectReference{{Name: "web"}},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+			for _, name := range tc.groups {
+				group := &v1alpha1.SecurityGroup{}
+				group.Name = name
+				fakeclient.Get(ctx).KfV1alpha1().SecurityGroups().Create(ctx, group, metav1.CreateOptions{})
+			}
+
+			ctrl := gomock.NewController(t)
+			fakeSpaces := fake.NewFakeClient(ctrl)
+
+			space := &v1alpha1.Space{}
+			space.Spec.NetworkConfig = tc.initial
+			if !tc.skipTransform {
+				fakeSpaces.EXPECT().
+					Transform(gomock.Any(), "my-space", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, mutator spaces.Mutator) (*v1alpha1.Space, error) {
+						return space, mutator(space)
+					})
+			}
+
+			cmd := NewUnbindSecurityGroupCommand(&config.KfParams{}, fakeSpaces)
+			if tc.bind {
+				cmd = NewBindSecurityGroupCommand(&config.KfParams{}, fakeSpaces)
+			}
+
+			var buffer bytes.Buffer
+			cmd.SetContext(ctx)
+			cmd.SetArgs(append(tc.args, "--async"))
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			if tc.wantErr != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "app groups", tc.wantApps, space.Spec.NetworkConfig.AppSecurityGroups)
+			testutil.AssertEqual(t, "build groups", tc.wantBuilds, space.Spec.NetworkConfig.BuildSecurityGroups)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfclientset "github.com/google/kf/v2/pkg/client/kf/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// spaceArchiveVersion is the version of the archive format written by
+	// export-space.
+	spaceArchiveVersion = "v1"
+
+	// redactedParams replaces the credentials of user-provided services in
+	// redacted archives.
+	redactedParams = "{}"
+)
+
+// spaceArchive holds the configuration of a Space and the Kf objects in it so
+// they can be recreated in another Space or cluster.
+type spaceArchive struct {
+	// Version is the version of the archive format.
+	Version string `json:"version"`
+
+	Space                   v1alpha1.Space                    `json:"space"`
+	Apps                    []v1alpha1.App                    `json:"apps,omitempty"`
+	Routes                  []v1alpha1.Route                  `json:"routes,omitempty"`
+	ServiceInstances        []v1alpha1.ServiceInstance        `json:"serviceInstances,omitempty"`
+	ServiceInstanceBindings []v1alpha1.ServiceInstanceBinding `json:"serviceInstanceBindings,omitempty"`
+	TaskSchedules           []v1alpha1.TaskSchedule           `json:"taskSchedules,omitempty"`
+	AppNetworkPolicies      []v1alpha1.AppNetworkPolicy       `json:"appNetworkPolicies,omitempty"`
+
+	// Secrets holds the parameters of the ServiceInstances and
+	// ServiceInstanceBindings.
+	Secrets []corev1.Secret `json:"secrets,omitempty"`
+
+	// NetworkPolicies holds the NetworkPolicies in the Space that aren't
+	// managed by Kf.
+	NetworkPolicies []networkingv1.NetworkPolicy `json:"networkPolicies,omitempty"`
+}
+
+// exportMeta returns the parts of an object's metadata that are kept in
+// archives.
+func exportMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        meta.Name,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
+
+// newSpaceArchive reads the Space and its objects into a spaceArchive. If
+// redact is set the credentials of user-provided services are left out.
+func newSpaceArchive(
+	ctx context.Context,
+	kfClient kfclientset.Interface,
+	k8sClient kubernetes.Interface,
+	spaceName string,
+	redact bool,
+) (*spaceArchive, error) {
+	kf := kfClient.KfV1alpha1()
+
+	space, err := kf.Spaces().Get(ctx, spaceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Space: %s", err)
+	}
+
+	archive := &spaceArchive{
+		Version: spaceArchiveVersion,
+		Space: v1alpha1.Space{
+			ObjectMeta: exportMeta(space.ObjectMeta),
+			Spec:       space.Spec,
+		},
+	}
+
+	apps, err := kf.Apps(spaceName).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Apps: %s", err)
+	}
+	for _, app := range apps.Items {
+		exported := v1alpha1.App{
+			ObjectMeta: exportMeta(app.ObjectMeta),
+			Spec:       app.Spec,
+		}
+
+		// SourcePackages aren't exported so Apps built from uploaded source
+		// use the image of their latest Build instead.
+		if build := exported.Spec.Build.Spec; build != nil && build.SourcePackage.Name != "" && app.Status.Image != "" {
+			exported.Spec.Build.Spec = nil
+			exported.Spec.Build.Image = &app.Status.Image
+		}
+
+		archive.Apps = append(archive.Apps, exported)
+	}
+
+	routes, err := kf.Routes(spaceName).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Routes: %s", err)
+	}
+	for _, route := range routes.Items {
+		archive.Routes = append(archive.Routes, v1alpha1.Route{
+			ObjectMeta: exportMeta(route.ObjectMeta),
+			Spec:       route.Spec,
+		})
+	}
+
+	paramsSecrets := sets.NewString()
+	upsSecrets := sets.NewString()
+
+	instances, err := kf.ServiceInstances(spaceName).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ServiceInstances: %s", err)
+	}
+	for _, instance := range instances.Items {
+		// Imported instances are provisioned with their merged parameters,
+		// so there are no updates left to send.
+		spec := instance.Spec
+		spec.UpdateRequests = 0
+
+		archive.ServiceInstances = append(archive.ServiceInstances, v1alpha1.ServiceInstance{
+			ObjectMeta: exportMeta(instance.ObjectMeta),
+			Spec:       spec,
+		})
+
+		if secretName := instance.Spec.ParametersFrom.Name; secretName != "" {
+			paramsSecrets.Insert(secretName)
+			if instance.IsUserProvided() {
+				upsSecrets.Insert(secretName)
+			}
+		}
+	}
+
+	bindings, err := kf.ServiceInstanceBindings(spaceName).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ServiceInstanceBindings: %s", err)
+	}
+	for _, binding := range bindings.Items {
+		archive.ServiceInstanceBindings = append(archive.ServiceInstanceBindings, v1alpha1.ServiceInstanceBinding{
+			ObjectMeta: exportMeta(binding.ObjectMeta),
+			Spec:       binding.Spec,
+		})
+
+		if secretName := binding.Spec.ParametersFrom.Name; secretName != "" {
+			paramsSecrets.Insert(secretName)
+		}
+	}
+
+	for _, secretName := range paramsSecrets.List() {
+		secret, err := k8sClient.CoreV1().Secrets(spaceName).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Secret %q: %s", secretName, err)
+		}
+
+		exported := corev1.Secret{
+			ObjectMeta: exportMeta(secret.ObjectMeta),
+			Type:       secret.Type,
+			Data:       secret.Data,
+		}
+		delete(exported.Annotations, v1alpha1.ServiceInstanceUpdateRequestsAnnotation)
+		delete(exported.Data, v1alpha1.ServiceInstanceUpdateParamsSecretKey)
+
+		if redact && upsSecrets.Has(secretName) {
+			exported.Data = map[string][]byte{
+				v1alpha1.ServiceInstanceParamsSecretKey: []byte(redactedParams),
+			}
+		}
+
+		archive.Secrets = append(archive.Secrets, exported)
+	}
+
+	taskSchedules, err := kf.TaskSchedules(spaceName).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TaskSchedules: %s", err)
+	}
+	for _, taskSchedule := range taskSchedules.Items {
+		archive.TaskSchedules = append(archive.TaskSchedules, v1alpha1.TaskSchedule{
+			ObjectMeta: exportMeta(taskSchedule.ObjectMeta),
+			Spec:       taskSchedule.Spec,
+		})
+	}
+
+	appNetworkPolicies, err := kf.AppNetworkPolicies(spaceName).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list AppNetworkPolicies: %s", err)
+	}
+	for _, policy := range appNetworkPolicies.Items {
+		archive.AppNetworkPolicies = append(archive.AppNetworkPolicies, v1alpha1.AppNetworkPolicy{
+			ObjectMeta: exportMeta(policy.ObjectMeta),
+			Spec:       policy.Spec,
+		})
+	}
+
+	networkPolicies, err := k8sClient.NetworkingV1().NetworkPolicies(spaceName).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NetworkPolicies: %s", err)
+	}
+	for _, policy := range networkPolicies.Items {
+		// Policies owned by the Space are recreated from its network config.
+		if len(policy.OwnerReferences) > 0 {
+			continue
+		}
+
+		archive.NetworkPolicies = append(archive.NetworkPolicies, networkingv1.NetworkPolicy{
+			ObjectMeta: exportMeta(policy.ObjectMeta),
+			Spec:       policy.Spec,
+		})
+	}
+
+	return archive, nil
+}
+
+// parseDomainMappings parses domain mappings in the form OLD=NEW.
+func parseDomainMappings(mappings []string) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, mapping := range mappings {
+		parts := strings.SplitN(mapping, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid domain mapping %q, expected OLD=NEW", mapping)
+		}
+
+		out[parts[0]] = parts[1]
+	}
+
+	return out, nil
+}
+
+// remap moves the archive to the given Space and replaces the domains in the
+// mapping. Routes and route service bindings are renamed to match their new
+// domains because their names are derived from them.
+func (archive *spaceArchive) remap(spaceName string, domains map[string]string) {
+	remapDomain := func(domain *string) {
+		if newDomain, ok := domains[*domain]; ok {
+			*domain = newDomain
+		}
+	}
+
+	if spaceName != "" {
+		oldSpaceName := archive.Space.Name
+		archive.Space.Name = spaceName
+
+		for i := range archive.AppNetworkPolicies {
+			if source := &archive.AppNetworkPolicies[i].Spec.Source; source.Space == oldSpaceName {
+				source.Space = spaceName
+			}
+		}
+	}
+
+	for i := range archive.Space.Spec.NetworkConfig.Domains {
+		remapDomain(&archive.Space.Spec.NetworkConfig.Domains[i].Domain)
+	}
+
+	for i := range archive.Apps {
+		for j := range archive.Apps[i].Spec.Routes {
+			remapDomain(&archive.Apps[i].Spec.Routes[j].Domain)
+		}
+	}
+
+	for i := range archive.Routes {
+		remapDomain(&archive.Routes[i].Spec.Domain)
+		archive.Routes[i].Name = v1alpha1.GenerateRouteNameFromFields(archive.Routes[i].Spec.RouteSpecFields)
+	}
+
+	secretNames := make(map[string]string)
+	for i := range archive.ServiceInstanceBindings {
+		binding := &archive.ServiceInstanceBindings[i]
+		route := binding.Spec.Route
+		if route == nil {
+			continue
+		}
+
+		remapDomain(&route.Domain)
+		instanceName := binding.Spec.InstanceRef.Name
+		binding.Name = v1alpha1.MakeRouteServiceBindingName(route.Hostname, route.Domain, route.Path, instanceName)
+		if oldSecretName := binding.Spec.ParametersFrom.Name; oldSecretName != "" {
+			newSecretName := v1alpha1.MakeRouteServiceBindingParamsSecretName(route.Hostname, route.Domain, route.Path, instanceName)
+			secretNames[oldSecretName] = newSecretName
+			binding.Spec.ParametersFrom.Name = newSecretName
+		}
+	}
+
+	for i := range archive.Secrets {
+		if newName, ok := secretNames[archive.Secrets[i].Name]; ok {
+			archive.Secrets[i].Name = newName
+		}
+	}
+}
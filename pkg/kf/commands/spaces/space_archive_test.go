@@ -0,0 +1,341 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	fakeclient "github.com/google/kf/v2/pkg/client/kf/injection/client/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	fakeinjection "github.com/google/kf/v2/pkg/kf/injection/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	kubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+const testSpaceName = "my-space"
+
+// readyStatus is the status of a Space that's ready to use.
+var readyStatus = v1alpha1.SpaceStatus{
+	Status: duckv1beta1.Status{
+		Conditions: duckv1beta1.Conditions{{
+			Type:   apis.ConditionReady,
+			Status: corev1.ConditionTrue,
+		}},
+	},
+}
+
+// seedSpace creates a Space with one of each kind of exported object.
+func seedSpace(ctx context.Context, t *testing.T) {
+	t.Helper()
+
+	kf := fakeclient.Get(ctx).KfV1alpha1()
+	k8s := kubeclient.Get(ctx)
+	ns := testSpaceName
+
+	space := &v1alpha1.Space{ObjectMeta: metav1.ObjectMeta{Name: ns, UID: "space-uid"}}
+	space.Spec.NetworkConfig.Domains = []v1alpha1.SpaceDomain{{Domain: "example.com"}}
+	space.Status = readyStatus
+
+	app := &v1alpha1.App{ObjectMeta: metav1.ObjectMeta{Name: "my-app", Namespace: ns, ResourceVersion: "12"}}
+	app.Spec.Build.Spec = &v1alpha1.BuildSpec{SourcePackage: corev1.LocalObjectReference{Name: "my-app-1"}}
+	app.Spec.Routes = []v1alpha1.RouteWeightBinding{{
+		RouteSpecFields: v1alpha1.RouteSpecFields{Hostname: "my-app", Domain: "example.com"},
+	}}
+	app.Status.Image = "gcr.io/my-app@sha256:123"
+
+	route := &v1alpha1.Route{ObjectMeta: metav1.ObjectMeta{Name: "my-route", Namespace: ns}}
+	route.Spec.Hostname = "my-app"
+	route.Spec.Domain = "example.com"
+
+	instance := &v1alpha1.ServiceInstance{ObjectMeta: metav1.ObjectMeta{Name: "my-db", Namespace: ns}}
+	instance.Spec.UPS = &v1alpha1.UPSInstance{}
+	instance.Spec.ParametersFrom.Name = "my-db-params"
+	instance.Spec.UpdateRequests = 2
+
+	binding := &v1alpha1.ServiceInstanceBinding{ObjectMeta: metav1.ObjectMeta{Name: "my-binding", Namespace: ns}}
+	binding.Spec.App = &v1alpha1.AppRef{Name: "my-app"}
+	binding.Spec.InstanceRef.Name = "my-db"
+
+	routeBinding := &v1alpha1.ServiceInstanceBinding{ObjectMeta: metav1.ObjectMeta{
+		Name:      v1alpha1.MakeRouteServiceBindingName("my-app", "example.com", "", "my-route-service"),
+		Namespace: ns,
+	}}
+	routeBinding.Spec.Route = &v1alpha1.RouteRef{Hostname: "my-app", Domain: "example.com"}
+	routeBinding.Spec.InstanceRef.Name = "my-route-service"
+
+	appNetworkPolicy := &v1alpha1.AppNetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "my-policy", Namespace: ns}}
+	appNetworkPolicy.Spec.Source = v1alpha1.AppNetworkPolicySource{Space: ns, App: "my-frontend"}
+	appNetworkPolicy.Spec.DestinationApp = "my-app"
+
+	taskSchedule := &v1alpha1.TaskSchedule{ObjectMeta: metav1.ObjectMeta{Name: "my-job", Namespace: ns}}
+	taskSchedule.Spec.Schedule = "* * * * *"
+	taskSchedule.Spec.TaskTemplate.AppRef.Name = "my-app"
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-db-params",
+			Namespace:   ns,
+			Annotations: map[string]string{v1alpha1.ServiceInstanceUpdateRequestsAnnotation: "2"},
+		},
+		Data: map[string][]byte{
+			v1alpha1.ServiceInstanceParamsSecretKey:       []byte(`{"password":"hunter2"}`),
+			v1alpha1.ServiceInstanceUpdateParamsSecretKey: []byte(`{"password":"hunter2"}`),
+		},
+	}
+
+	userPolicy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "allow-monitoring", Namespace: ns}}
+	kfPolicy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+		Name:            "app-policy",
+		Namespace:       ns,
+		OwnerReferences: []metav1.OwnerReference{{Name: ns}},
+	}}
+
+	for _, err := range []error{
+		createErr(kf.Spaces().Create(ctx, space, metav1.CreateOptions{})),
+		createErr(kf.Apps(ns).Create(ctx, app, metav1.CreateOptions{})),
+		createErr(kf.Routes(ns).Create(ctx, route, metav1.CreateOptions{})),
+		createErr(kf.ServiceInstances(ns).Create(ctx, instance, metav1.CreateOptions{})),
+		createErr(kf.ServiceInstanceBindings(ns).Create(ctx, binding, metav1.CreateOptions{})),
+		createErr(kf.ServiceInstanceBindings(ns).Create(ctx, routeBinding, metav1.CreateOptions{})),
+		createErr(kf.AppNetworkPolicies(ns).Create(ctx, appNetworkPolicy, metav1.CreateOptions{})),
+		createErr(kf.TaskSchedules(ns).Create(ctx, taskSchedule, metav1.CreateOptions{})),
+		createErr(k8s.CoreV1().Secrets(ns).Create(ctx, secret, metav1.CreateOptions{})),
+		createErr(k8s.NetworkingV1().NetworkPolicies(ns).Create(ctx, userPolicy, metav1.CreateOptions{})),
+		createErr(k8s.NetworkingV1().NetworkPolicies(ns).Create(ctx, kfPolicy, metav1.CreateOptions{})),
+	} {
+		testutil.AssertNil(t, "create err", err)
+	}
+}
+
+func createErr(_ interface{}, err error) error {
+	return err
+}
+
+// exportSpace runs export-space and returns the archive.
+func exportSpace(t *testing.T, args ...string) *spaceArchive {
+	t.Helper()
+
+	ctx := fakeinjection.WithInjection(context.Background(), t)
+	seedSpace(ctx, t)
+
+	buf := new(bytes.Buffer)
+	cmd := NewExportSpaceCommand(&config.KfParams{})
+	cmd.SetContext(ctx)
+	cmd.SetOutput(buf)
+	cmd.SetArgs(append([]string{testSpaceName}, args...))
+	testutil.AssertNil(t, "export err", cmd.Execute())
+
+	archive := &spaceArchive{}
+	testutil.AssertNil(t, "unmarshal err", yaml.Unmarshal(buf.Bytes(), archive))
+	return archive
+}
+
+func TestExportSpace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exports contents", func(t *testing.T) {
+		archive := exportSpace(t)
+
+		testutil.AssertEqual(t, "version", spaceArchiveVersion, archive.Version)
+		testutil.AssertEqual(t, "space name", testSpaceName, archive.Space.Name)
+		testutil.AssertEqual(t, "space UID", "", string(archive.Space.UID))
+		testutil.AssertEqual(t, "app count", 1, len(archive.Apps))
+		testutil.AssertEqual(t, "app resource version", "", archive.Apps[0].ResourceVersion)
+		testutil.AssertEqual(t, "app status", v1alpha1.AppStatus{}, archive.Apps[0].Status)
+		testutil.AssertEqual(t, "app build", v1alpha1.AppSpecBuild{Image: ptr.String("gcr.io/my-app@sha256:123")}, archive.Apps[0].Spec.Build)
+		testutil.AssertEqual(t, "route count", 1, len(archive.Routes))
+		testutil.AssertEqual(t, "instance count", 1, len(archive.ServiceInstances))
+		testutil.AssertEqual(t, "binding count", 2, len(archive.ServiceInstanceBindings))
+		testutil.AssertEqual(t, "task schedule count", 1, len(archive.TaskSchedules))
+		testutil.AssertEqual(t, "app network policy count", 1, len(archive.AppNetworkPolicies))
+		testutil.AssertEqual(t, "secret count", 1, len(archive.Secrets))
+		testutil.AssertEqual(t, "credentials", `{"password":"hunter2"}`, string(archive.Secrets[0].Data[v1alpha1.ServiceInstanceParamsSecretKey]))
+		testutil.AssertEqual(t, "pending update requests", 0, archive.ServiceInstances[0].Spec.UpdateRequests)
+		testutil.AssertEqual(t, "update params", map[string][]byte{
+			v1alpha1.ServiceInstanceParamsSecretKey: []byte(`{"password":"hunter2"}`),
+		}, archive.Secrets[0].Data)
+		testutil.AssertEqual(t, "update annotation", map[string]string{}, archive.Secrets[0].Annotations)
+		testutil.AssertEqual(t, "network policies", 1, len(archive.NetworkPolicies))
+		testutil.AssertEqual(t, "network policy", "allow-monitoring", archive.NetworkPolicies[0].Name)
+	})
+
+	t.Run("redacts credentials", func(t *testing.T) {
+		archive := exportSpace(t, "--redact-credentials")
+
+		testutil.AssertEqual(t, "credentials", redactedParams, string(archive.Secrets[0].Data[v1alpha1.ServiceInstanceParamsSecretKey]))
+	})
+
+	t.Run("missing space", func(t *testing.T) {
+		ctx := fakeinjection.WithInjection(context.Background(), t)
+
+		cmd := NewExportSpaceCommand(&config.KfParams{})
+		cmd.SetContext(ctx)
+		cmd.SetOutput(new(bytes.Buffer))
+		cmd.SetArgs([]string{"missing"})
+
+		testutil.AssertErrorsEqual(t, errors.New(`failed to get Space: spaces.kf.dev "missing" not found`), cmd.Execute())
+	})
+}
+
+func TestImportSpace(t *testing.T) {
+	t.Parallel()
+
+	writeArchive := func(t *testing.T, archive *spaceArchive) string {
+		contents, err := yaml.Marshal(archive)
+		testutil.AssertNil(t, "marshal err", err)
+
+		path := filepath.Join(t.TempDir(), "archive.yaml")
+		testutil.AssertNil(t, "write err", os.WriteFile(path, contents, 0600))
+		return path
+	}
+
+	importSpace := func(t *testing.T, args ...string) (context.Context, string, error) {
+		ctx := fakeinjection.WithInjection(context.Background(), t)
+
+		// The Space reconciler isn't running so Spaces are ready as soon as
+		// they're created.
+		fakeclient.Get(ctx).PrependReactor("create", "spaces", func(action ktesting.Action) (bool, runtime.Object, error) {
+			space := action.(ktesting.CreateAction).GetObject().(*v1alpha1.Space)
+			space.Status = readyStatus
+			return false, nil, nil
+		})
+
+		buf := new(bytes.Buffer)
+		cmd := NewImportSpaceCommand(&config.KfParams{})
+		cmd.SetContext(ctx)
+		cmd.SetOutput(buf)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return ctx, buf.String(), err
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		path := writeArchive(t, exportSpace(t))
+
+		ctx, out, err := importSpace(t, path, "new-space", "--domain-mapping", "example.com=new.example.com")
+		testutil.AssertNil(t, "import err", err)
+		testutil.AssertContainsAll(t, out, []string{
+			`Space "new-space" created`,
+			"Imported 1 Route(s)",
+			"Imported 1 App(s)",
+			"Imported 1 service instance(s)",
+			"Imported 2 service binding(s)",
+			"Imported 1 Job(s)",
+			"Imported 1 AppNetworkPolicy(s)",
+			"Imported 1 NetworkPolicy(s)",
+		})
+
+		kf := fakeclient.Get(ctx).KfV1alpha1()
+		space, err := kf.Spaces().Get(ctx, "new-space", metav1.GetOptions{})
+		testutil.AssertNil(t, "get space err", err)
+		testutil.AssertEqual(t, "space domain", "new.example.com", space.Spec.NetworkConfig.Domains[0].Domain)
+
+		app, err := kf.Apps("new-space").Get(ctx, "my-app", metav1.GetOptions{})
+		testutil.AssertNil(t, "get app err", err)
+		testutil.AssertEqual(t, "app route domain", "new.example.com", app.Spec.Routes[0].Domain)
+
+		routeName := v1alpha1.GenerateRouteNameFromFields(v1alpha1.RouteSpecFields{Hostname: "my-app", Domain: "new.example.com"})
+		route, err := kf.Routes("new-space").Get(ctx, routeName, metav1.GetOptions{})
+		testutil.AssertNil(t, "get route err", err)
+		testutil.AssertEqual(t, "route domain", "new.example.com", route.Spec.Domain)
+
+		routeBindingName := v1alpha1.MakeRouteServiceBindingName("my-app", "new.example.com", "", "my-route-service")
+		routeBinding, err := kf.ServiceInstanceBindings("new-space").Get(ctx, routeBindingName, metav1.GetOptions{})
+		testutil.AssertNil(t, "get route binding err", err)
+		testutil.AssertEqual(t, "route binding domain", "new.example.com", routeBinding.Spec.Route.Domain)
+
+		appNetworkPolicy, err := kf.AppNetworkPolicies("new-space").Get(ctx, "my-policy", metav1.GetOptions{})
+		testutil.AssertNil(t, "get app network policy err", err)
+		testutil.AssertEqual(t, "app network policy source space", "new-space", appNetworkPolicy.Spec.Source.Space)
+
+		taskSchedule, err := kf.TaskSchedules("new-space").Get(ctx, "my-job", metav1.GetOptions{})
+		testutil.AssertNil(t, "get task schedule err", err)
+		testutil.AssertEqual(t, "task schedule owner", "my-app", taskSchedule.OwnerReferences[0].Name)
+
+		secret, err := kubeclient.Get(ctx).CoreV1().Secrets("new-space").Get(ctx, "my-db-params", metav1.GetOptions{})
+		testutil.AssertNil(t, "get secret err", err)
+		testutil.AssertEqual(t, "secret owner", "my-db", secret.OwnerReferences[0].Name)
+	})
+
+	t.Run("re-run", func(t *testing.T) {
+		archive := exportSpace(t)
+		path := writeArchive(t, archive)
+
+		ctx, _, err := importSpace(t, path, "new-space")
+		testutil.AssertNil(t, "first import err", err)
+
+		// Simulate an import that failed before creating the instance's
+		// Secret.
+		k8s := kubeclient.Get(ctx).CoreV1()
+		testutil.AssertNil(t, "delete secret err", k8s.Secrets("new-space").Delete(ctx, "my-db-params", metav1.DeleteOptions{}))
+
+		archive.remap("new-space", nil)
+		buf := new(bytes.Buffer)
+		err = importSpaceArchive(ctx, buf, fakeclient.Get(ctx), kubeclient.Get(ctx), archive, time.Minute)
+		testutil.AssertNil(t, "second import err", err)
+		testutil.AssertContainsAll(t, buf.String(), []string{
+			`Space "new-space" already exists, importing into it`,
+			`App "my-app" already exists, skipping`,
+			`Service instance "my-db" already exists, skipping`,
+			`Job "my-job" already exists, skipping`,
+			"Imported 0 Route(s)",
+			"Imported 0 App(s)",
+			"Imported 0 service instance(s)",
+			"Imported 0 service binding(s)",
+			"Imported 0 Job(s)",
+			"Imported 0 AppNetworkPolicy(s)",
+			"Imported 0 NetworkPolicy(s)",
+		})
+
+		secret, err := k8s.Secrets("new-space").Get(ctx, "my-db-params", metav1.GetOptions{})
+		testutil.AssertNil(t, "get secret err", err)
+		testutil.AssertEqual(t, "secret owner", "my-db", secret.OwnerReferences[0].Name)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		path := writeArchive(t, &spaceArchive{Version: "v0"})
+
+		_, _, err := importSpace(t, path)
+		testutil.AssertErrorsEqual(t, errors.New(`unsupported archive version "v0", expected "v1"`), err)
+	})
+
+	t.Run("invalid domain mapping", func(t *testing.T) {
+		path := writeArchive(t, &spaceArchive{Version: spaceArchiveVersion})
+
+		_, _, err := importSpace(t, path, "--domain-mapping", "example.com")
+		testutil.AssertErrorsEqual(t, errors.New(`invalid domain mapping "example.com", expected OLD=NEW`), err)
+	})
+
+	t.Run("missing archive", func(t *testing.T) {
+		_, _, err := importSpace(t, filepath.Join(t.TempDir(), "missing.yaml"))
+		testutil.AssertNotNil(t, "err", err)
+	})
+}
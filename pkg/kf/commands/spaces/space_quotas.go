@@ -0,0 +1,204 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/client/kf/injection/client"
+	"github.com/google/kf/v2/pkg/kf/commands/completion"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	"github.com/google/kf/v2/pkg/kf/internal/genericcli"
+	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
+	"github.com/google/kf/v2/pkg/kf/spaces"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var spaceQuotaResourceInfo = &genericcli.KubernetesType{
+	Group:    "kf.dev",
+	Version:  "v1alpha1",
+	Kind:     "SpaceQuota",
+	Resource: "spacequotas",
+	NsScoped: false,
+	KfName:   "SpaceQuota",
+}
+
+// NewCreateSpaceQuotaCommand allows operators to create a SpaceQuota that
+// Spaces can share.
+func NewCreateSpaceQuotaCommand(p *config.KfParams) *cobra.Command {
+	// limits holds the flag value for each quota resource.
+	limits := make(map[string]*string)
+
+	cmd := &cobra.Command{
+		Use:   "create-space-quota NAME",
+		Short: "Create a named quota that can be applied to many Spaces.",
+		Long: `
+		Creates a SpaceQuota, a named set of limits that can be applied to
+		many Spaces with set-space-quota. Limits that aren't set are
+		unlimited.
+
+		Changing a SpaceQuota changes the limits of every Space that
+		references it. If a Space also has its own quota, the lower of the
+		two limits is used for each resource.
+		`,
+		Example: `
+		# Create a quota for small Spaces.
+		kf create-space-quota small --memory 10Gi --apps 5 --routes 10
+		`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			toCreate := &v1alpha1.SpaceQuota{}
+			toCreate.Name = args[0]
+
+			for _, resourceName := range quotaResourceNames {
+				limit := limits[resourceName]
+				if *limit == "" {
+					continue
+				}
+
+				if err := setSpaceQuota(&toCreate.Spec, resourceName, limit); err != nil {
+					return fmt.Errorf("invalid --%s: %s", resourceName, err)
+				}
+			}
+
+			if _, err := client.Get(ctx).
+				KfV1alpha1().
+				SpaceQuotas().
+				Create(ctx, toCreate, metav1.CreateOptions{}); err != nil {
+				return fmt.Errorf("failed to create SpaceQuota: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "SpaceQuota %s created\n", toCreate.Name)
+			return nil
+		},
+	}
+
+	for _, resourceName := range quotaResourceNames {
+		limit := new(string)
+		limits[resourceName] = limit
+
+		cmd.Flags().StringVar(
+			limit,
+			resourceName,
+			"",
+			fmt.Sprintf("Limit on %s in each Space, unlimited if unset.", resourceName),
+		)
+	}
+
+	return cmd
+}
+
+// NewListSpaceQuotasCommand allows users to list SpaceQuotas.
+func NewListSpaceQuotasCommand(p *config.KfParams) *cobra.Command {
+	return genericcli.NewListCommand(
+		spaceQuotaResourceInfo,
+		p,
+		genericcli.WithListCommandName("space-quotas"),
+		genericcli.WithListPluralFriendlyName("SpaceQuotas"),
+	)
+}
+
+// NewSetSpaceQuotaCommand allows operators to apply a SpaceQuota to a Space.
+func NewSetSpaceQuotaCommand(p *config.KfParams, spacesClient spaces.Client) *cobra.Command {
+	var async utils.AsyncFlags
+
+	cmd := &cobra.Command{
+		Use:   "set-space-quota SPACE QUOTA",
+		Short: "Apply a SpaceQuota to a Space.",
+		Long: `
+		Applies the limits of a SpaceQuota to a Space, replacing any
+		SpaceQuota the Space previously referenced. If the Space also has its
+		own quota set with configure-space set-quota, the lower of the two
+		limits is used for each resource.
+		`,
+		Example:           `kf set-space-quota my-space small`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.SpaceCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			spaceName := args[0]
+			quotaName := args[1]
+
+			if _, err := client.Get(ctx).
+				KfV1alpha1().
+				SpaceQuotas().
+				Get(ctx, quotaName, metav1.GetOptions{}); err != nil {
+				return fmt.Errorf("failed to get SpaceQuota: %s", err)
+			}
+
+			return transformSpaceQuotaRef(cmd, spacesClient, &async, spaceName, &corev1.LocalObjectReference{
+				Name: quotaName,
+			})
+		},
+	}
+
+	async.Add(cmd)
+
+	return cmd
+}
+
+// NewUnsetSpaceQuotaCommand allows operators to remove a SpaceQuota from a
+// Space.
+func NewUnsetSpaceQuotaCommand(p *config.KfParams, spacesClient spaces.Client) *cobra.Command {
+	var async utils.AsyncFlags
+
+	cmd := &cobra.Command{
+		Use:               "unset-space-quota SPACE",
+		Short:             "Remove the SpaceQuota from a Space.",
+		Long:              "Removes the SpaceQuota from a Space. The Space's own quota still applies.",
+		Example:           `kf unset-space-quota my-space`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SpaceCompletionFn(p),
+		SilenceUsage:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transformSpaceQuotaRef(cmd, spacesClient, &async, args[0], nil)
+		},
+	}
+
+	async.Add(cmd)
+
+	return cmd
+}
+
+func transformSpaceQuotaRef(
+	cmd *cobra.Command,
+	spacesClient spaces.Client,
+	async *utils.AsyncFlags,
+	spaceName string,
+	quotaRef *corev1.LocalObjectReference,
+) error {
+	mutator := DiffWrapper(cmd.OutOrStdout(), func(space *v1alpha1.Space) error {
+		space.Spec.QuotaRef = quotaRef
+		return nil
+	})
+
+	if _, err := spacesClient.Transform(cmd.Context(), spaceName, mutator); err != nil {
+		return err
+	}
+
+	return async.AwaitAndLog(cmd.OutOrStdout(), "configuring Space", func() error {
+		_, err := spacesClient.WaitForConditionReadyTrue(context.Background(), spaceName, 1*time.Second)
+		return err
+	})
+}
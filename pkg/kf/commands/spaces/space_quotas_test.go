@@ -0,0 +1,136 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spaces
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	fakeclient "github.com/google/kf/v2/pkg/client/kf/injection/client/fake"
+	"github.com/google/kf/v2/pkg/kf/commands/config"
+	fakeinjection "github.com/google/kf/v2/pkg/kf/injection/fake"
+	"github.com/google/kf/v2/pkg/kf/spaces"
+	"github.com/google/kf/v2/pkg/kf/spaces/fake"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewCreateSpaceQuotaCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args    []string
+		wantErr error
+		assert  func(t *testing.T, quota *v1alpha1.SpaceQuota)
+	}{
+		"missing name": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"invalid limit": {
+			args:    []string{"small", "--apps", "five"},
+			wantErr: errors.New(`invalid --apps: invalid limit "five": must be a whole number`),
+		},
+		"unset limits are unlimited": {
+			args: []string{"small", "--memory", "10Gi", "--routes", "20"},
+			assert: func(t *testing.T, quota *v1alpha1.SpaceQuota) {
+				testutil.AssertEqual(t, "memory", "10Gi", quota.Spec.Memory.String())
+				testutil.AssertEqual(t, "routes", int64(20), *quota.Spec.Routes)
+				testutil.AssertEqual(t, "apps", (*int64)(nil), quota.Spec.Apps)
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+
+			var buffer bytes.Buffer
+			cmd := NewCreateSpaceQuotaCommand(&config.KfParams{})
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tc.args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+			if tc.wantErr != nil {
+				testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+				return
+			}
+			testutil.AssertNil(t, "err", gotErr)
+
+			quota, err := fakeclient.Get(ctx).
+				KfV1alpha1().
+				SpaceQuotas().
+				Get(ctx, "small", metav1.GetOptions{})
+			testutil.AssertNil(t, "get err", err)
+			tc.assert(t, quota)
+		})
+	}
+}
+
+func TestNewSetSpaceQuotaCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		quotas  []string
+		wantErr error
+	}{
+		"missing SpaceQuota": {
+			wantErr: errors.New(`failed to get SpaceQuota: spacequotas.kf.dev "small" not found`),
+		},
+		"sets reference": {
+			quotas: []string{"small"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+			for _, name := range tc.quotas {
+				quota := &v1alpha1.SpaceQuota{}
+				quota.Name = name
+				fakeclient.Get(ctx).KfV1alpha1().SpaceQuotas().Create(ctx, quota, metav1.CreateOptions{})
+			}
+
+			ctrl := gomock.NewController(t)
+			fakeSpaces := fake.NewFakeClient(ctrl)
+
+			space := &v1alpha1.Space{}
+			if tc.wantErr == nil {
+				fakeSpaces.EXPECT().
+					Transform(gomock.Any(), "my-space", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, mutator spaces.Mutator) (*v1alpha1.Space, error) {
+						return space, mutator(space)
+					})
+			}
+
+			var buffer bytes.Buffer
+			cmd := NewSetSpaceQuotaCommand(&config.KfParams{}, fakeSpaces)
+			cmd.SetContext(ctx)
+			cmd.SetArgs([]string{"my-space", "small", "--async"})
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			if tc.wantErr == nil {
+				testutil.AssertEqual(t, "quotaRef", "small", space.Spec.QuotaRef.Name)
+			}
+		})
+	}
+}
@@ -18,12 +18,15 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfclient "github.com/google/kf/v2/pkg/client/kf/injection/client"
 	"github.com/google/kf/v2/pkg/kf/commands/completion"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
 	utils "github.com/google/kf/v2/pkg/kf/internal/utils/cli"
 	"github.com/google/kf/v2/pkg/kf/spaces"
 	"github.com/spf13/cobra"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/logging"
 )
 
@@ -37,6 +40,16 @@ func suggestMissingSpaceNextAction() {
 	})
 }
 
+func suggestMissingOrgNextAction() {
+	utils.SuggestNextAction(utils.NextAction{
+		Description: "List known orgs",
+		Commands: []string{
+			"kf orgs",
+			"kubectl get orgs",
+		},
+	})
+}
+
 // NewTargetCommand creates a command that can set the default space.
 func NewTargetCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
 	command := &cobra.Command{
@@ -45,11 +58,19 @@ func NewTargetCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
 		},
 		Use:   "target",
 		Short: "Set the default Space to run commands against.",
+		Long: `
+		Sets the default Space to run commands against.
+
+		Targeting an Org without a Space clears the targeted Space. Targeting
+		an Org and a Space together checks that the Space belongs to the Org.
+		`,
 		Example: `
 		# See the current Space
 		kf target
 		# Target a Space
 		kf target -s my-space
+		# Target a Space in an Org
+		kf target -o my-org -s my-space
 		`,
 		Args:         cobra.MaximumNArgs(1),
 		SilenceUsage: true,
@@ -57,6 +78,7 @@ func NewTargetCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
 			ctx := cmd.Context()
 			flagsChanged := cmd.Flags().Lookup("target-space").Changed
 			flagsChanged = flagsChanged || cmd.Parent().PersistentFlags().Lookup("space").Changed
+			orgChanged := cmd.Flags().Lookup("target-org").Changed
 
 			// We'll support the use case where the user hands us an argument
 			// (instead of using a flag). But we're not going to pick between
@@ -79,20 +101,49 @@ func NewTargetCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
 				updateSpace = false
 			}
 
+			if orgChanged {
+				if _, err := kfclient.Get(ctx).
+					KfV1alpha1().
+					Orgs().
+					Get(ctx, p.Org, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+					suggestMissingOrgNextAction()
+					return fmt.Errorf("Org %q doesn't exist", p.Org)
+				} else if err != nil {
+					return err
+				}
+
+				// The previously targeted Space may belong to a different Org.
+				if !updateSpace {
+					p.Space = ""
+				}
+			}
+
 			if updateSpace {
-				if _, err := client.Get(cmd.Context(), p.Space); apierrors.IsNotFound(err) {
+				space, err := client.Get(cmd.Context(), p.Space)
+				if apierrors.IsNotFound(err) {
 					suggestMissingSpaceNextAction()
 					return fmt.Errorf("Space %q doesn't exist", p.Space)
 				} else if err != nil {
 					return err
 				}
 
+				spaceOrg := orgName(space)
+				if orgChanged && spaceOrg != p.Org {
+					return fmt.Errorf("Space %q isn't in Org %q", p.Space, p.Org)
+				}
+				p.Org = spaceOrg
+			}
+
+			if updateSpace || orgChanged {
 				if err := config.Write(p.Config, p); err != nil {
 					return err
 				}
 				logging.FromContext(ctx).Info("Updated target Space:")
 			}
 
+			if p.Org != "" {
+				logging.FromContext(ctx).Infof("Org: %s", p.Org)
+			}
 			fmt.Fprintln(cmd.OutOrStdout(), p.Space)
 
 			return nil
@@ -101,6 +152,16 @@ func NewTargetCommand(p *config.KfParams, client spaces.Client) *cobra.Command {
 
 	command.Flags().StringVarP(&p.Space, "target-space", "s", "", "Target the given space.")
 	command.RegisterFlagCompletionFunc("target-space", completion.SpaceCompletionFn(p))
+	command.Flags().StringVarP(&p.Org, "target-org", "o", "", "Target the given org.")
 
 	return command
 }
+
+// orgName returns the name of the Org the Space belongs to, or blank if it
+// doesn't belong to one.
+func orgName(space *v1alpha1.Space) string {
+	if space.Spec.OrgRef == nil {
+		return ""
+	}
+	return space.Spec.OrgRef.Name
+}
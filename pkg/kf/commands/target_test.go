@@ -16,6 +16,7 @@ package commands
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io/ioutil"
 	"os"
@@ -24,11 +25,15 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	fakeclient "github.com/google/kf/v2/pkg/client/kf/injection/client/fake"
 	"github.com/google/kf/v2/pkg/kf/commands/config"
+	fakeinjection "github.com/google/kf/v2/pkg/kf/injection/fake"
 	"github.com/google/kf/v2/pkg/kf/spaces/fake"
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestTargetCommand(t *testing.T) {
@@ -39,6 +44,7 @@ func TestTargetCommand(t *testing.T) {
 		setup  func(*testing.T, *config.KfParams, *fake.FakeClient)
 		assert func(*testing.T, string, error)
 		args   []string
+		orgs   []string
 	}{
 		{
 			name: "too many args",
@@ -137,11 +143,73 @@ func TestTargetCommand(t *testing.T) {
 				testutil.AssertErrorsEqual(t, nil, err)
 			},
 		},
+		{
+			name: "targets org which does not exist",
+			args: []string{"-o=missing"},
+			assert: func(t *testing.T, output string, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("Org \"missing\" doesn't exist"), err)
+			},
+		},
+		{
+			name: "targets space outside of org",
+			args: []string{"-o=my-org", "-s=foo"},
+			orgs: []string{"my-org"},
+			setup: func(t *testing.T, p *config.KfParams, fakeSpaces *fake.FakeClient) {
+				fakeSpaces.EXPECT().Get(gomock.Any(), "foo").Return(&v1alpha1.Space{}, nil)
+			},
+			assert: func(t *testing.T, output string, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("Space \"foo\" isn't in Org \"my-org\""), err)
+			},
+		},
+		{
+			name: "updates Org and clears Space",
+			args: []string{"-o=my-org"},
+			orgs: []string{"my-org"},
+			setup: func(t *testing.T, p *config.KfParams, fakeSpaces *fake.FakeClient) {
+				p.Space = "old-space"
+				p.Config = filepath.Join(t.TempDir(), "config")
+			},
+			assert: func(t *testing.T, output string, err error) {
+				testutil.AssertErrorsEqual(t, nil, err)
+				testutil.AssertEqual(t, "output", "\n", output)
+			},
+		},
+		{
+			name: "updates Org from Space",
+			args: []string{"-s=foo"},
+			setup: func(t *testing.T, p *config.KfParams, fakeSpaces *fake.FakeClient) {
+				space := &v1alpha1.Space{}
+				space.Spec.OrgRef = &corev1.LocalObjectReference{Name: "my-org"}
+				fakeSpaces.EXPECT().Get(gomock.Any(), "foo").Return(space, nil)
+
+				configPath := filepath.Join(t.TempDir(), "config")
+				p.Config = configPath
+				t.Cleanup(func() {
+					if t.Failed() {
+						return
+					}
+
+					pp, err := config.Load(configPath, &config.KfParams{})
+					testutil.AssertErrorsEqual(t, nil, err)
+					testutil.AssertEqual(t, "space", "foo", pp.Space)
+					testutil.AssertEqual(t, "org", "my-org", pp.Org)
+				})
+			},
+			assert: func(t *testing.T, output string, err error) {
+				testutil.AssertErrorsEqual(t, nil, err)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			p := &config.KfParams{}
+			ctx := fakeinjection.WithInjection(context.Background(), t)
+			for _, name := range tc.orgs {
+				org := &v1alpha1.Org{}
+				org.Name = name
+				fakeclient.Get(ctx).KfV1alpha1().Orgs().Create(ctx, org, metav1.CreateOptions{})
+			}
 
 			buf := bytes.Buffer{}
 			ctrl := gomock.NewController(t)
@@ -168,7 +236,7 @@ func TestTargetCommand(t *testing.T) {
 
 			fakeParent.SetArgs(append([]string{"target"}, tc.args...))
 			fakeParent.SetOut(&buf)
-			err := fakeParent.Execute()
+			err := fakeParent.ExecuteContext(ctx)
 
 			if tc.assert != nil {
 				tc.assert(t, buf.String(), err)
@@ -116,6 +116,32 @@ func InjectCreateAutoscalingRule(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectCreateAutoscalingSchedule(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	client := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, client, tailer)
+	command := autoscaling.NewCreateAutoscalingSchedule(p, appsClient)
+	return command
+}
+
+func InjectAutoscalingSchedules(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	client := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, client, tailer)
+	command := autoscaling.NewAutoscalingSchedules(p, appsClient)
+	return command
+}
+
 func InjectDeleteAutoscalingRules(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
@@ -220,6 +246,34 @@ func InjectRestage(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectRollout(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	client := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, client, tailer)
+	command := apps2.NewRolloutCommand(p, appsClient)
+	return command
+}
+
+func InjectCreateAppManifest(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	buildsGetter := provideKfBuilds(kfV1alpha1Interface)
+	kubernetesInterface := config.GetKubernetes(p)
+	buildTailer := builds.TektonLoggingShim(kubernetesInterface)
+	client := builds.NewClient(p, buildsGetter, buildTailer)
+	tailer := logs.NewTailer(kubernetesInterface)
+	appsClient := apps.NewClient(appsGetter, client, tailer)
+	serviceInstanceBindingsGetter := provideServiceInstanceBindingsGetter(kfV1alpha1Interface)
+	serviceinstancebindingsClient := serviceinstancebindings.NewClient(serviceInstanceBindingsGetter)
+	command := apps2.NewCreateAppManifestCommand(p, appsClient, serviceinstancebindingsClient)
+	return command
+}
+
 func InjectProxy(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
@@ -296,6 +350,35 @@ func InjectCreateService(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectUpdateService(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	serviceInstancesGetter := provideServiceInstancesGetter(kfV1alpha1Interface)
+	client := serviceinstances.NewClient(serviceInstancesGetter)
+	kubernetesInterface := config.GetKubernetes(p)
+	secretsGetter := provideSecretsGetter(kubernetesInterface)
+	secretsClient := secrets.NewClient(secretsGetter)
+	clientInterface := marketplace.NewClient(kfV1alpha1Interface)
+	command := services.NewUpdateServiceCommand(p, client, secretsClient, clientInterface)
+	return command
+}
+
+func InjectShareService(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	serviceInstancesGetter := provideServiceInstancesGetter(kfV1alpha1Interface)
+	client := serviceinstances.NewClient(serviceInstancesGetter)
+	clientInterface := marketplace.NewClient(kfV1alpha1Interface)
+	command := services.NewShareServiceCommand(p, client, clientInterface)
+	return command
+}
+
+func InjectUnshareService(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	serviceInstancesGetter := provideServiceInstancesGetter(kfV1alpha1Interface)
+	client := serviceinstances.NewClient(serviceInstancesGetter)
+	command := services.NewUnshareServiceCommand(p, client)
+	return command
+}
+
 func InjectCreateUserProvidedService(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	serviceInstancesGetter := provideServiceInstancesGetter(kfV1alpha1Interface)
@@ -398,6 +481,44 @@ func InjectVcapServices(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectCreateServiceKey(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	serviceInstanceBindingsGetter := provideServiceInstanceBindingsGetter(kfV1alpha1Interface)
+	client := serviceinstancebindings.NewClient(serviceInstanceBindingsGetter)
+	kubernetesInterface := config.GetKubernetes(p)
+	secretsGetter := provideSecretsGetter(kubernetesInterface)
+	secretsClient := secrets.NewClient(secretsGetter)
+	command := servicebindings.NewCreateServiceKeyCommand(p, client, secretsClient)
+	return command
+}
+
+func InjectServiceKeys(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	serviceInstanceBindingsGetter := provideServiceInstanceBindingsGetter(kfV1alpha1Interface)
+	client := serviceinstancebindings.NewClient(serviceInstanceBindingsGetter)
+	command := servicebindings.NewServiceKeysCommand(p, client)
+	return command
+}
+
+func InjectServiceKey(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	serviceInstanceBindingsGetter := provideServiceInstanceBindingsGetter(kfV1alpha1Interface)
+	client := serviceinstancebindings.NewClient(serviceInstanceBindingsGetter)
+	kubernetesInterface := config.GetKubernetes(p)
+	secretsGetter := provideSecretsGetter(kubernetesInterface)
+	secretsClient := secrets.NewClient(secretsGetter)
+	command := servicebindings.NewServiceKeyCommand(p, client, secretsClient)
+	return command
+}
+
+func InjectDeleteServiceKey(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	serviceInstanceBindingsGetter := provideServiceInstanceBindingsGetter(kfV1alpha1Interface)
+	client := serviceinstancebindings.NewClient(serviceInstanceBindingsGetter)
+	command := servicebindings.NewDeleteServiceKeyCommand(p, client)
+	return command
+}
+
 func InjectCreateServiceBroker(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	client := cluster.NewClient(kfV1alpha1Interface)
@@ -417,6 +538,35 @@ func InjectDeleteServiceBroker(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectUpdateServiceBroker(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := cluster.NewClient(kfV1alpha1Interface)
+	namespacedClient := namespaced.NewClient(kfV1alpha1Interface)
+	command := servicebrokers.NewUpdateServiceBrokerCommand(p, client, namespacedClient)
+	return command
+}
+
+func InjectServiceAccess(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := cluster.NewClient(kfV1alpha1Interface)
+	command := servicebrokers.NewServiceAccessCommand(p, client)
+	return command
+}
+
+func InjectEnableServiceAccess(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := cluster.NewClient(kfV1alpha1Interface)
+	command := servicebrokers.NewEnableServiceAccessCommand(p, client)
+	return command
+}
+
+func InjectDisableServiceAccess(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := cluster.NewClient(kfV1alpha1Interface)
+	command := servicebrokers.NewDisableServiceAccessCommand(p, client)
+	return command
+}
+
 func InjectBuildpacksClient(p *config.KfParams) buildpacks.Client {
 	remoteImageFetcher := provideRemoteImageFetcher()
 	client := buildpacks.NewClient(remoteImageFetcher)
@@ -488,6 +638,78 @@ func InjectUnsetSpaceRole(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectExportSpace(p *config.KfParams) *cobra.Command {
+	command := spaces.NewExportSpaceCommand(p)
+	return command
+}
+
+func InjectImportSpace(p *config.KfParams) *cobra.Command {
+	command := spaces.NewImportSpaceCommand(p)
+	return command
+}
+
+func InjectCreateSpaceQuota(p *config.KfParams) *cobra.Command {
+	command := spaces.NewCreateSpaceQuotaCommand(p)
+	return command
+}
+
+func InjectSpaceQuotas(p *config.KfParams) *cobra.Command {
+	command := spaces.NewListSpaceQuotasCommand(p)
+	return command
+}
+
+func InjectSetSpaceQuota(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces2.NewClient(spacesGetter)
+	command := spaces.NewSetSpaceQuotaCommand(p, client)
+	return command
+}
+
+func InjectUnsetSpaceQuota(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces2.NewClient(spacesGetter)
+	command := spaces.NewUnsetSpaceQuotaCommand(p, client)
+	return command
+}
+
+func InjectOrgs(p *config.KfParams) *cobra.Command {
+	command := spaces.NewListOrgsCommand(p)
+	return command
+}
+
+func InjectCreateOrg(p *config.KfParams) *cobra.Command {
+	command := spaces.NewCreateOrgCommand(p)
+	return command
+}
+
+func InjectCreateSecurityGroup(p *config.KfParams) *cobra.Command {
+	command := spaces.NewCreateSecurityGroupCommand(p)
+	return command
+}
+
+func InjectSecurityGroups(p *config.KfParams) *cobra.Command {
+	command := spaces.NewListSecurityGroupsCommand(p)
+	return command
+}
+
+func InjectBindSecurityGroup(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces2.NewClient(spacesGetter)
+	command := spaces.NewBindSecurityGroupCommand(p, client)
+	return command
+}
+
+func InjectUnbindSecurityGroup(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
+	client := spaces2.NewClient(spacesGetter)
+	command := spaces.NewUnbindSecurityGroupCommand(p, client)
+	return command
+}
+
 func InjectDomains(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	spacesGetter := provideKfSpaces(kfV1alpha1Interface)
@@ -524,6 +746,13 @@ func InjectCreateRoute(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectUpdateRoute(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := routes2.NewClient(kfV1alpha1Interface)
+	command := routes.NewUpdateRouteCommand(p, client)
+	return command
+}
+
 func InjectDeleteRoute(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	client := routes2.NewClient(kfV1alpha1Interface)
@@ -689,6 +918,21 @@ func InjectDescribeNetworkPolicy(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectAddNetworkPolicy(p *config.KfParams) *cobra.Command {
+	command := networkpolicies.NewAddCommand(p)
+	return command
+}
+
+func InjectRemoveNetworkPolicy(p *config.KfParams) *cobra.Command {
+	command := networkpolicies.NewRemoveCommand(p)
+	return command
+}
+
+func InjectAppNetworkPolicies(p *config.KfParams) *cobra.Command {
+	command := networkpolicies.NewListAppNetworkPoliciesCommand(p)
+	return command
+}
+
 // wire_injector.go:
 
 func provideSrcImageBuilder() apps2.SrcImageBuilder {
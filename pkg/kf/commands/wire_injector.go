@@ -127,6 +127,16 @@ func InjectCreateAutoscalingRule(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectCreateAutoscalingSchedule(p *config.KfParams) *cobra.Command {
+	wire.Build(autoscaling.NewCreateAutoscalingSchedule, AppsSet)
+	return nil
+}
+
+func InjectAutoscalingSchedules(p *config.KfParams) *cobra.Command {
+	wire.Build(autoscaling.NewAutoscalingSchedules, AppsSet)
+	return nil
+}
+
 func InjectDeleteAutoscalingRules(p *config.KfParams) *cobra.Command {
 	wire.Build(autoscaling.NewDeleteAutoscalingRules, AppsSet)
 	return nil
@@ -167,6 +177,21 @@ func InjectRestage(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectRollout(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewRolloutCommand, AppsSet)
+	return nil
+}
+
+func InjectCreateAppManifest(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		capps.NewCreateAppManifestCommand,
+		provideServiceInstanceBindingsGetter,
+		serviceinstancebindings.NewClient,
+		AppsSet,
+	)
+	return nil
+}
+
 func InjectProxy(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		capps.NewProxyCommand,
@@ -242,6 +267,30 @@ func InjectCreateService(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectUpdateService(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicescmd.NewUpdateServiceCommand,
+		ServicesSet,
+	)
+	return nil
+}
+
+func InjectShareService(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicescmd.NewShareServiceCommand,
+		ServicesSet,
+	)
+	return nil
+}
+
+func InjectUnshareService(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicescmd.NewUnshareServiceCommand,
+		ServicesSet,
+	)
+	return nil
+}
+
 func InjectCreateUserProvidedService(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		servicescmd.NewCreateUserProvidedServiceCommand,
@@ -350,6 +399,38 @@ func InjectVcapServices(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectCreateServiceKey(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicebindingscmd.NewCreateServiceKeyCommand,
+		ServiceBindingsSet,
+	)
+	return nil
+}
+
+func InjectServiceKeys(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicebindingscmd.NewServiceKeysCommand,
+		ServiceBindingsSet,
+	)
+	return nil
+}
+
+func InjectServiceKey(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicebindingscmd.NewServiceKeyCommand,
+		ServiceBindingsSet,
+	)
+	return nil
+}
+
+func InjectDeleteServiceKey(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicebindingscmd.NewDeleteServiceKeyCommand,
+		ServiceBindingsSet,
+	)
+	return nil
+}
+
 ///////////////////////
 // Service Brokers  //
 /////////////////////
@@ -379,6 +460,38 @@ func InjectDeleteServiceBroker(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectUpdateServiceBroker(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicebrokerscmd.NewUpdateServiceBrokerCommand,
+		serviceBrokerSet,
+	)
+	return nil
+}
+
+func InjectServiceAccess(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicebrokerscmd.NewServiceAccessCommand,
+		serviceBrokerSet,
+	)
+	return nil
+}
+
+func InjectEnableServiceAccess(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicebrokerscmd.NewEnableServiceAccessCommand,
+		serviceBrokerSet,
+	)
+	return nil
+}
+
+func InjectDisableServiceAccess(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		servicebrokerscmd.NewDisableServiceAccessCommand,
+		serviceBrokerSet,
+	)
+	return nil
+}
+
 // ///////////////
 // Buildpacks //
 // /////////////
@@ -470,6 +583,78 @@ func InjectUnsetSpaceRole(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectExportSpace(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewExportSpaceCommand)
+
+	return nil
+}
+
+func InjectImportSpace(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewImportSpaceCommand)
+
+	return nil
+}
+
+func InjectCreateSpaceQuota(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewCreateSpaceQuotaCommand)
+
+	return nil
+}
+
+func InjectSpaceQuotas(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewListSpaceQuotasCommand)
+
+	return nil
+}
+
+func InjectSetSpaceQuota(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewSetSpaceQuotaCommand, SpacesSet)
+
+	return nil
+}
+
+func InjectUnsetSpaceQuota(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewUnsetSpaceQuotaCommand, SpacesSet)
+
+	return nil
+}
+
+func InjectOrgs(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewListOrgsCommand)
+
+	return nil
+}
+
+func InjectCreateOrg(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewCreateOrgCommand)
+
+	return nil
+}
+
+func InjectCreateSecurityGroup(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewCreateSecurityGroupCommand)
+
+	return nil
+}
+
+func InjectSecurityGroups(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewListSecurityGroupsCommand)
+
+	return nil
+}
+
+func InjectBindSecurityGroup(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewBindSecurityGroupCommand, SpacesSet)
+
+	return nil
+}
+
+func InjectUnbindSecurityGroup(p *config.KfParams) *cobra.Command {
+	wire.Build(cspaces.NewUnbindSecurityGroupCommand, SpacesSet)
+
+	return nil
+}
+
 func InjectDomains(p *config.KfParams) *cobra.Command {
 	wire.Build(cspaces.NewDomainsCommand, SpacesSet)
 
@@ -516,6 +701,15 @@ func InjectCreateRoute(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectUpdateRoute(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		croutes.NewUpdateRouteCommand,
+		routes.NewClient,
+		config.GetKfClient,
+	)
+	return nil
+}
+
 func InjectDeleteRoute(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		croutes.NewDeleteRouteCommand,
@@ -712,3 +906,21 @@ func InjectDescribeNetworkPolicy(p *config.KfParams) *cobra.Command {
 
 	return nil
 }
+
+func InjectAddNetworkPolicy(p *config.KfParams) *cobra.Command {
+	wire.Build(cnetworkpolicies.NewAddCommand)
+
+	return nil
+}
+
+func InjectRemoveNetworkPolicy(p *config.KfParams) *cobra.Command {
+	wire.Build(cnetworkpolicies.NewRemoveCommand)
+
+	return nil
+}
+
+func InjectAppNetworkPolicies(p *config.KfParams) *cobra.Command {
+	wire.Build(cnetworkpolicies.NewListAppNetworkPoliciesCommand)
+
+	return nil
+}
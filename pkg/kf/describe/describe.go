@@ -128,6 +128,29 @@ func AppSpecInstances(w io.Writer, instances kfv1alpha1.AppSpecInstances) {
 	})
 }
 
+// AppSpecProcesses describes the additional processes of the app.
+func AppSpecProcesses(w io.Writer, processes []kfv1alpha1.AppSpecProcess) {
+	if len(processes) == 0 {
+		return
+	}
+
+	SectionWriter(w, "Processes", func(w io.Writer) {
+		fmt.Fprintln(w, "Type\tReplicas\tCommand")
+		for _, process := range processes {
+			replicas := "1"
+			if process.Replicas != nil {
+				replicas = fmt.Sprint(*process.Replicas)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\n",
+				process.Type,
+				replicas,
+				strings.Join(append(append([]string{}, process.Command...), process.Args...), " "),
+			)
+		}
+	})
+}
+
 // AppSpecAutoscaling describes the autoscaling features of the app.
 func AppSpecAutoscaling(w io.Writer, autoscalingSpec *kfv1alpha1.AppSpecAutoscaling) {
 	if autoscalingSpec == nil {
@@ -158,6 +181,29 @@ func AppSpecAutoscaling(w io.Writer, autoscalingSpec *kfv1alpha1.AppSpecAutoscal
 	})
 }
 
+// AppRolloutStatus describes the latest rollout of the app.
+func AppRolloutStatus(w io.Writer, rollout *kfv1alpha1.AppRolloutStatus) {
+	if rollout == nil {
+		return
+	}
+
+	SectionWriter(w, "Rollout", func(w io.Writer) {
+		fmt.Fprintf(w, "Strategy:\t%s\n", rollout.Strategy)
+		fmt.Fprintf(w, "State:\t%s\n", rollout.State)
+		fmt.Fprintf(w, "Revision:\t%s\n", rollout.Revision)
+
+		if rollout.Strategy == kfv1alpha1.CanaryRolloutStrategy {
+			fmt.Fprintf(w, "Step:\t%d\n", rollout.Step+1)
+		}
+
+		fmt.Fprintf(w, "Traffic:\t%d%%\n", rollout.CandidatePercent)
+
+		if rollout.Message != "" {
+			fmt.Fprintf(w, "Message:\t%s\n", rollout.Message)
+		}
+	})
+}
+
 // MetaV1Beta1Table can print Kubernetes server-side rendered tables.
 func MetaV1Beta1Table(w io.Writer, table *metav1beta1.Table) error {
 	TabbedWriter(w, func(w io.Writer) {
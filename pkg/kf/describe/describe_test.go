@@ -152,6 +152,28 @@ func ExampleAppSpecInstances_exactly() {
 	//   Replicas:  3
 }
 
+func ExampleAppSpecProcesses() {
+	processes := []kfv1alpha1.AppSpecProcess{
+		{
+			Type:     "worker",
+			Args:     []string{"bundle exec sidekiq"},
+			Replicas: ptr.Int32(2),
+		},
+		{
+			Type:    "clock",
+			Command: []string{"/bin/sh"},
+			Args:    []string{"-c", "./clock"},
+		},
+	}
+
+	describe.AppSpecProcesses(os.Stdout, processes)
+
+	// Output: Processes:
+	//   Type    Replicas  Command
+	//   worker  2         bundle exec sidekiq
+	//   clock   1         /bin/sh -c ./clock
+}
+
 func ExampleAppSpecAutoscaling() {
 	autoscalingSpec := &kfv1alpha1.AppSpecAutoscaling{
 		Enabled:     true,
@@ -177,6 +199,27 @@ func ExampleAppSpecAutoscaling() {
 	//     CPU       80
 }
 
+func ExampleAppRolloutStatus() {
+	rollout := &kfv1alpha1.AppRolloutStatus{
+		Strategy:         kfv1alpha1.CanaryRolloutStrategy,
+		State:            kfv1alpha1.RolloutStatePaused,
+		Revision:         "1a2b3c4d",
+		Step:             1,
+		CandidatePercent: 50,
+		Message:          "Sending 50% of traffic to the new revision, promote to continue",
+	}
+
+	describe.AppRolloutStatus(os.Stdout, rollout)
+
+	// Output: Rollout:
+	//   Strategy:  Canary
+	//   State:     Paused
+	//   Revision:  1a2b3c4d
+	//   Step:      2
+	//   Traffic:   50%
+	//   Message:   Sending 50% of traffic to the new revision, promote to continue
+}
+
 func ExampleMetaV1Beta1Table() {
 	describe.MetaV1Beta1Table(os.Stdout, &metav1beta1.Table{
 		ColumnDefinitions: []metav1beta1.TableColumnDefinition{
@@ -0,0 +1,332 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/ptr"
+)
+
+// NewFromApp creates a Manifest that pushes an App equivalent to the given
+// one. Bindings that don't belong to the App are ignored.
+func NewFromApp(app *v1alpha1.App, bindings []v1alpha1.ServiceInstanceBinding) *Manifest {
+	out := Application{
+		Name: app.Name,
+	}
+
+	var container corev1.Container
+	if containers := app.Spec.Template.Spec.Containers; len(containers) > 0 {
+		container = containers[0]
+	}
+
+	env := make(map[string]string)
+	for _, envVar := range container.Env {
+		// Values from secrets and config maps can't be expressed in manifests.
+		if envVar.ValueFrom == nil {
+			env[envVar.Name] = envVar.Value
+		}
+	}
+	if len(env) > 0 {
+		out.Env = env
+	}
+
+	for _, binding := range bindings {
+		if binding.Spec.App == nil || binding.Spec.App.Name != app.Name {
+			continue
+		}
+		out.Services = append(out.Services, binding.Spec.InstanceRef.Name)
+	}
+
+	out.Memory, out.DiskQuota, out.CPU = fromResourceRequests(container.Resources.Requests)
+	out.Entrypoint, out.Args = fromCommandArgs(container.Command, container.Args)
+	if len(out.Args) == 1 {
+		out.Command = out.Args[0]
+		out.Args = nil
+	}
+
+	hc := fromProbes(container.LivenessProbe, container.ReadinessProbe, container.StartupProbe)
+	out.HealthCheckType = hc.HealthCheckType
+	out.HealthCheckHTTPEndpoint = hc.HealthCheckHTTPEndpoint
+	out.HealthCheckTimeout = hc.HealthCheckTimeout
+	out.HealthCheckInvocationTimeout = hc.HealthCheckInvocationTimeout
+	out.ReadinessHealthCheckType = hc.ReadinessHealthCheckType
+	out.ReadinessHealthCheckHTTPEndpoint = hc.ReadinessHealthCheckHTTPEndpoint
+	out.ReadinessHealthCheckInterval = hc.ReadinessHealthCheckInterval
+
+	for _, port := range container.Ports {
+		out.Ports = append(out.Ports, AppPort{
+			Port:     port.ContainerPort,
+			Protocol: portProtocol(port),
+		})
+	}
+
+	out.Instances = app.Spec.Instances.Replicas
+	if app.Spec.Instances.Stopped {
+		out.NoStart = ptr.Bool(true)
+	}
+
+	declaredPorts := sets.NewInt()
+	for _, port := range out.Ports {
+		declaredPorts.Insert(int(port.Port))
+	}
+	for _, route := range app.Spec.Routes {
+		manifestRoute := Route{Route: route.RouteSpecFields.String()}
+		if route.DestinationPort != nil && declaredPorts.Has(int(*route.DestinationPort)) {
+			manifestRoute.AppPort = *route.DestinationPort
+		}
+		out.Routes = append(out.Routes, manifestRoute)
+	}
+	if len(out.Routes) == 0 {
+		out.NoRoute = ptr.Bool(true)
+	}
+
+	out.setBuild(app.Spec.Build)
+
+	for _, process := range app.Spec.Processes {
+		out.Processes = append(out.Processes, fromAppSpecProcess(process))
+	}
+
+	out.Sidecars = fromSidecarContainers(app)
+
+	return &Manifest{
+		Applications: []Application{out},
+	}
+}
+
+// setBuild sets the fields that produce the App's build. Builds with the
+// built-in Dockerfile and V2 buildpack tasks are converted into their Cloud
+// Foundry equivalents, other builds are copied as-is.
+func (app *Application) setBuild(build v1alpha1.AppSpecBuild) {
+	if build.Image != nil {
+		app.Docker.Image = *build.Image
+		return
+	}
+
+	if build.Spec == nil {
+		return
+	}
+
+	spec := build.Spec
+	isBuiltin := spec.Kind == v1alpha1.BuiltinTaskKind
+
+	switch {
+	case isBuiltin && spec.Name == v1alpha1.DockerfileBuildTaskName:
+		app.Dockerfile.Path = buildParam(spec, "DOCKERFILE")
+
+	case isBuiltin && spec.Name == v1alpha1.BuildpackV2BuildTaskName:
+		for _, env := range spec.Env {
+			if env.Name == v1alpha1.StackV2EnvVarName {
+				app.Stack = env.Value
+			}
+		}
+
+		// Buildpacks are only skipped when they're explicitly set, otherwise
+		// they're the defaults for the Space.
+		if buildpacks := buildParam(spec, v1alpha1.BuildpackV2ParamName); buildpacks != "" && buildParam(spec, "SKIP_DETECT") == "true" {
+			app.Buildpacks = strings.Split(buildpacks, ",")
+		}
+
+	default:
+		// The source is filled in when the App is pushed.
+		specCopy := spec.DeepCopy()
+		specCopy.SourcePackage = corev1.LocalObjectReference{}
+		var params []v1alpha1.BuildParam
+		for _, param := range specCopy.Params {
+			if param.Name != v1alpha1.SourceImageParamName {
+				params = append(params, param)
+			}
+		}
+		specCopy.Params = params
+
+		app.Build = specCopy
+	}
+}
+
+func buildParam(spec *v1alpha1.BuildSpec, name string) string {
+	for _, param := range spec.Params {
+		if param.Name == name {
+			return param.Value
+		}
+	}
+
+	return ""
+}
+
+// fromAppSpecProcess converts one of an App's additional processes into a
+// manifest Process.
+func fromAppSpecProcess(process v1alpha1.AppSpecProcess) Process {
+	out := Process{
+		Type:      process.Type,
+		Instances: process.Replicas,
+	}
+
+	// Processes share the App's entrypoint and can only override its
+	// command.
+	out.Command = strings.Join(process.Args, " ")
+
+	if process.Resources != nil {
+		out.Memory, out.DiskQuota, _ = fromResourceRequests(process.Resources.Requests)
+	}
+
+	hc := fromProbes(process.LivenessProbe, process.ReadinessProbe, process.StartupProbe)
+	out.HealthCheckType = hc.HealthCheckType
+	out.HealthCheckHTTPEndpoint = hc.HealthCheckHTTPEndpoint
+	out.HealthCheckTimeout = hc.HealthCheckTimeout
+	out.HealthCheckInvocationTimeout = hc.HealthCheckInvocationTimeout
+	out.ReadinessHealthCheckType = hc.ReadinessHealthCheckType
+	out.ReadinessHealthCheckHTTPEndpoint = hc.ReadinessHealthCheckHTTPEndpoint
+	out.ReadinessHealthCheckInterval = hc.ReadinessHealthCheckInterval
+
+	return out
+}
+
+// fromSidecarContainers converts the sidecars of the App's processes into
+// manifest Sidecars. Sidecars with the same name are merged.
+func fromSidecarContainers(app *v1alpha1.App) []Sidecar {
+	var out []Sidecar
+	indexes := make(map[string]int)
+
+	add := func(processType string, containers []corev1.Container) {
+		for _, container := range containers {
+			if idx, ok := indexes[container.Name]; ok {
+				out[idx].ProcessTypes = append(out[idx].ProcessTypes, processType)
+				continue
+			}
+
+			memory, _, _ := fromResourceRequests(container.Resources.Requests)
+			indexes[container.Name] = len(out)
+			out = append(out, Sidecar{
+				Name:         container.Name,
+				Command:      strings.Join(append(append([]string{}, container.Command...), container.Args...), " "),
+				Memory:       memory,
+				ProcessTypes: []string{processType},
+			})
+		}
+	}
+
+	if containers := app.Spec.Template.Spec.Containers; len(containers) > 1 {
+		add(v1alpha1.WebProcessType, containers[1:])
+	}
+
+	for _, process := range app.Spec.Processes {
+		add(process.Type, process.Sidecars)
+	}
+
+	return out
+}
+
+// fromResourceRequests returns the memory, disk, and CPU requests as manifest
+// quantities.
+func fromResourceRequests(requests corev1.ResourceList) (memory, disk, cpu string) {
+	if quantity, ok := requests[corev1.ResourceMemory]; ok {
+		memory = toCFQuantity(quantity)
+	}
+
+	if quantity, ok := requests[corev1.ResourceEphemeralStorage]; ok {
+		disk = toCFQuantity(quantity)
+	}
+
+	if quantity, ok := requests[corev1.ResourceCPU]; ok {
+		cpu = quantity.String()
+	}
+
+	return
+}
+
+// toCFQuantity formats a quantity so it has the same value when it's read by
+// CFToSIUnits. CF interprets K, M, G, and T as binary units so quantities with
+// decimal units are written in bytes.
+func toCFQuantity(quantity resource.Quantity) string {
+	if quantity.Format == resource.DecimalSI {
+		return strconv.FormatInt(quantity.Value(), 10)
+	}
+
+	return quantity.String()
+}
+
+// fromCommandArgs converts a container's command and args into a manifest
+// entrypoint and args. Manifests only hold a single entrypoint so additional
+// parts of the command are moved into the args.
+func fromCommandArgs(command, args []string) (string, []string) {
+	if len(command) == 0 {
+		return "", args
+	}
+
+	return command[0], append(append([]string{}, command[1:]...), args...)
+}
+
+// portProtocol returns the L7 protocol from the name of a port created from a
+// manifest, e.g. http-8080. Ports with other names are assumed to be TCP.
+func portProtocol(port corev1.ContainerPort) string {
+	protocol := strings.SplitN(port.Name, "-", 2)[0]
+	if sets.NewString(protocolHTTP, protocolHTTP2, protocolTCP).Has(protocol) {
+		return protocol
+	}
+
+	return protocolTCP
+}
+
+// fromProbes converts the liveness, readiness, and startup probes of a
+// container into the health check fields of an Application.
+func fromProbes(liveness, readiness, startup *corev1.Probe) Process {
+	var out Process
+	out.HealthCheckType, out.HealthCheckHTTPEndpoint = probeType(liveness)
+
+	switch {
+	case startup != nil:
+		out.HealthCheckTimeout = int(startup.FailureThreshold * startup.PeriodSeconds)
+		out.HealthCheckInvocationTimeout = int(startup.TimeoutSeconds)
+	case liveness != nil:
+		out.HealthCheckInvocationTimeout = int(liveness.TimeoutSeconds)
+	}
+
+	readinessType, readinessEndpoint := probeType(readiness)
+	if readinessType != out.HealthCheckType || normalizeEndpoint(readinessEndpoint) != normalizeEndpoint(out.HealthCheckHTTPEndpoint) {
+		out.ReadinessHealthCheckType = readinessType
+		out.ReadinessHealthCheckHTTPEndpoint = readinessEndpoint
+	}
+
+	if readiness != nil && readiness.PeriodSeconds != v1alpha1.DefaultHealthCheckPeriodSeconds {
+		out.ReadinessHealthCheckInterval = int(readiness.PeriodSeconds)
+	}
+
+	return out
+}
+
+// probeType returns the health check type and HTTP endpoint of a probe.
+func probeType(probe *corev1.Probe) (string, string) {
+	switch {
+	case probe == nil:
+		return "process", ""
+	case probe.HTTPGet != nil:
+		return "http", probe.HTTPGet.Path
+	default:
+		return "port", ""
+	}
+}
+
+func normalizeEndpoint(endpoint string) string {
+	if endpoint == "" {
+		return v1alpha1.DefaultHealthCheckProbeEndpoint
+	}
+
+	return endpoint
+}
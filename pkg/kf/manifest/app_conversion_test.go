@@ -0,0 +1,280 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/config"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+// appFromManifest creates the App that kf push creates for the Application.
+func appFromManifest(t *testing.T, app *Application) *v1alpha1.App {
+	t.Helper()
+
+	runtimeConfig := &v1alpha1.SpaceStatusRuntimeConfig{}
+
+	container, err := app.ToContainer(runtimeConfig)
+	testutil.AssertNil(t, "ToContainer err", err)
+
+	sidecars, err := app.ToSidecarContainers(v1alpha1.WebProcessType)
+	testutil.AssertNil(t, "ToSidecarContainers err", err)
+
+	processes, err := app.ToAppSpecProcesses(runtimeConfig)
+	testutil.AssertNil(t, "ToAppSpecProcesses err", err)
+
+	out := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: app.Name},
+		Spec: v1alpha1.AppSpec{
+			Instances: app.ToAppSpecInstances(),
+			Processes: processes,
+		},
+	}
+	out.Spec.Template.Spec.Containers = append([]corev1.Container{container}, sidecars...)
+
+	if app.Docker.Image != "" {
+		out.Spec.Build.Image = ptr.String(app.Docker.Image)
+	} else {
+		builder, _, err := app.DetectBuildType(v1alpha1.SpaceStatusBuildConfig{
+			StacksV2: config.StackV2List{{Name: "cflinuxfs3", Image: "cflinuxfs3-image"}},
+		})
+		testutil.AssertNil(t, "DetectBuildType err", err)
+
+		build, err := builder("")
+		testutil.AssertNil(t, "builder err", err)
+		out.Spec.Build.Spec = build
+	}
+
+	for _, route := range app.Routes {
+		parts := strings.SplitN(route.Route, ".", 2)
+		binding := v1alpha1.RouteWeightBinding{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Hostname: parts[0],
+				Domain:   parts[1],
+			},
+		}
+		if route.AppPort != 0 {
+			binding.DestinationPort = ptr.Int32(route.AppPort)
+		}
+		out.Spec.Routes = append(out.Spec.Routes, binding)
+	}
+
+	out.SetDefaults(context.Background())
+
+	return out
+}
+
+func TestNewFromApp_roundTrip(t *testing.T) {
+	cases := map[string]string{
+		"minimal": `
+applications:
+- name: minimal
+`,
+		"everything": `
+applications:
+- name: everything
+  stack: cflinuxfs3
+  buildpacks:
+  - https://github.com/cloudfoundry/go-buildpack
+  env:
+    GREETING: hello
+  memory: 512M
+  disk_quota: 2G
+  cpu: 200m
+  instances: 3
+  command: ./server --port 9000
+  routes:
+  - route: everything.example.com
+  - route: grpc.example.com
+    appPort: 9000
+  ports:
+  - port: 8080
+    protocol: http
+  - port: 9000
+    protocol: http2
+  timeout: 30
+  health-check-type: http
+  health-check-http-endpoint: /healthz
+  health-check-invocation-timeout: 5
+  readiness-health-check-type: http
+  readiness-health-check-http-endpoint: /ready
+  readiness-health-check-interval: 3
+  processes:
+  - type: worker
+    command: ./worker
+    instances: 2
+    memory: 256M
+  sidecars:
+  - name: agent
+    command: ./agent
+    memory: 64M
+    process_types:
+    - web
+    - worker
+`,
+		"docker": `
+applications:
+- name: docker
+  docker:
+    image: gcr.io/my-project/my-app
+  entrypoint: /bin/sh
+  args:
+  - -c
+  - ./start.sh
+  no-start: true
+  no-route: true
+  health-check-type: process
+`,
+	}
+
+	for tn, manifestYAML := range cases {
+		t.Run(tn, func(t *testing.T) {
+			original, err := NewFromReader(context.Background(), strings.NewReader(manifestYAML), "", nil)
+			testutil.AssertNil(t, "NewFromReader err", err)
+			originalApp := appFromManifest(t, &original.Applications[0])
+
+			exported, err := yaml.Marshal(NewFromApp(originalApp, nil))
+			testutil.AssertNil(t, "Marshal err", err)
+
+			roundTripped, err := NewFromReader(context.Background(), bytes.NewReader(exported), "", nil)
+			testutil.AssertNil(t, "NewFromReader err", err)
+			roundTrippedApp := appFromManifest(t, &roundTripped.Applications[0])
+
+			testutil.AssertEqual(t, "App", originalApp, roundTrippedApp)
+		})
+	}
+}
+
+func TestNewFromApp(t *testing.T) {
+	appWithBuild := func(spec v1alpha1.BuildSpec) *v1alpha1.App {
+		app := &v1alpha1.App{}
+		app.Name = "my-app"
+		app.Spec.Build.Spec = &spec
+		app.Spec.Routes = []v1alpha1.RouteWeightBinding{{
+			RouteSpecFields: v1alpha1.RouteSpecFields{Domain: "example.com"},
+		}}
+		return app
+	}
+
+	cases := map[string]struct {
+		app      *v1alpha1.App
+		bindings []v1alpha1.ServiceInstanceBinding
+		expected Application
+	}{
+		"only bindings to the App": {
+			app: appWithBuild(v1alpha1.DockerfileBuild("", "Dockerfile")),
+			bindings: []v1alpha1.ServiceInstanceBinding{
+				{Spec: v1alpha1.ServiceInstanceBindingSpec{
+					BindingType: v1alpha1.BindingType{App: &v1alpha1.AppRef{Name: "my-app"}},
+					InstanceRef: corev1.LocalObjectReference{Name: "db"},
+				}},
+				{Spec: v1alpha1.ServiceInstanceBindingSpec{
+					BindingType: v1alpha1.BindingType{App: &v1alpha1.AppRef{Name: "other-app"}},
+					InstanceRef: corev1.LocalObjectReference{Name: "cache"},
+				}},
+				{Spec: v1alpha1.ServiceInstanceBindingSpec{
+					BindingType: v1alpha1.BindingType{Route: &v1alpha1.RouteRef{Domain: "example.com"}},
+					InstanceRef: corev1.LocalObjectReference{Name: "route-service"},
+				}},
+			},
+			expected: Application{
+				Name:            "my-app",
+				Services:        []string{"db"},
+				Routes:          []Route{{Route: "example.com"}},
+				HealthCheckType: "process",
+				KfApplicationExtension: KfApplicationExtension{
+					Dockerfile: Dockerfile{Path: "Dockerfile"},
+				},
+			},
+		},
+		"custom build": {
+			app: appWithBuild(v1alpha1.BuildSpec{
+				BuildTaskRef:  v1alpha1.BuildTaskRef{Name: "my-task", Kind: "Task"},
+				SourcePackage: corev1.LocalObjectReference{Name: "my-app-1"},
+				Params: []v1alpha1.BuildParam{
+					v1alpha1.StringParam(v1alpha1.SourceImageParamName, "gcr.io/source"),
+					v1alpha1.StringParam("FLAVOR", "spicy"),
+				},
+			}),
+			expected: Application{
+				Name:            "my-app",
+				Routes:          []Route{{Route: "example.com"}},
+				HealthCheckType: "process",
+				KfApplicationExtension: KfApplicationExtension{
+					Build: &v1alpha1.BuildSpec{
+						BuildTaskRef: v1alpha1.BuildTaskRef{Name: "my-task", Kind: "Task"},
+						Params:       []v1alpha1.BuildParam{v1alpha1.StringParam("FLAVOR", "spicy")},
+					},
+				},
+			},
+		},
+		"default buildpacks": {
+			app: appWithBuild(v1alpha1.BuildpackV2Build(
+				"",
+				config.StackV2Definition{Name: "cflinuxfs3"},
+				[]string{"https://github.com/cloudfoundry/java-buildpack"},
+				false,
+			)),
+			expected: Application{
+				Name:            "my-app",
+				Stack:           "cflinuxfs3",
+				Routes:          []Route{{Route: "example.com"}},
+				HealthCheckType: "process",
+			},
+		},
+		"decimal quantities": {
+			app: func() *v1alpha1.App {
+				app := appWithBuild(v1alpha1.DockerfileBuild("", "Dockerfile"))
+				app.Spec.Template.Spec.Containers = []corev1.Container{{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceMemory:           resource.MustParse("1G"),
+							corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+						},
+					},
+				}}
+				return app
+			}(),
+			expected: Application{
+				Name:            "my-app",
+				Memory:          "1000000000",
+				DiskQuota:       "2Gi",
+				Routes:          []Route{{Route: "example.com"}},
+				HealthCheckType: "process",
+				KfApplicationExtension: KfApplicationExtension{
+					Dockerfile: Dockerfile{Path: "Dockerfile"},
+				},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual := NewFromApp(tc.app, tc.bindings)
+
+			testutil.AssertEqual(t, "Applications", []Application{tc.expected}, actual.Applications)
+		})
+	}
+}
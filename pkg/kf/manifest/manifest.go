@@ -68,10 +68,90 @@ type Application struct {
 	// get requests to determine liveness if HealthCheckType is http.
 	HealthCheckHTTPEndpoint string `json:"health-check-http-endpoint,omitempty"`
 
+	// HealthCheckInvocationTimeout holds the number of seconds each health
+	// check can take before it's considered failed.
+	HealthCheckInvocationTimeout int `json:"health-check-invocation-timeout,omitempty"`
+
+	// ReadinessHealthCheckType holds the type of health check that will be
+	// performed to determine if the app can receive traffic. Either port,
+	// http, or process, blank means the same check as HealthCheckType.
+	ReadinessHealthCheckType string `json:"readiness-health-check-type,omitempty"`
+
+	// ReadinessHealthCheckHTTPEndpoint holds the HTTP endpoint that will
+	// receive the get requests to determine readiness if
+	// ReadinessHealthCheckType is http.
+	ReadinessHealthCheckHTTPEndpoint string `json:"readiness-health-check-http-endpoint,omitempty"`
+
+	// ReadinessHealthCheckInterval holds the number of seconds between
+	// readiness checks.
+	ReadinessHealthCheckInterval int `json:"readiness-health-check-interval,omitempty"`
+
+	// Processes holds the configuration of the App's processes. A process
+	// with the web type overrides the App's top-level configuration, other
+	// types run in their own Deployments.
+	Processes []Process `json:"processes,omitempty"`
+
+	// Sidecars holds additional processes that run in the same containers as
+	// the App's processes.
+	Sidecars []Sidecar `json:"sidecars,omitempty"`
+
 	// KfApplicationExtension holds fields that aren't officially in cf
 	KfApplicationExtension `json:",inline"`
 }
 
+// Process is the configuration of one of an Application's processes. Blank
+// fields are inherited from the Application except the health check, which
+// defaults to the process type for processes other than web.
+type Process struct {
+	Type      string `json:"type,omitempty"`
+	Command   string `json:"command,omitempty"`
+	DiskQuota string `json:"disk_quota,omitempty"`
+	Memory    string `json:"memory,omitempty"`
+	Instances *int32 `json:"instances,omitempty"`
+
+	// HealthCheckTimeout holds the health check timeout.
+	// Note the serialized field is just timeout.
+	HealthCheckTimeout int `json:"timeout,omitempty"`
+
+	// HealthCheckType holds the type of health check that will be performed to
+	// determine if the process is alive.
+	HealthCheckType string `json:"health-check-type,omitempty"`
+
+	// HealthCheckHTTPEndpoint holds the HTTP endpoint that will receive the
+	// get requests to determine liveness if HealthCheckType is http.
+	HealthCheckHTTPEndpoint string `json:"health-check-http-endpoint,omitempty"`
+
+	// HealthCheckInvocationTimeout holds the number of seconds each health
+	// check can take before it's considered failed.
+	HealthCheckInvocationTimeout int `json:"health-check-invocation-timeout,omitempty"`
+
+	// ReadinessHealthCheckType holds the type of health check that will be
+	// performed to determine if the process can receive traffic. Either port,
+	// http, or process, blank means the same check as HealthCheckType.
+	ReadinessHealthCheckType string `json:"readiness-health-check-type,omitempty"`
+
+	// ReadinessHealthCheckHTTPEndpoint holds the HTTP endpoint that will
+	// receive the get requests to determine readiness if
+	// ReadinessHealthCheckType is http.
+	ReadinessHealthCheckHTTPEndpoint string `json:"readiness-health-check-http-endpoint,omitempty"`
+
+	// ReadinessHealthCheckInterval holds the number of seconds between
+	// readiness checks.
+	ReadinessHealthCheckInterval int `json:"readiness-health-check-interval,omitempty"`
+}
+
+// Sidecar is an additional process that runs next to the Application's
+// processes, e.g. a metrics agent or log shipper. Sidecars run from the
+// Application's image with its environment.
+type Sidecar struct {
+	Name    string `json:"name,omitempty"`
+	Command string `json:"command,omitempty"`
+	Memory  string `json:"memory,omitempty"`
+
+	// ProcessTypes holds the types of the processes the sidecar runs next to.
+	ProcessTypes []string `json:"process_types,omitempty"`
+}
+
 // KfApplicationExtension holds fields that aren't officially in cf
 type KfApplicationExtension struct {
 	// TODO(#95): These aren't CF proper. How do we expose these in the manifest?
@@ -116,6 +196,12 @@ type Manifest struct {
 	// to.
 	RelativePathRoot string `json:"-"`
 
+	// Inherit holds the path of a base manifest, relative to this one, that
+	// provides defaults for the Applications. Base Applications without a
+	// name apply to every Application, others apply to the Application with
+	// the same name.
+	Inherit string `json:"inherit,omitempty"`
+
 	Applications []Application `json:"applications"`
 }
 
@@ -124,17 +210,99 @@ type Dockerfile struct {
 	Path string `json:"path,omitempty"`
 }
 
-// NewFromFile creates a Manifest from a manifest file.
+// NewFromFile creates a Manifest from a manifest file. If the manifest
+// inherits from a base manifest, the base is read with the same variables and
+// merged into the returned Manifest.
 func NewFromFile(
 	ctx context.Context,
 	manifestFile string,
 	variables map[string]interface{},
 ) (*Manifest, error) {
+	return newFromFile(ctx, manifestFile, variables, sets.NewString())
+}
+
+func newFromFile(
+	ctx context.Context,
+	manifestFile string,
+	variables map[string]interface{},
+	seen sets.String,
+) (*Manifest, error) {
+	absPath, err := filepath.Abs(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	if seen.Has(absPath) {
+		return nil, fmt.Errorf("manifest %q inherits from itself", manifestFile)
+	}
+	seen.Insert(absPath)
+
 	reader, err := os.Open(manifestFile)
 	if err != nil {
 		return nil, err
 	}
-	return NewFromReader(ctx, reader, filepath.Dir(manifestFile), variables)
+	defer reader.Close()
+
+	m, err := NewFromReader(ctx, reader, filepath.Dir(manifestFile), variables)
+	if err != nil || m.Inherit == "" {
+		return m, err
+	}
+
+	basePath := m.Inherit
+	if !filepath.IsAbs(basePath) {
+		basePath = filepath.Join(m.RelativePathRoot, basePath)
+	}
+
+	base, err := newFromFile(ctx, basePath, variables, seen)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read inherited manifest %q: %v", m.Inherit, err)
+	}
+
+	if err := m.inheritFrom(base); err != nil {
+		return nil, fmt.Errorf("couldn't inherit manifest %q: %v", m.Inherit, err)
+	}
+
+	return m, nil
+}
+
+// inheritFrom fills in blank values of the Manifest's Applications using the
+// base Manifest. Base Applications with a matching name take priority over
+// ones without a name.
+func (m *Manifest) inheritFrom(base *Manifest) error {
+	for i := range m.Applications {
+		app := &m.Applications[i]
+
+		for _, matchName := range []string{app.Name, ""} {
+			for _, baseApp := range base.Applications {
+				if baseApp.Name != matchName {
+					continue
+				}
+
+				// Copy the base so Applications don't share its maps and
+				// slices.
+				var defaults Application
+				if err := copyApplication(baseApp, &defaults); err != nil {
+					return err
+				}
+
+				if err := mergo.Merge(app, defaults); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	m.Inherit = ""
+
+	return nil
+}
+
+func copyApplication(in Application, out *Application) error {
+	bytes, err := yaml.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(bytes, out)
 }
 
 // NewFromReader creates a Manifest from a reader.
@@ -264,6 +432,45 @@ func (app *Application) Override(overrides *Application) error {
 		app.Routes = overrides.Routes
 	}
 
+	// Overrides apply to the web process so they must take precedence over
+	// its configuration in the processes block.
+	for i := range app.Processes {
+		web := &app.Processes[i]
+		if web.Type != v1alpha1.WebProcessType {
+			continue
+		}
+
+		if overrides.Command != "" || len(overrides.Args) > 0 {
+			web.Command = ""
+		}
+		if overrides.Instances != nil {
+			web.Instances = nil
+		}
+		if overrides.Memory != "" {
+			web.Memory = ""
+		}
+		if overrides.DiskQuota != "" {
+			web.DiskQuota = ""
+		}
+		if overrides.HealthCheckType != "" {
+			web.HealthCheckType = ""
+			web.HealthCheckHTTPEndpoint = ""
+		}
+		if overrides.HealthCheckTimeout != 0 {
+			web.HealthCheckTimeout = 0
+		}
+		if overrides.HealthCheckInvocationTimeout != 0 {
+			web.HealthCheckInvocationTimeout = 0
+		}
+		if overrides.ReadinessHealthCheckType != "" {
+			web.ReadinessHealthCheckType = ""
+			web.ReadinessHealthCheckHTTPEndpoint = ""
+		}
+		if overrides.ReadinessHealthCheckInterval != 0 {
+			web.ReadinessHealthCheckInterval = 0
+		}
+	}
+
 	if err := mergo.Merge(app, overrides, mergo.WithOverride); err != nil {
 		return err
 	}
@@ -306,8 +513,8 @@ func (app *Application) WriteWarnings(ctx context.Context) error {
 
 	for _, port := range app.Ports {
 		if port.Protocol == protocolTCP {
-			logger.Warn("Kf supports TCP ports but currently only HTTP Routes. " +
-				"TCP ports can be reached on the App's cluster-internal app-<name>.<space>.svc.cluster.local address.")
+			logger.Warn("TCP ports can only be reached from outside the cluster with TCP Routes on a tcp domain. " +
+				"TCP ports can also be reached on the App's cluster-internal app-<name>.<space>.svc.cluster.local address.")
 			break // only show once
 		}
 	}
@@ -24,14 +24,170 @@ import (
 	"github.com/google/kf/v2/pkg/internal/envutil"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// startupProbePeriodSeconds is the number of seconds between health checks
+	// while an App is starting.
+	startupProbePeriodSeconds = 2
 )
 
 var (
 	ramDivisor = resource.MustParse("1Gi")
 )
 
+// ForProcess returns a copy of the Application with the process's
+// configuration applied.
+func (source *Application) ForProcess(process Process) *Application {
+	out := *source
+	out.Processes = nil
+
+	if process.Command != "" {
+		out.Command = process.Command
+		out.Args = nil
+	}
+
+	if process.DiskQuota != "" {
+		out.DiskQuota = process.DiskQuota
+	}
+
+	if process.Memory != "" {
+		out.Memory = process.Memory
+	}
+
+	if process.Instances != nil {
+		out.Instances = process.Instances
+	}
+
+	// Processes other than web usually don't listen on a port so they don't
+	// inherit the App's health checks.
+	if process.Type != v1alpha1.WebProcessType {
+		out.HealthCheckType = "process"
+		out.HealthCheckHTTPEndpoint = ""
+		out.HealthCheckTimeout = 0
+		out.HealthCheckInvocationTimeout = 0
+		out.ReadinessHealthCheckType = ""
+		out.ReadinessHealthCheckHTTPEndpoint = ""
+		out.ReadinessHealthCheckInterval = 0
+	}
+
+	if process.HealthCheckType != "" {
+		out.HealthCheckType = process.HealthCheckType
+		out.HealthCheckHTTPEndpoint = process.HealthCheckHTTPEndpoint
+	}
+
+	if process.HealthCheckTimeout != 0 {
+		out.HealthCheckTimeout = process.HealthCheckTimeout
+	}
+
+	if process.HealthCheckInvocationTimeout != 0 {
+		out.HealthCheckInvocationTimeout = process.HealthCheckInvocationTimeout
+	}
+
+	if process.ReadinessHealthCheckType != "" {
+		out.ReadinessHealthCheckType = process.ReadinessHealthCheckType
+		out.ReadinessHealthCheckHTTPEndpoint = process.ReadinessHealthCheckHTTPEndpoint
+	}
+
+	if process.ReadinessHealthCheckInterval != 0 {
+		out.ReadinessHealthCheckInterval = process.ReadinessHealthCheckInterval
+	}
+
+	return &out
+}
+
+// webApplication returns the Application with the configuration of its web
+// process applied, if it has one.
+func (source *Application) webApplication() *Application {
+	for _, process := range source.Processes {
+		if process.Type == v1alpha1.WebProcessType {
+			return source.ForProcess(process)
+		}
+	}
+
+	return source
+}
+
+// ToAppSpecProcesses converts the processes other than web into additional
+// processes of the App.
+func (source *Application) ToAppSpecProcesses(runtimeConfig *v1alpha1.SpaceStatusRuntimeConfig) ([]v1alpha1.AppSpecProcess, error) {
+	var out []v1alpha1.AppSpecProcess
+	for _, process := range source.Processes {
+		if process.Type == v1alpha1.WebProcessType {
+			continue
+		}
+
+		container, err := source.ForProcess(process).ToContainer(runtimeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("process %q: %v", process.Type, err)
+		}
+
+		appProcess := v1alpha1.AppSpecProcess{
+			Type:           process.Type,
+			Command:        container.Command,
+			Args:           container.Args,
+			Replicas:       process.Instances,
+			ReadinessProbe: container.ReadinessProbe,
+			LivenessProbe:  container.LivenessProbe,
+			StartupProbe:   container.StartupProbe,
+		}
+
+		if container.Resources.Requests != nil {
+			appProcess.Resources = &container.Resources
+		}
+
+		sidecars, err := source.ToSidecarContainers(process.Type)
+		if err != nil {
+			return nil, fmt.Errorf("process %q: %v", process.Type, err)
+		}
+		appProcess.Sidecars = sidecars
+
+		out = append(out, appProcess)
+	}
+
+	return out, nil
+}
+
+// ToSidecarContainers converts the sidecars that run next to the given process
+// type into containers. The containers get the App's image and environment
+// when they're deployed.
+func (source *Application) ToSidecarContainers(processType string) ([]corev1.Container, error) {
+	var out []corev1.Container
+	for _, sidecar := range source.Sidecars {
+		if !sets.NewString(sidecar.ProcessTypes...).Has(processType) {
+			continue
+		}
+
+		container := corev1.Container{
+			Name: sidecar.Name,
+			Args: []string{sidecar.Command},
+		}
+
+		if sidecar.Memory != "" {
+			memory, err := resource.ParseQuantity(CFToSIUnits(sidecar.Memory))
+			if err != nil {
+				return nil, fmt.Errorf("sidecar %q: couldn't parse resource quantity %s: %v", sidecar.Name, sidecar.Memory, err)
+			}
+
+			// Requests and limits match to mirror CF.
+			resources := corev1.ResourceList{corev1.ResourceMemory: memory}
+			container.Resources = corev1.ResourceRequirements{
+				Requests: resources,
+				Limits:   resources,
+			}
+		}
+
+		out = append(out, container)
+	}
+
+	return out, nil
+}
+
 // ToAppSpecInstances extracts scaling info from the manifest.
 func (source *Application) ToAppSpecInstances() v1alpha1.AppSpecInstances {
+	source = source.webApplication()
+
 	instances := v1alpha1.AppSpecInstances{}
 	if source.NoStart != nil {
 		instances.Stopped = *source.NoStart
@@ -117,25 +273,88 @@ func CFToSIUnits(orig string) string {
 }
 
 // ToHealthCheck creates a corev1.Probe that maps the health checks CloudFoundry
-// does.
+// does. It's used to determine if the App is alive.
 func (source *Application) ToHealthCheck() (*corev1.Probe, error) {
 	if source.HealthCheckTimeout < 0 {
 		return nil, errors.New("health check timeouts can't be negative")
 	}
 
+	return source.toProbe(source.HealthCheckType, source.HealthCheckHTTPEndpoint)
+}
+
+// ToReadinessHealthCheck creates a corev1.Probe that determines if the App can
+// receive traffic. Apps without a readiness health check use their health
+// check.
+func (source *Application) ToReadinessHealthCheck() (*corev1.Probe, error) {
+	if source.ReadinessHealthCheckType == "" && source.ReadinessHealthCheckHTTPEndpoint == "" {
+		probe, err := source.ToHealthCheck()
+		if probe != nil && source.ReadinessHealthCheckInterval > 0 {
+			probe.PeriodSeconds = int32(source.ReadinessHealthCheckInterval)
+		}
+		return probe, err
+	}
+
+	if source.ReadinessHealthCheckInterval < 0 {
+		return nil, errors.New("readiness health check intervals can't be negative")
+	}
+
+	probe, err := source.toProbe(source.ReadinessHealthCheckType, source.ReadinessHealthCheckHTTPEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid readiness health check: %v", err)
+	}
+
+	if probe != nil {
+		probe.PeriodSeconds = int32(source.ReadinessHealthCheckInterval)
+	}
+
+	return probe, nil
+}
+
+// ToStartupHealthCheck creates a corev1.Probe that gives the App its health
+// check timeout to become healthy before its other health checks start. Apps
+// without a health check timeout or a health check don't get one.
+func (source *Application) ToStartupHealthCheck() (*corev1.Probe, error) {
+	if source.HealthCheckTimeout == 0 {
+		return nil, nil
+	}
+
+	probe, err := source.ToHealthCheck()
+	if probe == nil || err != nil {
+		return nil, err
+	}
+
+	probe.PeriodSeconds = startupProbePeriodSeconds
+	probe.FailureThreshold = int32(math.Ceil(float64(source.HealthCheckTimeout) / startupProbePeriodSeconds))
+
+	return probe, nil
+}
+
+// toProbe creates a corev1.Probe for the health check type. Probes time out
+// after the health check invocation timeout, or the health check timeout if
+// it's not set.
+func (source *Application) toProbe(checkType, endpoint string) (*corev1.Probe, error) {
+	if source.HealthCheckInvocationTimeout < 0 {
+		return nil, errors.New("health check invocation timeouts can't be negative")
+	}
+
+	timeout := source.HealthCheckInvocationTimeout
+	if timeout == 0 {
+		timeout = source.HealthCheckTimeout
+	}
+
 	probe := &corev1.Probe{
-		TimeoutSeconds:   int32(source.HealthCheckTimeout),
+		TimeoutSeconds:   int32(timeout),
 		SuccessThreshold: 1,
 		ProbeHandler:     corev1.ProbeHandler{},
 	}
 
-	switch source.HealthCheckType {
+	switch checkType {
 	case "http":
-		probe.ProbeHandler.HTTPGet = &corev1.HTTPGetAction{Path: source.HealthCheckHTTPEndpoint}
+		probe.ProbeHandler.HTTPGet = &corev1.HTTPGetAction{Path: endpoint}
 		return probe, nil
 
 	case "port", "": // By default, cf uses a port based health check.
-		if source.HealthCheckHTTPEndpoint != "" {
+		if endpoint != "" {
 			return nil, errors.New("health check endpoints can only be used with http checks")
 		}
 
@@ -149,13 +368,15 @@ func (source *Application) ToHealthCheck() (*corev1.Probe, error) {
 		return nil, nil
 
 	default:
-		return nil, fmt.Errorf("unknown health check type %s, supported types are http and port", source.HealthCheckType)
+		return nil, fmt.Errorf("unknown health check type %s, supported types are http, port, and process", checkType)
 	}
 }
 
 // ToContainer converts the manifest to a container suitable for use in a pod,
 // ksvc, or app.
 func (source *Application) ToContainer(runtimeConfig *v1alpha1.SpaceStatusRuntimeConfig) (corev1.Container, error) {
+	source = source.webApplication()
+
 	resourceRequests, err := source.ToResourceRequests(runtimeConfig)
 	if err != nil {
 		return corev1.Container{}, err
@@ -166,6 +387,16 @@ func (source *Application) ToContainer(runtimeConfig *v1alpha1.SpaceStatusRuntim
 		return corev1.Container{}, err
 	}
 
+	readinessCheck, err := source.ToReadinessHealthCheck()
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
+	startupCheck, err := source.ToStartupHealthCheck()
+	if err != nil {
+		return corev1.Container{}, err
+	}
+
 	container := corev1.Container{
 		Args:    source.CommandArgs(),
 		Command: source.CommandEntrypoint(),
@@ -174,8 +405,9 @@ func (source *Application) ToContainer(runtimeConfig *v1alpha1.SpaceStatusRuntim
 			Requests: resourceRequests,
 			Limits:   resourceRequests,
 		},
-		ReadinessProbe: healthCheck,
+		ReadinessProbe: readinessCheck,
 		LivenessProbe:  healthCheck,
+		StartupProbe:   startupCheck,
 	}
 
 	if len(source.Env) > 0 {
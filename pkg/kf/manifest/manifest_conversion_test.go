@@ -196,6 +196,18 @@ func TestApplication_ToAppSpecInstances(t *testing.T) {
 				Stopped: true,
 			},
 		},
+		"web process instances": {
+			source: Application{
+				Instances: ptr.Int32(3),
+				Processes: []Process{
+					{Type: "worker", Instances: ptr.Int32(5)},
+					{Type: "web", Instances: ptr.Int32(2)},
+				},
+			},
+			expected: v1alpha1.AppSpecInstances{
+				Replicas: ptr.Int32(2),
+			},
+		},
 		"started app with instances": {
 			source: Application{
 				Instances: ptr.Int32(3),
@@ -220,16 +232,17 @@ func TestApplication_ToAppSpecInstances(t *testing.T) {
 
 func TestApplication_ToHealthCheck(t *testing.T) {
 	cases := map[string]struct {
-		checkType string
-		endpoint  string
-		timeout   int
+		checkType         string
+		endpoint          string
+		timeout           int
+		invocationTimeout int
 
 		expectProbe *corev1.Probe
 		expectErr   error
 	}{
 		"invalid type": {
 			checkType: "foo",
-			expectErr: errors.New("unknown health check type foo, supported types are http and port"),
+			expectErr: errors.New("unknown health check type foo, supported types are http, port, and process"),
 		},
 		"process type": {
 			checkType:   "process",
@@ -299,14 +312,31 @@ func TestApplication_ToHealthCheck(t *testing.T) {
 			endpoint:  "/healthz",
 			expectErr: errors.New("health check endpoints can only be used with http checks"),
 		},
+		"invocation timeout takes priority": {
+			checkType:         "port",
+			timeout:           180,
+			invocationTimeout: 5,
+			expectProbe: &corev1.Probe{
+				TimeoutSeconds:   int32(5),
+				SuccessThreshold: 1,
+				ProbeHandler: corev1.ProbeHandler{
+					TCPSocket: &corev1.TCPSocketAction{},
+				},
+			},
+		},
+		"negative invocation timeout": {
+			invocationTimeout: -1,
+			expectErr:         errors.New("health check invocation timeouts can't be negative"),
+		},
 	}
 
 	for tn, tc := range cases {
 		t.Run(tn, func(t *testing.T) {
 			app := Application{
-				HealthCheckType:         tc.checkType,
-				HealthCheckHTTPEndpoint: tc.endpoint,
-				HealthCheckTimeout:      tc.timeout,
+				HealthCheckType:              tc.checkType,
+				HealthCheckHTTPEndpoint:      tc.endpoint,
+				HealthCheckTimeout:           tc.timeout,
+				HealthCheckInvocationTimeout: tc.invocationTimeout,
 			}
 
 			actualProbe, actualErr := app.ToHealthCheck()
@@ -317,6 +347,162 @@ func TestApplication_ToHealthCheck(t *testing.T) {
 	}
 }
 
+func TestApplication_ToReadinessHealthCheck(t *testing.T) {
+	cases := map[string]struct {
+		app Application
+
+		expectProbe *corev1.Probe
+		expectErr   error
+	}{
+		"defaults to health check": {
+			app: Application{
+				HealthCheckType:         "http",
+				HealthCheckHTTPEndpoint: "/healthz",
+				HealthCheckTimeout:      30,
+			},
+			expectProbe: &corev1.Probe{
+				TimeoutSeconds:   int32(30),
+				SuccessThreshold: 1,
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"},
+				},
+			},
+		},
+		"interval with default check": {
+			app: Application{
+				ReadinessHealthCheckInterval: 5,
+			},
+			expectProbe: &corev1.Probe{
+				PeriodSeconds:    int32(5),
+				SuccessThreshold: 1,
+				ProbeHandler: corev1.ProbeHandler{
+					TCPSocket: &corev1.TCPSocketAction{},
+				},
+			},
+		},
+		"separate http check": {
+			app: Application{
+				HealthCheckType:                  "port",
+				HealthCheckInvocationTimeout:     2,
+				ReadinessHealthCheckType:         "http",
+				ReadinessHealthCheckHTTPEndpoint: "/ready",
+				ReadinessHealthCheckInterval:     15,
+			},
+			expectProbe: &corev1.Probe{
+				TimeoutSeconds:   int32(2),
+				PeriodSeconds:    int32(15),
+				SuccessThreshold: 1,
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{Path: "/ready"},
+				},
+			},
+		},
+		"process check": {
+			app: Application{
+				HealthCheckType:          "http",
+				ReadinessHealthCheckType: "process",
+			},
+			expectProbe: nil,
+		},
+		"endpoint without type": {
+			app: Application{
+				ReadinessHealthCheckHTTPEndpoint: "/ready",
+			},
+			expectErr: errors.New("invalid readiness health check: health check endpoints can only be used with http checks"),
+		},
+		"invalid type": {
+			app: Application{
+				ReadinessHealthCheckType: "foo",
+			},
+			expectErr: errors.New("invalid readiness health check: unknown health check type foo, supported types are http, port, and process"),
+		},
+		"negative interval": {
+			app: Application{
+				ReadinessHealthCheckType:     "port",
+				ReadinessHealthCheckInterval: -1,
+			},
+			expectErr: errors.New("readiness health check intervals can't be negative"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actualProbe, actualErr := tc.app.ToReadinessHealthCheck()
+
+			testutil.AssertErrorsEqual(t, tc.expectErr, actualErr)
+			testutil.AssertEqual(t, "probe", tc.expectProbe, actualProbe)
+		})
+	}
+}
+
+func TestApplication_ToStartupHealthCheck(t *testing.T) {
+	cases := map[string]struct {
+		app Application
+
+		expectProbe *corev1.Probe
+		expectErr   error
+	}{
+		"no timeout": {
+			app:         Application{HealthCheckType: "http"},
+			expectProbe: nil,
+		},
+		"process check": {
+			app: Application{
+				HealthCheckType:    "process",
+				HealthCheckTimeout: 60,
+			},
+			expectProbe: nil,
+		},
+		"timeout": {
+			app: Application{
+				HealthCheckType:              "http",
+				HealthCheckHTTPEndpoint:      "/healthz",
+				HealthCheckTimeout:           181,
+				HealthCheckInvocationTimeout: 3,
+			},
+			expectProbe: &corev1.Probe{
+				TimeoutSeconds:   int32(3),
+				PeriodSeconds:    int32(2),
+				FailureThreshold: int32(91),
+				SuccessThreshold: 1,
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"},
+				},
+			},
+		},
+		"timeout without invocation timeout": {
+			app: Application{
+				HealthCheckTimeout: 60,
+			},
+			expectProbe: &corev1.Probe{
+				TimeoutSeconds:   int32(60),
+				PeriodSeconds:    int32(2),
+				FailureThreshold: int32(30),
+				SuccessThreshold: 1,
+				ProbeHandler: corev1.ProbeHandler{
+					TCPSocket: &corev1.TCPSocketAction{},
+				},
+			},
+		},
+		"bad health check": {
+			app: Application{
+				HealthCheckType:    "foo",
+				HealthCheckTimeout: 60,
+			},
+			expectErr: errors.New("unknown health check type foo, supported types are http, port, and process"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actualProbe, actualErr := tc.app.ToStartupHealthCheck()
+
+			testutil.AssertErrorsEqual(t, tc.expectErr, actualErr)
+			testutil.AssertEqual(t, "probe", tc.expectProbe, actualProbe)
+		})
+	}
+}
+
 func TestApplication_ToContainer(t *testing.T) {
 	defaultHealthCheck := &corev1.Probe{
 		SuccessThreshold: 1,
@@ -354,12 +540,96 @@ func TestApplication_ToContainer(t *testing.T) {
 			runtimeConfig: defaultRuntimeConfig,
 			expectErr:     errors.New("couldn't parse resource quantity 21ZB: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'"),
 		},
+		"separate health checks": {
+			app: Application{
+				HealthCheckType:          "http",
+				HealthCheckTimeout:       10,
+				ReadinessHealthCheckType: "process",
+			},
+			runtimeConfig: defaultRuntimeConfig,
+			expectContainer: corev1.Container{
+				LivenessProbe: &corev1.Probe{
+					TimeoutSeconds:   int32(10),
+					SuccessThreshold: 1,
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{},
+					},
+				},
+				StartupProbe: &corev1.Probe{
+					TimeoutSeconds:   int32(10),
+					PeriodSeconds:    int32(2),
+					FailureThreshold: int32(5),
+					SuccessThreshold: 1,
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{},
+					},
+				},
+			},
+		},
+		"timeout only": {
+			// Manifests that only set a timeout get a startup probe in
+			// addition to the probes they had before.
+			app: Application{
+				HealthCheckTimeout: 30,
+			},
+			runtimeConfig: defaultRuntimeConfig,
+			expectContainer: corev1.Container{
+				ReadinessProbe: &corev1.Probe{
+					TimeoutSeconds:   int32(30),
+					SuccessThreshold: 1,
+					ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{},
+					},
+				},
+				LivenessProbe: &corev1.Probe{
+					TimeoutSeconds:   int32(30),
+					SuccessThreshold: 1,
+					ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{},
+					},
+				},
+				StartupProbe: &corev1.Probe{
+					TimeoutSeconds:   int32(30),
+					PeriodSeconds:    int32(2),
+					FailureThreshold: int32(15),
+					SuccessThreshold: 1,
+					ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{},
+					},
+				},
+			},
+		},
 		"bad health check": {
 			app: Application{
 				HealthCheckType: "NOT ALLOWED",
 			},
 			runtimeConfig: defaultRuntimeConfig,
-			expectErr:     errors.New("unknown health check type NOT ALLOWED, supported types are http and port"),
+			expectErr:     errors.New("unknown health check type NOT ALLOWED, supported types are http, port, and process"),
+		},
+		"web process overrides": {
+			app: Application{
+				Command:         "python app.py",
+				Memory:          "1G",
+				HealthCheckType: "process",
+				Processes: []Process{
+					{Type: "worker", Command: "python worker.py"},
+					{Type: "web", Command: "gunicorn app", Memory: "30M", HealthCheckType: "port"},
+				},
+			},
+			runtimeConfig: defaultRuntimeConfig,
+			expectContainer: corev1.Container{
+				Args:           []string{"gunicorn app"},
+				ReadinessProbe: defaultHealthCheck,
+				LivenessProbe:  defaultHealthCheck,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("30Mi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("30Mi"),
+					},
+				},
+			},
 		},
 		"full manifest": {
 			app: Application{
@@ -413,6 +683,190 @@ func TestApplication_ToContainer(t *testing.T) {
 	}
 }
 
+func TestApplication_ToAppSpecProcesses(t *testing.T) {
+	httpHealthCheck := &corev1.Probe{
+		SuccessThreshold: 1,
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: "/healthz"},
+		},
+	}
+
+	defaultRuntimeConfig := &v1alpha1.SpaceStatusRuntimeConfig{}
+
+	cases := map[string]struct {
+		app             Application
+		expectProcesses []v1alpha1.AppSpecProcess
+		expectErr       error
+	}{
+		"no processes": {
+			app: Application{},
+		},
+		"web process only": {
+			app: Application{
+				Processes: []Process{{Type: "web", Instances: ptr.Int32(2)}},
+			},
+		},
+		"worker inherits resources": {
+			app: Application{
+				Command:         "bundle exec rails server",
+				Memory:          "1G",
+				HealthCheckType: "http",
+				Processes: []Process{
+					{Type: "web"},
+					{Type: "worker", Command: "bundle exec sidekiq", Instances: ptr.Int32(3)},
+				},
+			},
+			expectProcesses: []v1alpha1.AppSpecProcess{
+				{
+					Type:     "worker",
+					Args:     []string{"bundle exec sidekiq"},
+					Replicas: ptr.Int32(3),
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			},
+		},
+		"process health check": {
+			app: Application{
+				Processes: []Process{
+					{
+						Type:                    "clock",
+						HealthCheckType:         "http",
+						HealthCheckHTTPEndpoint: "/healthz",
+					},
+				},
+			},
+			expectProcesses: []v1alpha1.AppSpecProcess{
+				{
+					Type:           "clock",
+					ReadinessProbe: httpHealthCheck,
+					LivenessProbe:  httpHealthCheck,
+				},
+			},
+		},
+		"process sidecars": {
+			app: Application{
+				Processes: []Process{
+					{Type: "worker", HealthCheckType: "process"},
+				},
+				Sidecars: []Sidecar{
+					{Name: "metrics-agent", Command: "./metrics-agent", ProcessTypes: []string{"web", "worker"}},
+					{Name: "web-only", Command: "./web-only", ProcessTypes: []string{"web"}},
+				},
+			},
+			expectProcesses: []v1alpha1.AppSpecProcess{
+				{
+					Type: "worker",
+					Sidecars: []corev1.Container{
+						{Name: "metrics-agent", Args: []string{"./metrics-agent"}},
+					},
+				},
+			},
+		},
+		"process doesn't inherit readiness check": {
+			app: Application{
+				ReadinessHealthCheckType:         "http",
+				ReadinessHealthCheckHTTPEndpoint: "/ready",
+				Processes: []Process{
+					{Type: "worker"},
+				},
+			},
+			expectProcesses: []v1alpha1.AppSpecProcess{
+				{Type: "worker"},
+			},
+		},
+		"bad process health check": {
+			app: Application{
+				Processes: []Process{
+					{Type: "worker", HealthCheckType: "NOT ALLOWED"},
+				},
+			},
+			expectErr: errors.New(`process "worker": unknown health check type NOT ALLOWED, supported types are http, port, and process`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actualProcesses, actualErr := tc.app.ToAppSpecProcesses(defaultRuntimeConfig)
+
+			testutil.AssertErrorsEqual(t, tc.expectErr, actualErr)
+			testutil.AssertEqual(t, "processes", tc.expectProcesses, actualProcesses)
+		})
+	}
+}
+
+func TestApplication_ToSidecarContainers(t *testing.T) {
+	cases := map[string]struct {
+		app              Application
+		processType      string
+		expectContainers []corev1.Container
+		expectErr        error
+	}{
+		"no sidecars": {
+			app:         Application{},
+			processType: "web",
+		},
+		"filters by process type": {
+			app: Application{
+				Sidecars: []Sidecar{
+					{Name: "metrics-agent", Command: "./metrics-agent", ProcessTypes: []string{"web"}},
+					{Name: "worker-only", Command: "./worker-only", ProcessTypes: []string{"worker"}},
+				},
+			},
+			processType: "web",
+			expectContainers: []corev1.Container{
+				{Name: "metrics-agent", Args: []string{"./metrics-agent"}},
+			},
+		},
+		"memory": {
+			app: Application{
+				Sidecars: []Sidecar{
+					{Name: "log-shipper", Command: "./log-shipper", Memory: "64M", ProcessTypes: []string{"web"}},
+				},
+			},
+			processType: "web",
+			expectContainers: []corev1.Container{
+				{
+					Name: "log-shipper",
+					Args: []string{"./log-shipper"},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+					},
+				},
+			},
+		},
+		"bad memory": {
+			app: Application{
+				Sidecars: []Sidecar{
+					{Name: "log-shipper", Command: "./log-shipper", Memory: "lots", ProcessTypes: []string{"web"}},
+				},
+			},
+			processType: "web",
+			expectErr:   errors.New(`sidecar "log-shipper": couldn't parse resource quantity lots: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actualContainers, actualErr := tc.app.ToSidecarContainers(tc.processType)
+
+			testutil.AssertErrorsEqual(t, tc.expectErr, actualErr)
+			testutil.AssertEqual(t, "containers", tc.expectContainers, actualContainers)
+		})
+	}
+}
+
 func TestCFToSIUnits(t *testing.T) {
 	cases := map[string]struct {
 		input        string
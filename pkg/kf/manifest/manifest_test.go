@@ -105,6 +105,101 @@ applications:
 				},
 			},
 		},
+		"processes": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  processes:
+  - type: web
+    command: bundle exec rails server
+    instances: 2
+  - type: worker
+    command: bundle exec sidekiq
+    memory: 512M
+    health-check-type: process
+    timeout: 30
+`,
+			expected: &manifest.Manifest{
+				RelativePathRoot: relativePathRoot,
+
+				Applications: []manifest.Application{
+					{
+						Name: "MY-APP",
+						Processes: []manifest.Process{
+							{
+								Type:      "web",
+								Command:   "bundle exec rails server",
+								Instances: ptr.Int32(2),
+							},
+							{
+								Type:               "worker",
+								Command:            "bundle exec sidekiq",
+								Memory:             "512M",
+								HealthCheckType:    "process",
+								HealthCheckTimeout: 30,
+							},
+						},
+					},
+				},
+			},
+		},
+		"health checks": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  health-check-type: port
+  health-check-invocation-timeout: 5
+  timeout: 120
+  readiness-health-check-type: http
+  readiness-health-check-http-endpoint: /ready
+  readiness-health-check-interval: 15
+`,
+			expected: &manifest.Manifest{
+				RelativePathRoot: relativePathRoot,
+
+				Applications: []manifest.Application{
+					{
+						Name:                             "MY-APP",
+						HealthCheckType:                  "port",
+						HealthCheckInvocationTimeout:     5,
+						HealthCheckTimeout:               120,
+						ReadinessHealthCheckType:         "http",
+						ReadinessHealthCheckHTTPEndpoint: "/ready",
+						ReadinessHealthCheckInterval:     15,
+					},
+				},
+			},
+		},
+		"sidecars": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  sidecars:
+  - name: metrics-agent
+    command: ./metrics-agent
+    memory: 64M
+    process_types:
+    - web
+    - worker
+`,
+			expected: &manifest.Manifest{
+				RelativePathRoot: relativePathRoot,
+
+				Applications: []manifest.Application{
+					{
+						Name: "MY-APP",
+						Sidecars: []manifest.Sidecar{
+							{
+								Name:         "metrics-agent",
+								Command:      "./metrics-agent",
+								Memory:       "64M",
+								ProcessTypes: []string{"web", "worker"},
+							},
+						},
+					},
+				},
+			},
+		},
 		"legacy-buildpack": {
 			fileContent: `---
 applications:
@@ -239,6 +334,60 @@ applications:
 	}
 }
 
+func TestNewFromFile_inherit(t *testing.T) {
+	cases := map[string]struct {
+		path      string
+		variables map[string]interface{}
+
+		expected  *manifest.Manifest
+		expectErr error
+	}{
+		"inherits defaults": {
+			path:      "testdata/inherit/manifest.yml",
+			variables: map[string]interface{}{"region": "us-east1"},
+			expected: &manifest.Manifest{
+				RelativePathRoot: "testdata/inherit",
+				Applications: []manifest.Application{
+					{
+						Name:      "orders",
+						Memory:    "512M",
+						Instances: ptr.Int32(4),
+						Env: map[string]string{
+							"LOG_LEVEL": "debug",
+							"REGION":    "us-east1",
+						},
+					},
+					{
+						Name:      "payments",
+						Memory:    "1G",
+						Instances: ptr.Int32(2),
+						Env: map[string]string{
+							"LOG_LEVEL": "info",
+							"REGION":    "us-east1",
+						},
+					},
+				},
+			},
+		},
+		"base uses variables": {
+			path:      "testdata/inherit/manifest.yml",
+			expectErr: errors.New(`couldn't read inherited manifest "base.yml": no variables found for keys: ((region))`),
+		},
+		"inheritance loop": {
+			path:      "testdata/inherit/loop-a.yml",
+			expectErr: errors.New(`couldn't read inherited manifest "loop-b.yml": couldn't read inherited manifest "loop-a.yml": manifest "testdata/inherit/loop-a.yml" inherits from itself`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual, err := manifest.NewFromFile(context.Background(), tc.path, tc.variables)
+			testutil.AssertErrorsEqual(t, tc.expectErr, err)
+			testutil.AssertEqual(t, "manifest", tc.expected, actual)
+		})
+	}
+}
+
 func TestCheckForManifest(t *testing.T) {
 	pwd, err := os.Getwd()
 	if err != nil {
@@ -328,6 +477,23 @@ func TestOverride(t *testing.T) {
 			override: manifest.Application{Buildpacks: []string{"node", "npm"}},
 			expected: manifest.Application{Buildpacks: []string{"node", "npm"}},
 		},
+		"overrides take precedence over web process": {
+			base: manifest.Application{
+				Processes: []manifest.Process{
+					{Type: "web", Command: "rails server", Instances: ptr.Int32(2), Memory: "1G"},
+					{Type: "worker", Command: "sidekiq", Instances: ptr.Int32(2)},
+				},
+			},
+			override: manifest.Application{Instances: ptr.Int32(5), Memory: "2G"},
+			expected: manifest.Application{
+				Instances: ptr.Int32(5),
+				Memory:    "2G",
+				Processes: []manifest.Process{
+					{Type: "web", Command: "rails server"},
+					{Type: "worker", Command: "sidekiq", Instances: ptr.Int32(2)},
+				},
+			},
+		},
 		"no start, no override": {
 			base:     manifest.Application{KfApplicationExtension: manifest.KfApplicationExtension{NoStart: ptr.Bool(true)}},
 			override: manifest.Application{},
@@ -433,7 +599,7 @@ func ExampleApplication_WriteWarnings() {
 
 	// Output:
 	// WARN The field(s) [no-start ports] are Kf-specific manifest extensions and may change.
-	// WARN Kf supports TCP ports but currently only HTTP Routes. TCP ports can be reached on the App's cluster-internal app-<name>.<space>.svc.cluster.local address.
+	// WARN TCP ports can only be reached from outside the cluster with TCP Routes on a tcp domain. TCP ports can also be reached on the App's cluster-internal app-<name>.<space>.svc.cluster.local address.
 	// WARN Underscores ('_') in names are not allowed in Kubernetes. Replacing with hyphens ('-')...
 }
 
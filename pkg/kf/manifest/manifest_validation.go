@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	kfapis "github.com/google/kf/v2/pkg/apis/kf"
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
@@ -51,6 +52,29 @@ func (app *Application) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(app.Ports.Validate(ctx).ViaField("ports"))
 
+	processTypes := sets.NewString()
+	for i, process := range app.Processes {
+		switch {
+		case process.Type == "":
+			errs = errs.Also(apis.ErrMissingField("type").ViaFieldIndex("processes", i))
+		case processTypes.Has(process.Type):
+			errs = errs.Also(kfapis.ErrDuplicateValue(process.Type, "type").ViaFieldIndex("processes", i))
+		}
+		processTypes.Insert(process.Type)
+	}
+
+	sidecarNames := sets.NewString()
+	for i, sidecar := range app.Sidecars {
+		errs = errs.Also(sidecar.Validate(ctx).ViaFieldIndex("sidecars", i))
+
+		if sidecarNames.Has(sidecar.Name) {
+			errs = errs.Also(kfapis.ErrDuplicateValue(sidecar.Name, "name").ViaFieldIndex("sidecars", i))
+		}
+		if sidecar.Name != "" {
+			sidecarNames.Insert(sidecar.Name)
+		}
+	}
+
 	okRoutePorts := sets.NewInt(0) // 0 means default
 	for _, port := range app.Ports {
 		okRoutePorts.Insert(int(port.Port))
@@ -64,6 +88,26 @@ func (app *Application) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return
 }
 
+// Validate implements apis.Validatable
+func (s *Sidecar) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch s.Name {
+	case "":
+		errs = errs.Also(apis.ErrMissingField("name"))
+	case v1alpha1.DefaultUserContainerName:
+		errs = errs.Also(apis.ErrInvalidValue(s.Name, "name", "the name is reserved for the App's container"))
+	}
+
+	if s.Command == "" {
+		errs = errs.Also(apis.ErrMissingField("command"))
+	}
+
+	if len(s.ProcessTypes) == 0 {
+		errs = errs.Also(apis.ErrMissingField("process_types"))
+	}
+
+	return
+}
+
 // Validate implements apis.Validatable
 func (a AppPortList) Validate(ctx context.Context) (errs *apis.FieldError) {
 	seen := sets.NewInt()
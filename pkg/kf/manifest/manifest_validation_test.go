@@ -58,6 +58,66 @@ func TestApplication_Validation(t *testing.T) {
 			},
 			want: apis.ErrMultipleOneOf("entrypoint", "command"),
 		},
+		"processes": {
+			spec: Application{
+				Processes: []Process{
+					{Type: "web"},
+					{Type: "worker", Command: "bundle exec sidekiq"},
+				},
+			},
+		},
+		"process missing type": {
+			spec: Application{
+				Processes: []Process{
+					{Command: "bundle exec sidekiq"},
+				},
+			},
+			want: apis.ErrMissingField("processes[0].type"),
+		},
+		"duplicate process types": {
+			spec: Application{
+				Processes: []Process{
+					{Type: "worker"},
+					{Type: "worker"},
+				},
+			},
+			want: kfapis.ErrDuplicateValue("worker", "processes[1].type"),
+		},
+		"sidecars": {
+			spec: Application{
+				Sidecars: []Sidecar{
+					{Name: "metrics-agent", Command: "./metrics-agent", ProcessTypes: []string{"web"}},
+					{Name: "log-shipper", Command: "./log-shipper", ProcessTypes: []string{"web", "worker"}},
+				},
+			},
+		},
+		"sidecar missing fields": {
+			spec: Application{
+				Sidecars: []Sidecar{{}},
+			},
+			want: apis.ErrMissingField(
+				"sidecars[0].name",
+				"sidecars[0].command",
+				"sidecars[0].process_types",
+			),
+		},
+		"duplicate sidecar names": {
+			spec: Application{
+				Sidecars: []Sidecar{
+					{Name: "metrics-agent", Command: "./metrics-agent", ProcessTypes: []string{"web"}},
+					{Name: "metrics-agent", Command: "./metrics-agent", ProcessTypes: []string{"worker"}},
+				},
+			},
+			want: kfapis.ErrDuplicateValue("metrics-agent", "sidecars[1].name"),
+		},
+		"reserved sidecar name": {
+			spec: Application{
+				Sidecars: []Sidecar{
+					{Name: "user-container", Command: "./metrics-agent", ProcessTypes: []string{"web"}},
+				},
+			},
+			want: apis.ErrInvalidValue("user-container", "sidecars[0].name", "the name is reserved for the App's container"),
+		},
 		"buildpack and buildpacks": {
 			spec: Application{
 				LegacyBuildpack: "default",
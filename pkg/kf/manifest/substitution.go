@@ -16,9 +16,11 @@ package manifest
 
 import (
 	"fmt"
+	"io/ioutil"
 	"regexp"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
 )
 
@@ -31,13 +33,23 @@ var variableRegex = regexp.MustCompile(substitutionPattern)
 var wholeStringRegex = regexp.MustCompile(`^` + substitutionPattern + `$`)
 
 // ApplySubstitution applies variable substitution to a YAML or JSON byte array.
-// Variables in the source are specified using ((VARNAME)) syntax.
+// Variables in the source are specified using ((VARNAME)) syntax. All
+// variables without a value are reported in the returned error.
 func ApplySubstitution(source []byte, variables map[string]interface{}) ([]byte, error) {
 	var obj map[string]interface{}
 	if err := yaml.Unmarshal(source, &obj); err != nil {
 		return nil, err
 	}
 
+	if missing := missingVariables(obj, variables); missing.Len() > 0 {
+		var keys []string
+		for _, key := range missing.List() {
+			keys = append(keys, fmt.Sprintf("((%s))", key))
+		}
+
+		return nil, fmt.Errorf("no variables found for keys: %s", strings.Join(keys, ", "))
+	}
+
 	out, err := substitute(obj, variables)
 	if err != nil {
 		return nil, err
@@ -101,6 +113,115 @@ func substitute(obj interface{}, variables map[string]interface{}) (interface{},
 	}
 }
 
+// missingVariables returns the names of the variables in obj that don't have
+// a value.
+func missingVariables(obj interface{}, variables map[string]interface{}) sets.String {
+	missing := sets.NewString()
+
+	switch typed := obj.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			missing = missing.Union(missingVariables(k, variables))
+			missing = missing.Union(missingVariables(v, variables))
+		}
+
+	case []interface{}:
+		for _, v := range typed {
+			missing = missing.Union(missingVariables(v, variables))
+		}
+
+	case string:
+		for _, match := range variableRegex.FindAllString(typed, -1) {
+			key := strings.TrimSuffix(strings.TrimPrefix(match, "(("), "))")
+			if _, ok := variables[key]; !ok {
+				missing.Insert(key)
+			}
+		}
+	}
+
+	return missing
+}
+
+// LoadVariables reads the variables used for substitution from YAML or JSON
+// files and NAME=VALUE pairs. Each pair may hold several comma separated
+// NAME=VALUE entries. Files are read in order with later files taking
+// precedence, and pairs take precedence over all files.
+func LoadVariables(varsFiles []string, vars []string) (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+
+	for _, path := range varsFiles {
+		bytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read var-file %q: %v", path, err)
+		}
+
+		fileVariables := make(map[string]interface{})
+		if err = yaml.Unmarshal(bytes, &fileVariables); err != nil {
+			return nil, fmt.Errorf("invalid var-file %q: %v", path, err)
+		}
+
+		for k, v := range fileVariables {
+			variables[k] = v
+		}
+	}
+
+	for _, flag := range vars {
+		pairs, err := splitVarPairs(flag)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pair := range pairs {
+			parts := strings.SplitN(pair, "=", 2)
+			variables[parts[0]] = parts[1]
+		}
+	}
+
+	return variables, nil
+}
+
+// splitVarPairs splits a --var value holding one or more comma separated
+// NAME=VALUE pairs. Commas escaped as \, never split pairs. Segments without
+// a NAME= prefix are part of the previous value so values can still contain
+// unescaped commas.
+func splitVarPairs(flag string) ([]string, error) {
+	var pairs []string
+	for _, segment := range splitVarSegments(flag) {
+		if name := strings.SplitN(segment, "=", 2)[0]; name != segment && name != "" {
+			pairs = append(pairs, segment)
+			continue
+		}
+
+		if len(pairs) == 0 {
+			return nil, fmt.Errorf("invalid var %q, expected NAME=VALUE", flag)
+		}
+		pairs[len(pairs)-1] += "," + segment
+	}
+
+	return pairs, nil
+}
+
+// splitVarSegments splits a --var value on commas that aren't escaped as \,
+// and unescapes the escaped ones.
+func splitVarSegments(flag string) []string {
+	var segments []string
+	var segment strings.Builder
+	for i := 0; i < len(flag); i++ {
+		switch {
+		case flag[i] == '\\' && i+1 < len(flag) && flag[i+1] == ',':
+			segment.WriteByte(',')
+			i++
+		case flag[i] == ',':
+			segments = append(segments, segment.String())
+			segment.Reset()
+		default:
+			segment.WriteByte(flag[i])
+		}
+	}
+
+	return append(segments, segment.String())
+}
+
 func findSubstitution(key string, variables map[string]interface{}) (interface{}, error) {
 	key = strings.TrimPrefix(key, "((")
 	key = strings.TrimSuffix(key, "))")
@@ -33,7 +33,7 @@ func TestApplySubstitution(t *testing.T) {
 		"missing substitution": {
 			input:     "name: ((name))",
 			variables: nil,
-			wantErr:   errors.New("no variable found for key: ((name))"),
+			wantErr:   errors.New("no variables found for keys: ((name))"),
 		},
 		"nested map": {
 			input: `
@@ -157,6 +157,111 @@ func Test_substitute(t *testing.T) {
 	}
 }
 
+func Test_missingVariables(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		obj       interface{}
+		variables map[string]interface{}
+
+		wantMissing []string
+	}{
+		"none missing": {
+			obj:       map[string]interface{}{"((key))": "((value))"},
+			variables: map[string]interface{}{"key": 1, "value": 2},
+		},
+		"keys and values": {
+			obj: map[string]interface{}{
+				"((key))": []interface{}{"((first))-((second))", 3},
+				"other":   "((first))",
+			},
+			variables:   map[string]interface{}{"second": 2},
+			wantMissing: []string{"first", "key"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gotMissing := missingVariables(tc.obj, tc.variables)
+
+			testutil.AssertEqual(t, "missing", tc.wantMissing, gotMissing.List())
+		})
+	}
+}
+
+func TestLoadVariables(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		varsFiles []string
+		vars      []string
+
+		wantVariables map[string]interface{}
+		wantErr       error
+	}{
+		"empty": {
+			wantVariables: map[string]interface{}{},
+		},
+		"later files take precedence": {
+			varsFiles: []string{"testdata/vars/common.yml", "testdata/vars/prod.yml"},
+			wantVariables: map[string]interface{}{
+				"region":    "us-east1",
+				"instances": float64(5),
+			},
+		},
+		"vars take precedence over files": {
+			varsFiles: []string{"testdata/vars/common.yml"},
+			vars:      []string{"region=europe-west1", "url=http://a.test/?b=c,d"},
+			wantVariables: map[string]interface{}{
+				"region":    "europe-west1",
+				"instances": float64(2),
+				"url":       "http://a.test/?b=c,d",
+			},
+		},
+		"comma separated vars": {
+			vars: []string{"a=b,c=d", "e=f,g,h=i"},
+			wantVariables: map[string]interface{}{
+				"a": "b",
+				"c": "d",
+				"e": "f,g",
+				"h": "i",
+			},
+		},
+		"escaped commas": {
+			vars: []string{`query=x=1\,y=2`, `list=a\,b,c=d`, `path=C:\dir`},
+			wantVariables: map[string]interface{}{
+				"query": "x=1,y=2",
+				"list":  "a,b",
+				"c":     "d",
+				"path":  `C:\dir`,
+			},
+		},
+		"missing file": {
+			varsFiles: []string{"testdata/vars/dne.yml"},
+			wantErr:   errors.New(`couldn't read var-file "testdata/vars/dne.yml": open testdata/vars/dne.yml: no such file or directory`),
+		},
+		"invalid var": {
+			vars:    []string{"=value"},
+			wantErr: errors.New(`invalid var "=value", expected NAME=VALUE`),
+		},
+		"var without name": {
+			vars:    []string{"value,a=b"},
+			wantErr: errors.New(`invalid var "value,a=b", expected NAME=VALUE`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gotVariables, gotErr := LoadVariables(tc.varsFiles, tc.vars)
+
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			if gotErr == nil {
+				testutil.AssertEqual(t, "variables", tc.wantVariables, gotVariables)
+			}
+		})
+	}
+}
+
 func Test_findSubstitution(t *testing.T) {
 	t.Parallel()
 
@@ -162,13 +162,41 @@ func (c *Client) Marketplace(ctx context.Context, namespace string) (*KfMarketpl
 			return nil, err
 		}
 		for i := range brokers.Items {
-			out.Brokers = append(out.Brokers, &brokers.Items[i])
+			broker := &brokers.Items[i]
+			if namespace != "" {
+				broker.Status.Services = visibleOfferings(broker, namespace)
+			}
+			out.Brokers = append(out.Brokers, broker)
 		}
 	}
 
 	return &out, nil
 }
 
+// visibleOfferings returns the broker's offerings with the plans that aren't
+// visible in the Space removed. Offerings without any visible plans are
+// removed entirely.
+func visibleOfferings(broker v1alpha1.CommonServiceBroker, space string) []v1alpha1.ServiceOffering {
+	var out []v1alpha1.ServiceOffering
+	for _, offering := range broker.GetServiceOfferings() {
+		var plans []v1alpha1.ServicePlan
+		for _, plan := range offering.Plans {
+			if broker.IsPlanVisible(offering.DisplayName, plan.DisplayName, space) {
+				plans = append(plans, plan)
+			}
+		}
+
+		if len(plans) == 0 {
+			continue
+		}
+
+		offering.Plans = plans
+		out = append(out, offering)
+	}
+
+	return out
+}
+
 // ListPlanOptions holds additional filtering options used when listing plans.
 type ListPlanOptions struct {
 	PlanName    string
@@ -63,8 +63,26 @@ func TestClient_Marketplace(t *testing.T) {
 					{DisplayName: "some-cluster-plan"},
 				},
 			},
+			{
+				DisplayName: "limited-class",
+				Plans: []v1alpha1.ServicePlan{
+					{DisplayName: "visible-plan"},
+					{DisplayName: "hidden-plan"},
+				},
+			},
+			{
+				DisplayName: "disabled-class",
+				Plans: []v1alpha1.ServicePlan{
+					{DisplayName: "disabled-plan"},
+				},
+			},
 		},
 	}
+	clusterBroker.Spec.PlanVisibilities = []v1alpha1.ServicePlanVisibility{
+		{ServiceName: "limited-class", PlanName: "visible-plan", Spaces: []string{namespace}},
+		{ServiceName: "limited-class", PlanName: "hidden-plan", Spaces: []string{"other-ns"}},
+		{ServiceName: "disabled-class", PlanName: "disabled-plan"},
+	}
 	volumeBroker := &v1alpha1.ClusterServiceBroker{}
 	volumeBroker.Name = "volume-broker-a"
 	volumeBroker.Status = v1alpha1.CommonServiceBrokerStatus{
@@ -98,6 +116,7 @@ func TestClient_Marketplace(t *testing.T) {
 
 	testutil.AssertEqual(t, "plans", sets.NewString(
 		"/broker-a/db-service/free",
+		"/broker-a/limited-class/visible-plan",
 		"/broker-a/some-cluster-class/some-cluster-plan",
 		"/volume-broker-a/volume-class/volume-plan",
 		"some-kf-ns/ns-broker-a/db-service-ns/free",
@@ -105,6 +124,7 @@ func TestClient_Marketplace(t *testing.T) {
 	), planNames)
 	testutil.AssertEqual(t, "classes", sets.NewString(
 		"/broker-a/db-service",
+		"/broker-a/limited-class",
 		"/broker-a/some-cluster-class",
 		"/volume-broker-a/volume-class",
 		"some-kf-ns/ns-broker-a/db-service-ns",
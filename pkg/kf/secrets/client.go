@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	v1 "k8s.io/api/core/v1"
@@ -31,6 +32,7 @@ import (
 type ClientExtension interface {
 	CreateParamsSecret(ctx context.Context, owner kmeta.OwnerRefable, name string, params json.RawMessage) (*v1.Secret, error)
 	UpdateParamsSecret(ctx context.Context, namespace string, name string, params json.RawMessage) (*v1.Secret, error)
+	UpdateServiceInstanceParams(ctx context.Context, namespace string, name string, updateRequests int, params json.RawMessage) (*v1.Secret, error)
 }
 
 // NewClient creates a new service client.
@@ -57,6 +59,54 @@ func (core *coreClient) UpdateParamsSecret(ctx context.Context, namespace string
 	return core.kclient.Secrets(namespace).Patch(ctx, name, types.JSONPatchType, data, metav1.PatchOptions{})
 }
 
+// UpdateServiceInstanceParams records the parameters for an update to a
+// brokered service instance in its parameters secret. See MergeUpdateParams.
+func (core *coreClient) UpdateServiceInstanceParams(ctx context.Context, namespace string, name string, updateRequests int, params json.RawMessage) (*v1.Secret, error) {
+	return core.Transform(ctx, namespace, name, func(secret *v1.Secret) error {
+		return MergeUpdateParams(secret, updateRequests, params)
+	})
+}
+
+// MergeUpdateParams merges the parameters of an update into the instance's
+// parameters because OSB update parameters only contain the values being
+// changed. The update parameters are also kept on their own and marked with
+// the UpdateRequests value they'll be sent to the service broker with.
+func MergeUpdateParams(secret *v1.Secret, updateRequests int, params json.RawMessage) error {
+	merged := make(map[string]interface{})
+	if existing := secret.Data[v1alpha1.ServiceInstanceParamsSecretKey]; len(existing) > 0 {
+		if err := json.Unmarshal(existing, &merged); err != nil {
+			return fmt.Errorf("couldn't read existing parameters: %s", err)
+		}
+	}
+
+	update := make(map[string]interface{})
+	if err := json.Unmarshal(params, &update); err != nil {
+		return fmt.Errorf("couldn't read update parameters: %s", err)
+	}
+
+	for key, value := range update {
+		merged[key] = value
+	}
+
+	mergedJSON, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	secret.Data[v1alpha1.ServiceInstanceParamsSecretKey] = mergedJSON
+	secret.Data[v1alpha1.ServiceInstanceUpdateParamsSecretKey] = params
+
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[v1alpha1.ServiceInstanceUpdateRequestsAnnotation] = strconv.Itoa(updateRequests)
+
+	return nil
+}
+
 // BuildParamsSecret builds a secret that holds parameters for a service instance
 // or binding.
 func BuildParamsSecret(owner kmeta.OwnerRefable, name string, params json.RawMessage) *v1.Secret {
@@ -90,3 +90,54 @@ func TestCreateJSONPatch(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeUpdateParams(t *testing.T) {
+	tests := map[string]struct {
+		secret *v1.Secret
+		params json.RawMessage
+
+		wantErr          bool
+		wantParams       string
+		wantUpdateParams string
+	}{
+		"merges keys": {
+			secret: &v1.Secret{
+				Data: map[string][]byte{
+					"params": []byte(`{"region":"us-east1","ram_gb":4}`),
+				},
+			},
+			params:           json.RawMessage(`{"ram_gb":8}`),
+			wantParams:       `{"ram_gb":8,"region":"us-east1"}`,
+			wantUpdateParams: `{"ram_gb":8}`,
+		},
+		"no existing params": {
+			secret:           &v1.Secret{},
+			params:           json.RawMessage(`{"ram_gb":8}`),
+			wantParams:       `{"ram_gb":8}`,
+			wantUpdateParams: `{"ram_gb":8}`,
+		},
+		"bad existing params": {
+			secret: &v1.Secret{
+				Data: map[string][]byte{
+					"params": []byte(`{[]}`),
+				},
+			},
+			params:  json.RawMessage(`{"ram_gb":8}`),
+			wantErr: true,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			err := MergeUpdateParams(tc.secret, 3, tc.params)
+			testutil.AssertEqual(t, "wantErr", tc.wantErr, err != nil)
+			if err != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "params", tc.wantParams, string(tc.secret.Data[v1alpha1.ServiceInstanceParamsSecretKey]))
+			testutil.AssertEqual(t, "updateParams", tc.wantUpdateParams, string(tc.secret.Data[v1alpha1.ServiceInstanceUpdateParamsSecretKey]))
+			testutil.AssertEqual(t, "updateRequests", "3", tc.secret.Annotations[v1alpha1.ServiceInstanceUpdateRequestsAnnotation])
+		})
+	}
+}
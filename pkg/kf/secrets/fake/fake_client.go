@@ -158,6 +158,21 @@ func (mr *FakeClientMockRecorder) UpdateParamsSecret(arg0, arg1, arg2, arg3 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateParamsSecret", reflect.TypeOf((*FakeClient)(nil).UpdateParamsSecret), arg0, arg1, arg2, arg3)
 }
 
+// UpdateServiceInstanceParams mocks base method.
+func (m *FakeClient) UpdateServiceInstanceParams(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 json.RawMessage) (*v1.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateServiceInstanceParams", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*v1.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateServiceInstanceParams indicates an expected call of UpdateServiceInstanceParams.
+func (mr *FakeClientMockRecorder) UpdateServiceInstanceParams(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceInstanceParams", reflect.TypeOf((*FakeClient)(nil).UpdateServiceInstanceParams), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Upsert mocks base method.
 func (m *FakeClient) Upsert(arg0 context.Context, arg1 string, arg2 *v1.Secret, arg3 secrets.Merger) (*v1.Secret, error) {
 	m.ctrl.T.Helper()
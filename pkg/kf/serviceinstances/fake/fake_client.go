@@ -156,6 +156,21 @@ func (mr *FakeClientMockRecorder) WaitFor(arg0, arg1, arg2, arg3, arg4 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitFor", reflect.TypeOf((*FakeClient)(nil).WaitFor), arg0, arg1, arg2, arg3, arg4)
 }
 
+// WaitForConditionInstanceUpdatedTrue mocks base method.
+func (m *FakeClient) WaitForConditionInstanceUpdatedTrue(arg0 context.Context, arg1, arg2 string, arg3 time.Duration) (*v1alpha1.ServiceInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForConditionInstanceUpdatedTrue", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*v1alpha1.ServiceInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForConditionInstanceUpdatedTrue indicates an expected call of WaitForConditionInstanceUpdatedTrue.
+func (mr *FakeClientMockRecorder) WaitForConditionInstanceUpdatedTrue(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForConditionInstanceUpdatedTrue", reflect.TypeOf((*FakeClient)(nil).WaitForConditionInstanceUpdatedTrue), arg0, arg1, arg2, arg3)
+}
+
 // WaitForConditionParamsSecretReadyTrue mocks base method.
 func (m *FakeClient) WaitForConditionParamsSecretReadyTrue(arg0 context.Context, arg1, arg2 string, arg3 time.Duration) (*v1alpha1.ServiceInstance, error) {
 	m.ctrl.T.Helper()
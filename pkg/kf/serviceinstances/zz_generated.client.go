@@ -1,4 +1,4 @@
-// Copyright 2022 Google LLC
+// Copyright 2026 Google LLC
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -78,6 +78,7 @@ func (*ResourceInfo) FriendlyName() string {
 var (
 	ConditionReady             = apis.ConditionType(v1alpha1.ServiceInstanceConditionReady)
 	ConditionParamsSecretReady = apis.ConditionType(v1alpha1.ServiceInstanceConditionParamsSecretReady)
+	ConditionInstanceUpdated   = apis.ConditionType(v1alpha1.ServiceInstanceConditionInstanceUpdated)
 )
 
 // Predicate is a boolean function for a v1alpha1.ServiceInstance.
@@ -127,6 +128,7 @@ type Client interface {
 	WaitForDeletion(ctx context.Context, namespace string, name string, interval time.Duration) (*v1alpha1.ServiceInstance, error)
 	WaitForConditionReadyTrue(ctx context.Context, namespace string, name string, interval time.Duration) (*v1alpha1.ServiceInstance, error)
 	WaitForConditionParamsSecretReadyTrue(ctx context.Context, namespace string, name string, interval time.Duration) (*v1alpha1.ServiceInstance, error)
+	WaitForConditionInstanceUpdatedTrue(ctx context.Context, namespace string, name string, interval time.Duration) (*v1alpha1.ServiceInstance, error)
 
 	// ClientExtension can be used by the developer to extend the client.
 	ClientExtension
@@ -365,3 +367,14 @@ func ConditionParamsSecretReadyTrue(obj *v1alpha1.ServiceInstance, err error) (b
 func (core *coreClient) WaitForConditionParamsSecretReadyTrue(ctx context.Context, namespace string, name string, interval time.Duration) (instance *v1alpha1.ServiceInstance, err error) {
 	return core.waitForE(ctx, namespace, name, interval, ConditionParamsSecretReadyTrue)
 }
+
+// ConditionInstanceUpdatedTrue is a ConditionFuncE that waits for Condition{InstanceUpdated v1alpha1.ServiceInstanceConditionInstanceUpdated } to
+// become true and fails with an error if the condition becomes false.
+func ConditionInstanceUpdatedTrue(obj *v1alpha1.ServiceInstance, err error) (bool, error) {
+	return checkConditionTrue(obj, err, ConditionInstanceUpdated)
+}
+
+// WaitForConditionInstanceUpdatedTrue is a utility function that combines waitForE with ConditionInstanceUpdatedTrue.
+func (core *coreClient) WaitForConditionInstanceUpdatedTrue(ctx context.Context, namespace string, name string, interval time.Duration) (instance *v1alpha1.ServiceInstance, err error) {
+	return core.waitForE(ctx, namespace, name, interval, ConditionInstanceUpdatedTrue)
+}
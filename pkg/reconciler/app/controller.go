@@ -28,6 +28,8 @@ import (
 	serviceinstancebindinginformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstancebinding"
 	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	networkingclient "github.com/google/kf/v2/pkg/client/networking/injection/client"
+	serviceentryinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/serviceentry"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -38,7 +40,7 @@ import (
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/tools/cache"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
-	autoscalinginformer "knative.dev/pkg/client/injection/kube/informers/autoscaling/v1/horizontalpodautoscaler"
+	autoscalinginformer "knative.dev/pkg/client/injection/kube/informers/autoscaling/v2/horizontalpodautoscaler"
 	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	serviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount"
 	"knative.dev/pkg/configmap"
@@ -60,6 +62,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	serviceInformer := serviceinformer.Get(ctx)
 	serviceAccountInformer := serviceaccountinformer.Get(ctx)
 	hpaInformer := autoscalinginformer.Get(ctx)
+	serviceEntryInformer := serviceentryinformer.Get(ctx)
 
 	appLister := appInformer.Lister()
 
@@ -90,6 +93,8 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		serviceAccountLister:         serviceAccountInformer.Lister(),
 		autoscalingLister:            hpaInformer.Lister(),
 		adxBuildLister:               adxBuildInformer.Lister(),
+		serviceEntryLister:           serviceEntryInformer.Lister(),
+		networkingClientSet:          networkingclient.Get(ctx),
 	}
 
 	// We only want to start this informer if the ADX build type is installed.
@@ -125,6 +130,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		serviceInformer.Informer(),
 		serviceAccountInformer.Informer(),
 		hpaInformer.Informer(),
+		serviceEntryInformer.Informer(),
 	} {
 		informer.AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("App")),
@@ -0,0 +1,180 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	networking "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/app/resources"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// reconcileInternalService creates or updates the headless Service and
+// ServiceEntry that make the App discoverable on the Space's internal domain.
+// Both are deleted if the Space doesn't have an internal domain.
+func (r *Reconciler) reconcileInternalService(
+	ctx context.Context,
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+) error {
+	logger := logging.FromContext(ctx)
+
+	desiredService := resources.MakeInternalService(app)
+	actualService, err := r.serviceLister.Services(desiredService.Namespace).Get(desiredService.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		actualService = nil
+	case err != nil:
+		return err
+	case !metav1.IsControlledBy(actualService, app):
+		return fmt.Errorf("service %q is not owned by App %q", desiredService.Name, app.Name)
+	}
+
+	actualEntry, err := r.serviceEntryLister.ServiceEntries(desiredService.Namespace).Get(desiredService.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		actualEntry = nil
+	case err != nil:
+		return err
+	case !metav1.IsControlledBy(actualEntry, app):
+		return fmt.Errorf("service entry %q is not owned by App %q", desiredService.Name, app.Name)
+	}
+
+	if resources.InternalHostname(app, space) == "" {
+		if actualEntry != nil {
+			logger.Infof("Deleting internal ServiceEntry %s", actualEntry.Name)
+			if err := r.networkingClientSet.
+				NetworkingV1alpha3().
+				ServiceEntries(actualEntry.Namespace).
+				Delete(ctx, actualEntry.Name, metav1.DeleteOptions{}); err != nil {
+				return err
+			}
+		}
+
+		if actualService != nil {
+			logger.Infof("Deleting internal Service %s", actualService.Name)
+			if err := r.KubeClientSet.CoreV1().Services(actualService.Namespace).Delete(ctx, actualService.Name, metav1.DeleteOptions{}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if actualService == nil {
+		if _, err := r.KubeClientSet.CoreV1().Services(desiredService.Namespace).Create(ctx, desiredService, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else if err := r.reconcileHeadlessService(ctx, desiredService, actualService); err != nil {
+		return err
+	}
+
+	desiredEntry, err := resources.MakeServiceEntry(app, space)
+	if err != nil {
+		return err
+	}
+
+	if actualEntry == nil {
+		_, err := r.networkingClientSet.
+			NetworkingV1alpha3().
+			ServiceEntries(desiredEntry.Namespace).
+			Create(ctx, desiredEntry, metav1.CreateOptions{})
+		return err
+	}
+
+	return r.reconcileServiceEntry(ctx, desiredEntry, actualEntry)
+}
+
+// reconcileHeadlessService is like ReconcileService but also keeps the
+// fields specific to headless Services in sync.
+func (r *Reconciler) reconcileHeadlessService(ctx context.Context, desired, actual *corev1.Service) error {
+	logger := logging.FromContext(ctx)
+
+	// The ClusterIP of a Service can't be changed after creation so it's
+	// recreated if it's no longer headless.
+	if actual.Spec.ClusterIP != desired.Spec.ClusterIP {
+		logger.Infof("Recreating internal Service %s", actual.Name)
+		if err := r.KubeClientSet.CoreV1().Services(actual.Namespace).Delete(ctx, actual.Name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+		_, err := r.KubeClientSet.CoreV1().Services(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+
+	// Check for differences, if none we don't need to reconcile.
+	if reconciler.NewSemanticEqualityBuilder(logger, "Service").
+		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
+		Append("spec.ports", desired.Spec.Ports, actual.Spec.Ports).
+		Append("spec.selector", desired.Spec.Selector, actual.Spec.Selector).
+		Append("spec.publishNotReadyAddresses", desired.Spec.PublishNotReadyAddresses, actual.Spec.PublishNotReadyAddresses).
+		IsSemanticallyEqual() {
+		return nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec.Ports = desired.Spec.Ports
+	existing.Spec.Selector = desired.Spec.Selector
+	existing.Spec.PublishNotReadyAddresses = desired.Spec.PublishNotReadyAddresses
+
+	_, err := r.KubeClientSet.CoreV1().Services(existing.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// reconcileServiceEntry syncs the existing ServiceEntry to the desired one.
+func (r *Reconciler) reconcileServiceEntry(ctx context.Context, desired, actual *networking.ServiceEntry) error {
+	logger := logging.FromContext(ctx)
+
+	// Check for differences, if none we don't need to reconcile.
+	if reconciler.NewSemanticEqualityBuilder(logger, "ServiceEntry").
+		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
+		Append("spec.hosts", desired.Spec.Hosts, actual.Spec.Hosts).
+		Append("spec.exportTo", desired.Spec.ExportTo, actual.Spec.ExportTo).
+		Append("spec.ports", desired.Spec.Ports, actual.Spec.Ports).
+		Append("spec.location", desired.Spec.Location, actual.Spec.Location).
+		Append("spec.resolution", desired.Spec.Resolution, actual.Spec.Resolution).
+		Append("spec.workloadSelector", desired.Spec.WorkloadSelector, actual.Spec.WorkloadSelector).
+		IsSemanticallyEqual() {
+		return nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec.Hosts = desired.Spec.Hosts
+	existing.Spec.ExportTo = desired.Spec.ExportTo
+	existing.Spec.Ports = desired.Spec.Ports
+	existing.Spec.Location = desired.Spec.Location
+	existing.Spec.Resolution = desired.Spec.Resolution
+	existing.Spec.WorkloadSelector = desired.Spec.WorkloadSelector
+
+	_, err := r.networkingClientSet.
+		NetworkingV1alpha3().
+		ServiceEntries(existing.Namespace).
+		Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
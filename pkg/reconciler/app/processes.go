@@ -0,0 +1,83 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/reconciler/app/resources"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// reconcileProcesses creates or updates a Deployment for each of the App's
+// additional processes and deletes Deployments of processes that were
+// removed. The returned Deployments are in the same order as the processes.
+func (r *Reconciler) reconcileProcesses(
+	ctx context.Context,
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+) ([]*appsv1.Deployment, error) {
+	logger := logging.FromContext(ctx)
+
+	var out []*appsv1.Deployment
+	desiredNames := make(map[string]bool)
+	for i := range app.Spec.Processes {
+		desired, err := resources.MakeProcessDeployment(app, space, &app.Spec.Processes[i])
+		if err != nil {
+			return nil, err
+		}
+		desiredNames[desired.Name] = true
+
+		actual, err := r.deploymentLister.Deployments(desired.Namespace).Get(desired.Name)
+		switch {
+		case apierrs.IsNotFound(err):
+			actual, err = r.KubeClientSet.AppsV1().Deployments(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		case err != nil:
+			return nil, err
+		case !metav1.IsControlledBy(actual, app):
+			return nil, fmt.Errorf("deployment %q is not owned by App %q", desired.Name, app.Name)
+		default:
+			actual, err = r.ReconcileDeployment(ctx, desired, actual)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, actual)
+	}
+
+	existing, err := r.deploymentLister.Deployments(app.Namespace).List(resources.ProcessDeploymentSelector(app))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, actual := range existing {
+		if desiredNames[actual.Name] || !metav1.IsControlledBy(actual, app) {
+			continue
+		}
+
+		logger.Infof("Deleting process Deployment %s", actual.Name)
+		if err := r.KubeClientSet.AppsV1().Deployments(actual.Namespace).Delete(ctx, actual.Name, metav1.DeleteOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
@@ -21,17 +21,20 @@ import (
 	"math"
 	"reflect"
 	"sort"
+	"time"
 
 	kfconfig "github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	networkingclientset "github.com/google/kf/v2/pkg/client/networking/clientset/versioned"
+	networkinglisters "github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3"
 	"github.com/google/kf/v2/pkg/kf/cfutil"
 	"github.com/google/kf/v2/pkg/kf/dynamicutils"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/app/resources"
 	spaces "github.com/google/kf/v2/pkg/reconciler/space/resources"
 	"go.uber.org/zap"
-	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -40,7 +43,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
-	autoscalingv1listers "k8s.io/client-go/listers/autoscaling/v1"
+	autoscalingv2listers "k8s.io/client-go/listers/autoscaling/v2"
 	v1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
@@ -63,8 +66,10 @@ type Reconciler struct {
 	deploymentLister             appsv1listers.DeploymentLister
 	serviceLister                v1listers.ServiceLister
 	serviceAccountLister         v1listers.ServiceAccountLister
-	autoscalingLister            autoscalingv1listers.HorizontalPodAutoscalerLister
+	autoscalingLister            autoscalingv2listers.HorizontalPodAutoscalerLister
 	adxBuildLister               cache.GenericLister
+	serviceEntryLister           networkinglisters.ServiceEntryLister
+	networkingClientSet          networkingclientset.Interface
 
 	kfConfigStore *kfconfig.Store
 }
@@ -142,6 +147,14 @@ func (r *Reconciler) reconcileApp(ctx context.Context, namespace, name string) (
 		return uErr
 	}
 
+	if reconcileErr == nil {
+		// Reconcile again when an autoscaling schedule starts or ends so the
+		// HPA limits are updated on time.
+		if next := toReconcile.Status.NextAutoscalingScheduleTransition(); next != nil {
+			return controller.NewRequeueAfter(time.Until(*next) + time.Second)
+		}
+	}
+
 	return reconcileErr
 }
 
@@ -235,6 +248,16 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 		logger.Debug("reconciling service")
 		condition := app.Status.ServiceCondition()
 		desired := resources.MakeService(app)
+		stable, err := r.deploymentLister.Deployments(app.Namespace).Get(resources.DeploymentName(app))
+		switch {
+		case apierrs.IsNotFound(err):
+			stable = nil
+		case err != nil:
+			return condition.MarkReconciliationError("getting deployment", err)
+		}
+		if !stablePodsLabeled(stable) {
+			desired.Spec.Selector = resources.PodLabels(app)
+		}
 
 		actual, err := r.serviceLister.Services(desired.GetNamespace()).Get(desired.Name)
 		if apierrs.IsNotFound(err) {
@@ -250,6 +273,10 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 			return condition.MarkReconciliationError("updating existing", err)
 		}
 
+		if err := r.reconcileInternalService(ctx, app, space); err != nil {
+			return condition.MarkReconciliationError("reconciling internal service", err)
+		}
+
 		app.Status.PropagateServiceStatus(actual)
 	}
 
@@ -290,9 +317,9 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 
 		// Grab the full state of the world to see if any Routes are holding onto
 		// bindings that don't exist anymore.
-		desiredSet := make(map[v1alpha1.QualifiedRouteBinding]bool)
+		desiredSet := make(map[string]bool)
 		for _, v := range desiredBindings {
-			desiredSet[v] = true
+			desiredSet[v.Key()] = true
 		}
 
 		allRoutes, err := r.routeLister.
@@ -310,7 +337,7 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 					Destination: destination,
 				}
 
-				_, found := desiredSet[qualified]
+				_, found := desiredSet[qualified.Key()]
 
 				if destination.ServiceName == app.Name && !found {
 					undeclaredBindings = append(undeclaredBindings, qualified)
@@ -533,12 +560,17 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 	// reconcile HorizontalPodAutoscaler
 	{
 		logger.Debug("reconciling HorizontalPodAutoscaler")
+
+		// Determine which autoscaling schedule applies before generating the
+		// HPA so it gets the scheduled limits.
+		app.Status.PropagateAutoscalingScheduleStatus(&app.Spec.Instances.Autoscaling, time.Now())
+
 		actualHpa, err := r.reconcileHorizontalPodAutoscaler(ctx, app, app.Namespace, resources.AutoscalerName(app), app.Spec.Instances.Autoscaling)
 		if err != nil {
 			return err
 		}
 		// actual can be nil and is expected when deletion of HPA succeeded.
-		app.Status.PropagateAutoscalerV1Status(actualHpa)
+		app.Status.PropagateAutoscalerV2Status(actualHpa)
 
 		// Propagate the human-readable app instances after HPA has been reconciled
 		instanceStatus.PropagateAutoscalingStatus(app, actualHpa)
@@ -557,6 +589,8 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 			return condition.MarkTemplateError(err)
 		}
 
+		rolloutStatus := app.Status.Rollout
+
 		actual, err := r.deploymentLister.Deployments(desired.GetNamespace()).Get(desired.Name)
 		if apierrs.IsNotFound(err) {
 			actual, err = r.KubeClientSet.AppsV1().Deployments(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
@@ -567,11 +601,56 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 			return condition.MarkReconciliationError("getting latest", err)
 		} else if !metav1.IsControlledBy(actual, app) {
 			return condition.MarkChildNotOwned(desired.Name)
-		} else if actual, err = r.ReconcileDeployment(ctx, desired, actual); err != nil {
-			return condition.MarkReconciliationError("updating existing", err)
+		} else {
+			desiredCandidate, err := resources.MakeCandidateDeployment(app, space, *desired.Spec.Replicas)
+			if err != nil {
+				return condition.MarkTemplateError(err)
+			}
+
+			candidate, err := r.deploymentLister.Deployments(desiredCandidate.GetNamespace()).Get(desiredCandidate.Name)
+			if apierrs.IsNotFound(err) {
+				candidate = nil
+			} else if err != nil {
+				return condition.MarkReconciliationError("getting candidate", err)
+			}
+
+			// The rollout determines whether the stable Deployment can be
+			// updated in place or if the new revision runs alongside it first.
+			plan := planRollout(app, desired, desiredCandidate, actual, candidate)
+			if err := r.reconcileCandidate(ctx, app, desiredCandidate, plan.candidateReplicas); err != nil {
+				return condition.MarkReconciliationError("reconciling candidate", err)
+			}
+
+			if !plan.updateStable {
+				// Keep the previous revision but follow changes in scale.
+				scaled := actual.DeepCopy()
+				scaled.Spec.Replicas = desired.Spec.Replicas
+				desired = scaled
+			}
+
+			if actual, err = r.ReconcileDeployment(ctx, desired, actual); err != nil {
+				return condition.MarkReconciliationError("updating existing", err)
+			}
+
+			rolloutStatus = plan.status
 		}
 
 		app.Status.PropagateDeploymentStatus(actual)
+		app.Status.PropagateRolloutStatus(rolloutStatus)
+	}
+
+	// Reconcile the Deployments of additional processes, these share the
+	// configuration of the web process so they also MUST go after routes.
+	{
+		logger.Debug("reconciling process deployments")
+		condition := app.Status.DeploymentCondition()
+
+		processes, err := r.reconcileProcesses(ctx, app, space)
+		if err != nil {
+			return condition.MarkReconciliationError("reconciling processes", err)
+		}
+
+		app.Status.PropagateProcessStatus(processes)
 	}
 
 	// Update the human-readable app instances after the backing service has been
@@ -579,7 +658,7 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 	{
 		// Set the selectors.
 		instanceStatus.LabelSelector = labels.
-			SelectorFromSet(resources.PodLabels(app)).
+			SelectorFromSet(resources.StablePodLabels(app)).
 			String()
 
 		app.Status.PropagateInstanceStatus(instanceStatus)
@@ -620,7 +699,7 @@ func (r *Reconciler) reconcileHorizontalPodAutoscaler(
 	app *v1alpha1.App,
 	namespace string,
 	autoscalerName string,
-	autoscalingSpec v1alpha1.AppSpecAutoscaling) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	autoscalingSpec v1alpha1.AppSpecAutoscaling) (*autoscalingv2.HorizontalPodAutoscaler, error) {
 
 	condition := app.Status.HorizontalPodAutoscalerCondition()
 	desired, err := resources.MakeHorizontalPodAutoScaler(app)
@@ -631,7 +710,7 @@ func (r *Reconciler) reconcileHorizontalPodAutoscaler(
 
 	if desired == nil {
 		err := r.KubeClientSet.
-			AutoscalingV1().
+			AutoscalingV2().
 			HorizontalPodAutoscalers(namespace).
 			Delete(ctx, autoscalerName, metav1.DeleteOptions{})
 		if apierrs.IsNotFound(err) {
@@ -645,7 +724,7 @@ func (r *Reconciler) reconcileHorizontalPodAutoscaler(
 	switch {
 	case apierrs.IsNotFound(err):
 		actual, err = r.
-			KubeClientSet.AutoscalingV1().
+			KubeClientSet.AutoscalingV2().
 			HorizontalPodAutoscalers(desired.Namespace).
 			Create(ctx, desired, metav1.CreateOptions{})
 		if err != nil {
@@ -666,7 +745,7 @@ func (r *Reconciler) reconcileHorizontalPodAutoscaler(
 }
 
 // ReconcileAutoscaler syncs the existing K8s autoscaler to the desired autoscaler.
-func (r *Reconciler) reconcileAutoscaler(ctx context.Context, desired, actual *autoscalingv1.HorizontalPodAutoscaler) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+func (r *Reconciler) reconcileAutoscaler(ctx context.Context, desired, actual *autoscalingv2.HorizontalPodAutoscaler) (*autoscalingv2.HorizontalPodAutoscaler, error) {
 	logger := logging.FromContext(ctx)
 
 	// Check for differences, if none we don't need to reconcile.
@@ -683,7 +762,7 @@ func (r *Reconciler) reconcileAutoscaler(ctx context.Context, desired, actual *a
 	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
 	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
 	existing.Spec = desired.Spec
-	return r.KubeClientSet.AutoscalingV1().HorizontalPodAutoscalers(existing.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	return r.KubeClientSet.AutoscalingV2().HorizontalPodAutoscalers(existing.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
 }
 
 func (r *Reconciler) updateStatus(ctx context.Context, desired *v1alpha1.App) (*v1alpha1.App, error) {
@@ -18,7 +18,9 @@ import (
 	"errors"
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
-	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/kmeta"
 )
@@ -31,7 +33,7 @@ func AutoscalerName(app *v1alpha1.App) string {
 // MakeHorizontalPodAutoScaler creates a HorizontalPodAutoScaler from an app definition.
 func MakeHorizontalPodAutoScaler(
 	app *v1alpha1.App,
-) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+) (*autoscalingv2.HorizontalPodAutoscaler, error) {
 
 	if app.Spec.Instances.Stopped || !app.Spec.Instances.Autoscaling.RequiresHPA() {
 		return nil, nil
@@ -41,7 +43,7 @@ func MakeHorizontalPodAutoScaler(
 		return nil, errors.New("too many autoscaling rules")
 	}
 
-	autoscaler := &autoscalingv1.HorizontalPodAutoscaler{
+	autoscaler := &autoscalingv2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      AutoscalerName(app),
 			Namespace: app.Namespace,
@@ -50,8 +52,8 @@ func MakeHorizontalPodAutoScaler(
 			},
 			Labels: v1alpha1.UnionMaps(app.GetLabels(), app.ComponentLabels("autoscaler")),
 		},
-		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
-			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
 				APIVersion: app.GetGroupVersionKind().GroupVersion().String(),
 				Kind:       app.GetGroupVersionKind().Kind,
 				Name:       app.Name,
@@ -61,12 +63,89 @@ func MakeHorizontalPodAutoScaler(
 		},
 	}
 
+	if schedule := activeAutoscalingSchedule(app); schedule != nil {
+		autoscaler.Spec.MinReplicas = schedule.MinReplicas
+		autoscaler.Spec.MaxReplicas = *schedule.MaxReplicas
+	}
+
 	rule := app.Spec.Instances.Autoscaling.Rules[0]
-	if rule.RuleType != v1alpha1.CPURuleType {
-		return nil, errors.New("invalid autoscaling rule")
+	metric, err := makeAutoscalingMetric(rule)
+	if err != nil {
+		return nil, err
 	}
 
-	autoscaler.Spec.TargetCPUUtilizationPercentage = rule.Target
+	autoscaler.Spec.Metrics = []autoscalingv2.MetricSpec{*metric}
 
 	return autoscaler, nil
 }
+
+// activeAutoscalingSchedule returns the schedule the App's status reports as
+// active, or nil if the default limits apply.
+func activeAutoscalingSchedule(app *v1alpha1.App) *v1alpha1.AppAutoscalingSchedule {
+	for _, scheduleStatus := range app.Status.AutoscalingSchedules {
+		if !scheduleStatus.Active {
+			continue
+		}
+
+		for i, schedule := range app.Spec.Instances.Autoscaling.Schedules {
+			if schedule.Name == scheduleStatus.Name && schedule.MaxReplicas != nil {
+				return &app.Spec.Instances.Autoscaling.Schedules[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// makeAutoscalingMetric converts an autoscaling rule into the metric the
+// HorizontalPodAutoscaler should target.
+func makeAutoscalingMetric(rule v1alpha1.AppAutoscalingRule) (*autoscalingv2.MetricSpec, error) {
+	if rule.Target == nil {
+		return nil, errors.New("autoscaling rule is missing a target")
+	}
+
+	switch rule.RuleType {
+	case v1alpha1.CPURuleType:
+		return makeResourceUtilizationMetric(corev1.ResourceCPU, *rule.Target), nil
+	case v1alpha1.MemoryRuleType:
+		return makeResourceUtilizationMetric(corev1.ResourceMemory, *rule.Target), nil
+	case v1alpha1.HTTPThroughputRuleType:
+		return makePodsAverageMetric(v1alpha1.HTTPThroughputMetricName, *rule.Target), nil
+	case v1alpha1.HTTPLatencyRuleType:
+		return makePodsAverageMetric(v1alpha1.HTTPLatencyMetricName, *rule.Target), nil
+	default:
+		return nil, errors.New("invalid autoscaling rule")
+	}
+}
+
+// makeResourceUtilizationMetric targets the average utilization of a
+// container resource as a percentage of the requested amount.
+func makeResourceUtilizationMetric(name corev1.ResourceName, target int32) *autoscalingv2.MetricSpec {
+	return &autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &target,
+			},
+		},
+	}
+}
+
+// makePodsAverageMetric targets the average value of a per-instance metric
+// served by the custom metrics API.
+func makePodsAverageMetric(name string, target int32) *autoscalingv2.MetricSpec {
+	return &autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name: name,
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: resource.NewQuantity(int64(target), resource.DecimalSI),
+			},
+		},
+	}
+}
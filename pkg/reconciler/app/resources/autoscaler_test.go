@@ -21,7 +21,9 @@ import (
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/testutil"
-	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"knative.dev/pkg/ptr"
@@ -42,7 +44,7 @@ func TestMakeAutoscaler(t *testing.T) {
 	tests := map[string]struct {
 		app     *v1alpha1.App
 		space   *v1alpha1.Space
-		want    *autoscalingv1.HorizontalPodAutoscaler
+		want    *autoscalingv2.HorizontalPodAutoscaler
 		wantErr error
 	}{
 		"disabled": {
@@ -162,7 +164,7 @@ func TestMakeAutoscaler(t *testing.T) {
 				},
 			},
 			space: &v1alpha1.Space{},
-			want: &autoscalingv1.HorizontalPodAutoscaler{
+			want: &autoscalingv2.HorizontalPodAutoscaler{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "my-app",
 					Labels: map[string]string{
@@ -181,15 +183,26 @@ func TestMakeAutoscaler(t *testing.T) {
 					},
 				},
 
-				Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
-					ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
 						APIVersion: "kf.dev/v1alpha1",
 						Kind:       "App",
 						Name:       "my-app",
 					},
-					MinReplicas:                    ptr.Int32(1),
-					MaxReplicas:                    1,
-					TargetCPUUtilizationPercentage: ptr.Int32(50),
+					MinReplicas: ptr.Int32(1),
+					MaxReplicas: 1,
+					Metrics: []autoscalingv2.MetricSpec{
+						{
+							Type: autoscalingv2.ResourceMetricSourceType,
+							Resource: &autoscalingv2.ResourceMetricSource{
+								Name: corev1.ResourceCPU,
+								Target: autoscalingv2.MetricTarget{
+									Type:               autoscalingv2.UtilizationMetricType,
+									AverageUtilization: ptr.Int32(50),
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -203,3 +216,173 @@ func TestMakeAutoscaler(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeAutoscaler_metrics(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		rule    v1alpha1.AppAutoscalingRule
+		want    autoscalingv2.MetricSpec
+		wantErr error
+	}{
+		"memory": {
+			rule: v1alpha1.AppAutoscalingRule{
+				RuleType: v1alpha1.MemoryRuleType,
+				Target:   ptr.Int32(70),
+			},
+			want: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name: corev1.ResourceMemory,
+					Target: autoscalingv2.MetricTarget{
+						Type:               autoscalingv2.UtilizationMetricType,
+						AverageUtilization: ptr.Int32(70),
+					},
+				},
+			},
+		},
+		"http throughput": {
+			rule: v1alpha1.AppAutoscalingRule{
+				RuleType: v1alpha1.HTTPThroughputRuleType,
+				Target:   ptr.Int32(200),
+			},
+			want: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "istio_requests_per_second",
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: resource.NewQuantity(200, resource.DecimalSI),
+					},
+				},
+			},
+		},
+		"http latency": {
+			rule: v1alpha1.AppAutoscalingRule{
+				RuleType: v1alpha1.HTTPLatencyRuleType,
+				Target:   ptr.Int32(250),
+			},
+			want: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "istio_request_duration_milliseconds",
+					},
+					Target: autoscalingv2.MetricTarget{
+						Type:         autoscalingv2.AverageValueMetricType,
+						AverageValue: resource.NewQuantity(250, resource.DecimalSI),
+					},
+				},
+			},
+		},
+		"unknown rule type": {
+			rule: v1alpha1.AppAutoscalingRule{
+				RuleType: "DISK",
+				Target:   ptr.Int32(50),
+			},
+			wantErr: errors.New("invalid autoscaling rule"),
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			app := &v1alpha1.App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-app",
+				},
+				Spec: v1alpha1.AppSpec{
+					Instances: v1alpha1.AppSpecInstances{
+						Autoscaling: v1alpha1.AppSpecAutoscaling{
+							Enabled:     true,
+							MinReplicas: ptr.Int32(1),
+							MaxReplicas: ptr.Int32(5),
+							Rules:       []v1alpha1.AppAutoscalingRule{tc.rule},
+						},
+					},
+				},
+			}
+
+			got, err := MakeHorizontalPodAutoScaler(app)
+			testutil.AssertEqual(t, "Error", tc.wantErr, err)
+			if tc.wantErr != nil {
+				return
+			}
+
+			testutil.AssertEqual(t, "Metrics", []autoscalingv2.MetricSpec{tc.want}, got.Spec.Metrics)
+		})
+	}
+}
+
+func TestMakeAutoscaler_schedules(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-app",
+		},
+		Spec: v1alpha1.AppSpec{
+			Instances: v1alpha1.AppSpecInstances{
+				Autoscaling: v1alpha1.AppSpecAutoscaling{
+					Enabled:     true,
+					MinReplicas: ptr.Int32(1),
+					MaxReplicas: ptr.Int32(5),
+					Rules: []v1alpha1.AppAutoscalingRule{
+						{RuleType: v1alpha1.CPURuleType, Target: ptr.Int32(50)},
+					},
+					Schedules: []v1alpha1.AppAutoscalingSchedule{
+						{Name: "nights", MinReplicas: ptr.Int32(1), MaxReplicas: ptr.Int32(2)},
+						{Name: "business-hours", MinReplicas: ptr.Int32(5), MaxReplicas: ptr.Int32(20)},
+					},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		schedules []v1alpha1.AutoscalingScheduleStatus
+		wantMin   int32
+		wantMax   int32
+	}{
+		"no schedule status": {
+			wantMin: 1,
+			wantMax: 5,
+		},
+		"no active schedule": {
+			schedules: []v1alpha1.AutoscalingScheduleStatus{
+				{Name: "nights"},
+				{Name: "business-hours"},
+			},
+			wantMin: 1,
+			wantMax: 5,
+		},
+		"active schedule": {
+			schedules: []v1alpha1.AutoscalingScheduleStatus{
+				{Name: "nights"},
+				{Name: "business-hours", Active: true},
+			},
+			wantMin: 5,
+			wantMax: 20,
+		},
+		"active schedule removed from spec": {
+			schedules: []v1alpha1.AutoscalingScheduleStatus{
+				{Name: "weekends", Active: true},
+			},
+			wantMin: 1,
+			wantMax: 5,
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			app := app.DeepCopy()
+			app.Status.AutoscalingSchedules = tc.schedules
+
+			got, err := MakeHorizontalPodAutoScaler(app)
+			testutil.AssertNil(t, "Error", err)
+			testutil.AssertEqual(t, "MinReplicas", ptr.Int32(tc.wantMin), got.Spec.MinReplicas)
+			testutil.AssertEqual(t, "MaxReplicas", tc.wantMax, got.Spec.MaxReplicas)
+		})
+	}
+}
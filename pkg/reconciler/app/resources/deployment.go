@@ -83,11 +83,14 @@ func MakeDeployment(
 			Labels: v1alpha1.UnionMaps(app.GetLabels(), app.ComponentLabels("app-scaler")),
 		},
 		Spec: appsv1.DeploymentSpec{
+			// The selector is immutable so it doesn't include the
+			// RolloutLabel. Candidate ReplicaSets match it but aren't adopted
+			// because they're owned by the candidate Deployment.
 			Selector: metav1.SetAsLabelSelector(labels.Set(PodLabels(app))),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: v1alpha1.UnionMaps(
-						PodLabels(app),
+						StablePodLabels(app),
 
 						// Insert a label for isolating apps with their own NetworkPolicies.
 						map[string]string{
@@ -122,7 +125,7 @@ func makePodSpec(app *v1alpha1.App, space *v1alpha1.Space) (*corev1.PodSpec, err
 	// doing so could cause misconfiguration for apps.
 	spec.EnableServiceLinks = ptr.Bool(false)
 
-	// At this point in the lifecycle there should be exactly one container
+	// At this point in the lifecycle there should be at least one container
 	// if the webhhook is working but create one to avoid panics just in case.
 	if len(spec.Containers) == 0 {
 		spec.Containers = append(spec.Containers, corev1.Container{})
@@ -167,6 +170,20 @@ func makePodSpec(app *v1alpha1.App, space *v1alpha1.Space) (*corev1.PodSpec, err
 	// If the client provides probes, we should fill in the port for them.
 	rewriteUserProbe(userContainer.LivenessProbe, userPort)
 	rewriteUserProbe(userContainer.ReadinessProbe, userPort)
+	rewriteUserProbe(userContainer.StartupProbe, userPort)
+
+	// Sidecars run from the App's image and share its environment. Their own
+	// environment variables take priority.
+	for i := 1; i < len(spec.Containers); i++ {
+		sidecar := &spec.Containers[i]
+		sidecar.Image = app.Status.Image
+		sidecar.Env = append(append([]corev1.EnvVar{}, containerEnv...), sidecar.Env...)
+		sidecar.Stdin = false
+		sidecar.TTY = false
+		sidecar.ImagePullPolicy = corev1.PullIfNotPresent
+		sidecar.TerminationMessagePath = corev1.TerminationMessagePathDefault
+		sidecar.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	}
 
 	spec.ServiceAccountName = app.Status.ServiceAccountName
 	// This need to be removed after we implement server side apply.
@@ -175,6 +192,12 @@ func makePodSpec(app *v1alpha1.App, space *v1alpha1.Space) (*corev1.PodSpec, err
 	// If the client provides a node selector, we should fill in the corresponding field in the podspec.
 	spec.NodeSelector = selectorutil.GetNodeSelector(app.Spec.Build.Spec, space)
 
+	// Give each instance a stable DNS name under the App's headless Service
+	// so peers can address each other directly.
+	if space.Spec.NetworkConfig.InternalDomain != "" {
+		spec.Subdomain = InternalServiceName(app)
+	}
+
 	if len(app.Status.Volumes) > 0 {
 		// mapfs for volumes needs the extra permission.
 		userContainer.SecurityContext = &corev1.SecurityContext{
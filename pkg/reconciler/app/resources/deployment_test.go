@@ -100,6 +100,7 @@ func TestMakeDeployment(t *testing.T) {
 								"app.kubernetes.io/managed-by": "kf",
 								"app.kubernetes.io/name":       "my-app",
 								v1alpha1.NetworkPolicyLabel:    v1alpha1.NetworkPolicyApp,
+								v1alpha1.RolloutLabel:          v1alpha1.RolloutStable,
 							},
 							Annotations: map[string]string{
 								"sidecar.istio.io/inject":                          "true",
@@ -207,6 +208,12 @@ func Test_makePodSpec(t *testing.T) {
 											TCPSocket: &corev1.TCPSocketAction{},
 										},
 									},
+									StartupProbe: &corev1.Probe{
+										FailureThreshold: 30,
+										ProbeHandler: corev1.ProbeHandler{
+											TCPSocket: &corev1.TCPSocketAction{},
+										},
+									},
 								},
 							},
 						},
@@ -282,6 +289,14 @@ func Test_makePodSpec(t *testing.T) {
 									},
 								},
 							},
+							StartupProbe: &corev1.Probe{
+								FailureThreshold: 30,
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.FromInt(9999),
+									},
+								},
+							},
 							ImagePullPolicy:          corev1.PullIfNotPresent,
 							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
 							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
@@ -302,6 +317,116 @@ func Test_makePodSpec(t *testing.T) {
 				}
 			},
 		},
+		"sidecars": {
+			app: &v1alpha1.App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-app",
+				},
+				Spec: v1alpha1.AppSpec{
+					Template: v1alpha1.AppSpecTemplate{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{},
+								{
+									Name: "metrics-agent",
+									Args: []string{"./metrics-agent"},
+									Env: []corev1.EnvVar{
+										{Name: "sidecar-key", Value: "bar"},
+									},
+								},
+							},
+						},
+					},
+				},
+				Status: v1alpha1.AppStatus{
+					BuildStatusFields: v1alpha1.BuildStatusFields{
+						Image: "gcr.io/my-app",
+					},
+				},
+			},
+			space: &v1alpha1.Space{},
+			want: func(app *v1alpha1.App) corev1.PodSpec {
+				var wantEnv []corev1.EnvVar
+
+				wantEnv = append(wantEnv, BuildRuntimeEnvVars(CFRunning, app)...)
+				wantEnv = append(wantEnv, corev1.EnvVar{Name: "KF_UPDATE_REQUESTS_", Value: "0"})
+
+				// sidecars get the App's environment followed by their own
+				wantSidecarEnv := append([]corev1.EnvVar{}, wantEnv...)
+				wantSidecarEnv = append(wantSidecarEnv, corev1.EnvVar{Name: "sidecar-key", Value: "bar"})
+
+				return corev1.PodSpec{
+					EnableServiceLinks: ptr.Bool(false),
+					Containers: []corev1.Container{
+						{
+							Name:                     "user-container",
+							Image:                    "gcr.io/my-app",
+							Ports:                    buildContainerPorts(DefaultUserPort),
+							Env:                      wantEnv,
+							ImagePullPolicy:          corev1.PullIfNotPresent,
+							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+						},
+						{
+							Name:                     "metrics-agent",
+							Image:                    "gcr.io/my-app", // copied from status
+							Args:                     []string{"./metrics-agent"},
+							Env:                      wantSidecarEnv,
+							ImagePullPolicy:          corev1.PullIfNotPresent,
+							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+						},
+					},
+					NodeSelector:                  map[string]string{},
+					RestartPolicy:                 corev1.RestartPolicyAlways,
+					TerminationGracePeriodSeconds: ptr.Int64(corev1.DefaultTerminationGracePeriodSeconds),
+					DNSPolicy:                     corev1.DNSClusterFirst,
+					SecurityContext:               &corev1.PodSecurityContext{},
+					SchedulerName:                 corev1.DefaultSchedulerName,
+				}
+			},
+		},
+		"internal domain": {
+			app: &v1alpha1.App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-app",
+				},
+			},
+			space: &v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					NetworkConfig: v1alpha1.SpaceSpecNetworkConfig{
+						InternalDomain: "apps.internal",
+					},
+				},
+			},
+			want: func(app *v1alpha1.App) corev1.PodSpec {
+				var wantEnv []corev1.EnvVar
+
+				wantEnv = append(wantEnv, BuildRuntimeEnvVars(CFRunning, app)...)
+				wantEnv = append(wantEnv, corev1.EnvVar{Name: "KF_UPDATE_REQUESTS_", Value: "0"})
+
+				return corev1.PodSpec{
+					EnableServiceLinks: ptr.Bool(false),
+					Containers: []corev1.Container{
+						{
+							Name:                     "user-container",
+							Ports:                    buildContainerPorts(DefaultUserPort),
+							Env:                      wantEnv,
+							ImagePullPolicy:          corev1.PullIfNotPresent,
+							TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+						},
+					},
+					NodeSelector:                  map[string]string{},
+					Subdomain:                     "my-app-internal",
+					RestartPolicy:                 corev1.RestartPolicyAlways,
+					TerminationGracePeriodSeconds: ptr.Int64(corev1.DefaultTerminationGracePeriodSeconds),
+					DNSPolicy:                     corev1.DNSClusterFirst,
+					SecurityContext:               &corev1.PodSecurityContext{},
+					SchedulerName:                 corev1.DefaultSchedulerName,
+				}
+			},
+		},
 	}
 	for tn, tc := range tests {
 		t.Run(tn, func(t *testing.T) {
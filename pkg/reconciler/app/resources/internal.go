@@ -0,0 +1,162 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfistio "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	istio "istio.io/api/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/kmeta"
+)
+
+// InternalServiceName is the name of the headless Service and ServiceEntry
+// used for discovering the App on the Space's internal domain.
+func InternalServiceName(app *v1alpha1.App) string {
+	return v1alpha1.GenerateName(app.Name, "internal")
+}
+
+// InternalHostname returns the hostname the App can be reached at on the
+// internal domain, or an empty string if the Space has no internal domain.
+func InternalHostname(app *v1alpha1.App, space *v1alpha1.Space) string {
+	domain := space.Spec.NetworkConfig.InternalDomain
+	if domain == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s.%s", app.Name, domain)
+}
+
+// MakeInternalService creates a headless Service selecting the App's Pods.
+// Each Pod gets a stable DNS record under the Service so instances can find
+// their peers.
+func MakeInternalService(app *v1alpha1.App) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InternalServiceName(app),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(app),
+			},
+			Labels: v1alpha1.UnionMaps(app.GetLabels(), app.ComponentLabels("internal-service")),
+		},
+		Spec: corev1.ServiceSpec{
+			Ports:     makeInternalServicePorts(app),
+			Selector:  PodLabels(app),
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: corev1.ClusterIPNone,
+			// Peers need to be able to find each other before they're ready,
+			// e.g. to form a cluster.
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
+// MakeServiceEntry creates an Istio ServiceEntry that resolves the App's
+// internal hostname directly to its Pods, bypassing the ingress gateway.
+//
+// The ServiceEntry is only exported to the App's namespace so Spaces using
+// the same internal domain can have Apps with the same name. Sidecars only
+// resolve the hostname if Istio's DNS proxying is turned on.
+func MakeServiceEntry(app *v1alpha1.App, space *v1alpha1.Space) (*kfistio.ServiceEntry, error) {
+	hostname := InternalHostname(app, space)
+	if hostname == "" {
+		return nil, fmt.Errorf("Space %q has no internal domain", space.Name)
+	}
+
+	var ports []*istio.Port
+	for _, port := range makeInternalServicePorts(app) {
+		// Istio doesn't proxy UDP, those ports are only reachable through the
+		// headless Service.
+		if port.Protocol == corev1.ProtocolUDP {
+			continue
+		}
+
+		ports = append(ports, &istio.Port{
+			Number:   uint32(port.Port),
+			Protocol: istioProtocol(port),
+			Name:     port.Name,
+		})
+	}
+
+	return &kfistio.ServiceEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      InternalServiceName(app),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(app),
+			},
+			Labels: v1alpha1.UnionMaps(app.GetLabels(), app.ComponentLabels("internal-service")),
+		},
+		Spec: istio.ServiceEntry{
+			Hosts:      []string{hostname},
+			ExportTo:   []string{"."},
+			Ports:      ports,
+			Location:   istio.ServiceEntry_MESH_INTERNAL,
+			Resolution: istio.ServiceEntry_STATIC,
+			WorkloadSelector: &istio.WorkloadSelector{
+				Labels: PodLabels(app),
+			},
+		},
+	}, nil
+}
+
+// makeInternalServicePorts exposes the App container's ports directly. Unlike
+// the App's Service no port 80 is injected because traffic is sent straight
+// to the Pods.
+func makeInternalServicePorts(app *v1alpha1.App) (ports []corev1.ServicePort) {
+	containers := app.Spec.Template.Spec.Containers
+	if len(containers) != 0 {
+		for _, containerPort := range containers[0].Ports {
+			ports = append(ports, corev1.ServicePort{
+				Name:       containerPort.Name,
+				Protocol:   containerPort.Protocol,
+				Port:       containerPort.ContainerPort,
+				TargetPort: intstr.FromInt(int(containerPort.ContainerPort)),
+			})
+		}
+	}
+
+	if len(ports) == 0 {
+		userPort := getUserPort(app)
+		ports = append(ports, corev1.ServicePort{
+			Name:       UserPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       userPort,
+			TargetPort: intstr.FromInt(int(userPort)),
+		})
+	}
+
+	return
+}
+
+// istioProtocol converts a Service port to the protocol Istio should use for
+// it. Istio uses the name prefix to detect the protocol the same way it does
+// for Kubernetes Services.
+func istioProtocol(port corev1.ServicePort) string {
+	switch {
+	case strings.HasPrefix(port.Name, "http2"), strings.HasPrefix(port.Name, "grpc"):
+		return "HTTP2"
+	case strings.HasPrefix(port.Name, "http"):
+		return "HTTP"
+	default:
+		return "TCP"
+	}
+}
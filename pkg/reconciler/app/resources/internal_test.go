@@ -0,0 +1,134 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ExampleInternalServiceName() {
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "my-ns",
+		},
+	}
+
+	fmt.Println(InternalServiceName(app))
+
+	// Output: my-app-internal
+}
+
+func ExampleInternalHostname() {
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "my-ns",
+		},
+	}
+
+	space := &v1alpha1.Space{}
+	fmt.Printf("Disabled: %q\n", InternalHostname(app, space))
+
+	space.Spec.NetworkConfig.InternalDomain = "apps.internal"
+	fmt.Printf("Enabled: %q\n", InternalHostname(app, space))
+
+	// Output: Disabled: ""
+	// Enabled: "my-app.apps.internal"
+}
+
+// internalServiceTestApps returns Apps with different port configurations for
+// testing the resources created for the Space's internal domain.
+func internalServiceTestApps() map[string]*v1alpha1.App {
+	appWithoutPorts := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "my-ns",
+		},
+		Spec: v1alpha1.AppSpec{
+			Template: v1alpha1.AppSpecTemplate{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "user-service"},
+					},
+				},
+			},
+		},
+	}
+
+	appWithPorts := appWithoutPorts.DeepCopy()
+	appWithPorts.Spec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: "http-8888", ContainerPort: 8888},
+		{Name: "grpc-9000", ContainerPort: 9000},
+		{Name: "tcp-4369", ContainerPort: 4369},
+		{Name: "udp-53", ContainerPort: 53, Protocol: corev1.ProtocolUDP},
+	}
+
+	return map[string]*v1alpha1.App{
+		"default":               appWithoutPorts,
+		"multiple custom ports": appWithPorts,
+	}
+}
+
+func TestMakeInternalService(t *testing.T) {
+	for tn, app := range internalServiceTestApps() {
+		t.Run(tn, func(t *testing.T) {
+			svc := MakeInternalService(app)
+
+			testutil.AssertGoldenJSONContext(t, "service", svc, map[string]interface{}{
+				"app": app,
+			})
+		})
+	}
+}
+
+func TestMakeServiceEntry(t *testing.T) {
+	space := &v1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-ns",
+		},
+		Spec: v1alpha1.SpaceSpec{
+			NetworkConfig: v1alpha1.SpaceSpecNetworkConfig{
+				InternalDomain: "apps.internal",
+			},
+		},
+	}
+
+	for tn, app := range internalServiceTestApps() {
+		t.Run(tn, func(t *testing.T) {
+			serviceEntry, err := MakeServiceEntry(app, space)
+			testutil.AssertNil(t, "err", err)
+
+			testutil.AssertGoldenJSONContext(t, "serviceentry", serviceEntry, map[string]interface{}{
+				"app":   app,
+				"space": space,
+			})
+		})
+	}
+}
+
+func TestMakeServiceEntry_noInternalDomain(t *testing.T) {
+	_, err := MakeServiceEntry(&v1alpha1.App{}, &v1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-ns"},
+	})
+
+	testutil.AssertErrorsEqual(t, fmt.Errorf(`Space "my-ns" has no internal domain`), err)
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/ptr"
+)
+
+// ProcessDeploymentName gets the name of the Deployment running one of the
+// App's additional processes.
+func ProcessDeploymentName(app *v1alpha1.App, processType string) string {
+	return v1alpha1.GenerateName(app.Name, "process", processType)
+}
+
+// ProcessPodLabels returns the labels for selecting pods of an additional
+// process. They must not overlap with PodLabels so the App's Service doesn't
+// send traffic to the process.
+func ProcessPodLabels(app *v1alpha1.App, processType string) map[string]string {
+	return v1alpha1.UnionMaps(
+		app.ComponentLabels(v1alpha1.AppProcessComponent),
+		map[string]string{
+			v1alpha1.ProcessTypeLabel: processType,
+		})
+}
+
+// ProcessDeploymentSelector selects all Deployments running additional
+// processes for the App.
+func ProcessDeploymentSelector(app *v1alpha1.App) labels.Selector {
+	return labels.SelectorFromSet(app.ComponentLabels("process-scaler"))
+}
+
+// MakeProcessDeployment creates a K8s Deployment for one of the App's
+// additional processes. The Deployment runs the same image, environment, and
+// volumes as the one created by MakeDeployment but with the process's
+// command, resources, probes, sidecars, and scale.
+func MakeProcessDeployment(
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+	process *v1alpha1.AppSpecProcess,
+) (*appsv1.Deployment, error) {
+	processApp := app.DeepCopy()
+	processApp.Spec.Instances.Replicas = ptr.Int32(process.DeploymentReplicas(app.Spec.Instances))
+
+	templateSpec := &processApp.Spec.Template.Spec
+	if len(templateSpec.Containers) == 0 {
+		templateSpec.Containers = append(templateSpec.Containers, corev1.Container{})
+	}
+
+	container := &templateSpec.Containers[0]
+	if len(process.Command) > 0 || len(process.Args) > 0 {
+		container.Command = process.Command
+		container.Args = process.Args
+	}
+	if process.Resources != nil {
+		container.Resources = *process.Resources
+	}
+	container.ReadinessProbe = process.ReadinessProbe.DeepCopy()
+	container.LivenessProbe = process.LivenessProbe.DeepCopy()
+	container.StartupProbe = process.StartupProbe.DeepCopy()
+
+	// The template's sidecars belong to the web process.
+	templateSpec.Containers = templateSpec.Containers[:1]
+	for _, sidecar := range process.Sidecars {
+		templateSpec.Containers = append(templateSpec.Containers, *sidecar.DeepCopy())
+	}
+
+	deployment, err := MakeDeployment(processApp, space)
+	if err != nil {
+		return nil, err
+	}
+
+	podLabels := ProcessPodLabels(app, process.Type)
+
+	deployment.Name = ProcessDeploymentName(app, process.Type)
+	deployment.Labels = v1alpha1.UnionMaps(
+		app.GetLabels(),
+		app.ComponentLabels("process-scaler"),
+		map[string]string{
+			v1alpha1.ProcessTypeLabel: process.Type,
+		})
+	deployment.Spec.Selector = metav1.SetAsLabelSelector(labels.Set(podLabels))
+	deployment.Spec.Template.Labels = v1alpha1.UnionMaps(
+		podLabels,
+		map[string]string{
+			v1alpha1.NetworkPolicyLabel: v1alpha1.NetworkPolicyApp,
+		})
+	// Additional processes aren't selected by the App's headless Service.
+	deployment.Spec.Template.Spec.Subdomain = ""
+
+	return deployment, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/ptr"
+)
+
+func ExampleProcessDeploymentName() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+
+	fmt.Println("Deployment name:", ProcessDeploymentName(app, "worker"))
+
+	// Output: Deployment name: my-app-process-worker
+}
+
+func ExampleProcessPodLabels() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	worker := labels.Set(ProcessPodLabels(app, "worker"))
+
+	fmt.Println("Labels:", worker)
+	fmt.Println("Selected by App Service:", labels.SelectorFromSet(PodLabels(app)).Matches(worker))
+
+	// Output: Labels: app.kubernetes.io/component=app-process,app.kubernetes.io/managed-by=kf,app.kubernetes.io/name=my-app,kf.dev/process-type=worker
+	// Selected by App Service: false
+}
+
+func TestMakeProcessDeployment(t *testing.T) {
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "my-space",
+		},
+		Spec: v1alpha1.AppSpec{
+			Template: v1alpha1.AppSpecTemplate{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Args: []string{"rails server"},
+							Env:  []corev1.EnvVar{{Name: "RAILS_ENV", Value: "production"}},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{}},
+							},
+						},
+						{Name: "web-sidecar"},
+					},
+				},
+			},
+			Instances: v1alpha1.AppSpecInstances{
+				Replicas: ptr.Int32(10),
+			},
+		},
+		Status: v1alpha1.AppStatus{
+			BuildStatusFields: v1alpha1.BuildStatusFields{
+				Image: "gcr.io/my-app",
+			},
+		},
+	}
+	space := &v1alpha1.Space{}
+
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+	process := &v1alpha1.AppSpecProcess{
+		Type:      "worker",
+		Args:      []string{"sidekiq"},
+		Replicas:  ptr.Int32(2),
+		Resources: &resources,
+		Sidecars:  []corev1.Container{{Name: "worker-sidecar"}},
+	}
+
+	web, err := MakeDeployment(app, space)
+	testutil.AssertNil(t, "web err", err)
+
+	worker, err := MakeProcessDeployment(app, space, process)
+	testutil.AssertNil(t, "worker err", err)
+
+	container := worker.Spec.Template.Spec.Containers[0]
+	testutil.AssertEqual(t, "name", "my-app-process-worker", worker.Name)
+	testutil.AssertEqual(t, "process label", "worker", worker.Labels[v1alpha1.ProcessTypeLabel])
+	testutil.AssertEqual(t, "replicas", ptr.Int32(2), worker.Spec.Replicas)
+	testutil.AssertEqual(t, "selector", ProcessPodLabels(app, "worker"), worker.Spec.Selector.MatchLabels)
+	testutil.AssertEqual(t, "owner", web.OwnerReferences, worker.OwnerReferences)
+	testutil.AssertEqual(t, "args", []string{"sidekiq"}, container.Args)
+	testutil.AssertEqual(t, "resources", resources, container.Resources)
+	testutil.AssertEqual(t, "env", web.Spec.Template.Spec.Containers[0].Env, container.Env)
+	testutil.AssertEqual(t, "image", "gcr.io/my-app", container.Image)
+	testutil.AssertTrue(t, "readiness probe is nil", container.ReadinessProbe == nil)
+	testutil.AssertEqual(t, "sidecar count", 2, len(worker.Spec.Template.Spec.Containers))
+	testutil.AssertEqual(t, "sidecar", "worker-sidecar", worker.Spec.Template.Spec.Containers[1].Name)
+	testutil.AssertEqual(t, "sidecar image", "gcr.io/my-app", worker.Spec.Template.Spec.Containers[1].Image)
+	testutil.AssertTrue(t, "process deployments selected", ProcessDeploymentSelector(app).Matches(labels.Set(worker.Labels)))
+	testutil.AssertFalse(t, "web deployment selected", ProcessDeploymentSelector(app).Matches(labels.Set(web.Labels)))
+
+	selector, err := metav1.LabelSelectorAsSelector(web.Spec.Selector)
+	testutil.AssertNil(t, "selector err", err)
+	testutil.AssertFalse(t, "web selects worker", selector.Matches(labels.Set(worker.Spec.Template.Labels)))
+
+	app.Spec.Instances.Stopped = true
+	stopped, err := MakeProcessDeployment(app, space, process)
+	testutil.AssertNil(t, "stopped err", err)
+	testutil.AssertEqual(t, "stopped replicas", ptr.Int32(0), stopped.Spec.Replicas)
+}
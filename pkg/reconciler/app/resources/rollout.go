@@ -0,0 +1,167 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/ptr"
+)
+
+// CandidateDeploymentName gets the name of the Deployment running the new
+// revision of an App during a rollout.
+func CandidateDeploymentName(app *v1alpha1.App) string {
+	return v1alpha1.GenerateName(app.Name, "candidate")
+}
+
+// CandidateServiceName gets the name of the Service routing traffic to the
+// new revision of an App during a rollout.
+func CandidateServiceName(app *v1alpha1.App) string {
+	return v1alpha1.GenerateName(app.Name, "candidate")
+}
+
+// CandidatePodLabels returns the labels for selecting pods of the candidate
+// Deployment. They include PodLabels so the candidate's pods show up in logs
+// and SSH, but not StablePodLabels so the App's Service doesn't send traffic
+// to the candidate.
+func CandidatePodLabels(app *v1alpha1.App) map[string]string {
+	return v1alpha1.UnionMaps(
+		PodLabels(app),
+		map[string]string{
+			v1alpha1.RolloutLabel: v1alpha1.RolloutCandidate,
+		})
+}
+
+const (
+	// candidateStartPaddingSeconds is the time a candidate Pod is given to be
+	// scheduled and pull its image before its probes start.
+	candidateStartPaddingSeconds = 60
+
+	// maxCandidateProgressDeadlineSeconds matches the progress deadline of the
+	// stable Deployment.
+	maxCandidateProgressDeadlineSeconds = 600
+)
+
+// CandidateProgressDeadlineSeconds returns how long the candidate Deployment
+// can go without a new Pod becoming ready before the rollout is rolled back.
+// It's based on how long the user container's probes give the App to start
+// before it's restarted so revisions that fail to start are rolled back
+// quickly.
+func CandidateProgressDeadlineSeconds(spec corev1.PodSpec) int32 {
+	if len(spec.Containers) == 0 {
+		return maxCandidateProgressDeadlineSeconds
+	}
+
+	container := spec.Containers[0]
+	probe := container.StartupProbe
+	if probe == nil {
+		probe = container.LivenessProbe
+	}
+
+	switch {
+	case probe == nil && container.ReadinessProbe != nil:
+		// Nothing restarts the App if it takes a long time to become ready.
+		return maxCandidateProgressDeadlineSeconds
+	case probe == nil:
+		// Pods without probes are ready as soon as they start.
+		return candidateStartPaddingSeconds
+	}
+
+	// Zero values are defaulted by Kubernetes.
+	period := probe.PeriodSeconds
+	if period == 0 {
+		period = 10
+	}
+	failureThreshold := probe.FailureThreshold
+	if failureThreshold == 0 {
+		failureThreshold = 3
+	}
+
+	deadline := candidateStartPaddingSeconds + probe.InitialDelaySeconds + period*failureThreshold
+	if deadline > maxCandidateProgressDeadlineSeconds {
+		return maxCandidateProgressDeadlineSeconds
+	}
+
+	return deadline
+}
+
+// RevisionHash returns a short hash identifying the revision a Pod template
+// represents.
+func RevisionHash(template corev1.PodTemplateSpec) string {
+	hasher := fnv.New32a()
+	// PodTemplateSpecs can always be marshaled.
+	encoded, _ := json.Marshal(template)
+	hasher.Write(encoded)
+	return fmt.Sprintf("%08x", hasher.Sum32())
+}
+
+// CandidateReplicas returns the number of instances the candidate revision
+// needs to serve the given percentage of an App's traffic. At least one
+// instance is always returned.
+func CandidateReplicas(replicas, percent int32) int32 {
+	out := (replicas*percent + 99) / 100
+	if out < 1 {
+		return 1
+	}
+
+	return out
+}
+
+// MakeCandidateDeployment creates a K8s Deployment for the new revision of an
+// App during a rollout. The Deployment mirrors the one created by
+// MakeDeployment but selects a separate set of Pods and has a shorter
+// progress deadline.
+func MakeCandidateDeployment(
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+	replicas int32,
+) (*appsv1.Deployment, error) {
+	deployment, err := MakeDeployment(app, space)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment.Name = CandidateDeploymentName(app)
+	deployment.Labels = v1alpha1.UnionMaps(app.GetLabels(), app.ComponentLabels("app-candidate-scaler"))
+	deployment.Spec.Selector = metav1.SetAsLabelSelector(labels.Set(CandidatePodLabels(app)))
+	deployment.Spec.Template.Labels = v1alpha1.UnionMaps(
+		CandidatePodLabels(app),
+		map[string]string{
+			v1alpha1.NetworkPolicyLabel: v1alpha1.NetworkPolicyApp,
+		})
+	deployment.Spec.Replicas = ptr.Int32(replicas)
+	deployment.Spec.ProgressDeadlineSeconds = ptr.Int32(
+		CandidateProgressDeadlineSeconds(deployment.Spec.Template.Spec))
+
+	return deployment, nil
+}
+
+// MakeCandidateService creates a K8s Service that routes to the Pods of the
+// candidate Deployment.
+func MakeCandidateService(app *v1alpha1.App) *corev1.Service {
+	service := MakeService(app)
+	service.Name = CandidateServiceName(app)
+	service.Labels = v1alpha1.UnionMaps(app.GetLabels(), app.ComponentLabels("candidate-service"))
+	service.Spec.Selector = CandidatePodLabels(app)
+
+	return service
+}
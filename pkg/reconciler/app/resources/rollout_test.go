@@ -0,0 +1,225 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/ptr"
+)
+
+func ExampleCandidateDeploymentName() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+
+	fmt.Println("Deployment name:", CandidateDeploymentName(app))
+	fmt.Println("Service name:", CandidateServiceName(app))
+
+	// Output: Deployment name: my-app-candidate
+	// Service name: my-app-candidate
+}
+
+func ExampleCandidatePodLabels() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	candidate := labels.Set(CandidatePodLabels(app))
+
+	fmt.Println("Labels:", candidate)
+	fmt.Println("Selected by App Service:", labels.SelectorFromSet(MakeService(app).Spec.Selector).Matches(candidate))
+	fmt.Println("Selected by logs:", labels.SelectorFromSet(PodLabels(app)).Matches(candidate))
+
+	// Output: Labels: app.kubernetes.io/component=app-server,app.kubernetes.io/managed-by=kf,app.kubernetes.io/name=my-app,kf.dev/rollout=candidate
+	// Selected by App Service: false
+	// Selected by logs: true
+}
+
+func TestCandidateReplicas(t *testing.T) {
+	cases := map[string]struct {
+		replicas int32
+		percent  int32
+		want     int32
+	}{
+		"rounds up": {
+			replicas: 10,
+			percent:  15,
+			want:     2,
+		},
+		"exact": {
+			replicas: 10,
+			percent:  50,
+			want:     5,
+		},
+		"at least one": {
+			replicas: 1,
+			percent:  1,
+			want:     1,
+		},
+		"zero replicas": {
+			replicas: 0,
+			percent:  50,
+			want:     1,
+		},
+		"full": {
+			replicas: 3,
+			percent:  100,
+			want:     3,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "replicas", tc.want, CandidateReplicas(tc.replicas, tc.percent))
+		})
+	}
+}
+
+func TestRevisionHash(t *testing.T) {
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Image: "gcr.io/my-app:v1"}},
+		},
+	}
+
+	changed := template.DeepCopy()
+	changed.Spec.Containers[0].Image = "gcr.io/my-app:v2"
+
+	testutil.AssertEqual(t, "stable", RevisionHash(template), RevisionHash(*template.DeepCopy()))
+	testutil.AssertEqual(t, "length", 8, len(RevisionHash(template)))
+	testutil.AssertTrue(t, "changed", RevisionHash(template) != RevisionHash(*changed))
+}
+
+func TestMakeCandidateDeployment(t *testing.T) {
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "my-space",
+		},
+		Spec: v1alpha1.AppSpec{
+			Instances: v1alpha1.AppSpecInstances{
+				Replicas: ptr.Int32(10),
+			},
+		},
+		Status: v1alpha1.AppStatus{
+			BuildStatusFields: v1alpha1.BuildStatusFields{
+				Image: "gcr.io/my-app",
+			},
+		},
+	}
+	space := &v1alpha1.Space{}
+
+	stable, err := MakeDeployment(app, space)
+	testutil.AssertNil(t, "stable err", err)
+
+	candidate, err := MakeCandidateDeployment(app, space, 2)
+	testutil.AssertNil(t, "candidate err", err)
+
+	testutil.AssertEqual(t, "name", "my-app-candidate", candidate.Name)
+	testutil.AssertEqual(t, "replicas", ptr.Int32(2), candidate.Spec.Replicas)
+	testutil.AssertEqual(t, "selector", CandidatePodLabels(app), candidate.Spec.Selector.MatchLabels)
+	testutil.AssertEqual(t, "owner", stable.OwnerReferences, candidate.OwnerReferences)
+	testutil.AssertEqual(t, "pod spec", stable.Spec.Template.Spec, candidate.Spec.Template.Spec)
+	testutil.AssertEqual(
+		t,
+		"network policy label",
+		v1alpha1.NetworkPolicyApp,
+		candidate.Spec.Template.Labels[v1alpha1.NetworkPolicyLabel],
+	)
+
+	testutil.AssertEqual(t, "progress deadline", ptr.Int32(60), candidate.Spec.ProgressDeadlineSeconds)
+
+	selector, err := metav1.LabelSelectorAsSelector(candidate.Spec.Selector)
+	testutil.AssertNil(t, "selector err", err)
+	testutil.AssertFalse(t, "candidate selects stable", selector.Matches(labels.Set(stable.Spec.Template.Labels)))
+	testutil.AssertFalse(
+		t,
+		"App Service selects candidate",
+		labels.SelectorFromSet(MakeService(app).Spec.Selector).Matches(labels.Set(candidate.Spec.Template.Labels)),
+	)
+}
+
+func TestCandidateProgressDeadlineSeconds(t *testing.T) {
+	cases := map[string]struct {
+		container corev1.Container
+		want      int32
+	}{
+		"no probes": {
+			container: corev1.Container{},
+			want:      60,
+		},
+		"readiness only": {
+			container: corev1.Container{
+				ReadinessProbe: &corev1.Probe{PeriodSeconds: 1},
+			},
+			want: 600,
+		},
+		"liveness defaults": {
+			container: corev1.Container{
+				ReadinessProbe: &corev1.Probe{PeriodSeconds: 1},
+				LivenessProbe:  &corev1.Probe{},
+			},
+			want: 90,
+		},
+		"startup probe": {
+			container: corev1.Container{
+				LivenessProbe: &corev1.Probe{},
+				StartupProbe: &corev1.Probe{
+					InitialDelaySeconds: 5,
+					PeriodSeconds:       2,
+					FailureThreshold:    30,
+				},
+			},
+			want: 125,
+		},
+		"capped": {
+			container: corev1.Container{
+				StartupProbe: &corev1.Probe{
+					PeriodSeconds:    10,
+					FailureThreshold: 100,
+				},
+			},
+			want: 600,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			spec := corev1.PodSpec{Containers: []corev1.Container{tc.container}}
+			testutil.AssertEqual(t, "deadline", tc.want, CandidateProgressDeadlineSeconds(spec))
+		})
+	}
+}
+
+func TestMakeCandidateService(t *testing.T) {
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-app",
+			Namespace: "my-space",
+		},
+	}
+
+	stable := MakeService(app)
+	candidate := MakeCandidateService(app)
+
+	testutil.AssertEqual(t, "name", "my-app-candidate", candidate.Name)
+	testutil.AssertEqual(t, "namespace", "my-space", candidate.Namespace)
+	testutil.AssertEqual(t, "selector", CandidatePodLabels(app), candidate.Spec.Selector)
+	testutil.AssertEqual(t, "ports", stable.Spec.Ports, candidate.Spec.Ports)
+}
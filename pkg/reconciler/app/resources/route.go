@@ -52,14 +52,18 @@ func MakeRoutes(
 	// now equal get merged.
 	bindings = v1alpha1.MergeQualifiedBindings(bindings)
 
+	// Split traffic with the candidate revision if a rollout is shifting it.
+	if rollout := app.Status.Rollout; rollout.IsActive() && rollout.CandidatePercent > 0 {
+		for i := range bindings {
+			bindings[i].Destination.CandidateServiceName = CandidateServiceName(app)
+			bindings[i].Destination.CandidatePercent = rollout.CandidatePercent
+		}
+	}
+
 	// Build claims, only one claim per name will be built
 	claimNames := sets.NewString()
 	for _, binding := range bindings {
-		name := v1alpha1.GenerateRouteName(
-			binding.Source.Hostname,
-			binding.Source.Domain,
-			binding.Source.Path,
-		)
+		name := v1alpha1.GenerateRouteNameFromFields(binding.Source)
 
 		if claimNames.Has(name) {
 			continue
@@ -37,6 +37,17 @@ func PodLabels(app *v1alpha1.App) map[string]string {
 	return app.ComponentLabels("app-server")
 }
 
+// StablePodLabels returns the labels for selecting pods of the stable
+// deployment. Candidate pods during a rollout also have PodLabels so they show
+// up in logs and SSH, but they're excluded from the App's Service.
+func StablePodLabels(app *v1alpha1.App) map[string]string {
+	return v1alpha1.UnionMaps(
+		PodLabels(app),
+		map[string]string{
+			v1alpha1.RolloutLabel: v1alpha1.RolloutStable,
+		})
+}
+
 // ServiceName is the name of the service for the app
 func ServiceName(app *kfv1alpha1.App) string {
 	return ServiceNameForAppName(app.Name)
@@ -62,7 +73,7 @@ func MakeService(app *kfv1alpha1.App) *corev1.Service {
 		},
 		Spec: corev1.ServiceSpec{
 			Ports:    makeServicePorts(app),
-			Selector: PodLabels(app),
+			Selector: StablePodLabels(app),
 			Type:     corev1.ServiceTypeClusterIP,
 		},
 	}
@@ -0,0 +1,359 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/reconciler/app/resources"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// rolloutPlan contains the actions needed to move an App's rollout forward.
+type rolloutPlan struct {
+	// status is the new status of the rollout, nil if the App has never used a
+	// BlueGreen or Canary strategy.
+	status *v1alpha1.AppRolloutStatus
+
+	// updateStable is true if the stable Deployment should be updated to the
+	// desired revision.
+	updateStable bool
+
+	// candidateReplicas is the number of instances the candidate Deployment
+	// should have, nil if the candidate shouldn't exist.
+	candidateReplicas *int32
+}
+
+// planRollout determines the next step of an App's rollout given the desired
+// stable Deployment and the actual stable and candidate Deployments. The
+// candidate may be nil if it doesn't exist.
+//
+// Traffic is shifted by the Routes using the CandidatePercent of the previous
+// status, so the candidate is only scaled down or deleted once the previous
+// status no longer sends it traffic. Likewise, the stable Deployment is only
+// replaced once the App's Routes report they send all traffic to the
+// candidate.
+func planRollout(
+	app *v1alpha1.App,
+	desired *appsv1.Deployment,
+	desiredCandidate *appsv1.Deployment,
+	stable *appsv1.Deployment,
+	candidate *appsv1.Deployment,
+) rolloutPlan {
+	spec := app.Spec.Rollout
+	prev := app.Status.Rollout
+	strategy := spec.GetStrategy()
+	replicas := *desired.Spec.Replicas
+
+	// Keeps the candidate around until traffic is no longer being sent to it.
+	drainCandidate := func() *int32 {
+		if candidate != nil && prev != nil && prev.CandidatePercent > 0 {
+			return candidate.Spec.Replicas
+		}
+		return nil
+	}
+
+	// Marks an in-flight rollout as aborted and updates the stable Deployment
+	// in place.
+	inPlace := func(message string) rolloutPlan {
+		if prev == nil {
+			return rolloutPlan{updateStable: true, candidateReplicas: drainCandidate()}
+		}
+
+		status := prev.DeepCopy()
+		if status.IsActive() {
+			status.State = v1alpha1.RolloutStateAborted
+			status.CandidatePercent = 0
+			status.Message = message
+		}
+
+		return rolloutPlan{status: status, updateStable: true, candidateReplicas: drainCandidate()}
+	}
+
+	switch {
+	case strategy == v1alpha1.RollingRolloutStrategy:
+		return inPlace("Rollout strategy changed to Rolling")
+	case replicas == 0 || stable.Status.AvailableReplicas == 0:
+		// Nothing is serving traffic so there's nothing to protect.
+		return inPlace("App has no running instances")
+	}
+
+	revision := resources.RevisionHash(desired.Spec.Template)
+
+	if equality.Semantic.DeepEqual(desired.Spec.Template, stable.Spec.Template) {
+		// The stable Deployment already runs the desired revision.
+		if prev == nil || !prev.IsActive() {
+			return rolloutPlan{status: prev.DeepCopy(), updateStable: true, candidateReplicas: drainCandidate()}
+		}
+
+		status := prev.DeepCopy()
+		if status.State != v1alpha1.RolloutStatePromoting || status.Revision != revision {
+			status.State = v1alpha1.RolloutStateAborted
+			status.CandidatePercent = 0
+			status.Message = "Rollout superseded by the running revision"
+			return rolloutPlan{status: status, updateStable: true, candidateReplicas: drainCandidate()}
+		}
+
+		if !deploymentAvailable(stable, replicas) {
+			// Keep the candidate serving until the stable Deployment catches up.
+			status.Message = "Waiting for the promoted revision to replace the previous one"
+			return rolloutPlan{status: status, updateStable: true, candidateReplicas: &replicas}
+		}
+
+		status.State = v1alpha1.RolloutStateComplete
+		status.CandidatePercent = 0
+		status.Message = "New revision promoted"
+		return rolloutPlan{status: status, updateStable: true, candidateReplicas: drainCandidate()}
+	}
+
+	status := prev.DeepCopy()
+	switch {
+	case status == nil,
+		status.Revision != revision,
+		status.State == v1alpha1.RolloutStateComplete:
+		status = &v1alpha1.AppRolloutStatus{
+			Revision:             revision,
+			State:                v1alpha1.RolloutStateProgressing,
+			StartPromoteRequests: spec.PromoteRequests,
+			StartAbortRequests:   spec.AbortRequests,
+		}
+	case !status.IsActive():
+		// The revision was aborted or rolled back, keep the stable Deployment
+		// running until a new revision is pushed.
+		return rolloutPlan{status: status, candidateReplicas: drainCandidate()}
+	}
+	status.Strategy = strategy
+
+	if spec.AbortRequests > status.StartAbortRequests && status.State != v1alpha1.RolloutStatePromoting {
+		status.State = v1alpha1.RolloutStateAborted
+		status.CandidatePercent = 0
+		status.Message = "Rollout aborted, traffic returned to the previous revision"
+		return rolloutPlan{status: status, candidateReplicas: drainCandidate()}
+	}
+
+	steps := spec.Steps
+	if len(steps) == 0 {
+		steps = v1alpha1.DefaultCanarySteps
+	}
+
+	initialReplicas := replicas
+	if strategy == v1alpha1.CanaryRolloutStrategy {
+		initialReplicas = resources.CandidateReplicas(replicas, steps[0])
+	}
+
+	candidateCurrent := candidate != nil &&
+		equality.Semantic.DeepEqual(desiredCandidate.Spec.Template, candidate.Spec.Template) &&
+		candidate.Generation <= candidate.Status.ObservedGeneration
+
+	if !candidateCurrent {
+		status.Message = "Waiting for the new revision to start"
+		if status.State == v1alpha1.RolloutStatePromoting {
+			return rolloutPlan{status: status, candidateReplicas: &replicas}
+		}
+		return rolloutPlan{status: status, candidateReplicas: &initialReplicas}
+	}
+
+	if status.State != v1alpha1.RolloutStatePromoting {
+		failed := deploymentDeadlineExceeded(candidate)
+		if status.State == v1alpha1.RolloutStatePaused && candidate.Status.AvailableReplicas == 0 {
+			failed = true
+		}
+
+		if failed {
+			status.State = v1alpha1.RolloutStateRolledBack
+			status.CandidatePercent = 0
+			status.Message = fmt.Sprintf("New revision %s failed to become ready and was rolled back", revision)
+			return rolloutPlan{status: status, candidateReplicas: drainCandidate()}
+		}
+	}
+
+	if status.State == v1alpha1.RolloutStateProgressing {
+		if !deploymentAvailable(candidate, initialReplicas) {
+			status.Message = "Waiting for the new revision to become ready"
+			return rolloutPlan{status: status, candidateReplicas: &initialReplicas}
+		}
+
+		if strategy == v1alpha1.CanaryRolloutStrategy {
+			status.State = v1alpha1.RolloutStatePaused
+		} else {
+			status.State = v1alpha1.RolloutStatePromoting
+		}
+	}
+
+	if status.State == v1alpha1.RolloutStatePaused {
+		step := spec.PromoteRequests - status.StartPromoteRequests
+		if step < len(steps) {
+			percent := steps[step]
+			stepReplicas := resources.CandidateReplicas(replicas, percent)
+
+			status.Step = int32(step)
+			if deploymentAvailable(candidate, stepReplicas) {
+				status.CandidatePercent = percent
+			}
+			status.Message = fmt.Sprintf("Sending %d%% of traffic to the new revision, promote to continue", status.CandidatePercent)
+			return rolloutPlan{status: status, candidateReplicas: &stepReplicas}
+		}
+
+		status.State = v1alpha1.RolloutStatePromoting
+	}
+
+	// The candidate is scaled to the full number of instances and receives all
+	// traffic before the stable Deployment is updated in place.
+	if deploymentAvailable(candidate, replicas) {
+		status.CandidatePercent = 100
+	}
+	status.Message = "Promoting the new revision"
+
+	trafficShifted := prev != nil &&
+		prev.State == v1alpha1.RolloutStatePromoting &&
+		prev.Revision == revision &&
+		prev.CandidatePercent == 100
+
+	if trafficShifted && !routesProgrammed(app) {
+		// The Routes haven't picked up the new weights yet so the previous
+		// revision may still be receiving traffic.
+		trafficShifted = false
+		status.Message = "Waiting for the Routes to send all traffic to the new revision"
+	}
+
+	return rolloutPlan{status: status, updateStable: trafficShifted, candidateReplicas: &replicas}
+}
+
+// routesProgrammed returns true if every Route the App is bound to has
+// observed the App's bindings, including the traffic split from the previous
+// rollout status, and programmed its VirtualService with them.
+func routesProgrammed(app *v1alpha1.App) bool {
+	for _, route := range app.Status.Routes {
+		if route.Status == v1alpha1.RouteBindingStatusUnknown {
+			return false
+		}
+	}
+
+	return true
+}
+
+// deploymentAvailable returns true if the Deployment has observed its latest
+// spec and all of the given number of replicas are updated and available.
+func deploymentAvailable(deployment *appsv1.Deployment, replicas int32) bool {
+	return deployment.Generation <= deployment.Status.ObservedGeneration &&
+		deployment.Spec.Replicas != nil &&
+		*deployment.Spec.Replicas == replicas &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
+
+// deploymentDeadlineExceeded returns true if the Deployment failed to make
+// progress within its deadline.
+func deploymentDeadlineExceeded(deployment *appsv1.Deployment) bool {
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing &&
+			cond.Status == corev1.ConditionFalse &&
+			cond.Reason == "ProgressDeadlineExceeded" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stablePodsLabeled returns true if all Pods of the App's stable Deployment
+// have the stable RolloutLabel. Until they do, the App's Service selects all
+// of the App's Pods so Pods created before the label was added keep receiving
+// traffic.
+func stablePodsLabeled(deployment *appsv1.Deployment) bool {
+	if deployment == nil {
+		// The Deployment will be created with the label.
+		return true
+	}
+
+	return deployment.Spec.Template.Labels[v1alpha1.RolloutLabel] == v1alpha1.RolloutStable &&
+		deployment.Generation <= deployment.Status.ObservedGeneration &&
+		deployment.Status.Replicas == deployment.Status.UpdatedReplicas
+}
+
+// reconcileCandidate creates, updates, or deletes the candidate Deployment and
+// Service for an App's rollout.
+func (r *Reconciler) reconcileCandidate(
+	ctx context.Context,
+	app *v1alpha1.App,
+	desired *appsv1.Deployment,
+	replicas *int32,
+) error {
+	logger := logging.FromContext(ctx)
+
+	desiredService := resources.MakeCandidateService(app)
+	actualService, err := r.serviceLister.Services(desiredService.Namespace).Get(desiredService.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		actualService = nil
+	case err != nil:
+		return err
+	case !metav1.IsControlledBy(actualService, app):
+		return fmt.Errorf("service %q is not owned by App %q", desiredService.Name, app.Name)
+	}
+
+	actual, err := r.deploymentLister.Deployments(desired.Namespace).Get(desired.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		actual = nil
+	case err != nil:
+		return err
+	case !metav1.IsControlledBy(actual, app):
+		return fmt.Errorf("deployment %q is not owned by App %q", desired.Name, app.Name)
+	}
+
+	if replicas == nil {
+		if actual != nil {
+			logger.Infof("Deleting candidate Deployment %s", actual.Name)
+			if err := r.KubeClientSet.AppsV1().Deployments(actual.Namespace).Delete(ctx, actual.Name, metav1.DeleteOptions{}); err != nil {
+				return err
+			}
+		}
+
+		if actualService != nil {
+			logger.Infof("Deleting candidate Service %s", actualService.Name)
+			if err := r.KubeClientSet.CoreV1().Services(actualService.Namespace).Delete(ctx, actualService.Name, metav1.DeleteOptions{}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if actualService == nil {
+		if _, err := r.KubeClientSet.CoreV1().Services(desiredService.Namespace).Create(ctx, desiredService, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else if _, err := r.ReconcileService(ctx, desiredService, actualService); err != nil {
+		return err
+	}
+
+	desired = desired.DeepCopy()
+	desired.Spec.Replicas = replicas
+	if actual == nil {
+		_, err = r.KubeClientSet.AppsV1().Deployments(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+	} else {
+		_, err = r.ReconcileDeployment(ctx, desired, actual)
+	}
+
+	return err
+}
@@ -0,0 +1,317 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler/app/resources"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/ptr"
+)
+
+func makeRolloutDeployment(image string, replicas, available int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(replicas),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Image: image}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas:   available,
+			AvailableReplicas: available,
+		},
+	}
+}
+
+func TestPlanRollout(t *testing.T) {
+	t.Parallel()
+
+	desired := makeRolloutDeployment("v2", 4, 0)
+	revision := resources.RevisionHash(desired.Spec.Template)
+
+	canary := &v1alpha1.AppSpecRollout{
+		Strategy: v1alpha1.CanaryRolloutStrategy,
+		Steps:    []int32{10, 50},
+	}
+
+	cases := map[string]struct {
+		spec      *v1alpha1.AppSpecRollout
+		status    *v1alpha1.AppRolloutStatus
+		stable    *appsv1.Deployment
+		candidate *appsv1.Deployment
+		routes    []v1alpha1.AppRouteStatus
+
+		wantState        v1alpha1.RolloutState
+		wantPercent      int32
+		wantUpdateStable bool
+		wantReplicas     *int32
+	}{
+		"rolling updates in place": {
+			spec:             nil,
+			stable:           makeRolloutDeployment("v1", 4, 4),
+			wantUpdateStable: true,
+		},
+		"no running instances updates in place": {
+			spec:             canary,
+			stable:           makeRolloutDeployment("v1", 4, 0),
+			wantUpdateStable: true,
+		},
+		"switching to rolling aborts rollout": {
+			spec: nil,
+			status: &v1alpha1.AppRolloutStatus{
+				State:            v1alpha1.RolloutStatePaused,
+				Revision:         revision,
+				CandidatePercent: 10,
+			},
+			stable:           makeRolloutDeployment("v1", 4, 4),
+			candidate:        makeRolloutDeployment("v2", 1, 1),
+			wantState:        v1alpha1.RolloutStateAborted,
+			wantUpdateStable: true,
+			wantReplicas:     ptr.Int32(1),
+		},
+		"blue-green starts full candidate": {
+			spec:         &v1alpha1.AppSpecRollout{Strategy: v1alpha1.BlueGreenRolloutStrategy},
+			stable:       makeRolloutDeployment("v1", 4, 4),
+			wantState:    v1alpha1.RolloutStateProgressing,
+			wantReplicas: ptr.Int32(4),
+		},
+		"blue-green candidate ready promotes": {
+			spec: &v1alpha1.AppSpecRollout{Strategy: v1alpha1.BlueGreenRolloutStrategy},
+			status: &v1alpha1.AppRolloutStatus{
+				State:    v1alpha1.RolloutStateProgressing,
+				Revision: revision,
+			},
+			stable:       makeRolloutDeployment("v1", 4, 4),
+			candidate:    makeRolloutDeployment("v2", 4, 4),
+			wantState:    v1alpha1.RolloutStatePromoting,
+			wantPercent:  100,
+			wantReplicas: ptr.Int32(4),
+		},
+		"canary starts at first step": {
+			spec:         canary,
+			stable:       makeRolloutDeployment("v1", 4, 4),
+			wantState:    v1alpha1.RolloutStateProgressing,
+			wantReplicas: ptr.Int32(1),
+		},
+		"canary candidate ready pauses": {
+			spec: canary,
+			status: &v1alpha1.AppRolloutStatus{
+				State:    v1alpha1.RolloutStateProgressing,
+				Revision: revision,
+			},
+			stable:       makeRolloutDeployment("v1", 4, 4),
+			candidate:    makeRolloutDeployment("v2", 1, 1),
+			wantState:    v1alpha1.RolloutStatePaused,
+			wantPercent:  10,
+			wantReplicas: ptr.Int32(1),
+		},
+		"canary promote scales before shifting traffic": {
+			spec: &v1alpha1.AppSpecRollout{
+				Strategy:        v1alpha1.CanaryRolloutStrategy,
+				Steps:           []int32{10, 50},
+				PromoteRequests: 1,
+			},
+			status: &v1alpha1.AppRolloutStatus{
+				State:            v1alpha1.RolloutStatePaused,
+				Revision:         revision,
+				CandidatePercent: 10,
+			},
+			stable:       makeRolloutDeployment("v1", 4, 4),
+			candidate:    makeRolloutDeployment("v2", 1, 1),
+			wantState:    v1alpha1.RolloutStatePaused,
+			wantPercent:  10,
+			wantReplicas: ptr.Int32(2),
+		},
+		"canary promote past last step": {
+			spec: &v1alpha1.AppSpecRollout{
+				Strategy:        v1alpha1.CanaryRolloutStrategy,
+				Steps:           []int32{10, 50},
+				PromoteRequests: 2,
+			},
+			status: &v1alpha1.AppRolloutStatus{
+				State:            v1alpha1.RolloutStatePaused,
+				Revision:         revision,
+				Step:             1,
+				CandidatePercent: 50,
+			},
+			stable:       makeRolloutDeployment("v1", 4, 4),
+			candidate:    makeRolloutDeployment("v2", 2, 2),
+			wantState:    v1alpha1.RolloutStatePromoting,
+			wantPercent:  50,
+			wantReplicas: ptr.Int32(4),
+		},
+		"promoting updates stable once traffic shifted": {
+			spec: canary,
+			status: &v1alpha1.AppRolloutStatus{
+				State:            v1alpha1.RolloutStatePromoting,
+				Revision:         revision,
+				CandidatePercent: 100,
+			},
+			stable:           makeRolloutDeployment("v1", 4, 4),
+			candidate:        makeRolloutDeployment("v2", 4, 4),
+			wantState:        v1alpha1.RolloutStatePromoting,
+			wantPercent:      100,
+			wantUpdateStable: true,
+			wantReplicas:     ptr.Int32(4),
+		},
+		"promoting waits for routes to shift traffic": {
+			spec: canary,
+			status: &v1alpha1.AppRolloutStatus{
+				State:            v1alpha1.RolloutStatePromoting,
+				Revision:         revision,
+				CandidatePercent: 100,
+			},
+			stable:    makeRolloutDeployment("v1", 4, 4),
+			candidate: makeRolloutDeployment("v2", 4, 4),
+			routes: []v1alpha1.AppRouteStatus{
+				{Status: v1alpha1.RouteBindingStatusReady},
+				{Status: v1alpha1.RouteBindingStatusUnknown},
+			},
+			wantState:    v1alpha1.RolloutStatePromoting,
+			wantPercent:  100,
+			wantReplicas: ptr.Int32(4),
+		},
+		"promoted stable completes": {
+			spec: canary,
+			status: &v1alpha1.AppRolloutStatus{
+				State:            v1alpha1.RolloutStatePromoting,
+				Revision:         revision,
+				CandidatePercent: 100,
+			},
+			stable:           makeRolloutDeployment("v2", 4, 4),
+			candidate:        makeRolloutDeployment("v2", 4, 4),
+			wantState:        v1alpha1.RolloutStateComplete,
+			wantUpdateStable: true,
+			wantReplicas:     ptr.Int32(4),
+		},
+		"abort drains candidate": {
+			spec: &v1alpha1.AppSpecRollout{
+				Strategy:      v1alpha1.CanaryRolloutStrategy,
+				Steps:         []int32{10, 50},
+				AbortRequests: 1,
+			},
+			status: &v1alpha1.AppRolloutStatus{
+				State:            v1alpha1.RolloutStatePaused,
+				Revision:         revision,
+				CandidatePercent: 10,
+			},
+			stable:       makeRolloutDeployment("v1", 4, 4),
+			candidate:    makeRolloutDeployment("v2", 1, 1),
+			wantState:    v1alpha1.RolloutStateAborted,
+			wantReplicas: ptr.Int32(1),
+		},
+		"aborted revision holds": {
+			spec: canary,
+			status: &v1alpha1.AppRolloutStatus{
+				State:    v1alpha1.RolloutStateAborted,
+				Revision: revision,
+			},
+			stable:    makeRolloutDeployment("v1", 4, 4),
+			candidate: makeRolloutDeployment("v2", 1, 1),
+			wantState: v1alpha1.RolloutStateAborted,
+		},
+		"failed candidate rolls back": {
+			spec: canary,
+			status: &v1alpha1.AppRolloutStatus{
+				State:    v1alpha1.RolloutStateProgressing,
+				Revision: revision,
+			},
+			stable: makeRolloutDeployment("v1", 4, 4),
+			candidate: func() *appsv1.Deployment {
+				d := makeRolloutDeployment("v2", 1, 0)
+				d.Status.Conditions = []appsv1.DeploymentCondition{{
+					Type:   appsv1.DeploymentProgressing,
+					Status: corev1.ConditionFalse,
+					Reason: "ProgressDeadlineExceeded",
+				}}
+				return d
+			}(),
+			wantState: v1alpha1.RolloutStateRolledBack,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			app := &v1alpha1.App{}
+			app.Spec.Rollout = tc.spec
+			app.Status.Rollout = tc.status
+			app.Status.Routes = tc.routes
+
+			plan := planRollout(app, desired, desired, tc.stable, tc.candidate)
+
+			if tc.wantState == "" {
+				testutil.AssertTrue(t, "status is nil", plan.status == nil)
+			} else {
+				testutil.AssertNotNil(t, "status", plan.status)
+				testutil.AssertEqual(t, "state", tc.wantState, plan.status.State)
+				testutil.AssertEqual(t, "percent", tc.wantPercent, plan.status.CandidatePercent)
+			}
+			testutil.AssertEqual(t, "updateStable", tc.wantUpdateStable, plan.updateStable)
+			testutil.AssertEqual(t, "candidateReplicas", tc.wantReplicas, plan.candidateReplicas)
+		})
+	}
+}
+
+func TestStablePodsLabeled(t *testing.T) {
+	labeled := func(replicas, updated int32) *appsv1.Deployment {
+		d := makeRolloutDeployment("image", replicas, updated)
+		d.Spec.Template.Labels = map[string]string{v1alpha1.RolloutLabel: v1alpha1.RolloutStable}
+		d.Status.Replicas = replicas
+		return d
+	}
+
+	cases := map[string]struct {
+		deployment *appsv1.Deployment
+		want       bool
+	}{
+		"no deployment": {
+			want: true,
+		},
+		"all pods labeled": {
+			deployment: labeled(3, 3),
+			want:       true,
+		},
+		"old pods remaining": {
+			deployment: labeled(4, 3),
+			want:       false,
+		},
+		"template not labeled": {
+			deployment: makeRolloutDeployment("image", 3, 3),
+			want:       false,
+		},
+		"template not observed": {
+			deployment: func() *appsv1.Deployment {
+				d := labeled(3, 3)
+				d.Generation = 2
+				d.Status.ObservedGeneration = 1
+				return d
+			}(),
+			want: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "labeled", tc.want, stablePodsLabeled(tc.deployment))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appnetworkpolicy
+
+import (
+	"context"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	appnetworkpolicyinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/appnetworkpolicy"
+	networkpolicyinformer "github.com/google/kf/v2/pkg/client/kube/injection/informers/networking/v1/networkpolicy"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+)
+
+// NewController creates a new controller capable of reconciling Kf
+// AppNetworkPolicies.
+func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	logger := reconciler.NewControllerLogger(ctx, "appnetworkpolicies.kf.dev")
+
+	// Get informers off context
+	appNetworkPolicyInformer := appnetworkpolicyinformer.Get(ctx)
+	networkPolicyInformer := networkpolicyinformer.Get(ctx)
+
+	// Create reconciler
+	c := &Reconciler{
+		Base:                   reconciler.NewBase(ctx, cmw),
+		appNetworkPolicyLister: appNetworkPolicyInformer.Lister(),
+		networkPolicyLister:    networkPolicyInformer.Lister(),
+	}
+
+	impl := controller.NewContext(ctx, c, controller.ControllerOptions{WorkQueueName: "AppNetworkPolicies", Logger: logger})
+
+	logger.Info("Setting up event handlers")
+
+	// Watch for changes in sub-resources so we can sync accordingly
+	appNetworkPolicyInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	networkPolicyInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGVK(v1alpha1.SchemeGroupVersion.WithKind("AppNetworkPolicy")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	return impl
+}
@@ -0,0 +1,195 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appnetworkpolicy
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	networkingv1listers "github.com/google/kf/v2/pkg/client/kube/listers/networking/v1"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/appnetworkpolicy/resources"
+	"go.uber.org/zap"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// Reconciler reconciles an AppNetworkPolicy object with the K8s cluster.
+type Reconciler struct {
+	*reconciler.Base
+
+	appNetworkPolicyLister kflisters.AppNetworkPolicyLister
+	networkPolicyLister    networkingv1listers.NetworkPolicyLister
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile is called by knative/pkg when a new event is observed by one of the
+// watchers in the controller.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	return r.reconcileAppNetworkPolicy(
+		logging.WithLogger(ctx,
+			logging.FromContext(ctx).With("namespace", namespace)),
+		namespace,
+		name,
+	)
+}
+
+func (r *Reconciler) reconcileAppNetworkPolicy(ctx context.Context, namespace, name string) error {
+	logger := logging.FromContext(ctx)
+
+	original, err := r.appNetworkPolicyLister.AppNetworkPolicies(namespace).Get(name)
+	switch {
+	case apierrs.IsNotFound(err):
+		logger.Info("resource no longer exists")
+		return nil
+	case err != nil:
+		return err
+	case original.GetDeletionTimestamp() != nil:
+		logger.Info("resource deletion requested")
+		toUpdate := original.DeepCopy()
+		toUpdate.Status.PropagateTerminatingStatus()
+		if _, uErr := r.updateStatus(ctx, toUpdate); uErr != nil {
+			logger.Warnw("Failed to update AppNetworkPolicy status", zap.Error(uErr))
+			return uErr
+		}
+		return nil
+	}
+
+	if r.IsNamespaceTerminating(namespace) {
+		logger.Info("namespace is terminating, skipping reconciliation")
+		return nil
+	}
+
+	// Don't modify the informers copy
+	toReconcile := original.DeepCopy()
+
+	// ALWAYS update the ObservedGenration: "If the primary resource your
+	// controller is reconciling supports ObservedGeneration in its status, make
+	// sure you correctly set it to metadata.Generation whenever the values
+	// between the two fields mismatches."
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-api-machinery/controllers.md
+	toReconcile.Status.ObservedGeneration = toReconcile.Generation
+
+	// Reconcile this copy of the service and then write back any status
+	// updates regardless of whether the reconciliation errored out.
+	reconcileErr := r.ApplyChanges(ctx, toReconcile)
+	if reconcileErr != nil {
+		logger.Debugf("AppNetworkPolicy reconcilerErr is not empty: %+v", reconcileErr)
+	}
+	if equality.Semantic.DeepEqual(original.Status, toReconcile.Status) {
+		// If we didn't change anything then don't call updateStatus.
+		// This is important because the copy we loaded from the informer's
+		// cache may be stale and we don't want to overwrite a prior update
+		// to status with this stale state.
+	} else if _, uErr := r.updateStatus(ctx, toReconcile); uErr != nil {
+		logger.Warnw("Failed to update AppNetworkPolicy status", zap.Error(uErr))
+		return uErr
+	}
+
+	return reconcileErr
+}
+
+// ApplyChanges updates the linked resources in the cluster with the current
+// status of the AppNetworkPolicy.
+func (r *Reconciler) ApplyChanges(ctx context.Context, policy *v1alpha1.AppNetworkPolicy) error {
+	logger := logging.FromContext(ctx)
+	policy.Status.InitializeConditions()
+
+	{
+		logger.Debug("reconciling NetworkPolicy")
+		condition := policy.Status.NetworkPolicyCondition()
+
+		desired, err := resources.MakeNetworkPolicy(policy)
+		if err != nil {
+			return condition.MarkTemplateError(err)
+		}
+
+		actual, err := r.networkPolicyLister.
+			NetworkPolicies(desired.Namespace).
+			Get(desired.Name)
+		if apierrs.IsNotFound(err) {
+			actual, err = r.KubeClientSet.
+				NetworkingV1().
+				NetworkPolicies(desired.Namespace).
+				Create(ctx, desired, metav1.CreateOptions{})
+			if err != nil {
+				return condition.MarkReconciliationError("creating", err)
+			}
+		} else if err != nil {
+			return condition.MarkReconciliationError("getting latest", err)
+		} else if !metav1.IsControlledBy(actual, policy) {
+			return condition.MarkChildNotOwned(desired.Name)
+		} else if actual, err = r.reconcileNetworkPolicy(ctx, desired, actual); err != nil {
+			return condition.MarkReconciliationError("synchronizing", err)
+		}
+
+		policy.Status.PropagateNetworkPolicyStatus(actual)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileNetworkPolicy(ctx context.Context, desired, actual *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	logger := logging.FromContext(ctx)
+
+	// Check for differences, if none we don't need to reconcile.
+	if reconciler.NewSemanticEqualityBuilder(logger, "NetworkPolicy").
+		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
+		Append("spec", desired.Spec, actual.Spec).
+		IsSemanticallyEqual() {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object.
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec = desired.Spec
+	return r.KubeClientSet.NetworkingV1().NetworkPolicies(existing.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, desired *v1alpha1.AppNetworkPolicy) (*v1alpha1.AppNetworkPolicy, error) {
+	actual, err := r.appNetworkPolicyLister.AppNetworkPolicies(desired.GetNamespace()).Get(desired.Name)
+	if err != nil {
+		return nil, err
+	}
+	// If there's nothing to update, just return.
+	if reflect.DeepEqual(actual.Status, desired.Status) {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Status = desired.Status
+
+	return r.KfClientSet.KfV1alpha1().
+		AppNetworkPolicies(existing.GetNamespace()).
+		UpdateStatus(ctx, existing, metav1.UpdateOptions{})
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
+)
+
+// NetworkPolicyName gets the name of the NetworkPolicy for the
+// AppNetworkPolicy.
+func NetworkPolicyName(policy *v1alpha1.AppNetworkPolicy) string {
+	return v1alpha1.GenerateName("app-network-policy", policy.Name)
+}
+
+// AppPodLabels selects every Pod running the App's code: the web process,
+// additional processes, and rollout candidates. It's the App's pod labels
+// without the component.
+func AppPodLabels(appName string) map[string]string {
+	labels := v1alpha1.AppComponentLabels(appName, v1alpha1.AppServerComponent)
+	delete(labels, v1alpha1.ComponentLabel)
+	labels[v1alpha1.NetworkPolicyLabel] = v1alpha1.NetworkPolicyApp
+
+	return labels
+}
+
+// MakeNetworkPolicy creates a NetworkPolicy that allows traffic from the
+// source App to the destination App of the AppNetworkPolicy. The
+// NetworkPolicy lives in the destination App's Space.
+func MakeNetworkPolicy(policy *v1alpha1.AppNetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	var protocol corev1.Protocol
+	switch policy.Spec.Protocol {
+	case v1alpha1.AppNetworkPolicyProtocolTCP:
+		protocol = corev1.ProtocolTCP
+	case v1alpha1.AppNetworkPolicyProtocolUDP:
+		protocol = corev1.ProtocolUDP
+	default:
+		return nil, fmt.Errorf("unknown protocol: %s", policy.Spec.Protocol)
+	}
+
+	portRanges, err := v1alpha1.ParsePortRanges(policy.Spec.Ports)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []networkingv1.NetworkPolicyPort
+	for _, portRange := range portRanges {
+		port := intstr.FromInt(int(portRange.Start))
+		policyPort := networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		}
+
+		if portRange.End != portRange.Start {
+			policyPort.EndPort = ptr.Int32(portRange.End)
+		}
+
+		ports = append(ports, policyPort)
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NetworkPolicyName(policy),
+			Namespace: policy.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(policy),
+			},
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+			},
+			PodSelector: *metav1.SetAsLabelSelector(AppPodLabels(policy.Spec.DestinationApp)),
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							NamespaceSelector: metav1.SetAsLabelSelector(map[string]string{
+								corev1.LabelMetadataName: policy.SourceSpace(),
+							}),
+							PodSelector: metav1.SetAsLabelSelector(AppPodLabels(policy.Spec.Source.App)),
+						},
+					},
+					Ports: ports,
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestMakeNetworkPolicy(t *testing.T) {
+	cases := map[string]struct {
+		spec v1alpha1.AppNetworkPolicySpec
+	}{
+		"same space": {
+			spec: v1alpha1.AppNetworkPolicySpec{
+				Source:         v1alpha1.AppNetworkPolicySource{App: "frontend"},
+				DestinationApp: "backend",
+				Protocol:       v1alpha1.AppNetworkPolicyProtocolTCP,
+				Ports:          "8080",
+			},
+		},
+		"other space with port range": {
+			spec: v1alpha1.AppNetworkPolicySpec{
+				Source:         v1alpha1.AppNetworkPolicySource{Space: "web", App: "frontend"},
+				DestinationApp: "backend",
+				Protocol:       v1alpha1.AppNetworkPolicyProtocolUDP,
+				Ports:          "8000-9000",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			policy := &v1alpha1.AppNetworkPolicy{}
+			policy.Name = "frontend-to-backend"
+			policy.Namespace = "api"
+			policy.Spec = tc.spec
+
+			networkPolicy, err := MakeNetworkPolicy(policy)
+			testutil.AssertNil(t, "err", err)
+
+			testutil.AssertGoldenJSONContext(t, "networkpolicy", networkPolicy, map[string]interface{}{
+				"spec": tc.spec,
+			})
+		})
+	}
+}
+
+func TestMakeNetworkPolicy_invalid(t *testing.T) {
+	policy := &v1alpha1.AppNetworkPolicy{}
+	policy.Spec.Protocol = "icmp"
+
+	_, err := MakeNetworkPolicy(policy)
+	testutil.AssertErrorsEqual(t, fmt.Errorf("unknown protocol: icmp"), err)
+}
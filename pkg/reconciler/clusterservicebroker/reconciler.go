@@ -148,6 +148,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 		return err
 	}
 
+	// Requeue the broker so the catalog is periodically refreshed.
+	if delay, ok := toReconcile.Status.CatalogRefreshDelay(&toReconcile.Spec.CommonServiceBrokerSpec, time.Now()); ok {
+		return controller.NewRequeueAfter(delay)
+	}
+
 	return nil
 }
 
@@ -212,7 +217,8 @@ func (r *Reconciler) applyChanges(ctx context.Context, broker *v1alpha1.ClusterS
 }
 
 // ReconcileCatalog makes an OSB catalog request if necessary and updates
-// the catalog on the status.
+// the catalog on the status. The catalog is fetched when an update is
+// requested or the periodic refresh interval has elapsed.
 //
 // This function is public because it's shared with the servicebroker reconciler.
 func ReconcileCatalog(
@@ -220,11 +226,14 @@ func ReconcileCatalog(
 	spec *v1alpha1.CommonServiceBrokerSpec,
 	status *v1alpha1.CommonServiceBrokerStatus,
 ) error {
+	now := time.Now()
 
-	// Fetch catalog if update is requested.
-	if spec.UpdateRequests != status.UpdateRequests {
-		// Always update the last synchronized UpdateRequests version.
+	// Fetch catalog if update is requested or the cached copy is stale.
+	if status.NeedsCatalogRefresh(spec, now) {
+		// Always update the last synchronized UpdateRequests version and
+		// refresh time so failing brokers aren't retried in a hot loop.
 		status.UpdateRequests = spec.UpdateRequests
+		status.LastCatalogRefreshTime = &metav1.Time{Time: now}
 
 		condition := status.CatalogCondition()
 
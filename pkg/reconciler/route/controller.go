@@ -24,15 +24,22 @@ import (
 	routeinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/route"
 	serviceinstancebindinginformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstancebinding"
 	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	networkingclient "github.com/google/kf/v2/pkg/client/networking/injection/client"
+	envoyfilterinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/envoyfilter"
+	gatewayinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/gateway"
 	virtualserviceinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/virtualservice"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/route/resources"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
+	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 )
 
 // NewController creates a new controller capable of reconciling Kf Routes.
@@ -45,6 +52,9 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	appInformer := appinformer.Get(ctx)
 	spaceInformer := spaceinformer.Get(ctx)
 	serviceInstanceBindingInformer := serviceinstancebindinginformer.Get(ctx)
+	gatewayInformer := gatewayinformer.Get(ctx)
+	envoyFilterInformer := envoyfilterinformer.Get(ctx)
+	serviceInformer := serviceinformer.Get(ctx)
 
 	// Create reconciler
 	c := &Reconciler{
@@ -55,6 +65,10 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		virtualServiceLister:         vsInformer.Lister(),
 		networkingClientSet:          networkingclient.Get(ctx),
 		serviceInstanceBindingLister: serviceInstanceBindingInformer.Lister(),
+		gatewayLister:                gatewayInformer.Lister(),
+		envoyFilterLister:            envoyFilterInformer.Lister(),
+		serviceLister:                serviceInformer.Lister(),
+		certificateClient:            dynamicclient.Get(ctx).Resource(resources.CertificateGVR),
 	}
 
 	impl := controller.NewContext(ctx, c, controller.ControllerOptions{WorkQueueName: "Routes", Logger: logger})
@@ -71,6 +85,16 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		controller.HandleAll(enqueue),
 	)
 
+	// Re-render the domains of a Space when their configuration changes, e.g.
+	// TLS being enabled or released to another Space.
+	spaceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			_, ok := obj.(*v1alpha1.Space)
+			return ok
+		},
+		Handler: controller.HandleAll(enqueue),
+	})
+
 	vsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: FilterVSManagedByKf(),
 		Handler:    controller.HandleAll(EnqueueRoutesOfVirtualService(enqueue)),
@@ -90,6 +114,25 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler: controller.HandleAll(enqueue),
 	})
 
+	gatewayInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: FilterGatewayManagedByKf(),
+		Handler:    controller.HandleAll(enqueue),
+	})
+
+	envoyFilterInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: FilterRateLimitEnvoyFilters(),
+		Handler:    controller.HandleAll(enqueue),
+	})
+
+	// Certificates are re-issued and renewed out of band so the Routes need
+	// to be reconciled to copy them to the ingress gateways.
+	c.SecretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: FilterTLSSecrets(),
+		Handler: controller.HandleAll(
+			EnqueueDomainsOfSecret(c.routeLister, reconciler.LogEnqueueError(logger, BuildEnqueuer(impl.EnqueueKey))),
+		),
+	})
+
 	return impl
 }
 
@@ -108,6 +151,15 @@ func BuildEnqueuer(enqueue func(types.NamespacedName)) func(interface{}) error {
 					Name:      rt.Source.Domain,
 				})
 			}
+		case *v1alpha1.Space:
+			// Spaces are cluster scoped, their Routes live in the namespace
+			// with the same name.
+			for _, domain := range r.Status.NetworkConfig.Domains {
+				enqueue(types.NamespacedName{
+					Namespace: r.Name,
+					Name:      domain.Domain,
+				})
+			}
 		case *v1alpha1.Route:
 			enqueue(types.NamespacedName{
 				Namespace: r.GetNamespace(),
@@ -120,6 +172,33 @@ func BuildEnqueuer(enqueue func(types.NamespacedName)) func(interface{}) error {
 					Name:      domain,
 				})
 			}
+		case *networking.Gateway:
+			if domain, ok := r.Annotations[resources.DomainAnnotation]; ok {
+				enqueue(types.NamespacedName{
+					Namespace: r.GetNamespace(),
+					Name:      domain,
+				})
+			}
+		case *networking.EnvoyFilter:
+			// EnvoyFilters in ingress gateway namespaces belong to the
+			// domain in the Space the rate limits came from.
+			sourceNamespace, hasSource := r.Labels[resources.RateLimitSourceNamespaceLabel]
+			if domain, ok := r.Annotations[resources.DomainAnnotation]; ok && hasSource {
+				enqueue(types.NamespacedName{
+					Namespace: sourceNamespace,
+					Name:      domain,
+				})
+			}
+		case *corev1.Secret:
+			// Copies of certificates in ingress gateway namespaces belong to
+			// the domain in the Space they were copied from.
+			sourceNamespace, hasSource := r.Labels[resources.TLSSourceNamespaceLabel]
+			if domain, ok := r.Annotations[resources.DomainAnnotation]; ok && hasSource {
+				enqueue(types.NamespacedName{
+					Namespace: sourceNamespace,
+					Name:      domain,
+				})
+			}
 		case *v1alpha1.ServiceInstanceBinding:
 			routeSpecFields := r.Spec.Route
 			if routeSpecFields != nil {
@@ -151,6 +230,73 @@ func FilterVSManagedByKf() func(obj interface{}) bool {
 	}
 }
 
+// FilterGatewayManagedByKf makes it simple to create FilterFunc's for use with
+// cache.FilteringResourceEventHandler that filter based on the
+// "app.kubernetes.io/managed-by": "kf" label and if the type is a Gateway.
+func FilterGatewayManagedByKf() func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		if object, ok := obj.(metav1.Object); ok {
+			if "kf" == object.GetLabels()[v1alpha1.ManagedByLabel] {
+				_, ok := obj.(*networking.Gateway)
+				return ok
+			}
+		}
+		return false
+	}
+}
+
+// FilterRateLimitEnvoyFilters makes it simple to create FilterFunc's for use
+// with cache.FilteringResourceEventHandler that filter EnvoyFilters holding
+// the rate limits of Routes.
+func FilterRateLimitEnvoyFilters() func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		filter, ok := obj.(*networking.EnvoyFilter)
+		if !ok || filter.Labels[v1alpha1.ManagedByLabel] != "kf" {
+			return false
+		}
+
+		_, ok = filter.Labels[resources.RateLimitSourceNamespaceLabel]
+		return ok
+	}
+}
+
+// FilterTLSSecrets makes it simple to create FilterFunc's for use with
+// cache.FilteringResourceEventHandler that filter Secrets holding TLS
+// certificates.
+func FilterTLSSecrets() func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		secret, ok := obj.(*corev1.Secret)
+		return ok && secret.Type == corev1.SecretTypeTLS
+	}
+}
+
+// EnqueueDomainsOfSecret enqueues the domains that may serve the certificate
+// in the Secret. Certificates can be referenced by any Route in the Space
+// (or the Space's domains) so all the domains of Routes in the Secret's
+// namespace are enqueued. Copies of certificates are enqueued directly.
+func EnqueueDomainsOfSecret(routeLister kflisters.RouteLister, enqueue func(interface{})) func(obj interface{}) {
+	return func(obj interface{}) {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return
+		}
+
+		if _, ok := secret.Labels[resources.TLSSourceNamespaceLabel]; ok {
+			enqueue(secret)
+			return
+		}
+
+		routes, err := routeLister.Routes(secret.Namespace).List(labels.Everything())
+		if err != nil {
+			return
+		}
+
+		for _, route := range routes {
+			enqueue(route)
+		}
+	}
+}
+
 // EnqueueRoutesOfVirtualService will find the corresponding routes for the
 // VirtualService.  It will Enqueue a key for each one. We aren't able to use
 // EnqueueControllerOf (as other components do), because a VirtualService is
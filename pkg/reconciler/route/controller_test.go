@@ -65,6 +65,23 @@ func TestBuildEnqueuer(t *testing.T) {
 				{Namespace: "some-namespace", Name: "some-domain2"},
 			},
 		},
+		"space": {
+			obj: &v1alpha1.Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-namespace"},
+				Status: v1alpha1.SpaceStatus{
+					NetworkConfig: v1alpha1.SpaceStatusNetworkConfig{
+						Domains: []v1alpha1.SpaceDomain{
+							{Domain: "some-domain1"},
+							{Domain: "some-domain2"},
+						},
+					},
+				},
+			},
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "some-namespace", Name: "some-domain1"},
+				{Namespace: "some-namespace", Name: "some-domain2"},
+			},
+		},
 		"route claim": {
 			obj: &v1alpha1.Route{
 				ObjectMeta: metav1.ObjectMeta{Namespace: "some-namespace"},
@@ -78,6 +95,61 @@ func TestBuildEnqueuer(t *testing.T) {
 				{Namespace: "some-namespace", Name: "some-domain"},
 			},
 		},
+		"tls gateway": {
+			obj: &networking.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+					Annotations: map[string]string{
+						resources.DomainAnnotation: "some-domain",
+					},
+				},
+			},
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "some-namespace", Name: "some-domain"},
+			},
+		},
+		"certificate copy": {
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "istio-system",
+					Labels: map[string]string{
+						resources.TLSSourceNamespaceLabel: "some-namespace",
+					},
+					Annotations: map[string]string{
+						resources.DomainAnnotation: "some-domain",
+					},
+				},
+			},
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "some-namespace", Name: "some-domain"},
+			},
+		},
+		"rate limits": {
+			obj: &networking.EnvoyFilter{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "istio-system",
+					Labels: map[string]string{
+						resources.RateLimitSourceNamespaceLabel: "some-namespace",
+					},
+					Annotations: map[string]string{
+						resources.DomainAnnotation: "some-domain",
+					},
+				},
+			},
+			wantEnqueued: []types.NamespacedName{
+				{Namespace: "some-namespace", Name: "some-domain"},
+			},
+		},
+		"shared rate limit filter": {
+			obj: &networking.EnvoyFilter{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system"},
+			},
+		},
+		"other secret": {
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "some-namespace"},
+			},
+		},
 		"unhandled type": {
 			wantErr: errors.New("unexpected type: int"),
 			obj:     99,
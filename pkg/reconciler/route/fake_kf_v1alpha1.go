@@ -55,6 +55,20 @@ func (m *FakeKfAlpha1Interface) EXPECT() *FakeKfAlpha1InterfaceMockRecorder {
 	return m.recorder
 }
 
+// AppNetworkPolicies mocks base method.
+func (m *FakeKfAlpha1Interface) AppNetworkPolicies(arg0 string) v1alpha10.AppNetworkPolicyInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppNetworkPolicies", arg0)
+	ret0, _ := ret[0].(v1alpha10.AppNetworkPolicyInterface)
+	return ret0
+}
+
+// AppNetworkPolicies indicates an expected call of AppNetworkPolicies.
+func (mr *FakeKfAlpha1InterfaceMockRecorder) AppNetworkPolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppNetworkPolicies", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).AppNetworkPolicies), arg0)
+}
+
 // Apps mocks base method.
 func (m *FakeKfAlpha1Interface) Apps(arg0 string) v1alpha10.AppInterface {
 	m.ctrl.T.Helper()
@@ -97,6 +111,20 @@ func (mr *FakeKfAlpha1InterfaceMockRecorder) ClusterServiceBrokers() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterServiceBrokers", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).ClusterServiceBrokers))
 }
 
+// Orgs mocks base method.
+func (m *FakeKfAlpha1Interface) Orgs() v1alpha10.OrgInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Orgs")
+	ret0, _ := ret[0].(v1alpha10.OrgInterface)
+	return ret0
+}
+
+// Orgs indicates an expected call of Orgs.
+func (mr *FakeKfAlpha1InterfaceMockRecorder) Orgs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Orgs", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).Orgs))
+}
+
 // RESTClient mocks base method.
 func (m *FakeKfAlpha1Interface) RESTClient() rest.Interface {
 	m.ctrl.T.Helper()
@@ -139,6 +167,20 @@ func (mr *FakeKfAlpha1InterfaceMockRecorder) Scales(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scales", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).Scales), arg0)
 }
 
+// SecurityGroups mocks base method.
+func (m *FakeKfAlpha1Interface) SecurityGroups() v1alpha10.SecurityGroupInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityGroups")
+	ret0, _ := ret[0].(v1alpha10.SecurityGroupInterface)
+	return ret0
+}
+
+// SecurityGroups indicates an expected call of SecurityGroups.
+func (mr *FakeKfAlpha1InterfaceMockRecorder) SecurityGroups() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityGroups", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).SecurityGroups))
+}
+
 // ServiceBrokers mocks base method.
 func (m *FakeKfAlpha1Interface) ServiceBrokers(arg0 string) v1alpha10.ServiceBrokerInterface {
 	m.ctrl.T.Helper()
@@ -195,6 +237,20 @@ func (mr *FakeKfAlpha1InterfaceMockRecorder) SourcePackages(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SourcePackages", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).SourcePackages), arg0)
 }
 
+// SpaceQuotas mocks base method.
+func (m *FakeKfAlpha1Interface) SpaceQuotas() v1alpha10.SpaceQuotaInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpaceQuotas")
+	ret0, _ := ret[0].(v1alpha10.SpaceQuotaInterface)
+	return ret0
+}
+
+// SpaceQuotas indicates an expected call of SpaceQuotas.
+func (mr *FakeKfAlpha1InterfaceMockRecorder) SpaceQuotas() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpaceQuotas", reflect.TypeOf((*FakeKfAlpha1Interface)(nil).SpaceQuotas))
+}
+
 // Spaces mocks base method.
 func (m *FakeKfAlpha1Interface) Spaces() v1alpha10.SpaceInterface {
 	m.ctrl.T.Helper()
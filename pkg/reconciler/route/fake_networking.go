@@ -55,6 +55,34 @@ func (m *FakeNetworking) EXPECT() *FakeNetworkingMockRecorder {
 	return m.recorder
 }
 
+// EnvoyFilters mocks base method.
+func (m *FakeNetworking) EnvoyFilters(arg0 string) v1alpha30.EnvoyFilterInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnvoyFilters", arg0)
+	ret0, _ := ret[0].(v1alpha30.EnvoyFilterInterface)
+	return ret0
+}
+
+// EnvoyFilters indicates an expected call of EnvoyFilters.
+func (mr *FakeNetworkingMockRecorder) EnvoyFilters(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnvoyFilters", reflect.TypeOf((*FakeNetworking)(nil).EnvoyFilters), arg0)
+}
+
+// Gateways mocks base method.
+func (m *FakeNetworking) Gateways(arg0 string) v1alpha30.GatewayInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gateways", arg0)
+	ret0, _ := ret[0].(v1alpha30.GatewayInterface)
+	return ret0
+}
+
+// Gateways indicates an expected call of Gateways.
+func (mr *FakeNetworkingMockRecorder) Gateways(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gateways", reflect.TypeOf((*FakeNetworking)(nil).Gateways), arg0)
+}
+
 // RESTClient mocks base method.
 func (m *FakeNetworking) RESTClient() rest.Interface {
 	m.ctrl.T.Helper()
@@ -0,0 +1,145 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	networking "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/route/resources"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/logging"
+)
+
+// reconcileRateLimits programs the ingress gateways the domain is routed
+// through with the rate limits of the Routes. The limits are configured with
+// EnvoyFilters in the namespaces of the gateway workloads.
+func (r *Reconciler) reconcileRateLimits(
+	ctx context.Context,
+	namespace string,
+	domain string,
+	routes []*v1alpha1.Route,
+	spaceDomain *v1alpha1.SpaceDomain,
+) error {
+	logger := logging.FromContext(ctx)
+
+	desiredFilters := sets.NewString()
+	if rateLimited := resources.RateLimitedRoutes(routes, spaceDomain); len(rateLimited) > 0 {
+		selector, err := r.ingressGatewaySelector(spaceDomain.GatewayName)
+		if err != nil {
+			return err
+		}
+
+		ingressNamespaces, err := r.ingressNamespaces(selector)
+		if err != nil {
+			return err
+		}
+
+		for _, ingressNamespace := range ingressNamespaces {
+			shared, err := resources.MakeSharedRateLimitEnvoyFilter(ingressNamespace, selector)
+			if err != nil {
+				return err
+			}
+			if err := r.reconcileEnvoyFilter(ctx, shared); err != nil {
+				return fmt.Errorf("EnvoyFilter %s/%s: %v", shared.Namespace, shared.Name, err)
+			}
+
+			desired, err := resources.MakeRateLimitEnvoyFilter(rateLimited, domain, ingressNamespace, selector)
+			if err != nil {
+				return err
+			}
+			desiredFilters.Insert(desired.Namespace + "/" + desired.Name)
+			if err := r.reconcileEnvoyFilter(ctx, desired); err != nil {
+				return fmt.Errorf("EnvoyFilter %s/%s: %v", desired.Namespace, desired.Name, err)
+			}
+		}
+	}
+
+	// Clean up rate limits that are no longer configured.
+	filters, err := r.envoyFilterLister.List(resources.RateLimitEnvoyFilterSelector(namespace))
+	if err != nil {
+		return err
+	}
+	for _, filter := range filters {
+		if filter.Annotations[resources.DomainAnnotation] != domain ||
+			desiredFilters.Has(filter.Namespace+"/"+filter.Name) {
+			continue
+		}
+
+		logger.Infow("Deleting stale rate limits", zap.String("envoyFilter", filter.Namespace+"/"+filter.Name))
+		if err := r.networkingClientSet.
+			NetworkingV1alpha3().
+			EnvoyFilters(filter.Namespace).
+			Delete(ctx, filter.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileEnvoyFilter(ctx context.Context, desired *networking.EnvoyFilter) error {
+	logger := logging.FromContext(ctx)
+
+	actual, err := r.envoyFilterLister.EnvoyFilters(desired.Namespace).Get(desired.Name)
+	if errors.IsNotFound(err) {
+		_, err = r.networkingClientSet.
+			NetworkingV1alpha3().
+			EnvoyFilters(desired.Namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	} else if actual.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	// Check for differences, if none we don't need to reconcile.
+	semanticEquality := reconciler.NewSemanticEqualityBuilder(logger, "EnvoyFilter").
+		Append("metadata.labels", desired.Labels, actual.Labels).
+		Append("metadata.annotations", desired.Annotations, actual.Annotations).
+		Append("spec.workloadSelector", desired.Spec.WorkloadSelector, actual.Spec.WorkloadSelector)
+
+	if len(desired.Spec.ConfigPatches) == len(actual.Spec.ConfigPatches) {
+		for i, patch := range desired.Spec.ConfigPatches {
+			semanticEquality.Append(fmt.Sprintf("spec.configPatches[%d]", i), patch, actual.Spec.ConfigPatches[i])
+		}
+
+		if semanticEquality.IsSemanticallyEqual() {
+			return nil
+		}
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.Spec.WorkloadSelector = desired.Spec.WorkloadSelector
+	existing.Spec.ConfigPatches = desired.Spec.ConfigPatches
+
+	_, err = r.networkingClientSet.
+		NetworkingV1alpha3().
+		EnvoyFilters(existing.Namespace).
+		Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,165 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	networking "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	networkingfake "github.com/google/kf/v2/pkg/client/networking/clientset/versioned/fake"
+	networkinglisters "github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler/route/resources"
+	istio "istio.io/api/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestReconciler_reconcileRateLimits(t *testing.T) {
+	t.Parallel()
+
+	const (
+		namespace = "some-namespace"
+		domain    = "example.com"
+	)
+
+	selector := map[string]string{"istio": "ingressgateway"}
+
+	staleFilter := &networking.EnvoyFilter{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stale-filter",
+			Namespace: "istio-system",
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel:                 "kf",
+				v1alpha1.ComponentLabel:                 "ratelimit",
+				resources.RateLimitSourceNamespaceLabel: namespace,
+			},
+			Annotations: map[string]string{
+				resources.DomainAnnotation: domain,
+			},
+		},
+	}
+
+	baseGateway := &networking.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-gateway", Namespace: "kf"},
+		Spec: istio.Gateway{
+			Selector: selector,
+		},
+	}
+
+	ingressService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "istio-ingressgateway",
+			Namespace: "istio-system",
+			Labels:    selector,
+		},
+	}
+
+	cases := map[string]struct {
+		policy      *v1alpha1.RoutePolicy
+		gatewayName string
+
+		wantFilters []string
+	}{
+		"rate limited": {
+			policy: &v1alpha1.RoutePolicy{
+				RateLimit: &v1alpha1.RouteRateLimit{Requests: 10, Unit: v1alpha1.RateLimitUnitSecond},
+			},
+			gatewayName: "kf/external-gateway",
+			wantFilters: []string{
+				resources.MakeSharedRateLimitEnvoyFilterName(selector),
+				resources.MakeRateLimitEnvoyFilterName(namespace, domain),
+			},
+		},
+		"policy without rate limit": {
+			policy: &v1alpha1.RoutePolicy{
+				Timeout: &metav1.Duration{},
+			},
+			gatewayName: "kf/external-gateway",
+		},
+		"internal gateway": {
+			policy: &v1alpha1.RoutePolicy{
+				RateLimit: &v1alpha1.RouteRateLimit{Requests: 10, Unit: v1alpha1.RateLimitUnitSecond},
+			},
+			gatewayName: resources.KfInternalIngressGateway,
+		},
+		"no policy": {
+			gatewayName: "kf/external-gateway",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := context.Background()
+
+			envoyFilterIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			envoyFilterIndexer.Add(staleFilter)
+
+			gatewayIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			gatewayIndexer.Add(baseGateway)
+
+			serviceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			serviceIndexer.Add(ingressService)
+
+			networkingClient := networkingfake.NewSimpleClientset(staleFilter)
+
+			r := &Reconciler{
+				networkingClientSet: networkingClient,
+				envoyFilterLister:   networkinglisters.NewEnvoyFilterLister(envoyFilterIndexer),
+				gatewayLister:       networkinglisters.NewGatewayLister(gatewayIndexer),
+				serviceLister:       v1listers.NewServiceLister(serviceIndexer),
+			}
+
+			spaceDomain := &v1alpha1.SpaceDomain{
+				Domain:      domain,
+				GatewayName: tc.gatewayName,
+			}
+
+			routes := []*v1alpha1.Route{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "some-route", Namespace: namespace},
+					Spec: v1alpha1.RouteSpec{
+						RouteSpecFields: v1alpha1.RouteSpecFields{
+							Hostname: "app",
+							Domain:   domain,
+						},
+						Policy: tc.policy,
+					},
+				},
+			}
+
+			err := r.reconcileRateLimits(ctx, namespace, domain, routes, spaceDomain)
+			testutil.AssertNil(t, "err", err)
+
+			filters, err := networkingClient.
+				NetworkingV1alpha3().
+				EnvoyFilters("istio-system").
+				List(ctx, metav1.ListOptions{})
+			testutil.AssertNil(t, "err", err)
+
+			var gotFilters []string
+			for i := range filters.Items {
+				gotFilters = append(gotFilters, filters.Items[i].Name)
+			}
+			sort.Strings(gotFilters)
+			testutil.AssertEqual(t, "filters", tc.wantFilters, gotFilters)
+		})
+	}
+}
@@ -31,6 +31,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+	v1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -47,6 +49,10 @@ type Reconciler struct {
 	appLister                    kflisters.AppLister
 	spaceLister                  kflisters.SpaceLister
 	serviceInstanceBindingLister kflisters.ServiceInstanceBindingLister
+	gatewayLister                networkinglisters.GatewayLister
+	envoyFilterLister            networkinglisters.EnvoyFilterLister
+	serviceLister                v1listers.ServiceLister
+	certificateClient            dynamic.NamespaceableResourceInterface
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -181,6 +187,24 @@ func (r *Reconciler) ApplyChanges(
 	}
 	logger = logger.With(zap.Reflect("routeServiceBindings", routeServiceBindings))
 
+	// Issue certificates and program the Gateway serving them
+	certs, certSecrets, tlsErr := r.reconcileTLS(
+		logging.WithLogger(ctx, logger),
+		namespace,
+		domain,
+		routes,
+		spaceDomain,
+	)
+
+	// Program the rate limits of the Routes on the ingress gateways
+	rateLimitErr := r.reconcileRateLimits(
+		logging.WithLogger(ctx, logger),
+		namespace,
+		domain,
+		routes,
+		spaceDomain,
+	)
+
 	// Create or update VirtualService
 	actualVS, sErr := r.reconcileVirtualService(
 		logging.WithLogger(ctx, logger),
@@ -208,12 +232,20 @@ func (r *Reconciler) ApplyChanges(
 		toReconcile.Status.ObservedGeneration = toReconcile.Generation
 
 		toReconcile.Status.PropagateVirtualService(actualVS, sErr)
+		if actualVS == nil && sErr == nil && spaceDomain != nil && spaceDomain.IsTCP() {
+			// TCP Routes don't need a VirtualService until an App is
+			// running on one of the domain's ports.
+			toReconcile.Status.VirtualServiceCondition().MarkSuccess()
+		}
 		toReconcile.Status.PropagateRouteSpecFields(origRoute.Spec.RouteSpecFields)
 
 		rsfString := toReconcile.Spec.RouteSpecFields.String()
 		toReconcile.Status.PropagateBindings(appBindings[rsfString])
 		toReconcile.Status.PropagateRouteServiceBinding(routeServiceBindings[rsfString])
 		toReconcile.Status.PropagateSpaceDomain(spaceDomain)
+		if tlsErr == nil {
+			propagateTLS(toReconcile, certs, certSecrets)
+		}
 
 		// If we didn't change anything then don't call updateStatus.
 		// This is important because the copy we loaded from the
@@ -239,6 +271,12 @@ func (r *Reconciler) ApplyChanges(
 	if sErr != nil {
 		return fmt.Errorf("Error occurred while reconciling VirtualService: %s", sErr.Error())
 	}
+	if tlsErr != nil {
+		return fmt.Errorf("Error occurred while reconciling TLS: %s", tlsErr.Error())
+	}
+	if rateLimitErr != nil {
+		return fmt.Errorf("Error occurred while reconciling rate limits: %s", rateLimitErr.Error())
+	}
 	return exitErr
 }
 
@@ -277,6 +315,22 @@ func (r *Reconciler) reconcileVirtualService(
 	}
 	logger = logger.With(zap.Reflect("desired", desired))
 
+	// Istio rejects VirtualServices without any routes, this happens when
+	// none of the TCP Routes on a domain have running Apps.
+	if len(desired.Spec.Http) == 0 && len(desired.Spec.Tcp) == 0 {
+		logger.Info("Deleting VirtualService because no Routes have running Apps")
+
+		err := r.networkingClientSet.
+			NetworkingV1alpha3().
+			VirtualServices(namespace).
+			Delete(ctx, desired.Name, metav1.DeleteOptions{})
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		// Pass error back exactly so API status code is preserved
+		return nil, err
+	}
+
 	actual, err := r.virtualServiceLister.
 		VirtualServices(namespace).
 		Get(desired.Name)
@@ -322,11 +376,15 @@ func (r *Reconciler) update(
 		Append("spec.gateways", desired.Spec.Gateways, actual.Spec.Gateways).
 		Append("spec.hosts", desired.Spec.Hosts, actual.Spec.Hosts)
 
-	if len(desired.Spec.Http) == len(actual.Spec.Http) {
+	if len(desired.Spec.Http) == len(actual.Spec.Http) && len(desired.Spec.Tcp) == len(actual.Spec.Tcp) {
 		for i, http := range desired.Spec.Http {
 			semanticEquality.Append(fmt.Sprintf("spec.http[%d]", i), http, actual.Spec.Http[i])
 		}
 
+		for i, tcp := range desired.Spec.Tcp {
+			semanticEquality.Append(fmt.Sprintf("spec.tcp[%d]", i), tcp, actual.Spec.Tcp[i])
+		}
+
 		if semanticEquality.IsSemanticallyEqual() {
 			return actual, nil
 		}
@@ -340,8 +398,9 @@ func (r *Reconciler) update(
 	existing.Annotations = desired.Annotations
 	existing.OwnerReferences = desired.OwnerReferences
 
-	// Set HTTP Routes, Hosts and Gateways
+	// Set HTTP and TCP Routes, Hosts and Gateways
 	existing.Spec.Http = desired.Spec.Http
+	existing.Spec.Tcp = desired.Spec.Tcp
 	existing.Spec.Hosts = desired.Spec.Hosts
 	existing.Spec.Gateways = desired.Spec.Gateways
 
@@ -23,6 +23,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	v1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	networkinglisters "github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3"
 	"github.com/google/kf/v2/pkg/kf/testutil"
 	"github.com/google/kf/v2/pkg/reconciler"
 	istio "istio.io/api/networking/v1alpha3"
@@ -31,6 +32,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	types "k8s.io/apimachinery/pkg/types"
+	v1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 //go:generate mockgen --package=route --copyright_file ../../kf/internal/tools/option-builder/LICENSE_HEADER --destination=fake_listers.go --mock_names=RouteLister=FakeRouteLister,RouteNamespaceLister=FakeRouteNamespaceLister,AppLister=FakeAppLister,AppNamespaceLister=FakeAppNamespaceLister,SpaceLister=FakeSpaceLister,ServiceInstanceBindingLister=FakeServiceInstanceBindingLister,ServiceInstanceBindingNamespaceLister=FakeServiceInstanceBindingNamespaceLister github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1 RouteLister,RouteNamespaceLister,AppLister,AppNamespaceLister,SpaceLister,ServiceInstanceBindingLister,ServiceInstanceBindingNamespaceLister
@@ -610,7 +613,8 @@ func TestReconciler_Reconcile_ApplyChanges(t *testing.T) {
 
 			r := &Reconciler{
 				Base: &reconciler.Base{
-					KfClientSet: fakeKfInterface,
+					KfClientSet:  fakeKfInterface,
+					SecretLister: v1listers.NewSecretLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
 				},
 				networkingClientSet:          fakeNetworkingClient,
 				routeLister:                  fakeRouteLister,
@@ -618,6 +622,8 @@ func TestReconciler_Reconcile_ApplyChanges(t *testing.T) {
 				appLister:                    fakeAppLister,
 				spaceLister:                  fakeSpaceLister,
 				serviceInstanceBindingLister: fakeServiceInstanceBindingLister,
+				gatewayLister:                networkinglisters.NewGatewayLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+				envoyFilterLister:            networkinglisters.NewEnvoyFilterLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
 			}
 
 			err := r.ApplyChanges(
@@ -0,0 +1,250 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfistio "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	"google.golang.org/protobuf/types/known/structpb"
+	istio "istio.io/api/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// RateLimitSourceNamespaceLabel holds the Space the rate limits in an
+	// EnvoyFilter in an ingress gateway's namespace came from.
+	RateLimitSourceNamespaceLabel = "kf.dev/ratelimit-source-namespace"
+
+	rateLimitComponent = "ratelimit"
+
+	localRateLimitFilter   = "envoy.filters.http.local_ratelimit"
+	localRateLimitTypeURL  = "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit"
+	localRateLimitStatName = "kf_route_local_rate_limiter"
+	typedStructTypeURL     = "type.googleapis.com/udpa.type.v1.TypedStruct"
+)
+
+// MakeRateLimitEnvoyFilterName returns the name of the EnvoyFilter holding
+// the rate limits for a domain in a Space.
+func MakeRateLimitEnvoyFilterName(namespace, domain string) string {
+	return v1alpha1.GenerateName("kf", namespace, domain, rateLimitComponent)
+}
+
+// MakeSharedRateLimitEnvoyFilterName returns the name of the EnvoyFilter that
+// adds the local rate limit filter to the ingress gateway workloads matched
+// by the selector. The filter doesn't limit requests unless a route
+// configures it so it's shared by all Spaces and never removed.
+func MakeSharedRateLimitEnvoyFilterName(selector map[string]string) string {
+	return v1alpha1.GenerateName("kf-local-ratelimit", labels.Set(selector).String())
+}
+
+// RateLimitEnvoyFilterSelector selects the EnvoyFilters holding rate limits
+// for Routes in the namespace.
+func RateLimitEnvoyFilterSelector(namespace string) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{
+		v1alpha1.ManagedByLabel:       "kf",
+		v1alpha1.ComponentLabel:       rateLimitComponent,
+		RateLimitSourceNamespaceLabel: namespace,
+	})
+}
+
+// RateLimitedRoutes returns the Routes on the domain whose rate limits are
+// applied, sorted by name. Domains routed internally don't go through a
+// gateway so their Routes can't be rate limited.
+func RateLimitedRoutes(routes []*v1alpha1.Route, spaceDomain *v1alpha1.SpaceDomain) []*v1alpha1.Route {
+	if spaceDomain == nil || spaceDomain.IsTCP() || spaceDomain.GatewayName == KfInternalIngressGateway {
+		return nil
+	}
+
+	var out []*v1alpha1.Route
+	for _, route := range buildRoutePolicies(routes) {
+		if route.Spec.Policy.RateLimit != nil && !route.Spec.IsTCP() {
+			out = append(out, route)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+
+	return out
+}
+
+// MakeSharedRateLimitEnvoyFilter creates the EnvoyFilter that adds the local
+// rate limit filter to the ingress gateway workloads matched by the
+// selector.
+func MakeSharedRateLimitEnvoyFilter(ingressNamespace string, selector map[string]string) (*kfistio.EnvoyFilter, error) {
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"name": localRateLimitFilter,
+		"typed_config": map[string]interface{}{
+			"@type":    typedStructTypeURL,
+			"type_url": localRateLimitTypeURL,
+			"value": map[string]interface{}{
+				"stat_prefix": localRateLimitStatName,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kfistio.EnvoyFilter{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "EnvoyFilter",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakeSharedRateLimitEnvoyFilterName(selector),
+			Namespace: ingressNamespace,
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: rateLimitComponent,
+			},
+		},
+		Spec: istio.EnvoyFilter{
+			WorkloadSelector: &istio.WorkloadSelector{
+				Labels: selector,
+			},
+			ConfigPatches: []*istio.EnvoyFilter_EnvoyConfigObjectPatch{
+				{
+					ApplyTo: istio.EnvoyFilter_HTTP_FILTER,
+					Match: &istio.EnvoyFilter_EnvoyConfigObjectMatch{
+						Context: istio.EnvoyFilter_GATEWAY,
+						ObjectTypes: &istio.EnvoyFilter_EnvoyConfigObjectMatch_Listener{
+							Listener: &istio.EnvoyFilter_ListenerMatch{
+								FilterChain: &istio.EnvoyFilter_ListenerMatch_FilterChainMatch{
+									Filter: &istio.EnvoyFilter_ListenerMatch_FilterMatch{
+										Name: "envoy.filters.network.http_connection_manager",
+										SubFilter: &istio.EnvoyFilter_ListenerMatch_SubFilterMatch{
+											Name: "envoy.filters.http.router",
+										},
+									},
+								},
+							},
+						},
+					},
+					Patch: &istio.EnvoyFilter_Patch{
+						Operation: istio.EnvoyFilter_Patch_INSERT_BEFORE,
+						Value:     value,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// MakeRateLimitEnvoyFilter creates the EnvoyFilter that configures the rate
+// limits of the Routes on the ingress gateway workloads matched by the
+// selector. Each gateway instance keeps its own token bucket so the limits
+// apply per instance.
+func MakeRateLimitEnvoyFilter(
+	routes []*v1alpha1.Route,
+	domain string,
+	ingressNamespace string,
+	selector map[string]string,
+) (*kfistio.EnvoyFilter, error) {
+	namespace := routes[0].Namespace
+
+	var patches []*istio.EnvoyFilter_EnvoyConfigObjectPatch
+	for _, route := range routes {
+		value, err := buildRateLimitRouteConfig(route.Spec.Policy.RateLimit)
+		if err != nil {
+			return nil, fmt.Errorf("Route %s: %v", route.Name, err)
+		}
+
+		patches = append(patches, &istio.EnvoyFilter_EnvoyConfigObjectPatch{
+			ApplyTo: istio.EnvoyFilter_HTTP_ROUTE,
+			Match: &istio.EnvoyFilter_EnvoyConfigObjectMatch{
+				Context: istio.EnvoyFilter_GATEWAY,
+				ObjectTypes: &istio.EnvoyFilter_EnvoyConfigObjectMatch_RouteConfiguration{
+					RouteConfiguration: &istio.EnvoyFilter_RouteConfigurationMatch{
+						Vhost: &istio.EnvoyFilter_RouteConfigurationMatch_VirtualHostMatch{
+							Route: &istio.EnvoyFilter_RouteConfigurationMatch_RouteMatch{
+								Name: MakeRateLimitRouteName(route),
+							},
+						},
+					},
+				},
+			},
+			Patch: &istio.EnvoyFilter_Patch{
+				Operation: istio.EnvoyFilter_Patch_MERGE,
+				Value:     value,
+			},
+		})
+	}
+
+	return &kfistio.EnvoyFilter{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "EnvoyFilter",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakeRateLimitEnvoyFilterName(namespace, domain),
+			Namespace: ingressNamespace,
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel:       "kf",
+				v1alpha1.ComponentLabel:       rateLimitComponent,
+				RateLimitSourceNamespaceLabel: namespace,
+			},
+			Annotations: map[string]string{
+				DomainAnnotation: domain,
+			},
+		},
+		Spec: istio.EnvoyFilter{
+			WorkloadSelector: &istio.WorkloadSelector{
+				Labels: selector,
+			},
+			ConfigPatches: patches,
+		},
+	}, nil
+}
+
+// buildRateLimitRouteConfig creates the per-route configuration of the local
+// rate limit filter. The token bucket holds the number of requests allowed in
+// the period and is refilled at the end of each period.
+func buildRateLimitRouteConfig(rateLimit *v1alpha1.RouteRateLimit) (*structpb.Struct, error) {
+	percent := map[string]interface{}{
+		"numerator":   100,
+		"denominator": "HUNDRED",
+	}
+
+	return structpb.NewStruct(map[string]interface{}{
+		"typed_per_filter_config": map[string]interface{}{
+			localRateLimitFilter: map[string]interface{}{
+				"@type":    typedStructTypeURL,
+				"type_url": localRateLimitTypeURL,
+				"value": map[string]interface{}{
+					"stat_prefix": localRateLimitStatName,
+					"token_bucket": map[string]interface{}{
+						"max_tokens":      rateLimit.Requests,
+						"tokens_per_fill": rateLimit.Requests,
+						"fill_interval":   fmt.Sprintf("%gs", rateLimit.FillInterval().Seconds()),
+					},
+					"filter_enabled": map[string]interface{}{
+						"runtime_key":   "local_rate_limit_enabled",
+						"default_value": percent,
+					},
+					"filter_enforced": map[string]interface{}{
+						"runtime_key":   "local_rate_limit_enforced",
+						"default_value": percent,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func makeRateLimitRoute(host, domain, path, namespace string, requests int32, unit string) *v1alpha1.Route {
+	return makePolicyRoute(host, domain, path, namespace, &v1alpha1.RoutePolicy{
+		RateLimit: &v1alpha1.RouteRateLimit{Requests: requests, Unit: unit},
+	})
+}
+
+func TestRateLimitedRoutes(t *testing.T) {
+	t.Parallel()
+
+	limited := makeRateLimitRoute("app", "example.com", "", "some-namespace", 10, v1alpha1.RateLimitUnitSecond)
+	routes := []*v1alpha1.Route{
+		makeRoute("other", "example.com", "", "some-namespace"),
+		makePolicyRoute("timeout", "example.com", "", "some-namespace", &v1alpha1.RoutePolicy{}),
+		limited,
+	}
+
+	cases := map[string]struct {
+		spaceDomain *v1alpha1.SpaceDomain
+		want        []*v1alpha1.Route
+	}{
+		"external gateway": {
+			spaceDomain: &v1alpha1.SpaceDomain{Domain: "example.com", GatewayName: KfExternalIngressGateway},
+			want:        []*v1alpha1.Route{limited},
+		},
+		"internal gateway": {
+			spaceDomain: &v1alpha1.SpaceDomain{Domain: "example.com", GatewayName: KfInternalIngressGateway},
+		},
+		"domain not permitted": {},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			testutil.AssertEqual(t, "routes", tc.want, RateLimitedRoutes(routes, tc.spaceDomain))
+		})
+	}
+}
+
+func TestMakeSharedRateLimitEnvoyFilter(t *testing.T) {
+	t.Parallel()
+
+	filter, err := MakeSharedRateLimitEnvoyFilter("istio-system", map[string]string{"istio": "ingressgateway"})
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertGoldenJSON(t, "envoyfilter", filter)
+}
+
+func TestMakeRateLimitEnvoyFilter(t *testing.T) {
+	t.Parallel()
+
+	routes := []*v1alpha1.Route{
+		makeRateLimitRoute("app", "example.com", "", "some-namespace", 10, v1alpha1.RateLimitUnitSecond),
+		makeRateLimitRoute("app", "example.com", "/api", "some-namespace", 1000, v1alpha1.RateLimitUnitHour),
+	}
+
+	filter, err := MakeRateLimitEnvoyFilter(routes, "example.com", "istio-system", map[string]string{"istio": "ingressgateway"})
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertGoldenJSON(t, "envoyfilter", filter)
+}
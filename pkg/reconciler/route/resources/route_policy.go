@@ -0,0 +1,141 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"sort"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	istio "istio.io/api/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MakeRateLimitRouteName returns the name given to the HTTP routes of a
+// rate limited Route so the gateway's route configuration can be patched.
+// Names need to be unique across all the Spaces using the gateway, namespaces
+// can't contain dots so joining them with one is enough.
+func MakeRateLimitRouteName(route *v1alpha1.Route) string {
+	return route.Name + "." + route.Namespace
+}
+
+// buildRoutePolicies returns the Routes with policies keyed by their
+// RouteSpecFields string. If multiple Routes have the same fields, the first
+// by name wins so the result is deterministic.
+func buildRoutePolicies(routes []*v1alpha1.Route) map[string]*v1alpha1.Route {
+	sorted := append([]*v1alpha1.Route{}, routes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	policies := make(map[string]*v1alpha1.Route)
+	for _, route := range sorted {
+		if route.Spec.Policy == nil {
+			continue
+		}
+
+		rsfString := route.Spec.RouteSpecFields.String()
+		if _, ok := policies[rsfString]; !ok {
+			policies[rsfString] = route
+		}
+	}
+
+	return policies
+}
+
+// applyRoutePolicy configures the HTTP route with the Route's policy. Only
+// routes that send traffic to Apps are modified, requests that fail because
+// no App is running don't need retries or rate limits.
+func applyRoutePolicy(httpRoute *istio.HTTPRoute, route *v1alpha1.Route) {
+	if route == nil || len(httpRoute.Route) == 0 {
+		return
+	}
+
+	policy := route.Spec.Policy
+
+	if policy.Timeout != nil {
+		httpRoute.Timeout = buildDuration(policy.Timeout)
+	}
+
+	if retries := policy.Retries; retries != nil {
+		httpRoute.Retries = &istio.HTTPRetry{
+			Attempts:      retries.Attempts,
+			PerTryTimeout: buildDuration(retries.PerTryTimeout),
+			RetryOn:       retries.RetryOn,
+		}
+	}
+
+	if cors := policy.CORS; cors != nil {
+		httpRoute.CorsPolicy = &istio.CorsPolicy{
+			AllowOrigins:  buildCORSOrigins(cors.AllowOrigins),
+			AllowMethods:  cors.AllowMethods,
+			AllowHeaders:  cors.AllowHeaders,
+			ExposeHeaders: cors.ExposeHeaders,
+			MaxAge:        buildDuration(cors.MaxAge),
+		}
+
+		if cors.AllowCredentials {
+			httpRoute.CorsPolicy.AllowCredentials = wrapperspb.Bool(true)
+		}
+	}
+
+	if policy.RequestHeaders != nil || policy.ResponseHeaders != nil {
+		httpRoute.Headers = &istio.Headers{
+			Request:  buildHeaderOperations(policy.RequestHeaders),
+			Response: buildHeaderOperations(policy.ResponseHeaders),
+		}
+	}
+
+	if policy.RateLimit != nil {
+		httpRoute.Name = MakeRateLimitRouteName(route)
+	}
+}
+
+func buildDuration(d *metav1.Duration) *durationpb.Duration {
+	if d == nil {
+		return nil
+	}
+
+	return durationpb.New(d.Duration)
+}
+
+func buildCORSOrigins(origins []string) []*istio.StringMatch {
+	var out []*istio.StringMatch
+	for _, origin := range origins {
+		if origin == "*" {
+			out = append(out, &istio.StringMatch{
+				MatchType: &istio.StringMatch_Regex{Regex: ".*"},
+			})
+			continue
+		}
+
+		out = append(out, exactStringMatch(origin))
+	}
+
+	return out
+}
+
+func buildHeaderOperations(ops *v1alpha1.RouteHeaderOperations) *istio.Headers_HeaderOperations {
+	if ops == nil {
+		return nil
+	}
+
+	return &istio.Headers_HeaderOperations{
+		Set:    ops.Set,
+		Add:    ops.Add,
+		Remove: ops.Remove,
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kfistio "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	"github.com/google/kf/v2/pkg/internal/certutil"
+	"github.com/google/kf/v2/pkg/kf/dynamicutils"
+	istio "istio.io/api/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// TLSSourceNamespaceLabel holds the Space a certificate copied into an
+	// ingress gateway's namespace came from.
+	TLSSourceNamespaceLabel = "kf.dev/tls-source-namespace"
+
+	// SelfSignedCertificateValidity is how long certificates issued by
+	// kf-self-signed are valid for.
+	SelfSignedCertificateValidity = 90 * 24 * time.Hour
+
+	// SelfSignedCertificateRenewBefore is how long before expiry
+	// certificates issued by kf-self-signed are re-issued.
+	SelfSignedCertificateRenewBefore = 30 * 24 * time.Hour
+
+	// HTTPSPort is the port the TLS Gateway serves HTTPS traffic on.
+	HTTPSPort = 443
+
+	tlsComponent = "tls-certificate"
+)
+
+// CertificateGVR is the GroupVersionResource of cert-manager Certificates.
+var CertificateGVR = schema.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+// HostCertificate is a certificate served for a set of hosts on a domain.
+type HostCertificate struct {
+	// Hosts the certificate is served for.
+	Hosts []string
+
+	// SecretName is the name of the Secret in the Space that holds the
+	// certificate.
+	SecretName string
+
+	// TLS is the configuration the certificate comes from.
+	TLS v1alpha1.DomainTLS
+}
+
+// MakeHostCertificates returns the certificates that need to be served for
+// the domain. Certificates configured on Routes take precedence over the one
+// configured on the domain for the Route's host. Domains routed internally
+// don't go through a gateway so they can't be served over HTTPS.
+func MakeHostCertificates(routes []*v1alpha1.Route, spaceDomain *v1alpha1.SpaceDomain) []HostCertificate {
+	if spaceDomain == nil || spaceDomain.IsTCP() || spaceDomain.GatewayName == KfInternalIngressGateway {
+		return nil
+	}
+
+	domain := spaceDomain.Domain
+
+	// Sort the Routes so the first Route configuring a host wins.
+	sorted := append([]*v1alpha1.Route{}, routes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	claimed := make(map[string]bool)
+	var routeCerts []HostCertificate
+	for _, route := range sorted {
+		if route.Spec.TLS == nil || route.Spec.Domain != domain {
+			continue
+		}
+
+		host := route.Spec.RouteSpecFields.Host()
+		if claimed[host] {
+			continue
+		}
+		claimed[host] = true
+
+		routeCerts = append(routeCerts, HostCertificate{
+			Hosts:      []string{host},
+			SecretName: route.Spec.TLS.CertificateSecretName(host),
+			TLS:        *route.Spec.TLS,
+		})
+	}
+
+	sort.Slice(routeCerts, func(i, j int) bool {
+		return routeCerts[i].Hosts[0] < routeCerts[j].Hosts[0]
+	})
+
+	var out []HostCertificate
+	if spaceDomain.TLS != nil {
+		var hosts []string
+		for _, host := range []string{"*." + domain, domain} {
+			if !claimed[host] {
+				hosts = append(hosts, host)
+			}
+		}
+
+		if len(hosts) > 0 {
+			out = append(out, HostCertificate{
+				Hosts:      hosts,
+				SecretName: spaceDomain.TLS.CertificateSecretName(domain),
+				TLS:        *spaceDomain.TLS,
+			})
+		}
+	}
+
+	return append(out, routeCerts...)
+}
+
+// FindHostCertificate returns the certificate that would be served for the
+// host using SNI or nil if none match. Exact matches take precedence over
+// wildcards.
+func FindHostCertificate(certs []HostCertificate, host string) *HostCertificate {
+	var wildcard *HostCertificate
+	for i := range certs {
+		for _, h := range certs[i].Hosts {
+			switch {
+			case h == host:
+				return &certs[i]
+			case wildcard == nil && strings.HasPrefix(h, "*."):
+				// Wildcards only match a single DNS label.
+				if idx := strings.Index(host, "."); idx > 0 && host[idx:] == h[1:] {
+					wildcard = &certs[i]
+				}
+			}
+		}
+	}
+
+	return wildcard
+}
+
+// HasTLS returns true if the domain or any of the Routes on it are configured
+// to be served over HTTPS.
+func HasTLS(routes []*v1alpha1.Route, spaceDomain *v1alpha1.SpaceDomain) bool {
+	return len(MakeHostCertificates(routes, spaceDomain)) > 0
+}
+
+// MakeTLSGatewayName creates the name of the Gateway serving HTTPS for the
+// given domain.
+func MakeTLSGatewayName(domain string) string {
+	return v1alpha1.GenerateName(domain, "tls")
+}
+
+// MakeGatewaySecretName creates the name of the copy of a certificate Secret
+// in an ingress gateway's namespace.
+func MakeGatewaySecretName(namespace, domain, secretName string) string {
+	return v1alpha1.GenerateName("kf", namespace, domain, secretName)
+}
+
+// GatewaySecretSelector selects the copies of the certificates from the
+// namespace in ingress gateway namespaces.
+func GatewaySecretSelector(namespace string) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{
+		v1alpha1.ManagedByLabel: "kf",
+		v1alpha1.ComponentLabel: tlsComponent,
+		TLSSourceNamespaceLabel: namespace,
+	})
+}
+
+// MakeGateway creates a Gateway that terminates TLS for the certificates on
+// the ingress gateway workloads matched by the selector. Each certificate
+// gets its own server so the ingress gateway picks them using SNI.
+func MakeGateway(
+	routes []*v1alpha1.Route,
+	domain string,
+	selector map[string]string,
+	certs []HostCertificate,
+) *kfistio.Gateway {
+	namespace := routes[0].Namespace
+
+	var servers []*istio.Server
+	for _, cert := range certs {
+		servers = append(servers, &istio.Server{
+			Port: &istio.Port{
+				Number:   HTTPSPort,
+				Protocol: "HTTPS",
+				// Port names must be unique for HTTPS servers on the same
+				// workload.
+				Name: v1alpha1.GenerateName("https", namespace, cert.SecretName),
+			},
+			Hosts: cert.Hosts,
+			Tls: &istio.ServerTLSSettings{
+				Mode:           istio.ServerTLSSettings_SIMPLE,
+				CredentialName: MakeGatewaySecretName(namespace, domain, cert.SecretName),
+			},
+		})
+	}
+
+	return &kfistio.Gateway{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.istio.io/v1alpha3",
+			Kind:       "Gateway",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakeTLSGatewayName(domain),
+			Namespace: namespace,
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: "gateway",
+			},
+			Annotations: map[string]string{
+				DomainAnnotation: domain,
+			},
+			OwnerReferences: MakeRouteOwnerReferences(routes),
+		},
+		Spec: istio.Gateway{
+			Selector: selector,
+			Servers:  servers,
+		},
+	}
+}
+
+// MakeGatewaySecret copies a certificate Secret into the namespace of an
+// ingress gateway. Istio can only load certificates from the namespace the
+// gateway workload runs in.
+func MakeGatewaySecret(source *corev1.Secret, domain, ingressNamespace string) *corev1.Secret {
+	data := make(map[string][]byte)
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, "ca.crt"} {
+		if v, ok := source.Data[key]; ok {
+			data[key] = v
+		}
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MakeGatewaySecretName(source.Namespace, domain, source.Name),
+			Namespace: ingressNamespace,
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: tlsComponent,
+				TLSSourceNamespaceLabel: source.Namespace,
+			},
+			Annotations: map[string]string{
+				DomainAnnotation: domain,
+			},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: data,
+	}
+}
+
+// MakeSelfSignedSecret creates a Secret holding a newly issued self-signed
+// certificate for the hosts.
+func MakeSelfSignedSecret(routes []*v1alpha1.Route, cert HostCertificate, now time.Time) (*corev1.Secret, error) {
+	certPEM, keyPEM, err := certutil.SelfSigned(cert.Hosts, now, SelfSignedCertificateValidity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cert.SecretName,
+			Namespace: routes[0].Namespace,
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: tlsComponent,
+			},
+			Annotations: map[string]string{
+				v1alpha1.TLSSecretHostsAnnotation: strings.Join(cert.Hosts, ","),
+			},
+			OwnerReferences: MakeRouteOwnerReferences(routes),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}, nil
+}
+
+// SelfSignedSecretNeedsRenewal returns true if a self-signed certificate
+// needs to be re-issued because it's expiring or the hosts changed.
+func SelfSignedSecretNeedsRenewal(secret *corev1.Secret, cert HostCertificate, now time.Time) bool {
+	if secret.Annotations[v1alpha1.TLSSecretHostsAnnotation] != strings.Join(cert.Hosts, ",") {
+		return true
+	}
+
+	notAfter, err := certutil.NotAfter(secret)
+	if err != nil {
+		return true
+	}
+
+	return notAfter.Sub(now) < SelfSignedCertificateRenewBefore
+}
+
+// MakeCertificate creates a cert-manager Certificate that issues the
+// certificate into the Secret using the configured ClusterIssuer.
+func MakeCertificate(routes []*v1alpha1.Route, cert HostCertificate) *unstructured.Unstructured {
+	// NOTE: The types need to be []interface{} instead of []string because
+	// the value back from K8s will have this type.
+	var dnsNames []interface{}
+	for _, host := range cert.Hosts {
+		dnsNames = append(dnsNames, host)
+	}
+
+	u := dynamicutils.NewUnstructured(map[string]interface{}{
+		"kind":                 "Certificate",
+		"apiVersion":           fmt.Sprintf("%s/%s", CertificateGVR.Group, CertificateGVR.Version),
+		"metadata.name":        cert.SecretName,
+		"metadata.namespace":   routes[0].Namespace,
+		"spec.secretName":      cert.SecretName,
+		"spec.dnsNames":        dnsNames,
+		"spec.issuerRef.name":  cert.TLS.IssuerName,
+		"spec.issuerRef.kind":  "ClusterIssuer",
+		"spec.issuerRef.group": CertificateGVR.Group,
+	})
+
+	u.SetLabels(map[string]string{
+		v1alpha1.ManagedByLabel: "kf",
+		v1alpha1.ComponentLabel: tlsComponent,
+	})
+	u.SetOwnerReferences(MakeRouteOwnerReferences(routes))
+
+	return u
+}
+
+// MakeCertificateStatus returns the status of the certificate in the Secret
+// served for the host. A nil Secret means the certificate hasn't been issued
+// yet.
+func MakeCertificateStatus(host string, cert HostCertificate, secret *corev1.Secret) *v1alpha1.CertificateStatus {
+	status := &v1alpha1.CertificateStatus{
+		Host:       host,
+		SecretName: cert.SecretName,
+	}
+
+	if secret != nil {
+		if notAfter, err := certutil.NotAfter(secret); err == nil {
+			status.NotAfter = &metav1.Time{Time: notAfter}
+		}
+	}
+
+	return status
+}
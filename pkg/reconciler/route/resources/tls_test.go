@@ -0,0 +1,250 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/internal/certutil"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeTLSRoute(host, domain, path, namespace string, tls *v1alpha1.DomainTLS) *v1alpha1.Route {
+	route := makeRoute(host, domain, path, namespace)
+	route.Spec.TLS = tls
+	return route
+}
+
+func TestMakeHostCertificates(t *testing.T) {
+	t.Parallel()
+
+	domainTLS := &v1alpha1.DomainTLS{SecretName: "domain-cert"}
+	routeTLS := &v1alpha1.DomainTLS{IssuerName: "letsencrypt"}
+
+	cases := map[string]struct {
+		routes      []*v1alpha1.Route
+		spaceDomain *v1alpha1.SpaceDomain
+		expected    []HostCertificate
+	}{
+		"nil domain": {
+			routes: []*v1alpha1.Route{
+				makeTLSRoute("app", "example.com", "", "ns", routeTLS),
+			},
+		},
+		"tcp domain": {
+			spaceDomain: &v1alpha1.SpaceDomain{
+				Domain:   "example.com",
+				Protocol: v1alpha1.SpaceDomainProtocolTCP,
+			},
+		},
+		"no tls": {
+			routes: []*v1alpha1.Route{
+				makeRoute("app", "example.com", "", "ns"),
+			},
+			spaceDomain: &v1alpha1.SpaceDomain{Domain: "example.com"},
+		},
+		"domain tls": {
+			routes: []*v1alpha1.Route{
+				makeRoute("app", "example.com", "", "ns"),
+			},
+			spaceDomain: &v1alpha1.SpaceDomain{Domain: "example.com", TLS: domainTLS},
+			expected: []HostCertificate{
+				{
+					Hosts:      []string{"*.example.com", "example.com"},
+					SecretName: "domain-cert",
+					TLS:        *domainTLS,
+				},
+			},
+		},
+		"route tls takes precedence": {
+			routes: []*v1alpha1.Route{
+				makeTLSRoute("", "example.com", "/b", "ns", routeTLS),
+				makeTLSRoute("", "example.com", "/a", "ns", &v1alpha1.DomainTLS{SecretName: "ignored"}),
+				makeTLSRoute("app", "example.com", "", "ns", routeTLS),
+			},
+			spaceDomain: &v1alpha1.SpaceDomain{Domain: "example.com", TLS: domainTLS},
+			expected: []HostCertificate{
+				{
+					Hosts:      []string{"*.example.com"},
+					SecretName: "domain-cert",
+					TLS:        *domainTLS,
+				},
+				{
+					Hosts:      []string{"app.example.com"},
+					SecretName: "app-example-com-tlsa138bf1813061f0c99ba4083b45f4a46",
+					TLS:        *routeTLS,
+				},
+				{
+					Hosts:      []string{"example.com"},
+					SecretName: "ignored",
+					TLS:        v1alpha1.DomainTLS{SecretName: "ignored"},
+				},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual := MakeHostCertificates(tc.routes, tc.spaceDomain)
+			testutil.AssertEqual(t, "certificates", tc.expected, actual)
+		})
+	}
+}
+
+func TestFindHostCertificate(t *testing.T) {
+	t.Parallel()
+
+	certs := []HostCertificate{
+		{Hosts: []string{"*.example.com"}, SecretName: "wildcard"},
+		{Hosts: []string{"app.example.com"}, SecretName: "app"},
+	}
+
+	cases := map[string]struct {
+		host     string
+		expected string
+	}{
+		"exact match wins": {host: "app.example.com", expected: "app"},
+		"wildcard match":   {host: "other.example.com", expected: "wildcard"},
+		"multiple labels":  {host: "a.b.example.com"},
+		"bare domain":      {host: "example.com"},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var actual string
+			if cert := FindHostCertificate(certs, tc.host); cert != nil {
+				actual = cert.SecretName
+			}
+			testutil.AssertEqual(t, "secretName", tc.expected, actual)
+		})
+	}
+}
+
+func TestMakeGateway(t *testing.T) {
+	t.Parallel()
+
+	routes := []*v1alpha1.Route{
+		makeRoute("app", "example.com", "", "some-namespace"),
+	}
+	certs := []HostCertificate{
+		{
+			Hosts:      []string{"*.example.com", "example.com"},
+			SecretName: "domain-cert",
+		},
+		{
+			Hosts:      []string{"app.example.com"},
+			SecretName: "app-cert",
+		},
+	}
+
+	gateway := MakeGateway(routes, "example.com", map[string]string{"istio": "ingressgateway"}, certs)
+	testutil.AssertGoldenJSON(t, "gateway", gateway)
+}
+
+func TestMakeGatewaySecret(t *testing.T) {
+	t.Parallel()
+
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "domain-cert",
+			Namespace:   "some-namespace",
+			Labels:      map[string]string{"user": "label"},
+			Annotations: map[string]string{"user": "annotation"},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+			"other":                 []byte("not copied"),
+		},
+	}
+
+	secret := MakeGatewaySecret(source, "example.com", "istio-system")
+	testutil.AssertGoldenJSON(t, "secret", secret)
+}
+
+func TestMakeCertificate(t *testing.T) {
+	t.Parallel()
+
+	routes := []*v1alpha1.Route{
+		makeRoute("app", "example.com", "", "some-namespace"),
+	}
+	cert := HostCertificate{
+		Hosts:      []string{"app.example.com"},
+		SecretName: "app-cert",
+		TLS:        v1alpha1.DomainTLS{IssuerName: "letsencrypt"},
+	}
+
+	testutil.AssertGoldenJSON(t, "certificate", MakeCertificate(routes, cert))
+}
+
+func TestSelfSignedSecret(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	routes := []*v1alpha1.Route{
+		makeRoute("app", "example.com", "", "some-namespace"),
+	}
+	cert := HostCertificate{
+		Hosts:      []string{"*.example.com", "example.com"},
+		SecretName: "example-com-tls",
+		TLS:        v1alpha1.DomainTLS{IssuerName: v1alpha1.SelfSignedIssuerName},
+	}
+
+	secret, err := MakeSelfSignedSecret(routes, cert, now)
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "name", "example-com-tls", secret.Name)
+	testutil.AssertEqual(t, "namespace", "some-namespace", secret.Namespace)
+	testutil.AssertEqual(t, "hosts", "*.example.com,example.com", secret.Annotations[v1alpha1.TLSSecretHostsAnnotation])
+
+	parsed, err := certutil.ParseCertificate(secret.Data[corev1.TLSCertKey])
+	testutil.AssertNil(t, "err", err)
+	testutil.AssertEqual(t, "dnsNames", cert.Hosts, parsed.DNSNames)
+
+	cases := map[string]struct {
+		cert     HostCertificate
+		now      time.Time
+		expected bool
+	}{
+		"fresh": {
+			cert: cert,
+			now:  now,
+		},
+		"expiring": {
+			cert:     cert,
+			now:      now.Add(SelfSignedCertificateValidity - SelfSignedCertificateRenewBefore + time.Hour),
+			expected: true,
+		},
+		"hosts changed": {
+			cert: HostCertificate{
+				Hosts:      []string{"*.example.com"},
+				SecretName: cert.SecretName,
+			},
+			now:      now,
+			expected: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual := SelfSignedSecretNeedsRenewal(secret, tc.cert, tc.now)
+			testutil.AssertEqual(t, "needsRenewal", tc.expected, actual)
+		})
+	}
+}
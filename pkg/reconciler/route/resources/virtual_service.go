@@ -109,46 +109,41 @@ func MakeVirtualService(routes []*v1alpha1.Route, bindings map[string]RouteBindi
 	}
 	sort.Sort(rsfs)
 
-	httpRoutes, err := buildHTTPRoutes(rsfs, bindings, routeServiceBindings)
-	if err != nil {
-		return nil, err
-	}
-
 	gatewayName, err := buildGatewayName(spaceDomain.GatewayName)
 	if err != nil {
 		return nil, err
 	}
 
-	// Mark all of the Routes as owners so the VS gets deleted if
-	// they are all deleted.
-	//
-	// NOTE that this is NOT marking them as controllers, just as equal owners.
-	var owners []metav1.OwnerReference
-	for _, route := range routes {
-		gvk := route.GetGroupVersionKind()
-		owners = append(owners, metav1.OwnerReference{
-			APIVersion: gvk.GroupVersion().String(),
-			Kind:       gvk.Kind,
-			UID:        route.GetUID(),
-			Name:       route.GetName(),
-		})
-	}
-
-	sort.Slice(owners, func(i, j int) bool {
-		return owners[i].Name < owners[j].Name
-	})
+	var istioVirtualService istio.VirtualService
+	if spaceDomain.IsTCP() {
+		// TCP Routes can't be told apart by host so only the domain is
+		// matched.
+		istioVirtualService = istio.VirtualService{
+			Hosts: []string{domain},
+			Tcp:   buildTCPRoutes(rsfs, bindings),
+		}
+	} else {
+		httpRoutes, err := buildHTTPRoutes(rsfs, bindings, routeServiceBindings, buildRoutePolicies(routes))
+		if err != nil {
+			return nil, err
+		}
 
-	istioVirtualService := istio.VirtualService{}
-	if gatewayName == "" {
 		istioVirtualService = istio.VirtualService{
 			Hosts: []string{"*." + domain, domain}, // b/176970436: Value of Hosts can be hostname.example.com or example.com since hostname is optional.
 			Http:  httpRoutes,
 		}
-	} else {
-		istioVirtualService = istio.VirtualService{
-			Gateways: []string{gatewayName},
-			Hosts:    []string{"*." + domain, domain},
-			Http:     httpRoutes,
+	}
+
+	if gatewayName != "" {
+		istioVirtualService.Gateways = []string{gatewayName}
+
+		// HTTPS is terminated by a Gateway in the Space so each Space can
+		// provide its own certificates.
+		if HasTLS(routes, spaceDomain) {
+			istioVirtualService.Gateways = append(
+				istioVirtualService.Gateways,
+				fmt.Sprintf("%s/%s", namespace, MakeTLSGatewayName(domain)),
+			)
 		}
 	}
 
@@ -168,12 +163,36 @@ func MakeVirtualService(routes []*v1alpha1.Route, bindings map[string]RouteBindi
 			Annotations: map[string]string{
 				DomainAnnotation: domain,
 			},
-			OwnerReferences: owners,
+			// Mark all of the Routes as owners so the VS gets deleted if
+			// they are all deleted.
+			OwnerReferences: MakeRouteOwnerReferences(routes),
 		},
 		Spec: istioVirtualService,
 	}, nil
 }
 
+// MakeRouteOwnerReferences marks all of the Routes as owners of an object.
+//
+// NOTE that this is NOT marking them as controllers, just as equal owners.
+func MakeRouteOwnerReferences(routes []*v1alpha1.Route) []metav1.OwnerReference {
+	var owners []metav1.OwnerReference
+	for _, route := range routes {
+		gvk := route.GetGroupVersionKind()
+		owners = append(owners, metav1.OwnerReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			UID:        route.GetUID(),
+			Name:       route.GetName(),
+		})
+	}
+
+	sort.Slice(owners, func(i, j int) bool {
+		return owners[i].Name < owners[j].Name
+	})
+
+	return owners
+}
+
 // Checks the gatewayName specified.
 // For gatewayName=kf/internal-gateway use the internal service mesh and do not use any gateway.
 func buildGatewayName(gatewayName string) (string, error) {
@@ -185,10 +204,16 @@ func buildGatewayName(gatewayName string) (string, error) {
 
 // Create HTTP route rules for all routes with the same domain.
 // Paths that do not have an app bound to them will return a 404 when a request is sent to that path.
-func buildHTTPRoutes(routes v1alpha1.RouteSpecFieldsSlice, appBindings map[string]RouteBindingSlice, routeServiceBindings map[string][]v1alpha1.RouteServiceDestination) ([]*istio.HTTPRoute, error) {
+// Routes with policies have them applied to the HTTP routes sending traffic to Apps.
+func buildHTTPRoutes(routes v1alpha1.RouteSpecFieldsSlice, appBindings map[string]RouteBindingSlice, routeServiceBindings map[string][]v1alpha1.RouteServiceDestination, policies map[string]*v1alpha1.Route) ([]*istio.HTTPRoute, error) {
 	var httpRoutes []*istio.HTTPRoute
 
 	for _, r := range routes {
+		// TCP Routes can't be served on HTTP domains.
+		if r.IsTCP() {
+			continue
+		}
+
 		var rsfHTTPRoutes []*istio.HTTPRoute
 		appDestinations := appBindings[r.String()]
 
@@ -216,6 +241,10 @@ func buildHTTPRoutes(routes v1alpha1.RouteSpecFieldsSlice, appBindings map[strin
 
 			rsfHTTPRoutes = append(rsfHTTPRoutes, appHeaderHTTPRoutes...)
 			rsfHTTPRoutes = append(rsfHTTPRoutes, normalizedHTTPRoute)
+
+			for _, httpRoute := range rsfHTTPRoutes {
+				applyRoutePolicy(httpRoute, policies[r.String()])
+			}
 		}
 
 		// If there is a route service bound to this route, add header match rules to each HTTP route.
@@ -249,6 +278,42 @@ func buildHTTPRoutes(routes v1alpha1.RouteSpecFieldsSlice, appBindings map[strin
 	return httpRoutes, nil
 }
 
+// buildTCPRoutes creates a TCP route rule for each TCP Route on the domain
+// that has running Apps bound to it. Unlike HTTP there's no way to return an
+// error to the client so Routes without running Apps are left out and
+// connections to them are refused by the gateway.
+func buildTCPRoutes(routes v1alpha1.RouteSpecFieldsSlice, appBindings map[string]RouteBindingSlice) []*istio.TCPRoute {
+	var tcpRoutes []*istio.TCPRoute
+
+	for _, r := range routes {
+		if !r.IsTCP() {
+			continue
+		}
+
+		normalizedBindings := normalizeRouteWeights(appBindings[r.String()])
+		if len(normalizedBindings) == 0 {
+			continue
+		}
+
+		var destinations []*istio.RouteDestination
+		for _, httpDestination := range buildRouteDestinations(normalizedBindings) {
+			destinations = append(destinations, &istio.RouteDestination{
+				Destination: httpDestination.Destination,
+				Weight:      httpDestination.Weight,
+			})
+		}
+
+		tcpRoutes = append(tcpRoutes, &istio.TCPRoute{
+			Match: []*istio.L4MatchAttributes{
+				{Port: uint32(r.Port)},
+			},
+			Route: destinations,
+		})
+	}
+
+	return tcpRoutes
+}
+
 // buildAppHeaderHTTPRoutes creates a list of HTTPRoutes, where each HTTPRoute contains a header matching rule for an app destination.
 // Even when there are multiple apps mapped to a route,
 // Kf always directs to the requested app if the request contains the header "x-kf-app": [appname]
@@ -267,17 +332,7 @@ func buildAppHeaderHTTPRoutes(rsf v1alpha1.RouteSpecFields, bindings RouteBindin
 		} else {
 			httpRoute = &istio.HTTPRoute{
 				Match: []*istio.HTTPMatchRequest{pathAppMatchers},
-				Route: []*istio.HTTPRouteDestination{
-					{
-						Destination: &istio.Destination{
-							Host: binding.ServiceName,
-							Port: &istio.PortSelector{
-								Number: uint32(binding.Port),
-							},
-						},
-						Weight: 100,
-					},
-				},
+				Route: buildBindingDestinations(binding, 100),
 			}
 		}
 		appHeaderRoutes = append(appHeaderRoutes, httpRoute)
@@ -335,19 +390,47 @@ func buildRouteDestinations(normalizedRouteBindings RouteBindingSlice) []*istio.
 	// if they're not, we'll just sort again for safety
 	sort.Sort(normalizedRouteBindings)
 	for _, binding := range normalizedRouteBindings {
-		routeDestination := &istio.HTTPRouteDestination{
+		routeDestinations = append(routeDestinations, buildBindingDestinations(binding, binding.Weight)...)
+	}
+
+	return routeDestinations
+}
+
+// buildBindingDestinations creates the route destinations for a single binding
+// given the percentage of traffic it receives. If the binding is in the middle
+// of a rollout, the traffic is split between the binding's Service and the
+// candidate Service running the new revision.
+func buildBindingDestinations(binding v1alpha1.RouteDestination, weight int32) []*istio.HTTPRouteDestination {
+	var candidateWeight int32
+	if binding.CandidateServiceName != "" {
+		candidateWeight = (weight * binding.CandidatePercent) / 100
+	}
+
+	destinations := []*istio.HTTPRouteDestination{
+		{
 			Destination: &istio.Destination{
 				Host: binding.ServiceName,
 				Port: &istio.PortSelector{
 					Number: uint32(binding.Port),
 				},
 			},
-			Weight: int32(binding.Weight),
-		}
-		routeDestinations = append(routeDestinations, routeDestination)
+			Weight: weight - candidateWeight,
+		},
 	}
 
-	return routeDestinations
+	if candidateWeight > 0 {
+		destinations = append(destinations, &istio.HTTPRouteDestination{
+			Destination: &istio.Destination{
+				Host: binding.CandidateServiceName,
+				Port: &istio.PortSelector{
+					Number: uint32(binding.Port),
+				},
+			},
+			Weight: candidateWeight,
+		})
+	}
+
+	return destinations
 }
 
 // normalizeRouteWeights generates integer percentages for route weights that sum to 100, and returns
@@ -421,14 +504,51 @@ func buildPathMatchers(rsf v1alpha1.RouteSpecFields) (*istio.HTTPMatchRequest, e
 		}
 	}
 
-	return &istio.HTTPMatchRequest{
+	matchers := &istio.HTTPMatchRequest{
 		Uri: &istio.StringMatch{
 			MatchType: &istio.StringMatch_Regex{
 				Regex: regexpPath,
 			},
 		},
 		Authority: authorityMatch,
-	}, nil
+	}
+
+	addRouteMatchers(rsf, matchers)
+
+	return matchers, nil
+}
+
+// addRouteMatchers adds exact match rules for the headers, query parameters,
+// and method the route is restricted to.
+func addRouteMatchers(rsf v1alpha1.RouteSpecFields, matchers *istio.HTTPMatchRequest) {
+	for _, header := range rsf.Headers {
+		if matchers.Headers == nil {
+			matchers.Headers = make(map[string]*istio.StringMatch)
+		}
+
+		// Istio requires lower case header names.
+		matchers.Headers[strings.ToLower(header.Name)] = exactStringMatch(header.Value)
+	}
+
+	for _, param := range rsf.QueryParams {
+		if matchers.QueryParams == nil {
+			matchers.QueryParams = make(map[string]*istio.StringMatch)
+		}
+
+		matchers.QueryParams[param.Name] = exactStringMatch(param.Value)
+	}
+
+	if rsf.Method != "" {
+		matchers.Method = exactStringMatch(strings.ToUpper(rsf.Method))
+	}
+}
+
+func exactStringMatch(value string) *istio.StringMatch {
+	return &istio.StringMatch{
+		MatchType: &istio.StringMatch_Exact{
+			Exact: value,
+		},
+	}
 }
 
 // buildPathAppMatchers creates regex matchers for a route path
@@ -441,13 +561,10 @@ func buildPathAppMatchers(rsf v1alpha1.RouteSpecFields, appName string) (*istio.
 	}
 
 	// matchers.Headers takes lower-case HTTP headers
-	matchers.Headers = map[string]*istio.StringMatch{
-		strings.ToLower(KfAppMatchHeader): {
-			MatchType: &istio.StringMatch_Exact{
-				Exact: appName,
-			},
-		},
+	if matchers.Headers == nil {
+		matchers.Headers = make(map[string]*istio.StringMatch)
 	}
+	matchers.Headers[strings.ToLower(KfAppMatchHeader)] = exactStringMatch(appName)
 
 	return matchers, nil
 }
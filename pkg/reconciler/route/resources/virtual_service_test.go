@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/testutil"
@@ -100,6 +101,43 @@ func makeRoute(host, domain, path, namespace string) *v1alpha1.Route {
 	}
 }
 
+func makeTCPRoute(domain string, port int32, namespace string) *v1alpha1.Route {
+	route := makeRoute("", domain, "/", namespace)
+	route.Name = v1alpha1.GenerateName("fake-route", domain, fmt.Sprint(port))
+	route.Spec.Port = port
+	return route
+}
+
+func makeTCPRouteSpecFieldsStr(domain string, port int32) string {
+	return v1alpha1.RouteSpecFields{
+		Domain: domain,
+		Port:   port,
+	}.String()
+}
+
+func makeMatchRoute(hostname, domain, namespace string, headers []v1alpha1.RouteMatch, method string) *v1alpha1.Route {
+	route := makeRoute(hostname, domain, "/", namespace)
+	route.Spec.Headers = headers
+	route.Spec.Method = method
+	route.Name = v1alpha1.GenerateRouteNameFromFields(route.Spec.RouteSpecFields)
+	return route
+}
+
+func makePolicyRoute(hostname, domain, path, namespace string, policy *v1alpha1.RoutePolicy) *v1alpha1.Route {
+	route := makeRoute(hostname, domain, path, namespace)
+	route.Spec.Policy = policy
+	return route
+}
+
+func makeMatchRouteSpecFieldsStr(hostname, domain string, headers []v1alpha1.RouteMatch, method string) string {
+	return v1alpha1.RouteSpecFields{
+		Hostname: hostname,
+		Domain:   domain,
+		Headers:  headers,
+		Method:   method,
+	}.String()
+}
+
 func TestMakeVirtualService(t *testing.T) {
 	t.Parallel()
 
@@ -191,6 +229,27 @@ func TestMakeVirtualService(t *testing.T) {
 				GatewayName: "kf/some-gateway",
 			},
 		},
+		"app with candidate revision": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("some-host", "example.com", "/some-path", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", "/some-path"): []v1alpha1.RouteDestination{
+					{
+						Weight:               1,
+						ServiceName:          "app-1",
+						Port:                 v1alpha1.DefaultRouteDestinationPort,
+						CandidateServiceName: "app-1-candidate",
+						CandidatePercent:     10,
+					},
+					makeAppDestination("app-2", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/some-gateway",
+			},
+		},
 		"longest path first": {
 			Routes: []*v1alpha1.Route{
 				makeRoute("some-host", "example.com/", "", "some-namespace"),
@@ -278,6 +337,116 @@ func TestMakeVirtualService(t *testing.T) {
 				GatewayName: "kf/internal-gateway",
 			},
 		},
+		"tcp routes": {
+			Routes: []*v1alpha1.Route{
+				makeTCPRoute("tcp.example.com", 1025, "some-namespace"),
+				makeTCPRoute("tcp.example.com", 1024, "some-namespace"),
+				makeTCPRoute("tcp.example.com", 1026, "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeTCPRouteSpecFieldsStr("tcp.example.com", 1024): []v1alpha1.RouteDestination{
+					makeAppDestinationWithPort("mqtt", 1, 1883),
+				},
+				makeTCPRouteSpecFieldsStr("tcp.example.com", 1025): []v1alpha1.RouteDestination{
+					makeAppDestinationWithPort("pg-proxy-a", 1, 5432),
+					makeAppDestinationWithPort("pg-proxy-b", 3, 5432),
+				},
+				makeTCPRouteSpecFieldsStr("tcp.example.com", 1026): []v1alpha1.RouteDestination{
+					makeAppDestinationWithPort("stopped", 0, 5432),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:          "tcp.example.com",
+				GatewayName:     "kf/tcp-gateway",
+				Protocol:        v1alpha1.SpaceDomainProtocolTCP,
+				ReservablePorts: "1024-1033",
+			},
+		},
+		"tls domain": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("", "example.com", "", "some-namespace"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("", "example.com", ""): []v1alpha1.RouteDestination{
+					makeAppDestination("some-app", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/external-gateway",
+				TLS: &v1alpha1.DomainTLS{
+					SecretName: "example-com-cert",
+				},
+			},
+		},
+		"header and weight based routing": {
+			Routes: []*v1alpha1.Route{
+				makeRoute("some-host", "example.com", "/", "some-namespace"),
+				makeMatchRoute("some-host", "example.com", "some-namespace", []v1alpha1.RouteMatch{{Name: "X-Canary", Value: "true"}}, ""),
+				makeMatchRoute("some-host", "example.com", "some-namespace", []v1alpha1.RouteMatch{{Name: "X-Canary", Value: "true"}}, "POST"),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", "/"): []v1alpha1.RouteDestination{
+					makeAppDestination("app-a", 9), makeAppDestination("app-b", 1),
+				},
+				makeMatchRouteSpecFieldsStr("some-host", "example.com", []v1alpha1.RouteMatch{{Name: "x-canary", Value: "true"}}, ""): []v1alpha1.RouteDestination{
+					makeAppDestination("app-b", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/some-gateway",
+			},
+		},
+		"route policies": {
+			Routes: []*v1alpha1.Route{
+				makePolicyRoute("some-host", "example.com", "/api", "some-namespace", &v1alpha1.RoutePolicy{
+					Timeout: &metav1.Duration{Duration: 30 * time.Second},
+					Retries: &v1alpha1.RouteRetryPolicy{
+						Attempts:      3,
+						PerTryTimeout: &metav1.Duration{Duration: 5 * time.Second},
+						RetryOn:       "5xx,connect-failure",
+					},
+					CORS: &v1alpha1.RouteCORSPolicy{
+						AllowOrigins:     []string{"https://example.com", "*"},
+						AllowMethods:     []string{"GET", "POST"},
+						MaxAge:           &metav1.Duration{Duration: time.Hour},
+						AllowCredentials: true,
+					},
+					RateLimit: &v1alpha1.RouteRateLimit{Requests: 100, Unit: v1alpha1.RateLimitUnitMinute},
+					RequestHeaders: &v1alpha1.RouteHeaderOperations{
+						Set:    map[string]string{"x-forwarded-team": "payments"},
+						Remove: []string{"x-debug"},
+					},
+					ResponseHeaders: &v1alpha1.RouteHeaderOperations{
+						Add: map[string]string{"x-served-by": "kf"},
+					},
+				}),
+				makePolicyRoute("some-host", "example.com", "/unbound", "some-namespace", &v1alpha1.RoutePolicy{
+					Timeout: &metav1.Duration{Duration: 30 * time.Second},
+				}),
+			},
+			Bindings: map[string]RouteBindingSlice{
+				makeRouteSpecFieldsStr("some-host", "example.com", "/api"): []v1alpha1.RouteDestination{
+					makeAppDestination("app-a", 1),
+				},
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:      "example.com",
+				GatewayName: "kf/some-gateway",
+			},
+		},
+		"tcp routes without apps": {
+			Routes: []*v1alpha1.Route{
+				makeTCPRoute("tcp.example.com", 1024, "some-namespace"),
+			},
+			SpaceDomain: v1alpha1.SpaceDomain{
+				Domain:          "tcp.example.com",
+				GatewayName:     "kf/tcp-gateway",
+				Protocol:        v1alpha1.SpaceDomainProtocolTCP,
+				ReservablePorts: "1024-1033",
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			actualVS, actualErr := MakeVirtualService(tc.Routes, tc.Bindings, tc.RouteServiceBindings, &tc.SpaceDomain)
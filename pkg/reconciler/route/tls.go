@@ -0,0 +1,428 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/route/resources"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/logging"
+)
+
+// reconcileTLS provisions the certificates for the domain and Routes, copies
+// them to the ingress gateways and programs a Gateway in the Space to
+// terminate HTTPS for them. It returns the certificates that should be served
+// and the Secrets holding the ones that have been issued.
+func (r *Reconciler) reconcileTLS(
+	ctx context.Context,
+	namespace string,
+	domain string,
+	routes []*v1alpha1.Route,
+	spaceDomain *v1alpha1.SpaceDomain,
+) ([]resources.HostCertificate, map[string]*corev1.Secret, error) {
+	logger := logging.FromContext(ctx)
+
+	var certs []resources.HostCertificate
+	if len(routes) > 0 {
+		certs = resources.MakeHostCertificates(routes, spaceDomain)
+	}
+
+	// Issue or look up certificates.
+	secrets := make(map[string]*corev1.Secret)
+	var ready []resources.HostCertificate
+	for _, cert := range certs {
+		secret, err := r.reconcileCertificateSecret(ctx, routes, cert)
+		if err != nil {
+			return nil, nil, fmt.Errorf("certificate for %s: %v", strings.Join(cert.Hosts, ", "), err)
+		}
+
+		if secret == nil {
+			logger.Infow("Certificate hasn't been issued yet", zap.Strings("hosts", cert.Hosts))
+			continue
+		}
+
+		secrets[cert.SecretName] = secret
+		ready = append(ready, cert)
+	}
+
+	// Copy certificates to the namespaces of the ingress gateways.
+	var selector map[string]string
+	desiredCopies := sets.NewString()
+	if len(ready) > 0 {
+		var err error
+		selector, err = r.ingressGatewaySelector(spaceDomain.GatewayName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ingressNamespaces, err := r.ingressNamespaces(selector)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, cert := range ready {
+			for _, ingressNamespace := range ingressNamespaces {
+				desired := resources.MakeGatewaySecret(secrets[cert.SecretName], domain, ingressNamespace)
+				desiredCopies.Insert(desired.Namespace + "/" + desired.Name)
+				if err := r.reconcileGatewaySecret(ctx, desired); err != nil {
+					return nil, nil, fmt.Errorf("copying certificate to %s: %v", ingressNamespace, err)
+				}
+			}
+		}
+	}
+
+	// Clean up copies of certificates that are no longer served.
+	copies, err := r.SecretLister.List(resources.GatewaySecretSelector(namespace))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, secret := range copies {
+		if secret.Annotations[resources.DomainAnnotation] != domain ||
+			desiredCopies.Has(secret.Namespace+"/"+secret.Name) {
+			continue
+		}
+
+		logger.Infow("Deleting stale certificate copy", zap.String("secret", secret.Namespace+"/"+secret.Name))
+		if err := r.KubeClientSet.
+			CoreV1().
+			Secrets(secret.Namespace).
+			Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return nil, nil, err
+		}
+	}
+
+	if err := r.reconcileGateway(ctx, namespace, domain, routes, selector, ready); err != nil {
+		return nil, nil, fmt.Errorf("Gateway: %v", err)
+	}
+
+	return certs, secrets, nil
+}
+
+// reconcileCertificateSecret returns the Secret holding the certificate,
+// issuing it first if Kf or cert-manager is responsible for doing so. A nil
+// Secret is returned if the certificate hasn't been issued yet.
+func (r *Reconciler) reconcileCertificateSecret(
+	ctx context.Context,
+	routes []*v1alpha1.Route,
+	cert resources.HostCertificate,
+) (*corev1.Secret, error) {
+	namespace := routes[0].Namespace
+
+	switch {
+	case cert.TLS.SecretName != "":
+		// Nothing to do, the Secret is provided by the user.
+
+	case cert.TLS.IsSelfSigned():
+		return r.reconcileSelfSignedSecret(ctx, routes, cert)
+
+	default:
+		if err := r.reconcileCertificate(ctx, resources.MakeCertificate(routes, cert)); err != nil {
+			return nil, err
+		}
+	}
+
+	secret, err := r.SecretLister.Secrets(namespace).Get(cert.SecretName)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	return secret, err
+}
+
+func (r *Reconciler) reconcileSelfSignedSecret(
+	ctx context.Context,
+	routes []*v1alpha1.Route,
+	cert resources.HostCertificate,
+) (*corev1.Secret, error) {
+	logger := logging.FromContext(ctx)
+	now := time.Now()
+
+	actual, err := r.SecretLister.Secrets(routes[0].Namespace).Get(cert.SecretName)
+	if errors.IsNotFound(err) {
+		desired, err := resources.MakeSelfSignedSecret(routes, cert, now)
+		if err != nil {
+			return nil, err
+		}
+
+		return r.KubeClientSet.
+			CoreV1().
+			Secrets(desired.Namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	if resources.SelfSignedSecretNeedsRenewal(actual, cert, now) {
+		logger.Infow("Renewing self-signed certificate", zap.Strings("hosts", cert.Hosts))
+
+		desired, err := resources.MakeSelfSignedSecret(routes, cert, now)
+		if err != nil {
+			return nil, err
+		}
+
+		existing.Labels = desired.Labels
+		existing.Annotations = desired.Annotations
+		existing.OwnerReferences = desired.OwnerReferences
+		existing.Data = desired.Data
+	} else {
+		// Keep the owners up to date so the Secret is removed with the
+		// Routes without re-issuing the certificate.
+		owners := resources.MakeRouteOwnerReferences(routes)
+		if reconciler.NewSemanticEqualityBuilder(logger, "Secret").
+			Append("metadata.ownerReferences", owners, actual.OwnerReferences).
+			IsSemanticallyEqual() {
+			return actual, nil
+		}
+
+		existing.OwnerReferences = owners
+	}
+
+	return r.KubeClientSet.
+		CoreV1().
+		Secrets(existing.Namespace).
+		Update(ctx, existing, metav1.UpdateOptions{})
+}
+
+func (r *Reconciler) reconcileCertificate(ctx context.Context, desired *unstructured.Unstructured) error {
+	logger := logging.FromContext(ctx)
+	client := r.certificateClient.Namespace(desired.GetNamespace())
+
+	actual, err := client.Get(ctx, desired.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = client.Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	// Check for differences, if none we don't need to reconcile.
+	builder := reconciler.NewUnstructuredSemanticEqualityBuilder(logger, "Certificate").
+		Append("metadata.labels", desired, actual).
+		Append("metadata.ownerReferences", desired, actual).
+		Append("spec", desired, actual)
+
+	if builder.IsSemanticallyEqual() {
+		return nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	builder.Transform(existing)
+	_, err = client.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// ingressGatewaySelector returns the workload selector of the Gateway the
+// domain is routed through so HTTPS is terminated by the same workloads.
+func (r *Reconciler) ingressGatewaySelector(gatewayName string) (map[string]string, error) {
+	parts := strings.SplitN(gatewayName, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Gateway %q must be in the format namespace/name", gatewayName)
+	}
+
+	gateway, err := r.gatewayLister.Gateways(parts[0]).Get(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("getting Gateway %q: %v", gatewayName, err)
+	}
+
+	return gateway.Spec.Selector, nil
+}
+
+// ingressNamespaces returns the namespaces running ingress gateway workloads
+// matching the selector.
+func (r *Reconciler) ingressNamespaces(selector map[string]string) ([]string, error) {
+	services, err := r.serviceLister.List(labels.SelectorFromSet(selector))
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := sets.NewString()
+	for _, service := range services {
+		namespaces.Insert(service.Namespace)
+	}
+
+	if namespaces.Len() == 0 {
+		return nil, fmt.Errorf("no ingress gateway Services match %v", selector)
+	}
+
+	return namespaces.List(), nil
+}
+
+func (r *Reconciler) reconcileGatewaySecret(ctx context.Context, desired *corev1.Secret) error {
+	logger := logging.FromContext(ctx)
+
+	actual, err := r.SecretLister.Secrets(desired.Namespace).Get(desired.Name)
+	if errors.IsNotFound(err) {
+		_, err = r.KubeClientSet.
+			CoreV1().
+			Secrets(desired.Namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	// Check for differences, if none we don't need to reconcile.
+	if reconciler.NewSemanticEqualityBuilder(logger, "Secret").
+		Append("metadata.labels", desired.Labels, actual.Labels).
+		Append("metadata.annotations", desired.Annotations, actual.Annotations).
+		Append("type", desired.Type, actual.Type).
+		Append("data", desired.Data, actual.Data).
+		IsSemanticallyEqual() {
+		return nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.Data = desired.Data
+
+	// The type of a Secret is immutable.
+	if existing.Type != desired.Type {
+		if err := r.KubeClientSet.
+			CoreV1().
+			Secrets(existing.Namespace).
+			Delete(ctx, existing.Name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+
+		_, err = r.KubeClientSet.
+			CoreV1().
+			Secrets(desired.Namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+
+	_, err = r.KubeClientSet.
+		CoreV1().
+		Secrets(existing.Namespace).
+		Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// reconcileGateway syncs the Gateway terminating HTTPS for the domain. The
+// Gateway is removed if no certificates are ready to be served.
+func (r *Reconciler) reconcileGateway(
+	ctx context.Context,
+	namespace string,
+	domain string,
+	routes []*v1alpha1.Route,
+	selector map[string]string,
+	certs []resources.HostCertificate,
+) error {
+	logger := logging.FromContext(ctx)
+	name := resources.MakeTLSGatewayName(domain)
+
+	actual, err := r.gatewayLister.Gateways(namespace).Get(name)
+	switch {
+	case len(certs) == 0:
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		logger.Info("Deleting Gateway because no certificates are ready")
+		err := r.networkingClientSet.
+			NetworkingV1alpha3().
+			Gateways(namespace).
+			Delete(ctx, name, metav1.DeleteOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+
+	case errors.IsNotFound(err):
+		_, err := r.networkingClientSet.
+			NetworkingV1alpha3().
+			Gateways(namespace).
+			Create(ctx, resources.MakeGateway(routes, domain, selector, certs), metav1.CreateOptions{})
+		return err
+
+	case err != nil:
+		return err
+
+	case actual.GetDeletionTimestamp() != nil:
+		return nil
+	}
+
+	desired := resources.MakeGateway(routes, domain, selector, certs)
+
+	// Check for differences, if none we don't need to reconcile.
+	semanticEquality := reconciler.NewSemanticEqualityBuilder(logger, "Gateway").
+		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
+		Append("metadata.ownerReferences", desired.ObjectMeta.OwnerReferences, actual.ObjectMeta.OwnerReferences).
+		Append("spec.selector", desired.Spec.Selector, actual.Spec.Selector)
+
+	if len(desired.Spec.Servers) == len(actual.Spec.Servers) {
+		for i, server := range desired.Spec.Servers {
+			semanticEquality.Append(fmt.Sprintf("spec.servers[%d]", i), server, actual.Spec.Servers[i])
+		}
+
+		if semanticEquality.IsSemanticallyEqual() {
+			return nil
+		}
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec.Selector = desired.Spec.Selector
+	existing.Spec.Servers = desired.Spec.Servers
+
+	_, err = r.networkingClientSet.
+		NetworkingV1alpha3().
+		Gateways(existing.GetNamespace()).
+		Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// propagateTLS sets the certificate a Route is served with over HTTPS.
+func propagateTLS(
+	route *v1alpha1.Route,
+	certs []resources.HostCertificate,
+	secrets map[string]*corev1.Secret,
+) {
+	host := route.Spec.RouteSpecFields.Host()
+	cert := resources.FindHostCertificate(certs, host)
+	if route.Spec.IsTCP() || cert == nil {
+		route.Status.PropagateTLS(nil)
+		return
+	}
+
+	route.Status.PropagateTLS(resources.MakeCertificateStatus(host, *cert, secrets[cert.SecretName]))
+}
@@ -0,0 +1,213 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	networking "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	networkingfake "github.com/google/kf/v2/pkg/client/networking/clientset/versioned/fake"
+	networkinglisters "github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3"
+	"github.com/google/kf/v2/pkg/internal/certutil"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler"
+	"github.com/google/kf/v2/pkg/reconciler/route/resources"
+	istio "istio.io/api/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	v1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestReconciler_reconcileTLS(t *testing.T) {
+	t.Parallel()
+
+	const (
+		namespace = "some-namespace"
+		domain    = "example.com"
+	)
+
+	certPEM, keyPEM, err := certutil.SelfSigned([]string{"*." + domain}, time.Now(), time.Hour)
+	testutil.AssertNil(t, "err", err)
+
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "user-cert", Namespace: namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+
+	staleCopy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stale-copy",
+			Namespace: "istio-system",
+			Labels: map[string]string{
+				v1alpha1.ManagedByLabel:           "kf",
+				v1alpha1.ComponentLabel:           "tls-certificate",
+				resources.TLSSourceNamespaceLabel: namespace,
+			},
+			Annotations: map[string]string{
+				resources.DomainAnnotation: domain,
+			},
+		},
+	}
+
+	baseGateway := &networking.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-gateway", Namespace: "kf"},
+		Spec: istio.Gateway{
+			Selector: map[string]string{"istio": "ingressgateway"},
+		},
+	}
+
+	ingressService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "istio-ingressgateway",
+			Namespace: "istio-system",
+			Labels:    map[string]string{"istio": "ingressgateway"},
+		},
+	}
+
+	route := &v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: "some-route", Namespace: namespace},
+		Spec: v1alpha1.RouteSpec{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Hostname: "app",
+				Domain:   domain,
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		tls *v1alpha1.DomainTLS
+
+		wantGateway     bool
+		wantSecretCopy  bool
+		wantCertificate bool
+		wantNotAfter    bool
+	}{
+		"user provided secret": {
+			tls:            &v1alpha1.DomainTLS{SecretName: "user-cert"},
+			wantGateway:    true,
+			wantSecretCopy: true,
+			wantNotAfter:   true,
+		},
+		"missing user provided secret": {
+			tls: &v1alpha1.DomainTLS{SecretName: "missing-cert"},
+		},
+		"self-signed": {
+			tls:            &v1alpha1.DomainTLS{IssuerName: v1alpha1.SelfSignedIssuerName},
+			wantGateway:    true,
+			wantSecretCopy: true,
+			wantNotAfter:   true,
+		},
+		"cert-manager issuer": {
+			tls:             &v1alpha1.DomainTLS{IssuerName: "letsencrypt"},
+			wantCertificate: true,
+		},
+		"no tls": {},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := context.Background()
+
+			secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			secretIndexer.Add(userSecret)
+			secretIndexer.Add(staleCopy)
+
+			gatewayIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			gatewayIndexer.Add(baseGateway)
+
+			serviceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			serviceIndexer.Add(ingressService)
+
+			kubeClient := k8sfake.NewSimpleClientset(userSecret, staleCopy)
+			networkingClient := networkingfake.NewSimpleClientset()
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+				runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					resources.CertificateGVR: "CertificateList",
+				},
+			)
+
+			r := &Reconciler{
+				Base: &reconciler.Base{
+					KubeClientSet: kubeClient,
+					SecretLister:  v1listers.NewSecretLister(secretIndexer),
+				},
+				networkingClientSet: networkingClient,
+				gatewayLister:       networkinglisters.NewGatewayLister(gatewayIndexer),
+				serviceLister:       v1listers.NewServiceLister(serviceIndexer),
+				certificateClient:   dynamicClient.Resource(resources.CertificateGVR),
+			}
+
+			spaceDomain := &v1alpha1.SpaceDomain{
+				Domain:      domain,
+				GatewayName: "kf/external-gateway",
+				TLS:         tc.tls,
+			}
+
+			routes := []*v1alpha1.Route{route.DeepCopy()}
+			certs, secrets, err := r.reconcileTLS(ctx, namespace, domain, routes, spaceDomain)
+			testutil.AssertNil(t, "err", err)
+
+			_, err = networkingClient.
+				NetworkingV1alpha3().
+				Gateways(namespace).
+				Get(ctx, resources.MakeTLSGatewayName(domain), metav1.GetOptions{})
+			testutil.AssertEqual(t, "gateway created", tc.wantGateway, err == nil)
+
+			copies, err := kubeClient.CoreV1().Secrets("istio-system").List(ctx, metav1.ListOptions{})
+			testutil.AssertNil(t, "err", err)
+			var copyNames []string
+			for _, secret := range copies.Items {
+				copyNames = append(copyNames, secret.Name)
+			}
+			if tc.wantSecretCopy {
+				testutil.AssertEqual(t, "copies", []string{resources.MakeGatewaySecretName(namespace, domain, certs[0].SecretName)}, copyNames)
+			} else {
+				testutil.AssertEqual(t, "copies", []string(nil), copyNames)
+			}
+
+			if tc.tls == nil {
+				testutil.AssertEqual(t, "certificates", 0, len(certs))
+			} else {
+				_, err = dynamicClient.
+					Resource(resources.CertificateGVR).
+					Namespace(namespace).
+					Get(ctx, certs[0].SecretName, metav1.GetOptions{})
+				testutil.AssertEqual(t, "certificate created", tc.wantCertificate, !apierrors.IsNotFound(err))
+			}
+
+			propagateTLS(routes[0], certs, secrets)
+			if tc.tls == nil {
+				testutil.AssertEqual(t, "status.tls", (*v1alpha1.CertificateStatus)(nil), routes[0].Status.TLS)
+				return
+			}
+			testutil.AssertEqual(t, "status.tls.host", "app."+domain, routes[0].Status.TLS.Host)
+			testutil.AssertEqual(t, "status.tls.notAfter set", tc.wantNotAfter, routes[0].Status.TLS.NotAfter != nil)
+		})
+	}
+}
@@ -17,6 +17,7 @@ package servicebroker
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
@@ -151,6 +152,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 		return err
 	}
 
+	// Requeue the broker so the catalog is periodically refreshed.
+	if delay, ok := toReconcile.Status.CatalogRefreshDelay(&toReconcile.Spec.CommonServiceBrokerSpec, time.Now()); ok {
+		return controller.NewRequeueAfter(delay)
+	}
+
 	return nil
 }
 
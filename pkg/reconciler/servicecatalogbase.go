@@ -63,7 +63,7 @@ func (scb *ServiceCatalogBase) GetInstanceForBinding(
 ) (*v1alpha1.ServiceInstance, error) {
 	instanceName := binding.Spec.InstanceRef.Name
 	return scb.KfServiceInstanceLister.
-		ServiceInstances(binding.Namespace).
+		ServiceInstances(binding.InstanceNamespace()).
 		Get(instanceName)
 }
 
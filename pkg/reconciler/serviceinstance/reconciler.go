@@ -299,15 +299,31 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, serviceinstance *v1alpha1
 
 	case serviceinstance.IsKfBrokered():
 		condition := serviceinstance.Status.BackingResourceCondition()
+
+		recordAcceptedPlan(serviceinstance)
+
+		// Updates are deferred until the instance has no operations in progress.
+		updatePending := serviceinstance.Status.UpdateRequests < serviceinstance.Spec.UpdateRequests
+		updateRequested := updatePending && serviceinstance.Status.OSBStatus.IsUpdatable()
+		if updatePending && !updateRequested {
+			serviceinstance.Status.MarkUpdatePending()
+		}
+
 		// If the instance has already been actuated, don't try again.
-		if !condition.IsPending() {
+		if !condition.IsPending() && !updateRequested {
 			break
 		}
 
 		// If the resource isn't making progress, terminate it:
-		if timeoutErr := condition.ErrorIfTimeout(time.Duration(serviceinstance.Spec.OSB.ProgressDeadlineSeconds) * time.Second); timeoutErr != nil {
-			serviceinstance.Status.PropagateProvisionStatus(nil, timeoutErr)
-			break
+		if condition.IsPending() {
+			if timeoutErr := condition.ErrorIfTimeout(time.Duration(serviceinstance.Spec.OSB.ProgressDeadlineSeconds) * time.Second); timeoutErr != nil {
+				if serviceinstance.Status.OSBStatus.Updating != nil {
+					serviceinstance.Status.PropagateUpdateStatus(nil, timeoutErr)
+				} else {
+					serviceinstance.Status.PropagateProvisionStatus(nil, timeoutErr)
+				}
+				break
+			}
 		}
 
 		osbClient, err := r.GetClientForServiceInstance(serviceinstance)
@@ -336,11 +352,59 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, serviceinstance *v1alpha1
 			serviceinstance.Status.PropagateProvisionStatus(response, err)
 		}
 
+		if updateRequested {
+			broker, err := r.GetBrokerForInstance(serviceinstance)
+			if err != nil {
+				return condition.MarkReconciliationError("GettingBroker", err)
+			}
+
+			if err := resources.CheckOSBPlanUpdatable(serviceinstance, broker.GetServiceOfferings()); err != nil {
+				serviceinstance.Status.PropagateUpdateStatus(nil, err)
+				serviceinstance.Status.UpdateRequests = serviceinstance.Spec.UpdateRequests
+				break
+			}
+
+			namespace, err := r.NamespaceLister.Get(serviceinstance.Namespace)
+			if err != nil {
+				return condition.MarkReconciliationError("GettingNamespace", err)
+			}
+
+			// The CLI writes the update's parameters to the Secret before
+			// requesting the update, read it directly in case the informer
+			// cache hasn't seen the write yet.
+			latestParamsSecret, err := r.KubeClientSet.CoreV1().
+				Secrets(paramsSecret.Namespace).
+				Get(ctx, paramsSecret.Name, metav1.GetOptions{})
+			if err != nil {
+				return condition.MarkReconciliationError("GettingParamsSecret", err)
+			}
+
+			request, err := resources.MakeOSBUpdateRequest(serviceinstance, namespace, latestParamsSecret)
+			if err != nil {
+				return condition.MarkTemplateError(err)
+			}
+
+			response, err := osbClient.UpdateInstance(request)
+			if reconcilerutil.IsConflictOSBError(err) {
+				return err
+			}
+			serviceinstance.Status.PropagateUpdateStatus(response, err)
+			serviceinstance.Status.UpdateRequests = serviceinstance.Spec.UpdateRequests
+		}
+
 		if state := serviceinstance.Status.OSBStatus.Provisioning; state != nil {
 			request := resources.MakeOSBLastOperationRequest(serviceinstance, state.OperationKey)
 			response, err := osbClient.PollLastOperation(request)
 			serviceinstance.Status.PropagateProvisionAsyncStatus(response, err)
 		}
+
+		if state := serviceinstance.Status.OSBStatus.Updating; state != nil {
+			request := resources.MakeOSBLastOperationRequest(serviceinstance, state.OperationKey)
+			response, err := osbClient.PollLastOperation(request)
+			serviceinstance.Status.PropagateUpdateAsyncStatus(response, err)
+		}
+
+		recordAcceptedPlan(serviceinstance)
 	case serviceinstance.IsVolume():
 		// Reconcile Volume
 		{
@@ -474,9 +538,11 @@ func (r *Reconciler) deleteService(ctx context.Context, serviceInstance *v1alpha
 		retryDelete := (serviceInstance.Status.DeleteRequests < serviceInstance.Spec.DeleteRequests)
 
 		// Don't try deprovisioning if it's already failed, or if the resource is
-		// still provisioning, unless another delete command has been issued.
+		// still provisioning or updating, unless another delete command has been
+		// issued.
 		if !retryDelete && (serviceInstance.Status.OSBStatus.DeprovisionFailed != nil ||
-			serviceInstance.Status.OSBStatus.Provisioning != nil) {
+			serviceInstance.Status.OSBStatus.Provisioning != nil ||
+			serviceInstance.Status.OSBStatus.Updating != nil) {
 			return false
 		}
 
@@ -499,7 +565,10 @@ func (r *Reconciler) deleteService(ctx context.Context, serviceInstance *v1alpha
 		}
 
 		// If the service is currently provisioned or another delete command has been issued, attempt to delete it.
-		if serviceInstance.Status.OSBStatus.Provisioned != nil || retryDelete {
+		// Instances that failed to update still exist in the broker.
+		if serviceInstance.Status.OSBStatus.Provisioned != nil ||
+			serviceInstance.Status.OSBStatus.UpdateFailed != nil ||
+			retryDelete {
 			request := resources.MakeOSBDeprovisionRequest(serviceInstance)
 			response, err := osbClient.DeprovisionInstance(request)
 			serviceInstance.Status.PropagateDeprovisionStatus(response, err)
@@ -558,14 +627,25 @@ func (r *Reconciler) updateStatus(ctx context.Context, desired *v1alpha1.Service
 }
 
 func (r *Reconciler) serviceBindingExistsForServiceInstance(serviceinstance *v1alpha1.ServiceInstance) (bool, error) {
-	bindings, err := r.KfServiceInstanceBindingLister.ServiceInstanceBindings(serviceinstance.Namespace).List(labels.Everything())
+	// Bindings may be in other Spaces if the instance is shared.
+	bindings, err := r.KfServiceInstanceBindingLister.List(labels.Everything())
 	if err != nil {
 		return false, err
 	}
 	for _, binding := range bindings {
-		if binding.Spec.InstanceRef.Name == serviceinstance.Name {
+		if binding.IsBindingFor(serviceinstance) {
 			return true, nil
 		}
 	}
 	return false, nil
 }
+
+// recordAcceptedPlan records the plan the service broker has accepted once
+// there are no pending updates. Future updates use it to tell whether the plan
+// is changing, and bindings and deprovisioning use it as the instance's plan.
+func recordAcceptedPlan(serviceinstance *v1alpha1.ServiceInstance) {
+	if serviceinstance.Status.OSBStatus.Provisioned != nil &&
+		serviceinstance.Status.UpdateRequests == serviceinstance.Spec.UpdateRequests {
+		serviceinstance.Status.PlanUID = serviceinstance.Spec.OSB.PlanUID
+	}
+}
@@ -17,6 +17,7 @@ package resources
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"encoding/json"
 
@@ -32,7 +33,7 @@ func MakeOSBDeprovisionRequest(serviceInstance *v1alpha1.ServiceInstance) *osbcl
 		InstanceID:        fmt.Sprintf("%s", serviceInstance.UID),
 		AcceptsIncomplete: true,
 		ServiceID:         serviceInstance.Spec.OSB.ClassUID,
-		PlanID:            serviceInstance.Spec.OSB.PlanUID,
+		PlanID:            serviceInstance.AcceptedPlanUID(),
 
 		// Don't send OriginatingIdentity to the broker which may include
 		// PII (user's GAIA ID, or Project ID).
@@ -92,6 +93,92 @@ func MakeOSBProvisionRequest(
 	}, nil
 }
 
+// MakeOSBUpdateRequest creates a request to update the plan and parameters of
+// an OSB resource.
+func MakeOSBUpdateRequest(
+	serviceInstance *v1alpha1.ServiceInstance,
+	namespace *corev1.Namespace,
+	paramsSecret *corev1.Secret,
+) (*osbclient.UpdateInstanceRequest, error) {
+	if serviceInstance == nil || namespace == nil || paramsSecret == nil {
+		return nil, errors.New("ServiceInstance, Namespace, and Secret are all required")
+	}
+
+	// Update parameters are only sent with the update they were written for,
+	// otherwise the update only changes the plan.
+	var params map[string]interface{}
+	updateRequests := paramsSecret.Annotations[v1alpha1.ServiceInstanceUpdateRequestsAnnotation]
+	if updateRequests == strconv.Itoa(serviceInstance.Spec.UpdateRequests) {
+		paramsJSON, ok := paramsSecret.Data[v1alpha1.ServiceInstanceUpdateParamsSecretKey]
+		if !ok {
+			return nil, fmt.Errorf("Secret was missing key %q", v1alpha1.ServiceInstanceUpdateParamsSecretKey)
+		}
+
+		if err := json.Unmarshal(paramsJSON, &params); err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal update params from Secret: %s", err.Error())
+		}
+	}
+
+	request := &osbclient.UpdateInstanceRequest{
+		InstanceID:        fmt.Sprintf("%s", serviceInstance.UID),
+		AcceptsIncomplete: true,
+		ServiceID:         serviceInstance.Spec.OSB.ClassUID,
+		Parameters:        params,
+		Context:           CreateOSBContext(serviceInstance, namespace),
+
+		// Don't send OriginatingIdentity to the broker which may include
+		// PII (user's GAIA ID, or Project ID).
+	}
+
+	// Only include the plan if it's changing, OSB uses a blank plan to
+	// indicate the plan isn't being updated.
+	previousPlanUID := serviceInstance.Status.PlanUID
+	if previousPlanUID != serviceInstance.Spec.OSB.PlanUID {
+		request.PlanID = ptr.String(serviceInstance.Spec.OSB.PlanUID)
+	}
+
+	if previousPlanUID != "" {
+		request.PreviousValues = &osbclient.PreviousValues{
+			PlanID: previousPlanUID,
+		}
+	}
+
+	return request, nil
+}
+
+// CheckOSBPlanUpdatable returns an error if the ServiceInstance is changing
+// plans but the broker's catalog doesn't allow the current plan to be
+// updated.
+func CheckOSBPlanUpdatable(
+	serviceInstance *v1alpha1.ServiceInstance,
+	offerings []v1alpha1.ServiceOffering,
+) error {
+	previousPlanUID := serviceInstance.Status.PlanUID
+	if previousPlanUID == "" || previousPlanUID == serviceInstance.Spec.OSB.PlanUID {
+		return nil
+	}
+
+	for _, offering := range offerings {
+		if offering.UID != serviceInstance.Spec.OSB.ClassUID {
+			continue
+		}
+
+		for _, plan := range offering.Plans {
+			if plan.UID != previousPlanUID {
+				continue
+			}
+
+			if !plan.PlanUpdatable {
+				return fmt.Errorf("the broker doesn't allow instances of plan %q to change plans", plan.DisplayName)
+			}
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("couldn't find plan %q for service %q in the broker's catalog", previousPlanUID, serviceInstance.Spec.OSB.ClassUID)
+}
+
 // CreateOSBContext creates a context object for OSB requests.
 //
 // https://github.com/openservicebrokerapi/servicebroker/blob/master/profile.md#context-object
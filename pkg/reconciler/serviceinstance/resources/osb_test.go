@@ -15,6 +15,7 @@
 package resources
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
@@ -45,6 +46,13 @@ func TestMakeOSBDeprovisionRequest(t *testing.T) {
 		"good": {
 			serviceInstance: fakeServiceInstance(),
 		},
+		"plan change not accepted": {
+			serviceInstance: (func() *v1alpha1.ServiceInstance {
+				instance := fakeServiceInstance()
+				instance.Status.PlanUID = "old-plan-uid"
+				return instance
+			})(),
+		},
 	}
 
 	for tn, tc := range cases {
@@ -173,3 +181,148 @@ func TestMakeOSBProvisionRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeOSBUpdateRequest(t *testing.T) {
+	t.Parallel()
+
+	goodNamespace := &corev1.Namespace{}
+	goodNamespace.Name = "some-ns"
+	goodNamespace.UID = "11111111-1111-1111-1111-111111111111"
+
+	makeSecret := func(updateRequests string, updateParams string) *corev1.Secret {
+		secret := &corev1.Secret{}
+		secret.Annotations = map[string]string{
+			v1alpha1.ServiceInstanceUpdateRequestsAnnotation: updateRequests,
+		}
+		secret.Data = map[string][]byte{
+			v1alpha1.ServiceInstanceParamsSecretKey:       []byte(`{"foo":"bar","ram_gb":8}`),
+			v1alpha1.ServiceInstanceUpdateParamsSecretKey: []byte(updateParams),
+		}
+		return secret
+	}
+	goodSecret := makeSecret("1", `{"ram_gb":8}`)
+
+	planChangedInstance := fakeServiceInstance()
+	planChangedInstance.Spec.UpdateRequests = 1
+	planChangedInstance.Status.PlanUID = "old-plan-uid"
+
+	planUnchangedInstance := fakeServiceInstance()
+	planUnchangedInstance.Spec.UpdateRequests = 1
+	planUnchangedInstance.Status.PlanUID = "plan-uid"
+
+	cases := map[string]struct {
+		serviceInstance *v1alpha1.ServiceInstance
+		namespace       *corev1.Namespace
+		paramsSecret    *corev1.Secret
+
+		// NOTE: check for the invariant rather than specific error strings.
+		wantErr bool
+	}{
+		"missing serviceInstance": {
+			serviceInstance: nil,
+			namespace:       goodNamespace,
+			paramsSecret:    goodSecret,
+			wantErr:         true,
+		},
+		"missing secret": {
+			serviceInstance: fakeServiceInstance(),
+			namespace:       goodNamespace,
+			paramsSecret:    nil,
+			wantErr:         true,
+		},
+		"bad json secret": {
+			serviceInstance: planUnchangedInstance,
+			namespace:       goodNamespace,
+			paramsSecret:    makeSecret("1", `{[]}`),
+			wantErr:         true,
+		},
+		"params from another update": {
+			serviceInstance: planChangedInstance,
+			namespace:       goodNamespace,
+			paramsSecret:    makeSecret("0", `{"ram_gb":4}`),
+		},
+		"plan changed": {
+			serviceInstance: planChangedInstance,
+			namespace:       goodNamespace,
+			paramsSecret:    goodSecret,
+		},
+		"plan unchanged": {
+			serviceInstance: planUnchangedInstance,
+			namespace:       goodNamespace,
+			paramsSecret:    goodSecret,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			req, err := MakeOSBUpdateRequest(
+				tc.serviceInstance,
+				tc.namespace,
+				tc.paramsSecret,
+			)
+
+			if err != nil {
+				// either request or err is nil
+				testutil.AssertEqual(t, "request", (*osbclient.UpdateInstanceRequest)(nil), req)
+				testutil.AssertTrue(t, "wantErr", tc.wantErr)
+			} else {
+				testutil.AssertNotNil(t, "request", req) // either request or err is nil
+				testutil.AssertGoldenJSONContext(t, "OSBUpdateRequest", req, map[string]interface{}{
+					"serviceInstance": tc.serviceInstance,
+					"namespace":       tc.namespace,
+					"paramsSecret":    tc.paramsSecret,
+				})
+			}
+		})
+	}
+}
+
+func TestCheckOSBPlanUpdatable(t *testing.T) {
+	t.Parallel()
+
+	offerings := []v1alpha1.ServiceOffering{
+		{
+			UID: "class-uid",
+			Plans: []v1alpha1.ServicePlan{
+				{DisplayName: "updatable", UID: "updatable-uid", PlanUpdatable: true},
+				{DisplayName: "fixed", UID: "fixed-uid"},
+			},
+		},
+	}
+
+	withPreviousPlan := func(planUID string) *v1alpha1.ServiceInstance {
+		instance := fakeServiceInstance()
+		instance.Status.PlanUID = planUID
+		return instance
+	}
+
+	cases := map[string]struct {
+		serviceInstance *v1alpha1.ServiceInstance
+		wantErr         error
+	}{
+		"no previous plan": {
+			serviceInstance: withPreviousPlan(""),
+		},
+		"plan unchanged": {
+			serviceInstance: withPreviousPlan("plan-uid"),
+		},
+		"plan updatable": {
+			serviceInstance: withPreviousPlan("updatable-uid"),
+		},
+		"plan not updatable": {
+			serviceInstance: withPreviousPlan("fixed-uid"),
+			wantErr:         errors.New(`the broker doesn't allow instances of plan "fixed" to change plans`),
+		},
+		"plan missing": {
+			serviceInstance: withPreviousPlan("missing-uid"),
+			wantErr:         errors.New(`couldn't find plan "missing-uid" for service "class-uid" in the broker's catalog`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			err := CheckOSBPlanUpdatable(tc.serviceInstance, offerings)
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+		})
+	}
+}
@@ -103,15 +103,14 @@ func enqueueBindingsForService(
 			return nil
 		}
 
-		bindings, err := serviceBindingLister.
-			ServiceInstanceBindings(service.Namespace).
-			List(labels.Everything())
+		// Bindings may be in other Spaces if the instance is shared.
+		bindings, err := serviceBindingLister.List(labels.Everything())
 		if err != nil {
 			return fmt.Errorf("failed to list bindings: %s", err)
 		}
 
 		for _, binding := range bindings {
-			if binding.Spec.InstanceRef.Name == service.Name {
+			if binding.IsBindingFor(service) {
 				enqueue(binding)
 			}
 		}
@@ -136,21 +135,20 @@ func enqueueServiceInstanceSecrets(
 			return nil
 		}
 
-		bindings, err := serviceBindingLister.
-			ServiceInstanceBindings(secret.GetNamespace()).
-			List(labels.Everything())
+		// Bindings may be in other Spaces if the instance is shared.
+		bindings, err := serviceBindingLister.List(labels.Everything())
 		if err != nil {
-			return fmt.Errorf("failed to get ServiceInstance Bindings (%s): %v", secret.GetNamespace(), err)
+			return fmt.Errorf("failed to get ServiceInstance Bindings: %v", err)
 		}
 
 		for _, binding := range bindings {
-			if binding.Spec.InstanceRef.Name != serviceInstance.Name {
+			if binding.Spec.InstanceRef.Name != serviceInstance.Name ||
+				binding.InstanceNamespace() != secret.GetNamespace() {
 				continue
 			}
 
-			// Found the corresponding Binding for the Service Instance.
+			// Found a corresponding Binding for the Service Instance.
 			enqueue(binding)
-			return nil
 		}
 
 		return nil
@@ -200,6 +200,30 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, binding *v1alpha1.Service
 		return condition.MarkReconciliationError("getting service instance", err)
 	}
 
+	// Bindings to instances in other Spaces are only allowed if the instance
+	// is shared with the binding's Space.
+	if binding.IsSharedInstanceBinding() {
+		if !serviceInstance.IsSharedWith(binding.Namespace) {
+			condition.MarkFalse(
+				"NotShared",
+				"ServiceInstance %q in Space %q isn't shared with Space %q",
+				serviceInstance.Name, serviceInstance.Namespace, binding.Namespace)
+			return nil
+		}
+
+		if serviceInstance.IsKfBrokered() {
+			broker, err := r.GetBrokerForInstance(serviceInstance)
+			if err != nil {
+				return condition.MarkReconciliationError("getting service broker", err)
+			}
+
+			if err := resources.CheckOSBShareable(serviceInstance, broker.GetServiceOfferings()); err != nil {
+				condition.MarkFalse("NotShareable", err.Error())
+				return nil
+			}
+		}
+	}
+
 	// If the service instance is not ready, do not continue with the binding.
 	binding.Status.PropagateServiceInstanceStatus(serviceInstance)
 	if condition.IsPending() {
@@ -38,7 +38,7 @@ func MakeOSBUnbindRequest(
 		BindingID:         fmt.Sprintf("%s", binding.UID),
 		AcceptsIncomplete: true,
 		ServiceID:         serviceInstance.Spec.OSB.ClassUID,
-		PlanID:            serviceInstance.Spec.OSB.PlanUID,
+		PlanID:            serviceInstance.AcceptedPlanUID(),
 
 		// Don't send OriginatingIdentity to the broker which may include
 		// PII (user's GAIA ID, or Project ID).
@@ -57,7 +57,7 @@ func MakeOSBBindingLastOperationRequest(
 		InstanceID:   fmt.Sprintf("%s", serviceInstance.UID),
 		BindingID:    fmt.Sprintf("%s", binding.UID),
 		ServiceID:    ptr.String(serviceInstance.Spec.OSB.ClassUID),
-		PlanID:       ptr.String(serviceInstance.Spec.OSB.PlanUID),
+		PlanID:       ptr.String(serviceInstance.AcceptedPlanUID()),
 		OperationKey: (*osbclient.OperationKey)(operationKey),
 
 		// Don't send OriginatingIdentity to the broker which may include
@@ -94,7 +94,7 @@ func MakeOSBBindRequest(
 		BindingID:         fmt.Sprintf("%s", binding.UID),
 		AcceptsIncomplete: true,
 		ServiceID:         serviceInstance.Spec.OSB.ClassUID,
-		PlanID:            serviceInstance.Spec.OSB.PlanUID,
+		PlanID:            serviceInstance.AcceptedPlanUID(),
 		Parameters:        params,
 		Context:           serviceinstanceresources.CreateOSBContext(serviceInstance, namespace),
 
@@ -115,3 +115,24 @@ func MakeOSBGetBindingRequest(
 		BindingID:  fmt.Sprintf("%s", binding.UID),
 	}
 }
+
+// CheckOSBShareable returns an error if the broker's catalog doesn't allow
+// the ServiceInstance to be shared with other Spaces.
+func CheckOSBShareable(
+	serviceInstance *v1alpha1.ServiceInstance,
+	offerings []v1alpha1.ServiceOffering,
+) error {
+	for _, offering := range offerings {
+		if offering.UID != serviceInstance.Spec.OSB.ClassUID {
+			continue
+		}
+
+		if !offering.Shareable {
+			return fmt.Errorf("the broker doesn't allow instances of service %q to be shared", offering.DisplayName)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("couldn't find service %q in the broker's catalog", serviceInstance.Spec.OSB.ClassUID)
+}
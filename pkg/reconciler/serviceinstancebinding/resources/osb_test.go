@@ -15,6 +15,7 @@
 package resources
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
@@ -178,6 +179,16 @@ func TestMakeOSBBindRequest(t *testing.T) {
 			namespace:       goodNamespace,
 			paramsSecret:    goodSecret,
 		},
+		"plan change not accepted": {
+			serviceInstance: (func() *v1alpha1.ServiceInstance {
+				instance := fakeServiceInstance()
+				instance.Status.PlanUID = "old-plan-uid"
+				return instance
+			})(),
+			binding:      fakeServiceInstanceBinding(),
+			namespace:    goodNamespace,
+			paramsSecret: goodSecret,
+		},
 	}
 
 	for tn, tc := range cases {
@@ -230,3 +241,42 @@ func TestMakeOSBGetBindingRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckOSBShareable(t *testing.T) {
+	t.Parallel()
+
+	withClass := func(classUID string) *v1alpha1.ServiceInstance {
+		instance := fakeServiceInstance()
+		instance.Spec.OSB.ClassUID = classUID
+		return instance
+	}
+
+	offerings := []v1alpha1.ServiceOffering{
+		{DisplayName: "shared", UID: "shared-uid", Shareable: true},
+		{DisplayName: "unshared", UID: "unshared-uid"},
+	}
+
+	cases := map[string]struct {
+		serviceInstance *v1alpha1.ServiceInstance
+		wantErr         error
+	}{
+		"shareable": {
+			serviceInstance: withClass("shared-uid"),
+		},
+		"not shareable": {
+			serviceInstance: withClass("unshared-uid"),
+			wantErr:         errors.New(`the broker doesn't allow instances of service "unshared" to be shared`),
+		},
+		"service missing": {
+			serviceInstance: withClass("missing-uid"),
+			wantErr:         errors.New(`couldn't find service "missing-uid" in the broker's catalog`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			err := CheckOSBShareable(tc.serviceInstance, offerings)
+			testutil.AssertErrorsEqual(t, tc.wantErr, err)
+		})
+	}
+}
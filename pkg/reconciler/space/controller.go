@@ -22,22 +22,34 @@ import (
 	kfconfig "github.com/google/kf/v2/pkg/apis/kf/config"
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/apis/networking"
+	appinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/app"
+	orginformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/org"
+	routeinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/route"
+	securitygroupinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/securitygroup"
+	serviceinstanceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/serviceinstance"
 	spaceinformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/space"
+	spacequotainformer "github.com/google/kf/v2/pkg/client/kf/injection/informers/kf/v1alpha1/spacequota"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	networkpolicyinformer "github.com/google/kf/v2/pkg/client/kube/injection/informers/networking/v1/networkpolicy"
+	networkingclient "github.com/google/kf/v2/pkg/client/networking/injection/client"
+	envoyfilterinformer "github.com/google/kf/v2/pkg/client/networking/injection/informers/networking/v1alpha3/envoyfilter"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/build/config"
 	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
+	"github.com/google/kf/v2/pkg/reconciler/route"
 	"github.com/google/kf/v2/pkg/system"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/tools/cache"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	resourcequotainformer "knative.dev/pkg/client/injection/kube/informers/core/v1/resourcequota"
 	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	serviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount"
 	clusterroleinformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/clusterrole"
@@ -47,6 +59,7 @@ import (
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/pkg/kmeta"
 )
 
 const (
@@ -69,6 +82,14 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	clusterRoleInformer := clusterroleinformer.Get(ctx)
 	clusterRoleBindingInformer := clusterrolebindinginformer.Get(ctx)
 	configMapInformer := configmapinformer.Get(ctx)
+	resourceQuotaInformer := resourcequotainformer.Get(ctx)
+	appInformer := appinformer.Get(ctx)
+	routeInformer := routeinformer.Get(ctx)
+	serviceInstanceInformer := serviceinstanceinformer.Get(ctx)
+	spaceQuotaInformer := spacequotainformer.Get(ctx)
+	orgInformer := orginformer.Get(ctx)
+	securityGroupInformer := securitygroupinformer.Get(ctx)
+	envoyFilterInformer := envoyfilterinformer.Get(ctx)
 
 	// Dynamic client.
 	dynamicClient := dynamicclient.Get(ctx)
@@ -99,7 +120,16 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		clusterRoleBindingLister: clusterRoleBindingInformer.Lister(),
 		gsaPolicyLister:          gsaPolicyInformer.Lister(),
 		configMapLister:          configMapInformer.Lister(),
+		resourceQuotaLister:      resourceQuotaInformer.Lister(),
+		appLister:                appInformer.Lister(),
+		routeLister:              routeInformer.Lister(),
+		serviceInstanceLister:    serviceInstanceInformer.Lister(),
+		spaceQuotaLister:         spaceQuotaInformer.Lister(),
+		orgLister:                orgInformer.Lister(),
+		securityGroupLister:      securityGroupInformer.Lister(),
+		envoyFilterLister:        envoyFilterInformer.Lister(),
 		iamClientSet:             dynamicClient.Resource(*gsaPoliciesGVR),
+		networkingClientSet:      networkingclient.Get(ctx),
 	}
 
 	impl := controller.NewContext(ctx, c, controller.ControllerOptions{WorkQueueName: "Spaces", Logger: logger})
@@ -108,6 +138,13 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	// Watch for changes in sub-resources so we can sync accordingly
 	spaceInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
+	// Spaces can only serve certificates for domains no older Space serves, so
+	// they need to be re-evaluated when the Spaces they share domains with
+	// change or are deleted.
+	spaceInformer.Informer().AddEventHandler(controller.HandleAll(
+		EnqueueSpacesSharingDomains(c.spaceLister, impl.Enqueue),
+	))
+
 	// Set up all owned resources to be triggered only based on the controller.
 	for _, informer := range []cache.SharedIndexInformer{
 		nsInformer.Informer(),
@@ -118,6 +155,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		rolebindingInformer.Informer(),
 		clusterRoleInformer.Informer(),
 		clusterRoleBindingInformer.Informer(),
+		resourceQuotaInformer.Informer(),
 	} {
 		informer.AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterControllerGVK(v1alpha1.SchemeGroupVersion.WithKind("Space")),
@@ -125,6 +163,92 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		})
 	}
 
+	// Update the quota usage of a Space when the resources it counts change.
+	for _, informer := range []cache.SharedIndexInformer{
+		appInformer.Informer(),
+		routeInformer.Informer(),
+		serviceInstanceInformer.Informer(),
+	} {
+		informer.AddEventHandler(controller.HandleAll(impl.EnqueueNamespaceOf))
+	}
+
+	// Refresh the expiry of domain certificates when they're (re-)issued or
+	// renewed out of band.
+	c.SecretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: route.FilterTLSSecrets(),
+		Handler:    controller.HandleAll(EnqueueSpaceOfCertificate(c.spaceLister, impl.Enqueue)),
+	})
+
+	// Re-apply the limits of a SpaceQuota to every Space that references it,
+	// either directly or through its Org.
+	spaceQuotaInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		object, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil {
+			logger.Warnw("failed to get SpaceQuota", zap.Error(err))
+			return
+		}
+
+		spaces, err := c.spaceLister.List(labels.Everything())
+		if err != nil {
+			logger.Warnw("failed to list Spaces", zap.Error(err))
+			return
+		}
+
+		for _, space := range spaces {
+			var org *v1alpha1.Org
+			if space.Spec.OrgRef != nil {
+				// A missing Org is treated the same as no Org.
+				org, _ = c.orgLister.Get(space.Spec.OrgRef.Name)
+			}
+
+			if quotaRef := space.EffectiveQuotaRef(org); quotaRef != nil && quotaRef.Name == object.GetName() {
+				impl.Enqueue(space)
+			}
+		}
+	}))
+
+	// Propagate changes to an Org's roles, domains, and quota to its Spaces.
+	orgInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		object, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil {
+			logger.Warnw("failed to get Org", zap.Error(err))
+			return
+		}
+
+		spaces, err := c.spaceLister.List(labels.Everything())
+		if err != nil {
+			logger.Warnw("failed to list Spaces", zap.Error(err))
+			return
+		}
+
+		for _, space := range spaces {
+			if space.Spec.OrgRef != nil && space.Spec.OrgRef.Name == object.GetName() {
+				impl.Enqueue(space)
+			}
+		}
+	}))
+
+	// Re-render the NetworkPolicies of every Space a SecurityGroup is bound to.
+	securityGroupInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		object, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil {
+			logger.Warnw("failed to get SecurityGroup", zap.Error(err))
+			return
+		}
+
+		spaces, err := c.spaceLister.List(labels.Everything())
+		if err != nil {
+			logger.Warnw("failed to list Spaces", zap.Error(err))
+			return
+		}
+
+		for _, space := range spaces {
+			if space.Spec.NetworkConfig.BindsSecurityGroup(object.GetName()) {
+				impl.Enqueue(space)
+			}
+		}
+	}))
+
 	// Watch for any IAM policy changes in the Kf namespace.
 	gsaPolicyInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: func(obj interface{}) bool {
@@ -176,6 +300,72 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	return impl
 }
 
+// EnqueueSpaceOfCertificate enqueues the Space in the Secret's namespace if
+// one of its domains is served with the certificate in the Secret.
+func EnqueueSpaceOfCertificate(spaceLister kflisters.SpaceLister, enqueue func(interface{})) func(obj interface{}) {
+	return func(obj interface{}) {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return
+		}
+
+		space, err := spaceLister.Get(secret.Namespace)
+		if err != nil {
+			return
+		}
+
+		for _, domain := range space.Status.NetworkConfig.Domains {
+			if domain.TLS != nil && domain.TLS.CertificateSecretName(domain.Domain) == secret.Name {
+				enqueue(space)
+				return
+			}
+		}
+	}
+}
+
+// EnqueueSpacesSharingDomains enqueues the other Spaces that have one of the
+// Space's TLS domains.
+func EnqueueSpacesSharingDomains(spaceLister kflisters.SpaceLister, enqueue func(interface{})) func(obj interface{}) {
+	return func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+
+		changed, ok := obj.(*v1alpha1.Space)
+		if !ok {
+			return
+		}
+
+		tlsDomains := sets.NewString()
+		for _, domain := range changed.Status.NetworkConfig.Domains {
+			if domain.TLS != nil {
+				tlsDomains.Insert(domain.Domain)
+			}
+		}
+		if tlsDomains.Len() == 0 {
+			return
+		}
+
+		spaces, err := spaceLister.List(labels.Everything())
+		if err != nil {
+			return
+		}
+
+		for _, space := range spaces {
+			if space.Name == changed.Name {
+				continue
+			}
+
+			for _, domain := range space.Status.NetworkConfig.Domains {
+				if tlsDomains.Has(domain.Domain) {
+					enqueue(space)
+					break
+				}
+			}
+		}
+	}
+}
+
 var _ reconcilerutil.HealthChecker = &Reconciler{}
 
 // Healthy implements HealthChecker.
@@ -0,0 +1,129 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestEnqueueSpaceOfCertificate(t *testing.T) {
+	t.Parallel()
+
+	space := &v1alpha1.Space{ObjectMeta: metav1.ObjectMeta{Name: "my-space"}}
+	space.Status.NetworkConfig.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "plain.example.com"},
+		{Domain: "example.com", TLS: &v1alpha1.DomainTLS{SecretName: "my-cert"}},
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	testutil.AssertNil(t, "add err", indexer.Add(space))
+	lister := kflisters.NewSpaceLister(indexer)
+
+	secret := func(namespace, name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+
+	cases := map[string]struct {
+		obj interface{}
+
+		wantEnqueue bool
+	}{
+		"domain certificate": {
+			obj:         secret("my-space", "my-cert"),
+			wantEnqueue: true,
+		},
+		"other secret in space": {
+			obj: secret("my-space", "other-cert"),
+		},
+		"secret outside a space": {
+			obj: secret("istio-system", "my-cert"),
+		},
+		"handle non Secrets": {
+			obj: 99,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			gotEnqueue := false
+			enqueuer := func(_ interface{}) {
+				gotEnqueue = true
+			}
+
+			EnqueueSpaceOfCertificate(lister, enqueuer)(tc.obj)
+			testutil.AssertEqual(t, "object enqueued", tc.wantEnqueue, gotEnqueue)
+		})
+	}
+}
+
+func TestEnqueueSpacesSharingDomains(t *testing.T) {
+	t.Parallel()
+
+	tls := &v1alpha1.DomainTLS{IssuerName: "letsencrypt"}
+
+	makeSpace := func(name string, domains ...v1alpha1.SpaceDomain) *v1alpha1.Space {
+		space := &v1alpha1.Space{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		space.Status.NetworkConfig.Domains = domains
+		return space
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	testutil.AssertNil(t, "add err", indexer.Add(makeSpace("owner", v1alpha1.SpaceDomain{Domain: "example.com", TLS: tls})))
+	testutil.AssertNil(t, "add err", indexer.Add(makeSpace("sharing", v1alpha1.SpaceDomain{Domain: "example.com"})))
+	testutil.AssertNil(t, "add err", indexer.Add(makeSpace("unrelated", v1alpha1.SpaceDomain{Domain: "other.com"})))
+	lister := kflisters.NewSpaceLister(indexer)
+
+	owner := makeSpace("owner", v1alpha1.SpaceDomain{Domain: "example.com", TLS: tls})
+
+	cases := map[string]struct {
+		obj interface{}
+
+		wantEnqueued []string
+	}{
+		"Space with TLS domain": {
+			obj:          owner,
+			wantEnqueued: []string{"sharing"},
+		},
+		"deleted Space": {
+			obj:          cache.DeletedFinalStateUnknown{Key: "owner", Obj: owner},
+			wantEnqueued: []string{"sharing"},
+		},
+		"Space without TLS": {
+			obj: makeSpace("sharing", v1alpha1.SpaceDomain{Domain: "example.com"}),
+		},
+		"handle non Spaces": {
+			obj: 99,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var gotEnqueued []string
+			enqueuer := func(obj interface{}) {
+				gotEnqueued = append(gotEnqueued, obj.(*v1alpha1.Space).Name)
+			}
+
+			EnqueueSpacesSharingDomains(lister, enqueuer)(tc.obj)
+			testutil.AssertEqual(t, "enqueued", tc.wantEnqueued, gotEnqueued)
+		})
+	}
+}
@@ -25,9 +25,13 @@ import (
 	"github.com/google/kf/v2/pkg/apis/networking"
 	kflisters "github.com/google/kf/v2/pkg/client/kf/listers/kf/v1alpha1"
 	networkingv1listers "github.com/google/kf/v2/pkg/client/kube/listers/networking/v1"
+	networkingclientset "github.com/google/kf/v2/pkg/client/networking/clientset/versioned"
+	networkinglisters "github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3"
+	"github.com/google/kf/v2/pkg/internal/certutil"
 	"github.com/google/kf/v2/pkg/reconciler"
 	"github.com/google/kf/v2/pkg/reconciler/build/config"
 	"github.com/google/kf/v2/pkg/reconciler/reconcilerutil"
+	routeresources "github.com/google/kf/v2/pkg/reconciler/route/resources"
 	"github.com/google/kf/v2/pkg/reconciler/space/resources"
 	"github.com/google/kf/v2/pkg/system"
 	"go.uber.org/zap"
@@ -39,9 +43,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/dynamic"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	v1listers "k8s.io/client-go/listers/core/v1"
@@ -79,8 +85,17 @@ type Reconciler struct {
 	clusterRoleBindingLister rbacv1listers.ClusterRoleBindingLister
 	gsaPolicyLister          cache.GenericLister
 	configMapLister          v1listers.ConfigMapLister
-
-	iamClientSet dynamic.NamespaceableResourceInterface
+	resourceQuotaLister      v1listers.ResourceQuotaLister
+	appLister                kflisters.AppLister
+	routeLister              kflisters.RouteLister
+	serviceInstanceLister    kflisters.ServiceInstanceLister
+	spaceQuotaLister         kflisters.SpaceQuotaLister
+	orgLister                kflisters.OrgLister
+	securityGroupLister      kflisters.SecurityGroupLister
+	envoyFilterLister        networkinglisters.EnvoyFilterLister
+
+	iamClientSet        dynamic.NamespaceableResourceInterface
+	networkingClientSet networkingclientset.Interface
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -108,6 +123,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 	case apierrs.IsNotFound(err):
 		logger.Info("resource no longer exists")
 
+		if err := r.deleteIngressResources(ctx, name); err != nil {
+			logger.Warnw("failed to delete ingress resources", zap.Error(err))
+			return err
+		}
+
 		// We always have to update the IAM policies to ensure the proper list
 		// of Spaces are configured by the policy.
 		if err := r.updateGSAPolicies(ctx, original); err != nil {
@@ -128,6 +148,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 			return err
 		}
 
+		if err := r.deleteIngressResources(ctx, name); err != nil {
+			logger.Warnw("failed to delete ingress resources", zap.Error(err))
+			return err
+		}
+
 		// We always have to update the IAM policies to ensure the proper list
 		// of Spaces are configured by the policy.
 		if err := r.updateGSAPolicies(ctx, original); err != nil {
@@ -209,9 +234,33 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 		space.Status.PropagateRuntimeConfigStatus(space.Spec.RuntimeConfig, kfconfig.FromContext(ctx))
 	}
 
+	// A missing Org is ignored so the Space isn't left unreconciled after the
+	// Org is deleted; the Space falls back to its own settings.
+	org, err := r.getOrg(ctx, space)
+	if err != nil {
+		return err
+	}
+
 	{
 		logger.Debug("updating network config")
-		space.Status.PropagateNetworkConfigStatus(space.Spec.NetworkConfig, kfconfig.FromContext(ctx), space.Name)
+		space.Status.PropagateNetworkConfigStatus(space.EffectiveNetworkConfig(org), kfconfig.FromContext(ctx), space.Name)
+
+		// Domains shared by Spaces are served by the same ingress gateway so
+		// only one of them can serve the domain's certificate.
+		spaces, err := r.spaceLister.List(labels.Everything())
+		if err != nil {
+			return err
+		}
+		for _, domain := range releaseClaimedDomainTLS(space, spaces) {
+			logger.Warnf("TLS for domain %q is served by another Space, ignoring it", domain)
+		}
+	}
+
+	// Report the expiry of domain certificates, they're issued by the Route
+	// reconciler so only the status is updated here.
+	{
+		logger.Debug("updating domain certificates")
+		space.Status.PropagateDomainCertificates(r.domainCertificates(namespaceName, space.Status.NetworkConfig.Domains))
 	}
 
 	{
@@ -429,7 +478,12 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 		logger.Debug("reconciling app NetworkPolicy")
 		condition := space.Status.AppNetworkPolicyCondition()
 
-		desired, err := resources.MakeAppNetworkPolicy(space)
+		groups, err := r.securityGroups(ctx, space.Spec.NetworkConfig.AppSecurityGroups)
+		if err != nil {
+			return condition.MarkReconciliationError("getting SecurityGroups", err)
+		}
+
+		desired, err := resources.MakeAppNetworkPolicy(space, groups)
 		if err != nil {
 			return condition.MarkTemplateError(err)
 		}
@@ -461,7 +515,12 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 		logger.Debug("reconciling build NetworkPolicy")
 		condition := space.Status.BuildNetworkPolicyCondition()
 
-		desired, err := resources.MakeBuildNetworkPolicy(space)
+		groups, err := r.securityGroups(ctx, space.Spec.NetworkConfig.BuildSecurityGroups)
+		if err != nil {
+			return condition.MarkReconciliationError("getting SecurityGroups", err)
+		}
+
+		desired, err := resources.MakeBuildNetworkPolicy(space, groups)
 		if err != nil {
 			return condition.MarkTemplateError(err)
 		}
@@ -489,6 +548,27 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 		condition.MarkSuccess()
 	}
 
+	{
+		logger.Debug("reconciling quota")
+		condition := space.Status.QuotaCondition()
+
+		quota, err := r.effectiveQuota(ctx, space, org)
+		if err != nil {
+			return condition.MarkReconciliationError("getting SpaceQuota", err)
+		}
+
+		if err := r.reconcileResourceQuota(ctx, space, quota); err != nil {
+			return condition.MarkReconciliationError("synchronizing ResourceQuota", err)
+		}
+
+		used, err := r.quotaUsage(namespaceName)
+		if err != nil {
+			return condition.MarkReconciliationError("computing usage", err)
+		}
+
+		space.Status.PropagateQuotaStatus(quota, used)
+	}
+
 	{
 		logger.Debug("reconciling RoleBindings")
 		condition := space.Status.RoleBindingsCondition()
@@ -516,6 +596,29 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 			// exists so users can manage it.
 		}
 
+		// Bindings for the Org's roles are owned by Kf and kept in sync with the
+		// Org.
+		for _, desired := range resources.MakeOrgRoleBindings(space, org) {
+			actual, err := r.roleBindingLister.
+				RoleBindings(desired.Namespace).
+				Get(desired.Name)
+			if errors.IsNotFound(err) {
+				_, err = r.KubeClientSet.
+					RbacV1().
+					RoleBindings(desired.Namespace).
+					Create(ctx, desired, metav1.CreateOptions{})
+				if err != nil {
+					return condition.MarkReconciliationError("creating", err)
+				}
+			} else if err != nil {
+				return condition.MarkReconciliationError("getting latest", err)
+			} else if !metav1.IsControlledBy(actual, space) {
+				return condition.MarkChildNotOwned(desired.Name)
+			} else if _, err = r.ReconcileRoleBinding(ctx, desired, actual); err != nil {
+				return condition.MarkReconciliationError("updating existing", err)
+			}
+		}
+
 		condition.MarkSuccess()
 	}
 
@@ -720,6 +823,148 @@ func (r *Reconciler) reconcileClusterRole(ctx context.Context, desired, actual *
 	return r.KubeClientSet.RbacV1().ClusterRoles().Update(ctx, existing, metav1.UpdateOptions{})
 }
 
+// getOrg returns the Org the Space belongs to, or nil if the Space doesn't
+// reference one or the Org doesn't exist.
+func (r *Reconciler) getOrg(ctx context.Context, space *v1alpha1.Space) (*v1alpha1.Org, error) {
+	if space.Spec.OrgRef == nil {
+		return nil, nil
+	}
+
+	org, err := r.orgLister.Get(space.Spec.OrgRef.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		logging.FromContext(ctx).Warnf("Org %q not found, ignoring", space.Spec.OrgRef.Name)
+		return nil, nil
+	case err != nil:
+		return nil, err
+	default:
+		return org, nil
+	}
+}
+
+// securityGroups returns the SecurityGroups with the given names. Missing
+// SecurityGroups are ignored so the Space isn't left unreconciled after one is
+// deleted.
+func (r *Reconciler) securityGroups(ctx context.Context, refs []corev1.LocalObjectReference) ([]*v1alpha1.SecurityGroup, error) {
+	var out []*v1alpha1.SecurityGroup
+	for _, ref := range refs {
+		group, err := r.securityGroupLister.Get(ref.Name)
+		switch {
+		case apierrs.IsNotFound(err):
+			logging.FromContext(ctx).Warnf("SecurityGroup %q not found, ignoring", ref.Name)
+		case err != nil:
+			return nil, err
+		default:
+			out = append(out, group)
+		}
+	}
+
+	return out, nil
+}
+
+// effectiveQuota returns the limits that apply to the Space, combining its own
+// quota with the SpaceQuota it or its Org references. A missing SpaceQuota is
+// ignored so the Space isn't left unreconciled after the SpaceQuota is deleted.
+func (r *Reconciler) effectiveQuota(ctx context.Context, space *v1alpha1.Space, org *v1alpha1.Org) (v1alpha1.SpaceSpecQuota, error) {
+	quotaRef := space.EffectiveQuotaRef(org)
+	if quotaRef == nil {
+		return space.EffectiveQuota(nil), nil
+	}
+
+	plan, err := r.spaceQuotaLister.Get(quotaRef.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		logging.FromContext(ctx).Warnf("SpaceQuota %q not found, ignoring", quotaRef.Name)
+		return space.EffectiveQuota(nil), nil
+	case err != nil:
+		return v1alpha1.SpaceSpecQuota{}, err
+	default:
+		return space.EffectiveQuota(plan), nil
+	}
+}
+
+func (r *Reconciler) reconcileResourceQuota(ctx context.Context, space *v1alpha1.Space, quota v1alpha1.SpaceSpecQuota) error {
+	logger := logging.FromContext(ctx)
+	desired := resources.MakeResourceQuota(space, quota)
+
+	actual, err := r.resourceQuotaLister.
+		ResourceQuotas(resources.NamespaceName(space)).
+		Get(resources.ResourceQuotaName)
+	switch {
+	case apierrs.IsNotFound(err):
+		if desired == nil {
+			return nil
+		}
+
+		_, err = r.KubeClientSet.
+			CoreV1().
+			ResourceQuotas(desired.Namespace).
+			Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	case err != nil:
+		return err
+	case !metav1.IsControlledBy(actual, space):
+		return fmt.Errorf("there is an existing ResourceQuota %q that we do not own", actual.Name)
+	case desired == nil:
+		return r.KubeClientSet.
+			CoreV1().
+			ResourceQuotas(actual.Namespace).
+			Delete(ctx, actual.Name, metav1.DeleteOptions{})
+	}
+
+	// Check for differences, if none we don't need to reconcile.
+	if reconciler.NewSemanticEqualityBuilder(logger, "ResourceQuota").
+		Append("metadata.labels", desired.ObjectMeta.Labels, actual.ObjectMeta.Labels).
+		Append("spec", desired.Spec, actual.Spec).
+		IsSemanticallyEqual() {
+		return nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object.
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec = desired.Spec
+	_, err = r.KubeClientSet.CoreV1().ResourceQuotas(existing.Namespace).Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// quotaUsage counts how much of each quota resource is used by the Apps,
+// Routes, and ServiceInstances in the namespace.
+func (r *Reconciler) quotaUsage(namespace string) (corev1.ResourceList, error) {
+	used := corev1.ResourceList{
+		v1alpha1.QuotaResourceApps:             *resource.NewQuantity(0, resource.DecimalSI),
+		v1alpha1.QuotaResourceInstances:        *resource.NewQuantity(0, resource.DecimalSI),
+		v1alpha1.QuotaResourceMemory:           *resource.NewQuantity(0, resource.BinarySI),
+		v1alpha1.QuotaResourceCPU:              *resource.NewMilliQuantity(0, resource.DecimalSI),
+		v1alpha1.QuotaResourceRoutes:           *resource.NewQuantity(0, resource.DecimalSI),
+		v1alpha1.QuotaResourceServiceInstances: *resource.NewQuantity(0, resource.DecimalSI),
+	}
+
+	apps, err := r.appLister.Apps(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, app := range apps {
+		v1alpha1.AddQuotaUsage(used, v1alpha1.AppQuotaUsage(app))
+	}
+
+	routes, err := r.routeLister.Routes(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	used[v1alpha1.QuotaResourceRoutes] = *resource.NewQuantity(int64(len(routes)), resource.DecimalSI)
+
+	serviceInstances, err := r.serviceInstanceLister.ServiceInstances(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	used[v1alpha1.QuotaResourceServiceInstances] = *resource.NewQuantity(int64(len(serviceInstances)), resource.DecimalSI)
+
+	return used, nil
+}
+
 func (r *Reconciler) reconcileNetworkPolicy(ctx context.Context, desired, actual *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
 	logger := logging.FromContext(ctx)
 
@@ -843,3 +1088,107 @@ func (r *Reconciler) removeFinalizer(ctx context.Context, s *v1alpha1.Space) err
 
 	return nil
 }
+
+// deleteIngressResources deletes the copies of the Space's certificates and
+// the rate limits of its Routes from the ingress gateway namespaces. They live
+// outside the Space's namespace so they aren't garbage collected with it, and
+// the Route reconciler skips terminating namespaces.
+func (r *Reconciler) deleteIngressResources(ctx context.Context, namespace string) error {
+	logger := logging.FromContext(ctx)
+
+	secrets, err := r.SecretLister.List(routeresources.GatewaySecretSelector(namespace))
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets {
+		logger.Infow("Deleting certificate copy", zap.String("secret", secret.Namespace+"/"+secret.Name))
+		if err := r.KubeClientSet.
+			CoreV1().
+			Secrets(secret.Namespace).
+			Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	filters, err := r.envoyFilterLister.List(routeresources.RateLimitEnvoyFilterSelector(namespace))
+	if err != nil {
+		return err
+	}
+	for _, filter := range filters {
+		logger.Infow("Deleting rate limits", zap.String("envoyFilter", filter.Namespace+"/"+filter.Name))
+		if err := r.networkingClientSet.
+			NetworkingV1alpha3().
+			EnvoyFilters(filter.Namespace).
+			Delete(ctx, filter.Name, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseClaimedDomainTLS removes TLS from the Space's domains if an older
+// Space already serves a certificate for them and returns the affected
+// domains. The ingress gateway can only serve one certificate per host.
+func releaseClaimedDomainTLS(space *v1alpha1.Space, spaces []*v1alpha1.Space) []string {
+	claimed := sets.NewString()
+	for _, other := range spaces {
+		if other.Name == space.Name || !olderSpace(other, space) {
+			continue
+		}
+
+		for _, domain := range other.Status.NetworkConfig.Domains {
+			if domain.TLS != nil {
+				claimed.Insert(domain.Domain)
+			}
+		}
+	}
+
+	var released []string
+	for i, domain := range space.Status.NetworkConfig.Domains {
+		if domain.TLS != nil && claimed.Has(domain.Domain) {
+			space.Status.NetworkConfig.Domains[i].TLS = nil
+			released = append(released, domain.Domain)
+		}
+	}
+
+	return released
+}
+
+// olderSpace returns true if a was created before b, using the name to break
+// ties.
+func olderSpace(a, b *v1alpha1.Space) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+
+	return a.Name < b.Name
+}
+
+// domainCertificates returns the status of the certificates the domains are
+// served with over HTTPS.
+func (r *Reconciler) domainCertificates(namespace string, domains []v1alpha1.SpaceDomain) []v1alpha1.CertificateStatus {
+	var out []v1alpha1.CertificateStatus
+	for _, domain := range domains {
+		if domain.TLS == nil {
+			continue
+		}
+
+		cert := v1alpha1.CertificateStatus{
+			Host:       domain.Domain,
+			SecretName: domain.TLS.CertificateSecretName(domain.Domain),
+		}
+
+		// Certificates that haven't been issued yet or are invalid are
+		// reported without an expiry.
+		if secret, err := r.SecretLister.Secrets(namespace).Get(cert.SecretName); err == nil {
+			if notAfter, err := certutil.NotAfter(secret); err == nil {
+				cert.NotAfter = &metav1.Time{Time: notAfter}
+			}
+		}
+
+		out = append(out, cert)
+	}
+
+	return out
+}
@@ -0,0 +1,173 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	networking "github.com/google/kf/v2/pkg/apis/networking/v1alpha3"
+	networkingfake "github.com/google/kf/v2/pkg/client/networking/clientset/versioned/fake"
+	networkinglisters "github.com/google/kf/v2/pkg/client/networking/listers/networking/v1alpha3"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler"
+	routeresources "github.com/google/kf/v2/pkg/reconciler/route/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	v1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestReconciler_deleteIngressResources(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "deleted-space", Name: "my-cert"},
+	}
+	otherSource := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "other-space", Name: "my-cert"},
+	}
+	secretCopy := routeresources.MakeGatewaySecret(source, "example.com", "istio-system")
+	otherCopy := routeresources.MakeGatewaySecret(otherSource, "example.com", "istio-system")
+
+	rateLimits := func(namespace string) *networking.EnvoyFilter {
+		return &networking.EnvoyFilter{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      routeresources.MakeRateLimitEnvoyFilterName(namespace, "example.com"),
+				Namespace: "istio-system",
+				Labels: map[string]string{
+					v1alpha1.ManagedByLabel:                      "kf",
+					v1alpha1.ComponentLabel:                      "ratelimit",
+					routeresources.RateLimitSourceNamespaceLabel: namespace,
+				},
+			},
+		}
+	}
+	filter := rateLimits("deleted-space")
+	otherFilter := rateLimits("other-space")
+
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	testutil.AssertNil(t, "add err", secretIndexer.Add(secretCopy))
+	testutil.AssertNil(t, "add err", secretIndexer.Add(otherCopy))
+
+	envoyFilterIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	testutil.AssertNil(t, "add err", envoyFilterIndexer.Add(filter))
+	testutil.AssertNil(t, "add err", envoyFilterIndexer.Add(otherFilter))
+
+	kubeClient := kubefake.NewSimpleClientset(secretCopy, otherCopy)
+	networkingClient := networkingfake.NewSimpleClientset(filter, otherFilter)
+
+	r := &Reconciler{
+		Base: &reconciler.Base{
+			KubeClientSet: kubeClient,
+			SecretLister:  v1listers.NewSecretLister(secretIndexer),
+		},
+		envoyFilterLister:   networkinglisters.NewEnvoyFilterLister(envoyFilterIndexer),
+		networkingClientSet: networkingClient,
+	}
+
+	testutil.AssertNil(t, "err", r.deleteIngressResources(ctx, "deleted-space"))
+
+	secrets, err := kubeClient.CoreV1().Secrets("istio-system").List(ctx, metav1.ListOptions{})
+	testutil.AssertNil(t, "err", err)
+	var gotSecrets []string
+	for _, secret := range secrets.Items {
+		gotSecrets = append(gotSecrets, secret.Name)
+	}
+	testutil.AssertEqual(t, "secrets", []string{otherCopy.Name}, gotSecrets)
+
+	filters, err := networkingClient.NetworkingV1alpha3().EnvoyFilters("istio-system").List(ctx, metav1.ListOptions{})
+	testutil.AssertNil(t, "err", err)
+	var gotFilters []string
+	for i := range filters.Items {
+		gotFilters = append(gotFilters, filters.Items[i].Name)
+	}
+	testutil.AssertEqual(t, "filters", []string{otherFilter.Name}, gotFilters)
+}
+
+func TestReleaseClaimedDomainTLS(t *testing.T) {
+	t.Parallel()
+
+	tls := &v1alpha1.DomainTLS{IssuerName: "letsencrypt"}
+	older := metav1.NewTime(time.Unix(1000, 0))
+	newer := metav1.NewTime(time.Unix(2000, 0))
+
+	makeSpace := func(name string, created metav1.Time, domains ...v1alpha1.SpaceDomain) *v1alpha1.Space {
+		space := &v1alpha1.Space{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: created},
+		}
+		space.Status.NetworkConfig.Domains = domains
+		return space
+	}
+
+	cases := map[string]struct {
+		space  *v1alpha1.Space
+		others []*v1alpha1.Space
+
+		wantReleased []string
+		wantDomains  []v1alpha1.SpaceDomain
+	}{
+		"no other Spaces": {
+			space:       makeSpace("a", newer, v1alpha1.SpaceDomain{Domain: "example.com", TLS: tls}),
+			wantDomains: []v1alpha1.SpaceDomain{{Domain: "example.com", TLS: tls}},
+		},
+		"older Space serves the domain": {
+			space: makeSpace("a", newer, v1alpha1.SpaceDomain{Domain: "example.com", TLS: tls}),
+			others: []*v1alpha1.Space{
+				makeSpace("b", older, v1alpha1.SpaceDomain{Domain: "example.com", TLS: tls}),
+			},
+			wantReleased: []string{"example.com"},
+			wantDomains:  []v1alpha1.SpaceDomain{{Domain: "example.com"}},
+		},
+		"newer Space serves the domain": {
+			space: makeSpace("a", older, v1alpha1.SpaceDomain{Domain: "example.com", TLS: tls}),
+			others: []*v1alpha1.Space{
+				makeSpace("b", newer, v1alpha1.SpaceDomain{Domain: "example.com", TLS: tls}),
+			},
+			wantDomains: []v1alpha1.SpaceDomain{{Domain: "example.com", TLS: tls}},
+		},
+		"same age uses name": {
+			space: makeSpace("b", older, v1alpha1.SpaceDomain{Domain: "example.com", TLS: tls}),
+			others: []*v1alpha1.Space{
+				makeSpace("a", older, v1alpha1.SpaceDomain{Domain: "example.com", TLS: tls}),
+			},
+			wantReleased: []string{"example.com"},
+			wantDomains:  []v1alpha1.SpaceDomain{{Domain: "example.com"}},
+		},
+		"older Space without TLS": {
+			space: makeSpace("a", newer, v1alpha1.SpaceDomain{Domain: "example.com", TLS: tls}),
+			others: []*v1alpha1.Space{
+				makeSpace("b", older, v1alpha1.SpaceDomain{Domain: "example.com"}),
+			},
+			wantDomains: []v1alpha1.SpaceDomain{{Domain: "example.com", TLS: tls}},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			spaces := append([]*v1alpha1.Space{tc.space}, tc.others...)
+
+			released := releaseClaimedDomainTLS(tc.space, spaces)
+
+			testutil.AssertEqual(t, "released", tc.wantReleased, released)
+			testutil.AssertEqual(t, "domains", tc.wantDomains, tc.space.Status.NetworkConfig.Domains)
+		})
+	}
+}
@@ -19,32 +19,42 @@ import (
 	"fmt"
 
 	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
 )
 
 func networkPolicyName(policyType string) string {
 	return fmt.Sprintf("space-%s-policy", policyType)
 }
 
-// MakeAppNetworkPolicy creates a network policy targeting apps.
-func MakeAppNetworkPolicy(space *v1alpha1.Space) (*networkingv1.NetworkPolicy, error) {
+// MakeAppNetworkPolicy creates a network policy targeting apps. The rules of
+// the given SecurityGroups are added to the policy if it denies egress.
+func MakeAppNetworkPolicy(space *v1alpha1.Space, groups []*v1alpha1.SecurityGroup) (*networkingv1.NetworkPolicy, error) {
 	policyType := v1alpha1.NetworkPolicyApp
 	policy := space.Spec.NetworkConfig.AppNetworkPolicy
 
-	return makeNetworkPolicy(space, policyType, policy)
+	return makeNetworkPolicy(space, policyType, policy, groups)
 }
 
-// MakeBuildNetworkPolicy creates a network policy targeting builds.
-func MakeBuildNetworkPolicy(space *v1alpha1.Space) (*networkingv1.NetworkPolicy, error) {
+// MakeBuildNetworkPolicy creates a network policy targeting builds. The rules
+// of the given SecurityGroups are added to the policy if it denies egress.
+func MakeBuildNetworkPolicy(space *v1alpha1.Space, groups []*v1alpha1.SecurityGroup) (*networkingv1.NetworkPolicy, error) {
 	policyType := v1alpha1.NetworkPolicyBuild
 	policy := space.Spec.NetworkConfig.BuildNetworkPolicy
 
-	return makeNetworkPolicy(space, policyType, policy)
+	return makeNetworkPolicy(space, policyType, policy, groups)
 }
 
-func makeNetworkPolicy(space *v1alpha1.Space, policyLabelValue string, policy v1alpha1.SpaceSpecNetworkConfigPolicy) (*networkingv1.NetworkPolicy, error) {
+func makeNetworkPolicy(
+	space *v1alpha1.Space,
+	policyLabelValue string,
+	policy v1alpha1.SpaceSpecNetworkConfigPolicy,
+	groups []*v1alpha1.SecurityGroup,
+) (*networkingv1.NetworkPolicy, error) {
 	// Set defaults to upgrade old policies
 	policy.SetDefaults(context.Background())
 
@@ -58,6 +68,16 @@ func makeNetworkPolicy(space *v1alpha1.Space, policyLabelValue string, policy v1
 		return nil, err
 	}
 
+	// SecurityGroups only have an effect if egress is denied by default,
+	// otherwise all traffic is already allowed.
+	if policy.Egress == v1alpha1.DenyAllNetworkPolicy {
+		groupRules, err := makeSecurityGroupEgressRules(groups)
+		if err != nil {
+			return nil, err
+		}
+		egressRule = append(egressRule, groupRules...)
+	}
+
 	return &networkingv1.NetworkPolicy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      networkPolicyName(policyLabelValue),
@@ -116,3 +136,72 @@ func makeEgressRule(policy v1alpha1.SpaceSpecNetworkConfigPolicy) ([]networkingv
 		return nil, fmt.Errorf("unknown egress type: %s", pt)
 	}
 }
+
+func makeSecurityGroupEgressRules(groups []*v1alpha1.SecurityGroup) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	var out []networkingv1.NetworkPolicyEgressRule
+	for _, group := range groups {
+		for idx, rule := range group.Spec.Rules {
+			egressRule, err := makeSecurityGroupEgressRule(rule)
+			if err != nil {
+				return nil, fmt.Errorf("SecurityGroup %q rule %d: %v", group.Name, idx, err)
+			}
+			out = append(out, egressRule)
+		}
+	}
+
+	return out, nil
+}
+
+func makeSecurityGroupEgressRule(rule v1alpha1.SecurityGroupRule) (networkingv1.NetworkPolicyEgressRule, error) {
+	var out networkingv1.NetworkPolicyEgressRule
+
+	cidrs, err := rule.DestinationCIDRs()
+	if err != nil {
+		return out, err
+	}
+
+	for _, cidr := range cidrs {
+		out.To = append(out.To, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+
+	var protocol corev1.Protocol
+	switch rule.Protocol {
+	case v1alpha1.SecurityGroupProtocolAll:
+		// No ports allows every protocol and port.
+		return out, nil
+	case v1alpha1.SecurityGroupProtocolTCP:
+		protocol = corev1.ProtocolTCP
+	case v1alpha1.SecurityGroupProtocolUDP:
+		protocol = corev1.ProtocolUDP
+	default:
+		return out, fmt.Errorf("unknown protocol: %s", rule.Protocol)
+	}
+
+	portRanges, err := rule.ParsePorts()
+	if err != nil {
+		return out, err
+	}
+
+	if len(portRanges) == 0 {
+		out.Ports = []networkingv1.NetworkPolicyPort{{Protocol: &protocol}}
+		return out, nil
+	}
+
+	for _, portRange := range portRanges {
+		port := intstr.FromInt(int(portRange.Start))
+		policyPort := networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		}
+
+		if portRange.End != portRange.Start {
+			policyPort.EndPort = ptr.Int32(portRange.End)
+		}
+
+		out.Ports = append(out.Ports, policyPort)
+	}
+
+	return out, nil
+}
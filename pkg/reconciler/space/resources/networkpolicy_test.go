@@ -0,0 +1,68 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+)
+
+func TestMakeAppNetworkPolicy(t *testing.T) {
+	group := &v1alpha1.SecurityGroup{}
+	group.Name = "services"
+	group.Spec.Rules = []v1alpha1.SecurityGroupRule{
+		{Protocol: "tcp", Destination: "10.0.0.0/8", Ports: "443,8000-9000"},
+		{Protocol: "udp", Destination: "10.0.0.10"},
+		{Protocol: "all", Destination: "192.168.0.1-192.168.0.2"},
+	}
+
+	cases := map[string]struct {
+		policy v1alpha1.SpaceSpecNetworkConfigPolicy
+		groups []*v1alpha1.SecurityGroup
+	}{
+		"permit all ignores groups": {
+			policy: v1alpha1.SpaceSpecNetworkConfigPolicy{
+				Ingress: v1alpha1.PermitAllNetworkPolicy,
+				Egress:  v1alpha1.PermitAllNetworkPolicy,
+			},
+			groups: []*v1alpha1.SecurityGroup{group},
+		},
+		"deny all adds groups": {
+			policy: v1alpha1.SpaceSpecNetworkConfigPolicy{
+				Ingress: v1alpha1.PermitAllNetworkPolicy,
+				Egress:  v1alpha1.DenyAllNetworkPolicy,
+			},
+			groups: []*v1alpha1.SecurityGroup{group},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			space := &v1alpha1.Space{}
+			space.Name = "test"
+			space.Spec.NetworkConfig.AppNetworkPolicy = tc.policy
+
+			policy, err := MakeAppNetworkPolicy(space, tc.groups)
+			testutil.AssertNil(t, "err", err)
+
+			testutil.AssertGoldenJSONContext(t, "networkpolicy", policy, map[string]interface{}{
+				"policy": tc.policy,
+				"groups": tc.groups,
+			})
+		})
+	}
+}
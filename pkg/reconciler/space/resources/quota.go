@@ -0,0 +1,74 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// ResourceQuotaName is the name of the ResourceQuota that enforces the
+// Space's quota.
+const ResourceQuotaName = "space-quota"
+
+// MakeResourceQuota creates a ResourceQuota that enforces the Space's
+// effective quota on the number of Apps, Routes, and ServiceInstances. Nil is
+// returned if the quota has no such limits.
+//
+// Memory, CPU, and instance limits are only enforced by the App webhook. A
+// ResourceQuota would count every Pod in the namespace, including sidecars,
+// Builds, and the extra Pods created during rollouts, which don't count
+// towards the quota.
+func MakeResourceQuota(space *v1alpha1.Space, quota v1alpha1.SpaceSpecQuota) *corev1.ResourceQuota {
+	hard := corev1.ResourceList{}
+
+	counts := map[corev1.ResourceName]*int64{
+		objectCountResource("apps"):             quota.Apps,
+		objectCountResource("routes"):           quota.Routes,
+		objectCountResource("serviceinstances"): quota.ServiceInstances,
+	}
+	for name, count := range counts {
+		if count != nil {
+			hard[name] = *resource.NewQuantity(*count, resource.DecimalSI)
+		}
+	}
+
+	if len(hard) == 0 {
+		return nil
+	}
+
+	return &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ResourceQuotaName,
+			Namespace: NamespaceName(space),
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(space),
+			},
+			Labels: map[string]string{
+				managedByLabel: "kf",
+			},
+		},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: hard,
+		},
+	}
+}
+
+func objectCountResource(resourceName string) corev1.ResourceName {
+	return corev1.ResourceName("count/" + resourceName + "." + v1alpha1.SchemeGroupVersion.Group)
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	kfv1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/pkg/ptr"
+)
+
+func quantityPtr(value string) *resource.Quantity {
+	out := resource.MustParse(value)
+	return &out
+}
+
+func quotaSpace(quota kfv1alpha1.SpaceSpecQuota) *kfv1alpha1.Space {
+	space := &kfv1alpha1.Space{}
+	space.Name = "test"
+	space.Spec.Quota = quota
+	return space
+}
+
+func TestMakeResourceQuota(t *testing.T) {
+	cases := map[string]struct {
+		space *kfv1alpha1.Space
+	}{
+		"no quota": {
+			space: quotaSpace(kfv1alpha1.SpaceSpecQuota{}),
+		},
+		"instance limits only": {
+			space: quotaSpace(kfv1alpha1.SpaceSpecQuota{
+				InstanceMemory: quantityPtr("1Gi"),
+				Instances:      ptr.Int64(10),
+			}),
+		},
+		"all limits": {
+			space: quotaSpace(kfv1alpha1.SpaceSpecQuota{
+				Memory:           quantityPtr("10Gi"),
+				CPU:              quantityPtr("4"),
+				InstanceMemory:   quantityPtr("1Gi"),
+				Instances:        ptr.Int64(10),
+				Apps:             ptr.Int64(5),
+				Routes:           ptr.Int64(20),
+				ServiceInstances: ptr.Int64(0),
+			}),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			obj := MakeResourceQuota(tc.space, tc.space.Spec.Quota)
+
+			testutil.AssertGoldenJSONContext(t, "resourcequota", obj, map[string]interface{}{
+				"space": tc.space,
+			})
+		})
+	}
+}
@@ -79,6 +79,37 @@ func MakeRoleBindingForClusterRole(space *v1alpha1.Space, clusterRoleName RoleNa
 	}
 }
 
+// OrgRoleBindingName generates the name of the RoleBinding that grants members
+// of the Space's Org the given Role.
+func OrgRoleBindingName(space *v1alpha1.Space, role RoleName) string {
+	return v1alpha1.GenerateName(space.Name, "org", string(role))
+}
+
+// MakeOrgRoleBindings creates RoleBindings that grant the Org's managers and
+// auditors the SpaceManager and SpaceAuditor roles in the Space. Unlike the
+// Space's own RoleBindings, these are fully managed by Kf: subjects come from
+// the Org and any manual edits are overwritten. If org is nil the bindings
+// have no subjects.
+func MakeOrgRoleBindings(space *v1alpha1.Space, org *v1alpha1.Org) []*rbacv1.RoleBinding {
+	var managers, auditors []rbacv1.Subject
+	if org != nil {
+		managers = org.Spec.Managers
+		auditors = org.Spec.Auditors
+	}
+
+	return []*rbacv1.RoleBinding{
+		makeOrgRoleBinding(space, SpaceManager, managers),
+		makeOrgRoleBinding(space, SpaceAuditor, auditors),
+	}
+}
+
+func makeOrgRoleBinding(space *v1alpha1.Space, clusterRoleName RoleName, subjects []rbacv1.Subject) *rbacv1.RoleBinding {
+	binding := MakeRoleBindingForClusterRole(space, clusterRoleName)
+	binding.Name = OrgRoleBindingName(space, clusterRoleName)
+	binding.Subjects = subjects
+	return binding
+}
+
 // GetRoleBindingName finds the [space] RoleBinding name given an input role name.
 func GetRoleBindingName(role, space string) string {
 	validRoles := map[string]string{
@@ -19,6 +19,7 @@ import (
 
 	kfv1alpha1 "github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/v2/pkg/kf/testutil"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 func TestMakeRoleBindingForClusterRole(t *testing.T) {
@@ -55,6 +56,42 @@ func TestMakeRoleBindingForClusterRole(t *testing.T) {
 	}
 }
 
+func TestMakeOrgRoleBindings(t *testing.T) {
+	testSpace := &kfv1alpha1.Space{}
+	testSpace.Name = "test"
+
+	testOrg := &kfv1alpha1.Org{}
+	testOrg.Name = "my-org"
+	testOrg.Spec.Managers = []rbacv1.Subject{
+		{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "manager@example.com"},
+	}
+	testOrg.Spec.Auditors = []rbacv1.Subject{
+		{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "auditors@example.com"},
+	}
+
+	cases := map[string]struct {
+		org *kfv1alpha1.Org
+	}{
+		"with org": {
+			org: testOrg,
+		},
+		"nil org": {
+			org: nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			bindings := MakeOrgRoleBindings(testSpace, tc.org)
+
+			testutil.AssertGoldenJSONContext(t, "bindings", bindings, map[string]interface{}{
+				"space": testSpace,
+				"org":   tc.org,
+			})
+		})
+	}
+}
+
 func TestGetRoleBindingName(t *testing.T) {
 	cases := map[string]struct {
 		Role                    string
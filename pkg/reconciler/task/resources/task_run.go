@@ -103,7 +103,7 @@ func getUserContainer(
 ) (*corev1.Container, error) {
 	spec := app.Spec.Template.Spec.DeepCopy()
 
-	// At this point in the lifecycle there should be exactly one container
+	// At this point in the lifecycle there should be at least one container
 	// spec in the App if the webhhook is working but create one to avoid
 	// panics just in case.
 	if len(spec.Containers) == 0 {
@@ -141,9 +141,10 @@ func getUserContainer(
 	if err := overrideResourceRequests(userContainer, task); err != nil {
 		return nil, err
 	}
-	// Task does not have readiness or liveness probe.
+	// Task does not have readiness, liveness, or startup probe.
 	userContainer.ReadinessProbe = nil
 	userContainer.LivenessProbe = nil
+	userContainer.StartupProbe = nil
 
 	return userContainer, nil
 }
@@ -0,0 +1,380 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicesbroker
+
+import (
+	"fmt"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	osbclient "sigs.k8s.io/go-open-service-broker-client/v2"
+)
+
+// Credentials are the generated values stored in the Secret backing each
+// instance.
+type Credentials struct {
+	Username string
+	Password string
+	Database string
+}
+
+// Command is run in the container of an instance.
+type Command struct {
+	// Args is the command and its arguments.
+	Args []string
+	// Stdin is written to the command's standard input.
+	Stdin string
+}
+
+// Plan is a size of an Engine that can be provisioned.
+type Plan struct {
+	ID          string
+	Name        string
+	Description string
+
+	// Storage is the size of the PersistentVolumeClaim holding the data.
+	Storage resource.Quantity
+	// Memory is the memory requested by and limited for the container.
+	Memory resource.Quantity
+}
+
+// Engine describes how to run a backing service and connect to it.
+type Engine struct {
+	ID          string
+	Name        string
+	Description string
+	Tags        []string
+
+	// Image is the container image run by the StatefulSet.
+	Image string
+	// Port is the port the service listens on.
+	Port int32
+	// DataPath is the directory the PersistentVolumeClaim is mounted at.
+	DataPath string
+	// Args are passed to the container, they may reference the environment.
+	Args []string
+	// Env maps environment variable names to keys in the instance Secret.
+	Env map[string]string
+	// ExtraEnv are literal environment variables set on the container.
+	ExtraEnv []corev1.EnvVar
+
+	// BindCredentials builds the credentials returned to Apps bound to an
+	// instance reachable at host.
+	BindCredentials func(host string, port int32, creds Credentials) map[string]interface{}
+
+	// CreateUser returns the Command that creates the user in creds, or
+	// resets its password if it exists, with access to the instance's data.
+	CreateUser func(creds Credentials) Command
+	// DeleteUser returns the Command that deletes a user if it exists.
+	DeleteUser func(username string) Command
+
+	Plans []Plan
+}
+
+// FindPlan returns the plan with the given ID or nil if none exists.
+func (e *Engine) FindPlan(planID string) *Plan {
+	for i := range e.Plans {
+		if e.Plans[i].ID == planID {
+			return &e.Plans[i]
+		}
+	}
+
+	return nil
+}
+
+// Catalog is the set of Engines offered by the broker.
+type Catalog []Engine
+
+// FindEngine returns the Engine with the given ID or nil if none exists.
+func (c Catalog) FindEngine(serviceID string) *Engine {
+	for i := range c {
+		if c[i].ID == serviceID {
+			return &c[i]
+		}
+	}
+
+	return nil
+}
+
+// FindEngineByName returns the Engine with the given name or nil if none
+// exists.
+func (c Catalog) FindEngineByName(name string) *Engine {
+	for i := range c {
+		if c[i].Name == name {
+			return &c[i]
+		}
+	}
+
+	return nil
+}
+
+// ToOSB converts the Catalog into an OSB catalog response.
+func (c Catalog) ToOSB() *osbclient.CatalogResponse {
+	out := &osbclient.CatalogResponse{}
+
+	for _, engine := range c {
+		service := osbclient.Service{
+			ID:            engine.ID,
+			Name:          engine.Name,
+			Description:   engine.Description,
+			Tags:          engine.Tags,
+			Bindable:      true,
+			PlanUpdatable: boolPtr(false),
+			Metadata: map[string]interface{}{
+				"displayName": engine.Name,
+				"shareable":   true,
+			},
+		}
+
+		for _, plan := range engine.Plans {
+			service.Plans = append(service.Plans, osbclient.Plan{
+				ID:          plan.ID,
+				Name:        plan.Name,
+				Description: plan.Description,
+				Free:        boolPtr(true),
+				Metadata: map[string]interface{}{
+					"displayName": plan.Name,
+				},
+			})
+		}
+
+		out.Services = append(out.Services, service)
+	}
+
+	return out
+}
+
+// DefaultCatalog returns the Engines offered by the broker out of the box.
+func DefaultCatalog() Catalog {
+	return Catalog{
+		{
+			ID:          "a4d06650-d91f-483e-8578-4d735658b3d1",
+			Name:        "postgres",
+			Description: "Single-node PostgreSQL database for development.",
+			Tags:        []string{"postgres", "postgresql", "relational", "sql"},
+			Image:       "postgres:14",
+			Port:        5432,
+			DataPath:    "/var/lib/postgresql/data",
+			Env: map[string]string{
+				"POSTGRES_USER":     SecretKeyUsername,
+				"POSTGRES_PASSWORD": SecretKeyPassword,
+				"POSTGRES_DB":       SecretKeyDatabase,
+			},
+			BindCredentials: func(host string, port int32, creds Credentials) map[string]interface{} {
+				return map[string]interface{}{
+					"hostname": host,
+					"port":     port,
+					"name":     creds.Database,
+					"username": creds.Username,
+					"password": creds.Password,
+					"uri":      connectionURI("postgres", host, port, creds),
+					"jdbcUrl": fmt.Sprintf("jdbc:postgresql://%s:%d/%s?user=%s&password=%s",
+						host, port, creds.Database, url.QueryEscape(creds.Username), url.QueryEscape(creds.Password)),
+				}
+			},
+			// Binding users are members of the instance's user so they can
+			// use each other's tables.
+			CreateUser: func(creds Credentials) Command {
+				return postgresCommand(fmt.Sprintf(`DO $$
+BEGIN
+  IF EXISTS (SELECT FROM pg_roles WHERE rolname = '%[1]s') THEN
+    ALTER ROLE "%[1]s" LOGIN PASSWORD '%[2]s';
+  ELSE
+    CREATE ROLE "%[1]s" LOGIN PASSWORD '%[2]s';
+  END IF;
+END $$;
+GRANT "%[3]s" TO "%[1]s";
+`, creds.Username, creds.Password, defaultUser))
+			},
+			DeleteUser: func(username string) Command {
+				return postgresCommand(fmt.Sprintf(`SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE usename = '%[1]s';
+DO $$
+BEGIN
+  IF EXISTS (SELECT FROM pg_roles WHERE rolname = '%[1]s') THEN
+    REASSIGN OWNED BY "%[1]s" TO "%[2]s";
+    DROP OWNED BY "%[1]s";
+    DROP ROLE "%[1]s";
+  END IF;
+END $$;
+`, username, defaultUser))
+			},
+			Plans: []Plan{
+				{
+					ID:          "a76b4279-3567-4f16-967e-b35e7fe48643",
+					Name:        "small",
+					Description: "1Gi of storage and 256Mi of memory.",
+					Storage:     resource.MustParse("1Gi"),
+					Memory:      resource.MustParse("256Mi"),
+				},
+				{
+					ID:          "45e548e4-71f0-4d20-bb16-8478b6399003",
+					Name:        "medium",
+					Description: "5Gi of storage and 1Gi of memory.",
+					Storage:     resource.MustParse("5Gi"),
+					Memory:      resource.MustParse("1Gi"),
+				},
+			},
+		},
+		{
+			ID:          "067e30b6-228b-4043-a461-479142873c1c",
+			Name:        "mysql",
+			Description: "Single-node MySQL database for development.",
+			Tags:        []string{"mysql", "relational", "sql"},
+			Image:       "mysql:8.0",
+			Port:        3306,
+			DataPath:    "/var/lib/mysql",
+			// The root user shares the instance's password so the broker can
+			// manage binding users.
+			Env: map[string]string{
+				"MYSQL_USER":          SecretKeyUsername,
+				"MYSQL_PASSWORD":      SecretKeyPassword,
+				"MYSQL_DATABASE":      SecretKeyDatabase,
+				"MYSQL_ROOT_PASSWORD": SecretKeyPassword,
+			},
+			BindCredentials: func(host string, port int32, creds Credentials) map[string]interface{} {
+				return map[string]interface{}{
+					"hostname": host,
+					"port":     port,
+					"name":     creds.Database,
+					"username": creds.Username,
+					"password": creds.Password,
+					"uri":      connectionURI("mysql", host, port, creds),
+					"jdbcUrl": fmt.Sprintf("jdbc:mysql://%s:%d/%s?user=%s&password=%s",
+						host, port, creds.Database, url.QueryEscape(creds.Username), url.QueryEscape(creds.Password)),
+				}
+			},
+			CreateUser: func(creds Credentials) Command {
+				return mysqlCommand(fmt.Sprintf(`CREATE USER IF NOT EXISTS '%[1]s'@'%%' IDENTIFIED BY '%[2]s';
+ALTER USER '%[1]s'@'%%' IDENTIFIED BY '%[2]s';
+GRANT ALL PRIVILEGES ON `+"`%[3]s`"+`.* TO '%[1]s'@'%%';
+`, creds.Username, creds.Password, creds.Database))
+			},
+			DeleteUser: func(username string) Command {
+				return mysqlCommand(fmt.Sprintf("DROP USER IF EXISTS '%s'@'%%';\n", username))
+			},
+			Plans: []Plan{
+				{
+					ID:          "a8a6760f-92e7-49e4-9720-14d493f34275",
+					Name:        "small",
+					Description: "1Gi of storage and 512Mi of memory.",
+					Storage:     resource.MustParse("1Gi"),
+					Memory:      resource.MustParse("512Mi"),
+				},
+				{
+					ID:          "c0dd1dc3-91a4-47a5-a51e-2ab66e4c7d89",
+					Name:        "medium",
+					Description: "5Gi of storage and 1Gi of memory.",
+					Storage:     resource.MustParse("5Gi"),
+					Memory:      resource.MustParse("1Gi"),
+				},
+			},
+		},
+		{
+			ID:          "3ce3a37d-82ca-4f93-8a4b-cb158756ef77",
+			Name:        "redis",
+			Description: "Single-node Redis key-value store with append-only persistence.",
+			Tags:        []string{"redis", "key-value"},
+			Image:       "redis:7",
+			Port:        6379,
+			DataPath:    "/data",
+			// Binding users are stored in an ACL file which must exist before
+			// Redis starts.
+			Args: []string{
+				"sh", "-c",
+				`touch /data/users.acl && exec docker-entrypoint.sh redis-server --requirepass "$REDIS_PASSWORD" --appendonly yes --aclfile /data/users.acl`,
+			},
+			Env: map[string]string{
+				"REDIS_PASSWORD": SecretKeyPassword,
+			},
+			BindCredentials: func(host string, port int32, creds Credentials) map[string]interface{} {
+				return map[string]interface{}{
+					"host":     host,
+					"hostname": host,
+					"port":     port,
+					"username": creds.Username,
+					"password": creds.Password,
+					"uri": (&url.URL{
+						Scheme: "redis",
+						User:   url.UserPassword(creds.Username, creds.Password),
+						Host:   fmt.Sprintf("%s:%d", host, port),
+					}).String(),
+				}
+			},
+			// Deleting a user also closes its connections.
+			CreateUser: func(creds Credentials) Command {
+				return redisCommand(fmt.Sprintf("ACL SETUSER %s reset on >%s ~* &* +@all\nACL SAVE\n", creds.Username, creds.Password))
+			},
+			DeleteUser: func(username string) Command {
+				return redisCommand(fmt.Sprintf("ACL DELUSER %s\nACL SAVE\n", username))
+			},
+			Plans: []Plan{
+				{
+					ID:          "44d336ab-cef3-45a4-8500-c121512e0549",
+					Name:        "small",
+					Description: "1Gi of storage and 256Mi of memory.",
+					Storage:     resource.MustParse("1Gi"),
+					Memory:      resource.MustParse("256Mi"),
+				},
+				{
+					ID:          "336782ae-700b-44b3-8794-8dd911e46f28",
+					Name:        "medium",
+					Description: "5Gi of storage and 1Gi of memory.",
+					Storage:     resource.MustParse("5Gi"),
+					Memory:      resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+}
+
+func connectionURI(scheme, host string, port int32, creds Credentials) string {
+	return (&url.URL{
+		Scheme: scheme,
+		User:   url.UserPassword(creds.Username, creds.Password),
+		Host:   fmt.Sprintf("%s:%d", host, port),
+		Path:   "/" + creds.Database,
+	}).String()
+}
+
+// The commands below run SQL as the instance's administrator using the
+// credentials in the container's environment. Usernames and passwords are
+// generated by the broker and only contain hex digits and underscores so
+// they're safe to include in statements.
+
+func postgresCommand(sql string) Command {
+	return Command{
+		Args:  []string{"sh", "-c", `psql -v ON_ERROR_STOP=1 -U "$POSTGRES_USER" -d "$POSTGRES_DB"`},
+		Stdin: sql,
+	}
+}
+
+func mysqlCommand(sql string) Command {
+	return Command{
+		Args:  []string{"sh", "-c", `MYSQL_PWD="$MYSQL_ROOT_PASSWORD" mysql -u root`},
+		Stdin: sql,
+	}
+}
+
+func redisCommand(commands string) Command {
+	return Command{
+		Args:  []string{"sh", "-c", `REDISCLI_AUTH="$REDIS_PASSWORD" redis-cli`},
+		Stdin: commands,
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicesbroker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// Executor runs Commands in the containers of instances.
+type Executor interface {
+	// Exec runs the Command in a container and returns an error if it fails.
+	Exec(ctx context.Context, namespace, pod, container string, cmd Command) error
+}
+
+// NewExecutor creates an Executor that runs Commands using the exec
+// subresource of Pods.
+func NewExecutor(config *rest.Config, kubeClient kubernetes.Interface) Executor {
+	return &podExecutor{
+		config:     config,
+		kubeClient: kubeClient,
+	}
+}
+
+type podExecutor struct {
+	config     *rest.Config
+	kubeClient kubernetes.Interface
+}
+
+func (e *podExecutor) Exec(ctx context.Context, namespace, pod, container string, cmd Command) error {
+	req := e.kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   cmd.Args,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := exec.Stream(remotecommand.StreamOptions{
+		Stdin:  strings.NewReader(cmd.Stdin),
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}); err != nil {
+		return fmt.Errorf("command failed in %s/%s: %v: %s", namespace, pod, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicesbroker
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// SecretKeyUsername is the key in the instance Secret holding the user.
+	SecretKeyUsername = "username"
+	// SecretKeyPassword is the key in the instance Secret holding the
+	// password.
+	SecretKeyPassword = "password"
+	// SecretKeyDatabase is the key in the instance Secret holding the name of
+	// the database.
+	SecretKeyDatabase = "database"
+
+	// ManagedByLabel is set on every object created by the broker.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByValue is the value of ManagedByLabel.
+	ManagedByValue = "kf-services-broker"
+	// InstanceLabel holds the name of the objects backing an instance, it's
+	// also set on PersistentVolumeClaims so they can be cleaned up.
+	InstanceLabel = "servicesbroker.kf.dev/instance"
+	// EngineLabel holds the name of the Engine backing an instance.
+	EngineLabel = "servicesbroker.kf.dev/engine"
+
+	// InstanceIDAnnotation holds the OSB instance ID.
+	InstanceIDAnnotation = "servicesbroker.kf.dev/instance-id"
+	// PlanIDAnnotation holds the OSB plan ID the instance was provisioned
+	// with.
+	PlanIDAnnotation = "servicesbroker.kf.dev/plan-id"
+	// BindingIDAnnotation holds the OSB binding ID a binding Secret was
+	// issued for.
+	BindingIDAnnotation = "servicesbroker.kf.dev/binding-id"
+
+	dataVolumeName = "data"
+	defaultUser    = "kf"
+	defaultDB      = "kf"
+)
+
+// ResourceName returns the name shared by the Secret, Service and
+// StatefulSet backing an instance. OSB instance IDs are arbitrary strings so
+// they're hashed into a valid DNS label.
+func ResourceName(instanceID string) string {
+	sum := md5.Sum([]byte(instanceID))
+	return "kf-" + hex.EncodeToString(sum[:])
+}
+
+// HeadlessServiceName returns the name of the headless Service governing the
+// StatefulSet backing an instance.
+func HeadlessServiceName(instanceID string) string {
+	return ResourceName(instanceID) + "-headless"
+}
+
+// BindingResourceName returns the name of the Secret holding the Credentials
+// issued to a binding.
+func BindingResourceName(bindingID string) string {
+	sum := md5.Sum([]byte(bindingID))
+	return "kf-binding-" + hex.EncodeToString(sum[:])
+}
+
+// BindingUsername returns the name of the user created for a binding. It fits
+// in the 32 character limit MySQL has for usernames.
+func BindingUsername(bindingID string) string {
+	sum := md5.Sum([]byte(bindingID))
+	return "kf_" + hex.EncodeToString(sum[:])[:24]
+}
+
+// ServiceHost returns the cluster-local DNS name Apps connect to.
+func ServiceHost(name, namespace string) string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local", name, namespace)
+}
+
+// GenerateCredentials creates random Credentials for a new instance.
+func GenerateCredentials() (Credentials, error) {
+	password, err := generatePassword()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return Credentials{
+		Username: defaultUser,
+		Password: password,
+		Database: defaultDB,
+	}, nil
+}
+
+// GenerateBindingCredentials creates random Credentials for a new binding.
+func GenerateBindingCredentials(bindingID string) (Credentials, error) {
+	password, err := generatePassword()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return Credentials{
+		Username: BindingUsername(bindingID),
+		Password: password,
+		Database: defaultDB,
+	}, nil
+}
+
+func generatePassword() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
+
+// CredentialsFromSecret reads Credentials out of an instance Secret.
+func CredentialsFromSecret(secret *corev1.Secret) Credentials {
+	return Credentials{
+		Username: string(secret.Data[SecretKeyUsername]),
+		Password: string(secret.Data[SecretKeyPassword]),
+		Database: string(secret.Data[SecretKeyDatabase]),
+	}
+}
+
+func makeLabels(engine *Engine, name string) map[string]string {
+	return map[string]string{
+		ManagedByLabel: ManagedByValue,
+		InstanceLabel:  name,
+		EngineLabel:    engine.Name,
+	}
+}
+
+func makeObjectMeta(engine *Engine, plan *Plan, name, namespace, instanceID string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    makeLabels(engine, name),
+		Annotations: map[string]string{
+			InstanceIDAnnotation: instanceID,
+			PlanIDAnnotation:     plan.ID,
+		},
+	}
+}
+
+// MakeSecret creates the Secret holding the Credentials of an instance.
+func MakeSecret(engine *Engine, plan *Plan, namespace, instanceID string, creds Credentials) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: makeObjectMeta(engine, plan, ResourceName(instanceID), namespace, instanceID),
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			SecretKeyUsername: []byte(creds.Username),
+			SecretKeyPassword: []byte(creds.Password),
+			SecretKeyDatabase: []byte(creds.Database),
+		},
+	}
+}
+
+// MakeBindingSecret creates the Secret holding the Credentials issued to a
+// binding. It's labeled with the instance so it's cleaned up if the instance
+// is deprovisioned first.
+func MakeBindingSecret(engine *Engine, plan *Plan, namespace, instanceID, bindingID string, creds Credentials) *corev1.Secret {
+	secret := MakeSecret(engine, plan, namespace, instanceID, creds)
+	secret.Name = BindingResourceName(bindingID)
+	secret.Annotations[BindingIDAnnotation] = bindingID
+	return secret
+}
+
+// MakeService creates the Service Apps use to reach an instance.
+func MakeService(engine *Engine, plan *Plan, namespace, instanceID string) *corev1.Service {
+	name := ResourceName(instanceID)
+
+	return &corev1.Service{
+		ObjectMeta: makeObjectMeta(engine, plan, name, namespace, instanceID),
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: makeLabels(engine, name),
+			Ports: []corev1.ServicePort{
+				{
+					// The tcp- prefix keeps Istio from sniffing the protocol
+					// of server-first protocols like MySQL.
+					Name:       "tcp-" + engine.Name,
+					Protocol:   corev1.ProtocolTCP,
+					Port:       engine.Port,
+					TargetPort: intstr.FromInt(int(engine.Port)),
+				},
+			},
+		},
+	}
+}
+
+// MakeHeadlessService creates the Service governing the StatefulSet running
+// an instance, which gives its Pod a stable DNS name.
+func MakeHeadlessService(engine *Engine, plan *Plan, namespace, instanceID string) *corev1.Service {
+	service := MakeService(engine, plan, namespace, instanceID)
+	service.Name = HeadlessServiceName(instanceID)
+	service.Spec.ClusterIP = corev1.ClusterIPNone
+	return service
+}
+
+// MakeStatefulSet creates the single-replica StatefulSet running an
+// instance.
+func MakeStatefulSet(engine *Engine, plan *Plan, namespace, instanceID string) *appsv1.StatefulSet {
+	name := ResourceName(instanceID)
+	labels := makeLabels(engine, name)
+	replicas := int32(1)
+
+	var env []corev1.EnvVar
+	for _, envName := range sortedKeys(engine.Env) {
+		env = append(env, corev1.EnvVar{
+			Name: envName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: name},
+					Key:                  engine.Env[envName],
+				},
+			},
+		})
+	}
+	env = append(env, engine.ExtraEnv...)
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: makeObjectMeta(engine, plan, name, namespace, instanceID),
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: HeadlessServiceName(instanceID),
+			Selector:    metav1.SetAsLabelSelector(labels),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  engine.Name,
+							Image: engine.Image,
+							Args:  engine.Args,
+							Env:   env,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "tcp-" + engine.Name,
+									ContainerPort: engine.Port,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{
+										Port: intstr.FromInt(int(engine.Port)),
+									},
+								},
+								PeriodSeconds: 5,
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("100m"),
+									corev1.ResourceMemory: plan.Memory,
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceMemory: plan.Memory,
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      dataVolumeName,
+									MountPath: engine.DataPath,
+									// Mount a subdirectory because some engines
+									// refuse to initialize a data directory
+									// containing lost+found.
+									SubPath: engine.Name,
+								},
+							},
+						},
+					},
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   dataVolumeName,
+						Labels: labels,
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{
+							corev1.ReadWriteOnce,
+						},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: plan.Storage,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,512 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicesbroker
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	osbclient "sigs.k8s.io/go-open-service-broker-client/v2"
+)
+
+// Broker serves the OSB API and manages instances in a single namespace.
+type Broker struct {
+	kubeClient kubernetes.Interface
+	executor   Executor
+	namespace  string
+	username   string
+	password   string
+	catalog    Catalog
+}
+
+// NewBroker creates a Broker that provisions instances from the catalog in
+// namespace and requires clients to authenticate with the given basic auth
+// credentials. The executor is used to manage the users issued to bindings.
+func NewBroker(
+	kubeClient kubernetes.Interface,
+	executor Executor,
+	namespace string,
+	username string,
+	password string,
+	catalog Catalog,
+) *Broker {
+	return &Broker{
+		kubeClient: kubeClient,
+		executor:   executor,
+		namespace:  namespace,
+		username:   username,
+		password:   password,
+		catalog:    catalog,
+	}
+}
+
+// provisionRequestBody is the body of OSB provision requests.
+type provisionRequestBody struct {
+	ServiceID string `json:"service_id"`
+	PlanID    string `json:"plan_id"`
+}
+
+// updateRequestBody is the body of OSB update requests.
+type updateRequestBody struct {
+	ServiceID string  `json:"service_id"`
+	PlanID    *string `json:"plan_id,omitempty"`
+}
+
+// bindResponseBody is the body of OSB bind responses.
+type bindResponseBody struct {
+	Credentials map[string]interface{} `json:"credentials"`
+}
+
+// errorResponseBody is the body of OSB error responses.
+type errorResponseBody struct {
+	Error       string `json:"error,omitempty"`
+	Description string `json:"description"`
+}
+
+// Handler returns the http.Handler serving the OSB API.
+func (b *Broker) Handler() http.Handler {
+	r := mux.NewRouter()
+
+	// Health check, unauthenticated so it can be used by probes.
+	r.Methods("GET").Path("/healthz").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "ok")
+	})
+
+	api := r.PathPrefix("/v2").Subrouter()
+	api.Use(b.authenticate)
+	api.Methods("GET").Path("/catalog").HandlerFunc(b.getCatalog)
+	api.Methods("PUT").Path("/service_instances/{instance_id}").HandlerFunc(b.provision)
+	api.Methods("PATCH").Path("/service_instances/{instance_id}").HandlerFunc(b.update)
+	api.Methods("DELETE").Path("/service_instances/{instance_id}").HandlerFunc(b.deprovision)
+	api.Methods("GET").Path("/service_instances/{instance_id}/last_operation").HandlerFunc(b.lastOperation)
+	api.Methods("PUT").Path("/service_instances/{instance_id}/service_bindings/{binding_id}").HandlerFunc(b.bind)
+	api.Methods("DELETE").Path("/service_instances/{instance_id}/service_bindings/{binding_id}").HandlerFunc(b.unbind)
+
+	return r
+}
+
+// authenticate rejects requests without the broker's basic auth credentials.
+func (b *Broker) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(username), []byte(b.username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(password), []byte(b.password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="kf-services-broker"`)
+			respondError(w, http.StatusUnauthorized, "", "invalid credentials")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (b *Broker) getCatalog(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, b.catalog.ToOSB())
+}
+
+func (b *Broker) provision(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	instanceID := mux.Vars(r)["instance_id"]
+
+	if !acceptsIncomplete(r) {
+		respondError(w, http.StatusUnprocessableEntity, "AsyncRequired", "This broker only provisions asynchronously.")
+		return
+	}
+
+	var body provisionRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "", fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	engine := b.catalog.FindEngine(body.ServiceID)
+	if engine == nil {
+		respondError(w, http.StatusBadRequest, "", fmt.Sprintf("unknown service %q", body.ServiceID))
+		return
+	}
+
+	plan := engine.FindPlan(body.PlanID)
+	if plan == nil {
+		respondError(w, http.StatusBadRequest, "", fmt.Sprintf("unknown plan %q for service %s", body.PlanID, engine.Name))
+		return
+	}
+
+	existing, err := b.getStatefulSet(ctx, instanceID)
+	switch {
+	case apierrs.IsNotFound(err):
+		// Provision below.
+	case err != nil:
+		respondInternalError(w, err)
+		return
+	case existing.Labels[EngineLabel] != engine.Name || existing.Annotations[PlanIDAnnotation] != plan.ID:
+		respondError(w, http.StatusConflict, "", "instance already exists with a different service or plan")
+		return
+	case isReady(existing):
+		respondJSON(w, http.StatusOK, struct{}{})
+		return
+	default:
+		respondJSON(w, http.StatusAccepted, struct{}{})
+		return
+	}
+
+	// The Secret is reused if it exists so a retried provision doesn't change
+	// the password of a database that was already initialized.
+	if _, err := b.kubeClient.CoreV1().Secrets(b.namespace).Get(ctx, ResourceName(instanceID), metav1.GetOptions{}); apierrs.IsNotFound(err) {
+		creds, err := GenerateCredentials()
+		if err != nil {
+			respondInternalError(w, err)
+			return
+		}
+
+		secret := MakeSecret(engine, plan, b.namespace, instanceID, creds)
+		if _, err := b.kubeClient.CoreV1().Secrets(b.namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil && !apierrs.IsAlreadyExists(err) {
+			respondInternalError(w, err)
+			return
+		}
+	} else if err != nil {
+		respondInternalError(w, err)
+		return
+	}
+
+	for _, service := range []*corev1.Service{
+		MakeService(engine, plan, b.namespace, instanceID),
+		MakeHeadlessService(engine, plan, b.namespace, instanceID),
+	} {
+		if _, err := b.kubeClient.CoreV1().Services(b.namespace).Create(ctx, service, metav1.CreateOptions{}); err != nil && !apierrs.IsAlreadyExists(err) {
+			respondInternalError(w, err)
+			return
+		}
+	}
+
+	statefulSet := MakeStatefulSet(engine, plan, b.namespace, instanceID)
+	if _, err := b.kubeClient.AppsV1().StatefulSets(b.namespace).Create(ctx, statefulSet, metav1.CreateOptions{}); err != nil && !apierrs.IsAlreadyExists(err) {
+		respondInternalError(w, err)
+		return
+	}
+
+	log.Printf("provisioning %s instance %s as %s/%s", engine.Name, instanceID, b.namespace, statefulSet.Name)
+	respondJSON(w, http.StatusAccepted, struct{}{})
+}
+
+func (b *Broker) update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	instanceID := mux.Vars(r)["instance_id"]
+
+	var body updateRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "", fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	existing, err := b.getStatefulSet(ctx, instanceID)
+	switch {
+	case apierrs.IsNotFound(err):
+		respondError(w, http.StatusBadRequest, "", fmt.Sprintf("instance %q doesn't exist", instanceID))
+		return
+	case err != nil:
+		respondInternalError(w, err)
+		return
+	}
+
+	if body.PlanID != nil && *body.PlanID != existing.Annotations[PlanIDAnnotation] {
+		respondError(w, http.StatusUnprocessableEntity, "", "plan changes aren't supported, create a new instance and migrate the data")
+		return
+	}
+
+	// Parameters aren't supported so there's nothing else to change.
+	respondJSON(w, http.StatusOK, struct{}{})
+}
+
+func (b *Broker) deprovision(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	instanceID := mux.Vars(r)["instance_id"]
+	name := ResourceName(instanceID)
+
+	found := false
+	deleteFuncs := []func() error{
+		func() error {
+			return b.kubeClient.AppsV1().StatefulSets(b.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+		func() error {
+			return b.kubeClient.CoreV1().Services(b.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+		func() error {
+			return b.kubeClient.CoreV1().Services(b.namespace).Delete(ctx, HeadlessServiceName(instanceID), metav1.DeleteOptions{})
+		},
+		func() error {
+			return b.kubeClient.CoreV1().Secrets(b.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+	}
+
+	for _, deleteFunc := range deleteFuncs {
+		switch err := deleteFunc(); {
+		case apierrs.IsNotFound(err):
+			// Already deleted.
+		case err != nil:
+			respondInternalError(w, err)
+			return
+		default:
+			found = true
+		}
+	}
+
+	// Secrets of bindings that weren't unbound are no longer valid because
+	// their users are deleted with the data.
+	selector := labels.SelectorFromSet(labels.Set{InstanceLabel: name}).String()
+	bindingSecrets, err := b.kubeClient.CoreV1().Secrets(b.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		respondInternalError(w, err)
+		return
+	}
+
+	for _, secret := range bindingSecrets.Items {
+		err := b.kubeClient.CoreV1().Secrets(b.namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			respondInternalError(w, err)
+			return
+		}
+	}
+
+	// StatefulSets don't delete the claims created from their templates so
+	// they're removed explicitly to release the storage.
+	claims, err := b.kubeClient.CoreV1().PersistentVolumeClaims(b.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		respondInternalError(w, err)
+		return
+	}
+
+	for _, claim := range claims.Items {
+		err := b.kubeClient.CoreV1().PersistentVolumeClaims(b.namespace).Delete(ctx, claim.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			respondInternalError(w, err)
+			return
+		}
+		found = true
+	}
+
+	if !found {
+		respondJSON(w, http.StatusGone, struct{}{})
+		return
+	}
+
+	log.Printf("deprovisioned %s/%s", b.namespace, name)
+	respondJSON(w, http.StatusOK, struct{}{})
+}
+
+func (b *Broker) lastOperation(w http.ResponseWriter, r *http.Request) {
+	existing, err := b.getStatefulSet(r.Context(), mux.Vars(r)["instance_id"])
+	switch {
+	case apierrs.IsNotFound(err):
+		respondJSON(w, http.StatusOK, lastOperationResponse(osbclient.StateFailed, "backing StatefulSet doesn't exist"))
+	case err != nil:
+		respondInternalError(w, err)
+	case isReady(existing):
+		respondJSON(w, http.StatusOK, lastOperationResponse(osbclient.StateSucceeded, "instance is ready"))
+	default:
+		respondJSON(w, http.StatusOK, lastOperationResponse(
+			osbclient.StateInProgress,
+			fmt.Sprintf("waiting for %s to become ready", existing.Labels[EngineLabel]),
+		))
+	}
+}
+
+func (b *Broker) bind(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	instanceID := mux.Vars(r)["instance_id"]
+	bindingID := mux.Vars(r)["binding_id"]
+
+	existing, err := b.getStatefulSet(ctx, instanceID)
+	switch {
+	case apierrs.IsNotFound(err):
+		respondError(w, http.StatusUnprocessableEntity, "", fmt.Sprintf("instance %q doesn't exist", instanceID))
+		return
+	case err != nil:
+		respondInternalError(w, err)
+		return
+	}
+
+	engine := b.catalog.FindEngineByName(existing.Labels[EngineLabel])
+	if engine == nil {
+		respondInternalError(w, fmt.Errorf("instance %q has unknown engine %q", instanceID, existing.Labels[EngineLabel]))
+		return
+	}
+
+	plan := engine.FindPlan(existing.Annotations[PlanIDAnnotation])
+	if plan == nil {
+		respondInternalError(w, fmt.Errorf("instance %q has unknown plan %q", instanceID, existing.Annotations[PlanIDAnnotation]))
+		return
+	}
+
+	host := ServiceHost(existing.Name, b.namespace)
+
+	// Retried binds return the credentials that were already issued.
+	secret, err := b.kubeClient.CoreV1().Secrets(b.namespace).Get(ctx, BindingResourceName(bindingID), metav1.GetOptions{})
+	switch {
+	case err == nil:
+		respondJSON(w, http.StatusOK, &bindResponseBody{
+			Credentials: engine.BindCredentials(host, engine.Port, CredentialsFromSecret(secret)),
+		})
+		return
+	case !apierrs.IsNotFound(err):
+		respondInternalError(w, err)
+		return
+	}
+
+	if !isReady(existing) {
+		respondError(w, http.StatusUnprocessableEntity, "", fmt.Sprintf("instance %q isn't ready", instanceID))
+		return
+	}
+
+	creds, err := GenerateBindingCredentials(bindingID)
+	if err != nil {
+		respondInternalError(w, err)
+		return
+	}
+
+	// The user is created before the Secret, CreateUser resets the password
+	// if a previous attempt failed after creating the user.
+	if err := b.executor.Exec(ctx, b.namespace, instancePodName(existing), engine.Name, engine.CreateUser(creds)); err != nil {
+		respondInternalError(w, err)
+		return
+	}
+
+	secret = MakeBindingSecret(engine, plan, b.namespace, instanceID, bindingID, creds)
+	if _, err := b.kubeClient.CoreV1().Secrets(b.namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		respondInternalError(w, err)
+		return
+	}
+
+	log.Printf("issued user %s for binding %s to %s/%s", creds.Username, bindingID, b.namespace, existing.Name)
+	respondJSON(w, http.StatusCreated, &bindResponseBody{
+		Credentials: engine.BindCredentials(host, engine.Port, creds),
+	})
+}
+
+func (b *Broker) unbind(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	instanceID := mux.Vars(r)["instance_id"]
+	bindingID := mux.Vars(r)["binding_id"]
+
+	existing, err := b.getStatefulSet(ctx, instanceID)
+	switch {
+	case apierrs.IsNotFound(err):
+		respondJSON(w, http.StatusGone, struct{}{})
+		return
+	case err != nil:
+		respondInternalError(w, err)
+		return
+	}
+
+	secretName := BindingResourceName(bindingID)
+	switch _, err := b.kubeClient.CoreV1().Secrets(b.namespace).Get(ctx, secretName, metav1.GetOptions{}); {
+	case apierrs.IsNotFound(err):
+		respondJSON(w, http.StatusGone, struct{}{})
+		return
+	case err != nil:
+		respondInternalError(w, err)
+		return
+	}
+
+	engine := b.catalog.FindEngineByName(existing.Labels[EngineLabel])
+	if engine == nil {
+		respondInternalError(w, fmt.Errorf("instance %q has unknown engine %q", instanceID, existing.Labels[EngineLabel]))
+		return
+	}
+
+	// The Secret is deleted last so a failed unbind can be retried.
+	username := BindingUsername(bindingID)
+	if err := b.executor.Exec(ctx, b.namespace, instancePodName(existing), engine.Name, engine.DeleteUser(username)); err != nil {
+		respondInternalError(w, err)
+		return
+	}
+
+	if err := b.kubeClient.CoreV1().Secrets(b.namespace).Delete(ctx, secretName, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+		respondInternalError(w, err)
+		return
+	}
+
+	log.Printf("deleted user %s for binding %s to %s/%s", username, bindingID, b.namespace, existing.Name)
+	respondJSON(w, http.StatusOK, struct{}{})
+}
+
+func (b *Broker) getStatefulSet(ctx context.Context, instanceID string) (*appsv1.StatefulSet, error) {
+	return b.kubeClient.AppsV1().StatefulSets(b.namespace).Get(ctx, ResourceName(instanceID), metav1.GetOptions{})
+}
+
+// instancePodName returns the name of the single Pod of an instance's
+// StatefulSet.
+func instancePodName(statefulSet *appsv1.StatefulSet) string {
+	return statefulSet.Name + "-0"
+}
+
+// isReady returns true if the instance's Pod is accepting connections.
+func isReady(statefulSet *appsv1.StatefulSet) bool {
+	return statefulSet.Status.ReadyReplicas > 0
+}
+
+// acceptsIncomplete checks for the accepts_incomplete=true query parameter
+// used by OSB clients to indicate they support asynchronous operations.
+func acceptsIncomplete(r *http.Request) bool {
+	return r.URL.Query().Get("accepts_incomplete") == "true"
+}
+
+func lastOperationResponse(state osbclient.LastOperationState, description string) *osbclient.LastOperationResponse {
+	return &osbclient.LastOperationResponse{
+		State:       state,
+		Description: &description,
+	}
+}
+
+// respondJSON writes body as the JSON response.
+func respondJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("error writing response: %v", err)
+	}
+}
+
+// respondError writes an OSB error response.
+func respondError(w http.ResponseWriter, status int, code, description string) {
+	respondJSON(w, status, &errorResponseBody{
+		Error:       code,
+		Description: description,
+	})
+}
+
+// respondInternalError logs and writes a 500 response for a runtime error.
+func respondInternalError(w http.ResponseWriter, err error) {
+	log.Printf("error: %v", err)
+	respondError(w, http.StatusInternalServerError, "", err.Error())
+}
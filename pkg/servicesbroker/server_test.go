@@ -0,0 +1,402 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicesbroker_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/kf/v2/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/v2/pkg/kf/cfutil"
+	"github.com/google/kf/v2/pkg/kf/testutil"
+	"github.com/google/kf/v2/pkg/reconciler/serviceinstancebinding/resources"
+	"github.com/google/kf/v2/pkg/servicesbroker"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	osbclient "sigs.k8s.io/go-open-service-broker-client/v2"
+)
+
+const (
+	testNamespace  = "kf-services"
+	testUsername   = "broker-user"
+	testPassword   = "broker-pass"
+	testInstanceID = "4a5e9a0e-1f6c-4c1f-8f4e-0d5b6e4e2a11"
+)
+
+// fakeExecutor records the Commands run in instances.
+type fakeExecutor struct {
+	mu       sync.Mutex
+	pods     []string
+	commands []servicesbroker.Command
+}
+
+func (e *fakeExecutor) Exec(ctx context.Context, namespace, pod, container string, cmd servicesbroker.Command) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.pods = append(e.pods, namespace+"/"+pod+"/"+container)
+	e.commands = append(e.commands, cmd)
+	return nil
+}
+
+func newTestServer(t *testing.T) (*k8sfake.Clientset, *httptest.Server, osbclient.Client) {
+	t.Helper()
+
+	kubeClient, _, server, client := newTestServerWithExecutor(t)
+	return kubeClient, server, client
+}
+
+func newTestServerWithExecutor(t *testing.T) (*k8sfake.Clientset, *fakeExecutor, *httptest.Server, osbclient.Client) {
+	t.Helper()
+
+	kubeClient := k8sfake.NewSimpleClientset()
+	executor := &fakeExecutor{}
+	broker := servicesbroker.NewBroker(kubeClient, executor, testNamespace, testUsername, testPassword, servicesbroker.DefaultCatalog())
+	server := httptest.NewServer(broker.Handler())
+	t.Cleanup(server.Close)
+
+	config := osbclient.DefaultClientConfiguration()
+	config.URL = server.URL
+	config.AuthConfig = &osbclient.AuthConfig{
+		BasicAuthConfig: &osbclient.BasicAuthConfig{
+			Username: testUsername,
+			Password: testPassword,
+		},
+	}
+	client, err := osbclient.NewClient(config)
+	testutil.AssertNil(t, "NewClient error", err)
+
+	return kubeClient, executor, server, client
+}
+
+func provisionRequest(serviceID, planID string) *osbclient.ProvisionRequest {
+	return &osbclient.ProvisionRequest{
+		InstanceID:        testInstanceID,
+		AcceptsIncomplete: true,
+		ServiceID:         serviceID,
+		PlanID:            planID,
+		OrganizationGUID:  "some-org",
+		SpaceGUID:         "some-space",
+	}
+}
+
+func TestBroker_lifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	kubeClient, executor, _, client := newTestServerWithExecutor(t)
+	catalog := servicesbroker.DefaultCatalog()
+	postgres := catalog.FindEngineByName("postgres")
+	name := servicesbroker.ResourceName(testInstanceID)
+	bindingName := servicesbroker.BindingResourceName("some-binding")
+	bindingUser := servicesbroker.BindingUsername("some-binding")
+
+	{
+		catalogResponse, err := client.GetCatalog()
+		testutil.AssertNil(t, "GetCatalog error", err)
+		testutil.AssertEqual(t, "services", len(catalog), len(catalogResponse.Services))
+	}
+
+	{
+		response, err := client.ProvisionInstance(provisionRequest(postgres.ID, postgres.Plans[0].ID))
+		testutil.AssertNil(t, "ProvisionInstance error", err)
+		testutil.AssertTrue(t, "async", response.Async)
+
+		_, err = kubeClient.CoreV1().Secrets(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		testutil.AssertNil(t, "Secret error", err)
+		_, err = kubeClient.CoreV1().Services(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		testutil.AssertNil(t, "Service error", err)
+
+		headless, err := kubeClient.CoreV1().Services(testNamespace).Get(ctx, servicesbroker.HeadlessServiceName(testInstanceID), metav1.GetOptions{})
+		testutil.AssertNil(t, "headless Service error", err)
+		testutil.AssertEqual(t, "cluster IP", corev1.ClusterIPNone, headless.Spec.ClusterIP)
+
+		statefulSet, err := kubeClient.AppsV1().StatefulSets(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		testutil.AssertNil(t, "StatefulSet error", err)
+		testutil.AssertEqual(t, "governing Service", headless.Name, statefulSet.Spec.ServiceName)
+	}
+
+	{
+		response, err := client.PollLastOperation(&osbclient.LastOperationRequest{InstanceID: testInstanceID})
+		testutil.AssertNil(t, "PollLastOperation error", err)
+		testutil.AssertEqual(t, "state", osbclient.StateInProgress, response.State)
+	}
+
+	{
+		statefulSet, err := kubeClient.AppsV1().StatefulSets(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		testutil.AssertNil(t, "StatefulSet error", err)
+		statefulSet.Status.ReadyReplicas = 1
+		_, err = kubeClient.AppsV1().StatefulSets(testNamespace).UpdateStatus(ctx, statefulSet, metav1.UpdateOptions{})
+		testutil.AssertNil(t, "UpdateStatus error", err)
+
+		response, err := client.PollLastOperation(&osbclient.LastOperationRequest{InstanceID: testInstanceID})
+		testutil.AssertNil(t, "PollLastOperation error", err)
+		testutil.AssertEqual(t, "state", osbclient.StateSucceeded, response.State)
+	}
+
+	{
+		response, err := client.ProvisionInstance(provisionRequest(postgres.ID, postgres.Plans[0].ID))
+		testutil.AssertNil(t, "re-provision error", err)
+		testutil.AssertFalse(t, "async", response.Async)
+
+		_, err = client.ProvisionInstance(provisionRequest(postgres.ID, postgres.Plans[1].ID))
+		statusErr, ok := osbclient.IsHTTPError(err)
+		testutil.AssertTrue(t, "is HTTP error", ok)
+		testutil.AssertEqual(t, "status", http.StatusConflict, statusErr.StatusCode)
+	}
+
+	bindRequest := &osbclient.BindRequest{
+		BindingID:  "some-binding",
+		InstanceID: testInstanceID,
+		ServiceID:  postgres.ID,
+		PlanID:     postgres.Plans[0].ID,
+	}
+
+	{
+		response, err := client.Bind(bindRequest)
+		testutil.AssertNil(t, "Bind error", err)
+
+		testutil.AssertEqual(t, "exec pods", []string{testNamespace + "/" + name + "-0/postgres"}, executor.pods)
+		testutil.AssertContainsAll(t, executor.commands[0].Stdin, []string{`CREATE ROLE "` + bindingUser + `"`})
+
+		secret, err := kubeClient.CoreV1().Secrets(testNamespace).Get(ctx, bindingName, metav1.GetOptions{})
+		testutil.AssertNil(t, "binding Secret error", err)
+		testutil.AssertEqual(t, "username", bindingUser, string(secret.Data[servicesbroker.SecretKeyUsername]))
+		password := string(secret.Data[servicesbroker.SecretKeyPassword])
+		testutil.AssertNotBlank(t, "password", password)
+
+		instanceSecret, err := kubeClient.CoreV1().Secrets(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		testutil.AssertNil(t, "instance Secret error", err)
+		testutil.AssertTrue(t, "binding password differs", password != string(instanceSecret.Data[servicesbroker.SecretKeyPassword]))
+
+		// Run the credentials through the same path the binding reconciler
+		// and App environment use.
+		binding := &v1alpha1.ServiceInstanceBinding{}
+		credentialsSecret, err := resources.MakeCredentialsForOSBService(binding, response.Credentials)
+		testutil.AssertNil(t, "MakeCredentialsForOSBService error", err)
+		vcapService := cfutil.NewVcapService(*binding, *credentialsSecret)
+
+		var uri string
+		testutil.AssertNil(t, "uri error", json.Unmarshal(vcapService.Credentials["uri"], &uri))
+		testutil.AssertEqual(
+			t,
+			"uri",
+			"postgres://"+bindingUser+":"+password+"@"+name+".kf-services.svc.cluster.local:5432/kf",
+			uri,
+		)
+
+		var port int
+		testutil.AssertNil(t, "port error", json.Unmarshal(vcapService.Credentials["port"], &port))
+		testutil.AssertEqual(t, "port", 5432, port)
+
+		// Retrying returns the same credentials without creating another
+		// user.
+		retried, err := client.Bind(bindRequest)
+		testutil.AssertNil(t, "retried Bind error", err)
+		testutil.AssertEqual(t, "retried credentials", response.Credentials["password"], retried.Credentials["password"])
+		testutil.AssertEqual(t, "commands", 1, len(executor.commands))
+	}
+
+	unbindRequest := &osbclient.UnbindRequest{
+		BindingID:  "some-binding",
+		InstanceID: testInstanceID,
+		ServiceID:  postgres.ID,
+		PlanID:     postgres.Plans[0].ID,
+	}
+
+	{
+		_, err := client.Unbind(unbindRequest)
+		testutil.AssertNil(t, "Unbind error", err)
+
+		testutil.AssertEqual(t, "commands", 2, len(executor.commands))
+		testutil.AssertContainsAll(t, executor.commands[1].Stdin, []string{`DROP ROLE "` + bindingUser + `"`})
+
+		_, err = kubeClient.CoreV1().Secrets(testNamespace).Get(ctx, bindingName, metav1.GetOptions{})
+		testutil.AssertTrue(t, "binding Secret deleted", apierrs.IsNotFound(err))
+
+		// The client treats Gone as success.
+		_, err = client.Unbind(unbindRequest)
+		testutil.AssertNil(t, "repeated Unbind error", err)
+		testutil.AssertEqual(t, "commands", 2, len(executor.commands))
+	}
+
+	{
+		claim := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "data-" + name + "-0",
+				Namespace: testNamespace,
+				Labels:    map[string]string{servicesbroker.InstanceLabel: name},
+			},
+		}
+		_, err := kubeClient.CoreV1().PersistentVolumeClaims(testNamespace).Create(ctx, claim, metav1.CreateOptions{})
+		testutil.AssertNil(t, "create PVC error", err)
+
+		response, err := client.DeprovisionInstance(&osbclient.DeprovisionRequest{
+			InstanceID:        testInstanceID,
+			AcceptsIncomplete: true,
+			ServiceID:         postgres.ID,
+			PlanID:            postgres.Plans[0].ID,
+		})
+		testutil.AssertNil(t, "DeprovisionInstance error", err)
+		testutil.AssertFalse(t, "async", response.Async)
+
+		_, err = kubeClient.AppsV1().StatefulSets(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		testutil.AssertTrue(t, "StatefulSet deleted", apierrs.IsNotFound(err))
+		_, err = kubeClient.CoreV1().Services(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		testutil.AssertTrue(t, "Service deleted", apierrs.IsNotFound(err))
+		_, err = kubeClient.CoreV1().Services(testNamespace).Get(ctx, servicesbroker.HeadlessServiceName(testInstanceID), metav1.GetOptions{})
+		testutil.AssertTrue(t, "headless Service deleted", apierrs.IsNotFound(err))
+		_, err = kubeClient.CoreV1().Secrets(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		testutil.AssertTrue(t, "Secret deleted", apierrs.IsNotFound(err))
+		_, err = kubeClient.CoreV1().PersistentVolumeClaims(testNamespace).Get(ctx, claim.Name, metav1.GetOptions{})
+		testutil.AssertTrue(t, "PVC deleted", apierrs.IsNotFound(err))
+	}
+}
+
+func TestBroker_errors(t *testing.T) {
+	t.Parallel()
+
+	catalog := servicesbroker.DefaultCatalog()
+	redis := catalog.FindEngineByName("redis")
+
+	cases := map[string]struct {
+		method         string
+		path           string
+		body           string
+		skipAuth       bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		"no credentials": {
+			method:         http.MethodGet,
+			path:           "/v2/catalog",
+			skipAuth:       true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"health check doesn't need credentials": {
+			method:         http.MethodGet,
+			path:           "/healthz",
+			skipAuth:       true,
+			expectedStatus: http.StatusOK,
+		},
+		"synchronous provision": {
+			method:         http.MethodPut,
+			path:           "/v2/service_instances/some-instance",
+			body:           `{"service_id":"` + redis.ID + `","plan_id":"` + redis.Plans[0].ID + `"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   "AsyncRequired",
+		},
+		"unknown service": {
+			method:         http.MethodPut,
+			path:           "/v2/service_instances/some-instance?accepts_incomplete=true",
+			body:           `{"service_id":"unknown","plan_id":"` + redis.Plans[0].ID + `"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `unknown service`,
+		},
+		"unknown plan": {
+			method:         http.MethodPut,
+			path:           "/v2/service_instances/some-instance?accepts_incomplete=true",
+			body:           `{"service_id":"` + redis.ID + `","plan_id":"unknown"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `unknown plan`,
+		},
+		"deprovision missing instance": {
+			method:         http.MethodDelete,
+			path:           "/v2/service_instances/some-instance?service_id=x&plan_id=y",
+			expectedStatus: http.StatusGone,
+		},
+		"bind missing instance": {
+			method:         http.MethodPut,
+			path:           "/v2/service_instances/some-instance/service_bindings/some-binding",
+			body:           `{"service_id":"` + redis.ID + `","plan_id":"` + redis.Plans[0].ID + `"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		"unbind missing instance": {
+			method:         http.MethodDelete,
+			path:           "/v2/service_instances/some-instance/service_bindings/some-binding?service_id=x&plan_id=y",
+			expectedStatus: http.StatusGone,
+		},
+		"last operation missing instance": {
+			method:         http.MethodGet,
+			path:           "/v2/service_instances/some-instance/last_operation",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"state":"failed"`,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			_, server, _ := newTestServer(t)
+
+			req, err := http.NewRequest(tc.method, server.URL+tc.path, strings.NewReader(tc.body))
+			testutil.AssertNil(t, "NewRequest error", err)
+			if !tc.skipAuth {
+				req.SetBasicAuth(testUsername, testPassword)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			testutil.AssertNil(t, "request error", err)
+			defer resp.Body.Close()
+
+			body := new(strings.Builder)
+			_, err = io.Copy(body, resp.Body)
+			testutil.AssertNil(t, "read error", err)
+
+			testutil.AssertEqual(t, "status", tc.expectedStatus, resp.StatusCode)
+			testutil.AssertContainsAll(t, body.String(), []string{tc.expectedBody})
+		})
+	}
+}
+
+func TestBroker_updatePlan(t *testing.T) {
+	t.Parallel()
+
+	_, _, client := newTestServer(t)
+	mysql := servicesbroker.DefaultCatalog().FindEngineByName("mysql")
+
+	_, err := client.ProvisionInstance(provisionRequest(mysql.ID, mysql.Plans[0].ID))
+	testutil.AssertNil(t, "ProvisionInstance error", err)
+
+	samePlan := mysql.Plans[0].ID
+	_, err = client.UpdateInstance(&osbclient.UpdateInstanceRequest{
+		InstanceID:        testInstanceID,
+		AcceptsIncomplete: true,
+		ServiceID:         mysql.ID,
+		PlanID:            &samePlan,
+	})
+	testutil.AssertNil(t, "same plan error", err)
+
+	otherPlan := mysql.Plans[1].ID
+	_, err = client.UpdateInstance(&osbclient.UpdateInstanceRequest{
+		InstanceID:        testInstanceID,
+		AcceptsIncomplete: true,
+		ServiceID:         mysql.ID,
+		PlanID:            &otherPlan,
+	})
+	statusErr, ok := osbclient.IsHTTPError(err)
+	testutil.AssertTrue(t, "is HTTP error", ok)
+	testutil.AssertEqual(t, "status", http.StatusUnprocessableEntity, statusErr.StatusCode)
+}